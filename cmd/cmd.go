@@ -27,8 +27,15 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
 	deployimagev1alpha1 "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1"
 	golangv4 "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4"
+	autoupdatev1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/autoupdate/v1alpha"
+	compositionv1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/composition/v1alpha"
+	devcontainerv1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/devcontainer/v1alpha"
+	devenvv1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/devenv/v1alpha"
 	grafanav1alpha1 "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/grafana/v1alpha"
 	helmv1alpha1 "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha"
+	manifestlintv1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/manifestlint/v1alpha"
+	prometheusrulesv1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/prometheusrules/v1alpha"
+	supplychainv1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/supplychain/v1alpha"
 )
 
 func init() {
@@ -38,10 +45,11 @@ func init() {
 
 // Run bootstraps & runs the CLI
 func Run() {
-	// Bundle plugin which built the golang projects scaffold with base.go/v4 and kustomize/v2 plugins
+	// Bundle plugin which built the golang projects scaffold with base.go/v4, kustomize/v2 and
+	// devcontainer/v1-alpha plugins
 	gov4Bundle, _ := plugin.NewBundleWithOptions(plugin.WithName(golang.DefaultNameQualifier),
 		plugin.WithVersion(plugin.Version{Number: 4}),
-		plugin.WithPlugins(kustomizecommonv2.Plugin{}, golangv4.Plugin{}),
+		plugin.WithPlugins(kustomizecommonv2.Plugin{}, golangv4.Plugin{}, devcontainerv1alpha.Plugin{}),
 	)
 
 	fs := machinery.Filesystem{
@@ -55,6 +63,7 @@ func Run() {
 	c, err := cli.New(
 		cli.WithCommandName("kubebuilder"),
 		cli.WithVersion(versionString()),
+		cli.WithCliVersion(getKubebuilderVersion()),
 		cli.WithPlugins(
 			golangv4.Plugin{},
 			gov4Bundle,
@@ -62,11 +71,19 @@ func Run() {
 			&deployimagev1alpha1.Plugin{},
 			&grafanav1alpha1.Plugin{},
 			&helmv1alpha1.Plugin{},
+			&autoupdatev1alpha.Plugin{},
+			&devcontainerv1alpha.Plugin{},
+			&devenvv1alpha.Plugin{},
+			&prometheusrulesv1alpha.Plugin{},
+			&supplychainv1alpha.Plugin{},
+			&manifestlintv1alpha.Plugin{},
+			&compositionv1alpha.Plugin{},
 		),
 		cli.WithPlugins(externalPlugins...),
 		cli.WithDefaultPlugins(cfgv3.Version, gov4Bundle),
 		cli.WithDefaultProjectVersion(cfgv3.Version),
 		cli.WithCompletion(),
+		cli.WithExtraCommands(newDoctorCmd()),
 	)
 	if err != nil {
 		logrus.Fatal(err)
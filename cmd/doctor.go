@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/diagnostics"
+	golangv4scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds"
+)
+
+// newDoctorCmd returns the command which inspects the project and prints the issues found.
+// Nothing is sent anywhere, the findings are only printed.
+func newDoctorCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "doctor",
+		SilenceUsage: true,
+		Short:        "Inspect the project and print the issues found",
+		Long: `Inspect the project in the current directory and print the issues which may prevent kubebuilder
+from scaffolding it correctly, with suggestions to fix them:
+
+  - the PROJECT file can not be loaded or is incomplete;
+  - the versions of controller-runtime and of the Kubernetes modules in go.mod are not aligned;
+  - the scaffold markers are missing from cmd/main.go, where the new APIs are then wired by parsing it;
+  - the manifests under config/ drift from the APIs tracked in the PROJECT file.
+
+The inspection is local: nothing is sent anywhere. The command fails when an error is found.
+`,
+		Example: `  # Inspect the project
+  kubebuilder doctor
+
+  # Print the findings in JSON
+  kubebuilder doctor --output json
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			findings := diagnostics.Diagnose(afero.NewOsFs(), diagnostics.Options{
+				ControllerRuntimeVersion: golangv4scaffolds.ControllerRuntimeVersion,
+			})
+
+			output, _ := cmd.Flags().GetString("output")
+			switch output {
+			case "json":
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if findings == nil {
+					findings = []diagnostics.Finding{}
+				}
+				if err := encoder.Encode(findings); err != nil {
+					return fmt.Errorf("unable to encode the findings: %w", err)
+				}
+			case "", "text":
+				if len(findings) == 0 {
+					fmt.Println("No issues found")
+				}
+				for _, finding := range findings {
+					fmt.Println(finding)
+				}
+			default:
+				return fmt.Errorf("invalid output format %q, must be one of \"text\" or \"json\"", output)
+			}
+
+			if diagnostics.HasErrors(findings) {
+				return errors.New("errors were found in the project")
+			}
+			return nil
+		},
+	}
+}
@@ -45,16 +45,20 @@ type version struct {
 	GoArch             string `json:"goArch"`
 }
 
-// versionString returns the CLI version
-func versionString() string {
+// getKubebuilderVersion returns the version of the CLI, e.g. v4.6.0
+func getKubebuilderVersion() string {
 	if kubeBuilderVersion == unknown {
 		if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
 			kubeBuilderVersion = info.Main.Version
 		}
 	}
+	return kubeBuilderVersion
+}
 
+// versionString returns the CLI version
+func versionString() string {
 	return fmt.Sprintf("Version: %#v", version{
-		kubeBuilderVersion,
+		getKubebuilderVersion(),
 		kubernetesVendorVersion,
 		gitCommit,
 		buildDate,
@@ -30,13 +30,33 @@ import (
 
 var (
 	// Optional Environment Variables:
+	// - CLUSTER_PROVIDER: Provider of the cluster where the tests run: kind (default), k3d or existing.
+	//   The existing provider uses the cluster of the current KUBECONFIG context.
+	// - CLUSTER_NAME: Name of the kind or k3d cluster. Defaults to KIND_CLUSTER or "kind" for kind
+	//   and to "k3s-default" for k3d.
+	// - SKIP_CLUSTER_CREATION=true: Fails instead of creating the cluster when it is not running.
+	// - SKIP_CLUSTER_DELETION=true: Keeps the cluster created by the tests, e.g. to debug failures.
+	// - IMG: Name of the image which is built and loaded to the cluster.
+	clusterProvider     = os.Getenv("CLUSTER_PROVIDER")
+	clusterName         = os.Getenv("CLUSTER_NAME")
+	skipClusterCreation = os.Getenv("SKIP_CLUSTER_CREATION") == "true"
+	skipClusterDeletion = os.Getenv("SKIP_CLUSTER_DELETION") == "true"
+	// isClusterCreated will be set true when the cluster is created by the tests,
+	// so that a cluster which was already running is never deleted.
+	isClusterCreated = false
+	cluster          utils.ClusterProvider
+
 	// - CERT_MANAGER_INSTALL_SKIP=true: Skips CertManager installation during test setup.
 	// These variables are useful if CertManager is already installed, avoiding
 	// re-installation and conflicts.
 	skipCertManagerInstall = os.Getenv("CERT_MANAGER_INSTALL_SKIP") == "true"
 	// isCertManagerAlreadyInstalled will be set true when CertManager CRDs be found on the cluster
 	isCertManagerAlreadyInstalled = false
-	// isPrometheusOperatorAlreadyInstalled will be set true when prometheus CRDs be found on the cluster
+
+	// - PROMETHEUS_INSTALL_SKIP=true: Skips the Prometheus Operator installation during test setup,
+	// which is required to test the ServiceMonitor of the project.
+	prometheusInstall = os.Getenv("PROMETHEUS_INSTALL_SKIP") != "true"
+	// isPrometheusOperatorAlreadyInstalled will be set true when Prometheus CRDs be found on the cluster
 	isPrometheusOperatorAlreadyInstalled = false
 
 	// projectImage is the name of the image which will be build and loaded
@@ -46,8 +66,8 @@ var (
 
 // TestE2E runs the end-to-end (e2e) test suite for the project. These tests execute in an isolated,
 // temporary environment to validate project changes with the the purposed to be used in CI jobs.
-// The default setup requires Kind, builds/loads the Manager Docker image locally, and installs
-// CertManager.
+// The default setup uses Kind, creating the cluster if it is not running, builds/loads the Manager
+// Docker image locally, and installs CertManager.
 func TestE2E(t *testing.T) {
 	RegisterFailHandler(Fail)
 	_, _ = fmt.Fprintf(GinkgoWriter, "Starting project integration test suite\n")
@@ -56,32 +76,41 @@ func TestE2E(t *testing.T) {
 
 var _ = BeforeSuite(func() {
 	By("Ensure that Prometheus is enabled")
+	_ = utils.UncommentCode("config/default/kustomization.yaml", "#components:", "#")
 	_ = utils.UncommentCode("config/default/kustomization.yaml", "#- ../prometheus", "#")
 
+	if v, ok := os.LookupEnv("IMG"); ok && v != "" {
+		projectImage = v
+	}
+	if clusterName == "" {
+		clusterName = defaultClusterName(clusterProvider)
+	}
+
+	var err error
+	cluster, err = utils.NewClusterProvider(clusterProvider, clusterName)
+	Expect(err).NotTo(HaveOccurred(), "Failed to set up the cluster provider")
+
+	By(fmt.Sprintf("checking if the %s cluster is running", cluster.Name()))
+	if !cluster.Exists() {
+		Expect(skipClusterCreation).To(BeFalse(),
+			"The cluster is not running and SKIP_CLUSTER_CREATION is set")
+		By(fmt.Sprintf("creating the %s cluster", cluster.Name()))
+		Expect(cluster.Create()).To(Succeed(), "Failed to create the cluster")
+		isClusterCreated = true
+	}
+
 	By("building the manager(Operator) image")
 	cmd := exec.Command("make", "docker-build", fmt.Sprintf("IMG=%s", projectImage))
-	_, err := utils.Run(cmd)
+	_, err = utils.Run(cmd)
 	ExpectWithOffset(1, err).NotTo(HaveOccurred(), "Failed to build the manager(Operator) image")
 
-	// TODO(user): If you want to change the e2e test vendor from Kind, ensure the image is
-	// built and available before running the tests. Also, remove the following block.
-	By("loading the manager(Operator) image on Kind")
-	err = utils.LoadImageToKindClusterWithName(projectImage)
-	ExpectWithOffset(1, err).NotTo(HaveOccurred(), "Failed to load the manager(Operator) image into Kind")
+	// TODO(user): With the existing provider the image is not loaded, so ensure that it is
+	// pushed to a registry the cluster can pull from before running the tests.
+	By(fmt.Sprintf("loading the manager(Operator) image on the %s cluster", cluster.Name()))
+	err = cluster.LoadImage(projectImage)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred(), "Failed to load the manager(Operator) image into the cluster")
 
 	// The tests-e2e are intended to run on a temporary cluster that is created and destroyed for testing.
-	// To prevent errors when tests run in environments with Prometheus already installed,
-	// we check for it's presence before execution.
-	// Setup Prometheus before the suite if not already installed
-	By("checking if prometheus is installed already")
-	isPrometheusOperatorAlreadyInstalled = utils.IsPrometheusCRDsInstalled()
-	if !isPrometheusOperatorAlreadyInstalled {
-		_, _ = fmt.Fprintf(GinkgoWriter, "Installing Prometheus Operator...\n")
-		Expect(utils.InstallPrometheusOperator()).To(Succeed(), "Failed to install Prometheus Operator")
-	} else {
-		_, _ = fmt.Fprintf(GinkgoWriter, "WARNING: Prometheus Operator is already installed. Skipping installation...\n")
-	}
-
 	// To prevent errors when tests run in environments with CertManager already installed,
 	// we check for its presence before execution.
 	// Setup CertManager before the suite if not skipped and if not already installed
@@ -95,11 +124,23 @@ var _ = BeforeSuite(func() {
 			_, _ = fmt.Fprintf(GinkgoWriter, "WARNING: CertManager is already installed. Skipping installation...\n")
 		}
 	}
+
+	// Setup the Prometheus Operator before the suite if requested and if not already installed
+	if prometheusInstall {
+		By("checking if prometheus is installed already")
+		isPrometheusOperatorAlreadyInstalled = utils.IsPrometheusCRDsInstalled()
+		if !isPrometheusOperatorAlreadyInstalled {
+			_, _ = fmt.Fprintf(GinkgoWriter, "Installing Prometheus Operator...\n")
+			Expect(utils.InstallPrometheusOperator()).To(Succeed(), "Failed to install Prometheus Operator")
+		} else {
+			_, _ = fmt.Fprintf(GinkgoWriter, "WARNING: Prometheus Operator is already installed. Skipping installation...\n")
+		}
+	}
 })
 
 var _ = AfterSuite(func() {
-	// Teardown Prometheus after the suite if it was not already installed
-	if !isPrometheusOperatorAlreadyInstalled {
+	// Teardown the Prometheus Operator after the suite if it was installed by the tests
+	if prometheusInstall && !isPrometheusOperatorAlreadyInstalled {
 		_, _ = fmt.Fprintf(GinkgoWriter, "Uninstalling Prometheus Operator...\n")
 		utils.UninstallPrometheusOperator()
 	}
@@ -109,4 +150,25 @@ var _ = AfterSuite(func() {
 		_, _ = fmt.Fprintf(GinkgoWriter, "Uninstalling CertManager...\n")
 		utils.UninstallCertManager()
 	}
+
+	// Delete the cluster only if it was created by the tests and the deletion is not skipped
+	if cluster != nil && isClusterCreated && !skipClusterDeletion {
+		By(fmt.Sprintf("deleting the %s cluster", cluster.Name()))
+		if err := cluster.Delete(); err != nil {
+			_, _ = fmt.Fprintf(GinkgoWriter, "WARNING: Failed to delete the cluster: %v\n", err)
+		}
+	}
 })
+
+// defaultClusterName returns the name of the cluster used when CLUSTER_NAME is not set.
+func defaultClusterName(provider string) string {
+	switch provider {
+	case utils.K3dProvider:
+		return "k3s-default"
+	default:
+		if v, ok := os.LookupEnv("KIND_CLUSTER"); ok && v != "" {
+			return v
+		}
+		return "kind"
+	}
+}
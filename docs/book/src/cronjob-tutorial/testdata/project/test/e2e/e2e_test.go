@@ -17,11 +17,8 @@ limitations under the License.
 package e2e
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"os/exec"
-	"path/filepath"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -190,9 +187,13 @@ var _ = Describe("Manager", Ordered, func() {
 			Expect(err).NotTo(HaveOccurred(), "ServiceMonitor should exist")
 
 			By("getting the service account token")
-			token, err := serviceAccountToken()
-			Expect(err).NotTo(HaveOccurred())
-			Expect(token).NotTo(BeEmpty())
+			var token string
+			verifyTokenCreation := func(g Gomega) {
+				var err error
+				token, err = utils.ServiceAccountToken(namespace, serviceAccountName)
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			Eventually(verifyTokenCreation).Should(Succeed())
 
 			By("waiting for the metrics endpoint to be ready")
 			verifyMetricsEndpointReady := func(g Gomega) {
@@ -214,33 +215,8 @@ var _ = Describe("Manager", Ordered, func() {
 			Eventually(verifyMetricsServerStarted).Should(Succeed())
 
 			By("creating the curl-metrics pod to access the metrics endpoint")
-			cmd = exec.Command("kubectl", "run", "curl-metrics", "--restart=Never",
-				"--namespace", namespace,
-				"--image=curlimages/curl:latest",
-				"--overrides",
-				fmt.Sprintf(`{
-					"spec": {
-						"containers": [{
-							"name": "curl",
-							"image": "curlimages/curl:latest",
-							"command": ["/bin/sh", "-c"],
-							"args": ["curl -v -k -H 'Authorization: Bearer %s' https://%s.%s.svc.cluster.local:8443/metrics"],
-							"securityContext": {
-								"allowPrivilegeEscalation": false,
-								"capabilities": {
-									"drop": ["ALL"]
-								},
-								"runAsNonRoot": true,
-								"runAsUser": 1000,
-								"seccompProfile": {
-									"type": "RuntimeDefault"
-								}
-							}
-						}],
-						"serviceAccount": "%s"
-					}
-				}`, token, metricsServiceName, namespace, serviceAccountName))
-			_, err = utils.Run(cmd)
+			err = utils.CreateCurlMetricsPod("curl-metrics", namespace, serviceAccountName, token,
+				fmt.Sprintf("https://%s.%s.svc.cluster.local:8443/metrics", metricsServiceName, namespace))
 			Expect(err).NotTo(HaveOccurred(), "Failed to create curl-metrics pod")
 
 			By("waiting for the curl-metrics pod to complete.")
@@ -264,23 +240,24 @@ var _ = Describe("Manager", Ordered, func() {
 		It("should provisioned cert-manager", func() {
 			By("validating that cert-manager has the certificate Secret")
 			verifyCertManager := func(g Gomega) {
-				cmd := exec.Command("kubectl", "get", "secrets", "webhook-server-cert", "-n", namespace)
-				_, err := utils.Run(cmd)
-				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(utils.VerifyCertificateSecret("webhook-server-cert", namespace)).To(Succeed())
 			}
 			Eventually(verifyCertManager).Should(Succeed())
 		})
 
+		It("should serve the webhooks", func() {
+			By("validating that the webhook service has ready endpoints")
+			verifyWebhookServiceReady := func(g Gomega) {
+				g.Expect(utils.VerifyServiceReady("project-webhook-service", namespace)).To(Succeed())
+			}
+			Eventually(verifyWebhookServiceReady).Should(Succeed())
+		})
+
 		It("should have CA injection for mutating webhooks", func() {
 			By("checking CA injection for mutating webhooks")
 			verifyCAInjection := func(g Gomega) {
-				cmd := exec.Command("kubectl", "get",
-					"mutatingwebhookconfigurations.admissionregistration.k8s.io",
-					"project-mutating-webhook-configuration",
-					"-o", "go-template={{ range .webhooks }}{{ .clientConfig.caBundle }}{{ end }}")
-				mwhOutput, err := utils.Run(cmd)
-				g.Expect(err).NotTo(HaveOccurred())
-				g.Expect(len(mwhOutput)).To(BeNumerically(">", 10))
+				g.Expect(utils.VerifyCAInjection("mutatingwebhookconfigurations.admissionregistration.k8s.io",
+					"project-mutating-webhook-configuration", utils.WebhookCABundlePath)).To(Succeed())
 			}
 			Eventually(verifyCAInjection).Should(Succeed())
 		})
@@ -288,19 +265,34 @@ var _ = Describe("Manager", Ordered, func() {
 		It("should have CA injection for validating webhooks", func() {
 			By("checking CA injection for validating webhooks")
 			verifyCAInjection := func(g Gomega) {
-				cmd := exec.Command("kubectl", "get",
-					"validatingwebhookconfigurations.admissionregistration.k8s.io",
-					"project-validating-webhook-configuration",
-					"-o", "go-template={{ range .webhooks }}{{ .clientConfig.caBundle }}{{ end }}")
-				vwhOutput, err := utils.Run(cmd)
-				g.Expect(err).NotTo(HaveOccurred())
-				g.Expect(len(vwhOutput)).To(BeNumerically(">", 10))
+				g.Expect(utils.VerifyCAInjection("validatingwebhookconfigurations.admissionregistration.k8s.io",
+					"project-validating-webhook-configuration", utils.WebhookCABundlePath)).To(Succeed())
 			}
 			Eventually(verifyCAInjection).Should(Succeed())
 		})
 
+		// The webhooks of the APIs are exercised with their samples by webhooks_test.go
+		webhookChecks()
+
 		// +kubebuilder:scaffold:e2e-webhooks-checks
 
+		It("should accept the samples", func() {
+			By("applying the samples of config/samples with a server-side dry run")
+			samples, err := sampleFiles()
+			Expect(err).NotTo(HaveOccurred(), "Failed to list the samples")
+			for _, sample := range samples {
+				// The webhooks may not be serving yet right after the deployment
+				Eventually(func(g Gomega) {
+					verifySample(g, sample)
+				}).Should(Succeed())
+			}
+
+			// TODO: Assert the fields set by the defaulting of your APIs in the samples, i.e.:
+			// Eventually(func(g Gomega) {
+			//    g.Expect(verifySample(g, "<path of the sample>")).To(ContainSubstring("<defaulted field>"))
+			// }).Should(Succeed())
+		})
+
 		// TODO: Customize the e2e test suite with scenarios specific to your project.
 		// Consider applying sample/CR(s) and check their status and/or verifying
 		// the reconciliation by using the metrics, i.e.:
@@ -312,61 +304,10 @@ var _ = Describe("Manager", Ordered, func() {
 	})
 })
 
-// serviceAccountToken returns a token for the specified service account in the given namespace.
-// It uses the Kubernetes TokenRequest API to generate a token by directly sending a request
-// and parsing the resulting token from the API response.
-func serviceAccountToken() (string, error) {
-	const tokenRequestRawString = `{
-		"apiVersion": "authentication.k8s.io/v1",
-		"kind": "TokenRequest"
-	}`
-
-	// Temporary file to store the token request
-	secretName := fmt.Sprintf("%s-token-request", serviceAccountName)
-	tokenRequestFile := filepath.Join("/tmp", secretName)
-	err := os.WriteFile(tokenRequestFile, []byte(tokenRequestRawString), os.FileMode(0o644))
-	if err != nil {
-		return "", err
-	}
-
-	var out string
-	verifyTokenCreation := func(g Gomega) {
-		// Execute kubectl command to create the token
-		cmd := exec.Command("kubectl", "create", "--raw", fmt.Sprintf(
-			"/api/v1/namespaces/%s/serviceaccounts/%s/token",
-			namespace,
-			serviceAccountName,
-		), "-f", tokenRequestFile)
-
-		output, err := cmd.CombinedOutput()
-		g.Expect(err).NotTo(HaveOccurred())
-
-		// Parse the JSON output to extract the token
-		var token tokenRequest
-		err = json.Unmarshal(output, &token)
-		g.Expect(err).NotTo(HaveOccurred())
-
-		out = token.Status.Token
-	}
-	Eventually(verifyTokenCreation).Should(Succeed())
-
-	return out, err
-}
-
 // getMetricsOutput retrieves and returns the logs from the curl pod used to access the metrics endpoint.
 func getMetricsOutput() string {
 	By("getting the curl-metrics logs")
-	cmd := exec.Command("kubectl", "logs", "curl-metrics", "-n", namespace)
-	metricsOutput, err := utils.Run(cmd)
-	Expect(err).NotTo(HaveOccurred(), "Failed to retrieve logs from curl pod")
-	Expect(metricsOutput).To(ContainSubstring("< HTTP/1.1 200 OK"))
+	metricsOutput, err := utils.MetricsOutput("curl-metrics", namespace)
+	Expect(err).NotTo(HaveOccurred(), "Failed to retrieve the metrics from curl pod")
 	return metricsOutput
 }
-
-// tokenRequest is a simplified representation of the Kubernetes TokenRequest API response,
-// containing only the token field that we need to extract.
-type tokenRequest struct {
-	Status struct {
-		Token string `json:"token"`
-	} `json:"status"`
-}
@@ -1,7 +1,12 @@
 #!/bin/bash
 set -x
 
-curl -Lo ./kind https://kind.sigs.k8s.io/dl/latest/kind-linux-amd64
+# The versions are pinned by the devcontainer plugin and can be updated with:
+# kubebuilder edit --plugins=devcontainer/v1-alpha
+KIND_VERSION=v0.27.0
+KUBECTL_VERSION=v1.32.2
+
+curl -Lo ./kind "https://kind.sigs.k8s.io/dl/${KIND_VERSION}/kind-linux-amd64"
 chmod +x ./kind
 mv ./kind /usr/local/bin/kind
 
@@ -9,7 +14,6 @@ curl -L -o kubebuilder https://go.kubebuilder.io/dl/latest/linux/amd64
 chmod +x kubebuilder
 mv kubebuilder /usr/local/bin/
 
-KUBECTL_VERSION=$(curl -L -s https://dl.k8s.io/release/stable.txt)
 curl -LO "https://dl.k8s.io/release/$KUBECTL_VERSION/bin/linux/amd64/kubectl"
 chmod +x kubectl
 mv kubectl /usr/local/bin/kubectl
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"flag"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Options", func() {
+	var (
+		opts *Options
+		fs   *flag.FlagSet
+	)
+
+	BeforeEach(func() {
+		opts = New()
+		fs = flag.NewFlagSet("manager", flag.ContinueOnError)
+		opts.BindFlags(fs)
+	})
+
+	It("should keep the default values when no flag is set", func() {
+		Expect(fs.Parse(nil)).To(Succeed())
+		Expect(opts).To(Equal(New()))
+		Expect(opts.Validate()).To(Succeed())
+	})
+
+	It("should parse the flags", func() {
+		Expect(fs.Parse([]string{
+			"--metrics-bind-address=:8443",
+			"--metrics-secure=false",
+			"--webhook-port=9444",
+			"--health-probe-bind-address=:8082",
+			"--leader-elect",
+			"--leader-election-id=example",
+		})).To(Succeed())
+
+		Expect(opts.MetricsBindAddress).To(Equal(":8443"))
+		Expect(opts.SecureMetrics).To(BeFalse())
+		Expect(opts.WebhookPort).To(Equal(9444))
+		Expect(opts.ProbeBindAddress).To(Equal(":8082"))
+		Expect(opts.LeaderElection).To(BeTrue())
+		Expect(opts.LeaderElectionID).To(Equal("example"))
+		Expect(opts.Validate()).To(Succeed())
+	})
+
+	It("should bind the flags of the logger", func() {
+		Expect(fs.Parse([]string{"--zap-devel=false"})).To(Succeed())
+		Expect(opts.Zap.Development).To(BeFalse())
+	})
+
+	It("should fail to validate an invalid webhook port", func() {
+		Expect(fs.Parse([]string{"--webhook-port=0"})).To(Succeed())
+		Expect(opts.Validate()).NotTo(Succeed())
+	})
+
+	It("should fail to validate the leader election without ID", func() {
+		Expect(fs.Parse([]string{"--leader-elect", "--leader-election-id="})).To(Succeed())
+		Expect(opts.Validate()).NotTo(Succeed())
+	})
+})
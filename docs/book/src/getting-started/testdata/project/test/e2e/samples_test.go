@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/gomega"
+
+	"example.com/memcached/test/utils"
+)
+
+// sampleFiles returns the manifests of the custom resources under config/samples.
+func sampleFiles() ([]string, error) {
+	projectDir, err := utils.GetProjectDir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(projectDir, "config", "samples", "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]string, 0, len(files))
+	for _, file := range files {
+		if filepath.Base(file) != "kustomization.yaml" {
+			samples = append(samples, file)
+		}
+	}
+	return samples, nil
+}
+
+// verifySample applies the sample with a server-side dry run. The sample goes through the schema
+// validation and the defaulting of its CRD and through the defaulting and validation webhooks of the
+// project, but it is not persisted. It returns the sample in YAML as it would be stored, once defaulted.
+func verifySample(g Gomega, sample string) string {
+	cmd := exec.Command("kubectl", "apply", "--dry-run=server", "-f", sample, "-n", namespace, "-o", "yaml")
+	output, err := utils.Run(cmd)
+	g.Expect(err).NotTo(HaveOccurred(), "The sample %s was rejected", filepath.Base(sample))
+	return output
+}
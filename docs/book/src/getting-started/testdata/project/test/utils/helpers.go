@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	// WebhookCABundlePath is the JSONPath of the CA bundles of a webhook configuration
+	WebhookCABundlePath = "{.webhooks[*].clientConfig.caBundle}"
+	// ConversionCABundlePath is the JSONPath of the CA bundle of the conversion webhook of a CRD
+	ConversionCABundlePath = "{.spec.conversion.webhook.clientConfig.caBundle}"
+
+	curlImage = "curlimages/curl:latest"
+
+	tokenRequestBody = `{"apiVersion": "authentication.k8s.io/v1", "kind": "TokenRequest"}`
+)
+
+// WaitForCertManager waits for the webhook of cert-manager to be available, which can take time
+// if cert-manager was re-installed after uninstalling it from the cluster.
+func WaitForCertManager(timeout time.Duration) error {
+	cmd := exec.Command("kubectl", "wait", "deployment.apps/cert-manager-webhook",
+		"--for", "condition=Available",
+		"--namespace", "cert-manager",
+		"--timeout", timeout.String(),
+	)
+	_, err := Run(cmd)
+	return err
+}
+
+// VerifyCertificateSecret returns an error if the Secret of a certificate issued by cert-manager does not exist
+func VerifyCertificateSecret(name, namespace string) error {
+	cmd := exec.Command("kubectl", "get", "secrets", name, "-n", namespace)
+	_, err := Run(cmd)
+	return err
+}
+
+// VerifyCAInjection returns an error if cert-manager has not injected the CA bundle into the object,
+// e.g. into the webhooks of a validatingwebhookconfigurations.admissionregistration.k8s.io object
+// with WebhookCABundlePath, or into the conversion webhook of a CRD with ConversionCABundlePath.
+func VerifyCAInjection(resource, name, caBundlePath string) error {
+	cmd := exec.Command("kubectl", "get", resource, name, "-o", "jsonpath="+caBundlePath)
+	output, err := Run(cmd)
+	if err != nil {
+		return err
+	}
+	if !hasCABundle(output) {
+		return fmt.Errorf("the CA bundle was not injected into %s %s", resource, name)
+	}
+	return nil
+}
+
+// VerifyServiceReady returns an error if the Service has no ready endpoint, e.g. when the webhook
+// server of the manager is not serving yet.
+func VerifyServiceReady(name, namespace string) error {
+	cmd := exec.Command("kubectl", "get", "endpoints", name, "-n", namespace,
+		"-o", "jsonpath={.subsets[*].addresses[*].ip}")
+	output, err := Run(cmd)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(output) == "" {
+		return fmt.Errorf("the service %s has no ready endpoint", name)
+	}
+	return nil
+}
+
+// ServiceAccountToken returns a token of the service account, created with the TokenRequest API.
+func ServiceAccountToken(namespace, serviceAccountName string) (string, error) {
+	cmd := exec.Command("kubectl", "create", "--raw", fmt.Sprintf(
+		"/api/v1/namespaces/%s/serviceaccounts/%s/token", namespace, serviceAccountName,
+	), "-f", "-")
+	cmd.Stdin = strings.NewReader(tokenRequestBody)
+
+	// The standard error is not part of the response of the API
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to create a token for the service account %s: %w", serviceAccountName, err)
+	}
+	return parseTokenRequest(output)
+}
+
+// CreateCurlMetricsPod creates a pod running as the service account which requests the metrics
+// endpoint at the URL with the token.
+func CreateCurlMetricsPod(name, namespace, serviceAccountName, token, url string) error {
+	overrides, err := curlPodOverrides(serviceAccountName, token, url)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("kubectl", "run", name, "--restart=Never",
+		"--namespace", namespace,
+		"--image="+curlImage,
+		"--overrides", overrides)
+	_, err = Run(cmd)
+	return err
+}
+
+// MetricsOutput returns the output of the pod created with CreateCurlMetricsPod, and an error
+// if the metrics endpoint did not respond successfully.
+func MetricsOutput(name, namespace string) (string, error) {
+	cmd := exec.Command("kubectl", "logs", name, "-n", namespace)
+	output, err := Run(cmd)
+	if err != nil {
+		return "", err
+	}
+	if !strings.Contains(output, "< HTTP/1.1 200 OK") {
+		return output, errors.New("the metrics endpoint did not respond with 200 OK")
+	}
+	return output, nil
+}
+
+// tokenRequest is a simplified representation of the Kubernetes TokenRequest API response,
+// containing only the token field that we need to extract.
+type tokenRequest struct {
+	Status struct {
+		Token string `json:"token"`
+	} `json:"status"`
+}
+
+// parseTokenRequest returns the token of the response of the TokenRequest API
+func parseTokenRequest(output []byte) (string, error) {
+	var token tokenRequest
+	if err := json.Unmarshal(output, &token); err != nil {
+		return "", fmt.Errorf("failed to parse the TokenRequest: %w", err)
+	}
+	if token.Status.Token == "" {
+		return "", errors.New("the TokenRequest has no token")
+	}
+	return token.Status.Token, nil
+}
+
+// curlPodOverrides returns the overrides of the spec of the curl pod, which comply with the
+// restricted pod security standard.
+func curlPodOverrides(serviceAccountName, token, url string) (string, error) {
+	container := map[string]any{
+		"name":    "curl",
+		"image":   curlImage,
+		"command": []string{"/bin/sh", "-c"},
+		"args":    []string{fmt.Sprintf("curl -v -k -H 'Authorization: Bearer %s' %s", token, url)},
+		"securityContext": map[string]any{
+			"allowPrivilegeEscalation": false,
+			"capabilities": map[string]any{
+				"drop": []string{"ALL"},
+			},
+			"runAsNonRoot": true,
+			"runAsUser":    1000,
+			"seccompProfile": map[string]any{
+				"type": "RuntimeDefault",
+			},
+		},
+	}
+	overrides := map[string]any{
+		"spec": map[string]any{
+			"containers":     []map[string]any{container},
+			"serviceAccount": serviceAccountName,
+		},
+	}
+
+	content, err := json.Marshal(overrides)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the overrides of the curl pod: %w", err)
+	}
+	return string(content), nil
+}
+
+// hasCABundle returns true if the output contains CA bundles and all of them are PEM certificates
+func hasCABundle(output string) bool {
+	bundles := strings.Fields(output)
+	if len(bundles) == 0 {
+		return false
+	}
+	for _, bundle := range bundles {
+		decoded, err := base64.StdEncoding.DecodeString(bundle)
+		if err != nil || !strings.Contains(string(decoded), "-----BEGIN CERTIFICATE-----") {
+			return false
+		}
+	}
+	return true
+}
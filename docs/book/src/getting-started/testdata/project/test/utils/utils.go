@@ -23,6 +23,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2" //nolint:golint,revive
 )
@@ -120,16 +121,7 @@ func InstallCertManager() error {
 	if _, err := Run(cmd); err != nil {
 		return err
 	}
-	// Wait for cert-manager-webhook to be ready, which can take time if cert-manager
-	// was re-installed after uninstalling on a cluster.
-	cmd = exec.Command("kubectl", "wait", "deployment.apps/cert-manager-webhook",
-		"--for", "condition=Available",
-		"--namespace", "cert-manager",
-		"--timeout", "5m",
-	)
-
-	_, err := Run(cmd)
-	return err
+	return WaitForCertManager(5 * time.Minute)
 }
 
 // IsCertManagerCRDsInstalled checks if any Cert Manager CRDs are installed
@@ -177,6 +169,123 @@ func LoadImageToKindClusterWithName(name string) error {
 	return err
 }
 
+const (
+	// KindProvider runs the tests on a kind cluster.
+	KindProvider = "kind"
+	// K3dProvider runs the tests on a k3d cluster.
+	K3dProvider = "k3d"
+	// ExistingProvider runs the tests on the cluster of the current KUBECONFIG context. The cluster is
+	// neither created nor deleted, and the image must be pushed to a registry the cluster can pull from.
+	ExistingProvider = "existing"
+)
+
+// ClusterProvider manages the lifecycle of the cluster used by the e2e tests.
+type ClusterProvider interface {
+	// Name returns the name of the provider.
+	Name() string
+	// Exists returns true if the cluster is already running.
+	Exists() bool
+	// Create creates the cluster.
+	Create() error
+	// Delete deletes the cluster.
+	Delete() error
+	// LoadImage makes the given local image available to the cluster.
+	LoadImage(image string) error
+}
+
+// NewClusterProvider returns the ClusterProvider for the given provider and cluster names.
+// If the provider name is empty, kind is used.
+func NewClusterProvider(provider, cluster string) (ClusterProvider, error) {
+	switch provider {
+	case "", KindProvider:
+		return &kindCluster{name: cluster}, nil
+	case K3dProvider:
+		return &k3dCluster{name: cluster}, nil
+	case ExistingProvider:
+		return &existingCluster{}, nil
+	default:
+		return nil, fmt.Errorf("unknown cluster provider %q, supported providers are: %s",
+			provider, strings.Join([]string{KindProvider, K3dProvider, ExistingProvider}, ", "))
+	}
+}
+
+type kindCluster struct {
+	name string
+}
+
+func (c *kindCluster) Name() string { return KindProvider }
+
+func (c *kindCluster) Exists() bool {
+	output, err := Run(exec.Command("kind", "get", "clusters"))
+	if err != nil {
+		return false
+	}
+	for _, line := range GetNonEmptyLines(output) {
+		if line == c.name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *kindCluster) Create() error {
+	_, err := Run(exec.Command("kind", "create", "cluster", "--name", c.name))
+	return err
+}
+
+func (c *kindCluster) Delete() error {
+	_, err := Run(exec.Command("kind", "delete", "cluster", "--name", c.name))
+	return err
+}
+
+func (c *kindCluster) LoadImage(image string) error {
+	_, err := Run(exec.Command("kind", "load", "docker-image", image, "--name", c.name))
+	return err
+}
+
+type k3dCluster struct {
+	name string
+}
+
+func (c *k3dCluster) Name() string { return K3dProvider }
+
+func (c *k3dCluster) Exists() bool {
+	_, err := Run(exec.Command("k3d", "cluster", "get", c.name))
+	return err == nil
+}
+
+func (c *k3dCluster) Create() error {
+	_, err := Run(exec.Command("k3d", "cluster", "create", c.name, "--wait"))
+	return err
+}
+
+func (c *k3dCluster) Delete() error {
+	_, err := Run(exec.Command("k3d", "cluster", "delete", c.name))
+	return err
+}
+
+func (c *k3dCluster) LoadImage(image string) error {
+	_, err := Run(exec.Command("k3d", "image", "import", image, "--cluster", c.name))
+	return err
+}
+
+type existingCluster struct{}
+
+func (c *existingCluster) Name() string { return ExistingProvider }
+
+func (c *existingCluster) Exists() bool {
+	_, err := Run(exec.Command("kubectl", "cluster-info"))
+	return err == nil
+}
+
+func (c *existingCluster) Create() error {
+	return fmt.Errorf("the %s provider does not create clusters, check the KUBECONFIG context", ExistingProvider)
+}
+
+func (c *existingCluster) Delete() error { return nil }
+
+func (c *existingCluster) LoadImage(_ string) error { return nil }
+
 // GetNonEmptyLines converts given command output string into individual objects
 // according to line breakers, and ignores the empty elements in it.
 func GetNonEmptyLines(output string) []string {
@@ -16,37 +16,21 @@ limitations under the License.
 
 package cronjob
 
-const isPrometheusInstalledVar = `
-// isPrometheusOperatorAlreadyInstalled will be set true when prometheus CRDs be found on the cluster
-isPrometheusOperatorAlreadyInstalled = false
-`
+// The tutorial always tests its ServiceMonitor, so the Prometheus Operator is installed unless skipped
+const prometheusInstallVar = `// - PROMETHEUS_INSTALL=true: Installs the Prometheus Operator during test setup, which is
+	// required to test the ServiceMonitor of the project.
+	prometheusInstall = os.Getenv("PROMETHEUS_INSTALL") == "true"`
+
+const prometheusInstallSkipVar = `// - PROMETHEUS_INSTALL_SKIP=true: Skips the Prometheus Operator installation during test setup,
+	// which is required to test the ServiceMonitor of the project.
+	prometheusInstall = os.Getenv("PROMETHEUS_INSTALL_SKIP") != "true"`
 
 const beforeSuitePrometheus = `
 By("Ensure that Prometheus is enabled")
+	_ = utils.UncommentCode("config/default/kustomization.yaml", "#components:", "#")
 	_ = utils.UncommentCode("config/default/kustomization.yaml", "#- ../prometheus", "#")
 `
 
-const afterSuitePrometheus = `
-// Teardown Prometheus after the suite if it was not already installed
-if !isPrometheusOperatorAlreadyInstalled {
-	_, _ = fmt.Fprintf(GinkgoWriter, "Uninstalling Prometheus Operator...\n")
-	utils.UninstallPrometheusOperator()
-}
-`
-
-const checkPrometheusInstalled = `
-// To prevent errors when tests run in environments with Prometheus already installed,
-// we check for it's presence before execution.
-// Setup Prometheus before the suite if not already installed
-By("checking if prometheus is installed already")
-isPrometheusOperatorAlreadyInstalled = utils.IsPrometheusCRDsInstalled()
-if !isPrometheusOperatorAlreadyInstalled {
-	_, _ = fmt.Fprintf(GinkgoWriter, "Installing Prometheus Operator...\n")
-	Expect(utils.InstallPrometheusOperator()).To(Succeed(), "Failed to install Prometheus Operator")
-} else {
-	_, _ = fmt.Fprintf(GinkgoWriter, "WARNING: Prometheus Operator is already installed. Skipping installation...\n")
-}
-`
 const serviceMonitorE2e = `
 
 By("validating that the ServiceMonitor for Prometheus is applied in the namespace")
@@ -501,6 +501,8 @@ Then, we set up the webhook with the manager.
 	err = pluginutil.ReplaceInFile(
 		filepath.Join(sp.ctx.Dir, "internal/webhook/v1/cronjob_webhook.go"),
 		`// TODO(user): fill in your validation logic upon object creation.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.replicas above 10 may exhaust the cluster quota"}, nil
 
 	return nil, nil`,
 		`return nil, validateCronJob(cronjob)`)
@@ -509,6 +511,8 @@ Then, we set up the webhook with the manager.
 	err = pluginutil.ReplaceInFile(
 		filepath.Join(sp.ctx.Dir, "internal/webhook/v1/cronjob_webhook.go"),
 		`// TODO(user): fill in your validation logic upon object update.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.foo is deprecated and will be removed in a future version"}, nil
 
 	return nil, nil`,
 		`return nil, validateCronJob(cronjob)`)
@@ -607,16 +611,11 @@ var _ = AfterSuite(func() {
 }
 
 func (sp *Sample) updateKustomization() {
-	var err error
-	err = pluginutil.UncommentCode(
-		filepath.Join(sp.ctx.Dir, "config/default/kustomization.yaml"),
-		`#- ../certmanager`, `#`)
-	hackutils.CheckError("fixing default/kustomization", err)
-
-	err = pluginutil.UncommentCode(
-		filepath.Join(sp.ctx.Dir, "config/default/kustomization.yaml"),
-		`#- ../prometheus`, `#`)
-	hackutils.CheckError("fixing default/kustomization", err)
+	err := sp.ctx.Edit(
+		"--plugins", "kustomize/v2",
+		"--enable-components", "certmanager,prometheus",
+	)
+	hackutils.CheckError("enabling the certmanager and prometheus components", err)
 
 	err = pluginutil.UncommentCode(
 		filepath.Join(sp.ctx.Dir, "config/default/kustomization.yaml"),
@@ -634,9 +633,9 @@ func (sp *Sample) updateKustomization() {
 	hackutils.CheckError("enabling monitor tls patch", err)
 
 	err = pluginutil.UncommentCode(
-		filepath.Join(sp.ctx.Dir, "config/default/kustomization.yaml"),
-		certManagerForMetricsAndWebhooks, `#`)
-	hackutils.CheckError("fixing default/kustomization", err)
+		filepath.Join(sp.ctx.Dir, "config/certmanager/kustomization.yaml"),
+		certManagerForMetrics, `#`)
+	hackutils.CheckError("enabling the certificates of the metrics in certmanager/kustomization", err)
 }
 
 func (sp *Sample) updateExample() {
@@ -665,20 +664,12 @@ func (sp *Sample) updateE2E() {
 	cronjobE2ETest := filepath.Join(sp.ctx.Dir, "test", "e2e", "e2e_test.go")
 	var err error
 
-	err = pluginutil.InsertCode(cronjobE2ESuite, `isCertManagerAlreadyInstalled = false`, isPrometheusInstalledVar)
+	err = pluginutil.ReplaceInFile(cronjobE2ESuite, prometheusInstallVar, prometheusInstallSkipVar)
 	hackutils.CheckError("fixing test/e2e/e2e_suite_test.go", err)
 
 	err = pluginutil.InsertCode(cronjobE2ESuite, `var _ = BeforeSuite(func() {`, beforeSuitePrometheus)
 	hackutils.CheckError("fixing test/e2e/e2e_suite_test.go", err)
 
-	err = pluginutil.InsertCode(cronjobE2ESuite,
-		`// The tests-e2e are intended to run on a temporary cluster that is created and destroyed for testing.`,
-		checkPrometheusInstalled)
-	hackutils.CheckError("fixing test/e2e/e2e_suite_test.go", err)
-
-	err = pluginutil.InsertCode(cronjobE2ESuite, `var _ = AfterSuite(func() {`, afterSuitePrometheus)
-	hackutils.CheckError("fixing test/e2e/e2e_suite_test.go", err)
-
 	err = pluginutil.InsertCode(cronjobE2ETest, `Expect(err).NotTo(HaveOccurred(), "Metrics service should exist")`, serviceMonitorE2e)
 	hackutils.CheckError("fixing test/e2e/e2e_test.go", err)
 }
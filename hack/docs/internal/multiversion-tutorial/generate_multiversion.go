@@ -103,7 +103,6 @@ func (sp *Sample) UpdateTutorial() {
 	sp.updateConversionFiles()
 	sp.updateSampleV2()
 	sp.updateMain()
-	sp.updateDefaultKustomize()
 }
 
 func (sp *Sample) updateCronjobV1DueForce() {
@@ -281,20 +280,6 @@ interfaces, a conversion webhook will be registered.
 	hackutils.CheckError("fix cronjob v1 tests after each", err)
 }
 
-func (sp *Sample) updateDefaultKustomize() {
-	// Enable CA for Conversion Webhook
-	err := pluginutil.UncommentCode(
-		filepath.Join(sp.ctx.Dir, "config/default/kustomization.yaml"),
-		caInjectionNamespace, `#`)
-	hackutils.CheckError("fixing default/kustomization", err)
-
-	// Enable CA for Conversion Webhook
-	err = pluginutil.UncommentCode(
-		filepath.Join(sp.ctx.Dir, "config/default/kustomization.yaml"),
-		caInjectionCert, `#`)
-	hackutils.CheckError("fixing default/kustomization", err)
-}
-
 func (sp *Sample) updateSampleV2() {
 	path := filepath.Join(sp.ctx.Dir, "config/samples/batch_v2_cronjob.yaml")
 	oldText := `# TODO(user): Add fields here`
@@ -493,6 +478,8 @@ func (sp *Sample) updateWebhookV2() {
 	err = pluginutil.ReplaceInFile(
 		filepath.Join(sp.ctx.Dir, path),
 		`// TODO(user): fill in your validation logic upon object creation.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.replicas above 10 may exhaust the cluster quota"}, nil
 
 	return nil, nil`,
 		`return nil, validateCronJob(cronjob)`,
@@ -502,6 +489,8 @@ func (sp *Sample) updateWebhookV2() {
 	err = pluginutil.ReplaceInFile(
 		filepath.Join(sp.ctx.Dir, path),
 		`// TODO(user): fill in your validation logic upon object update.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.foo is deprecated and will be removed in a future version"}, nil
 
 	return nil, nil`,
 		`return nil, validateCronJob(cronjob)`,
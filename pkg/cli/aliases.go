@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+// PluginAlias is a named set of plugins registered by the CLIs which embed this package, e.g. by a downstream
+// distribution, which is informed with --plugins as a single key: `--plugins=mycompany-operator` expands
+// into the plugins of the alias.
+type PluginAlias struct {
+	// Name is the key of the alias in --plugins. It can not be the name of a registered plugin.
+	Name string
+	// Plugins are the keys of the plugins of the alias, in the order they are executed.
+	// They should pin the versions of the plugins.
+	Plugins []string
+	// FlagDefaults are the default values of the flags of the subcommands, e.g. "init" or "create api",
+	// when the alias is informed. The default flag values of the user and of the project take precedence.
+	FlagDefaults map[string]map[string]interface{}
+	// Order are the ordering constraints of the plugins of the alias, which are checked when the alias
+	// is informed with other plugins.
+	Order []PluginOrder
+}
+
+// PluginOrder is an ordering constraint of the plugin chain: when both plugins are resolved, the plugin
+// matching the Before key must be executed before the plugin matching the After key.
+type PluginOrder struct {
+	Before string
+	After  string
+}
+
+// validate checks that the alias is well-formed, independently of the plugins of the CLI.
+func (a PluginAlias) validate() error {
+	if err := plugin.ValidateKey(a.Name); err != nil {
+		return fmt.Errorf("invalid name: %w", err)
+	}
+	if _, version := plugin.SplitKey(a.Name); version != "" {
+		return errors.New("the name can not contain a version")
+	}
+	if len(a.Plugins) == 0 {
+		return errors.New("no plugins provided")
+	}
+	for i, key := range a.Plugins {
+		if err := plugin.ValidateKey(key); err != nil {
+			return fmt.Errorf("invalid plugin %q: %w", key, err)
+		}
+		for _, previous := range a.Plugins[:i] {
+			if sameName(previous, key) {
+				return fmt.Errorf("the plugins %q and %q are the same", previous, key)
+			}
+		}
+	}
+	for subcommand, flags := range a.FlagDefaults {
+		if _, found := flags[pluginsFlag]; found {
+			return fmt.Errorf("the default value of --%s of %q can not be set", pluginsFlag, subcommand)
+		}
+	}
+	for _, order := range a.Order {
+		for _, key := range []string{order.Before, order.After} {
+			if err := plugin.ValidateKey(key); err != nil {
+				return fmt.Errorf("invalid plugin %q in the ordering constraints: %w", key, err)
+			}
+		}
+		if sameName(order.Before, order.After) {
+			return fmt.Errorf("the plugin %q can not be ordered before itself", order.Before)
+		}
+	}
+	return nil
+}
+
+// sameName returns true when both plugin keys refer to the same plugin name, which may be not fully qualified,
+// e.g. go/v4 and go.kubebuilder.io/v4.
+func sameName(key1, key2 string) bool {
+	name1, _ := plugin.SplitKey(key1)
+	name2, _ := plugin.SplitKey(key2)
+	return name1 == name2 || strings.HasPrefix(name1, name2+".") || strings.HasPrefix(name2, name1+".")
+}
+
+// sortedPluginAliasNames returns the names of the plugin aliases, sorted so that errors and help are deterministic.
+func (c CLI) sortedPluginAliasNames() []string {
+	names := make([]string, 0, len(c.pluginAliases))
+	for name := range c.pluginAliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkPluginAliases checks that the plugin aliases do not conflict with the plugins of the CLI
+// and that they only contain known plugins.
+func (c CLI) checkPluginAliases() error {
+	plugins := make([]plugin.Plugin, 0, len(c.plugins))
+	for _, p := range c.plugins {
+		plugins = append(plugins, p)
+	}
+
+	for _, name := range c.sortedPluginAliasNames() {
+		// We can omit the errors because the keys have already been validated
+		if conflicting, _ := plugin.FilterPluginsByKey(plugins, name); len(conflicting) != 0 {
+			return fmt.Errorf("the plugin alias %q conflicts with the plugin %q", name, plugin.KeyFor(conflicting[0]))
+		}
+		for _, key := range c.pluginAliases[name].Plugins {
+			if _, isAlias := c.pluginAliases[key]; isAlias {
+				return fmt.Errorf("the plugin alias %q can not contain the plugin alias %q", name, key)
+			}
+			if matching, _ := plugin.FilterPluginsByKey(plugins, key); len(matching) == 0 {
+				return fmt.Errorf("the plugin alias %q contains the unknown plugin %q", name, key)
+			}
+		}
+	}
+	return nil
+}
+
+// expandPluginAliases replaces the plugin aliases of the plugin keys by their plugins and records the aliases
+// which are used. The plugins informed several times are only kept once, and informing two versions of the
+// same plugin, e.g. one from an alias and another one from the flag, results in an error.
+func (c *CLI) expandPluginAliases(pluginKeys []string) ([]string, error) {
+	expanded := make([]string, 0, len(pluginKeys))
+	for _, key := range pluginKeys {
+		keys := []string{key}
+		if alias, isAlias := c.pluginAliases[key]; isAlias {
+			keys = alias.Plugins
+			c.usedPluginAliases = append(c.usedPluginAliases, alias.Name)
+		}
+
+	KeysLoop:
+		for _, key := range keys {
+			for _, previous := range expanded {
+				if !sameName(previous, key) {
+					continue
+				}
+				_, previousVersion := plugin.SplitKey(previous)
+				if _, version := plugin.SplitKey(key); version != previousVersion {
+					return nil, fmt.Errorf("conflicting plugins %q and %q", previous, key)
+				}
+				continue KeysLoop
+			}
+			expanded = append(expanded, key)
+		}
+	}
+	return expanded, nil
+}
+
+// checkPluginOrder checks the ordering constraints of the used plugin aliases against the resolved plugins.
+func (c CLI) checkPluginOrder() error {
+	for _, name := range c.usedPluginAliases {
+		for _, order := range c.pluginAliases[name].Order {
+			before, after := c.resolvedPluginIndex(order.Before), c.resolvedPluginIndex(order.After)
+			if before >= 0 && after >= 0 && before > after {
+				return fmt.Errorf("the plugin %q must be executed before the plugin %q, as required by the plugin alias %q",
+					order.Before, order.After, name)
+			}
+		}
+	}
+	return nil
+}
+
+// resolvedPluginIndex returns the position in the plugin chain of the resolved plugin matching the key,
+// including the plugins of the bundles, or -1 if none matches.
+func (c CLI) resolvedPluginIndex(key string) int {
+	for i, p := range c.resolvedPlugins {
+		plugins := []plugin.Plugin{p}
+		if bundle, isBundle := p.(plugin.Bundle); isBundle {
+			plugins = append(plugins, bundle.Plugins()...)
+		}
+		// We can omit the error because the keys have already been validated
+		if matching, _ := plugin.FilterPluginsByKey(plugins, key); len(matching) != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// getPluginAliasList returns the list of the plugin aliases and of their plugins.
+func (c CLI) getPluginAliasList() string {
+	lines := make([]string, 0, len(c.pluginAliases))
+	for _, name := range c.sortedPluginAliasNames() {
+		lines = append(lines, fmt.Sprintf("    %s: %s", name, strings.Join(c.pluginAliases[name].Plugins, ",")))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	goPluginV4 "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4"
+)
+
+var _ = Describe("Plugin aliases", func() {
+	var (
+		projectVersion = config.Version{Number: 3}
+		plugins        = []plugin.Plugin{
+			newMockPlugin("go.example.com", "v1", projectVersion),
+			newMockPlugin("go.example.com", "v2", projectVersion),
+			newMockPlugin("helm.example.com", "v1", projectVersion),
+			newMockPlugin("internal.example.com", "v1", projectVersion),
+		}
+		alias = PluginAlias{
+			Name:    "mycompany-operator",
+			Plugins: []string{"go.example.com/v2", "helm.example.com/v1", "internal.example.com/v1"},
+			Order:   []PluginOrder{{Before: "go.example.com", After: "internal.example.com"}},
+		}
+
+		args []string
+	)
+
+	BeforeEach(func() { args = os.Args })
+	AfterEach(func() { os.Args = args })
+
+	Context("WithPluginAliases", func() {
+		It("should register the aliases", func() {
+			c, err := newCLI(WithPlugins(plugins...), WithPluginAliases(alias))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.pluginAliases).To(HaveKeyWithValue(alias.Name, alias))
+		})
+
+		DescribeTable("should fail",
+			func(aliases ...PluginAlias) {
+				_, err := newCLI(WithPlugins(plugins...), WithPluginAliases(aliases...))
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("for duplicated aliases", alias, alias),
+			Entry("for an invalid name", PluginAlias{Name: "_", Plugins: alias.Plugins}),
+			Entry("for a name with a version", PluginAlias{Name: "mycompany/v1", Plugins: alias.Plugins}),
+			Entry("for a name conflicting with a plugin", PluginAlias{Name: "helm", Plugins: alias.Plugins}),
+			Entry("for no plugins", PluginAlias{Name: "mycompany"}),
+			Entry("for an invalid plugin", PluginAlias{Name: "mycompany", Plugins: []string{"_/v1"}}),
+			Entry("for an unknown plugin", PluginAlias{Name: "mycompany", Plugins: []string{"grafana.example.com/v1"}}),
+			Entry("for two versions of a plugin",
+				PluginAlias{Name: "mycompany", Plugins: []string{"go.example.com/v1", "go/v2"}}),
+			Entry("for nested aliases", alias, PluginAlias{Name: "mycompany", Plugins: []string{alias.Name}}),
+			Entry("for the default value of the plugins flag", PluginAlias{Name: "mycompany", Plugins: alias.Plugins,
+				FlagDefaults: map[string]map[string]interface{}{"init": {pluginsFlag: "go/v1"}}}),
+			Entry("for a plugin ordered before itself", PluginAlias{Name: "mycompany", Plugins: alias.Plugins,
+				Order: []PluginOrder{{Before: "go", After: "go.example.com"}}}),
+		)
+	})
+
+	Context("expandPluginAliases", func() {
+		var c *CLI
+
+		BeforeEach(func() {
+			var err error
+			c, err = newCLI(WithPlugins(plugins...), WithPluginAliases(alias))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should replace the aliases by their plugins", func() {
+			keys, err := c.expandPluginAliases([]string{"mycompany-operator"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal(alias.Plugins))
+			Expect(c.usedPluginAliases).To(Equal([]string{alias.Name}))
+		})
+
+		It("should keep the plugins informed several times once", func() {
+			keys, err := c.expandPluginAliases([]string{"helm/v1", "mycompany-operator"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]string{"helm/v1", "go.example.com/v2", "internal.example.com/v1"}))
+		})
+
+		It("should keep the plugin keys which are not aliases", func() {
+			keys, err := c.expandPluginAliases([]string{"go/v1", "helm/v1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]string{"go/v1", "helm/v1"}))
+			Expect(c.usedPluginAliases).To(BeEmpty())
+		})
+
+		It("should fail for two versions of the same plugin", func() {
+			_, err := c.expandPluginAliases([]string{"mycompany-operator", "go/v1"})
+			Expect(err).To(MatchError(ContainSubstring(`conflicting plugins "go.example.com/v2" and "go/v1"`)))
+		})
+	})
+
+	Context("New", func() {
+		It("should resolve the plugins of the alias", func() {
+			setPluginsFlag(alias.Name)
+
+			c, err := New(WithPlugins(plugins...), WithPluginAliases(alias))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.resolvedPlugins).To(HaveLen(3))
+			Expect(plugin.KeyFor(c.resolvedPlugins[0])).To(Equal("go.example.com/v2"))
+			Expect(c.cmd.Example).To(ContainSubstring("mycompany-operator: go.example.com/v2,"))
+		})
+
+		It("should fail when the ordering constraints are not met", func() {
+			setPluginsFlag("internal/v1," + alias.Name)
+
+			c, err := New(WithPlugins(plugins...), WithPluginAliases(alias))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.cmd.RunE(c.cmd, nil)).To(MatchError(ContainSubstring(
+				`the plugin "go.example.com" must be executed before the plugin "internal.example.com"`)))
+		})
+
+		It("should set the default flag values of the alias", func() {
+			fs := machinery.Filesystem{FS: afero.NewMemMapFs()}
+			Expect(afero.WriteFile(fs.FS, flagDefaultsFile, []byte("init:\n  owner: The Team\n"), 0o644)).To(Succeed())
+			setPluginsFlag("mycompany")
+
+			c, err := New(
+				WithFilesystem(fs),
+				WithPlugins(&goPluginV4.Plugin{}),
+				WithPluginAliases(PluginAlias{
+					Name:    "mycompany",
+					Plugins: []string{"base.go.kubebuilder.io/v4"},
+					FlagDefaults: map[string]map[string]interface{}{
+						"init": {"owner": "The Company", "license": "copyright"},
+					},
+				}),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			cmd, _, err := c.cmd.Find([]string{"init"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.Flags().Lookup("license").Value.String()).To(Equal("copyright"))
+			// The defaults of the project take precedence
+			Expect(cmd.Flags().Lookup("owner").Value.String()).To(Equal("The Team"))
+		})
+	})
+})
@@ -18,19 +18,59 @@ package cli
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+
 	"sigs.k8s.io/kubebuilder/v4/pkg/cli/alpha"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 )
 
 const (
 	alphaCommand = "alpha"
+
+	// validateProjectCommand is the alpha subcommand validating the PROJECT file, which is run even when
+	// the PROJECT file can not be loaded
+	validateProjectCommand = "validate-project"
 )
 
-var alphaCommands = []*cobra.Command{
-	newAlphaCommand(),
-	alpha.NewScaffoldCommand(),
+// alphaCommands returns the alpha subcommands provided by the CLI.
+func (c *CLI) alphaCommands() []*cobra.Command {
+	plugins := make([]plugin.Plugin, 0, len(c.plugins))
+	for _, p := range c.plugins {
+		plugins = append(plugins, p)
+	}
+
+	return []*cobra.Command{
+		newAlphaCommand(),
+		alpha.NewScaffoldCommand(plugins...),
+		alpha.NewDiffCommand(plugins...),
+		alpha.NewUpdateCommand(),
+		alpha.NewConfigViewCommand(),
+		alpha.NewConfigSetCommand(),
+		alpha.NewRenameCommand(),
+		alpha.NewConvertCommand(),
+		alpha.NewRBACAuditCommand(),
+		alpha.NewScaffoldExternalPluginCommand(),
+		alpha.NewValidateProjectCommand(plugins...),
+	}
+}
+
+// isValidatingProject returns true if the command line runs the alpha subcommand validating the PROJECT file.
+// The global flags are parsed so that their values, e.g. '--plugins go/v4', are not taken for the subcommand.
+func (c *CLI) isValidatingProject() bool {
+	fs := c.newBaseFlagSet()
+	fs.String(projectVersionFlag, "", "project version")
+	fs.String(fromTemplateFlag, "", "template repository")
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		return false
+	}
+
+	args := fs.Args()
+	return len(args) >= 2 && args[0] == alphaCommand && args[1] == validateProjectCommand
 }
 
 func newAlphaCommand() *cobra.Command {
@@ -40,7 +80,7 @@ func newAlphaCommand() *cobra.Command {
 	return cmd
 }
 
-func (c *CLI) newAlphaCmd() *cobra.Command {
+func (c *CLI) newAlphaCmd(alphaCommands []*cobra.Command) *cobra.Command {
 	alpha := &cobra.Command{
 		Use:        alphaCommand,
 		SuggestFor: []string{"experimental"},
@@ -60,8 +100,9 @@ Alpha subcommands are for unstable features.
 }
 
 func (c *CLI) addAlphaCmd() {
+	alphaCommands := c.alphaCommands()
 	if (len(alphaCommands) + len(c.extraAlphaCommands)) > 0 {
-		c.cmd.AddCommand(c.newAlphaCmd())
+		c.cmd.AddCommand(c.newAlphaCmd(alphaCommands))
 	}
 }
 
@@ -16,7 +16,9 @@ package alpha
 import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+
 	"sigs.k8s.io/kubebuilder/v4/pkg/cli/alpha/internal"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 )
 
 // NewScaffoldCommand returns a new scaffold command, providing the `kubebuilder alpha generate`
@@ -34,8 +36,11 @@ import (
 //
 // Technically, implementing functions that allow re-scaffolding with the exact plugins and project-specific
 // code of external projects is not feasible within Kubebuilder’s current design.
-func NewScaffoldCommand() *cobra.Command {
-	opts := internal.Generate{}
+//
+// The provided plugins are the ones available in the CLI: the deprecated plugins of the project
+// are replaced by their successors when re-scaffolding it.
+func NewScaffoldCommand(plugins ...plugin.Plugin) *cobra.Command {
+	opts := internal.Generate{PluginReplacements: pluginReplacements(plugins)}
 	scaffoldCmd := &cobra.Command{
 		Use:   "generate",
 		Short: "Re-scaffold an existing Kuberbuilder project",
@@ -44,8 +49,20 @@ using the current version of KubeBuilder binary available.
 # make sure the PROJECT file is in the 'input-dir' argument, the default is the current directory.
 $ kubebuilder alpha generate --input-dir="./test" --output-dir="./my-output"
 Then we will re-scaffold the project by Kubebuilder in the directory specified by 'output-dir'.
+
+# re-apply only the scaffold of the given optional plugins over the existing project, e.g. to adopt them
+# in an old project without re-scaffolding the whole project.
+$ kubebuilder alpha generate --plugins=helm/v1-alpha,grafana/v1-alpha
 		`,
-		PreRunE: func(_ *cobra.Command, _ []string) error {
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			// The plugins flag is the global flag of the CLI
+			if cmd.Flags().Lookup("plugins") != nil {
+				plugins, err := cmd.Flags().GetStringSlice("plugins")
+				if err != nil {
+					return err
+				}
+				opts.Plugins = plugins
+			}
 			return opts.Validate()
 		},
 		Run: func(_ *cobra.Command, _ []string) {
@@ -63,3 +80,14 @@ Then we will re-scaffold the project by Kubebuilder in the directory specified b
 
 	return scaffoldCmd
 }
+
+// pluginReplacements maps the keys of the deprecated plugins to the keys of the plugins replacing them.
+func pluginReplacements(plugins []plugin.Plugin) map[string]string {
+	replacements := make(map[string]string)
+	for _, p := range plugins {
+		if replacement := plugin.ReplacedBy(p); replacement != "" {
+			replacements[plugin.KeyFor(p)] = replacement
+		}
+	}
+	return replacements
+}
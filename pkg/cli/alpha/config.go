@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/cli/alpha/internal"
+)
+
+// NewConfigViewCommand returns a new config-view command, providing the `kubebuilder alpha config-view`
+// feature to read the PROJECT file and the configuration of its plugins.
+func NewConfigViewCommand() *cobra.Command {
+	opts := internal.ConfigView{}
+	viewCmd := &cobra.Command{
+		Use:   "config-view [key]",
+		Short: "Show the PROJECT file or one of its values",
+		Long: `It's an experimental feature that shows the PROJECT file of the project, or the value of one of its
+keys: ` + strings.Join(internal.ConfigKeys, ", ") + `, or the configuration of a plugin as
+plugins.<plugin key>[.<field>].
+# show the whole PROJECT file
+$ kubebuilder alpha config-view
+# show the domain of the project
+$ kubebuilder alpha config-view domain
+# show the directory of the helm chart
+$ kubebuilder alpha config-view plugins.helm.kubebuilder.io/v1-alpha.chartDir
+		`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(_ *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Key = args[0]
+			}
+			return opts.Validate()
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := opts.View(); err != nil {
+				log.Fatalf("Failed to command %s", err)
+			}
+		},
+	}
+	viewCmd.Flags().StringVar(&opts.InputDir, "input-dir", "",
+		"Specifies the full path to a Kubebuilder project file. If not provided, "+
+			"the current working directory is used.")
+
+	return viewCmd
+}
+
+// NewConfigSetCommand returns a new config-set command, providing the `kubebuilder alpha config-set`
+// feature to change the PROJECT file and the configuration of its plugins without editing it by hand.
+func NewConfigSetCommand() *cobra.Command {
+	opts := internal.ConfigSet{}
+	setCmd := &cobra.Command{
+		Use:   "config-set <key> [value]",
+		Short: "Set a value of the PROJECT file",
+		Long: `It's an experimental feature that validates and sets the value of a key of the PROJECT file:
+domain, repo, projectName, multigroup, or a field of the configuration of a plugin as
+plugins.<plugin key>.<field>, whose value is parsed as YAML. The fields of the plugin configurations
+can be removed with --unset.
+
+The files scaffolded from the previous value are not changed: the command prints the steps
+to update them.
+# rename the domain of the project
+$ kubebuilder alpha config-set domain example.com
+# enable the multigroup layout
+$ kubebuilder alpha config-set multigroup true
+# move the helm chart to the directory deploy
+$ kubebuilder alpha config-set plugins.helm.kubebuilder.io/v1-alpha.chartDir deploy
+# move the helm chart back to its default directory
+$ kubebuilder alpha config-set plugins.helm.kubebuilder.io/v1-alpha.chartDir --unset
+		`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.Unset {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
+		PreRunE: func(_ *cobra.Command, args []string) error {
+			opts.Key = args[0]
+			if len(args) > 1 {
+				opts.Value = args[1]
+			}
+			return opts.Validate()
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := opts.Set(); err != nil {
+				log.Fatalf("Failed to command %s", err)
+			}
+		},
+	}
+	setCmd.Flags().StringVar(&opts.InputDir, "input-dir", "",
+		"Specifies the full path to a Kubebuilder project file. If not provided, "+
+			"the current working directory is used.")
+	setCmd.Flags().BoolVar(&opts.Unset, "unset", false,
+		"If set, removes the field of the plugin configuration instead of setting it.")
+
+	return setCmd
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/cli/alpha/internal"
+)
+
+// NewConvertCommand returns a new convert command, providing the `kubebuilder alpha convert`
+// feature to convert the kustomize manifests of a project to its helm chart and back.
+func NewConvertCommand() *cobra.Command {
+	opts := internal.Convert{}
+	convertCmd := &cobra.Command{
+		Use:   "convert",
+		Short: "Convert the kustomize manifests of the project to a helm chart and back",
+		Long: `It's an experimental feature that helps projects to migrate their distribution strategy.
+
+With --to=helm, the kustomize manifests of config/ are converted to the chart of the helm/v1-alpha
+plugin, which is scaffolded under dist/chart, or the --chart-dir tracked in the PROJECT file.
+
+With --to=kustomize, the chart is rendered with its default values and the objects are written in the
+layout of config/, e.g. the CRDs in config/crd/bases and the RBAC in config/rbac, along with their
+kustomizations. The helm labels, the namespace of the release and the project name prefixing the
+names are removed, since they are set by config/default/kustomization.yaml. The objects which can
+not be converted, e.g. the helm hooks and the templates without counterpart in config/, are reported.
+
+The objects deployed by the kustomize manifests and by the chart are compared once converted, when
+kustomize and helm are found, to report the objects which the converted manifests do not deploy.
+
+# convert the kustomize manifests to the helm chart
+$ kubebuilder alpha convert --to=helm
+
+# convert the helm chart to the kustomize manifests, overwriting the existing ones
+$ kubebuilder alpha convert --to=kustomize --force
+		`,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return opts.Validate()
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := opts.Convert(); err != nil {
+				log.Fatalf("Failed to command %s", err)
+			}
+		},
+	}
+	convertCmd.Flags().StringVar(&opts.InputDir, "input-dir", "",
+		"Specifies the full path to a Kubebuilder project file. If not provided, "+
+			"the current working directory is used.")
+	convertCmd.Flags().StringVar(&opts.To, "to", "",
+		fmt.Sprintf("distribution the project is converted to, %q or %q",
+			internal.ConvertToHelm, internal.ConvertToKustomize))
+	convertCmd.Flags().BoolVar(&opts.Force, "force", false,
+		"overwrite the chart or the kustomize manifests which already exist")
+
+	return convertCmd
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/cli/alpha/internal"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+// NewDiffCommand returns a new diff command, providing the `kubebuilder alpha diff`
+// feature to show how a project drifted from the scaffold of the plugins it was created with.
+//
+// IMPORTANT: As `kubebuilder alpha generate`, which it relies on, this command is intended
+// solely for Kubebuilder's use.
+//
+// The provided plugins are the ones available in the CLI: the deprecated plugins of the project
+// are replaced by their successors in the pristine scaffold.
+func NewDiffCommand(plugins ...plugin.Plugin) *cobra.Command {
+	opts := internal.Diff{PluginReplacements: pluginReplacements(plugins)}
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show the differences between a project and its pristine scaffold",
+		Long: `It's an experimental feature that re-scaffolds the project in a temporary directory,
+using the plugins recorded in its PROJECT file, and shows the differences between the project and
+this pristine scaffold.
+
+The project is re-scaffolded with the KubeBuilder release it was scaffolded with, which is set with
+--from-version or recorded in the PROJECT file by the autoupdate plugin, so that only the changes made
+on top of the scaffold are shown. When this release is unknown, the running binary is used and the
+changes of the scaffold since the release of the project are shown as well.
+
+It helps to identify the changes made on top of the scaffold before running 'alpha generate'
+or upgrading the project.
+
+The command exits with the status code 1 when the project differs from the scaffold.
+# make sure the PROJECT file is in the 'input-dir' argument, the default is the current directory.
+$ kubebuilder alpha diff --input-dir="./test"
+# only list the files which differ
+$ kubebuilder alpha diff --name-only
+# compare the project with the scaffold of the release v4.5.0
+$ kubebuilder alpha diff --from-version v4.5.0
+		`,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return opts.Validate()
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			drifted, err := opts.Diff()
+			if err != nil {
+				log.Fatalf("Failed to command %s", err)
+			}
+			if drifted {
+				os.Exit(1)
+			}
+		},
+	}
+	diffCmd.Flags().StringVar(&opts.InputDir, "input-dir", "",
+		"Specifies the full path to a Kubebuilder project file. If not provided, "+
+			"the current working directory is used.")
+	diffCmd.Flags().StringVar(&opts.FromVersion, "from-version", "",
+		"Kubebuilder release used to scaffold the project (e.g. v4.5.0). If not provided, the release recorded "+
+			"in the PROJECT file is used, or the running binary if none is recorded.")
+	diffCmd.Flags().StringSliceVar(&opts.Excludes, "exclude", internal.DefaultDiffExcludes,
+		"File or directory names which are ignored when comparing the project with the scaffold.")
+	diffCmd.Flags().BoolVar(&opts.NameOnly, "name-only", false,
+		"If set, only the names of the files which differ are shown.")
+	diffCmd.Flags().BoolVar(&opts.Keep, "keep", false,
+		"If set, the temporary directory with the pristine scaffold is not removed.")
+
+	return diffCmd
+}
@@ -0,0 +1,397 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/config/store"
+	storeyaml "sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	"sigs.k8s.io/kubebuilder/v4/pkg/internal/validation"
+)
+
+const (
+	domainKey      = "domain"
+	repoKey        = "repo"
+	projectNameKey = "projectName"
+	multigroupKey  = "multigroup"
+	layoutKey      = "layout"
+	versionKey     = "version"
+	pluginsKey     = "plugins"
+)
+
+// ConfigKeys are the keys of the PROJECT file supported by config-view and config-set, besides the
+// plugin configurations which are addressed as `plugins.<plugin key>[.<field>]`
+var ConfigKeys = []string{domainKey, repoKey, projectNameKey, multigroupKey, layoutKey, versionKey}
+
+// ConfigView store the required info for the config-view command
+type ConfigView struct {
+	InputDir string
+	// Key is the key of the PROJECT file to show, the whole file is shown when it is empty
+	Key string
+}
+
+// Validate ensures the options are valid.
+func (opts *ConfigView) Validate() error {
+	var err error
+	opts.InputDir, err = getInputPath(opts.InputDir)
+	return err
+}
+
+// View prints the value of the key of the PROJECT file, or the whole file when no key is set.
+func (opts *ConfigView) View() error {
+	projectStore, err := loadProjectConfig(opts.InputDir)
+	if err != nil {
+		return err
+	}
+	cfg := projectStore.Config()
+
+	value, err := viewConfigKey(cfg, opts.Key)
+	if err != nil {
+		return err
+	}
+	fmt.Println(strings.TrimRight(value, "\n"))
+	return nil
+}
+
+// viewConfigKey returns the value of the key of the PROJECT file
+func viewConfigKey(cfg config.Config, key string) (string, error) {
+	switch key {
+	case "":
+		content, err := cfg.MarshalYAML()
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal the PROJECT file: %w", err)
+		}
+		return string(content), nil
+	case domainKey:
+		return cfg.GetDomain(), nil
+	case repoKey:
+		return cfg.GetRepository(), nil
+	case projectNameKey:
+		return cfg.GetProjectName(), nil
+	case multigroupKey:
+		return strconv.FormatBool(cfg.IsMultiGroup()), nil
+	case layoutKey:
+		return strings.Join(cfg.GetPluginChain(), ","), nil
+	case versionKey:
+		return cfg.GetVersion().String(), nil
+	}
+
+	pluginKey, field, err := splitPluginConfigKey(key)
+	if err != nil {
+		return "", err
+	}
+	pluginConfig, err := decodePluginConfig(cfg, pluginKey)
+	if err != nil {
+		return "", err
+	}
+
+	var value interface{} = pluginConfig
+	if field != "" {
+		var found bool
+		if value, found = pluginConfig[field]; !found {
+			return "", fmt.Errorf("the field %q is not set in the configuration of the plugin %q", field, pluginKey)
+		}
+	}
+	content, err := yaml.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the value of %q: %w", key, err)
+	}
+	return string(content), nil
+}
+
+// ConfigSet store the required info for the config-set command
+type ConfigSet struct {
+	InputDir string
+	// Key is the key of the PROJECT file to set
+	Key string
+	// Value is the value to set, in YAML for the fields of the plugin configurations
+	Value string
+	// Unset removes the field of the plugin configuration addressed by the key instead of setting it
+	Unset bool
+}
+
+// Validate ensures the options are valid.
+func (opts *ConfigSet) Validate() error {
+	var err error
+	opts.InputDir, err = getInputPath(opts.InputDir)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Key {
+	case layoutKey, versionKey:
+		return fmt.Errorf("the %s of the project can not be changed, "+
+			"re-scaffold the project with `kubebuilder alpha generate` instead", opts.Key)
+	case domainKey, repoKey, projectNameKey, multigroupKey:
+		if opts.Unset {
+			return fmt.Errorf("the %s of the project can not be unset, only the fields of the plugin "+
+				"configurations can", opts.Key)
+		}
+		return nil
+	}
+	if _, field, err := splitPluginConfigKey(opts.Key); err != nil {
+		return err
+	} else if field == "" {
+		return fmt.Errorf("the key %q must address a field of the plugin configuration, "+
+			"in the form %s.<plugin key>.<field>", opts.Key, pluginsKey)
+	}
+	return nil
+}
+
+// Set sets the value of the key in the PROJECT file and prints the steps to re-scaffold
+// the files which depend on it.
+func (opts *ConfigSet) Set() error {
+	projectStore, err := loadProjectConfig(opts.InputDir)
+	if err != nil {
+		return err
+	}
+	cfg := projectStore.Config()
+
+	var hint string
+	if opts.Unset {
+		hint, err = unsetConfigKey(cfg, opts.Key)
+	} else {
+		hint, err = setConfigKey(afero.NewBasePathFs(afero.NewOsFs(), opts.InputDir), cfg, opts.Key, opts.Value)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := saveProjectConfig(projectStore, opts.InputDir); err != nil {
+		return err
+	}
+	if opts.Unset {
+		log.Infof("%s was removed from the PROJECT file", opts.Key)
+	} else {
+		log.Infof("%s was set to %q in the PROJECT file", opts.Key, opts.Value)
+	}
+	if hint != "" {
+		fmt.Println(hint)
+	}
+	return nil
+}
+
+// setConfigKey validates and sets the value of the key in the config. It returns the steps
+// to re-scaffold the files which depend on the key.
+func setConfigKey(fs afero.Fs, cfg config.Config, key, value string) (string, error) {
+	switch key {
+	case domainKey:
+		if errs := validation.IsDNS1123Subdomain(value); len(errs) != 0 {
+			return "", fmt.Errorf("domain (%s) is invalid: %s", value, strings.Join(errs, ", "))
+		}
+		if err := cfg.SetDomain(value); err != nil {
+			return "", err
+		}
+		return "The domain is used by the APIs created from now on, the existing APIs keep their domain in the " +
+			"PROJECT file. To move them to the new domain, use `kubebuilder alpha rename --domain` instead.", nil
+	case repoKey:
+		if value == "" {
+			return "", errors.New("the repository can not be empty")
+		}
+		if err := cfg.SetRepository(value); err != nil {
+			return "", err
+		}
+		return "Update the module path of the `go.mod` file and the imports of the Go files accordingly, " +
+			"or use `kubebuilder alpha rename --repo` instead.", nil
+	case projectNameKey:
+		if errs := validation.IsDNS1123Label(value); len(errs) != 0 {
+			return "", fmt.Errorf("project name (%s) is invalid: %s", value, strings.Join(errs, ", "))
+		}
+		if err := cfg.SetProjectName(value); err != nil {
+			return "", err
+		}
+		return "The project name is used as prefix of the manifests under `config/default`. Update the " +
+			"`namePrefix` and the `namespace` of `config/default/kustomization.yaml`, and run " +
+			"`kubebuilder edit --plugins=helm/v1-alpha --force` if the project has a helm chart, or use " +
+			"`kubebuilder alpha rename --project-name` instead.", nil
+	case multigroupKey:
+		multigroup, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid value %q of %s: %w", value, multigroupKey, err)
+		}
+		if multigroup == cfg.IsMultiGroup() {
+			return "", nil
+		}
+		if multigroup {
+			err = cfg.SetMultiGroup()
+		} else {
+			if groups := resourceGroups(cfg); len(groups) > 1 {
+				return "", fmt.Errorf("multigroup can not be disabled in a project with APIs in several groups: %s",
+					strings.Join(groups, ", "))
+			}
+			err = cfg.ClearMultiGroup()
+		}
+		if err != nil {
+			return "", err
+		}
+		return "The layout of the APIs and the controllers depends on multigroup. Move the packages " +
+			"under `api/` and `internal/controller/` accordingly and update their imports.", nil
+	}
+
+	pluginKey, field, err := splitPluginConfigKey(key)
+	if err != nil {
+		return "", err
+	}
+	pluginConfig, err := decodePluginConfig(cfg, pluginKey)
+	if err != nil {
+		return "", err
+	}
+
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+		return "", fmt.Errorf("invalid value %q of %s: %w", value, key, err)
+	}
+	if err := validatePluginConfigField(fs, field, parsed); err != nil {
+		return "", err
+	}
+	if _, found := pluginConfig[field]; !found {
+		log.Warnf("the field %q was not set in the configuration of the plugin %q", field, pluginKey)
+	}
+	pluginConfig[field] = parsed
+	if err := cfg.EncodePluginConfig(pluginKey, pluginConfig); err != nil {
+		return "", fmt.Errorf("failed to encode the configuration of the plugin %q: %w", pluginKey, err)
+	}
+
+	return fmt.Sprintf("Re-scaffold the files of the plugin with `kubebuilder edit --plugins=%s --force`. "+
+		"Files which were customized must be updated manually.", pluginShortKey(pluginKey)), nil
+}
+
+// unsetConfigKey removes the field of the plugin configuration addressed by the key from the config.
+// It returns the steps to re-scaffold the files which depend on the field.
+func unsetConfigKey(cfg config.Config, key string) (string, error) {
+	pluginKey, field, err := splitPluginConfigKey(key)
+	if err != nil {
+		return "", err
+	}
+	if field == "" {
+		return "", fmt.Errorf("the key %q must address a field of the plugin configuration, "+
+			"in the form %s.<plugin key>.<field>", key, pluginsKey)
+	}
+	pluginConfig, err := decodePluginConfig(cfg, pluginKey)
+	if err != nil {
+		return "", err
+	}
+
+	if _, found := pluginConfig[field]; !found {
+		return "", fmt.Errorf("the field %q is not set in the configuration of the plugin %q", field, pluginKey)
+	}
+	delete(pluginConfig, field)
+	if err := cfg.EncodePluginConfig(pluginKey, pluginConfig); err != nil {
+		return "", fmt.Errorf("failed to encode the configuration of the plugin %q: %w", pluginKey, err)
+	}
+
+	return fmt.Sprintf("Re-scaffold the files of the plugin with `kubebuilder edit --plugins=%s --force` to apply "+
+		"its default. Files which were customized must be updated manually.", pluginShortKey(pluginKey)), nil
+}
+
+// validatePluginConfigField validates the values of the known fields of the plugin configurations
+func validatePluginConfigField(fs afero.Fs, field string, value interface{}) error {
+	switch field {
+	case "chartDir":
+		dir, ok := value.(string)
+		if !ok || dir == "" {
+			return fmt.Errorf("chartDir must be a non-empty path")
+		}
+		if filepath.IsAbs(dir) || strings.HasPrefix(filepath.Clean(dir), "..") {
+			return fmt.Errorf("chartDir (%s) must be a path relative to the project", dir)
+		}
+		if exists, _ := afero.DirExists(fs, dir); exists {
+			log.Warnf("the directory %s already exists", dir)
+		}
+	}
+	return nil
+}
+
+// splitPluginConfigKey returns the plugin key and the field of a key in the form `plugins.<plugin key>[.<field>]`.
+// The plugin keys contain dots, so the field is the part after the first dot following the version of the plugin.
+func splitPluginConfigKey(key string) (pluginKey, field string, err error) {
+	rest, found := strings.CutPrefix(key, pluginsKey+".")
+	if !found || rest == "" {
+		return "", "", fmt.Errorf("unknown key %q, the supported keys are %s and %s.<plugin key>[.<field>]",
+			key, strings.Join(ConfigKeys, ", "), pluginsKey)
+	}
+
+	// The plugin keys are in the form <name>/<version>, e.g. helm.kubebuilder.io/v1-alpha
+	slash := strings.LastIndex(rest, "/")
+	if slash < 0 {
+		return "", "", fmt.Errorf("the key %q does not contain a plugin key in the form <name>/<version>", key)
+	}
+	pluginKey = rest
+	if dot := strings.Index(rest[slash:], "."); dot >= 0 {
+		pluginKey, field = rest[:slash+dot], rest[slash+dot+1:]
+	}
+	return pluginKey, field, nil
+}
+
+// decodePluginConfig returns the configuration of the plugin stored in the PROJECT file
+func decodePluginConfig(cfg config.Config, pluginKey string) (map[string]interface{}, error) {
+	pluginConfig := map[string]interface{}{}
+	if err := cfg.DecodePluginConfig(pluginKey, &pluginConfig); err != nil {
+		var notFoundErr config.PluginKeyNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return nil, fmt.Errorf("the plugin %q has no configuration in the PROJECT file", pluginKey)
+		}
+		return nil, fmt.Errorf("failed to decode the configuration of the plugin %q: %w", pluginKey, err)
+	}
+	return pluginConfig, nil
+}
+
+// pluginShortKey returns the key of the plugin as it is informed with --plugins, e.g. helm/v1-alpha
+func pluginShortKey(pluginKey string) string {
+	name, version, _ := strings.Cut(pluginKey, "/")
+	name, _, _ = strings.Cut(name, ".")
+	return name + "/" + version
+}
+
+// resourceGroups returns the sorted groups of the APIs of the project
+func resourceGroups(cfg config.Config) []string {
+	resources, err := cfg.GetResources()
+	if err != nil {
+		return nil
+	}
+	groupSet := make(map[string]struct{})
+	for _, res := range resources {
+		if res.HasAPI() {
+			groupSet[res.Group] = struct{}{}
+		}
+	}
+	groups := make([]string, 0, len(groupSet))
+	for group := range groupSet {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// saveProjectConfig writes the PROJECT file of the project
+func saveProjectConfig(projectStore store.Store, inputDir string) error {
+	if err := projectStore.SaveTo(fmt.Sprintf("%s/%s", inputDir, storeyaml.DefaultPath)); err != nil {
+		return fmt.Errorf("failed to save the PROJECT file: %w", err)
+	}
+	return nil
+}
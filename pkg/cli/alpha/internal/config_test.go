@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+)
+
+var _ = Describe("Config commands", func() {
+	var dir string
+
+	loadConfig := func() config.Config {
+		projectStore, err := loadProjectConfig(dir)
+		Expect(err).NotTo(HaveOccurred())
+		return projectStore.Config()
+	}
+
+	set := func(key, value string) error {
+		opts := ConfigSet{InputDir: dir, Key: key, Value: value}
+		if err := opts.Validate(); err != nil {
+			return err
+		}
+		return opts.Set()
+	}
+
+	unset := func(key string) error {
+		opts := ConfigSet{InputDir: dir, Key: key, Unset: true}
+		if err := opts.Validate(); err != nil {
+			return err
+		}
+		return opts.Set()
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "PROJECT"), []byte(projectFile), 0o644)).To(Succeed())
+	})
+
+	Context("config-view", func() {
+		DescribeTable("should return the value of the key",
+			func(key, expected string) {
+				value, err := viewConfigKey(loadConfig(), key)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(value).To(Equal(expected))
+			},
+			Entry("domain", domainKey, "example.org"),
+			Entry("repo", repoKey, "example.org/project"),
+			Entry("projectName", projectNameKey, "project"),
+			Entry("multigroup", multigroupKey, "false"),
+			Entry("layout", layoutKey, "go.kubebuilder.io/v4"),
+			Entry("version", versionKey, "3"),
+			Entry("a field of a plugin", "plugins.helm.kubebuilder.io/v1-alpha.chartDir", "dist/chart\n"),
+			Entry("a plugin", "plugins.helm.kubebuilder.io/v1-alpha", "chartDir: dist/chart\nforce: true\n"),
+		)
+
+		It("should return the whole PROJECT file without key", func() {
+			value, err := viewConfigKey(loadConfig(), "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal(projectConfig))
+		})
+
+		DescribeTable("should fail for invalid keys",
+			func(key, message string) {
+				_, err := viewConfigKey(loadConfig(), key)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("an unknown key", "owner", "unknown key"),
+			Entry("a plugin without version", "plugins.helm", "does not contain a plugin key"),
+			Entry("a plugin without configuration", "plugins.grafana.kubebuilder.io/v1-alpha", "has no configuration"),
+			Entry("a field which is not set", "plugins.helm.kubebuilder.io/v1-alpha.directory", "is not set"),
+		)
+	})
+
+	Context("config-set", func() {
+		DescribeTable("should reject the keys which can not be set",
+			func(key, message string) {
+				Expect(set(key, "value")).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("the layout", layoutKey, "can not be changed"),
+			Entry("the version", versionKey, "can not be changed"),
+			Entry("an unknown key", "owner", "unknown key"),
+			Entry("a plugin", "plugins.helm.kubebuilder.io/v1-alpha", "must address a field"),
+			Entry("a plugin without version", "plugins.helm.chartDir", "does not contain a plugin key"),
+		)
+
+		DescribeTable("should reject invalid values",
+			func(key, value, message string) {
+				Expect(set(key, value)).To(MatchError(ContainSubstring(message)))
+				Expect(os.ReadFile(filepath.Join(dir, "PROJECT"))).To(Equal([]byte(projectFile)))
+			},
+			Entry("a domain", domainKey, "Example_org", "domain (Example_org) is invalid"),
+			Entry("a repo", repoKey, "", "can not be empty"),
+			Entry("a project name", projectNameKey, "my.project", "project name (my.project) is invalid"),
+			Entry("a multigroup", multigroupKey, "yes please", "invalid value"),
+			Entry("an absolute chart directory", "plugins.helm.kubebuilder.io/v1-alpha.chartDir", "/tmp/chart",
+				"must be a path relative to the project"),
+			Entry("a chart directory out of the project", "plugins.helm.kubebuilder.io/v1-alpha.chartDir",
+				"../chart", "must be a path relative to the project"),
+			Entry("a plugin without configuration", "plugins.grafana.kubebuilder.io/v1-alpha.force", "true",
+				"has no configuration"),
+		)
+
+		It("should set the domain", func() {
+			Expect(set(domainKey, "example.com")).To(Succeed())
+			cfg := loadConfig()
+			Expect(cfg.GetDomain()).To(Equal("example.com"))
+
+			By("keeping the domain of the existing APIs")
+			resources, err := cfg.GetResources()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(HaveLen(1))
+			Expect(resources[0].Domain).To(Equal("example.org"))
+		})
+
+		It("should set the repo and the project name", func() {
+			Expect(set(repoKey, "example.com/other")).To(Succeed())
+			Expect(set(projectNameKey, "other")).To(Succeed())
+			cfg := loadConfig()
+			Expect(cfg.GetRepository()).To(Equal("example.com/other"))
+			Expect(cfg.GetProjectName()).To(Equal("other"))
+		})
+
+		It("should enable and disable multigroup", func() {
+			Expect(set(multigroupKey, "true")).To(Succeed())
+			Expect(loadConfig().IsMultiGroup()).To(BeTrue())
+
+			Expect(set(multigroupKey, "false")).To(Succeed())
+			Expect(loadConfig().IsMultiGroup()).To(BeFalse())
+		})
+
+		It("should not disable multigroup with APIs in several groups", func() {
+			Expect(set(multigroupKey, "true")).To(Succeed())
+			cfg := loadConfig()
+			resources, err := cfg.GetResources()
+			Expect(err).NotTo(HaveOccurred())
+			res := resources[0].Copy()
+			res.Group = "ship"
+			Expect(cfg.AddResource(res)).To(Succeed())
+			Expect(cfg.ClearMultiGroup()).To(Succeed())
+
+			_, err = setConfigKey(nil, cfg, multigroupKey, "true")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = setConfigKey(nil, cfg, multigroupKey, "false")
+			Expect(err).To(MatchError(ContainSubstring("APIs in several groups: crew, ship")))
+		})
+
+		It("should set a field of a plugin and keep its other fields", func() {
+			Expect(set("plugins.helm.kubebuilder.io/v1-alpha.chartDir", "deploy")).To(Succeed())
+
+			value, err := viewConfigKey(loadConfig(), "plugins.helm.kubebuilder.io/v1-alpha")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal("chartDir: deploy\nforce: true\n"))
+		})
+
+		It("should parse the values of the fields of the plugins as YAML", func() {
+			Expect(set("plugins.helm.kubebuilder.io/v1-alpha.force", "false")).To(Succeed())
+			Expect(set("plugins.helm.kubebuilder.io/v1-alpha.values", "{replicas: 2}")).To(Succeed())
+
+			value, err := viewConfigKey(loadConfig(), "plugins.helm.kubebuilder.io/v1-alpha")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal("chartDir: dist/chart\nforce: false\nvalues:\n  replicas: 2\n"))
+		})
+	})
+
+	Context("config-set --unset", func() {
+		It("should remove a field of a plugin and keep its other fields", func() {
+			Expect(unset("plugins.helm.kubebuilder.io/v1-alpha.force")).To(Succeed())
+
+			value, err := viewConfigKey(loadConfig(), "plugins.helm.kubebuilder.io/v1-alpha")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal("chartDir: dist/chart\n"))
+		})
+
+		DescribeTable("should fail for the keys which can not be unset",
+			func(key, message string) {
+				Expect(unset(key)).To(MatchError(ContainSubstring(message)))
+				Expect(os.ReadFile(filepath.Join(dir, "PROJECT"))).To(Equal([]byte(projectFile)))
+			},
+			Entry("the domain", domainKey, "can not be unset"),
+			Entry("the layout", layoutKey, "can not be changed"),
+			Entry("a plugin", "plugins.helm.kubebuilder.io/v1-alpha", "must address a field"),
+			Entry("a field which is not set", "plugins.helm.kubebuilder.io/v1-alpha.directory", "is not set"),
+			Entry("a plugin without configuration", "plugins.grafana.kubebuilder.io/v1-alpha.force",
+				"has no configuration"),
+		)
+	})
+
+	It("should round-trip the PROJECT file", func() {
+		Expect(set("plugins.helm.kubebuilder.io/v1-alpha.chartDir", "deploy")).To(Succeed())
+		Expect(set("plugins.helm.kubebuilder.io/v1-alpha.chartDir", "dist/chart")).To(Succeed())
+		Expect(unset("plugins.helm.kubebuilder.io/v1-alpha.force")).To(Succeed())
+		Expect(set("plugins.helm.kubebuilder.io/v1-alpha.force", "true")).To(Succeed())
+
+		Expect(os.ReadFile(filepath.Join(dir, "PROJECT"))).To(Equal([]byte(projectFile)))
+
+		content, err := loadConfig().MarshalYAML()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal(projectConfig))
+	})
+})
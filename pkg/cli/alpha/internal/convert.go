@@ -0,0 +1,381 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config/store"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/render"
+	helmrender "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/render"
+	helmv1alphascaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds"
+)
+
+const (
+	// ConvertToHelm converts the kustomize manifests of config/ to the helm chart
+	ConvertToHelm = "helm"
+	// ConvertToKustomize converts the helm chart to the kustomize manifests of config/
+	ConvertToKustomize = "kustomize"
+)
+
+// kustomizeDirs maps the directories of the templates of the chart to the directories of config/
+var kustomizeDirs = map[string]string{
+	"certmanager":    "certmanager",
+	"crd":            filepath.Join("crd", "bases"),
+	"manager":        "manager",
+	"metrics":        "default",
+	"network-policy": "network-policy",
+	"prometheus":     "prometheus",
+	"rbac":           "rbac",
+	"webhook":        "webhook",
+	"webhooks":       "webhook",
+}
+
+// kustomizeFiles maps the templates of the chart whose manifests have another name in config/, including the
+// templates of the charts scaffolded by the previous releases of the helm/v1-alpha plugin
+var kustomizeFiles = map[string]string{
+	path.Join("metrics", "service.yaml"):         "metrics_service.yaml",
+	path.Join("metrics", "metrics-service.yaml"): "metrics_service.yaml",
+	path.Join("webhooks", "webhooks.yaml"):       "manifests.yaml",
+	path.Join("webhook", "webhooks.yaml"):        "manifests.yaml",
+}
+
+// helmLabels are the labels set by the chart on its objects, which kustomize does not set
+var helmLabels = []string{"helm.sh/chart", "app.kubernetes.io/instance", "app.kubernetes.io/version"}
+
+// Convert store the required info for the convert command
+type Convert struct {
+	InputDir string
+	// To is the distribution the project is converted to, ConvertToHelm or ConvertToKustomize
+	To string
+	// Force overwrites the chart or the kustomize manifests which already exist
+	Force bool
+}
+
+// Validate ensures the options are valid.
+func (opts *Convert) Validate() error {
+	var err error
+	opts.InputDir, err = getInputPath(opts.InputDir)
+	if err != nil {
+		return err
+	}
+
+	switch opts.To {
+	case ConvertToHelm, ConvertToKustomize:
+	case "":
+		return fmt.Errorf("--to must be set to %q or %q", ConvertToHelm, ConvertToKustomize)
+	default:
+		return fmt.Errorf("unable to convert the project to %q, it can only be converted to %q or %q",
+			opts.To, ConvertToHelm, ConvertToKustomize)
+	}
+	return nil
+}
+
+// Convert converts the project to the distribution of opts.To and reports the objects which are not converted.
+func (opts *Convert) Convert() error {
+	config, err := loadProjectConfig(opts.InputDir)
+	if err != nil {
+		return err
+	}
+	if err := changeWorkingDirectory(opts.InputDir); err != nil {
+		return err
+	}
+
+	helmCfg, err := helmv1alphascaffolds.LoadPluginConfig(config.Config())
+	if err != nil {
+		return fmt.Errorf("failed to load the Helm plugin configuration: %w", err)
+	}
+	chartDir := helmCfg.ChartDir
+	if chartDir == "" {
+		chartDir = helmrender.DefaultChartDir
+	}
+	projectName := config.Config().GetProjectName()
+
+	if opts.To == ConvertToHelm {
+		if err := opts.convertToHelm(config); err != nil {
+			return err
+		}
+	} else {
+		if err := opts.convertToKustomize(chartDir, projectName); err != nil {
+			return err
+		}
+	}
+	reportUnconvertedObjects(opts.To, chartDir, projectName)
+	return nil
+}
+
+// convertToHelm scaffolds the chart from the kustomize manifests with the helm/v1-alpha plugin.
+func (opts *Convert) convertToHelm(config store.Store) error {
+	if _, err := os.Stat(filepath.Join(render.DefaultKustomization, "kustomization.yaml")); err != nil {
+		return fmt.Errorf("unable to find the kustomize manifests to convert in %s: %w",
+			render.DefaultKustomization, err)
+	}
+
+	var extraArgs []string
+	if opts.Force {
+		extraArgs = append(extraArgs, "--force")
+	}
+	return kubebuilderHelmEdit(config, extraArgs...)
+}
+
+// convertToKustomize writes the objects rendered from the chart with its default values in the layout of config/,
+// along with their kustomizations. The objects whose template has no counterpart in config/, e.g. the helm hooks,
+// are reported and not converted.
+func (opts *Convert) convertToKustomize(chartDir, projectName string) error {
+	chart := filepath.Join(chartDir, "chart")
+	if _, err := os.Stat(filepath.Join(chart, "Chart.yaml")); err != nil {
+		return fmt.Errorf("unable to find the chart to convert in %s: %w", chart, err)
+	}
+	defaultKustomization := filepath.Join(render.DefaultKustomization, "kustomization.yaml")
+	if _, err := os.Stat(defaultKustomization); err == nil && !opts.Force {
+		return fmt.Errorf("the kustomize manifests already exist in %s, use --force to overwrite them",
+			render.DefaultKustomization)
+	}
+
+	namespace := projectName + "-system"
+	objects, err := helmrender.Renderer{
+		ChartDir:    chartDir,
+		ReleaseName: projectName,
+		Namespace:   namespace,
+		APIVersions: helmrender.OptionalAPIVersions,
+	}.TemplateObjects(nil)
+	if err != nil {
+		return fmt.Errorf("failed to render the chart: %w", err)
+	}
+
+	manifests := make(map[string][]string)
+	for _, object := range objects {
+		file, reason := kustomizeFileFor(object)
+		if reason != "" {
+			log.Warnf("The %s %q is not converted: %s", object.Kind, object.Metadata.Name, reason)
+			continue
+		}
+
+		toKustomize(object.Content, projectName, namespace)
+		manifest, err := yaml.Marshal(object.Content)
+		if err != nil {
+			return fmt.Errorf("failed to encode the %s %q: %w", object.Kind, object.Metadata.Name, err)
+		}
+		manifests[file] = append(manifests[file], string(manifest))
+	}
+
+	// The namespace is created by 'helm install --create-namespace', while kustomize deploys it with the manager
+	managerFile := filepath.Join("config", "manager", "manager.yaml")
+	if _, found := manifests[managerFile]; found {
+		manifests[managerFile] = append([]string{fmt.Sprintf(namespaceManifest, projectName)}, manifests[managerFile]...)
+	}
+
+	for file, objects := range manifests {
+		if err := writeManifests(file, objects); err != nil {
+			return err
+		}
+	}
+	return writeKustomizations(manifests, projectName)
+}
+
+// kustomizeFileFor returns the file of config/ where the object is converted, based on the template of the chart
+// it is rendered from, or the reason why it can not be converted.
+func kustomizeFileFor(object helmrender.Object) (string, string) {
+	if hook, ok := object.Field("metadata", "annotations", "helm.sh/hook"); ok {
+		return "", fmt.Sprintf("it is a %v helm hook, which kustomize does not support", hook)
+	}
+
+	template := templateOf(object.Manifest)
+	dir, _, _ := strings.Cut(template, "/")
+	switch configDir, found := kustomizeDirs[dir]; {
+	case dir == "extras":
+		return "", "it is copied from an extra config directory of the project"
+	case !found || strings.Count(template, "/") != 1:
+		return "", fmt.Sprintf("its template %q has no counterpart in config/", template)
+	default:
+		file := path.Base(template)
+		if renamed, found := kustomizeFiles[template]; found {
+			file = renamed
+		}
+		return filepath.Join("config", configDir, file), ""
+	}
+}
+
+// templateOf returns the path of the template of the manifest rendered by helm, relative to the templates
+// directory of the chart, from its '# Source: <chart>/templates/<path>' comment.
+func templateOf(manifest string) string {
+	for _, line := range strings.Split(manifest, "\n") {
+		if source, found := strings.CutPrefix(line, "# Source: "); found {
+			if _, template, found := strings.Cut(source, "/templates/"); found {
+				return template
+			}
+		}
+	}
+	return ""
+}
+
+// toKustomize removes from the object the labels set by helm and replaces the namespace of the release and the
+// project name prefixing the names of the objects, which are set by the namespace and the namePrefix of
+// config/default/kustomization.yaml.
+func toKustomize(value interface{}, projectName, namespace string) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for _, label := range helmLabels {
+			delete(typed, label)
+		}
+		for key, field := range typed {
+			if s, ok := field.(string); ok {
+				switch {
+				case key == "app.kubernetes.io/managed-by" && s == "Helm":
+					typed[key] = "kustomize"
+				case s == namespace:
+					typed[key] = "system"
+				case strings.HasPrefix(s, namespace+"/"):
+					typed[key] = "system/" + strings.TrimPrefix(s, namespace+"/")
+				case (key == "name" || key == "serviceAccountName") && strings.HasPrefix(s, projectName+"-"):
+					typed[key] = strings.TrimPrefix(s, projectName+"-")
+				}
+				continue
+			}
+			toKustomize(field, projectName, namespace)
+		}
+	case []interface{}:
+		for _, item := range typed {
+			toKustomize(item, projectName, namespace)
+		}
+	}
+}
+
+// writeManifests writes the manifests of the objects in the file
+func writeManifests(file string, manifests []string) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return fmt.Errorf("failed to create the directory of %s: %w", file, err)
+	}
+	if err := os.WriteFile(file, []byte(strings.Join(manifests, "---\n")), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	log.Infof("Converted %s", file)
+	return nil
+}
+
+// writeKustomizations writes the kustomization of each directory of config/ with converted manifests, and
+// config/default/kustomization.yaml which deploys them.
+func writeKustomizations(manifests map[string][]string, projectName string) error {
+	resources := make(map[string][]string)
+	for file := range manifests {
+		dir := filepath.Dir(file)
+		// The CRDs are listed by the kustomization of config/crd, as scaffolded by the kustomize plugin
+		if filepath.Base(dir) == "bases" {
+			dir = filepath.Dir(dir)
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		resources[dir] = append(resources[dir], filepath.ToSlash(rel))
+	}
+
+	defaultDir := filepath.Join("config", "default")
+	for dir := range resources {
+		if dir == defaultDir {
+			continue
+		}
+		rel, err := filepath.Rel(defaultDir, dir)
+		if err != nil {
+			return err
+		}
+		resources[defaultDir] = append(resources[defaultDir], filepath.ToSlash(rel))
+	}
+
+	for dir, files := range resources {
+		sort.Strings(files)
+		kustomization := "apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\n"
+		if dir == defaultDir {
+			kustomization += fmt.Sprintf("namespace: %s-system\nnamePrefix: %s-\n", projectName, projectName)
+		}
+		kustomization += "resources:\n- " + strings.Join(files, "\n- ") + "\n"
+
+		file := filepath.Join(dir, "kustomization.yaml")
+		if err := os.WriteFile(file, []byte(kustomization), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// reportUnconvertedObjects warns about the objects deployed by the source distribution which the converted
+// distribution does not deploy, by comparing the manifests built by kustomize with the ones rendered from the
+// chart with its default values.
+func reportUnconvertedObjects(to, chartDir, projectName string) {
+	kustomizeObjects, err := render.Renderer{}.BuildObjects(render.DefaultKustomization)
+	if err != nil {
+		log.Warnf("Unable to compare the converted manifests: %v", err)
+		return
+	}
+	chartObjects, err := helmrender.Renderer{
+		ChartDir:    chartDir,
+		ReleaseName: projectName,
+		Namespace:   projectName + "-system",
+		APIVersions: helmrender.OptionalAPIVersions,
+	}.TemplateObjects(nil)
+	if err != nil {
+		log.Warnf("Unable to compare the converted manifests: %v", err)
+		return
+	}
+
+	converted := make(map[string]bool)
+	source := make([]render.Object, 0, len(kustomizeObjects))
+	if to == ConvertToHelm {
+		for _, object := range chartObjects {
+			converted[object.Kind+"/"+object.Metadata.Name] = true
+		}
+		source = kustomizeObjects
+	} else {
+		for _, object := range kustomizeObjects {
+			converted[object.Kind+"/"+object.Metadata.Name] = true
+		}
+		for _, object := range chartObjects {
+			source = append(source, object.Object)
+		}
+	}
+
+	var unconverted int
+	for _, object := range source {
+		// The namespace is created by 'helm install --create-namespace'
+		if object.Kind == "Namespace" || converted[object.Kind+"/"+object.Metadata.Name] {
+			continue
+		}
+		log.Warnf("The %s %q is not deployed by the converted %s manifests", object.Kind, object.Metadata.Name, to)
+		unconverted++
+	}
+	if unconverted == 0 {
+		log.Infof("All the objects are deployed by the converted %s manifests", to)
+	}
+}
+
+const namespaceManifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  labels:
+    app.kubernetes.io/managed-by: kustomize
+    app.kubernetes.io/name: %s
+    control-plane: controller-manager
+  name: system
+`
@@ -0,0 +1,277 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	helmrender "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/render"
+)
+
+var _ = Describe("Convert", func() {
+	renderedObject := func(manifest string) helmrender.Object {
+		objects, err := helmrender.Objects([]byte(manifest))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(1))
+		return objects[0]
+	}
+
+	DescribeTable("should map the templates of the chart to the files of config/",
+		func(template, expected string) {
+			object := renderedObject("# Source: project/templates/" + template + "\n" +
+				"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: project-config\n")
+			file, reason := kustomizeFileFor(object)
+			Expect(reason).To(BeEmpty())
+			Expect(file).To(Equal(expected))
+		},
+		Entry("the manager", "manager/manager.yaml", filepath.Join("config", "manager", "manager.yaml")),
+		Entry("a CRD", "crd/crew.example.org_captains.yaml",
+			filepath.Join("config", "crd", "bases", "crew.example.org_captains.yaml")),
+		Entry("the metrics service", "metrics/service.yaml", filepath.Join("config", "default", "metrics_service.yaml")),
+		Entry("the metrics service of the previous charts", "metrics/metrics-service.yaml",
+			filepath.Join("config", "default", "metrics_service.yaml")),
+		Entry("the webhooks", "webhook/webhooks.yaml", filepath.Join("config", "webhook", "manifests.yaml")),
+		Entry("the webhooks of the previous charts", "webhooks/webhooks.yaml",
+			filepath.Join("config", "webhook", "manifests.yaml")),
+		Entry("a role", "rbac/leader_election_role.yaml", filepath.Join("config", "rbac", "leader_election_role.yaml")),
+	)
+
+	DescribeTable("should not convert the objects without counterpart in config/",
+		func(manifest, reason string) {
+			file, notConverted := kustomizeFileFor(renderedObject(manifest))
+			Expect(file).To(BeEmpty())
+			Expect(notConverted).To(ContainSubstring(reason))
+		},
+		Entry("a helm hook", `# Source: project/templates/manager/upgrade.yaml
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: project-upgrade
+  annotations:
+    helm.sh/hook: pre-upgrade
+`, "helm hook"),
+		Entry("an extra config directory", `# Source: project/templates/extras/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: project-extra
+`, "extra config directory"),
+		Entry("an unknown directory", `# Source: project/templates/grafana/dashboard.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: project-dashboard
+`, `"grafana/dashboard.yaml" has no counterpart`),
+		Entry("a nested template", `# Source: project/templates/rbac/helper/role.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: project-role
+`, `"rbac/helper/role.yaml" has no counterpart`),
+		Entry("a manifest without source", `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: project-config
+`, `"" has no counterpart`),
+	)
+
+	It("should replace the release of the objects with the kustomize prefix and namespace", func() {
+		object := renderedObject(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: project-controller-manager
+  namespace: project-system
+  annotations:
+    cert-manager.io/inject-ca-from: project-system/project-serving-cert
+  labels:
+    app.kubernetes.io/managed-by: Helm
+    app.kubernetes.io/instance: project
+    app.kubernetes.io/version: 0.1.0
+    helm.sh/chart: project-0.1.0
+    app.kubernetes.io/name: project
+spec:
+  template:
+    spec:
+      serviceAccountName: project-controller-manager
+      containers:
+      - name: manager
+        image: controller:latest
+`)
+		toKustomize(object.Content, "project", "project-system")
+
+		expected := renderedObject(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller-manager
+  namespace: system
+  annotations:
+    cert-manager.io/inject-ca-from: system/project-serving-cert
+  labels:
+    app.kubernetes.io/managed-by: kustomize
+    app.kubernetes.io/name: project
+spec:
+  template:
+    spec:
+      serviceAccountName: controller-manager
+      containers:
+      - name: manager
+        image: controller:latest
+`)
+		Expect(object.Content).To(Equal(expected.Content))
+	})
+
+	Context("Convert", func() {
+		const chart = `---
+# Source: project/templates/rbac/service_account.yaml
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: project-controller-manager
+  namespace: project-system
+  labels:
+    app.kubernetes.io/managed-by: Helm
+    helm.sh/chart: project-0.1.0
+---
+# Source: project/templates/manager/manager.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: project-controller-manager
+  namespace: project-system
+spec:
+  template:
+    spec:
+      serviceAccountName: project-controller-manager
+---
+# Source: project/templates/metrics/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: project-controller-manager-metrics-service
+  namespace: project-system
+---
+# Source: project/templates/manager/upgrade.yaml
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: project-upgrade
+  namespace: project-system
+  annotations:
+    helm.sh/hook: pre-upgrade
+`
+
+		var dir string
+
+		// writeTemplates writes the manifests of the chart as its templates, along with the given templates
+		writeTemplates := func(templates map[string]string) {
+			chartPath := filepath.Join(dir, "dist", "chart", "chart")
+			for _, manifest := range strings.Split(chart, "---\n")[1:] {
+				source, content, _ := strings.Cut(manifest, "\n")
+				templates[strings.TrimPrefix(source, "# Source: project/")] = content
+			}
+			for name, content := range templates {
+				file := filepath.Join(chartPath, filepath.FromSlash(name))
+				Expect(os.MkdirAll(filepath.Dir(file), 0o755)).To(Succeed())
+				Expect(os.WriteFile(file, []byte(content), 0o644)).To(Succeed())
+			}
+		}
+
+		convert := func(to string, force bool) error {
+			opts := Convert{InputDir: dir, To: to, Force: force}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			return opts.Convert()
+		}
+
+		BeforeEach(func() {
+			wd, err := os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(os.Chdir, wd)
+
+			dir = GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(dir, "PROJECT"), []byte(projectFile), 0o644)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(dir, "dist", "chart", "chart"), 0o755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "dist", "chart", "chart", "Chart.yaml"),
+				[]byte("apiVersion: v2\nname: project\nversion: 0.1.0\n"), 0o644)).To(Succeed())
+		})
+
+		DescribeTable("should reject invalid distributions",
+			func(to, message string) {
+				Expect(convert(to, false)).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("without distribution", "", "--to must be set"),
+			Entry("an unknown distribution", "olm", `unable to convert the project to "olm"`),
+		)
+
+		It("should convert the chart to the kustomize manifests", func() {
+			writeTemplates(map[string]string{})
+			Expect(convert(ConvertToKustomize, false)).To(Succeed())
+
+			Expect(os.ReadFile(filepath.Join(dir, "config", "rbac", "service_account.yaml"))).To(BeEquivalentTo(
+				"apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  labels:\n" +
+					"    app.kubernetes.io/managed-by: kustomize\n  name: controller-manager\n  namespace: system\n"))
+			Expect(os.ReadFile(filepath.Join(dir, "config", "manager", "manager.yaml"))).To(
+				And(HavePrefix("apiVersion: v1\nkind: Namespace\n"), ContainSubstring("serviceAccountName: controller-manager")))
+			Expect(filepath.Join(dir, "config", "default", "metrics_service.yaml")).To(BeAnExistingFile())
+			Expect(filepath.Join(dir, "config", "manager", "upgrade.yaml")).NotTo(BeAnExistingFile())
+
+			Expect(os.ReadFile(filepath.Join(dir, "config", "default", "kustomization.yaml"))).To(BeEquivalentTo(
+				"apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\n" +
+					"namespace: project-system\nnamePrefix: project-\n" +
+					"resources:\n- ../manager\n- ../rbac\n- metrics_service.yaml\n"))
+			Expect(os.ReadFile(filepath.Join(dir, "config", "rbac", "kustomization.yaml"))).To(BeEquivalentTo(
+				"apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\n" +
+					"resources:\n- service_account.yaml\n"))
+		})
+
+		It("should fail when the chart can not be rendered", func() {
+			writeTemplates(map[string]string{"templates/manager/config.yaml": "{{ foo }}\n"})
+
+			err := convert(ConvertToKustomize, false)
+			Expect(err).To(MatchError(ContainSubstring("failed to render the chart")))
+			Expect(err).To(MatchError(ContainSubstring(`function "foo" not defined`)))
+			Expect(filepath.Join(dir, "config")).NotTo(BeADirectory())
+		})
+
+		It("should fail when the chart can not be found", func() {
+			writeTemplates(map[string]string{})
+			Expect(os.RemoveAll(filepath.Join(dir, "dist"))).To(Succeed())
+			Expect(convert(ConvertToKustomize, false)).To(MatchError(ContainSubstring("unable to find the chart")))
+		})
+
+		It("should not overwrite the kustomize manifests without force", func() {
+			writeTemplates(map[string]string{})
+			Expect(os.MkdirAll(filepath.Join(dir, "config", "default"), 0o755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "config", "default", "kustomization.yaml"),
+				[]byte("resources: []\n"), 0o644)).To(Succeed())
+
+			Expect(convert(ConvertToKustomize, false)).To(MatchError(ContainSubstring("use --force")))
+			Expect(convert(ConvertToKustomize, true)).To(Succeed())
+		})
+
+		It("should fail to convert to helm without kustomize manifests", func() {
+			Expect(convert(ConvertToHelm, false)).To(MatchError(ContainSubstring(
+				"unable to find the kustomize manifests to convert")))
+		})
+	})
+})
@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	autoupdatev1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/autoupdate/v1alpha"
+)
+
+// DefaultDiffExcludes are the paths which are never part of the scaffold and are ignored by the diff
+var DefaultDiffExcludes = []string{".git", "bin", "vendor", "cover.out"}
+
+// Diff store the required info for the command
+type Diff struct {
+	InputDir string
+	// FromVersion is the release of Kubebuilder used to scaffold the project, e.g. v4.5.0. The version
+	// recorded in the PROJECT file is used when empty, and the running binary when none is recorded
+	FromVersion string
+	// Excludes are the file or directory names ignored when comparing the project with the scaffold
+	Excludes []string
+	// NameOnly reports only the files which differ instead of their content
+	NameOnly bool
+	// Keep preserves the directory with the pristine scaffold so that it can be inspected
+	Keep bool
+	// PluginReplacements maps the keys of the deprecated plugins to the keys of the plugins replacing them
+	PluginReplacements map[string]string
+
+	// download downloads a release of Kubebuilder into a directory and returns it, downloadKubebuilder
+	// is used when nil
+	download func(version, dir string) (string, error)
+}
+
+// Validate ensures the options are valid and the required binaries are installed.
+func (opts *Diff) Validate() error {
+	var err error
+	opts.InputDir, err = getInputPath(opts.InputDir)
+	if err != nil {
+		return err
+	}
+
+	if opts.FromVersion != "" && !strings.HasPrefix(opts.FromVersion, "v") {
+		opts.FromVersion = "v" + opts.FromVersion
+	}
+
+	if _, err = exec.LookPath("diff"); err != nil {
+		return fmt.Errorf("diff not found in the path: %w", err)
+	}
+
+	return nil
+}
+
+// Diff re-scaffolds the project in a temporary directory with the plugins recorded in its PROJECT file
+// and shows the differences between the project and this pristine scaffold.
+// The project is re-scaffolded with the release of Kubebuilder it was scaffolded with, so that the changes
+// of the scaffold in the later releases are not reported as changes of the project. When this release is
+// unknown, the running binary is used.
+// It returns true when the project has drifted from the scaffold.
+func (opts *Diff) Diff() (bool, error) {
+	scaffoldDir, err := os.MkdirTemp("", "kubebuilder-diff-")
+	if err != nil {
+		return false, fmt.Errorf("failed to create the temporary directory: %w", err)
+	}
+	if opts.Keep {
+		log.Infof("The pristine scaffold is kept in %s", scaffoldDir)
+	} else {
+		defer func() {
+			if err := os.RemoveAll(scaffoldDir); err != nil {
+				log.Warnf("Unable to remove the temporary directory %s: %v", scaffoldDir, err)
+			}
+		}()
+	}
+
+	version := opts.FromVersion
+	if version == "" {
+		if version, err = recordedCliVersion(opts.InputDir); err != nil {
+			return false, err
+		}
+	}
+	if version == "" {
+		log.Warnf("The Kubebuilder release which scaffolded the project is not recorded in the PROJECT file, " +
+			"so the project is compared with the scaffold of the running binary. The changes of the scaffold " +
+			"since this release are reported as well, use --from-version to set it")
+		err = opts.generate(scaffoldDir)
+	} else {
+		err = opts.generateWith(version, scaffoldDir)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to scaffold the project in %s: %w", scaffoldDir, err)
+	}
+
+	return runDiff(scaffoldDir, opts.InputDir, opts.Excludes, opts.NameOnly)
+}
+
+// generate re-scaffolds the project into dir with the running binary.
+func (opts *Diff) generate(dir string) error {
+	if _, err := exec.LookPath("kubebuilder"); err != nil {
+		return fmt.Errorf("kubebuilder not found in the path: %w", err)
+	}
+
+	// The generation changes the working directory, so it must be restored afterwards
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	generate := Generate{InputDir: opts.InputDir, OutputDir: dir, PluginReplacements: opts.PluginReplacements}
+	generateErr := generate.Generate()
+	if err := changeWorkingDirectory(cwd); err != nil {
+		return err
+	}
+	return generateErr
+}
+
+// generateWith re-scaffolds the project into dir with the given release of Kubebuilder.
+func (opts *Diff) generateWith(version, dir string) error {
+	download := opts.download
+	if download == nil {
+		download = downloadKubebuilder
+	}
+	tmpDir, err := os.MkdirTemp("", "kubebuilder-diff-bin-")
+	if err != nil {
+		return fmt.Errorf("failed to create the temporary directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			log.Warnf("Unable to remove the temporary directory %s: %v", tmpDir, err)
+		}
+	}()
+
+	binDir, err := download(version, tmpDir)
+	if err != nil {
+		return err
+	}
+	return regenerate(opts.InputDir, dir, binDir)
+}
+
+// recordedCliVersion returns the release of Kubebuilder which last scaffolded the project, as recorded
+// by the autoupdate plugin in the PROJECT file, or an empty string when it is not recorded.
+func recordedCliVersion(inputDir string) (string, error) {
+	projectStore, err := loadProjectConfig(inputDir)
+	if err != nil {
+		return "", err
+	}
+
+	pluginConfig := struct {
+		CliVersion string `json:"cliVersion,omitempty"`
+	}{}
+	err = projectStore.Config().DecodePluginConfig(plugin.KeyFor(autoupdatev1alpha.Plugin{}), &pluginConfig)
+	if err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) &&
+		!errors.As(err, &config.UnsupportedFieldError{}) {
+		return "", fmt.Errorf("failed to decode the autoupdate plugin config: %w", err)
+	}
+	return pluginConfig.CliVersion, nil
+}
+
+// runDiff compares the pristine scaffold with the project, writing the differences to the standard output.
+func runDiff(scaffoldDir, projectDir string, excludes []string, nameOnly bool) (bool, error) {
+	args := []string{"-ruN"}
+	if nameOnly {
+		args = []string{"-rqN"}
+	}
+	for _, exclude := range excludes {
+		args = append(args, "--exclude", exclude)
+	}
+	args = append(args, scaffoldDir, projectDir)
+
+	cmd := exec.Command("diff", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	if err == nil {
+		return false, nil
+	}
+
+	// diff exits with 1 when the directories differ and with 2 when it failed
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return true, nil
+	}
+	return false, fmt.Errorf("failed to compare %s with %s: %w", projectDir, scaffoldDir, err)
+}
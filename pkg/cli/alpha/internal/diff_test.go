@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	var (
+		dir, pristine string
+		opts          Diff
+		versions      []string
+	)
+
+	// release downloads a fake release whose alpha generate copies the pristine scaffold of the project
+	release := func(version, binDir string) (string, error) {
+		versions = append(versions, version)
+		script := "#!/bin/sh\ntest \"$1 $2\" = \"alpha generate\" || exit 1\ncp -R " + pristine + "/. \"$6\"\n"
+		return binDir, os.WriteFile(filepath.Join(binDir, "kubebuilder"), []byte(script), 0o755)
+	}
+
+	diff := func() (bool, string) {
+		var drifted bool
+		output := captureStdout(func() {
+			var err error
+			drifted, err = opts.Diff()
+			Expect(err).NotTo(HaveOccurred())
+		})
+		return drifted, output
+	}
+
+	BeforeEach(func() {
+		if _, err := exec.LookPath("diff"); err != nil {
+			Skip("diff is required to compare the project with its scaffold")
+		}
+
+		dir = GinkgoT().TempDir()
+		scaffoldProject(dir)
+		pristine = GinkgoT().TempDir()
+		Expect(exec.Command("cp", "-R", dir+"/.", pristine).Run()).To(Succeed())
+
+		versions = nil
+		opts = Diff{InputDir: dir, FromVersion: "4.5.0", Excludes: DefaultDiffExcludes, download: release}
+		Expect(opts.Validate()).To(Succeed())
+		Expect(opts.FromVersion).To(Equal("v4.5.0"))
+	})
+
+	It("should not report any drift for a pristine project", func() {
+		drifted, output := diff()
+		Expect(drifted).To(BeFalse())
+		Expect(output).To(BeEmpty())
+		Expect(versions).To(Equal([]string{"v4.5.0"}))
+	})
+
+	It("should report the changes made on top of the scaffold", func() {
+		main := filepath.Join(dir, "cmd", "main.go")
+		content, err := os.ReadFile(main)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(main, append(content, []byte("\n// setupProbes adds the probes\n")...), 0o644)).
+			To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "config", "samples", "notes.yaml"), []byte("notes: []\n"), 0o644)).
+			To(Succeed())
+		// The files ignored by the diff
+		Expect(os.MkdirAll(filepath.Join(dir, "bin"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "bin", "manager"), []byte("binary"), 0o755)).To(Succeed())
+
+		drifted, output := diff()
+		Expect(drifted).To(BeTrue())
+		Expect(output).To(And(
+			ContainSubstring("+// setupProbes adds the probes"),
+			ContainSubstring("+notes: []"),
+			Not(ContainSubstring("binary")),
+		))
+
+		opts.NameOnly = true
+		drifted, output = diff()
+		Expect(drifted).To(BeTrue())
+		Expect(output).To(And(
+			ContainSubstring(filepath.Join(dir, "cmd", "main.go")+" differ"),
+			ContainSubstring(filepath.Join(dir, "config", "samples", "notes.yaml")+" differ"),
+			Not(ContainSubstring("bin")),
+		))
+	})
+
+	It("should re-scaffold the project with the version recorded in the PROJECT file", func() {
+		project := filepath.Join(dir, "PROJECT")
+		content, err := os.ReadFile(project)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(project, append(content,
+			[]byte("plugins:\n  autoupdate.kubebuilder.io/v1-alpha:\n    cliVersion: v4.6.0\n")...), 0o644)).To(Succeed())
+		Expect(exec.Command("cp", project, pristine).Run()).To(Succeed())
+
+		opts.FromVersion = ""
+		drifted, _ := diff()
+		Expect(drifted).To(BeFalse())
+		Expect(versions).To(Equal([]string{"v4.6.0"}))
+	})
+
+	It("should fail when the release can not be downloaded", func() {
+		opts.download = func(string, string) (string, error) { return "", errors.New("release not found") }
+		_, err := opts.Diff()
+		Expect(err).To(MatchError(ContainSubstring("release not found")))
+	})
+})
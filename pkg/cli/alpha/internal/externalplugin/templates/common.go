@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package templates provides the templates of the skeletons of the external plugins scaffolded by
+// `kubebuilder alpha scaffold-external-plugin`.
+package templates
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/external"
+)
+
+// PluginMixin provides the fields shared by the templates of the skeletons
+type PluginMixin struct {
+	// PluginName is the name of the plugin, e.g. sampleplugin for the plugin key sampleplugin/v1
+	PluginName string
+	// Force if true allows overwriting the scaffolded files
+	Force bool
+}
+
+// ifExistsAction returns the action of the templates when their file already exists
+func (m PluginMixin) ifExistsAction() machinery.IfExistsAction {
+	if m.Force {
+		return machinery.OverwriteFile
+	}
+	return machinery.Error
+}
+
+var _ machinery.Template = &Readme{}
+
+// Readme scaffolds the README of the skeleton, describing how to build, test and install the plugin
+type Readme struct {
+	machinery.TemplateMixin
+	PluginMixin
+
+	// Language is the language of the plugin, go, python or node
+	Language string
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *Readme) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = "README.md"
+	}
+
+	f.TemplateBody = readmeTemplate
+
+	f.IfExistsAction = f.ifExistsAction()
+
+	return nil
+}
+
+const readmeTemplate = `# {{ .PluginName }}
+
+An external plugin of [Kubebuilder](https://book.kubebuilder.io/plugins/extending/external-plugins) written
+in {{ if eq .Language "go" }}Go
+{{- else if eq .Language "python" }}Python
+{{- else }}NodeJS
+{{- end }}. Kubebuilder writes a PluginRequest in JSON to the stdin of the plugin and reads its
+PluginResponse in JSON from its stdout, as described by the JSON schemas of the ` + "`schemas`" + ` directory.
+
+## Testing
+
+` + "```shell" + `
+{{- if eq .Language "go" }}
+go test ./...
+{{- else if eq .Language "python" }}
+python3 -m unittest
+{{- else }}
+npm test
+{{- end }}
+` + "```" + `
+
+## Installing
+
+Kubebuilder looks for the plugin ` + "`{{ .PluginName }}/v1`" + ` in
+` + "`$EXTERNAL_PLUGINS_PATH/{{ .PluginName }}/v1/{{ .PluginName }}`" + `, or by default in
+` + "`$HOME/.config/kubebuilder/plugins/{{ .PluginName }}/v1/{{ .PluginName }}`" + ` on Linux and
+` + "`$HOME/Library/Application Support/kubebuilder/plugins/{{ .PluginName }}/v1/{{ .PluginName }}`" + ` on macOS:
+
+` + "```shell" + `
+mkdir -p "$HOME/.config/kubebuilder/plugins/{{ .PluginName }}/v1"
+{{- if eq .Language "go" }}
+go build -o "$HOME/.config/kubebuilder/plugins/{{ .PluginName }}/v1/{{ .PluginName }}" .
+{{- else if eq .Language "python" }}
+install -m 755 plugin.py "$HOME/.config/kubebuilder/plugins/{{ .PluginName }}/v1/{{ .PluginName }}"
+{{- else }}
+install -m 755 plugin.js "$HOME/.config/kubebuilder/plugins/{{ .PluginName }}/v1/{{ .PluginName }}"
+{{- end }}
+` + "```" + `
+
+## Using
+
+` + "```shell" + `
+kubebuilder init --plugins go/v4,{{ .PluginName }}/v1 --domain my.domain --greeting Hi
+kubebuilder create api --plugins go/v4,{{ .PluginName }}/v1 --group crew --version v1 --kind Captain
+` + "```" + `
+
+Write nothing else than the response to stdout: logs must be written to stderr or to a file.
+`
+
+var _ machinery.Template = &Schema{}
+
+// Schema scaffolds the JSON schema of the PluginRequest or of the PluginResponse
+type Schema struct {
+	machinery.TemplateMixin
+	PluginMixin
+
+	// Response if true scaffolds the schema of the PluginResponse instead of the PluginRequest
+	Response bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *Schema) SetTemplateDefaults() error {
+	name, schema := "plugin-request", external.PluginRequestSchema()
+	if f.Response {
+		name, schema = "plugin-response", external.PluginResponseSchema()
+	}
+	if f.Path == "" {
+		f.Path = filepath.Join("schemas", fmt.Sprintf("%s.%s.schema.json", name, external.SchemaVersion))
+	}
+
+	// The schema is copied verbatim
+	f.TemplateBody = string(schema)
+	f.SetDelim("[[%", "%]]")
+
+	f.IfExistsAction = f.ifExistsAction()
+
+	return nil
+}
@@ -0,0 +1,405 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &GoMod{}
+
+// GoMod scaffolds the go.mod of the skeleton in Go, which has no dependencies
+type GoMod struct {
+	machinery.TemplateMixin
+	PluginMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *GoMod) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = "go.mod"
+	}
+
+	f.TemplateBody = goModTemplate
+
+	f.IfExistsAction = f.ifExistsAction()
+
+	return nil
+}
+
+const goModTemplate = `module {{ .PluginName }}
+
+go 1.23.0
+`
+
+var _ machinery.Template = &GoMain{}
+
+// GoMain scaffolds the main.go of the skeleton in Go, which answers the request read from stdin
+type GoMain struct {
+	machinery.TemplateMixin
+	PluginMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *GoMain) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = "main.go"
+	}
+
+	f.TemplateBody = goMainTemplate
+
+	f.IfExistsAction = f.ifExistsAction()
+
+	return nil
+}
+
+const goMainTemplate = `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// main reads the PluginRequest from stdin and writes the PluginResponse to stdout. Nothing else must be
+// written to stdout, the logs can be written to stderr.
+func main() {
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to read the request: %v\n", err)
+		os.Exit(1)
+	}
+
+	var request PluginRequest
+	var response PluginResponse
+	if err := json.Unmarshal(input, &request); err != nil {
+		response = errorResponse(request, fmt.Errorf("unable to decode the request: %w", err))
+	} else {
+		response = Handle(request)
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(response); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to encode the response: %v\n", err)
+		os.Exit(1)
+	}
+}
+`
+
+var _ machinery.Template = &GoPlugin{}
+
+// GoPlugin scaffolds the plugin.go of the skeleton in Go, which defines the types of the protocol, the
+// parsing helpers and the subcommands of the plugin
+type GoPlugin struct {
+	machinery.TemplateMixin
+	PluginMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *GoPlugin) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = "plugin.go"
+	}
+
+	f.TemplateBody = goPluginTemplate
+
+	f.IfExistsAction = f.ifExistsAction()
+
+	return nil
+}
+
+const goPluginTemplate = `package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// apiVersion is the version of the protocol implemented by the plugin
+	apiVersion = "v1alpha1"
+	// pluginName is the name of the plugin
+	pluginName = "{{ .PluginName }}"
+)
+
+// PluginRequest is the request written by Kubebuilder to stdin, see schemas/plugin-request.v1alpha1.schema.json
+type PluginRequest struct {
+	APIVersion string            ` + "`json:\"apiVersion\"`" + `
+	Args       []string          ` + "`json:\"args\"`" + `
+	Command    string            ` + "`json:\"command\"`" + `
+	Universe   map[string]string ` + "`json:\"universe\"`" + `
+}
+
+// PluginResponse is the response written to stdout, see schemas/plugin-response.v1alpha1.schema.json
+type PluginResponse struct {
+	APIVersion string            ` + "`json:\"apiVersion\"`" + `
+	Command    string            ` + "`json:\"command\"`" + `
+	Metadata   Metadata          ` + "`json:\"metadata\"`" + `
+	Universe   map[string]string ` + "`json:\"universe\"`" + `
+	Error      bool              ` + "`json:\"error,omitempty\"`" + `
+	ErrorMsgs  []string          ` + "`json:\"errorMsgs,omitempty\"`" + `
+	Flags      []Flag            ` + "`json:\"flags,omitempty\"`" + `
+}
+
+// Metadata is the help of a subcommand
+type Metadata struct {
+	Description string ` + "`json:\"description,omitempty\"`" + `
+	Examples    string ` + "`json:\"examples,omitempty\"`" + `
+}
+
+// Flag is a flag of a subcommand
+type Flag struct {
+	Name    string ` + "`json:\"name\"`" + `
+	Type    string ` + "`json:\"type,omitempty\"`" + `
+	Default string ` + "`json:\"default,omitempty\"`" + `
+	Usage   string ` + "`json:\"usage,omitempty\"`" + `
+}
+
+// flags are the flags of the subcommands, by the argument of the flags and metadata commands
+var flags = map[string][]Flag{
+	"init": {
+		{
+			Name:    "greeting",
+			Type:    "string",
+			Default: "Hello",
+			Usage:   "greeting written in sample.txt",
+		},
+	},
+}
+
+// metadata is the help of the subcommands, by the argument of the flags and metadata commands
+var metadata = map[string]Metadata{
+	"init": {
+		Description: "Scaffolds sample.txt with a greeting.",
+		Examples:    "kubebuilder init --plugins go/v4," + pluginName + "/v1 --greeting Hi",
+	},
+	"api": {
+		Description: "Scaffolds sample_<kind>.txt for the API.",
+		Examples:    "kubebuilder create api --plugins go/v4," + pluginName + "/v1 --group crew --version v1 --kind Captain",
+	},
+}
+
+// Handle returns the response to the request
+func Handle(request PluginRequest) PluginResponse {
+	response := PluginResponse{
+		APIVersion: apiVersion,
+		Command:    request.Command,
+		Universe:   request.Universe,
+	}
+	if response.Universe == nil {
+		response.Universe = map[string]string{}
+	}
+
+	var err error
+	switch request.Command {
+	case "flags":
+		response.Flags, err = subcommandFlags(request.Args)
+	case "metadata":
+		response.Metadata, err = subcommandMetadata(request.Args)
+	case "init":
+		err = scaffoldInit(ParseArgs(request.Args), response.Universe)
+	case "create api":
+		err = scaffoldAPI(ParseArgs(request.Args), response.Universe)
+	case "create webhook", "edit":
+		// TODO: scaffold the files of the subcommand in response.Universe
+	default:
+		err = fmt.Errorf("unknown command %q", request.Command)
+	}
+	if err != nil {
+		return errorResponse(request, err)
+	}
+	return response
+}
+
+// errorResponse returns the response reporting the error
+func errorResponse(request PluginRequest, err error) PluginResponse {
+	return PluginResponse{
+		APIVersion: apiVersion,
+		Command:    request.Command,
+		Universe:   request.Universe,
+		Error:      true,
+		ErrorMsgs:  []string{err.Error()},
+	}
+}
+
+// ParseArgs returns the values of the flags of the arguments, in the forms --name=value, --name value
+// and --name for boolean flags, whose value is "true"
+func ParseArgs(args []string) map[string]string {
+	values := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "--") {
+			continue
+		}
+		name, value, found := strings.Cut(strings.TrimPrefix(args[i], "--"), "=")
+		if !found {
+			value = "true"
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+				i++
+				value = args[i]
+			}
+		}
+		values[name] = value
+	}
+	return values
+}
+
+// subcommand returns the subcommand of the flags and metadata commands, e.g. init for --init
+func subcommand(args []string) (string, error) {
+	if len(args) != 1 || !strings.HasPrefix(args[0], "--") {
+		return "", fmt.Errorf("expected the subcommand as the only argument, got %q", args)
+	}
+	return strings.TrimPrefix(args[0], "--"), nil
+}
+
+// subcommandFlags returns the flags of the subcommand
+func subcommandFlags(args []string) ([]Flag, error) {
+	name, err := subcommand(args)
+	if err != nil {
+		return nil, err
+	}
+	return flags[name], nil
+}
+
+// subcommandMetadata returns the help of the subcommand
+func subcommandMetadata(args []string) (Metadata, error) {
+	name, err := subcommand(args)
+	if err != nil {
+		return Metadata{}, err
+	}
+	return metadata[name], nil
+}
+
+// scaffoldInit scaffolds the files of the init subcommand
+func scaffoldInit(args map[string]string, universe map[string]string) error {
+	greeting := args["greeting"]
+	if greeting == "" {
+		greeting = "Hello"
+	}
+	universe["sample.txt"] = fmt.Sprintf("%s from %s\n", greeting, pluginName)
+	return nil
+}
+
+// scaffoldAPI scaffolds the files of the create api subcommand
+func scaffoldAPI(args map[string]string, universe map[string]string) error {
+	kind := args["kind"]
+	if kind == "" {
+		return errors.New("--kind is required")
+	}
+	universe["sample_"+strings.ToLower(kind)+".txt"] = fmt.Sprintf("%s/%s %s\n", args["group"], args["version"], kind)
+	return nil
+}
+`
+
+var _ machinery.Template = &GoPluginTest{}
+
+// GoPluginTest scaffolds the tests of the skeleton in Go
+type GoPluginTest struct {
+	machinery.TemplateMixin
+	PluginMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *GoPluginTest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = "plugin_test.go"
+	}
+
+	f.TemplateBody = goPluginTestTemplate
+
+	f.IfExistsAction = f.ifExistsAction()
+
+	return nil
+}
+
+const goPluginTestTemplate = `package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	got := ParseArgs([]string{"--force", "--domain", "my.domain", "--kind=Captain"})
+	want := map[string]string{"domain": "my.domain", "kind": "Captain", "force": "true"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestFlags(t *testing.T) {
+	initArgs := []string{"--init"}
+	response := Handle(PluginRequest{APIVersion: apiVersion, Command: "flags", Args: initArgs})
+	if response.Error || len(response.Flags) != 1 || response.Flags[0].Name != "greeting" {
+		t.Errorf("unexpected flags response %+v", response)
+	}
+
+	response = Handle(PluginRequest{APIVersion: apiVersion, Command: "flags"})
+	if !response.Error {
+		t.Errorf("expected an error without subcommand, got %+v", response)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	initArgs := []string{"--init"}
+	response := Handle(PluginRequest{APIVersion: apiVersion, Command: "metadata", Args: initArgs})
+	if response.Error || response.Metadata.Description == "" {
+		t.Errorf("unexpected metadata response %+v", response)
+	}
+}
+
+func TestInit(t *testing.T) {
+	response := Handle(PluginRequest{
+		APIVersion: apiVersion,
+		Command:    "init",
+		Args:       []string{"--greeting", "Hi"},
+		Universe:   map[string]string{"PROJECT": "version: \"3\"\n"},
+	})
+	if response.Error {
+		t.Fatalf("unexpected error %v", response.ErrorMsgs)
+	}
+	if got := response.Universe["sample.txt"]; got != "Hi from "+pluginName+"\n" {
+		t.Errorf("unexpected sample.txt %q", got)
+	}
+	if _, found := response.Universe["PROJECT"]; !found {
+		t.Error("the files of the request must be kept")
+	}
+}
+
+func TestCreateAPI(t *testing.T) {
+	response := Handle(PluginRequest{
+		APIVersion: apiVersion,
+		Command:    "create api",
+		Args:       []string{"--group", "crew", "--version", "v1", "--kind", "Captain"},
+	})
+	if response.Error || response.Universe["sample_captain.txt"] != "crew/v1 Captain\n" {
+		t.Errorf("unexpected create api response %+v", response)
+	}
+
+	response = Handle(PluginRequest{APIVersion: apiVersion, Command: "create api"})
+	if !response.Error {
+		t.Errorf("expected an error without --kind, got %+v", response)
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	response := Handle(PluginRequest{APIVersion: apiVersion, Command: "unknown"})
+	if !response.Error || len(response.ErrorMsgs) != 1 {
+		t.Errorf("expected an error, got %+v", response)
+	}
+}
+`
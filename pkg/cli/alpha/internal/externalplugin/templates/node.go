@@ -0,0 +1,317 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &NodePackage{}
+
+// NodePackage scaffolds the package.json of the skeleton in NodeJS, which has no dependencies
+type NodePackage struct {
+	machinery.TemplateMixin
+	PluginMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *NodePackage) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = "package.json"
+	}
+
+	f.TemplateBody = nodePackageTemplate
+
+	f.IfExistsAction = f.ifExistsAction()
+
+	return nil
+}
+
+const nodePackageTemplate = `{
+  "name": "{{ .PluginName }}",
+  "version": "0.1.0",
+  "description": "The {{ .PluginName }} external plugin of Kubebuilder",
+  "main": "plugin.js",
+  "bin": {
+    "{{ .PluginName }}": "plugin.js"
+  },
+  "scripts": {
+    "test": "node --test"
+  },
+  "engines": {
+    "node": ">=18"
+  }
+}
+`
+
+var _ machinery.Template = &NodePlugin{}
+
+// NodePlugin scaffolds the executable of the skeleton in NodeJS, which defines the parsing helpers and
+// the subcommands of the plugin
+type NodePlugin struct {
+	machinery.TemplateMixin
+	PluginMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *NodePlugin) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = "plugin.js"
+	}
+
+	f.TemplateBody = nodePluginTemplate
+	f.FilePermissions = machinery.ExecutableFilePermission
+
+	f.IfExistsAction = f.ifExistsAction()
+
+	return nil
+}
+
+//nolint:lll
+const nodePluginTemplate = `#!/usr/bin/env node
+// The {{ .PluginName }} external plugin of Kubebuilder.
+//
+// Kubebuilder writes a PluginRequest in JSON to stdin and reads the PluginResponse in JSON from stdout,
+// see the schemas directory. Nothing else must be written to stdout, the logs can be written to stderr.
+'use strict';
+
+// The version of the protocol implemented by the plugin
+const API_VERSION = 'v1alpha1';
+// The name of the plugin
+const PLUGIN_NAME = '{{ .PluginName }}';
+
+// The flags of the subcommands, by the argument of the flags and metadata commands
+const FLAGS = {
+  init: [
+    {
+      name: 'greeting',
+      type: 'string',
+      default: 'Hello',
+      usage: 'greeting written in sample.txt',
+    },
+  ],
+};
+
+// The help of the subcommands, by the argument of the flags and metadata commands
+const METADATA = {
+  init: {
+    description: 'Scaffolds sample.txt with a greeting.',
+    examples: ` + "`kubebuilder init --plugins go/v4,${PLUGIN_NAME}/v1 --greeting Hi`" + `,
+  },
+  api: {
+    description: 'Scaffolds sample_<kind>.txt for the API.',
+    examples: ` + "`kubebuilder create api --plugins go/v4,${PLUGIN_NAME}/v1 --group crew --version v1 --kind Captain`" + `,
+  },
+};
+
+// An error reported to Kubebuilder in the response
+class PluginError extends Error {}
+
+// Returns the values of the flags of the arguments, in the forms --name=value, --name value and --name for
+// boolean flags, whose value is "true"
+function parseArgs(args) {
+  const values = {};
+  for (let i = 0; i < args.length; i++) {
+    if (!args[i].startsWith('--')) {
+      continue;
+    }
+    const arg = args[i].slice(2);
+    const separator = arg.indexOf('=');
+    if (separator >= 0) {
+      values[arg.slice(0, separator)] = arg.slice(separator + 1);
+    } else if (i + 1 < args.length && !args[i + 1].startsWith('--')) {
+      values[arg] = args[++i];
+    } else {
+      values[arg] = 'true';
+    }
+  }
+  return values;
+}
+
+// Returns the subcommand of the flags and metadata commands, e.g. init for --init
+function subcommand(args) {
+  if (args.length !== 1 || !args[0].startsWith('--')) {
+    throw new PluginError(` + "`expected the subcommand as the only argument, got ${JSON.stringify(args)}`" + `);
+  }
+  return args[0].slice(2);
+}
+
+// Scaffolds the files of the init subcommand
+function scaffoldInit(args, universe) {
+  const greeting = args.greeting || 'Hello';
+  universe['sample.txt'] = ` + "`${greeting} from ${PLUGIN_NAME}\\n`" + `;
+}
+
+// Scaffolds the files of the create api subcommand
+function scaffoldAPI(args, universe) {
+  const kind = args.kind;
+  if (!kind) {
+    throw new PluginError('--kind is required');
+  }
+  universe[` + "`sample_${kind.toLowerCase()}.txt`" + `] = ` + "`${args.group || ''}/${args.version || ''} ${kind}\\n`" + `;
+}
+
+// Returns the response reporting the error
+function errorResponse(request, err) {
+  return {
+    apiVersion: API_VERSION,
+    command: request.command || '',
+    universe: request.universe || {},
+    error: true,
+    errorMsgs: [err.message],
+  };
+}
+
+// Returns the response to the request
+function handle(request) {
+  const command = request.command || '';
+  const args = request.args || [];
+  const universe = Object.assign({}, request.universe);
+  const response = {apiVersion: API_VERSION, command, universe};
+
+  try {
+    switch (command) {
+      case 'flags':
+        response.flags = FLAGS[subcommand(args)] || [];
+        break;
+      case 'metadata':
+        response.metadata = METADATA[subcommand(args)] || {};
+        break;
+      case 'init':
+        scaffoldInit(parseArgs(args), universe);
+        break;
+      case 'create api':
+        scaffoldAPI(parseArgs(args), universe);
+        break;
+      case 'create webhook':
+      case 'edit':
+        // TODO: scaffold the files of the subcommand in universe
+        break;
+      default:
+        throw new PluginError(` + "`unknown command ${JSON.stringify(command)}`" + `);
+    }
+  } catch (err) {
+    if (!(err instanceof PluginError)) {
+      throw err;
+    }
+    return errorResponse(request, err);
+  }
+  return response;
+}
+
+function main() {
+  const chunks = [];
+  process.stdin.on('data', (chunk) => chunks.push(chunk));
+  process.stdin.on('end', () => {
+    let response;
+    try {
+      response = handle(JSON.parse(Buffer.concat(chunks).toString()));
+    } catch (err) {
+      response = errorResponse({}, new PluginError(` + "`unable to handle the request: ${err.message}`" + `));
+    }
+    process.stdout.write(JSON.stringify(response));
+  });
+}
+
+if (require.main === module) {
+  main();
+}
+
+module.exports = {API_VERSION, PLUGIN_NAME, PluginError, handle, parseArgs};
+`
+
+var _ machinery.Template = &NodePluginTest{}
+
+// NodePluginTest scaffolds the tests of the skeleton in NodeJS, run by the test runner of NodeJS
+type NodePluginTest struct {
+	machinery.TemplateMixin
+	PluginMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *NodePluginTest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = "plugin.test.js"
+	}
+
+	f.TemplateBody = nodePluginTestTemplate
+
+	f.IfExistsAction = f.ifExistsAction()
+
+	return nil
+}
+
+const nodePluginTestTemplate = `'use strict';
+
+const assert = require('node:assert');
+const test = require('node:test');
+
+const plugin = require('./plugin');
+
+test('parseArgs', () => {
+  assert.deepStrictEqual(
+    plugin.parseArgs(['--force', '--domain', 'my.domain', '--kind=Captain']),
+    {domain: 'my.domain', kind: 'Captain', force: 'true'},
+  );
+});
+
+test('flags', () => {
+  let response = plugin.handle({apiVersion: plugin.API_VERSION, command: 'flags', args: ['--init']});
+  assert.strictEqual(response.error, undefined);
+  assert.deepStrictEqual(response.flags.map((flag) => flag.name), ['greeting']);
+
+  response = plugin.handle({apiVersion: plugin.API_VERSION, command: 'flags'});
+  assert.strictEqual(response.error, true);
+});
+
+test('metadata', () => {
+  const response = plugin.handle({apiVersion: plugin.API_VERSION, command: 'metadata', args: ['--init']});
+  assert.strictEqual(response.error, undefined);
+  assert.ok(response.metadata.description);
+});
+
+test('init', () => {
+  const response = plugin.handle({
+    apiVersion: plugin.API_VERSION,
+    command: 'init',
+    args: ['--greeting', 'Hi'],
+    universe: {PROJECT: 'version: "3"\n'},
+  });
+  assert.strictEqual(response.error, undefined);
+  assert.strictEqual(response.universe['sample.txt'], ` + "`Hi from ${plugin.PLUGIN_NAME}\\n`" + `);
+  assert.ok('PROJECT' in response.universe);
+});
+
+test('create api', () => {
+  let response = plugin.handle({
+    apiVersion: plugin.API_VERSION,
+    command: 'create api',
+    args: ['--group', 'crew', '--version', 'v1', '--kind', 'Captain'],
+  });
+  assert.strictEqual(response.error, undefined);
+  assert.strictEqual(response.universe['sample_captain.txt'], 'crew/v1 Captain\n');
+
+  response = plugin.handle({apiVersion: plugin.API_VERSION, command: 'create api'});
+  assert.strictEqual(response.error, true);
+});
+
+test('unknown command', () => {
+  const response = plugin.handle({apiVersion: plugin.API_VERSION, command: 'unknown'});
+  assert.strictEqual(response.error, true);
+  assert.strictEqual(response.errorMsgs.length, 1);
+});
+`
@@ -0,0 +1,260 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &PythonPlugin{}
+
+// PythonPlugin scaffolds the executable of the skeleton in Python, which defines the parsing helpers and
+// the subcommands of the plugin
+type PythonPlugin struct {
+	machinery.TemplateMixin
+	PluginMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *PythonPlugin) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = "plugin.py"
+	}
+
+	f.TemplateBody = pythonPluginTemplate
+	f.FilePermissions = machinery.ExecutableFilePermission
+
+	f.IfExistsAction = f.ifExistsAction()
+
+	return nil
+}
+
+const pythonPluginTemplate = `#!/usr/bin/env python3
+"""The {{ .PluginName }} external plugin of Kubebuilder.
+
+Kubebuilder writes a PluginRequest in JSON to stdin and reads the PluginResponse in JSON from stdout,
+see the schemas directory. Nothing else must be written to stdout, the logs can be written to stderr.
+"""
+
+import json
+import sys
+
+# The version of the protocol implemented by the plugin
+API_VERSION = "v1alpha1"
+# The name of the plugin
+PLUGIN_NAME = "{{ .PluginName }}"
+
+# The flags of the subcommands, by the argument of the flags and metadata commands
+FLAGS = {
+    "init": [
+        {
+            "name": "greeting",
+            "type": "string",
+            "default": "Hello",
+            "usage": "greeting written in sample.txt",
+        },
+    ],
+}
+
+# The help of the subcommands, by the argument of the flags and metadata commands
+METADATA = {
+    "init": {
+        "description": "Scaffolds sample.txt with a greeting.",
+        "examples": f"kubebuilder init --plugins go/v4,{PLUGIN_NAME}/v1 --greeting Hi",
+    },
+    "api": {
+        "description": "Scaffolds sample_<kind>.txt for the API.",
+        "examples": f"kubebuilder create api --plugins go/v4,{PLUGIN_NAME}/v1 "
+        "--group crew --version v1 --kind Captain",
+    },
+}
+
+
+class PluginError(Exception):
+    """An error reported to Kubebuilder in the response."""
+
+
+def parse_args(args):
+    """Returns the values of the flags of the arguments.
+
+    The flags are in the forms --name=value, --name value and --name for boolean flags, whose value is "true".
+    """
+    values = {}
+    i = 0
+    while i < len(args):
+        arg = args[i]
+        if arg.startswith("--"):
+            name, found, value = arg[2:].partition("=")
+            if not found:
+                value = "true"
+                if i + 1 < len(args) and not args[i + 1].startswith("--"):
+                    i += 1
+                    value = args[i]
+            values[name] = value
+        i += 1
+    return values
+
+
+def subcommand(args):
+    """Returns the subcommand of the flags and metadata commands, e.g. init for --init."""
+    if len(args) != 1 or not args[0].startswith("--"):
+        raise PluginError(f"expected the subcommand as the only argument, got {args}")
+    return args[0][2:]
+
+
+def scaffold_init(args, universe):
+    """Scaffolds the files of the init subcommand."""
+    greeting = args.get("greeting") or "Hello"
+    universe["sample.txt"] = f"{greeting} from {PLUGIN_NAME}\n"
+
+
+def scaffold_api(args, universe):
+    """Scaffolds the files of the create api subcommand."""
+    kind = args.get("kind")
+    if not kind:
+        raise PluginError("--kind is required")
+    universe[f"sample_{kind.lower()}.txt"] = f"{args.get('group', '')}/{args.get('version', '')} {kind}\n"
+
+
+def handle(request):
+    """Returns the response to the request."""
+    command = request.get("command", "")
+    args = request.get("args") or []
+    universe = dict(request.get("universe") or {})
+    response = {"apiVersion": API_VERSION, "command": command, "universe": universe}
+
+    try:
+        if command == "flags":
+            response["flags"] = FLAGS.get(subcommand(args), [])
+        elif command == "metadata":
+            response["metadata"] = METADATA.get(subcommand(args), {})
+        elif command == "init":
+            scaffold_init(parse_args(args), universe)
+        elif command == "create api":
+            scaffold_api(parse_args(args), universe)
+        elif command in ("create webhook", "edit"):
+            pass  # TODO: scaffold the files of the subcommand in universe
+        else:
+            raise PluginError(f"unknown command {command!r}")
+    except PluginError as err:
+        return error_response(request, err)
+    return response
+
+
+def error_response(request, err):
+    """Returns the response reporting the error."""
+    return {
+        "apiVersion": API_VERSION,
+        "command": request.get("command", ""),
+        "universe": request.get("universe") or {},
+        "error": True,
+        "errorMsgs": [str(err)],
+    }
+
+
+def main():
+    try:
+        request = json.load(sys.stdin)
+    except json.JSONDecodeError as err:
+        response = error_response({}, PluginError(f"unable to decode the request: {err}"))
+    else:
+        response = handle(request)
+    json.dump(response, sys.stdout)
+
+
+if __name__ == "__main__":
+    main()
+`
+
+var _ machinery.Template = &PythonPluginTest{}
+
+// PythonPluginTest scaffolds the tests of the skeleton in Python
+type PythonPluginTest struct {
+	machinery.TemplateMixin
+	PluginMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *PythonPluginTest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = "test_plugin.py"
+	}
+
+	f.TemplateBody = pythonPluginTestTemplate
+
+	f.IfExistsAction = f.ifExistsAction()
+
+	return nil
+}
+
+const pythonPluginTestTemplate = `import unittest
+
+import plugin
+
+
+class PluginTest(unittest.TestCase):
+    def test_parse_args(self):
+        self.assertEqual(
+            plugin.parse_args(["--force", "--domain", "my.domain", "--kind=Captain"]),
+            {"domain": "my.domain", "kind": "Captain", "force": "true"},
+        )
+
+    def test_flags(self):
+        response = plugin.handle({"apiVersion": plugin.API_VERSION, "command": "flags", "args": ["--init"]})
+        self.assertNotIn("error", response)
+        self.assertEqual([flag["name"] for flag in response["flags"]], ["greeting"])
+
+        response = plugin.handle({"apiVersion": plugin.API_VERSION, "command": "flags"})
+        self.assertTrue(response["error"])
+
+    def test_metadata(self):
+        response = plugin.handle({"apiVersion": plugin.API_VERSION, "command": "metadata", "args": ["--init"]})
+        self.assertNotIn("error", response)
+        self.assertTrue(response["metadata"]["description"])
+
+    def test_init(self):
+        response = plugin.handle({
+            "apiVersion": plugin.API_VERSION,
+            "command": "init",
+            "args": ["--greeting", "Hi"],
+            "universe": {"PROJECT": "version: \"3\"\n"},
+        })
+        self.assertNotIn("error", response)
+        self.assertEqual(response["universe"]["sample.txt"], f"Hi from {plugin.PLUGIN_NAME}\n")
+        self.assertIn("PROJECT", response["universe"])
+
+    def test_create_api(self):
+        response = plugin.handle({
+            "apiVersion": plugin.API_VERSION,
+            "command": "create api",
+            "args": ["--group", "crew", "--version", "v1", "--kind", "Captain"],
+        })
+        self.assertNotIn("error", response)
+        self.assertEqual(response["universe"]["sample_captain.txt"], "crew/v1 Captain\n")
+
+        response = plugin.handle({"apiVersion": plugin.API_VERSION, "command": "create api"})
+        self.assertTrue(response["error"])
+
+    def test_unknown_command(self):
+        response = plugin.handle({"apiVersion": plugin.API_VERSION, "command": "unknown"})
+        self.assertTrue(response["error"])
+        self.assertEqual(len(response["errorMsgs"]), 1)
+
+
+if __name__ == "__main__":
+    unittest.main()
+`
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -33,15 +34,40 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/util"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/render"
+	kustomizev2scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1"
+	golangv4scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds"
+	autoupdatev1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/autoupdate/v1alpha"
+	devenvv1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/devenv/v1alpha"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/grafana/v1alpha"
 	hemlv1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha"
+	helmv1alphascaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds"
+	manifestlintv1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/manifestlint/v1alpha"
+	prometheusrulesv1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/prometheusrules/v1alpha"
+	supplychainv1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/supplychain/v1alpha"
 )
 
 // Generate store the required info for the command
 type Generate struct {
 	InputDir  string
 	OutputDir string
+	// PluginReplacements maps the keys of the deprecated plugins to the keys of the plugins replacing them
+	PluginReplacements map[string]string
+	// Plugins are the keys of the optional plugins whose scaffold is re-applied over the existing project,
+	// instead of re-scaffolding the whole project
+	Plugins []string
+}
+
+// optionalPlugins are the plugins whose scaffold can be re-applied over an existing project
+var optionalPlugins = []plugin.Plugin{
+	hemlv1alpha.Plugin{},
+	v1alpha.Plugin{},
+	autoupdatev1alpha.Plugin{},
+	devenvv1alpha.Plugin{},
+	prometheusrulesv1alpha.Plugin{},
+	supplychainv1alpha.Plugin{},
+	manifestlintv1alpha.Plugin{},
 }
 
 // Generate handles the migration and scaffolding process.
@@ -51,6 +77,10 @@ func (opts *Generate) Generate() error {
 		return err
 	}
 
+	if len(opts.Plugins) != 0 {
+		return opts.generatePlugins(config)
+	}
+
 	if opts.OutputDir == "" {
 		cwd, err := os.Getwd()
 		if err != nil {
@@ -82,7 +112,11 @@ func (opts *Generate) Generate() error {
 		return err
 	}
 
-	if err := kubebuilderInit(config); err != nil {
+	if err := migrateCustomBoilerplate(config, opts.InputDir, opts.OutputDir); err != nil {
+		return err
+	}
+
+	if err := kubebuilderInit(config, opts.PluginReplacements); err != nil {
 		return err
 	}
 
@@ -98,12 +132,48 @@ func (opts *Generate) Generate() error {
 		return err
 	}
 
+	// The rules are scaffolded before the Helm chart, which includes them
+	if err := migratePrometheusRulesPlugin(config, opts.InputDir, opts.OutputDir); err != nil {
+		return err
+	}
+
 	if hasHelmPlugin(config) {
-		if err := kubebuilderHelmEdit(); err != nil {
+		if err := kubebuilderHelmEdit(config); err != nil {
 			return err
 		}
 	}
-	return migrateDeployImagePlugin(config)
+
+	if hasAutoUpdatePlugin(config) {
+		if err := kubebuilderAutoUpdateEdit(); err != nil {
+			return err
+		}
+	}
+
+	if tool, ok := getDevEnvTool(config); ok {
+		if err := kubebuilderDevEnvEdit(tool); err != nil {
+			return err
+		}
+	}
+
+	// The supply chain targets are added after the helm plugin, since they sign its chart
+	if hasSupplyChainPlugin(config) {
+		if err := kubebuilderSupplyChainEdit(config); err != nil {
+			return err
+		}
+	}
+
+	// The lint of the manifests is added after the helm plugin, since it lints its chart
+	if linter, ok := getManifestLinter(config); ok {
+		if err := kubebuilderManifestLintEdit(linter); err != nil {
+			return err
+		}
+	}
+	if err := migrateDeployImagePlugin(config); err != nil {
+		return err
+	}
+
+	validateKustomizations()
+	return nil
 }
 
 // Validate ensures the options are valid and kubebuilder is installed.
@@ -119,9 +189,93 @@ func (opts *Generate) Validate() error {
 		return fmt.Errorf("kubebuilder not found in the path: %w", err)
 	}
 
+	if len(opts.Plugins) != 0 {
+		if opts.OutputDir != "" {
+			return fmt.Errorf("the scaffold of the plugins is re-applied over the project in the input directory, " +
+				"--output-dir can not be used with --plugins")
+		}
+		for _, key := range opts.Plugins {
+			if _, err := resolveOptionalPlugin(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// generatePlugins re-applies the scaffold of the optional plugins over the project in the input directory,
+// e.g. to adopt them in an old project, without re-scaffolding the whole project.
+func (opts *Generate) generatePlugins(store store.Store) error {
+	if err := changeWorkingDirectory(opts.InputDir); err != nil {
+		return err
+	}
+
+	for _, key := range opts.Plugins {
+		p, err := resolveOptionalPlugin(key)
+		if err != nil {
+			return err
+		}
+
+		switch p.(type) {
+		case hemlv1alpha.Plugin:
+			err = kubebuilderHelmEdit(store)
+		case v1alpha.Plugin:
+			err = kubebuilderGrafanaEdit()
+		case autoupdatev1alpha.Plugin:
+			err = kubebuilderAutoUpdateEdit()
+		case devenvv1alpha.Plugin:
+			tool, _ := getDevEnvTool(store)
+			err = kubebuilderDevEnvEdit(tool)
+		case prometheusrulesv1alpha.Plugin:
+			err = kubebuilderPrometheusRulesEdit()
+		case supplychainv1alpha.Plugin:
+			err = kubebuilderSupplyChainEdit(store)
+		case manifestlintv1alpha.Plugin:
+			linter, _ := getManifestLinter(store)
+			err = kubebuilderManifestLintEdit(linter)
+		}
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// resolveOptionalPlugin returns the optional plugin matching the key, which may be not fully qualified,
+// e.g. helm/v1-alpha.
+func resolveOptionalPlugin(key string) (plugin.Plugin, error) {
+	plugins, err := plugin.FilterPluginsByKey(optionalPlugins, key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plugin %q: %w", key, err)
+	}
+	if len(plugins) != 1 {
+		keys := make([]string, 0, len(optionalPlugins))
+		for _, p := range optionalPlugins {
+			keys = append(keys, plugin.KeyFor(p))
+		}
+		return nil, fmt.Errorf("the scaffold of the plugin %q can not be re-applied, the supported plugins are: %s",
+			key, strings.Join(keys, ", "))
+	}
+	return plugins[0], nil
+}
+
+// validateKustomizations checks that the manifests of the re-scaffolded project can be built with kustomize.
+// Failures are only reported since the project may need to be completed by hand, e.g. with 'make manifests'.
+func validateKustomizations() {
+	// The kustomization of the CRDs refers to the manifests generated by controller-gen
+	if _, err := os.Stat(filepath.Join("config", "crd")); err == nil {
+		if _, err := os.Stat(filepath.Join("config", "crd", "bases")); err != nil {
+			log.Info("Skipping the validation of the kustomize manifests, run 'make manifests' to generate the CRDs")
+			return
+		}
+	}
+
+	if _, err := (render.Renderer{}).Build(render.DefaultKustomization); err != nil {
+		log.Warnf("The kustomize manifests of the re-scaffolded project are not valid: %v", err)
+	}
+}
+
 // Helper function to load the project configuration.
 func loadProjectConfig(inputDir string) (store.Store, error) {
 	config := yaml.New(machinery.Filesystem{FS: afero.NewOsFs()})
@@ -148,8 +302,8 @@ func changeWorkingDirectory(outputDir string) error {
 }
 
 // Initializes the project with Kubebuilder.
-func kubebuilderInit(store store.Store) error {
-	args := append([]string{"init"}, getInitArgs(store)...)
+func kubebuilderInit(store store.Store, replacements map[string]string) error {
+	args := append([]string{"init"}, getInitArgs(store, replacements)...)
 	return util.RunCmd("kubebuilder init", "kubebuilder", args...)
 }
 
@@ -176,6 +330,17 @@ func kubebuilderCreate(store store.Store) error {
 		}
 	}
 
+	// Then, scaffold the controllers reconciling a kind in addition to the controller of the kind
+	goConfig, err := golangv4scaffolds.LoadPluginConfig(store.Config())
+	if err != nil {
+		return fmt.Errorf("failed to load the go/v4 plugin configuration: %w", err)
+	}
+	for _, c := range goConfig.Controllers {
+		if err := createController(c); err != nil {
+			return fmt.Errorf("failed to create controller %s for %s/%s/%s: %w", c.Name, c.Group, c.Version, c.Kind, err)
+		}
+	}
+
 	// Then, scaffold all webhooks
 	// We cannot create a webhook for an API that does not exist
 	for _, r := range resources {
@@ -209,6 +374,37 @@ func migrateGrafanaPlugin(store store.Store, src, des string) error {
 	return kubebuilderGrafanaEdit()
 }
 
+// Migrates the Prometheus rules plugin, keeping the rules tuned for the project.
+func migratePrometheusRulesPlugin(store store.Store, src, des string) error {
+	var rulesPlugin map[string]interface{}
+	err := store.Config().DecodePluginConfig(plugin.KeyFor(prometheusrulesv1alpha.Plugin{}), &rulesPlugin)
+	if errors.As(err, &config.PluginKeyNotFoundError{}) {
+		log.Info("Prometheus rules plugin not found, skipping migration")
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to decode prometheus rules plugin config: %w", err)
+	}
+
+	if err := kubebuilderPrometheusRulesEdit(); err != nil {
+		return err
+	}
+
+	rules := filepath.Join("config", "prometheus", "rules.yaml")
+	if _, err := os.Stat(filepath.Join(src, rules)); os.IsNotExist(err) {
+		return nil
+	}
+	return copyFile(filepath.Join(src, rules), filepath.Join(des, rules))
+}
+
+// Edits the project to include the Prometheus rules plugin.
+func kubebuilderPrometheusRulesEdit() error {
+	args := []string{"edit", "--plugins", plugin.KeyFor(prometheusrulesv1alpha.Plugin{})}
+	if err := util.RunCmd("kubebuilder edit", "kubebuilder", args...); err != nil {
+		return fmt.Errorf("failed to run edit subcommand for Prometheus rules plugin: %w", err)
+	}
+	return nil
+}
+
 // Migrates the Deploy Image plugin.
 func migrateDeployImagePlugin(store store.Store) error {
 	var deployImagePlugin v1alpha1.PluginConfig
@@ -253,9 +449,9 @@ func getInputPath(inputPath string) (string, error) {
 }
 
 // Helper function to get Init arguments for Kubebuilder.
-func getInitArgs(store store.Store) []string {
+func getInitArgs(store store.Store, replacements map[string]string) []string {
 	var args []string
-	plugins := store.Config().GetPluginChain()
+	plugins := replaceDeprecatedPlugins(store.Config().GetPluginChain(), replacements)
 	if len(plugins) > 0 {
 		args = append(args, "--plugins", strings.Join(plugins, ","))
 	}
@@ -265,9 +461,63 @@ func getInitArgs(store store.Store) []string {
 	if repo := store.Config().GetRepository(); repo != "" {
 		args = append(args, "--repo", repo)
 	}
+	if kustomizeConfig, err := kustomizev2scaffolds.LoadPluginConfig(store.Config()); err != nil {
+		log.Errorf("Error decoding kustomize plugin config: %v", err)
+	} else {
+		if kustomizeConfig.Namespaced {
+			args = append(args, "--namespaced")
+		}
+		if kustomizeConfig.WebhookOnly {
+			args = append(args, "--webhook-only")
+		}
+		if kustomizeConfig.ComponentConfig {
+			args = append(args, "--component-config")
+		}
+	}
+	if goConfig, err := golangv4scaffolds.LoadPluginConfig(store.Config()); err != nil {
+		log.Errorf("Error decoding go plugin config: %v", err)
+	} else {
+		if goConfig.Pprof {
+			args = append(args, "--with-pprof")
+		}
+		if goConfig.HAOptions {
+			args = append(args, "--with-ha-options")
+		}
+		if goConfig.UsesWorkspace() {
+			args = append(args, "--use-workspace")
+		}
+		if goConfig.ControllerLayout != "" {
+			args = append(args, "--controller-layout", goConfig.ControllerLayout)
+		}
+		if goConfig.UsesChainsaw() {
+			args = append(args, "--e2e-framework", golangv4scaffolds.ChainsawE2EFramework)
+		}
+		if goConfig.License != "" {
+			args = append(args, "--license", goConfig.License)
+		}
+		if goConfig.BoilerplatePath != "" {
+			args = append(args, "--boilerplate-path", goConfig.BoilerplatePath)
+		}
+		if goConfig.ToolMirror != "" {
+			args = append(args, "--tool-mirror", goConfig.ToolMirror)
+		}
+	}
 	return args
 }
 
+// replaceDeprecatedPlugins returns the plugin chain with the deprecated plugins replaced by their successors.
+func replaceDeprecatedPlugins(plugins []string, replacements map[string]string) []string {
+	replaced := make([]string, 0, len(plugins))
+	for _, key := range plugins {
+		if replacement, found := replacements[key]; found {
+			log.Infof("Replacing the deprecated plugin %q with %q", key, replacement)
+			key = replacement
+		}
+		replaced = append(replaced, key)
+	}
+	return replaced
+}
+
 // Gets the GVK flags for a resource.
 func getGVKFlags(resource resource.Resource) []string {
 	var args []string
@@ -316,6 +566,18 @@ func getDeployImageOptions(resource v1alpha1.ResourceData) []string {
 	if resource.Options.RunAsUser != "" {
 		args = append(args, fmt.Sprintf("--run-as-user=%s", resource.Options.RunAsUser))
 	}
+	if resource.Options.CPULimit != "" {
+		args = append(args, fmt.Sprintf("--image-cpu-limit=%s", resource.Options.CPULimit))
+	}
+	if resource.Options.MemoryLimit != "" {
+		args = append(args, fmt.Sprintf("--image-memory-limit=%s", resource.Options.MemoryLimit))
+	}
+	if resource.Options.LivenessProbePath != "" {
+		args = append(args, fmt.Sprintf("--liveness-probe-path=%s", resource.Options.LivenessProbePath))
+	}
+	if resource.Options.ReadinessProbePath != "" {
+		args = append(args, fmt.Sprintf("--readiness-probe-path=%s", resource.Options.ReadinessProbePath))
+	}
 	args = append(args, fmt.Sprintf("--plugins=%s", plugin.KeyFor(v1alpha1.Plugin{})))
 	return args
 }
@@ -329,11 +591,28 @@ func createAPI(resource resource.Resource) error {
 	if resource.IsExternal() {
 		args = append(args, "--external-api-path", resource.Path)
 		args = append(args, "--external-api-domain", resource.Domain)
+		if resource.Module != "" {
+			args = append(args, "--external-api-module", resource.Module)
+		}
 	}
 
 	return util.RunCmd("kubebuilder create api", "kubebuilder", args...)
 }
 
+// Creates a controller tracked by the go/v4 plugin.
+func createController(c golangv4scaffolds.NamedController) error {
+	args := append([]string{"create", "controller"}, getGVKFlags(resource.Resource{GVK: c.GVK, Plural: c.Plural})...)
+	args = append(args, "--name", c.Name)
+	if c.ExternalAPIPath != "" {
+		args = append(args, "--external-api-path", c.ExternalAPIPath)
+		args = append(args, "--external-api-domain", c.Domain)
+		if c.ExternalAPIModule != "" {
+			args = append(args, "--external-api-module", c.ExternalAPIModule)
+		}
+	}
+	return util.RunCmd("kubebuilder create controller", "kubebuilder", args...)
+}
+
 // Gets flags for API resource creation.
 func getAPIResourceFlags(resource resource.Resource) []string {
 	var args []string
@@ -347,6 +626,9 @@ func getAPIResourceFlags(resource resource.Resource) []string {
 		} else {
 			args = append(args, "--namespaced=false")
 		}
+		if resource.API.StorageVersion {
+			args = append(args, "--storage-version")
+		}
 	}
 	if resource.Controller {
 		args = append(args, "--controller")
@@ -372,6 +654,9 @@ func getWebhookResourceFlags(resource resource.Resource) []string {
 	if resource.IsExternal() {
 		args = append(args, "--external-api-path", resource.Path)
 		args = append(args, "--external-api-domain", resource.Domain)
+		if resource.Module != "" {
+			args = append(args, "--external-api-module", resource.Module)
+		}
 	}
 	if resource.HasValidationWebhook() {
 		args = append(args, "--programmatic-validation")
@@ -379,6 +664,9 @@ func getWebhookResourceFlags(resource resource.Resource) []string {
 	if resource.HasDefaultingWebhook() {
 		args = append(args, "--defaulting")
 	}
+	if resource.HasValidatingAdmissionPolicy() {
+		args = append(args, "--validating-admission-policy")
+	}
 	if resource.HasConversionWebhook() {
 		args = append(args, "--conversion")
 		if len(resource.Webhooks.Spoke) > 0 {
@@ -399,6 +687,23 @@ func copyFile(src, des string) error {
 	return os.WriteFile(des, bytesRead, 0755)
 }
 
+// Copies the boilerplate of the custom license, which is not scaffolded, to re-scaffold the project with it.
+func migrateCustomBoilerplate(store store.Store, src, des string) error {
+	goConfig, err := golangv4scaffolds.LoadPluginConfig(store.Config())
+	if err != nil {
+		return fmt.Errorf("failed to decode go plugin config: %w", err)
+	}
+	if goConfig.GetLicense() != golangv4scaffolds.CustomLicense {
+		return nil
+	}
+
+	boilerplatePath := goConfig.GetBoilerplatePath()
+	if err := os.MkdirAll(filepath.Join(des, filepath.Dir(boilerplatePath)), 0755); err != nil {
+		return fmt.Errorf("failed to create the directory of the boilerplate: %w", err)
+	}
+	return copyFile(filepath.Join(src, boilerplatePath), filepath.Join(des, boilerplatePath))
+}
+
 // Migrates Grafana configuration files.
 func grafanaConfigMigrate(src, des string) error {
 	grafanaConfig := fmt.Sprintf("%s/grafana/custom-metrics/config.yaml", src)
@@ -417,9 +722,31 @@ func kubebuilderGrafanaEdit() error {
 	return nil
 }
 
-// Edits the project to include the Helm plugin.
-func kubebuilderHelmEdit() error {
+// Edits the project to include the Helm plugin, with the given extra arguments, e.g. --force.
+func kubebuilderHelmEdit(store store.Store, extraArgs ...string) error {
 	args := []string{"edit", "--plugins", plugin.KeyFor(hemlv1alpha.Plugin{})}
+	helmCfg, err := helmv1alphascaffolds.LoadPluginConfig(store.Config())
+	if err != nil {
+		return fmt.Errorf("failed to load the Helm plugin configuration: %w", err)
+	}
+	if helmCfg.ChartDir != "" {
+		args = append(args, "--chart-dir", helmCfg.ChartDir)
+	}
+	// The chart of the CRDs does not distribute the chart-releaser workflow nor the extra manifests
+	if helmCfg.CRDsOnly {
+		args = append(args, "--crds-only")
+	} else {
+		if helmCfg.ChartReleaser {
+			args = append(args, "--chart-releaser")
+		}
+		if len(helmCfg.ExtraConfigDirs) > 0 {
+			args = append(args, "--extra-config-dirs", strings.Join(helmCfg.ExtraConfigDirs, ","))
+		}
+	}
+	if helmCfg.SkipCRDs {
+		args = append(args, "--skip-crds")
+	}
+	args = append(args, extraArgs...)
 	if err := util.RunCmd("kubebuilder edit", "kubebuilder", args...); err != nil {
 		return fmt.Errorf("failed to run edit subcommand for Helm plugin: %w", err)
 	}
@@ -445,3 +772,131 @@ func hasHelmPlugin(cfg store.Store) bool {
 	// Helm plugin is present
 	return true
 }
+
+// Edits the project to include the AutoUpdate plugin.
+func kubebuilderAutoUpdateEdit() error {
+	args := []string{"edit", "--plugins", plugin.KeyFor(autoupdatev1alpha.Plugin{})}
+	if err := util.RunCmd("kubebuilder edit", "kubebuilder", args...); err != nil {
+		return fmt.Errorf("failed to run edit subcommand for AutoUpdate plugin: %w", err)
+	}
+	return nil
+}
+
+// Edits the project to include the DevEnv plugin with the tool it was using.
+func kubebuilderDevEnvEdit(tool string) error {
+	args := []string{"edit", "--plugins", plugin.KeyFor(devenvv1alpha.Plugin{})}
+	if tool != "" {
+		args = append(args, "--tool", tool)
+	}
+	if err := util.RunCmd("kubebuilder edit", "kubebuilder", args...); err != nil {
+		return fmt.Errorf("failed to run edit subcommand for DevEnv plugin: %w", err)
+	}
+	return nil
+}
+
+// Edits the project to include the ManifestLint plugin with the linter it was using.
+func kubebuilderManifestLintEdit(linter string) error {
+	args := []string{"edit", "--plugins", plugin.KeyFor(manifestlintv1alpha.Plugin{})}
+	if linter != "" {
+		args = append(args, "--linter", linter)
+	}
+	if err := util.RunCmd("kubebuilder edit", "kubebuilder", args...); err != nil {
+		return fmt.Errorf("failed to run edit subcommand for ManifestLint plugin: %w", err)
+	}
+	return nil
+}
+
+// supplyChainPluginConfig is the configuration of the SupplyChain plugin tracked in the PROJECT file
+type supplyChainPluginConfig struct {
+	SBOMFormat string `json:"sbomFormat,omitempty"`
+	SignImage  bool   `json:"signImage,omitempty"`
+	SignChart  bool   `json:"signChart,omitempty"`
+}
+
+// Edits the project to include the SupplyChain plugin with the options it was using.
+func kubebuilderSupplyChainEdit(cfg store.Store) error {
+	var pluginConfig supplyChainPluginConfig
+	err := cfg.Config().DecodePluginConfig(plugin.KeyFor(supplychainv1alpha.Plugin{}), &pluginConfig)
+	if err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
+		return fmt.Errorf("failed to decode the SupplyChain plugin config: %w", err)
+	}
+
+	args := []string{"edit", "--plugins", plugin.KeyFor(supplychainv1alpha.Plugin{})}
+	// The options are only set when the plugin was used, otherwise its defaults are kept
+	if err == nil {
+		if pluginConfig.SBOMFormat != "" {
+			args = append(args, "--sbom-format", pluginConfig.SBOMFormat)
+		}
+		args = append(args,
+			fmt.Sprintf("--sign=%t", pluginConfig.SignImage),
+			fmt.Sprintf("--sign-chart=%t", pluginConfig.SignChart))
+	}
+	if err := util.RunCmd("kubebuilder edit", "kubebuilder", args...); err != nil {
+		return fmt.Errorf("failed to run edit subcommand for SupplyChain plugin: %w", err)
+	}
+	return nil
+}
+
+// hasSupplyChainPlugin checks if the SupplyChain plugin is present by inspecting the plugin configuration.
+func hasSupplyChainPlugin(cfg store.Store) bool {
+	var pluginConfig supplyChainPluginConfig
+
+	err := cfg.Config().DecodePluginConfig(plugin.KeyFor(supplychainv1alpha.Plugin{}), &pluginConfig)
+	if err != nil {
+		if !errors.As(err, &config.PluginKeyNotFoundError{}) {
+			log.Errorf("Error decoding SupplyChain plugin config: %v", err)
+		}
+		return false
+	}
+
+	return true
+}
+
+// getDevEnvTool returns the tool of the DevEnv plugin and whether the plugin is present in the configuration.
+func getDevEnvTool(cfg store.Store) (string, bool) {
+	var pluginConfig struct {
+		Tool string `json:"tool,omitempty"`
+	}
+
+	err := cfg.Config().DecodePluginConfig(plugin.KeyFor(devenvv1alpha.Plugin{}), &pluginConfig)
+	if err != nil {
+		if !errors.As(err, &config.PluginKeyNotFoundError{}) {
+			log.Errorf("Error decoding DevEnv plugin config: %v", err)
+		}
+		return "", false
+	}
+
+	return pluginConfig.Tool, true
+}
+
+// getManifestLinter returns the linter of the ManifestLint plugin and whether the plugin is present in the configuration.
+func getManifestLinter(cfg store.Store) (string, bool) {
+	var pluginConfig struct {
+		Linter string `json:"linter,omitempty"`
+	}
+
+	err := cfg.Config().DecodePluginConfig(plugin.KeyFor(manifestlintv1alpha.Plugin{}), &pluginConfig)
+	if err != nil {
+		if !errors.As(err, &config.PluginKeyNotFoundError{}) {
+			log.Errorf("Error decoding ManifestLint plugin config: %v", err)
+		}
+		return "", false
+	}
+
+	return pluginConfig.Linter, true
+}
+
+// hasAutoUpdatePlugin checks if the AutoUpdate plugin is present by inspecting the plugin configuration.
+func hasAutoUpdatePlugin(cfg store.Store) bool {
+	var pluginConfig map[string]interface{}
+
+	err := cfg.Config().DecodePluginConfig(plugin.KeyFor(autoupdatev1alpha.Plugin{}), &pluginConfig)
+	if err != nil {
+		if !errors.As(err, &config.PluginKeyNotFoundError{}) {
+			log.Errorf("Error decoding AutoUpdate plugin config: %v", err)
+		}
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	kustomizev2scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
+	golangv4scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds"
+)
+
+// projectFile is the PROJECT file of a project with a Captain API and the helm plugin
+const projectFile = `# Code generated by tool. DO NOT EDIT.
+# This file is used to track the info used to scaffold your project
+# and allow the plugins properly work.
+# More info: https://book.kubebuilder.io/reference/project-config.html
+` + projectConfig
+
+const projectConfig = `domain: example.org
+layout:
+- go.kubebuilder.io/v4
+plugins:
+  helm.kubebuilder.io/v1-alpha:
+    chartDir: dist/chart
+    force: true
+projectName: project
+repo: example.org/project
+resources:
+- api:
+    crdVersion: v1
+    namespaced: true
+  domain: example.org
+  group: crew
+  kind: Captain
+  path: example.org/project/api/v1
+  version: v1
+version: "3"
+`
+
+// scaffoldProject scaffolds in the directory a project with the go/v4 and kustomize/v2 plugins and a
+// Captain API of the crew group with its controller and webhooks, without running the Go commands
+func scaffoldProject(dir string) config.Config {
+	cfg := cfgv3.New()
+	Expect(cfg.SetPluginChain([]string{"go.kubebuilder.io/v4"})).To(Succeed())
+	Expect(cfg.SetDomain("example.com")).To(Succeed())
+	Expect(cfg.SetRepository("example.com/project")).To(Succeed())
+	Expect(cfg.SetProjectName("project")).To(Succeed())
+
+	res := resource.Resource{
+		GVK:    resource.GVK{Group: "crew", Domain: "example.com", Version: "v1", Kind: "Captain"},
+		Plural: "captains",
+		Path:   resource.APIPackagePath("example.com/project", "crew", "v1", false),
+		API:    &resource.API{CRDVersion: "v1", Namespaced: true},
+		Webhooks: &resource.Webhooks{
+			WebhookVersion: "v1",
+			Defaulting:     true,
+			Validation:     true,
+		},
+		Controller: true,
+	}
+	Expect(cfg.AddResource(res)).To(Succeed())
+
+	fs := machinery.Filesystem{FS: afero.NewBasePathFs(afero.NewOsFs(), dir)}
+	for _, scaffolder := range []plugins.Scaffolder{
+		golangv4scaffolds.NewInitScaffolder(cfg, "apache2", "The Kubernetes authors", "kubebuilder"),
+		kustomizev2scaffolds.NewInitScaffolder(cfg),
+		golangv4scaffolds.NewAPIScaffolder(cfg, res, false, golangv4scaffolds.ControllerOptions{}),
+		kustomizev2scaffolds.NewAPIScaffolder(cfg, res, false),
+		golangv4scaffolds.NewWebhookScaffolder(cfg, res, false, false, golangv4scaffolds.WebhookOptions{}),
+		kustomizev2scaffolds.NewWebhookScaffolder(cfg, res, false),
+	} {
+		scaffolder.InjectFS(fs)
+		Expect(scaffolder.Scaffold()).To(Succeed())
+	}
+
+	content, err := cfg.MarshalYAML()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(os.WriteFile(filepath.Join(dir, "PROJECT"), content, 0o644)).To(Succeed())
+	return cfg
+}
+
+// captureStdout returns what the function prints to the standard output
+func captureStdout(f func()) string {
+	reader, writer, err := os.Pipe()
+	Expect(err).NotTo(HaveOccurred())
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		defer GinkgoRecover()
+		content, err := io.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		output <- string(content)
+	}()
+	f()
+	Expect(writer.Close()).To(Succeed())
+	return <-output
+}
@@ -0,0 +1,710 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/render"
+)
+
+const (
+	// rbacMarkerPrefix is the prefix of the controller-gen markers which define the RBAC of the manager
+	rbacMarkerPrefix = "+kubebuilder:rbac:"
+
+	// DefaultRolePath is the path of the role generated by controller-gen from the RBAC markers
+	DefaultRolePath = "config/rbac/role.yaml"
+)
+
+// clientVerbs maps the methods of the controller-runtime client to the verbs they require
+var clientVerbs = map[string]string{
+	"Get":         "get",
+	"List":        "list",
+	"Create":      "create",
+	"Update":      "update",
+	"Patch":       "patch",
+	"Delete":      "delete",
+	"DeleteAllOf": "deletecollection",
+}
+
+// writeVerbs are the verbs whose permissions are reported as excessive when no API call requires them.
+// The get, list and watch permissions are required by the informers of the watched objects as well.
+var writeVerbs = map[string]bool{
+	"create": true, "update": true, "patch": true, "delete": true, "deletecollection": true,
+}
+
+// coreAPIGroups maps the packages of k8s.io/api to their API group, the other packages are in the
+// <package>.k8s.io group
+var coreAPIGroups = map[string]string{
+	"core":        "",
+	"apps":        "apps",
+	"autoscaling": "autoscaling",
+	"batch":       "batch",
+	"policy":      "policy",
+	"rbac":        "rbac.authorization.k8s.io",
+}
+
+// RBACAudit store the required info for the rbac-audit command
+type RBACAudit struct {
+	InputDir string
+	// RolePath is the path of the role generated by controller-gen, relative to the project
+	RolePath string
+	// DetectCalls compares the RBAC markers with the calls of the controller-runtime client found in the code
+	DetectCalls bool
+}
+
+// RBACAuditReport is the result of the audit of the RBAC markers
+type RBACAuditReport struct {
+	// Missing are the permissions required but not granted
+	Missing []string
+	// Excessive are the permissions granted but not required
+	Excessive []string
+}
+
+// permission is a verb allowed on a resource, or on a non-resource URL
+type permission struct {
+	namespace string
+	group     string
+	resource  string
+	url       string
+	verb      string
+}
+
+// String returns the permission as it is declared with a RBAC marker
+func (p permission) String() string {
+	var marker string
+	if p.url != "" {
+		marker = fmt.Sprintf("urls=%s,verbs=%s", p.url, p.verb)
+	} else {
+		marker = fmt.Sprintf("groups=%s,resources=%s,verbs=%s", markerGroup(p.group), p.resource, p.verb)
+	}
+	if p.namespace != "" {
+		marker += ",namespace=" + p.namespace
+	}
+	return rbacMarkerPrefix + marker
+}
+
+// grants returns true if the permission grants the other one, considering the wildcards
+func (p permission) grants(other permission, ignoreNamespace bool) bool {
+	match := func(value, other string) bool { return value == "*" || value == other }
+	if p.url != "" || other.url != "" {
+		return p.url != "" && other.url != "" && match(p.verb, other.verb) &&
+			(p.url == other.url || strings.HasSuffix(p.url, "*") && strings.HasPrefix(other.url, p.url[:len(p.url)-1]))
+	}
+	return (ignoreNamespace || p.namespace == other.namespace) && match(p.group, other.group) &&
+		match(p.resource, other.resource) && match(p.verb, other.verb)
+}
+
+// sourcedPermission is a permission along with the location which defines or requires it
+type sourcedPermission struct {
+	permission
+	source string
+}
+
+// Validate ensures the options are valid.
+func (opts *RBACAudit) Validate() error {
+	var err error
+	opts.InputDir, err = getInputPath(opts.InputDir)
+	if err != nil {
+		return err
+	}
+	if opts.RolePath == "" {
+		opts.RolePath = DefaultRolePath
+	}
+	return nil
+}
+
+// Audit compares the permissions declared by the RBAC markers of the project with the ones of the role
+// generated by controller-gen and, if DetectCalls is set, with the calls of the controller-runtime client.
+func (opts *RBACAudit) Audit() (RBACAuditReport, error) {
+	report := RBACAuditReport{}
+
+	config, err := loadProjectConfig(opts.InputDir)
+	if err != nil {
+		return report, err
+	}
+	if err := changeWorkingDirectory(opts.InputDir); err != nil {
+		return report, err
+	}
+
+	files, err := goSourceFiles(".")
+	if err != nil {
+		return report, fmt.Errorf("failed to find the Go source files: %w", err)
+	}
+	markers, err := findRBACMarkers(files)
+	if err != nil {
+		return report, err
+	}
+
+	role, err := readRole(opts.RolePath)
+	switch {
+	case os.IsNotExist(err):
+		log.Warnf("%s was not found, run `make manifests` to compare it with the RBAC markers", opts.RolePath)
+	case err != nil:
+		return report, err
+	default:
+		var notGranted, notDeclared []sourcedPermission
+		for _, marker := range markers {
+			if !isGranted(marker.permission, role, false) {
+				notGranted = append(notGranted, marker)
+			}
+		}
+		for _, rule := range role {
+			if !isGranted(rule.permission, markers, false) {
+				notDeclared = append(notDeclared, rule)
+			}
+		}
+		for _, p := range mergeVerbs(notGranted) {
+			report.Missing = append(report.Missing, fmt.Sprintf(
+				"%s: %q is not granted by %s, run `make manifests`", p.source, p, opts.RolePath))
+		}
+		for _, p := range mergeVerbs(notDeclared) {
+			report.Excessive = append(report.Excessive, fmt.Sprintf(
+				"%s: %q is not declared by any RBAC marker", p.source, p))
+		}
+	}
+
+	if opts.DetectCalls {
+		calls, err := findClientCalls(files, apiPackages(config.Config()))
+		if err != nil {
+			return report, err
+		}
+		var notAllowed, notRequired []sourcedPermission
+		for _, call := range calls {
+			if !isGranted(call.permission, markers, true) {
+				notAllowed = append(notAllowed, call)
+			}
+		}
+		for _, marker := range markers {
+			if writeVerbs[marker.verb] && marker.url == "" && !strings.Contains(marker.resource, "/finalizers") &&
+				!isRequired(marker.permission, calls) {
+				notRequired = append(notRequired, marker)
+			}
+		}
+		for _, p := range mergeVerbs(notAllowed) {
+			report.Missing = append(report.Missing, fmt.Sprintf(
+				"%s: the call of the client requires the marker %q", p.source, p))
+		}
+		for _, p := range mergeVerbs(notRequired) {
+			report.Excessive = append(report.Excessive, fmt.Sprintf(
+				"%s: %q is not required by any call of the client", p.source, p))
+		}
+	}
+
+	// The same permission can be declared by several markers or rules of the role
+	report.Missing = unique(report.Missing)
+	report.Excessive = unique(report.Excessive)
+	return report, nil
+}
+
+// mergeVerbs merges the verbs of the permissions which only differ by their verb, e.g. the permissions
+// declared by the same marker, keeping the first occurrences in order
+func mergeVerbs(permissions []sourcedPermission) []sourcedPermission {
+	var merged []sourcedPermission
+	indexes := map[sourcedPermission]int{}
+	for _, p := range permissions {
+		key := p
+		key.verb = ""
+		if i, found := indexes[key]; found {
+			if !slices.Contains(strings.Split(merged[i].verb, ";"), p.verb) {
+				merged[i].verb += ";" + p.verb
+			}
+			continue
+		}
+		indexes[key] = len(merged)
+		merged = append(merged, p)
+	}
+	return merged
+}
+
+// isGranted returns true if any of the permissions grants the permission
+func isGranted(p permission, permissions []sourcedPermission, ignoreNamespace bool) bool {
+	for _, granted := range permissions {
+		if granted.grants(p, ignoreNamespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRequired returns true if the permission grants any of the permissions required by the calls
+func isRequired(p permission, calls []sourcedPermission) bool {
+	for _, call := range calls {
+		if p.grants(call.permission, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// goSourceFiles returns the Go source files of the project. The test files, the dependencies and the
+// hidden directories are skipped, as controller-gen does.
+func goSourceFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "bin" ||
+				name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == ".go" && !strings.HasSuffix(path, "_test.go") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// findRBACMarkers returns the permissions declared by the RBAC markers of the files
+func findRBACMarkers(files []string) ([]sourcedPermission, error) {
+	var permissions []sourcedPermission
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w", file, err)
+		}
+		for i, line := range strings.Split(string(content), "\n") {
+			line = strings.TrimSpace(line)
+			if !strings.HasPrefix(line, "//") {
+				continue
+			}
+			line = strings.TrimSpace(strings.TrimPrefix(line, "//"))
+			if !strings.HasPrefix(line, rbacMarkerPrefix) {
+				continue
+			}
+
+			source := fmt.Sprintf("%s:%d", file, i+1)
+			parsed, err := parseRBACMarker(strings.TrimPrefix(line, rbacMarkerPrefix))
+			if err != nil {
+				log.Warnf("%s: skipping the RBAC marker: %v", source, err)
+				continue
+			}
+			for _, p := range parsed {
+				permissions = append(permissions, sourcedPermission{permission: p, source: source})
+			}
+		}
+	}
+	return permissions, nil
+}
+
+// parseRBACMarker returns the permissions declared by the arguments of a RBAC marker. As controller-gen
+// does, the core group can be declared either as an empty group or as `core`.
+func parseRBACMarker(marker string) ([]permission, error) {
+	var namespace string
+	var groups, resources, urls, verbs []string
+	for _, arg := range splitRBACMarkerArgs(marker, ',') {
+		key, value, found := strings.Cut(arg, "=")
+		if !found {
+			return nil, fmt.Errorf("argument %q has no value", arg)
+		}
+		switch key {
+		case "groups":
+			groups = rbacMarkerList(value)
+		case "resources":
+			resources = rbacMarkerList(value)
+		case "urls":
+			urls = rbacMarkerList(value)
+		case "verbs":
+			verbs = rbacMarkerList(value)
+		case "namespace":
+			namespace = rbacMarkerUnquote(value)
+		}
+	}
+	if len(verbs) == 0 {
+		return nil, fmt.Errorf("the verbs are required")
+	}
+
+	var permissions []permission
+	for _, verb := range verbs {
+		for _, url := range urls {
+			permissions = append(permissions, permission{namespace: namespace, url: url, verb: verb})
+		}
+		for _, group := range groups {
+			if group == "core" {
+				group = ""
+			}
+			for _, res := range resources {
+				permissions = append(permissions, permission{
+					namespace: namespace, group: group, resource: res, verb: verb,
+				})
+			}
+		}
+	}
+	return permissions, nil
+}
+
+// splitRBACMarkerArgs splits the arguments of a marker by the separator, ignoring the separators
+// within quotes and braces
+func splitRBACMarkerArgs(args string, separator rune) []string {
+	var (
+		parts   []string
+		current strings.Builder
+		depth   int
+		quoted  bool
+	)
+	for _, char := range args {
+		switch {
+		case char == '"':
+			quoted = !quoted
+		case char == '{' && !quoted:
+			depth++
+		case char == '}' && !quoted:
+			depth--
+		case char == separator && !quoted && depth == 0:
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(char)
+	}
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
+
+// rbacMarkerList returns the values of a list argument of a marker, either in the form `a;b` or `{a,b}`
+func rbacMarkerList(value string) []string {
+	separator := ';'
+	if strings.HasPrefix(value, "{") && strings.HasSuffix(value, "}") {
+		value = strings.TrimSuffix(strings.TrimPrefix(value, "{"), "}")
+		separator = ','
+	}
+
+	var values []string
+	for _, item := range splitRBACMarkerArgs(value, separator) {
+		values = append(values, rbacMarkerUnquote(strings.TrimSpace(item)))
+	}
+	return values
+}
+
+// rbacMarkerUnquote removes the quotes of a string argument of a marker
+func rbacMarkerUnquote(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return value
+}
+
+// markerGroup returns the group as it is declared in a RBAC marker
+func markerGroup(group string) string {
+	if group == "" {
+		return "core"
+	}
+	return group
+}
+
+// readRole returns the permissions granted by the roles and cluster roles of the manifest
+func readRole(path string) ([]sourcedPermission, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := render.Objects(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	var permissions []sourcedPermission
+	for _, object := range objects {
+		if object.Kind != "ClusterRole" && object.Kind != "Role" {
+			continue
+		}
+		var role struct {
+			Rules []struct {
+				APIGroups       []string `json:"apiGroups"`
+				Resources       []string `json:"resources"`
+				NonResourceURLs []string `json:"nonResourceURLs"`
+				Verbs           []string `json:"verbs"`
+			} `json:"rules"`
+		}
+		if err := yaml.Unmarshal([]byte(object.Manifest), &role); err != nil {
+			return nil, fmt.Errorf("failed to decode the %s %s of %s: %w", object.Kind, object.Metadata.Name, path, err)
+		}
+
+		source := fmt.Sprintf("%s (%s %s)", path, object.Kind, object.Metadata.Name)
+		namespace := ""
+		if object.Kind == "Role" {
+			namespace = object.Metadata.Namespace
+		}
+		for _, rule := range role.Rules {
+			for _, verb := range rule.Verbs {
+				for _, url := range rule.NonResourceURLs {
+					permissions = append(permissions, sourcedPermission{
+						permission: permission{url: url, verb: verb}, source: source,
+					})
+				}
+				for _, group := range rule.APIGroups {
+					for _, res := range rule.Resources {
+						permissions = append(permissions, sourcedPermission{
+							permission: permission{namespace: namespace, group: group, resource: res, verb: verb},
+							source:     source,
+						})
+					}
+				}
+			}
+		}
+	}
+	return permissions, nil
+}
+
+// apiPackage is the API group of the types of a package, along with the plural of the resources of its
+// kinds which are not regular
+type apiPackage struct {
+	group   string
+	plurals map[string]string
+}
+
+// apiPackages returns the API packages of the resources tracked in the PROJECT file, by import path
+func apiPackages(cfg config.Config) map[string]apiPackage {
+	packages := map[string]apiPackage{}
+	resources, err := cfg.GetResources()
+	if err != nil {
+		return packages
+	}
+	for _, res := range resources {
+		if res.Path == "" {
+			continue
+		}
+		pkg, found := packages[res.Path]
+		if !found {
+			pkg = apiPackage{group: res.QualifiedGroup(), plurals: map[string]string{}}
+			if res.Core && res.Group == "core" {
+				pkg.group = ""
+			}
+		}
+		if res.Plural != "" {
+			pkg.plurals[res.Kind] = res.Plural
+		}
+		packages[res.Path] = pkg
+	}
+	return packages
+}
+
+// packageGroup returns the API group of the types of the package, and false if it is not an API package
+func packageGroup(importPath string, packages map[string]apiPackage) (apiPackage, bool) {
+	if pkg, found := packages[importPath]; found {
+		return pkg, true
+	}
+
+	// k8s.io/api/<group>/<version>
+	if rest, found := strings.CutPrefix(importPath, "k8s.io/api/"); found {
+		group, _, found := strings.Cut(rest, "/")
+		if !found {
+			return apiPackage{}, false
+		}
+		if apiGroup, known := coreAPIGroups[group]; known {
+			return apiPackage{group: apiGroup}, true
+		}
+		return apiPackage{group: group + ".k8s.io"}, true
+	}
+	if strings.HasPrefix(importPath, "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/") {
+		return apiPackage{group: "apiextensions.k8s.io"}, true
+	}
+	return apiPackage{}, false
+}
+
+// findClientCalls returns the permissions required by the calls of the controller-runtime client of the
+// files, e.g. `r.Get(ctx, key, &appsv1.Deployment{})`. Only the calls whose object is declared in the same
+// function with a type of a known API package are detected.
+func findClientCalls(files []string, packages map[string]apiPackage) ([]sourcedPermission, error) {
+	fset := token.NewFileSet()
+	var permissions []sourcedPermission
+	for _, file := range files {
+		parsed, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+
+		imports := make(map[string]string, len(parsed.Imports))
+		for _, spec := range parsed.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			name := filepath.Base(importPath)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			imports[name] = importPath
+		}
+
+		for _, decl := range parsed.Decls {
+			fn, isFunc := decl.(*ast.FuncDecl)
+			if !isFunc || fn.Body == nil {
+				continue
+			}
+			types := localTypes(fn)
+			ast.Inspect(fn.Body, func(node ast.Node) bool {
+				call, isCall := node.(*ast.CallExpr)
+				if !isCall {
+					return true
+				}
+				p, found := callPermission(call, types, imports, packages)
+				if found {
+					source := fmt.Sprintf("%s:%d", file, fset.Position(call.Pos()).Line)
+					permissions = append(permissions, sourcedPermission{permission: p, source: source})
+				}
+				return true
+			})
+		}
+	}
+	return permissions, nil
+}
+
+// localTypes returns the types of the parameters and of the variables declared in the function, by name
+func localTypes(fn *ast.FuncDecl) map[string]ast.Expr {
+	types := map[string]ast.Expr{}
+	for _, field := range fn.Type.Params.List {
+		for _, name := range field.Names {
+			types[name.Name] = field.Type
+		}
+	}
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if n.Type != nil {
+					types[name.Name] = n.Type
+				} else if i < len(n.Values) {
+					types[name.Name] = literalType(n.Values[i])
+				}
+			}
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE || len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			for i, lhs := range n.Lhs {
+				if ident, isIdent := lhs.(*ast.Ident); isIdent {
+					if t := literalType(n.Rhs[i]); t != nil {
+						types[ident.Name] = t
+					}
+				}
+			}
+		}
+		return true
+	})
+	return types
+}
+
+// literalType returns the type of a composite literal, or of its address, and nil for other expressions
+func literalType(expr ast.Expr) ast.Expr {
+	if unary, isUnary := expr.(*ast.UnaryExpr); isUnary && unary.Op == token.AND {
+		expr = unary.X
+	}
+	if lit, isLit := expr.(*ast.CompositeLit); isLit {
+		return lit.Type
+	}
+	return nil
+}
+
+// callPermission returns the permission required by a call of the controller-runtime client, and false if
+// the call is not a call of the client on an object of a known API package
+func callPermission(call *ast.CallExpr, types map[string]ast.Expr, imports map[string]string,
+	packages map[string]apiPackage,
+) (permission, bool) {
+	selector, isSelector := call.Fun.(*ast.SelectorExpr)
+	if !isSelector {
+		return permission{}, false
+	}
+	verb, isClientMethod := clientVerbs[selector.Sel.Name]
+	if !isClientMethod {
+		return permission{}, false
+	}
+	// Get receives the object after the key, the other methods right after the context
+	objectArg := 1
+	if selector.Sel.Name == "Get" {
+		objectArg = 2
+	}
+	if len(call.Args) <= objectArg {
+		return permission{}, false
+	}
+
+	// The type of the object is either the type of a literal or of a local variable
+	typ := literalType(call.Args[objectArg])
+	if typ == nil {
+		arg := call.Args[objectArg]
+		if unary, isUnary := arg.(*ast.UnaryExpr); isUnary && unary.Op == token.AND {
+			arg = unary.X
+		}
+		if ident, isIdent := arg.(*ast.Ident); isIdent {
+			typ = types[ident.Name]
+		}
+	}
+	if star, isStar := typ.(*ast.StarExpr); isStar {
+		typ = star.X
+	}
+	typeSelector, isTypeSelector := typ.(*ast.SelectorExpr)
+	if !isTypeSelector {
+		return permission{}, false
+	}
+	pkgIdent, isIdent := typeSelector.X.(*ast.Ident)
+	if !isIdent {
+		return permission{}, false
+	}
+	pkg, isAPIPackage := packageGroup(imports[pkgIdent.Name], packages)
+	if !isAPIPackage {
+		return permission{}, false
+	}
+
+	kind := typeSelector.Sel.Name
+	if selector.Sel.Name == "List" || selector.Sel.Name == "DeleteAllOf" {
+		kind = strings.TrimSuffix(kind, "List")
+	}
+	plural, found := pkg.plurals[kind]
+	if !found {
+		plural = resource.RegularPlural(kind)
+	}
+
+	// The calls of the status client, e.g. `r.Status().Update(ctx, obj)`, require the status subresource
+	if inner, isCall := selector.X.(*ast.CallExpr); isCall {
+		if subresource, isSelector := inner.Fun.(*ast.SelectorExpr); isSelector && subresource.Sel.Name == "Status" {
+			plural += "/status"
+		}
+	}
+	return permission{group: pkg.group, resource: plural, verb: verb}, true
+}
+
+// unique removes the duplicate values, keeping the first occurrences in order
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := values[:0]
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RBAC audit", func() {
+	DescribeTable("parseRBACMarker",
+		func(marker string, expected []permission) {
+			permissions, err := parseRBACMarker(marker)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(permissions).To(Equal(expected))
+		},
+		Entry("a marker with lists separated by semicolons",
+			"groups=apps,resources=deployments;replicasets,verbs=get;list",
+			[]permission{
+				{group: "apps", resource: "deployments", verb: "get"},
+				{group: "apps", resource: "replicasets", verb: "get"},
+				{group: "apps", resource: "deployments", verb: "list"},
+				{group: "apps", resource: "replicasets", verb: "list"},
+			}),
+		Entry("a marker with lists in braces",
+			`groups={"",apps},resources=pods,verbs={get}`,
+			[]permission{
+				{group: "", resource: "pods", verb: "get"},
+				{group: "apps", resource: "pods", verb: "get"},
+			}),
+		Entry("the core group",
+			"groups=core,resources=configmaps,verbs=create",
+			[]permission{{group: "", resource: "configmaps", verb: "create"}}),
+		Entry("a namespaced marker",
+			`groups=coordination.k8s.io,resources=leases,verbs=update,namespace="system"`,
+			[]permission{{namespace: "system", group: "coordination.k8s.io", resource: "leases", verb: "update"}}),
+		Entry("a non-resource URL",
+			"urls=/metrics,verbs=get",
+			[]permission{{url: "/metrics", verb: "get"}}),
+	)
+
+	DescribeTable("parseRBACMarker with invalid markers",
+		func(marker string) {
+			_, err := parseRBACMarker(marker)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("without verbs", "groups=apps,resources=deployments"),
+		Entry("with an argument without value", "groups=apps,resources,verbs=get"),
+	)
+
+	DescribeTable("permission.grants",
+		func(granted, required permission, ignoreNamespace, expected bool) {
+			Expect(granted.grants(required, ignoreNamespace)).To(Equal(expected))
+		},
+		Entry("the same permission",
+			permission{group: "apps", resource: "deployments", verb: "get"},
+			permission{group: "apps", resource: "deployments", verb: "get"}, false, true),
+		Entry("another verb",
+			permission{group: "apps", resource: "deployments", verb: "get"},
+			permission{group: "apps", resource: "deployments", verb: "list"}, false, false),
+		Entry("another group with the same resource",
+			permission{group: "apps", resource: "deployments", verb: "get"},
+			permission{group: "extensions", resource: "deployments", verb: "get"}, false, false),
+		Entry("a subresource of the resource",
+			permission{group: "apps", resource: "deployments", verb: "update"},
+			permission{group: "apps", resource: "deployments/status", verb: "update"}, false, false),
+		Entry("wildcards",
+			permission{group: "*", resource: "*", verb: "*"},
+			permission{group: "apps", resource: "deployments", verb: "delete"}, false, true),
+		Entry("another namespace",
+			permission{namespace: "system", group: "", resource: "configmaps", verb: "get"},
+			permission{group: "", resource: "configmaps", verb: "get"}, false, false),
+		Entry("another namespace when the namespaces are ignored",
+			permission{namespace: "system", group: "", resource: "configmaps", verb: "get"},
+			permission{group: "", resource: "configmaps", verb: "get"}, true, true),
+		Entry("a URL prefix",
+			permission{url: "/metrics*", verb: "get"},
+			permission{url: "/metrics/cadvisor", verb: "get"}, false, true),
+		Entry("another URL",
+			permission{url: "/metrics", verb: "get"},
+			permission{url: "/healthz", verb: "get"}, false, false),
+		Entry("a URL and a resource",
+			permission{url: "*", verb: "*"},
+			permission{group: "", resource: "pods", verb: "get"}, false, false),
+	)
+
+	It("should merge the verbs of the same permission", func() {
+		merged := mergeVerbs([]sourcedPermission{
+			{permission: permission{group: "apps", resource: "deployments", verb: "get"}, source: "a.go:1"},
+			{permission: permission{group: "apps", resource: "deployments", verb: "list"}, source: "a.go:1"},
+			{permission: permission{group: "apps", resource: "deployments", verb: "get"}, source: "b.go:2"},
+			{permission: permission{group: "apps", resource: "deployments", verb: "get"}, source: "a.go:1"},
+		})
+		Expect(merged).To(HaveLen(2))
+		Expect(merged[0].String()).To(Equal("+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list"))
+		Expect(merged[0].source).To(Equal("a.go:1"))
+		Expect(merged[1].source).To(Equal("b.go:2"))
+	})
+
+	Context("Audit", func() {
+		const (
+			controller = `package controller
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	crewv1 "example.org/project/api/v1"
+)
+
+// +kubebuilder:rbac:groups=crew.example.org,resources=captains,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=crew.example.org,resources=captains/status,verbs=get;update
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;delete
+
+func (r *CaptainReconciler) Reconcile(ctx context.Context, key string) error {
+	captain := &crewv1.Captain{}
+	if err := r.Get(ctx, key, captain); err != nil {
+		return err
+	}
+	if err := r.Status().Update(ctx, captain); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, &appsv1.Deployment{}); err != nil {
+		return err
+	}
+	var secret corev1.Secret
+	return r.Patch(ctx, &secret, nil)
+}
+`
+			role = `---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: manager-role
+rules:
+- apiGroups:
+  - apps
+  resources:
+  - deployments
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - watch
+- apiGroups:
+  - crew.example.org
+  resources:
+  - captains
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - crew.example.org
+  resources:
+  - captains/status
+  verbs:
+  - get
+  - update
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  verbs:
+  - get
+`
+		)
+
+		var dir string
+
+		BeforeEach(func() {
+			wd, err := os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(os.Chdir, wd)
+
+			dir = GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(dir, "PROJECT"), []byte(projectFile), 0o644)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(dir, "internal", "controller"), 0o755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "internal", "controller", "captain_controller.go"),
+				[]byte(controller), 0o644)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(dir, "config", "rbac"), 0o755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "config", "rbac", "role.yaml"), []byte(role), 0o644)).To(Succeed())
+		})
+
+		audit := func(detectCalls bool) RBACAuditReport {
+			opts := RBACAudit{InputDir: dir, DetectCalls: detectCalls}
+			Expect(opts.Validate()).To(Succeed())
+			report, err := opts.Audit()
+			Expect(err).NotTo(HaveOccurred())
+			return report
+		}
+
+		It("should compare the RBAC markers with the role", func() {
+			report := audit(false)
+			Expect(report.Missing).To(Equal([]string{
+				`internal/controller/captain_controller.go:12: ` +
+					`"+kubebuilder:rbac:groups=crew.example.org,resources=captains,verbs=update" ` +
+					"is not granted by config/rbac/role.yaml, run `make manifests`",
+			}))
+			Expect(report.Excessive).To(Equal([]string{
+				`config/rbac/role.yaml (ClusterRole manager-role): ` +
+					`"+kubebuilder:rbac:groups=core,resources=pods,verbs=get" is not declared by any RBAC marker`,
+			}))
+		})
+
+		It("should compare the RBAC markers with the calls of the client", func() {
+			report := audit(true)
+			Expect(report.Missing).To(ContainElement(
+				`internal/controller/captain_controller.go:28: the call of the client requires the marker ` +
+					`"+kubebuilder:rbac:groups=core,resources=secrets,verbs=patch"`))
+			Expect(report.Excessive).To(ContainElement(
+				`internal/controller/captain_controller.go:14: ` +
+					`"+kubebuilder:rbac:groups=apps,resources=deployments,verbs=delete" ` +
+					"is not required by any call of the client"))
+			Expect(report.Excessive).NotTo(ContainElement(ContainSubstring("captains/status")))
+		})
+
+		It("should only warn when the role was not generated", func() {
+			Expect(os.Remove(filepath.Join(dir, "config", "rbac", "role.yaml"))).To(Succeed())
+			report := audit(false)
+			Expect(report.Missing).To(BeEmpty())
+			Expect(report.Excessive).To(BeEmpty())
+		})
+	})
+})
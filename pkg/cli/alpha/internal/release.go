@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// releaseURLFormat is the URL of the kubebuilder binary of a release for an OS and an architecture
+const releaseURLFormat = "https://github.com/kubernetes-sigs/kubebuilder/releases/download/%s/kubebuilder_%s_%s"
+
+// regenerate re-scaffolds the project described by the PROJECT file in inputDir into dir
+// with the kubebuilder binary found in binDir.
+func regenerate(inputDir, dir, binDir string) error {
+	cmd := exec.Command(filepath.Join(binDir, "kubebuilder"), "alpha", "generate",
+		"--input-dir", inputDir, "--output-dir", dir)
+	cmd.Dir = dir
+	// alpha generate calls kubebuilder by name to scaffold the project
+	cmd.Env = append(os.Environ(), fmt.Sprintf("PATH=%s%c%s", binDir, os.PathListSeparator, os.Getenv("PATH")))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	log.Println("kubebuilder alpha generate:\n$ " + strings.Join(cmd.Args, " "))
+	return cmd.Run()
+}
+
+// downloadKubebuilder downloads the given release of the kubebuilder binary into dir.
+func downloadKubebuilder(version, dir string) (string, error) {
+	url := fmt.Sprintf(releaseURLFormat, version, runtime.GOOS, runtime.GOARCH)
+	log.Infof("Downloading kubebuilder %s from %s", version, url)
+
+	resp, err := http.Get(url) //nolint:gosec
+	if err != nil {
+		return "", fmt.Errorf("failed to download kubebuilder %s: %w", version, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download kubebuilder %s: %s", version, resp.Status)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	binary, err := os.OpenFile(filepath.Join(dir, "kubebuilder"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return "", fmt.Errorf("failed to create the kubebuilder binary: %w", err)
+	}
+	defer func() {
+		_ = binary.Close()
+	}()
+	if _, err := io.Copy(binary, resp.Body); err != nil {
+		return "", fmt.Errorf("failed to write the kubebuilder binary: %w", err)
+	}
+
+	return dir, nil
+}
@@ -0,0 +1,533 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/scanner"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"golang.org/x/mod/module"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	storeyaml "sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	"sigs.k8s.io/kubebuilder/v4/pkg/internal/validation"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/util"
+)
+
+// Rename store the required info for the rename command
+type Rename struct {
+	InputDir string
+	// Domain is the new domain of the project, the domain is not changed when it is empty
+	Domain string
+	// Repo is the new module path of the project, the module path is not changed when it is empty
+	Repo string
+	// ProjectName is the new name of the project, the name is not changed when it is empty
+	ProjectName string
+	// DryRun prints the changes instead of writing them
+	DryRun bool
+}
+
+// Validate ensures the options are valid.
+func (opts *Rename) Validate() error {
+	var err error
+	opts.InputDir, err = getInputPath(opts.InputDir)
+	if err != nil {
+		return err
+	}
+
+	if opts.Domain == "" && opts.Repo == "" && opts.ProjectName == "" {
+		return errors.New("at least one of --domain, --repo or --project-name must be set")
+	}
+	if opts.Domain != "" {
+		if errs := validation.IsDNS1123Subdomain(opts.Domain); len(errs) != 0 {
+			return fmt.Errorf("domain (%s) is invalid: %s", opts.Domain, strings.Join(errs, ", "))
+		}
+	}
+	if opts.Repo != "" {
+		if err := module.CheckImportPath(opts.Repo); err != nil {
+			return fmt.Errorf("repo (%s) is invalid: %w", opts.Repo, err)
+		}
+	}
+	if opts.ProjectName != "" {
+		if errs := validation.IsDNS1123Label(opts.ProjectName); len(errs) != 0 {
+			return fmt.Errorf("project name (%s) is invalid: %s", opts.ProjectName, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// Rename changes the domain, the module path and the name of the project in the PROJECT file and
+// in the files of the project. Only the occurrences derived from the values of the PROJECT file by
+// the scaffolds are replaced, e.g. the qualified groups of the APIs and the prefix of the manifests,
+// so that the content of the users which happens to contain the same words is not changed.
+func (opts *Rename) Rename() error {
+	projectStore, err := loadProjectConfig(opts.InputDir)
+	if err != nil {
+		return err
+	}
+	cfg := projectStore.Config()
+
+	r, err := newRenamer(cfg, opts.Domain, opts.Repo, opts.ProjectName)
+	if err != nil {
+		return err
+	}
+	if r.empty() {
+		return errors.New("the project already has the informed domain, repo and project name")
+	}
+
+	fs := afero.NewBasePathFs(afero.NewOsFs(), opts.InputDir)
+	changes, err := r.renameFiles(fs)
+	if err != nil {
+		return err
+	}
+
+	before, err := cfg.MarshalYAML()
+	if err != nil {
+		return fmt.Errorf("failed to marshal the PROJECT file: %w", err)
+	}
+	if err := r.renameConfig(cfg); err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		after, err := cfg.MarshalYAML()
+		if err != nil {
+			return fmt.Errorf("failed to marshal the PROJECT file: %w", err)
+		}
+		fmt.Print(util.UnifiedDiff(storeyaml.DefaultPath, string(before), string(after)))
+		for _, change := range changes {
+			if change.newPath != change.path {
+				fmt.Printf("rename %s => %s\n", change.path, change.newPath)
+			}
+			fmt.Print(util.UnifiedDiff(change.newPath, change.before, change.after))
+		}
+		return nil
+	}
+
+	for _, change := range changes {
+		if err := afero.WriteFile(fs, change.path, []byte(change.after), change.mode); err != nil {
+			return fmt.Errorf("unable to write %s: %w", change.path, err)
+		}
+		if change.newPath != change.path {
+			if err := fs.Rename(change.path, change.newPath); err != nil {
+				return fmt.Errorf("unable to rename %s to %s: %w", change.path, change.newPath, err)
+			}
+		}
+	}
+	if err := saveProjectConfig(projectStore, opts.InputDir); err != nil {
+		return err
+	}
+
+	log.Infof("%d files of the project were updated", len(changes))
+	fmt.Println("Run `go mod tidy` and `make manifests generate` to regenerate the files which depend on the " +
+		"renamed values. The leader election ID of the manager is not changed, so that the manager which is " +
+		"running and the renamed one do not hold the lock at the same time during the rollout.")
+	return nil
+}
+
+// fileChange is a file of the project changed by the rename
+type fileChange struct {
+	path    string
+	newPath string
+	before  string
+	after   string
+	mode    os.FileMode
+}
+
+// tokenReplacement replaces the occurrences of a token which are accepted by the match function,
+// which is given the content preceding the occurrence and the content following it
+type tokenReplacement struct {
+	old, new string
+	match    func(before, rest string) bool
+}
+
+// renamer replaces the occurrences of the domain, the module path and the name of the project
+type renamer struct {
+	domain, repo, projectName struct{ old, new string }
+
+	// domainTokens are the qualified groups of the APIs of the project and the webhook paths built from them
+	domainTokens []tokenReplacement
+	// repoTokens are the module path in the imports, the go.mod files and the manifests
+	repoTokens []tokenReplacement
+	// projectNameTokens are the names of the manifests prefixed with the name of the project, the labels
+	// and the image of the project
+	projectNameTokens []tokenReplacement
+}
+
+func newRenamer(cfg config.Config, domain, repo, projectName string) (*renamer, error) {
+	r := &renamer{}
+	if domain != "" && domain != cfg.GetDomain() {
+		r.domain.old, r.domain.new = cfg.GetDomain(), domain
+	}
+	if repo != "" && repo != cfg.GetRepository() {
+		r.repo.old, r.repo.new = cfg.GetRepository(), repo
+	}
+	if projectName != "" && projectName != cfg.GetProjectName() {
+		r.projectName.old, r.projectName.new = cfg.GetProjectName(), projectName
+	}
+
+	if r.domain.old != "" {
+		resources, err := cfg.GetResources()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the resources of the project: %w", err)
+		}
+		groups := map[string]string{"config." + r.domain.old: "config." + r.domain.new}
+		for _, res := range resources {
+			if res.IsExternal() || res.Domain != r.domain.old {
+				continue
+			}
+			newGVK := res.GVK
+			newGVK.Domain = r.domain.new
+			groups[res.QualifiedGroup()] = newGVK.QualifiedGroup()
+		}
+		r.domainTokens = qualifiedGroupTokens(groups)
+	}
+	if r.repo.old != "" {
+		r.repoTokens = []tokenReplacement{{old: r.repo.old, new: r.repo.new, match: isModulePath}}
+	}
+	if r.projectName.old != "" {
+		r.projectNameTokens = []tokenReplacement{{old: r.projectName.old, new: r.projectName.new, match: isProjectName}}
+	}
+	return r, nil
+}
+
+// empty returns true when there is nothing to rename
+func (r *renamer) empty() bool {
+	return r.domain.old == "" && r.repo.old == "" && r.projectName.old == ""
+}
+
+// qualifiedGroupTokens returns the replacements of the qualified groups, longest first so that the groups
+// which are suffixes of other groups are replaced last, and of the paths of their webhooks
+func qualifiedGroupTokens(groups map[string]string) []tokenReplacement {
+	olds := make([]string, 0, len(groups))
+	for old := range groups {
+		olds = append(olds, old)
+	}
+	sort.Slice(olds, func(i, j int) bool {
+		if len(olds[i]) != len(olds[j]) {
+			return len(olds[i]) > len(olds[j])
+		}
+		return olds[i] < olds[j]
+	})
+
+	tokens := make([]tokenReplacement, 0, 3*len(olds))
+	for _, old := range olds {
+		tokens = append(tokens, tokenReplacement{old: old, new: groups[old], match: isQualifiedGroup})
+		// The webhook paths are built with the dashed qualified group, e.g. /mutate-ship-example-com-v1-frigate
+		oldDashed, newDashed := strings.ReplaceAll(old, ".", "-"), strings.ReplaceAll(groups[old], ".", "-")
+		for _, prefix := range []string{"/mutate-", "/validate-"} {
+			tokens = append(tokens, tokenReplacement{
+				old: prefix + oldDashed + "-", new: prefix + newDashed + "-", match: always,
+			})
+		}
+	}
+	return tokens
+}
+
+func always(string, string) bool { return true }
+
+// lastByte returns the last byte of the content, or 0 when it is empty
+func lastByte(content string) byte {
+	if content == "" {
+		return 0
+	}
+	return content[len(content)-1]
+}
+
+func isAlphanumeric(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// isQualifiedGroup matches the qualified groups in the manifests, the markers, the names of
+// the CRDs (<plural>.<group>) and of their files (<group>_<plural>.yaml)
+func isQualifiedGroup(before, rest string) bool {
+	prev := lastByte(before)
+	if isAlphanumeric(prev) || prev == '-' || prev == '_' {
+		return false
+	}
+	if rest == "" {
+		return true
+	}
+	// A dot is part of the group, unless it ends a sentence
+	if rest[0] == '.' {
+		return len(rest) == 1 || !(isAlphanumeric(rest[1]) || rest[1] == '-')
+	}
+	return !(isAlphanumeric(rest[0]) || rest[0] == '-')
+}
+
+// isModulePath matches the module path as a whole word, in the go.mod files and in the imports
+// of the packages of the project
+func isModulePath(before, rest string) bool {
+	if prev := lastByte(before); prev != 0 && !strings.ContainsRune(" \t\n\"'`=", rune(prev)) {
+		return false
+	}
+	return rest == "" || strings.ContainsRune("/ \t\r\n\"'`", rune(rest[0]))
+}
+
+// isProjectName matches the name of the project when it is the whole value, e.g. in the labels,
+// or the prefix of a name, e.g. <project-name>-system, or the name of the image of the project
+func isProjectName(before, rest string) bool {
+	prev := lastByte(before)
+	// The image of the project, e.g. example.com/<project-name>:v0.0.1
+	if len(rest) > 1 && rest[0] == ':' && rest[1] != ' ' && rest[1] != '\n' {
+		return prev == 0 || strings.ContainsRune(" \t\n\"'`=/", rune(prev))
+	}
+	// The names prefixed with the project name, e.g. <project-name>-system or namePrefix: <project-name>-,
+	// also in the references to the objects, e.g. servicemonitor/<project-name>-controller-manager, but
+	// not in the paths of the other modules or hosts, e.g. example.com/<project-name>-tools
+	if strings.HasPrefix(rest, "-") {
+		if prev == '/' {
+			path := before[strings.LastIndexAny(before, " \t\n\"'`=([,")+1:]
+			return !strings.Contains(path, ".")
+		}
+		return prev == 0 || strings.ContainsRune(" \t\n\"'`=([,", rune(prev))
+	}
+	if prev != 0 && !strings.ContainsRune(" \t\n\"'`=([,", rune(prev)) {
+		return false
+	}
+	switch {
+	case rest == "":
+		return true
+	case strings.HasPrefix(rest, ".{{"):
+		return true
+	case strings.ContainsRune("\"'`,)]", rune(rest[0])):
+		return true
+	}
+	// The end of the line, with the trailing spaces
+	line, _, _ := strings.Cut(rest, "\n")
+	return strings.TrimSpace(line) == ""
+}
+
+// replaceTokens replaces the occurrences of the tokens in the content
+func replaceTokens(content string, tokens []tokenReplacement) string {
+	for _, t := range tokens {
+		var b strings.Builder
+		last := 0
+		for i := 0; i < len(content); {
+			idx := strings.Index(content[i:], t.old)
+			if idx < 0 {
+				break
+			}
+			start, end := i+idx, i+idx+len(t.old)
+			if t.match(content[:start], content[end:]) {
+				b.WriteString(content[last:start])
+				b.WriteString(t.new)
+				last = end
+			}
+			i = end
+		}
+		if last > 0 {
+			b.WriteString(content[last:])
+			content = b.String()
+		}
+	}
+	return content
+}
+
+// replaceInGoStrings replaces the occurrences of the tokens in the string literals of a Go file only, so
+// that the identifiers and the comments are not changed
+func replaceInGoStrings(content string, tokens []tokenReplacement) string {
+	src := []byte(content)
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, src, nil, 0)
+
+	var b strings.Builder
+	last := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok != token.STRING {
+			continue
+		}
+		offset := file.Offset(pos)
+		b.WriteString(content[last:offset])
+		b.WriteString(replaceTokens(lit, tokens))
+		last = offset + len(lit)
+	}
+	b.WriteString(content[last:])
+	return b.String()
+}
+
+// skipDir returns true for the directories which are not part of the sources of the project
+func skipDir(name string) bool {
+	switch name {
+	case ".github", ".devcontainer":
+		return false
+	case "bin", "vendor", "node_modules":
+		return true
+	}
+	return strings.HasPrefix(name, ".")
+}
+
+// skipFile returns true for the files which are not renamed: the PROJECT file is updated from the
+// config, the checksums of the dependencies are regenerated by go mod tidy and the documentation
+// is the content of the users
+func skipFile(path string) bool {
+	switch filepath.Base(path) {
+	case storeyaml.DefaultPath, "go.sum", "go.work.sum":
+		return true
+	}
+	return filepath.Ext(path) == ".md"
+}
+
+// renameFiles returns the changes of the files of the project
+func (r *renamer) renameFiles(fs afero.Fs) ([]fileChange, error) {
+	var changes []fileChange
+	err := afero.Walk(fs, ".", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != "." && skipDir(info.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() || skipFile(path) {
+			return nil
+		}
+
+		content, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", path, err)
+		}
+		// Skip the binary files
+		if bytes.IndexByte(content, 0) >= 0 {
+			return nil
+		}
+
+		change := fileChange{
+			path:    path,
+			newPath: filepath.Join(filepath.Dir(path), replaceTokens(info.Name(), r.domainTokens)),
+			before:  string(content),
+			after:   r.renameContent(path, string(content)),
+			mode:    info.Mode(),
+		}
+		if change.after != change.before || change.newPath != change.path {
+			changes = append(changes, change)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to rename the files of the project: %w", err)
+	}
+	return changes, nil
+}
+
+// renameContent replaces the occurrences of the renamed values in the content of a file
+func (r *renamer) renameContent(path, content string) string {
+	renamed := replaceTokens(content, r.domainTokens)
+	renamed = replaceTokens(renamed, r.repoTokens)
+	if filepath.Ext(path) != ".go" {
+		// The go.mod files only contain the module paths
+		if base := filepath.Base(path); base != "go.mod" && base != "go.work" {
+			renamed = replaceTokens(renamed, r.projectNameTokens)
+		}
+		return renamed
+	}
+
+	renamed = replaceInGoStrings(renamed, r.projectNameTokens)
+	if renamed == content || r.repo.old == "" {
+		return renamed
+	}
+	// Sort the imports whose module path changed
+	if formatted, err := format.Source([]byte(renamed)); err == nil {
+		renamed = string(formatted)
+	}
+	return renamed
+}
+
+// renameConfig sets the renamed values in the PROJECT file, including the domain and the path of the
+// resources of the project and of the configuration of the plugins
+func (r *renamer) renameConfig(cfg config.Config) error {
+	if r.domain.old != "" {
+		if err := cfg.SetDomain(r.domain.new); err != nil {
+			return fmt.Errorf("failed to set the domain: %w", err)
+		}
+	}
+	if r.repo.old != "" {
+		if err := cfg.SetRepository(r.repo.new); err != nil {
+			return fmt.Errorf("failed to set the repo: %w", err)
+		}
+	}
+	if r.projectName.old != "" {
+		if err := cfg.SetProjectName(r.projectName.new); err != nil {
+			return fmt.Errorf("failed to set the project name: %w", err)
+		}
+	}
+
+	// The GVK of the resources can not be changed through the config, so the resources are
+	// renamed in the content of the PROJECT file
+	content, err := cfg.MarshalYAML()
+	if err != nil {
+		return fmt.Errorf("failed to marshal the PROJECT file: %w", err)
+	}
+	var project map[string]interface{}
+	if err := yaml.Unmarshal(content, &project); err != nil {
+		return fmt.Errorf("failed to unmarshal the PROJECT file: %w", err)
+	}
+	r.renameResources(project["resources"])
+	r.renameResources(project["plugins"])
+	if content, err = yaml.Marshal(project); err != nil {
+		return fmt.Errorf("failed to marshal the PROJECT file: %w", err)
+	}
+	if err := cfg.UnmarshalYAML(content); err != nil {
+		return fmt.Errorf("failed to update the resources of the PROJECT file: %w", err)
+	}
+	return nil
+}
+
+// renameResources sets the domain and the path of the resources found in the value, which are
+// the maps with a domain, skipping the external resources
+func (r *renamer) renameResources(value interface{}) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			r.renameResources(item)
+		}
+	case map[string]interface{}:
+		if external, _ := v["external"].(bool); external {
+			return
+		}
+		if domain, ok := v["domain"].(string); ok && r.domain.old != "" && domain == r.domain.old {
+			v["domain"] = r.domain.new
+		}
+		if path, ok := v["path"].(string); ok && r.repo.old != "" {
+			if path == r.repo.old || strings.HasPrefix(path, r.repo.old+"/") {
+				v["path"] = r.repo.new + strings.TrimPrefix(path, r.repo.old)
+			}
+		}
+		for _, item := range v {
+			r.renameResources(item)
+		}
+	}
+}
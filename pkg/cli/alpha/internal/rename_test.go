@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+// readFiles returns the content of the files of the directory by path
+func readFiles(dir string) map[string]string {
+	files := make(map[string]string)
+	Expect(filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[path] = string(content)
+		return nil
+	})).To(Succeed())
+	return files
+}
+
+var _ = Describe("Rename", func() {
+	DescribeTable("should only replace the module path as a whole",
+		func(content, expected string) {
+			tokens := []tokenReplacement{{old: "example.com/project", new: "example.org/ship", match: isModulePath}}
+			Expect(replaceTokens(content, tokens)).To(Equal(expected))
+		},
+		Entry("the module of go.mod", "module example.com/project\n", "module example.org/ship\n"),
+		Entry("an import", `import "example.com/project/api/v1"`, `import "example.org/ship/api/v1"`),
+		Entry("an aliased import", `crewv1 "example.com/project/api/crew/v1"`, `crewv1 "example.org/ship/api/crew/v1"`),
+		Entry("the replace of a group module", "replace example.com/project/api/crew => ./api/crew",
+			"replace example.org/ship/api/crew => ./api/crew"),
+		Entry("a value of a manifest", "repo: example.com/project\n", "repo: example.org/ship\n"),
+		Entry("a module with the module path as prefix", "require example.com/project-tools v1.0.0",
+			"require example.com/project-tools v1.0.0"),
+		Entry("a longer path element", `import "example.com/projects/api"`, `import "example.com/projects/api"`),
+		Entry("a module with the module path as suffix", `import "github.com/example.com/project/api"`,
+			`import "github.com/example.com/project/api"`),
+		Entry("a module with the module path as a substring", `import "myexample.com/project/api"`,
+			`import "myexample.com/project/api"`),
+		Entry("a URL", "https://example.com/project", "https://example.com/project"),
+	)
+
+	DescribeTable("should only replace the name of the project where it is the name or a prefix",
+		func(content, expected string) {
+			tokens := []tokenReplacement{{old: "project", new: "ship", match: isProjectName}}
+			Expect(replaceTokens(content, tokens)).To(Equal(expected))
+		},
+		Entry("a label", "app.kubernetes.io/name: project\n", "app.kubernetes.io/name: ship\n"),
+		Entry("a label with trailing spaces", "app.kubernetes.io/name: project  \n", "app.kubernetes.io/name: ship  \n"),
+		Entry("a quoted value", `"app.kubernetes.io/name": "project",`, `"app.kubernetes.io/name": "ship",`),
+		Entry("the name prefix", "namePrefix: project-\n", "namePrefix: ship-\n"),
+		Entry("the namespace", "namespace: project-system\n", "namespace: ship-system\n"),
+		Entry("the image", "IMG ?= example.com/project:v0.0.1\n", "IMG ?= example.com/ship:v0.0.1\n"),
+		Entry("a template of the chart", "{{- define \"project.name\" -}}", "{{- define \"project.name\" -}}"),
+		Entry("a template of the chart using the name", "name: project.{{ .Release.Name }}",
+			"name: ship.{{ .Release.Name }}"),
+		Entry("a reference to an object", "servicemonitor/project-controller-manager-metrics-monitor\n",
+			"servicemonitor/ship-controller-manager-metrics-monitor\n"),
+		Entry("a module with the name as prefix", "tools: example.com/project-tools\n",
+			"tools: example.com/project-tools\n"),
+		Entry("a repository with the name as prefix", "- github.com/org/project-tools\n",
+			"- github.com/org/project-tools\n"),
+		Entry("a word of a sentence", "# the project manages the crew\n", "# the project manages the crew\n"),
+		Entry("a longer word", "name: projectile\n", "name: projectile\n"),
+		Entry("a suffix", "name: my-project\n", "name: my-project\n"),
+		Entry("a file name", "- project.yaml\n", "- project.yaml\n"),
+		Entry("a path element", "path: /opt/project/bin\n", "path: /opt/project/bin\n"),
+	)
+
+	Context("with the qualified groups", func() {
+		var tokens []tokenReplacement
+
+		BeforeEach(func() {
+			cfg := cfgv3.New()
+			Expect(cfg.SetDomain("example.com")).To(Succeed())
+			Expect(cfg.SetRepository("example.com/project")).To(Succeed())
+			for _, res := range []resource.Resource{
+				{GVK: resource.GVK{Group: "crew", Domain: "example.com", Version: "v1", Kind: "Captain"}},
+				{GVK: resource.GVK{Group: "sea.crew", Domain: "example.com", Version: "v1", Kind: "Sailor"}},
+				// The same group in another domain, e.g. an API of another project
+				{
+					GVK:      resource.GVK{Group: "crew", Domain: "partner.io", Version: "v1", Kind: "Pilot"},
+					External: true,
+					Path:     "partner.io/api/crew/v1",
+				},
+			} {
+				Expect(cfg.AddResource(res)).To(Succeed())
+			}
+
+			r, err := newRenamer(cfg, "example.org", "", "")
+			Expect(err).NotTo(HaveOccurred())
+			tokens = r.domainTokens
+		})
+
+		DescribeTable("should only replace the groups of the project",
+			func(content, expected string) {
+				Expect(replaceTokens(content, tokens)).To(Equal(expected))
+			},
+			Entry("a group", "group: crew.example.com\n", "group: crew.example.org\n"),
+			Entry("a group list", "- crew.example.com\n- sea.crew.example.com\n",
+				"- crew.example.org\n- sea.crew.example.org\n"),
+			Entry("the name of a CRD", "name: captains.crew.example.com\n", "name: captains.crew.example.org\n"),
+			Entry("the file of a CRD", "- bases/crew.example.com_captains.yaml\n", "- bases/crew.example.org_captains.yaml\n"),
+			Entry("a marker", "+groupName=crew.example.com\n", "+groupName=crew.example.org\n"),
+			Entry("a group ending a sentence", "# the APIs of crew.example.com.\n", "# the APIs of crew.example.org.\n"),
+			Entry("the group of the component config", "apiVersion: config.example.com/v1\n",
+				"apiVersion: config.example.org/v1\n"),
+			Entry("a webhook path", "path=/mutate-crew-example-com-v1-captain,", "path=/mutate-crew-example-org-v1-captain,"),
+			Entry("a validating webhook path", "path: /validate-sea-crew-example-com-v1-sailor\n",
+				"path: /validate-sea-crew-example-org-v1-sailor\n"),
+			Entry("the domain alone", "domain: example.com\n", "domain: example.com\n"),
+			Entry("the group in another domain", "group: crew.partner.io\n", "group: crew.partner.io\n"),
+			Entry("a longer domain", "host: crew.example.community\n", "host: crew.example.community\n"),
+			Entry("a subdomain of the group", "host: crew.example.com.au\n", "host: crew.example.com.au\n"),
+			Entry("a group with the group as suffix", "group: mycrew.example.com\n", "group: mycrew.example.com\n"),
+			Entry("a dashed group", "name: my-crew.example.com\n", "name: my-crew.example.com\n"),
+			Entry("an URL of the domain", "see https://example.com/crew\n", "see https://example.com/crew\n"),
+		)
+	})
+
+	It("should only replace the name of the project in the strings of the Go files", func() {
+		content := `package main
+
+// project is the name of the project
+var project = "project"
+`
+		tokens := []tokenReplacement{{old: "project", new: "ship", match: isProjectName}}
+		Expect(replaceInGoStrings(content, tokens)).To(Equal(`package main
+
+// project is the name of the project
+var project = "ship"
+`))
+	})
+
+	Context("with a scaffolded project", func() {
+		var (
+			dir  string
+			opts Rename
+		)
+
+		// userFile is a file added by the users, which mentions the renamed values in other contexts
+		const userFile = `# the project of example.com
+homepage: https://example.com/project
+tools: example.com/project-tools
+group: crew.partner.io
+`
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			scaffoldProject(dir)
+			Expect(os.WriteFile(filepath.Join(dir, "config", "samples", "notes.yaml"), []byte(userFile), 0o644)).
+				To(Succeed())
+
+			opts = Rename{InputDir: dir, Domain: "example.org", Repo: "example.org/ship", ProjectName: "ship"}
+			Expect(opts.Validate()).To(Succeed())
+		})
+
+		read := func(path ...string) string {
+			content, err := os.ReadFile(filepath.Join(append([]string{dir}, path...)...))
+			Expect(err).NotTo(HaveOccurred())
+			return string(content)
+		}
+
+		It("should rename the domain, the module path and the name of the project", func() {
+			_ = captureStdout(func() { Expect(opts.Rename()).To(Succeed()) })
+
+			cfg := cfgv3.New()
+			Expect(cfg.UnmarshalYAML([]byte(read("PROJECT")))).To(Succeed())
+			Expect(cfg.GetDomain()).To(Equal("example.org"))
+			Expect(cfg.GetRepository()).To(Equal("example.org/ship"))
+			Expect(cfg.GetProjectName()).To(Equal("ship"))
+			res, err := cfg.GetResource(resource.GVK{Group: "crew", Domain: "example.org", Version: "v1", Kind: "Captain"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Path).To(Equal("example.org/ship/api/v1"))
+
+			Expect(read("go.mod")).To(HavePrefix("module example.org/ship\n"))
+			Expect(read("cmd", "main.go")).To(And(
+				ContainSubstring(`"example.org/ship/api/v1"`),
+				ContainSubstring(`"example.org/ship/internal/controller"`),
+				Not(ContainSubstring("example.com/project")),
+			))
+			Expect(read("api", "v1", "groupversion_info.go")).To(And(
+				ContainSubstring("+groupName=crew.example.org"),
+				ContainSubstring(`Group: "crew.example.org"`),
+			))
+			Expect(read("internal", "webhook", "v1", "captain_webhook.go")).To(And(
+				ContainSubstring("path=/mutate-crew-example-org-v1-captain"),
+				ContainSubstring("path=/validate-crew-example-org-v1-captain"),
+				ContainSubstring("groups=crew.example.org"),
+			))
+			Expect(read("config", "default", "kustomization.yaml")).To(And(
+				ContainSubstring("namespace: ship-system"),
+				ContainSubstring("namePrefix: ship-"),
+			))
+			Expect(read("config", "rbac", "captain_editor_role.yaml")).To(And(
+				ContainSubstring("- crew.example.org"),
+				ContainSubstring("app.kubernetes.io/name: ship"),
+				Not(ContainSubstring("example.com")),
+			))
+			Expect(read("Makefile")).To(ContainSubstring("buildx create --name ship-builder"))
+
+			Expect(read("config", "samples", "notes.yaml")).To(Equal(userFile))
+		})
+
+		It("should rename the files named after the qualified groups", func() {
+			crd := filepath.Join(dir, "config", "crd", "bases", "crew.example.com_captains.yaml")
+			Expect(os.MkdirAll(filepath.Dir(crd), 0o755)).To(Succeed())
+			Expect(os.WriteFile(crd, []byte("metadata:\n  name: captains.crew.example.com\n"), 0o644)).To(Succeed())
+
+			_ = captureStdout(func() { Expect(opts.Rename()).To(Succeed()) })
+
+			Expect(crd).NotTo(BeAnExistingFile())
+			Expect(read("config", "crd", "bases", "crew.example.org_captains.yaml")).To(
+				Equal("metadata:\n  name: captains.crew.example.org\n"))
+			Expect(read("config", "crd", "kustomization.yaml")).To(ContainSubstring("bases/crew.example.org_captains.yaml"))
+		})
+
+		It("should only print the changes on dry-run", func() {
+			before := readFiles(dir)
+
+			opts.DryRun = true
+			output := captureStdout(func() { Expect(opts.Rename()).To(Succeed()) })
+
+			Expect(readFiles(dir)).To(Equal(before))
+			Expect(output).To(And(
+				ContainSubstring("+domain: example.org"),
+				ContainSubstring("-module example.com/project"),
+				ContainSubstring("+module example.org/ship"),
+				ContainSubstring("+namePrefix: ship-"),
+			))
+		})
+
+		It("should fail when nothing changes", func() {
+			opts = Rename{InputDir: dir, Domain: "example.com", ProjectName: "project"}
+			Expect(opts.Rename()).To(MatchError(ContainSubstring("already has the informed")))
+		})
+	})
+
+	DescribeTable("should reject invalid values",
+		func(opts Rename, message string) {
+			dir := GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(dir, "PROJECT"), []byte(projectFile), 0o644)).To(Succeed())
+			opts.InputDir = dir
+			Expect(opts.Validate()).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("without value", Rename{}, "at least one of"),
+		Entry("an invalid domain", Rename{Domain: "Example_com"}, "domain (Example_com) is invalid"),
+		Entry("an invalid repo", Rename{Repo: "example.com/my project"}, "repo (example.com/my project) is invalid"),
+		Entry("an invalid project name", Rename{ProjectName: "My.Project"}, "project name (My.Project) is invalid"),
+	)
+})
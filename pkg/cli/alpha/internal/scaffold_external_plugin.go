@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/cli/alpha/internal/externalplugin/templates"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+const (
+	// ExternalPluginLanguageGo scaffolds the skeleton of an external plugin in Go
+	ExternalPluginLanguageGo = "go"
+	// ExternalPluginLanguagePython scaffolds the skeleton of an external plugin in Python
+	ExternalPluginLanguagePython = "python"
+	// ExternalPluginLanguageNode scaffolds the skeleton of an external plugin in NodeJS
+	ExternalPluginLanguageNode = "node"
+)
+
+// ExternalPluginLanguages are the languages of the skeletons of the external plugins
+var ExternalPluginLanguages = []string{
+	ExternalPluginLanguageGo, ExternalPluginLanguagePython, ExternalPluginLanguageNode,
+}
+
+// ScaffoldExternalPlugin store the required info for the scaffold-external-plugin command
+type ScaffoldExternalPlugin struct {
+	// Language is the language of the skeleton, one of ExternalPluginLanguages
+	Language string
+	// PluginName is the name of the plugin, e.g. sampleplugin for the plugin key sampleplugin/v1
+	PluginName string
+	// OutputDir is the directory of the skeleton, a directory named after the plugin by default
+	OutputDir string
+	// Force overwrites the files of the skeleton which already exist
+	Force bool
+}
+
+// Validate ensures the options are valid.
+func (opts *ScaffoldExternalPlugin) Validate() error {
+	if opts.PluginName == "" {
+		return fmt.Errorf("--name is required")
+	}
+	if err := plugin.ValidateKey(opts.PluginName + "/v1"); err != nil {
+		return fmt.Errorf("invalid plugin name %q: %w", opts.PluginName, err)
+	}
+
+	if !slices.Contains(ExternalPluginLanguages, opts.Language) {
+		return fmt.Errorf("unable to scaffold an external plugin in %q, the languages are %s",
+			opts.Language, strings.Join(ExternalPluginLanguages, ", "))
+	}
+
+	if opts.OutputDir == "" {
+		opts.OutputDir = opts.PluginName
+	}
+	return nil
+}
+
+// Scaffold writes the skeleton of the external plugin, implementing the PluginRequest and PluginResponse
+// protocol with its parsing helpers and tests, along with the JSON schemas of the protocol.
+func (opts *ScaffoldExternalPlugin) Scaffold() error {
+	if err := createDirectory(opts.OutputDir); err != nil {
+		return err
+	}
+
+	mixin := templates.PluginMixin{PluginName: opts.PluginName, Force: opts.Force}
+	builders := []machinery.Builder{
+		&templates.Readme{PluginMixin: mixin, Language: opts.Language},
+		&templates.Schema{PluginMixin: mixin},
+		&templates.Schema{PluginMixin: mixin, Response: true},
+	}
+	switch opts.Language {
+	case ExternalPluginLanguageGo:
+		builders = append(builders,
+			&templates.GoMod{PluginMixin: mixin},
+			&templates.GoMain{PluginMixin: mixin},
+			&templates.GoPlugin{PluginMixin: mixin},
+			&templates.GoPluginTest{PluginMixin: mixin},
+		)
+	case ExternalPluginLanguagePython:
+		builders = append(builders,
+			&templates.PythonPlugin{PluginMixin: mixin},
+			&templates.PythonPluginTest{PluginMixin: mixin},
+		)
+	case ExternalPluginLanguageNode:
+		builders = append(builders,
+			&templates.NodePackage{PluginMixin: mixin},
+			&templates.NodePlugin{PluginMixin: mixin},
+			&templates.NodePluginTest{PluginMixin: mixin},
+		)
+	}
+
+	fs := machinery.Filesystem{FS: afero.NewBasePathFs(afero.NewOsFs(), opts.OutputDir)}
+	if err := machinery.NewScaffold(fs).Execute(builders...); err != nil {
+		return fmt.Errorf("failed to scaffold the external plugin: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,404 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	golangv4scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds"
+)
+
+// Names of the temporary branches used to perform the three-way merge
+const (
+	ancestorBranch = "kubebuilder-update-ancestor"
+	originalBranch = "kubebuilder-update-original"
+	upgradeBranch  = "kubebuilder-update-upgrade"
+	mergeBranch    = "kubebuilder-update-merge"
+)
+
+// Update store the required info for the command
+type Update struct {
+	// FromVersion is the release of Kubebuilder used to scaffold the project, e.g. v4.5.0
+	FromVersion string
+	// ToVersion is the release of Kubebuilder to upgrade to. The running binary is used when empty
+	ToVersion string
+	// FromBranch is the branch with the project to upgrade. The current branch is used when empty
+	FromBranch string
+	// OutputBranch is the new branch where the result of the update is committed. The result is
+	// committed on top of FromBranch when empty
+	OutputBranch string
+
+	// projectDir is the root directory of the git repository with the project
+	projectDir string
+	// download downloads a release of Kubebuilder into a directory and returns it, downloadKubebuilder
+	// is used when nil
+	download func(version, dir string) (string, error)
+}
+
+// Validate ensures the options are valid and the project can be updated.
+func (opts *Update) Validate() error {
+	if opts.FromVersion == "" {
+		return errors.New("--from-version is required")
+	}
+	if !strings.HasPrefix(opts.FromVersion, "v") {
+		opts.FromVersion = "v" + opts.FromVersion
+	}
+	if opts.ToVersion != "" && !strings.HasPrefix(opts.ToVersion, "v") {
+		opts.ToVersion = "v" + opts.ToVersion
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git not found in the path: %w", err)
+	}
+
+	var err error
+	if opts.projectDir, err = getInputPath(""); err != nil {
+		return err
+	}
+
+	status, err := gitOutput(opts.projectDir, "status", "--porcelain")
+	if err != nil {
+		return fmt.Errorf("failed to check the git status, the project must be in a git repository: %w", err)
+	}
+	if status != "" {
+		return errors.New("the working tree has uncommitted changes, commit or stash them before updating")
+	}
+
+	if opts.FromBranch == "" {
+		if opts.FromBranch, err = gitOutput(opts.projectDir, "rev-parse", "--abbrev-ref", "HEAD"); err != nil {
+			return fmt.Errorf("failed to get the current branch: %w", err)
+		}
+	}
+	if opts.OutputBranch != "" {
+		if branchExists(opts.projectDir, opts.OutputBranch) {
+			return fmt.Errorf("the branch %s already exists", opts.OutputBranch)
+		}
+	}
+
+	return nil
+}
+
+// toVersion returns the name of the version to update to
+func (opts *Update) toVersion() string {
+	if opts.ToVersion == "" {
+		return "current"
+	}
+	return opts.ToVersion
+}
+
+// Update re-scaffolds the project with the old and the new Kubebuilder versions and performs a
+// three-way merge of the user's changes onto the new scaffold:
+//
+//   - ancestor: the pristine scaffold generated with the old version
+//   - original: the ancestor plus the current state of the project
+//   - upgrade: the ancestor plus the scaffold generated with the new version
+//
+// The original branch is then merged into a copy of the upgrade branch, so that the user's changes are
+// applied on top of the new scaffold. Conflicts are committed with the standard git conflict markers.
+// The result is committed on top of the branch to update, or in the output branch if any. Each step
+// runs in its own git worktree so that the working tree of the project is only modified when the
+// result is committed on top of its current branch.
+func (opts *Update) Update() error {
+	tmpDir, err := os.MkdirTemp("", "kubebuilder-update-")
+	if err != nil {
+		return fmt.Errorf("failed to create the temporary directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			log.Warnf("Unable to remove the temporary directory %s: %v", tmpDir, err)
+		}
+	}()
+
+	// alpha generate refuses to scaffold in a directory with a PROJECT file,
+	// so the PROJECT file is read from a separate directory
+	inputDir := filepath.Join(tmpDir, "input")
+	if err := opts.copyProjectFile(inputDir); err != nil {
+		return err
+	}
+	if err := opts.copyCustomBoilerplate(inputDir); err != nil {
+		return err
+	}
+
+	download := opts.download
+	if download == nil {
+		download = downloadKubebuilder
+	}
+	fromBinDir, err := download(opts.FromVersion, filepath.Join(tmpDir, "bin", "from"))
+	if err != nil {
+		return err
+	}
+	toBinDir, err := opts.toKubebuilder(download, filepath.Join(tmpDir, "bin", "to"))
+	if err != nil {
+		return err
+	}
+
+	defer opts.cleanup(tmpDir)
+
+	if err := opts.prepareBranch(ancestorBranch, opts.FromBranch, filepath.Join(tmpDir, "ancestor"),
+		fmt.Sprintf("Scaffold the project with Kubebuilder %s", opts.FromVersion),
+		func(dir string) error { return regenerate(inputDir, dir, fromBinDir) }); err != nil {
+		return err
+	}
+
+	if err := opts.prepareBranch(originalBranch, ancestorBranch, filepath.Join(tmpDir, "original"),
+		fmt.Sprintf("Add the changes of the branch %s", opts.FromBranch),
+		func(dir string) error { return runGit(dir, "checkout", opts.FromBranch, "--", ".") }); err != nil {
+		return err
+	}
+
+	if err := opts.prepareBranch(upgradeBranch, ancestorBranch, filepath.Join(tmpDir, "upgrade"),
+		"Scaffold the project with the new Kubebuilder version",
+		func(dir string) error { return regenerate(inputDir, dir, toBinDir) }); err != nil {
+		return err
+	}
+
+	conflicts, err := opts.merge(filepath.Join(tmpDir, "merge"))
+	if err != nil {
+		return err
+	}
+	if opts.OutputBranch != "" {
+		log.Infof("The updated project is in the branch %s", opts.OutputBranch)
+	} else if err := opts.commitToBranch(filepath.Join(tmpDir, "from"), conflicts); err != nil {
+		return err
+	}
+	if conflicts != "" {
+		log.Warnf("The update has conflicts in the following files, resolve their conflict markers:\n%s", conflicts)
+	}
+	return nil
+}
+
+// copyProjectFile copies the PROJECT file of the branch to update into dir.
+func (opts *Update) copyProjectFile(dir string) error {
+	project, err := gitOutput(opts.projectDir, "show", fmt.Sprintf("%s:%s", opts.FromBranch, yaml.DefaultPath))
+	if err != nil {
+		return fmt.Errorf("failed to read the PROJECT file of the branch %s: %w", opts.FromBranch, err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, yaml.DefaultPath), []byte(project+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to copy the PROJECT file: %w", err)
+	}
+	return nil
+}
+
+// copyCustomBoilerplate copies the boilerplate of the custom license of the branch to update into dir,
+// as it is not scaffolded but required to re-scaffold the project.
+func (opts *Update) copyCustomBoilerplate(dir string) error {
+	config, err := loadProjectConfig(dir)
+	if err != nil {
+		return err
+	}
+	goConfig, err := golangv4scaffolds.LoadPluginConfig(config.Config())
+	if err != nil {
+		return fmt.Errorf("failed to decode go plugin config: %w", err)
+	}
+	if goConfig.GetLicense() != golangv4scaffolds.CustomLicense {
+		return nil
+	}
+
+	boilerplatePath := goConfig.GetBoilerplatePath()
+	boilerplate, err := gitOutput(opts.projectDir, "show",
+		fmt.Sprintf("%s:%s", opts.FromBranch, filepath.ToSlash(boilerplatePath)))
+	if err != nil {
+		return fmt.Errorf("failed to read the boilerplate %s of the branch %s: %w", boilerplatePath, opts.FromBranch, err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(boilerplatePath)), 0755); err != nil {
+		return fmt.Errorf("failed to create the directory of the boilerplate: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, boilerplatePath), []byte(boilerplate+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to copy the boilerplate: %w", err)
+	}
+	return nil
+}
+
+// toKubebuilder returns the directory with the binary of the Kubebuilder version to upgrade to.
+func (opts *Update) toKubebuilder(download func(version, dir string) (string, error), dir string) (string, error) {
+	if opts.ToVersion != "" {
+		return download(opts.ToVersion, dir)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the kubebuilder binary: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	// The binary must be named kubebuilder since alpha generate calls it by name
+	if err := os.Symlink(executable, filepath.Join(dir, "kubebuilder")); err != nil {
+		return "", fmt.Errorf("failed to link the kubebuilder binary: %w", err)
+	}
+	return dir, nil
+}
+
+// prepareBranch creates the branch from the given base in a new worktree, replaces all of its
+// files with the ones produced by fill and commits the result.
+func (opts *Update) prepareBranch(branch, base, dir, message string, fill func(string) error) error {
+	if err := runGit(opts.projectDir, "worktree", "add", "-b", branch, dir, base); err != nil {
+		return fmt.Errorf("failed to create the branch %s: %w", branch, err)
+	}
+	if err := cleanUpWorktree(dir); err != nil {
+		return err
+	}
+	if err := fill(dir); err != nil {
+		return fmt.Errorf("failed to prepare the branch %s: %w", branch, err)
+	}
+	if err := runGit(dir, "add", "--all"); err != nil {
+		return fmt.Errorf("failed to stage the changes of the branch %s: %w", branch, err)
+	}
+	if err := runGit(dir, "commit", "--allow-empty", "--no-verify", "-m", message); err != nil {
+		return fmt.Errorf("failed to commit the changes of the branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// merge merges the original branch into a copy of the upgrade branch, which is the output branch if any.
+// It returns the files with conflicts, which are committed with the conflict markers.
+func (opts *Update) merge(dir string) (string, error) {
+	branch := opts.mergeBranch()
+	if err := runGit(opts.projectDir, "worktree", "add", "-b", branch, dir, upgradeBranch); err != nil {
+		return "", fmt.Errorf("failed to create the branch %s: %w", branch, err)
+	}
+
+	if err := runGit(dir, "merge", "--no-edit", "--no-ff", originalBranch); err != nil {
+		conflicts, diffErr := gitOutput(dir, "diff", "--name-only", "--diff-filter=U")
+		if diffErr != nil || conflicts == "" {
+			return "", fmt.Errorf("failed to merge the branch %s: %w", originalBranch, err)
+		}
+
+		if err := runGit(dir, "add", "--all"); err != nil {
+			return "", fmt.Errorf("failed to stage the conflicts: %w", err)
+		}
+		if err := runGit(dir, "commit", "--no-verify", "-m",
+			"Merge the project into the new scaffold (with conflicts)"); err != nil {
+			return "", fmt.Errorf("failed to commit the conflicts: %w", err)
+		}
+		return conflicts, nil
+	}
+	return "", nil
+}
+
+// mergeBranch returns the branch where the result of the merge is committed
+func (opts *Update) mergeBranch() string {
+	if opts.OutputBranch != "" {
+		return opts.OutputBranch
+	}
+	return mergeBranch
+}
+
+// commitToBranch commits the result of the merge on top of the branch to update. The branch is updated
+// in the working tree of the project when it is the current branch, otherwise in a new worktree in dir.
+func (opts *Update) commitToBranch(dir string, conflicts string) error {
+	current, err := gitOutput(opts.projectDir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to get the current branch: %w", err)
+	}
+	if current == opts.FromBranch {
+		dir = opts.projectDir
+	} else if err := runGit(opts.projectDir, "worktree", "add", dir, opts.FromBranch); err != nil {
+		return fmt.Errorf("failed to check out the branch %s: %w", opts.FromBranch, err)
+	}
+
+	// The files of the branch are replaced with the result of the merge, including the removed ones
+	if err := runGit(dir, "read-tree", "-u", "--reset", mergeBranch); err != nil {
+		return fmt.Errorf("failed to apply the result of the merge to the branch %s: %w", opts.FromBranch, err)
+	}
+	message := fmt.Sprintf("Update the project from Kubebuilder %s to %s", opts.FromVersion, opts.toVersion())
+	if conflicts != "" {
+		message += " (with conflicts)"
+	}
+	if err := runGit(dir, "commit", "--allow-empty", "--no-verify", "-m", message); err != nil {
+		return fmt.Errorf("failed to commit the update to the branch %s: %w", opts.FromBranch, err)
+	}
+
+	log.Infof("The update is committed on top of the branch %s", opts.FromBranch)
+	return nil
+}
+
+// cleanup removes the worktrees and the temporary branches. The output branch with the result is kept.
+func (opts *Update) cleanup(tmpDir string) {
+	for _, name := range []string{"ancestor", "original", "upgrade", "merge", "from"} {
+		dir := filepath.Join(tmpDir, name)
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+		if err := runGit(opts.projectDir, "worktree", "remove", "--force", dir); err != nil {
+			log.Warnf("Unable to remove the worktree %s: %v", dir, err)
+		}
+	}
+	branches := []string{ancestorBranch, originalBranch, upgradeBranch}
+	if opts.OutputBranch == "" {
+		branches = append(branches, mergeBranch)
+	}
+	for _, branch := range branches {
+		if !branchExists(opts.projectDir, branch) {
+			continue
+		}
+		if err := runGit(opts.projectDir, "branch", "-D", branch); err != nil {
+			log.Warnf("Unable to delete the branch %s: %v", branch, err)
+		}
+	}
+}
+
+// cleanUpWorktree removes all the files of the worktree except the git metadata.
+func cleanUpWorktree(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if entry.Name() == ".git" {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+// runGit runs git with the given arguments in dir.
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// branchExists returns true when the branch exists in the git repository of dir.
+func branchExists(dir, branch string) bool {
+	_, err := gitOutput(dir, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
+
+// gitOutput runs git with the given arguments in dir and returns its trimmed output.
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
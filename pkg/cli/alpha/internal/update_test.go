@@ -0,0 +1,223 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeKubebuilder is a kubebuilder release whose alpha generate copies the PROJECT file and writes
+// the scaffold of the release, i.e. main.go and Makefile, and the new files of the release
+const fakeKubebuilder = `#!/bin/sh
+test "$1 $2" = "alpha generate" || exit 1
+cp "$4/PROJECT" "$6/PROJECT"
+printf "$MAIN" > "$6/main.go"
+printf 'IMG ?= controller:latest\n' > "$6/Makefile"
+for file in $NEW; do printf 'new\n' > "$6/$file"; done
+`
+
+var _ = Describe("Update", func() {
+	var (
+		dir  string
+		opts Update
+	)
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(out))
+		return strings.TrimSpace(string(out))
+	}
+
+	read := func(file string) string {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		Expect(err).NotTo(HaveOccurred())
+		return string(content)
+	}
+
+	write := func(file, content string) {
+		Expect(os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644)).To(Succeed())
+	}
+
+	// release returns a download of the fake releases, with the content of main.go and the new files by version.
+	// A release without main.go fails to generate the project.
+	release := func(mains map[string]string, newFiles map[string]string) func(version, dir string) (string, error) {
+		return func(version, binDir string) (string, error) {
+			if err := os.MkdirAll(binDir, 0o755); err != nil {
+				return "", err
+			}
+			script := fakeKubebuilder
+			if main, ok := mains[version]; ok {
+				script = fmt.Sprintf("#!/bin/sh\nMAIN=%q\nNEW=%q\n", main, newFiles[version]) +
+					strings.TrimPrefix(fakeKubebuilder, "#!/bin/sh\n")
+			} else {
+				script = "#!/bin/sh\necho 'unable to scaffold the project' >&2\nexit 1\n"
+			}
+			return binDir, os.WriteFile(filepath.Join(binDir, "kubebuilder"), []byte(script), 0o755)
+		}
+	}
+
+	// worktrees returns the number of worktrees of the repository
+	worktrees := func() int {
+		return len(strings.Split(git("worktree", "list", "--porcelain"), "\n\n"))
+	}
+
+	BeforeEach(func() {
+		if _, err := exec.LookPath("git"); err != nil {
+			Skip("git is required to update the project")
+		}
+
+		wd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.Chdir, wd)
+
+		dir = GinkgoT().TempDir()
+		// The temporary directory may be a symbolic link, e.g. on macOS, while git reports the real paths
+		dir, err = filepath.EvalSymlinks(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir(dir)).To(Succeed())
+
+		git("init", "--quiet")
+		git("checkout", "--quiet", "-b", "main")
+		git("config", "user.name", "Kubebuilder")
+		git("config", "user.email", "kubebuilder@example.com")
+		git("config", "commit.gpgsign", "false")
+
+		// The project scaffolded with v1.0.0, then changed by the users
+		write("PROJECT", projectFile)
+		write("main.go", "package main\n")
+		write("Makefile", "IMG ?= controller:latest\n\n# Deploy the samples\nsamples:\n")
+		write("controller.go", "package controller\n")
+		git("add", "--all")
+		git("commit", "--quiet", "-m", "Scaffold the project")
+
+		opts = Update{
+			FromVersion: "1.0.0",
+			ToVersion:   "2.0.0",
+			download: release(
+				map[string]string{"v1.0.0": "package main\n", "v2.0.0": "package main\n\nfunc main() {}\n"},
+				map[string]string{"v2.0.0": "Dockerfile"},
+			),
+		}
+	})
+
+	It("should commit the update on top of the current branch", func() {
+		Expect(opts.Validate()).To(Succeed())
+		Expect(opts.FromVersion).To(Equal("v1.0.0"))
+		Expect(opts.FromBranch).To(Equal("main"))
+		Expect(opts.Update()).To(Succeed())
+
+		Expect(git("log", "-1", "--format=%s")).To(Equal("Update the project from Kubebuilder v1.0.0 to v2.0.0"))
+		Expect(git("rev-list", "--count", "HEAD")).To(Equal("2"))
+		Expect(git("status", "--porcelain")).To(BeEmpty())
+
+		// The new scaffold and the changes of the users
+		Expect(read("main.go")).To(Equal("package main\n\nfunc main() {}\n"))
+		Expect(read("Dockerfile")).To(Equal("new\n"))
+		Expect(read("Makefile")).To(Equal("IMG ?= controller:latest\n\n# Deploy the samples\nsamples:\n"))
+		Expect(read("controller.go")).To(Equal("package controller\n"))
+		Expect(read("PROJECT")).To(Equal(projectFile))
+
+		Expect(worktrees()).To(Equal(1))
+		Expect(git("branch", "--format=%(refname:short)")).To(Equal("main"))
+	})
+
+	It("should commit the conflicts with the conflict markers", func() {
+		write("main.go", "package main\n\nfunc main() {\n\trun()\n}\n")
+		git("commit", "--quiet", "--all", "-m", "Run the manager")
+
+		Expect(opts.Validate()).To(Succeed())
+		Expect(opts.Update()).To(Succeed())
+
+		Expect(git("log", "-1", "--format=%s")).To(Equal(
+			"Update the project from Kubebuilder v1.0.0 to v2.0.0 (with conflicts)"))
+		Expect(read("main.go")).To(And(
+			ContainSubstring("<<<<<<< "),
+			ContainSubstring("func main() {}"),
+			ContainSubstring("\trun()"),
+			ContainSubstring(">>>>>>> "),
+		))
+		Expect(read("Dockerfile")).To(Equal("new\n"))
+		Expect(git("status", "--porcelain")).To(BeEmpty())
+		Expect(worktrees()).To(Equal(1))
+		Expect(git("branch", "--format=%(refname:short)")).To(Equal("main"))
+	})
+
+	It("should commit the update in the output branch", func() {
+		head := git("rev-parse", "HEAD")
+
+		opts.OutputBranch = "update"
+		Expect(opts.Validate()).To(Succeed())
+		Expect(opts.Update()).To(Succeed())
+
+		Expect(git("rev-parse", "HEAD")).To(Equal(head))
+		Expect(read("main.go")).To(Equal("package main\n"))
+		Expect(git("show", "update:main.go")).To(Equal("package main\n\nfunc main() {}"))
+		Expect(git("show", "update:controller.go")).To(Equal("package controller"))
+		Expect(worktrees()).To(Equal(1))
+		Expect(strings.Fields(git("branch", "--format=%(refname:short)"))).To(ConsistOf("main", "update"))
+	})
+
+	It("should commit the update on top of a branch which is not checked out", func() {
+		git("checkout", "--quiet", "-b", "other")
+		head := git("rev-parse", "HEAD")
+
+		opts.FromBranch = "main"
+		Expect(opts.Validate()).To(Succeed())
+		Expect(opts.Update()).To(Succeed())
+
+		Expect(git("rev-parse", "HEAD")).To(Equal(head))
+		Expect(git("rev-parse", "--abbrev-ref", "HEAD")).To(Equal("other"))
+		Expect(git("log", "-1", "--format=%s", "main")).To(Equal("Update the project from Kubebuilder v1.0.0 to v2.0.0"))
+		Expect(git("show", "main:main.go")).To(Equal("package main\n\nfunc main() {}"))
+		Expect(worktrees()).To(Equal(1))
+	})
+
+	It("should remove the worktrees and the temporary branches when the update fails", func() {
+		head := git("rev-parse", "HEAD")
+
+		opts.download = release(map[string]string{"v1.0.0": "package main\n"}, nil)
+		Expect(opts.Validate()).To(Succeed())
+		Expect(opts.Update()).To(MatchError(ContainSubstring("failed to prepare the branch " + upgradeBranch)))
+
+		Expect(git("rev-parse", "HEAD")).To(Equal(head))
+		Expect(git("status", "--porcelain")).To(BeEmpty())
+		Expect(worktrees()).To(Equal(1))
+		Expect(git("branch", "--format=%(refname:short)")).To(Equal("main"))
+	})
+
+	DescribeTable("should reject invalid options",
+		func(prepare func(), message string) {
+			prepare()
+			Expect(opts.Validate()).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("without the version of the project", func() { opts.FromVersion = "" }, "--from-version is required"),
+		Entry("with uncommitted changes", func() { write("main.go", "package app\n") }, "uncommitted changes"),
+		Entry("with an existing output branch", func() {
+			git("branch", "update")
+			opts.OutputBranch = "update"
+		}, "the branch update already exists"),
+	)
+})
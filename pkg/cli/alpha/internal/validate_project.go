@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config/schema"
+	storeyaml "sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+// ValidateProject store the required info for the validate-project command
+type ValidateProject struct {
+	InputDir string
+	// PluginKeys are the keys of the plugins available in the CLI, the plugin keys of the PROJECT file
+	// which are not part of them are reported
+	PluginKeys []string
+}
+
+// Validate ensures the options are valid.
+func (opts *ValidateProject) Validate() error {
+	var err error
+	opts.InputDir, err = getInputPath(opts.InputDir)
+	return err
+}
+
+// Check validates the PROJECT file against its JSON schema and reports the plugin keys unknown to the CLI,
+// the duplicate resources and the resources with an invalid GVK. It returns the problems found in the file.
+func (opts *ValidateProject) Check() ([]string, error) {
+	projectPath := filepath.Join(opts.InputDir, storeyaml.DefaultPath)
+	content, err := os.ReadFile(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", projectPath, err)
+	}
+
+	violations, err := schema.Validate(content)
+	if err != nil {
+		return nil, err
+	}
+	problems := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		problems = append(problems, violation.String())
+	}
+
+	// The values are checked even when they do not comply with the schema, to report all the problems at once
+	var project struct {
+		Layout    interface{}              `json:"layout"`
+		Plugins   map[string]interface{}   `json:"plugins"`
+		Resources []map[string]interface{} `json:"resources"`
+	}
+	if err := yaml.Unmarshal(content, &project); err != nil {
+		// The content complies with the YAML syntax but not with the types of the schema, which was reported
+		return problems, nil
+	}
+
+	problems = append(problems, opts.checkPluginKeys(project.Layout, project.Plugins)...)
+	problems = append(problems, checkResources(project.Resources)...)
+	return problems, nil
+}
+
+// checkPluginKeys reports the plugin keys of the layout and of the plugin configurations unknown to the CLI
+func (opts *ValidateProject) checkPluginKeys(layout interface{}, plugins map[string]interface{}) []string {
+	if len(opts.PluginKeys) == 0 {
+		return nil
+	}
+
+	var problems []string
+	checkKey := func(path, key string) {
+		if !slices.Contains(opts.PluginKeys, key) {
+			problems = append(problems, fmt.Sprintf("%s: unknown plugin %q", path, key))
+		}
+	}
+	switch typed := layout.(type) {
+	case string:
+		checkKey("layout", typed)
+	case []interface{}:
+		for i, key := range typed {
+			if key, ok := key.(string); ok {
+				checkKey(fmt.Sprintf("layout[%d]", i), key)
+			}
+		}
+	}
+	keys := make([]string, 0, len(plugins))
+	for key := range plugins {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		checkKey("plugins."+key, key)
+	}
+	return problems
+}
+
+// checkResources reports the resources with an invalid GVK and the resources tracked more than once
+func checkResources(resources []map[string]interface{}) []string {
+	var problems []string
+	indexes := make(map[resource.GVK]int, len(resources))
+	for i, res := range resources {
+		field := func(name string) string {
+			value, _ := res[name].(string)
+			return value
+		}
+		gvk := resource.GVK{Group: field("group"), Domain: field("domain"), Version: field("version"), Kind: field("kind")}
+
+		if err := gvk.Validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("resources[%d]: invalid GVK: %v", i, err))
+		}
+		if previous, found := indexes[gvk]; found {
+			problems = append(problems, fmt.Sprintf("resources[%d]: duplicates resources[%d], %s/%s, Kind=%s",
+				i, previous, gvk.QualifiedGroup(), gvk.Version, gvk.Kind))
+			continue
+		}
+		indexes[gvk] = i
+	}
+	return problems
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/cli/alpha/internal"
+)
+
+// NewRBACAuditCommand returns a new rbac-audit command, providing the `kubebuilder alpha rbac-audit`
+// feature to compare the RBAC markers of the project with the role and the calls of the client.
+func NewRBACAuditCommand() *cobra.Command {
+	opts := internal.RBACAudit{}
+	auditCmd := &cobra.Command{
+		Use:   "rbac-audit",
+		Short: "Audit the permissions declared by the RBAC markers of the project",
+		Long: `It's an experimental feature that parses the +kubebuilder:rbac markers of the Go source files
+and compares the permissions they declare with the role generated by controller-gen, reporting the
+permissions missing from the role, e.g. because 'make manifests' was not run, and the permissions of
+the role which are not declared by any marker, e.g. because the role was edited.
+
+With --detect-calls, the calls of the controller-runtime client on typed objects, e.g.
+'r.Get(ctx, key, &appsv1.Deployment{})' or 'r.Status().Update(ctx, obj)', are detected as well. The calls
+which are not allowed by the markers are reported as missing permissions along with the marker to add,
+and the create, update, patch and delete permissions which no call requires are reported as excessive.
+Only the calls whose object is a literal or a variable declared in the same function are detected.
+
+The command exits with the status code 1 when permissions are missing.
+# audit the project in the 'input-dir' argument, the default is the current directory.
+$ kubebuilder alpha rbac-audit --input-dir="./test"
+# audit the markers against the calls of the client as well
+$ kubebuilder alpha rbac-audit --detect-calls
+		`,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return opts.Validate()
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			report, err := opts.Audit()
+			if err != nil {
+				log.Fatalf("Failed to command %s", err)
+			}
+			for _, excessive := range report.Excessive {
+				fmt.Println("excessive:", excessive)
+			}
+			for _, missing := range report.Missing {
+				fmt.Println("missing:", missing)
+			}
+			if len(report.Missing) == 0 {
+				log.Infof("No missing permission found, %d excessive permission(s) found", len(report.Excessive))
+				return
+			}
+			log.Errorf("%d missing permission(s) found", len(report.Missing))
+			os.Exit(1)
+		},
+	}
+	auditCmd.Flags().StringVar(&opts.InputDir, "input-dir", "",
+		"Specifies the full path to a Kubebuilder project file. If not provided, "+
+			"the current working directory is used.")
+	auditCmd.Flags().StringVar(&opts.RolePath, "role", internal.DefaultRolePath,
+		"path of the role generated by controller-gen, relative to the project")
+	auditCmd.Flags().BoolVar(&opts.DetectCalls, "detect-calls", false,
+		"compare the RBAC markers with the calls of the controller-runtime client as well")
+
+	return auditCmd
+}
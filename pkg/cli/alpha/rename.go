@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/cli/alpha/internal"
+)
+
+// NewRenameCommand returns a new rename command, providing the `kubebuilder alpha rename`
+// feature to change the domain, the module path or the name of an existing project.
+func NewRenameCommand() *cobra.Command {
+	opts := internal.Rename{}
+	renameCmd := &cobra.Command{
+		Use:   "rename",
+		Short: "Rename the domain, the repo or the name of the project",
+		Long: `It's an experimental feature that changes the domain, the module path or the name of the project
+in the PROJECT file and in the files of the project: the Go files, the go.mod files, the kustomize
+manifests, the helm chart and the Makefile.
+
+Only the occurrences which are scaffolded from the PROJECT file are replaced: the qualified groups
+of the APIs and the paths of their webhooks for the domain, the module path and the imports of the
+packages of the project for the repo, and the names prefixed with the project name, its labels and
+its image for the project name. The Markdown files are not changed.
+# preview the changes without writing them
+$ kubebuilder alpha rename --domain example.com --dry-run
+# change the module path and the name of the project
+$ kubebuilder alpha rename --repo github.com/example/memcached-operator --project-name memcached-operator
+		`,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return opts.Validate()
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := opts.Rename(); err != nil {
+				log.Fatalf("Failed to command %s", err)
+			}
+		},
+	}
+	renameCmd.Flags().StringVar(&opts.InputDir, "input-dir", "",
+		"Specifies the full path to a Kubebuilder project file. If not provided, "+
+			"the current working directory is used.")
+	renameCmd.Flags().StringVar(&opts.Domain, "domain", "", "new domain of the project and of its APIs")
+	renameCmd.Flags().StringVar(&opts.Repo, "repo", "", "new module path of the project")
+	renameCmd.Flags().StringVar(&opts.ProjectName, "project-name", "", "new name of the project")
+	renameCmd.Flags().BoolVar(&opts.DryRun, "dry-run", false,
+		"print the differences of the files instead of writing them")
+
+	return renameCmd
+}
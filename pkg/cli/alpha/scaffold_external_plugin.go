@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/cli/alpha/internal"
+)
+
+// NewScaffoldExternalPluginCommand returns a new scaffold-external-plugin command, providing the
+// `kubebuilder alpha scaffold-external-plugin` feature to start an external plugin in Go, Python or NodeJS.
+func NewScaffoldExternalPluginCommand() *cobra.Command {
+	opts := internal.ScaffoldExternalPlugin{}
+	scaffoldCmd := &cobra.Command{
+		Use:   "scaffold-external-plugin",
+		Short: "Scaffold the skeleton of an external plugin",
+		Long: `It's an experimental feature that scaffolds the skeleton of an external plugin, which implements the
+PluginRequest and PluginResponse protocol of the external plugins with the helpers parsing the requests and
+their arguments, along with its tests, so that the plugins can be written in other languages than Go.
+
+The JSON schemas of the PluginRequest and of the PluginResponse are scaffolded in the schemas directory of
+the skeleton, to validate the messages or to generate their types.
+
+# scaffold the skeleton of the plugin sampleplugin/v1 in Python in the sampleplugin directory
+$ kubebuilder alpha scaffold-external-plugin --language=python --name=sampleplugin
+# scaffold the skeleton in NodeJS in another directory
+$ kubebuilder alpha scaffold-external-plugin --language=node --name=sampleplugin --output-dir=./plugins/sample
+		`,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return opts.Validate()
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := opts.Scaffold(); err != nil {
+				log.Fatalf("Failed to command %s", err)
+			}
+			log.Infof("The external plugin %s was scaffolded in %s", opts.PluginName, opts.OutputDir)
+		},
+	}
+	scaffoldCmd.Flags().StringVar(&opts.Language, "language", internal.ExternalPluginLanguageGo,
+		fmt.Sprintf("language of the plugin, one of %s", strings.Join(internal.ExternalPluginLanguages, ", ")))
+	scaffoldCmd.Flags().StringVar(&opts.PluginName, "name", "",
+		"name of the plugin, e.g. sampleplugin for the plugin key sampleplugin/v1")
+	scaffoldCmd.Flags().StringVar(&opts.OutputDir, "output-dir", "",
+		"directory of the skeleton, a directory named after the plugin in the current directory by default")
+	scaffoldCmd.Flags().BoolVar(&opts.Force, "force", false,
+		"overwrite the files of the skeleton which already exist")
+
+	return scaffoldCmd
+}
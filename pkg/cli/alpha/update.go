@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/cli/alpha/internal"
+)
+
+// NewUpdateCommand returns a new update command, providing the `kubebuilder alpha update`
+// feature to upgrade projects to a new Kubebuilder version with a three-way merge.
+//
+// IMPORTANT: As `kubebuilder alpha generate`, which it relies on, this command is intended
+// solely for Kubebuilder's use.
+func NewUpdateCommand() *cobra.Command {
+	opts := internal.Update{}
+	updateCmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update a Kubebuilder project to a new version with a three-way merge",
+		Long: `It's an experimental feature that upgrades the project to a new version of KubeBuilder
+while keeping the changes made on top of the scaffold.
+
+The project is re-scaffolded with the old and the new versions of KubeBuilder in separate git branches,
+then the changes of the project are merged into the new scaffold with a three-way merge. The result is
+committed on top of the branch to update, or in a new branch when --output-branch is set. Conflicts are
+committed with the standard git conflict markers and must be resolved afterwards.
+
+The command must be run from the root directory of the project, which must be a git repository
+without uncommitted changes.
+# update a project scaffolded with v4.5.0 to the version of the running binary
+$ kubebuilder alpha update --from-version v4.5.0
+# update the branch 'main' to the release v4.6.0
+$ kubebuilder alpha update --from-version v4.5.0 --to-version v4.6.0 --from-branch main
+# commit the update of the current branch in the new branch 'update-v4.6.0' to review it
+$ kubebuilder alpha update --from-version v4.5.0 --to-version v4.6.0 --output-branch update-v4.6.0
+		`,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return opts.Validate()
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := opts.Update(); err != nil {
+				log.Fatalf("Failed to command %s", err)
+			}
+		},
+	}
+	updateCmd.Flags().StringVar(&opts.FromVersion, "from-version", "",
+		"Kubebuilder release used to scaffold the project (e.g. v4.5.0).")
+	updateCmd.Flags().StringVar(&opts.ToVersion, "to-version", "",
+		"Kubebuilder release to update the project to. If not provided, the running binary is used.")
+	updateCmd.Flags().StringVar(&opts.FromBranch, "from-branch", "",
+		"Git branch with the project to update. If not provided, the current branch is used.")
+	updateCmd.Flags().StringVar(&opts.OutputBranch, "output-branch", "",
+		"New git branch where the update is committed. If not provided, the update is committed on top of "+
+			"the branch to update.")
+
+	return updateCmd
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/cli/alpha/internal"
+	"sigs.k8s.io/kubebuilder/v4/pkg/config/schema"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+// NewValidateProjectCommand returns a new validate-project command, providing the `kubebuilder alpha
+// validate-project` feature to validate the PROJECT file against its JSON schema.
+//
+// The provided plugins are the ones available in the CLI: the plugin keys of the PROJECT file which
+// are not part of them are reported.
+func NewValidateProjectCommand(plugins ...plugin.Plugin) *cobra.Command {
+	opts := internal.ValidateProject{}
+	for _, p := range plugins {
+		opts.PluginKeys = append(opts.PluginKeys, plugin.KeyFor(p))
+	}
+	var printSchema bool
+
+	validateCmd := &cobra.Command{
+		Use:   "validate-project",
+		Short: "Validate the PROJECT file against its JSON schema",
+		Long: `It's an experimental feature that validates the PROJECT file against its JSON schema, and reports
+the plugin keys unknown to the CLI, the resources tracked more than once and the resources with an
+invalid group, version or kind, before they make the scaffolding commands misbehave.
+
+The command exits with the status code 1 when the PROJECT file is invalid.
+# make sure the PROJECT file is in the 'input-dir' argument, the default is the current directory.
+$ kubebuilder alpha validate-project --input-dir="./test"
+# print the JSON schema of the PROJECT file, e.g. to validate it in an editor
+$ kubebuilder alpha validate-project --print-schema > project.schema.json
+		`,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if printSchema {
+				return nil
+			}
+			return opts.Validate()
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			if printSchema {
+				fmt.Print(string(schema.ProjectV3()))
+				return
+			}
+
+			problems, err := opts.Check()
+			if err != nil {
+				log.Fatalf("Failed to command %s", err)
+			}
+			if len(problems) == 0 {
+				log.Info("The PROJECT file is valid")
+				return
+			}
+			for _, problem := range problems {
+				fmt.Println(problem)
+			}
+			log.Errorf("The PROJECT file has %d problem(s)", len(problems))
+			os.Exit(1)
+		},
+	}
+	validateCmd.Flags().StringVar(&opts.InputDir, "input-dir", "",
+		"Specifies the full path to a Kubebuilder project file. If not provided, "+
+			"the current working directory is used.")
+	validateCmd.Flags().BoolVar(&printSchema, "print-schema", false,
+		"If set, the JSON schema of the PROJECT file is printed instead of validating the PROJECT file.")
+
+	return validateCmd
+}
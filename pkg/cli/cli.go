@@ -36,9 +36,14 @@ import (
 const (
 	noticeColor    = "\033[1;33m%s\033[0m"
 	deprecationFmt = "[Deprecation Notice] %s\n\n"
-
-	pluginsFlag        = "plugins"
-	projectVersionFlag = "project-version"
+	replacementFmt = "%s\nThe plugin %q is replaced by %q, which is used instead when re-scaffolding the project " +
+		"with `kubebuilder alpha generate` or `kubebuilder alpha update`."
+	versionSkewFmt = "[Version Skew] %s\n\n"
+
+	pluginsFlag                = "plugins"
+	allowPluginVersionSkewFlag = "allow-plugin-version-skew"
+	projectVersionFlag         = "project-version"
+	verboseFlag                = "verbose"
 )
 
 // CLI is the command line utility that is used to scaffold kubebuilder project files.
@@ -49,12 +54,16 @@ type CLI struct { //nolint:maligned
 	commandName string
 	// CLI version string.
 	version string
+	// CLI version, without the build information, which is provided to the plugins.
+	cliVersion string
 	// CLI root's command description.
 	description string
 	// Plugins registered in the CLI.
 	plugins map[string]plugin.Plugin
 	// Default plugins in case none is provided and a config file can't be found.
 	defaultPlugins map[config.Version][]string
+	// Plugin aliases registered in the CLI, by name.
+	pluginAliases map[string]PluginAlias
 	// Default project version in case none is provided and a config file can't be found.
 	defaultProjectVersion config.Version
 	// Commands injected by options.
@@ -68,8 +77,15 @@ type CLI struct { //nolint:maligned
 
 	// Plugin keys to scaffold with.
 	pluginKeys []string
+	// Names of the plugin aliases informed in the flags.
+	usedPluginAliases []string
 	// Project version to scaffold.
 	projectVersion config.Version
+	// Organization template the project is initialized from, if any.
+	template *projectTemplate
+	// Whether the plugins of the project which are newer than the ones of the CLI are replaced by
+	// the latest versions of the CLI, with a warning, instead of failing.
+	allowPluginVersionSkew bool
 
 	// A filtered set of plugins that should be used by command constructors.
 	resolvedPlugins []plugin.Plugin
@@ -112,6 +128,11 @@ func New(options ...Option) (*CLI, error) {
 		return nil, err
 	}
 
+	// Set the default flag values of the subcommands once all commands have been constructed.
+	if err := c.applyFlagDefaults(); err != nil {
+		return nil, err
+	}
+
 	// Write deprecation notices after all commands have been constructed.
 	c.printDeprecationWarnings()
 
@@ -128,6 +149,7 @@ func newCLI(options ...Option) (*CLI, error) {
 `,
 		plugins:        make(map[string]plugin.Plugin),
 		defaultPlugins: make(map[config.Version][]string),
+		pluginAliases:  make(map[string]PluginAlias),
 		fs:             machinery.Filesystem{FS: afero.NewOsFs()},
 	}
 
@@ -138,6 +160,11 @@ func newCLI(options ...Option) (*CLI, error) {
 		}
 	}
 
+	// The plugin aliases can only be checked once all the plugins have been registered.
+	if err := c.checkPluginAliases(); err != nil {
+		return nil, err
+	}
+
 	return c, nil
 }
 
@@ -162,12 +189,27 @@ func (c *CLI) buildCmd() error {
 			// stable version not registered, let's bail out
 			return err
 		}
+	case c.isValidatingProject():
+		// The problems of the PROJECT file are reported by the command validating it
+		c.useDefaultPlugins()
 	default:
 		return err
 	}
 
 	// Resolve plugins for project version and plugin keys.
 	if err := c.resolvePlugins(); err != nil {
+		if !c.isValidatingProject() {
+			return err
+		}
+		// The plugin keys of the PROJECT file may be unknown, e.g. misspelled
+		c.useDefaultPlugins()
+		if err := c.resolvePlugins(); err != nil {
+			return err
+		}
+	}
+
+	// Check the ordering constraints of the plugin aliases against the resolved plugins.
+	if err := c.checkPluginOrder(); err != nil {
 		return err
 	}
 
@@ -177,6 +219,14 @@ func (c *CLI) buildCmd() error {
 	return nil
 }
 
+// useDefaultPlugins discards the plugin keys and the project version of the project configuration file and
+// of the flags, and uses the default ones instead
+func (c *CLI) useDefaultPlugins() {
+	c.pluginKeys = nil
+	c.projectVersion = config.Version{}
+	c.getInfoFromDefaults()
+}
+
 // getInfo obtains the plugin keys and project version resolving conflicts between the project config file and flags.
 func (c *CLI) getInfo() error {
 	// Get plugin keys and project version from project configuration file
@@ -226,23 +276,17 @@ func (c *CLI) getInfoFromConfig(projectConfig config.Config) error {
 		}
 	}
 
-	return nil
+	return c.loadProjectTemplates(projectConfig)
 }
 
-// getInfoFromFlags obtains the project version and plugin keys from flags.
-func (c *CLI) getInfoFromFlags(hasConfigFile bool) error {
-	// Partially parse the command line arguments
+// newBaseFlagSet returns a flag set with the global flags of the base command, which partially parses
+// the command line arguments before the subcommands and their flags are added.
+func (c *CLI) newBaseFlagSet() *pflag.FlagSet {
 	fs := pflag.NewFlagSet("base", pflag.ContinueOnError)
 
 	// Load the base command global flags
 	fs.AddFlagSet(c.cmd.PersistentFlags())
 
-	// If we were unable to load the project configuration, we should also accept the project version flag
-	var projectVersionStr string
-	if !hasConfigFile {
-		fs.StringVar(&projectVersionStr, projectVersionFlag, "", "project version")
-	}
-
 	// FlagSet special cases --help and -h, so we need to create a dummy flag with these 2 values to prevent the default
 	// behavior (printing the usage of this FlagSet) as we want to print the usage message of the underlying command.
 	fs.BoolP("help", "h", false, fmt.Sprintf("help for %s", c.commandName))
@@ -250,6 +294,21 @@ func (c *CLI) getInfoFromFlags(hasConfigFile bool) error {
 	// Omit unknown flags to avoid parsing errors
 	fs.ParseErrorsWhitelist = pflag.ParseErrorsWhitelist{UnknownFlags: true}
 
+	return fs
+}
+
+// getInfoFromFlags obtains the project version and plugin keys from flags.
+func (c *CLI) getInfoFromFlags(hasConfigFile bool) error {
+	// Partially parse the command line arguments
+	fs := c.newBaseFlagSet()
+
+	// If we were unable to load the project configuration, we should also accept the project version flag
+	var projectVersionStr, templateRepository string
+	if !hasConfigFile {
+		fs.StringVar(&projectVersionStr, projectVersionFlag, "", "project version")
+		fs.StringVar(&templateRepository, fromTemplateFlag, "", "template repository")
+	}
+
 	// Parse the arguments
 	if err := fs.Parse(os.Args[1:]); err != nil {
 		return err
@@ -259,17 +318,29 @@ func (c *CLI) getInfoFromFlags(hasConfigFile bool) error {
 	if pluginKeys, err := fs.GetStringSlice(pluginsFlag); err != nil {
 		return err
 	} else if len(pluginKeys) != 0 {
-		// Remove leading and trailing spaces and validate the plugin keys
+		// Remove leading and trailing spaces
 		for i, key := range pluginKeys {
 			pluginKeys[i] = strings.TrimSpace(key)
-			if err := plugin.ValidateKey(pluginKeys[i]); err != nil {
-				return fmt.Errorf("invalid plugin %q found in flags: %w", pluginKeys[i], err)
+		}
+		// Expand the plugin aliases and validate the plugin keys
+		if pluginKeys, err = c.expandPluginAliases(pluginKeys); err != nil {
+			return err
+		}
+		for _, key := range pluginKeys {
+			if err := plugin.ValidateKey(key); err != nil {
+				return fmt.Errorf("invalid plugin %q found in flags: %w", key, err)
 			}
 		}
 
 		c.pluginKeys = pluginKeys
 	}
 
+	allowPluginVersionSkew, err := fs.GetBool(allowPluginVersionSkewFlag)
+	if err != nil {
+		return err
+	}
+	c.allowPluginVersionSkew = allowPluginVersionSkew
+
 	// If the project version flag was accepted but not provided keep the empty version and try to resolve it later,
 	// else validate the provided project version
 	if projectVersionStr != "" {
@@ -278,6 +349,24 @@ func (c *CLI) getInfoFromFlags(hasConfigFile bool) error {
 		}
 	}
 
+	// The template is layered on top of the default plugins of the project version
+	if templateRepository != "" {
+		if len(c.pluginKeys) != 0 {
+			return fmt.Errorf("--%s can not be used with --%s, the template is layered on top of the default plugins",
+				fromTemplateFlag, pluginsFlag)
+		}
+		projectVersion := c.projectVersion
+		if projectVersion.Validate() != nil {
+			projectVersion = c.defaultProjectVersion
+		}
+		template, err := c.loadTemplate(templateRepository, "", projectVersion)
+		if err != nil {
+			return fmt.Errorf("unable to use the template %q: %w", templateRepository, err)
+		}
+		c.template = template
+		c.pluginKeys = []string{template.key}
+	}
+
 	return nil
 }
 
@@ -327,12 +416,12 @@ func (c *CLI) resolvePlugins() error {
 	for _, pluginKey := range c.pluginKeys {
 		var extraErrMsg string
 
-		plugins := make([]plugin.Plugin, 0, len(c.plugins))
+		available := make([]plugin.Plugin, 0, len(c.plugins))
 		for _, p := range c.plugins {
-			plugins = append(plugins, p)
+			available = append(available, p)
 		}
 		// We can omit the error because plugin keys have already been validated
-		plugins, _ = plugin.FilterPluginsByKey(plugins, pluginKey)
+		plugins, _ := plugin.FilterPluginsByKey(available, pluginKey)
 		if knownProjectVersion {
 			plugins = plugin.FilterPluginsByProjectVersion(plugins, c.projectVersion)
 			extraErrMsg += fmt.Sprintf(" for project version %q", c.projectVersion)
@@ -356,7 +445,17 @@ func (c *CLI) resolvePlugins() error {
 		case 1:
 			c.resolvedPlugins = append(c.resolvedPlugins, plugins[0])
 		case 0:
-			return fmt.Errorf("no plugin could be resolved with key %q%s", pluginKey, extraErrMsg)
+			if latest := c.latestPluginOlderThan(pluginKey); latest != nil {
+				skewErr := versionSkewError{key: pluginKey, latest: plugin.KeyFor(latest), cliVersion: c.cliVersion}
+				if !c.allowPluginVersionSkew {
+					return skewErr
+				}
+				_, _ = fmt.Fprintf(os.Stderr, noticeColor, fmt.Sprintf(versionSkewFmt, skewErr.warning()))
+				c.resolvedPlugins = append(c.resolvedPlugins, latest)
+				continue
+			}
+			return fmt.Errorf("no plugin could be resolved with key %q%s%s",
+				pluginKey, extraErrMsg, didYouMean(available, pluginKey))
 		default:
 			return fmt.Errorf("ambiguous plugin %q%s", pluginKey, extraErrMsg)
 		}
@@ -393,6 +492,74 @@ func (c *CLI) resolvePlugins() error {
 	return nil
 }
 
+// latestPluginOlderThan returns the latest version of the plugin of the key when the version of the key is newer
+// than all the versions of this plugin in the CLI, i.e. when the project was scaffolded by a newer CLI.
+// It returns nil otherwise.
+func (c *CLI) latestPluginOlderThan(pluginKey string) plugin.Plugin {
+	name, version := plugin.SplitKey(pluginKey)
+	var ver plugin.Version
+	if version == "" || ver.Parse(version) != nil {
+		return nil
+	}
+
+	plugins := make([]plugin.Plugin, 0, len(c.plugins))
+	for _, p := range c.plugins {
+		plugins = append(plugins, p)
+	}
+	plugins, _ = plugin.FilterPluginsByKey(plugins, name)
+	if c.projectVersion.Validate() == nil {
+		plugins = plugin.FilterPluginsByProjectVersion(plugins, c.projectVersion)
+	}
+
+	var latest plugin.Plugin
+	for _, p := range plugins {
+		// The plugins sharing the name prefix, e.g. "go" and "go.kubebuilder.io", must be the same plugin
+		if latest != nil && latest.Name() != p.Name() {
+			return nil
+		}
+		if latest == nil || p.Version().Compare(latest.Version()) > 0 {
+			latest = p
+		}
+	}
+	if latest == nil || latest.Version().Compare(ver) >= 0 {
+		return nil
+	}
+	return latest
+}
+
+// versionSkewError is returned when the project was scaffolded with a newer version of a plugin than the
+// versions provided by the CLI, which would result in a scaffold mismatching the project.
+type versionSkewError struct {
+	// key is the key of the plugin used by the project
+	key string
+	// latest is the key of the latest version of the plugin provided by the CLI
+	latest string
+	// cliVersion is the version of the CLI
+	cliVersion string
+}
+
+// Error implements error
+func (e versionSkewError) Error() string {
+	return fmt.Sprintf("the project uses the plugin %q, which is newer than the latest version %q supported "+
+		"by this binary. %s, or use --%s to scaffold with %q instead",
+		e.key, e.latest, e.suggestion(), allowPluginVersionSkewFlag, e.latest)
+}
+
+// warning returns the message printed when the version skew is allowed
+func (e versionSkewError) warning() string {
+	return fmt.Sprintf("The project uses the plugin %q, which is newer than the latest version %q supported "+
+		"by this binary. %q is used instead, so the scaffold may not match the project. %s.",
+		e.key, e.latest, e.latest, e.suggestion())
+}
+
+// suggestion returns the suggested binary version
+func (e versionSkewError) suggestion() string {
+	if e.cliVersion == "" {
+		return "Upgrade the binary to the release which scaffolded the project"
+	}
+	return fmt.Sprintf("Upgrade the binary to a release newer than %s which provides %q", e.cliVersion, e.key)
+}
+
 // addSubcommands returns a root command with a subcommand tree reflecting the
 // current project's state.
 func (c *CLI) addSubcommands() {
@@ -409,6 +576,7 @@ func (c *CLI) addSubcommands() {
 	createCmd := c.newCreateCmd()
 	// kubebuilder create api
 	createCmd.AddCommand(c.newCreateAPICmd())
+	createCmd.AddCommand(c.newCreateControllerCmd())
 	createCmd.AddCommand(c.newCreateWebhookCmd())
 	if createCmd.HasSubCommands() {
 		c.cmd.AddCommand(createCmd)
@@ -420,6 +588,12 @@ func (c *CLI) addSubcommands() {
 	// kubebuilder init
 	c.cmd.AddCommand(c.newInitCmd())
 
+	// kubebuilder explain
+	c.cmd.AddCommand(c.newExplainCmd())
+
+	// kubebuilder replay
+	c.cmd.AddCommand(c.newReplayCmd())
+
 	// kubebuilder version
 	// Only add version if a version string was provided
 	if c.version != "" {
@@ -443,9 +617,17 @@ func (c *CLI) addExtraCommands() error {
 // printDeprecationWarnings prints the deprecation warnings of the resolved plugins.
 func (c CLI) printDeprecationWarnings() {
 	for _, p := range c.resolvedPlugins {
-		if p != nil && p.(plugin.Deprecated) != nil && len(p.(plugin.Deprecated).DeprecationWarning()) > 0 {
-			_, _ = fmt.Fprintf(os.Stderr, noticeColor, fmt.Sprintf(deprecationFmt, p.(plugin.Deprecated).DeprecationWarning()))
+		if p == nil {
+			continue
+		}
+		deprecated, warning := plugin.IsDeprecated(p)
+		if !deprecated {
+			continue
+		}
+		if replacement := plugin.ReplacedBy(p); replacement != "" {
+			warning = fmt.Sprintf(replacementFmt, warning, plugin.KeyFor(p), replacement)
 		}
+		_, _ = fmt.Fprintf(os.Stderr, noticeColor, fmt.Sprintf(deprecationFmt, warning))
 	}
 }
 
@@ -453,6 +635,7 @@ func (c CLI) printDeprecationWarnings() {
 func (c CLI) metadata() plugin.CLIMetadata {
 	return plugin.CLIMetadata{
 		CommandName: c.commandName,
+		CliVersion:  c.cliVersion,
 	}
 }
 
@@ -17,6 +17,7 @@ limitations under the License.
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -280,6 +281,15 @@ plugins:
 			})
 		})
 
+		When(fmt.Sprintf("--%s flag is set", allowPluginVersionSkewFlag), func() {
+			It("should tolerate the version skew of the plugins", func() {
+				setBoolFlag(allowPluginVersionSkewFlag)
+
+				Expect(c.getInfoFromFlags(false)).To(Succeed())
+				Expect(c.allowPluginVersionSkew).To(BeTrue())
+			})
+		})
+
 		When("additional flags are set", func() {
 			It("should succeed", func() {
 				setFlag("extra-flag", "extra-value")
@@ -296,6 +306,37 @@ plugins:
 		})
 	})
 
+	Context("isValidatingProject", func() {
+		// Save os.Args and restore it for every test
+		var args []string
+		BeforeEach(func() {
+			c.cmd = c.newRootCmd()
+
+			args = os.Args
+		})
+		AfterEach(func() {
+			os.Args = args
+		})
+
+		DescribeTable("should detect the alpha subcommand validating the PROJECT file",
+			func(validating bool, cmdArgs ...string) {
+				os.Args = append([]string{os.Args[0]}, cmdArgs...)
+
+				Expect(c.isValidatingProject()).To(Equal(validating))
+			},
+			Entry("without flags", true, alphaCommand, validateProjectCommand),
+			Entry("with the plugins flag before the subcommand", true,
+				"--"+pluginsFlag, "go/v4", alphaCommand, validateProjectCommand),
+			Entry("with the project version flag before the subcommand", true,
+				"--"+projectVersionFlag, "3", alphaCommand, validateProjectCommand),
+			Entry("with the flags of the subcommand", true,
+				alphaCommand, validateProjectCommand, "--"+pluginsFlag, "foo/v1", "--help"),
+			Entry("for another alpha subcommand", false, alphaCommand, "generate"),
+			Entry("for the values of the flags", false, "--"+pluginsFlag, alphaCommand, validateProjectCommand),
+			Entry("for another subcommand", false, "edit", alphaCommand, validateProjectCommand),
+		)
+	})
+
 	Context("getInfoFromDefaults", func() {
 		pluginKeys := []string{"go.kubebuilder.io/v2"}
 
@@ -423,6 +464,41 @@ plugins:
 			Expect(c.resolvePlugins()).To(Succeed())
 			Expect(c.projectVersion.Compare(projectVersion)).To(Equal(0))
 		})
+
+		When("the project uses a newer version of a plugin than the CLI", func() {
+			BeforeEach(func() {
+				c.pluginKeys = []string{"bar.kubebuilder.io/v3"}
+				c.projectVersion = projectVersion
+				c.cliVersion = "v4.6.0"
+			})
+
+			It("should fail suggesting a newer binary", func() {
+				err := c.resolvePlugins()
+				Expect(err).To(HaveOccurred())
+
+				var skewErr versionSkewError
+				Expect(errors.As(err, &skewErr)).To(BeTrue())
+				Expect(skewErr.key).To(Equal("bar.kubebuilder.io/v3"))
+				Expect(skewErr.latest).To(Equal("bar.kubebuilder.io/v2"))
+				Expect(err.Error()).To(ContainSubstring("newer than v4.6.0"))
+			})
+
+			It("should resolve the latest version of the plugin when the version skew is allowed", func() {
+				c.allowPluginVersionSkew = true
+
+				Expect(c.resolvePlugins()).To(Succeed())
+				Expect(c.resolvedPlugins).To(HaveLen(1))
+				Expect(plugin.KeyFor(c.resolvedPlugins[0])).To(Equal("bar.kubebuilder.io/v2"))
+			})
+
+			It("should not report a version skew for an older version of the plugin", func() {
+				c.pluginKeys = []string{"foo.example.com/v0"}
+
+				err := c.resolvePlugins()
+				Expect(err).To(HaveOccurred())
+				Expect(errors.As(err, &versionSkewError{})).To(BeFalse())
+			})
+		})
 	})
 
 	Context("New", func() {
@@ -471,6 +547,27 @@ plugins:
 			})
 		})
 
+		When("providing a plugin which creates controllers", func() {
+			It("should create a valid CLI with the create controller subcommand", func() {
+				c, err = New(
+					WithPlugins(&goPluginV4.Plugin{}),
+					WithDefaultPlugins(projectVersion, &goPluginV4.Plugin{}),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				var create *cobra.Command
+				for _, subcmd := range c.cmd.Commands() {
+					if subcmd.Name() == "create" {
+						create = subcmd
+						break
+					}
+				}
+				Expect(create).NotTo(BeNil())
+				Expect(hasSubCommand(create, "api")).To(BeTrue())
+				Expect(hasSubCommand(create, "controller")).To(BeTrue())
+				Expect(hasSubCommand(create, "webhook")).To(BeTrue())
+			})
+		})
+
 		When("enabling completion", func() {
 			It("should create a valid CLI", func() {
 				c, err = New(
@@ -516,6 +613,25 @@ plugins:
 			})
 		})
 
+		When("validating the project with unknown plugins", func() {
+			// Save os.Args and restore it for every test
+			var args []string
+			BeforeEach(func() { args = os.Args })
+			AfterEach(func() { os.Args = args })
+
+			It("should create a valid CLI with the default plugins", func() {
+				os.Args = append(os.Args, alphaCommand, validateProjectCommand, "--"+pluginsFlag, "foo/v1")
+
+				c, err = New(
+					WithPlugins(&goPluginV4.Plugin{}),
+					WithDefaultPlugins(projectVersion, &goPluginV4.Plugin{}),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.pluginKeys).To(Equal([]string{plugin.KeyFor(goPluginV4.Plugin{})}))
+				Expect(hasSubCommand(c.cmd, alphaCommand)).To(BeTrue())
+			})
+		})
+
 		When("providing extra commands", func() {
 			It("should create a valid CLI for non-conflicting ones", func() {
 				extraCommand := &cobra.Command{Use: "extra"}
@@ -598,6 +714,42 @@ plugins:
 				Expect(string(printed)).To(Equal(
 					fmt.Sprintf(noticeColor, fmt.Sprintf(deprecationFmt, deprecationWarning))))
 			})
+
+			It("should succeed and print the successor of the deprecated plugins", func() {
+				const (
+					deprecationWarning = "DEPRECATED"
+					replacement        = "successor.example.com/v2"
+				)
+				deprecatedBundle, bundleErr := plugin.NewBundleWithOptions(
+					plugin.WithName("deprecated.example.com"),
+					plugin.WithVersion(plugin.Version{Number: 1}),
+					plugin.WithDeprecationMessage(deprecationWarning),
+					plugin.WithReplacement(replacement),
+					plugin.WithPlugins(newMockPlugin("deprecated", "v1", projectVersion)),
+				)
+				Expect(bundleErr).NotTo(HaveOccurred())
+
+				// Overwrite stderr to read the deprecation output and reset it afterwards
+				r, w, _ := os.Pipe()
+				temp := os.Stderr
+				defer func() {
+					os.Stderr = temp
+				}()
+				os.Stderr = w
+
+				c, err = New(
+					WithPlugins(deprecatedBundle),
+					WithDefaultPlugins(projectVersion, deprecatedBundle),
+					WithDefaultProjectVersion(projectVersion),
+				)
+
+				_ = w.Close()
+
+				Expect(err).NotTo(HaveOccurred())
+				printed, _ := io.ReadAll(r)
+				Expect(string(printed)).To(Equal(fmt.Sprintf(noticeColor, fmt.Sprintf(deprecationFmt,
+					fmt.Sprintf(replacementFmt, deprecationWarning, "deprecated.example.com/v1", replacement)))))
+			})
 		})
 
 		When("new succeeds", func() {
@@ -20,8 +20,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/config/store"
@@ -118,6 +120,9 @@ func (c *CLI) applySubcommandHooks(
 	}
 
 	options := initializationHooks(cmd, subcommands, c.metadata())
+	if options != nil {
+		registerResourceFlagsCompletion(cmd, yamlstore.New(c.fs))
+	}
 
 	factory := executionHooksFactory{
 		fs:             c.fs,
@@ -127,9 +132,35 @@ func (c *CLI) applySubcommandHooks(
 		projectVersion: c.projectVersion,
 		pluginChain:    pluginChain,
 	}
-	cmd.PreRunE = factory.preRunEFunc(options, createConfig)
-	cmd.RunE = factory.runEFunc()
-	cmd.PostRunE = factory.postRunEFunc()
+	if createConfig {
+		factory.template = c.template
+	}
+	preRunE := factory.preRunEFunc(options, createConfig)
+	runE := factory.runEFunc()
+	postRunE := factory.postRunEFunc(createConfig)
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := factory.startReport(cmd); err != nil {
+			return err
+		}
+		factory.startMetrics(cmd)
+		return factory.finishReportOnError(preRunE(cmd, args))
+	}
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return factory.finishReportOnError(runE(cmd, args))
+	}
+	cmd.PostRunE = func(cmd *cobra.Command, args []string) error {
+		err := postRunE(cmd, args)
+		if err == nil {
+			if err = c.recordInvocation(cmd, c.resolvedFlags(cmd, &factory)); err != nil {
+				err = fmt.Errorf("%s: unable to record the invocation: %w", errorMessage, err)
+			}
+		}
+		factory.finishMetrics()
+		if factory.reporter == nil {
+			return err
+		}
+		return factory.finishReport(err)
+	}
 }
 
 // initializationHooks executes update metadata and bind flags plugin hooks.
@@ -138,18 +169,32 @@ func initializationHooks(
 	subcommands []keySubcommandTuple,
 	meta plugin.CLIMetadata,
 ) *resourceOptions {
-	// Update metadata hook.
-	subcmdMeta := plugin.SubcommandMetadata{
+	subcmdMeta, options := bindSubcommands(cmd.Flags(), subcommands, meta, plugin.SubcommandMetadata{
 		Description: cmd.Long,
 		Examples:    cmd.Example,
-	}
+	})
+	cmd.Long = subcmdMeta.Description
+	cmd.Example = subcmdMeta.Examples
+	applyFlagsMetadata(cmd, subcmdMeta.Flags)
+
+	return options
+}
+
+// bindSubcommands executes the update metadata and bind flags plugin hooks of the subcommands on the flag set.
+// It returns the updated metadata, whose flags describe all the flags of the flag set, and the options of the
+// resource if any subcommand requires it.
+func bindSubcommands(
+	fs *pflag.FlagSet,
+	subcommands []keySubcommandTuple,
+	meta plugin.CLIMetadata,
+	subcmdMeta plugin.SubcommandMetadata,
+) (plugin.SubcommandMetadata, *resourceOptions) {
+	// Update metadata hook.
 	for _, tuple := range subcommands {
 		if subcommand, updatesMetadata := tuple.subcommand.(plugin.UpdatesMetadata); updatesMetadata {
 			subcommand.UpdateMetadata(meta, &subcmdMeta)
 		}
 	}
-	cmd.Long = subcmdMeta.Description
-	cmd.Example = subcmdMeta.Examples
 
 	// Before binding specific plugin flags, bind common ones.
 	requiresResource := false
@@ -160,17 +205,35 @@ func initializationHooks(
 	}
 	var options *resourceOptions
 	if requiresResource {
-		options = bindResourceFlags(cmd.Flags())
+		options = bindResourceFlags(fs)
 	}
 
 	// Bind flags hook.
 	for _, tuple := range subcommands {
 		if subcommand, hasFlags := tuple.subcommand.(plugin.HasFlags); hasFlags {
-			subcommand.BindFlags(cmd.Flags())
+			subcommand.BindFlags(fs)
 		}
 	}
 
-	return options
+	subcmdMeta.Flags = plugin.FlagsMetadata(fs, subcmdMeta.Flags)
+	return subcmdMeta, options
+}
+
+// applyFlagsMetadata renders the allowed values of the flags in their usage and offers them as completions,
+// and marks the deprecated flags.
+func applyFlagsMetadata(cmd *cobra.Command, flags []plugin.FlagMetadata) {
+	for _, flag := range flags {
+		if len(flag.Enum) != 0 {
+			if f := cmd.Flags().Lookup(flag.Name); f != nil {
+				f.Usage = fmt.Sprintf("%s (one of %s)", f.Usage, strings.Join(flag.Enum, ", "))
+			}
+			_ = cmd.RegisterFlagCompletionFunc(flag.Name,
+				cobra.FixedCompletions(flag.Enum, cobra.ShellCompDirectiveNoFileComp))
+		}
+		if flag.Deprecated != "" {
+			_ = cmd.Flags().MarkDeprecated(flag.Name, flag.Deprecated)
+		}
+	}
 }
 
 type executionHooksFactory struct {
@@ -187,6 +250,82 @@ type executionHooksFactory struct {
 	projectVersion config.Version
 	// pluginChain is the plugin chain configured for this project.
 	pluginChain []string
+	// template is the organization template the project is initialized from, if any.
+	// It is only used for initialization.
+	template *projectTemplate
+	// reporter builds the report of the execution when the machine-readable output is requested.
+	reporter *reporter
+}
+
+// startReport starts building the report of the execution if the machine-readable output is requested.
+func (factory *executionHooksFactory) startReport(cmd *cobra.Command) error {
+	output, err := cmd.Flags().GetString(outputFlag)
+	if err != nil {
+		// The flag is not bound when the command is not built by the CLI, e.g. in tests
+		return nil
+	}
+
+	switch output {
+	case textOutput:
+		return nil
+	case jsonOutput:
+		factory.reporter = newReporter(cmd.CommandPath())
+		factory.fs.Recorder = factory.reporter
+		return nil
+	default:
+		return fmt.Errorf("%s: invalid --%s %q, must be one of %q or %q",
+			factory.errorMessage, outputFlag, output, textOutput, jsonOutput)
+	}
+}
+
+// finishReportOnError writes the report and the metrics if the execution failed, and returns the error.
+func (factory *executionHooksFactory) finishReportOnError(err error) error {
+	if err == nil {
+		return nil
+	}
+	factory.finishMetrics()
+	if factory.reporter == nil {
+		return err
+	}
+	return factory.finishReport(err)
+}
+
+// startMetrics starts collecting the metrics of the builders executed by the plugins if they are requested.
+func (factory *executionHooksFactory) startMetrics(cmd *cobra.Command) {
+	// The flag is not bound when the command is not built by the CLI, e.g. in tests
+	if verbose, err := cmd.Flags().GetBool(verboseFlag); err == nil && verbose {
+		factory.fs.Metrics = machinery.NewMetrics()
+	}
+}
+
+// finishMetrics writes the metrics of the builders executed by the plugins, if they were collected, to the
+// standard error so that they do not mix with the report of the machine-readable output.
+func (factory *executionHooksFactory) finishMetrics() {
+	metrics := factory.fs.Metrics
+	if metrics == nil {
+		return
+	}
+	factory.fs.Metrics = nil
+
+	_, _ = fmt.Fprintln(os.Stderr, "Scaffolding metrics:")
+	if err := metrics.WriteReport(os.Stderr); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "unable to write the scaffolding metrics: %v\n", err)
+	}
+}
+
+// finishReport writes the report with the plugins which executed the subcommand, and returns the error.
+func (factory *executionHooksFactory) finishReport(err error) error {
+	plugins := make([]string, 0, len(factory.subcommands))
+	for _, tuple := range factory.subcommands {
+		if !tuple.skip {
+			plugins = append(plugins, tuple.key)
+		}
+	}
+
+	r := factory.reporter
+	factory.reporter = nil
+	factory.fs.Recorder = nil
+	return r.finish(plugins, err)
 }
 
 func (factory *executionHooksFactory) forEach(cb func(subcommand plugin.Subcommand) error, errorMessage string) error {
@@ -247,6 +386,13 @@ func (factory *executionHooksFactory) preRunEFunc(
 			_ = cfg.SetPluginChain(factory.pluginChain)
 		}
 
+		// Track the provenance of the template so that its plugins can be resolved by the later commands.
+		if factory.template != nil {
+			if err := cfg.EncodePluginConfig(factory.template.key, factory.template.provenance); err != nil {
+				return fmt.Errorf("%s: unable to track the template: %w", factory.errorMessage, err)
+			}
+		}
+
 		// Create the resource if non-nil options provided
 		var res *resource.Resource
 		if options != nil {
@@ -316,11 +462,18 @@ func (factory *executionHooksFactory) runEFunc() func(*cobra.Command, []string)
 
 // postRunEFunc returns a cobra RunE function that saves the configuration
 // and executes the post-scaffold hook.
-func (factory *executionHooksFactory) postRunEFunc() func(*cobra.Command, []string) error {
+func (factory *executionHooksFactory) postRunEFunc(createConfig bool) func(*cobra.Command, []string) error {
 	return func(*cobra.Command, []string) error {
 		if err := factory.store.Save(); err != nil {
 			return fmt.Errorf("%s: unable to save configuration file: %w", factory.errorMessage, err)
 		}
+		if factory.reporter != nil {
+			action := machinery.FileUpdated
+			if createConfig {
+				action = machinery.FileCreated
+			}
+			factory.reporter.RecordFile(yamlstore.DefaultPath, action)
+		}
 
 		// Post-scaffold hook.
 		//nolint:revive
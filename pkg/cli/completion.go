@@ -19,8 +19,12 @@ package cli
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config/store"
 )
 
 func (c CLI) newBashCmd() *cobra.Command {
@@ -102,3 +106,82 @@ Detailed instructions on how to do this for each shell are provided in their own
 	cmd.AddCommand(c.newPowerShellCmd())
 	return cmd
 }
+
+// completePluginKeys provides the dynamic completion of the --plugins flag with the keys of the registered plugins.
+// As the flag accepts a comma-separated list, only the last element is completed.
+func (c CLI) completePluginKeys(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix = toComplete[:i+1]
+	}
+
+	keys := make([]string, 0, len(c.plugins)+len(c.pluginAliases))
+	for key := range c.plugins {
+		if strings.HasPrefix(prefix+key, toComplete) {
+			keys = append(keys, prefix+key)
+		}
+	}
+	for name := range c.pluginAliases {
+		if strings.HasPrefix(prefix+name, toComplete) {
+			keys = append(keys, prefix+name)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// registerResourceFlagsCompletion provides the dynamic completion of the --group, --version and --kind flags
+// with the values of the resources tracked in the project configuration file. The values of the other
+// resource flags already set are used to only suggest the values of the matching resources.
+func registerResourceFlagsCompletion(cmd *cobra.Command, projectStore store.Store) {
+	for _, flag := range []string{"group", "version", "kind"} {
+		_ = cmd.RegisterFlagCompletionFunc(flag,
+			func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+				return resourceFlagValues(cmd, projectStore, flag, toComplete), cobra.ShellCompDirectiveNoFileComp
+			})
+	}
+}
+
+// resourceFlagValues returns the sorted and unique values of the resource flag for the resources tracked in the
+// project configuration file which match the other resource flags already set and start with toComplete.
+func resourceFlagValues(cmd *cobra.Command, projectStore store.Store, flag, toComplete string) []string {
+	// The completion must not fail, so no values are suggested if the project configuration can't be loaded
+	if err := projectStore.Load(); err != nil {
+		return nil
+	}
+	resources, err := projectStore.Config().GetResources()
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(resources))
+	values := make([]string, 0, len(resources))
+	for _, res := range resources {
+		fields := map[string]string{"group": res.Group, "version": res.Version, "kind": res.Kind}
+
+		matches := true
+		for name, field := range fields {
+			if name == flag {
+				continue
+			}
+			if set, _ := cmd.Flags().GetString(name); set != "" && set != field {
+				matches = false
+				break
+			}
+		}
+
+		v := fields[flag]
+		if !matches || v == "" || !strings.HasPrefix(v, toComplete) {
+			continue
+		}
+		if _, found := seen[v]; found {
+			continue
+		}
+		seen[v] = struct{}{}
+		values = append(values, v)
+	}
+	sort.Strings(values)
+
+	return values
+}
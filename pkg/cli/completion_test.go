@@ -19,6 +19,13 @@ package cli
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config/store"
+	yamlstore "sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 )
 
 var _ = Describe("Completion", func() {
@@ -80,4 +87,99 @@ var _ = Describe("Completion", func() {
 		})
 
 	})
+
+	Context("completePluginKeys", func() {
+		BeforeEach(func() {
+			c.plugins = map[string]plugin.Plugin{
+				"go.example.com/v1":        newMockPlugin("go.example.com", "v1"),
+				"kustomize.example.com/v2": newMockPlugin("kustomize.example.com", "v2"),
+			}
+		})
+
+		It("should complete the plugin keys starting with the given prefix", func() {
+			keys, directive := c.completePluginKeys(nil, nil, "go")
+			Expect(keys).To(Equal([]string{"go.example.com/v1"}))
+			Expect(directive & cobra.ShellCompDirectiveNoFileComp).NotTo(BeZero())
+		})
+
+		It("should complete all the plugin keys when no prefix is given", func() {
+			keys, _ := c.completePluginKeys(nil, nil, "")
+			Expect(keys).To(Equal([]string{"go.example.com/v1", "kustomize.example.com/v2"}))
+		})
+
+		It("should only complete the last element of a comma-separated list", func() {
+			keys, _ := c.completePluginKeys(nil, nil, "go.example.com/v1,kus")
+			Expect(keys).To(Equal([]string{"go.example.com/v1,kustomize.example.com/v2"}))
+		})
+	})
+
+	Context("resourceFlagValues", func() {
+		var (
+			fs           machinery.Filesystem
+			projectStore store.Store
+			cmd          *cobra.Command
+		)
+
+		BeforeEach(func() {
+			fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+			projectStore = yamlstore.New(fs)
+
+			cmd = &cobra.Command{}
+			_ = bindResourceFlags(cmd.Flags())
+		})
+
+		When("the project configuration file exists", func() {
+			BeforeEach(func() {
+				Expect(afero.WriteFile(fs.FS, "PROJECT", []byte(`domain: example.com
+layout:
+- go.kubebuilder.io/v4
+projectName: test
+repo: example.com/test
+resources:
+- api:
+    crdVersion: v1
+  group: crew
+  kind: Captain
+  version: v1
+- api:
+    crdVersion: v1
+  group: crew
+  kind: Captain
+  version: v2
+- api:
+    crdVersion: v1
+  group: ship
+  kind: Frigate
+  version: v1
+version: "3"
+`), 0o644)).To(Succeed())
+			})
+
+			It("should return the unique values of the resources", func() {
+				Expect(resourceFlagValues(cmd, projectStore, "group", "")).To(Equal([]string{"crew", "ship"}))
+				Expect(resourceFlagValues(cmd, projectStore, "version", "")).To(Equal([]string{"v1", "v2"}))
+				Expect(resourceFlagValues(cmd, projectStore, "kind", "")).To(Equal([]string{"Captain", "Frigate"}))
+			})
+
+			It("should only return the values starting with the given prefix", func() {
+				Expect(resourceFlagValues(cmd, projectStore, "group", "sh")).To(Equal([]string{"ship"}))
+			})
+
+			It("should only return the values of the resources matching the flags already set", func() {
+				Expect(cmd.Flags().Set("group", "crew")).To(Succeed())
+				Expect(resourceFlagValues(cmd, projectStore, "version", "")).To(Equal([]string{"v1", "v2"}))
+				Expect(resourceFlagValues(cmd, projectStore, "kind", "")).To(Equal([]string{"Captain"}))
+
+				Expect(cmd.Flags().Set("group", "")).To(Succeed())
+				Expect(cmd.Flags().Set("version", "v2")).To(Succeed())
+				Expect(resourceFlagValues(cmd, projectStore, "group", "")).To(Equal([]string{"crew"}))
+			})
+		})
+
+		When("the project configuration file does not exist", func() {
+			It("should not return any value", func() {
+				Expect(resourceFlagValues(cmd, projectStore, "group", "")).To(BeEmpty())
+			})
+		})
+	})
 })
@@ -24,7 +24,7 @@ func (CLI) newCreateCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:        "create",
 		SuggestFor: []string{"new"},
-		Short:      "Scaffold a Kubernetes API or webhook",
-		Long:       `Scaffold a Kubernetes API or webhook.`,
+		Short:      "Scaffold a Kubernetes API, controller or webhook",
+		Long:       `Scaffold a Kubernetes API, controller or webhook.`,
 	}
 }
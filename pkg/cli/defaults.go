@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// flagDefaultsFile is the name of the project-local file setting default flag values per subcommand
+	flagDefaultsFile = ".kubebuilder.yaml"
+	// userFlagDefaultsFile is the path, relative to the user configuration directory,
+	// of the file setting default flag values per subcommand for all the projects of the user
+	userFlagDefaultsFile = "kubebuilder/config.yaml"
+)
+
+// flagDefaults maps the subcommands, e.g. "create api", to the default values of their flags.
+type flagDefaults map[string]map[string]interface{}
+
+// applyFlagDefaults loads the default flag values of the user and of the project and sets them
+// as the defaults of the flags of the subcommands. It must be called once the command tree is built
+// and before the command line arguments are parsed, so that the provided flags take precedence.
+//
+// The project-local defaults take precedence over the user defaults, which take precedence over the
+// defaults of the plugin aliases informed in the flags. Flags which are not bound to the subcommand,
+// e.g. because they are provided by a plugin which was not resolved, are ignored.
+func (c *CLI) applyFlagDefaults() error {
+	defaults := flagDefaults{}
+	for _, name := range c.usedPluginAliases {
+		defaults.merge(c.pluginAliases[name].FlagDefaults)
+	}
+	if userConfigDir, err := os.UserConfigDir(); err == nil {
+		if err := defaults.load(c.fs.FS, filepath.Join(userConfigDir, userFlagDefaultsFile)); err != nil {
+			return err
+		}
+	}
+	if err := defaults.load(c.fs.FS, flagDefaultsFile); err != nil {
+		return err
+	}
+
+	// Sort the subcommands so that errors are deterministic
+	subcommands := make([]string, 0, len(defaults))
+	for subcommand := range defaults {
+		subcommands = append(subcommands, subcommand)
+	}
+	sort.Strings(subcommands)
+
+	for _, subcommand := range subcommands {
+		cmd, args, err := c.cmd.Find(strings.Fields(subcommand))
+		if err != nil || cmd == c.cmd || len(args) != 0 {
+			return fmt.Errorf("invalid default flag values: unknown subcommand %q", subcommand)
+		}
+		if err := setFlagDefaults(cmd.Flags(), defaults[subcommand]); err != nil {
+			return fmt.Errorf("invalid default flag values of %q: %w", subcommand, err)
+		}
+	}
+
+	return nil
+}
+
+// load merges the default flag values of the given file, if it exists, into the defaults.
+func (defaults flagDefaults) load(fs afero.Fs, path string) error {
+	content, err := afero.ReadFile(fs, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to read %q: %w", path, err)
+	}
+
+	loaded := flagDefaults{}
+	if err := yaml.Unmarshal(content, &loaded); err != nil {
+		return fmt.Errorf("unable to parse %q: %w", path, err)
+	}
+
+	defaults.merge(loaded)
+	return nil
+}
+
+// merge merges the given default flag values into the defaults, replacing the values already set.
+func (defaults flagDefaults) merge(values flagDefaults) {
+	for subcommand, flags := range values {
+		// Normalize the subcommand, e.g. "create  api" or " edit"
+		subcommand = strings.Join(strings.Fields(subcommand), " ")
+		if defaults[subcommand] == nil {
+			defaults[subcommand] = make(map[string]interface{}, len(flags))
+		}
+		for name, value := range flags {
+			defaults[subcommand][name] = value
+		}
+	}
+}
+
+// setFlagDefaults sets the given values as the values and defaults of the flags.
+func setFlagDefaults(flags *pflag.FlagSet, values map[string]interface{}) error {
+	for name, value := range values {
+		if name == pluginsFlag {
+			// The plugins are resolved before the subcommands are built
+			return fmt.Errorf("the default value of --%s can not be set", pluginsFlag)
+		}
+
+		flag := flags.Lookup(name)
+		if flag == nil {
+			continue
+		}
+
+		if err := setFlagValue(flag, value); err != nil {
+			return fmt.Errorf("invalid value %v for --%s: %w", value, name, err)
+		}
+		flag.DefValue = flag.Value.String()
+		_ = flags.SetAnnotation(name, defaultedFlagAnnotation, []string{"true"})
+	}
+	return nil
+}
+
+// setFlagValue sets the value of the flag without flagging it as changed.
+func setFlagValue(flag *pflag.Flag, value interface{}) error {
+	var values []string
+	if list, isList := value.([]interface{}); isList {
+		for _, v := range list {
+			values = append(values, fmt.Sprint(v))
+		}
+	} else {
+		values = []string{fmt.Sprint(value)}
+	}
+
+	// Slice flags append the values which are set after the first one,
+	// so they are replaced for the values provided in the command line to override them
+	if sliceValue, isSlice := flag.Value.(pflag.SliceValue); isSlice {
+		return sliceValue.Replace(values)
+	}
+	return flag.Value.Set(strings.Join(values, ","))
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	goPluginV4 "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4"
+)
+
+var _ = Describe("Flag defaults", func() {
+	const userConfigDir = "/home/user/.config"
+
+	var (
+		fs             machinery.Filesystem
+		projectVersion = config.Version{Number: 3}
+	)
+
+	BeforeEach(func() {
+		fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+
+		// Read the user defaults from the in-memory filesystem
+		xdgConfigHome, isSet := os.LookupEnv("XDG_CONFIG_HOME")
+		Expect(os.Setenv("XDG_CONFIG_HOME", userConfigDir)).To(Succeed())
+		DeferCleanup(func() {
+			if isSet {
+				_ = os.Setenv("XDG_CONFIG_HOME", xdgConfigHome)
+			} else {
+				_ = os.Unsetenv("XDG_CONFIG_HOME")
+			}
+		})
+	})
+
+	newCLIWithDefaults := func() (*CLI, error) {
+		return New(
+			WithFilesystem(fs),
+			WithPlugins(&goPluginV4.Plugin{}),
+			WithDefaultPlugins(projectVersion, &goPluginV4.Plugin{}),
+			WithDefaultProjectVersion(projectVersion),
+		)
+	}
+
+	initFlags := func(c *CLI) *pflag.FlagSet {
+		cmd, _, err := c.cmd.Find([]string{"init"})
+		Expect(err).NotTo(HaveOccurred())
+		return cmd.Flags()
+	}
+
+	It("should set the defaults of the project and of the user", func() {
+		Expect(afero.WriteFile(fs.FS, filepath.Join(userConfigDir, userFlagDefaultsFile),
+			[]byte("init:\n  owner: The User\n  license: none\n"), 0o644)).To(Succeed())
+		Expect(afero.WriteFile(fs.FS, flagDefaultsFile,
+			[]byte("init:\n  owner: The Team\n  skip-go-version-check: true\n"), 0o644)).To(Succeed())
+
+		c, err := newCLIWithDefaults()
+		Expect(err).NotTo(HaveOccurred())
+
+		flags := initFlags(c)
+		Expect(flags.Lookup("owner").Value.String()).To(Equal("The Team"))
+		Expect(flags.Lookup("owner").DefValue).To(Equal("The Team"))
+		Expect(flags.Lookup("owner").Changed).To(BeFalse())
+		Expect(flags.Lookup("license").Value.String()).To(Equal("none"))
+		Expect(flags.Lookup("skip-go-version-check").Value.String()).To(Equal("true"))
+
+		// The flags provided in the command line take precedence
+		Expect(flags.Parse([]string{"--owner", "Someone"})).To(Succeed())
+		Expect(flags.Lookup("owner").Value.String()).To(Equal("Someone"))
+	})
+
+	It("should ignore the flags which are not bound", func() {
+		Expect(afero.WriteFile(fs.FS, flagDefaultsFile,
+			[]byte("edit:\n  chart-dir: deploy\n"), 0o644)).To(Succeed())
+
+		_, err := newCLIWithDefaults()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	DescribeTable("should fail",
+		func(content string) {
+			Expect(afero.WriteFile(fs.FS, flagDefaultsFile, []byte(content), 0o644)).To(Succeed())
+
+			_, err := newCLIWithDefaults()
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("for unknown subcommands", "deploy:\n  namespace: system\n"),
+		Entry("for unknown nested subcommands", "create cronjob:\n  group: batch\n"),
+		Entry("for invalid values", "init:\n  skip-go-version-check: maybe\n"),
+		Entry("for the plugins flag", "init:\n  plugins: go.kubebuilder.io/v4\n"),
+		Entry("for invalid files", "init: [\n"),
+	)
+
+	Context("setFlagDefaults", func() {
+		It("should replace the values of slice flags", func() {
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flags.StringSlice("names", []string{"default"}, "")
+
+			Expect(setFlagDefaults(flags, map[string]interface{}{"names": []interface{}{"a", "b"}})).To(Succeed())
+			values, err := flags.GetStringSlice("names")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([]string{"a", "b"}))
+
+			// The values provided in the command line replace the defaults
+			Expect(flags.Parse([]string{"--names", "c"})).To(Succeed())
+			values, err = flags.GetStringSlice("names")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([]string{"c"}))
+		})
+	})
+})
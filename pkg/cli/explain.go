@@ -0,0 +1,260 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+// pluginExplanation is the description of the subcommands of plugins printed by the explain subcommand.
+type pluginExplanation struct {
+	// Plugins are the keys of the explained plugins
+	Plugins []string `json:"plugins"`
+	// Deprecations are the deprecation messages of the deprecated plugins
+	Deprecations []string `json:"deprecations,omitempty"`
+	// Subcommands are the subcommands provided by the plugins
+	Subcommands []subcommandExplanation `json:"subcommands"`
+}
+
+// subcommandExplanation is the description of a subcommand provided by the explained plugins.
+type subcommandExplanation struct {
+	// Command is the subcommand, e.g. "create api"
+	Command string `json:"command"`
+	// Description and Examples are the help of the subcommand
+	Description string `json:"description,omitempty"`
+	Examples    string `json:"examples,omitempty"`
+	// Flags are the flags of the subcommand
+	Flags []plugin.FlagMetadata `json:"flags"`
+}
+
+// explainedSubcommands are the subcommands which can be provided by plugins, in the order they are explained.
+var explainedSubcommands = []struct {
+	command string
+	extract func(plugin.Plugin) (plugin.Subcommand, bool)
+}{
+	{"init", func(p plugin.Plugin) (plugin.Subcommand, bool) {
+		if p, isValid := p.(plugin.Init); isValid {
+			return p.GetInitSubcommand(), true
+		}
+		return nil, false
+	}},
+	{"edit", func(p plugin.Plugin) (plugin.Subcommand, bool) {
+		if p, isValid := p.(plugin.Edit); isValid {
+			return p.GetEditSubcommand(), true
+		}
+		return nil, false
+	}},
+	{"create api", func(p plugin.Plugin) (plugin.Subcommand, bool) {
+		if p, isValid := p.(plugin.CreateAPI); isValid {
+			return p.GetCreateAPISubcommand(), true
+		}
+		return nil, false
+	}},
+	{"create controller", func(p plugin.Plugin) (plugin.Subcommand, bool) {
+		if p, isValid := p.(plugin.CreateController); isValid {
+			return p.GetCreateControllerSubcommand(), true
+		}
+		return nil, false
+	}},
+	{"create webhook", func(p plugin.Plugin) (plugin.Subcommand, bool) {
+		if p, isValid := p.(plugin.CreateWebhook); isValid {
+			return p.GetCreateWebhookSubcommand(), true
+		}
+		return nil, false
+	}},
+}
+
+func (c CLI) newExplainCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "explain <plugin>",
+		Short: "Describe the subcommands and the flags of a plugin",
+		Long: `Describe the subcommands provided by a plugin, or by the plugins of a plugin alias, and their flags,
+with their type, default value, allowed values and deprecation.
+
+The description is written as JSON with --output=json, so that it can be read by other tools.
+`,
+		Example: fmt.Sprintf(`  # Describe the subcommands of the go/v4 plugin
+  %[1]s explain go/v4
+
+  # Describe the flags of the subcommands of the helm/v1-alpha plugin as JSON
+  %[1]s explain helm/v1-alpha --output=json
+`, c.commandName),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: c.completeExplainedPlugin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, err := cmd.Flags().GetString(outputFlag)
+			if err != nil {
+				output = textOutput
+			}
+			if output != textOutput && output != jsonOutput {
+				return fmt.Errorf("invalid --%s %q, must be one of %q or %q", outputFlag, output, textOutput, jsonOutput)
+			}
+
+			explanation, err := c.explain(args[0])
+			if err != nil {
+				return err
+			}
+
+			if output == jsonOutput {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(explanation)
+			}
+			return explanation.write(cmd.OutOrStdout(), c.commandName)
+		},
+	}
+}
+
+// completeExplainedPlugin provides the dynamic completion of the plugin key argument of the explain subcommand.
+func (c CLI) completeExplainedPlugin(
+	cmd *cobra.Command,
+	args []string,
+	toComplete string,
+) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return c.completePluginKeys(cmd, args, toComplete)
+}
+
+// explain returns the description of the subcommands of the plugin, or of the plugins of the plugin alias,
+// of the given key.
+func (c CLI) explain(key string) (pluginExplanation, error) {
+	keys := []string{key}
+	if alias, isAlias := c.pluginAliases[key]; isAlias {
+		keys = alias.Plugins
+	}
+
+	available := make([]plugin.Plugin, 0, len(c.plugins))
+	for _, p := range c.plugins {
+		available = append(available, p)
+	}
+
+	var explanation pluginExplanation
+	var plugins []plugin.Plugin
+	for _, key := range keys {
+		if err := plugin.ValidateKey(key); err != nil {
+			return pluginExplanation{}, fmt.Errorf("invalid plugin key %q: %w", key, err)
+		}
+		matching, err := plugin.FilterPluginsByKey(available, key)
+		if err != nil {
+			return pluginExplanation{}, fmt.Errorf("invalid plugin key %q: %w", key, err)
+		}
+		switch len(matching) {
+		case 1:
+		case 0:
+			return pluginExplanation{}, fmt.Errorf("no plugin could be resolved with key %q%s",
+				key, didYouMean(available, key))
+		default:
+			matchingKeys := make([]string, 0, len(matching))
+			for _, p := range matching {
+				matchingKeys = append(matchingKeys, plugin.KeyFor(p))
+			}
+			sort.Strings(matchingKeys)
+			return pluginExplanation{}, fmt.Errorf("ambiguous plugin %q, it matches %q", key, matchingKeys)
+		}
+
+		p := matching[0]
+		explanation.Plugins = append(explanation.Plugins, plugin.KeyFor(p))
+		if deprecated, warning := plugin.IsDeprecated(p); deprecated {
+			explanation.Deprecations = append(explanation.Deprecations, warning)
+		}
+		if bundle, isBundle := p.(plugin.Bundle); isBundle {
+			plugins = append(plugins, bundle.Plugins()...)
+		} else {
+			plugins = append(plugins, p)
+		}
+	}
+
+	for _, explained := range explainedSubcommands {
+		subcommands := make([]keySubcommandTuple, 0, len(plugins))
+		for _, p := range plugins {
+			if subcommand, isValid := explained.extract(p); isValid {
+				subcommands = append(subcommands, keySubcommandTuple{key: plugin.KeyFor(p), subcommand: subcommand})
+			}
+		}
+		if len(subcommands) == 0 {
+			continue
+		}
+
+		fs := pflag.NewFlagSet(explained.command, pflag.ContinueOnError)
+		subcmdMeta, _ := bindSubcommands(fs, subcommands, c.metadata(), plugin.SubcommandMetadata{})
+		explanation.Subcommands = append(explanation.Subcommands, subcommandExplanation{
+			Command:     explained.command,
+			Description: strings.TrimSpace(subcmdMeta.Description),
+			Examples:    strings.TrimRight(subcmdMeta.Examples, "\n"),
+			Flags:       subcmdMeta.Flags,
+		})
+	}
+
+	return explanation, nil
+}
+
+// write writes the human-readable description of the subcommands and of their flags.
+func (e pluginExplanation) write(w io.Writer, commandName string) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Plugins: %s\n", strings.Join(e.Plugins, ", "))
+	for _, deprecation := range e.Deprecations {
+		fmt.Fprintf(&sb, "Deprecated: %s\n", deprecation)
+	}
+
+	for _, subcommand := range e.Subcommands {
+		fmt.Fprintf(&sb, "\n%s %s\n", commandName, subcommand.Command)
+		if len(subcommand.Flags) == 0 {
+			sb.WriteString("  No flags\n")
+		}
+		for _, flag := range subcommand.Flags {
+			fmt.Fprintf(&sb, "  --%s %s", flag.Name, flag.Type)
+			if !isZeroDefault(flag.Default) {
+				fmt.Fprintf(&sb, " (default %q)", flag.Default)
+			}
+			sb.WriteString("\n")
+			if flag.Usage != "" {
+				fmt.Fprintf(&sb, "      %s\n", flag.Usage)
+			}
+			if len(flag.Enum) != 0 {
+				fmt.Fprintf(&sb, "      Allowed values: %s\n", strings.Join(flag.Enum, ", "))
+			}
+			if flag.Deprecated != "" {
+				fmt.Fprintf(&sb, "      Deprecated: %s\n", flag.Deprecated)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// isZeroDefault returns true when the default value of a flag is the zero value of its type,
+// which is not written in the human-readable description.
+func isZeroDefault(value string) bool {
+	switch value {
+	case "", "false", "0", "[]":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+type mockInitSubcommand struct{}
+
+func (mockInitSubcommand) UpdateMetadata(_ plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	subcmdMeta.Description = "Initialize a project"
+	subcmdMeta.Flags = append(subcmdMeta.Flags,
+		plugin.FlagMetadata{Name: "license", Enum: []string{"apache2", "none"}},
+		plugin.FlagMetadata{Name: "legacy", Deprecated: "it will be removed"},
+	)
+}
+
+func (mockInitSubcommand) BindFlags(fs *pflag.FlagSet) {
+	fs.String("license", "apache2", "license of the project")
+	fs.Bool("legacy", false, "use the legacy layout")
+}
+
+func (mockInitSubcommand) Scaffold(machinery.Filesystem) error { return nil }
+
+type mockInitPlugin struct {
+	mockPlugin
+}
+
+func (mockInitPlugin) GetInitSubcommand() plugin.InitSubcommand { return mockInitSubcommand{} }
+
+var _ = Describe("explain", func() {
+	var c CLI
+
+	BeforeEach(func() {
+		c = CLI{
+			commandName: "kubebuilder",
+			plugins: map[string]plugin.Plugin{
+				"go.example.com/v1": mockInitPlugin{newMockPlugin("go.example.com", "v1").(mockPlugin)},
+				"go.example.com/v2": newMockPlugin("go.example.com", "v2"),
+				"kustomize.example.com/v2": newMockDeprecatedPlugin("kustomize.example.com", "v2",
+					"use kustomize.example.com/v3"),
+			},
+			pluginAliases: map[string]PluginAlias{
+				"company": {Name: "company", Plugins: []string{"go.example.com/v1", "kustomize.example.com/v2"}},
+			},
+		}
+	})
+
+	It("should describe the subcommands of the plugin and their flags", func() {
+		explanation, err := c.explain("go.example.com/v1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(explanation.Plugins).To(Equal([]string{"go.example.com/v1"}))
+		Expect(explanation.Subcommands).To(Equal([]subcommandExplanation{{
+			Command:     "init",
+			Description: "Initialize a project",
+			Flags: []plugin.FlagMetadata{
+				{
+					Name: "legacy", Type: "bool", Default: "false", Usage: "use the legacy layout",
+					Deprecated: "it will be removed",
+				},
+				{
+					Name: "license", Type: "string", Default: "apache2", Usage: "license of the project",
+					Enum: []string{"apache2", "none"},
+				},
+			},
+		}}))
+	})
+
+	It("should describe the plugins of a plugin alias", func() {
+		explanation, err := c.explain("company")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(explanation.Plugins).To(Equal([]string{"go.example.com/v1", "kustomize.example.com/v2"}))
+		Expect(explanation.Deprecations).To(Equal([]string{"use kustomize.example.com/v3"}))
+		Expect(explanation.Subcommands).To(HaveLen(1))
+	})
+
+	It("should fail for an unknown plugin", func() {
+		_, err := c.explain("helm.example.com/v1")
+		Expect(err).To(MatchError(ContainSubstring("no plugin could be resolved")))
+	})
+
+	It("should fail for an ambiguous plugin", func() {
+		_, err := c.explain("go.example.com")
+		Expect(err).To(MatchError(ContainSubstring("ambiguous plugin")))
+	})
+
+	It("should write the description as JSON", func() {
+		cmd := c.newExplainCmd()
+		cmd.Flags().String(outputFlag, textOutput, "")
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"go.example.com/v1", "--output", jsonOutput})
+		Expect(cmd.Execute()).To(Succeed())
+
+		var explanation pluginExplanation
+		Expect(json.Unmarshal(out.Bytes(), &explanation)).To(Succeed())
+		Expect(explanation.Subcommands[0].Flags[1].Enum).To(Equal([]string{"apache2", "none"}))
+	})
+
+	It("should write the human-readable description", func() {
+		out := &bytes.Buffer{}
+		explanation, err := c.explain("go.example.com/v1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(explanation.write(out, c.commandName)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("kubebuilder init\n"))
+		Expect(out.String()).To(ContainSubstring("  --license string (default \"apache2\")\n"))
+		Expect(out.String()).To(ContainSubstring("      Allowed values: apache2, none\n"))
+		Expect(out.String()).To(ContainSubstring("      Deprecated: it will be removed\n"))
+	})
+})
+
+var _ = Describe("applyFlagsMetadata", func() {
+	It("should render the allowed values, complete them and mark the deprecated flags", func() {
+		cmd := &cobra.Command{Use: "init"}
+		cmd.Flags().String("license", "apache2", "license of the project")
+		cmd.Flags().Bool("legacy", false, "use the legacy layout")
+
+		applyFlagsMetadata(cmd, []plugin.FlagMetadata{
+			{Name: "license", Enum: []string{"apache2", "none"}},
+			{Name: "legacy", Deprecated: "it will be removed"},
+		})
+
+		Expect(cmd.Flags().Lookup("license").Usage).To(Equal("license of the project (one of apache2, none)"))
+		completion, found := cmd.GetFlagCompletionFunc("license")
+		Expect(found).To(BeTrue())
+		values, _ := completion(cmd, nil, "")
+		Expect(values).To(Equal([]string{"apache2", "none"}))
+		Expect(cmd.Flags().Lookup("legacy").Deprecated).To(Equal("it will be removed"))
+	})
+})
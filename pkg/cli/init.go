@@ -45,6 +45,9 @@ For further help about a specific plugin, set --plugins.
 	// Register --project-version on the dynamically created command
 	// so that it shows up in help and does not cause a parse error.
 	cmd.Flags().String(projectVersionFlag, c.defaultProjectVersion.String(), "project version")
+	// Register --from-template, which is also resolved before building the commands as it provides the plugins.
+	cmd.Flags().String(fromTemplateFlag, "",
+		"git URL of an organization template whose plugins are layered on top of the default plugins")
 
 	// In case no plugin was resolved, instead of failing the construction of the CLI, fail the execution of
 	// this subcommand. This allows the use of subcommands that do not require resolved plugins like help.
@@ -92,7 +95,7 @@ func (c CLI) getAvailableProjectVersions() (projectVersions []string) {
 	versionSet := make(map[config.Version]struct{})
 	for _, p := range c.plugins {
 		// Only return versions of non-deprecated plugins.
-		if _, isDeprecated := p.(plugin.Deprecated); !isDeprecated {
+		if deprecated, _ := plugin.IsDeprecated(p); !deprecated {
 			for _, version := range p.SupportedProjectVersions() {
 				versionSet[version] = struct{}{}
 			}
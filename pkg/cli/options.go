@@ -57,6 +57,14 @@ func WithVersion(version string) Option {
 	}
 }
 
+// WithCliVersion is an Option that defines the version of the CLI (e.g. v4.6.0) which is provided to the plugins.
+func WithCliVersion(version string) Option {
+	return func(c *CLI) error {
+		c.cliVersion = version
+		return nil
+	}
+}
+
 // WithDescription is an Option that sets the CLI's root description.
 func WithDescription(description string) Option {
 	return func(c *CLI) error {
@@ -108,6 +116,26 @@ func WithDefaultPlugins(projectVersion config.Version, plugins ...plugin.Plugin)
 	}
 }
 
+// WithPluginAliases is an Option that registers plugin aliases in the CLI, which are expanded into
+// their plugins when they are informed with --plugins.
+//
+// Specifying an invalid alias or two aliases with the same name results in an error. The aliases which
+// conflict with the plugins of the CLI or contain unknown plugins make New fail.
+func WithPluginAliases(aliases ...PluginAlias) Option {
+	return func(c *CLI) error {
+		for _, alias := range aliases {
+			if _, isConflicting := c.pluginAliases[alias.Name]; isConflicting {
+				return fmt.Errorf("two plugin aliases have the same name: %q", alias.Name)
+			}
+			if err := alias.validate(); err != nil {
+				return fmt.Errorf("broken pre-set plugin alias %q: %w", alias.Name, err)
+			}
+			c.pluginAliases[alias.Name] = alias
+		}
+		return nil
+	}
+}
+
 // WithDefaultProjectVersion is an Option that sets the CLI's default project version.
 //
 // Setting an invalid version results in an error.
@@ -247,6 +275,12 @@ func DiscoverExternalPlugins(fs afero.Fs) (ps []plugin.Plugin, err error) {
 		return nil, err
 	}
 
+	return discoverExternalPluginsIn(fs, pluginsRoot)
+}
+
+// discoverExternalPluginsIn discovers the external plugins laid out as <name>/<version>/<executable> in the
+// provided directory.
+func discoverExternalPluginsIn(fs afero.Fs, pluginsRoot string) (ps []plugin.Plugin, err error) {
 	rootInfo, err := fs.Stat(pluginsRoot)
 	if err != nil {
 		if errors.Is(err, afero.ErrFileNotFound) {
@@ -528,6 +528,17 @@ var _ = Describe("CLI options", func() {
 		})
 	})
 
+	Context("WithCliVersion", func() {
+		It("should use the provided CLI version", func() {
+			version := "v4.6.0"
+			c, err = newCLI(WithCliVersion(version))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).NotTo(BeNil())
+			Expect(c.cliVersion).To(Equal(version))
+			Expect(c.metadata().CliVersion).To(Equal(version))
+		})
+	})
+
 	Context("WithDescription", func() {
 		It("should use the provided description string", func() {
 			description := "alternative description"
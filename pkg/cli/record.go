@@ -0,0 +1,270 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	recordFlag = "record"
+
+	replayDirFlag = "dir"
+
+	// projectNameFlag is the flag of the init subcommand of the plugins setting the name of the project
+	projectNameFlag = "project-name"
+
+	// defaultedFlagAnnotation flags the flags whose default value is set by the flag defaults files or by the
+	// plugin aliases, so that they are recorded with their value as if they were provided
+	defaultedFlagAnnotation = "kubebuilder.io/defaulted"
+)
+
+// unrecordedFlags are the flags which do not change the scaffolded files and are not recorded
+var unrecordedFlags = map[string]bool{
+	recordFlag:  true,
+	outputFlag:  true,
+	verboseFlag: true,
+	"help":      true,
+}
+
+// executable returns the binary of the CLI which runs the replayed invocations
+var executable = os.Executable
+
+// session is a recorded sequence of invocations of the CLI, which can be replayed to scaffold the same project.
+type session struct {
+	// CLIVersion is the version of the CLI which recorded the session
+	CLIVersion string `json:"cliVersion,omitempty"`
+	// Commands are the recorded invocations, in the order they were executed
+	Commands []sessionCommand `json:"commands"`
+}
+
+// sessionCommand is a recorded invocation of the CLI.
+type sessionCommand struct {
+	// Args are the arguments of the invocation without the name of the CLI, e.g. ["create", "api", "--group=ship"]
+	Args []string `json:"args"`
+}
+
+// loadSession reads the session recorded in the given file. An empty session is returned if the file does not exist.
+func loadSession(fs afero.Fs, path string) (session, error) {
+	var s session
+	content, err := afero.ReadFile(fs, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return s, fmt.Errorf("unable to read the session %q: %w", path, err)
+	}
+	if err := yaml.Unmarshal(content, &s); err != nil {
+		return s, fmt.Errorf("unable to parse the session %q: %w", path, err)
+	}
+	return s, nil
+}
+
+// save writes the session to the given file.
+func (s session) save(fs afero.Fs, path string) error {
+	content, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("unable to encode the session: %w", err)
+	}
+	if err := afero.WriteFile(fs, path, content, 0o644); err != nil {
+		return fmt.Errorf("unable to write the session %q: %w", path, err)
+	}
+	return nil
+}
+
+// resolvedFlags returns the values of the flags resolved by the CLI, which are recorded instead of the provided
+// values, e.g. the plugin keys expanded from the plugin aliases, or when they are not provided, so that the
+// invocation is replayed with the same plugins, project version and project name.
+func (c CLI) resolvedFlags(cmd *cobra.Command, factory *executionHooksFactory) map[string]string {
+	resolved := make(map[string]string)
+	if cmd.Flags().Changed(pluginsFlag) {
+		resolved[pluginsFlag] = strings.Join(c.pluginKeys, ",")
+	}
+
+	// The chain is only computed when initializing a project, the other subcommands resolve it from the PROJECT file
+	if len(factory.pluginChain) != 0 {
+		resolved[projectVersionFlag] = factory.projectVersion.String()
+		// The plugins of a template are resolved from the template, which can not be used with --plugins
+		if !cmd.Flags().Changed(fromTemplateFlag) {
+			resolved[pluginsFlag] = strings.Join(factory.pluginChain, ",")
+		}
+		// The project name defaults to the name of the directory, which differs where the session is replayed
+		if cmd.Flags().Lookup(projectNameFlag) != nil && factory.store.Config().GetProjectName() != "" {
+			resolved[projectNameFlag] = factory.store.Config().GetProjectName()
+		}
+	}
+	return resolved
+}
+
+// invocationArgs returns the arguments which reproduce the execution of the command: its path and the flags
+// which were resolved with the given values, provided or defaulted by the flag defaults files.
+func invocationArgs(cmd *cobra.Command, resolved map[string]string) []string {
+	args := strings.Fields(cmd.CommandPath())[1:]
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if unrecordedFlags[flag.Name] {
+			return
+		}
+		if value, isResolved := resolved[flag.Name]; isResolved {
+			args = append(args, fmt.Sprintf("--%s=%s", flag.Name, value))
+		} else if _, defaulted := flag.Annotations[defaultedFlagAnnotation]; flag.Changed || defaulted {
+			args = append(args, flagArgs(flag)...)
+		}
+	})
+	return args
+}
+
+// flagArgs returns the arguments which set the value of the flag. The lists are set with one argument
+// per value, which is supported by both the slice and the array flags.
+func flagArgs(flag *pflag.Flag) []string {
+	list, isList := flag.Value.(pflag.SliceValue)
+	if !isList {
+		return []string{fmt.Sprintf("--%s=%s", flag.Name, flag.Value.String())}
+	}
+
+	values := list.GetSlice()
+	if len(values) == 0 {
+		return []string{fmt.Sprintf("--%s=", flag.Name)}
+	}
+	args := make([]string, 0, len(values))
+	for _, value := range values {
+		args = append(args, fmt.Sprintf("--%s=%s", flag.Name, value))
+	}
+	return args
+}
+
+// recordInvocation appends the invocation of the command to the session recorded in the file set with --record,
+// if any. The given flags are recorded with their resolved values when they were not provided.
+func (c CLI) recordInvocation(cmd *cobra.Command, resolved map[string]string) error {
+	path, err := cmd.Flags().GetString(recordFlag)
+	if err != nil || path == "" {
+		// The flag is not bound when the command is not built by the CLI, e.g. in tests
+		return nil
+	}
+
+	s, err := loadSession(c.fs.FS, path)
+	if err != nil {
+		return err
+	}
+	if s.CLIVersion == "" {
+		s.CLIVersion = c.cliVersion
+	}
+	s.Commands = append(s.Commands, sessionCommand{Args: invocationArgs(cmd, resolved)})
+	return s.save(c.fs.FS, path)
+}
+
+func (c CLI) newReplayCmd() *cobra.Command {
+	var dir string
+	cmd := &cobra.Command{
+		Use:   "replay <session>",
+		Short: "Replay the invocations recorded in a session",
+		Long: fmt.Sprintf(`Replay the init, create and edit invocations recorded with --%[1]s in a fresh directory,
+e.g. to reproduce a bug, to migrate a project to a newer release or to document how a project was scaffolded.
+
+The invocations are executed in order, with the flags they were recorded with, by this binary. The replay
+stops at the first invocation which fails.
+`, recordFlag),
+		Example: fmt.Sprintf(`  # Record the scaffolding of a project
+  %[1]s init --domain example.org --repo example.org/project --%[2]s=session.yaml
+  %[1]s create api --group ship --version v1 --kind Frigate --%[2]s=session.yaml
+
+  # Scaffold the project again in the fresh directory session
+  %[1]s replay session.yaml
+
+  # Scaffold the project again in the fresh directory project-v2
+  %[1]s replay session.yaml --%[3]s=project-v2`, c.commandName, recordFlag, replayDirFlag),
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return c.replay(args[0], dir)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, replayDirFlag, "",
+		"directory where the session is replayed, which must be empty or not exist. If not provided, the "+
+			"directory named after the session file is used, e.g. session for session.yaml")
+
+	return cmd
+}
+
+// replay runs the invocations of the session recorded in the given file in the given directory,
+// or in the directory named after the session file when it is empty.
+func (c CLI) replay(path, dir string) error {
+	s, err := loadSession(c.fs.FS, path)
+	if err != nil {
+		return err
+	}
+	if len(s.Commands) == 0 {
+		return fmt.Errorf("the session %q has no recorded invocation", path)
+	}
+
+	if dir == "" {
+		dir = defaultReplayDir(path)
+	}
+
+	entries, err := afero.ReadDir(c.fs.FS, dir)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if err := c.fs.FS.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("unable to create the directory %q: %w", dir, err)
+		}
+	case err != nil:
+		return fmt.Errorf("unable to read the directory %q: %w", dir, err)
+	case len(entries) != 0:
+		return fmt.Errorf("the session must be replayed in a fresh directory, %q is not empty", dir)
+	}
+
+	if s.CLIVersion != "" && c.cliVersion != "" && s.CLIVersion != c.cliVersion {
+		log.Warnf("The session was recorded with %s, it is replayed with %s", s.CLIVersion, c.cliVersion)
+	}
+
+	binary, err := executable()
+	if err != nil {
+		return fmt.Errorf("unable to find the binary of %s: %w", c.commandName, err)
+	}
+	for i, command := range s.Commands {
+		log.Infof("Replaying %s %s", c.commandName, strings.Join(command.Args, " "))
+		cmd := exec.Command(binary, command.Args...) //nolint:gosec
+		cmd.Dir = dir
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("invocation %d of the session, %q, failed: %w",
+				i+1, strings.Join(command.Args, " "), err)
+		}
+	}
+	return nil
+}
+
+// defaultReplayDir returns the directory, in the current one, named after the session file without its
+// extension. The session files without an extension are replayed in the directory <name>-replay instead.
+func defaultReplayDir(path string) string {
+	name := filepath.Base(path)
+	if dir := strings.TrimSuffix(name, filepath.Ext(name)); dir != "" && dir != name {
+		return dir
+	}
+	return name + "-replay"
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+// recordingBinary appends its arguments, and the directory it runs in, to the file set in the REPLAYED
+// environment variable, and fails when it is invoked with --fail
+const recordingBinary = `#!/bin/sh
+for arg in "$@"; do test "$arg" = "--fail" && exit 1; done
+echo "$(basename "$PWD") $*" >> "$REPLAYED"
+`
+
+var _ = Describe("Recording", func() {
+	var (
+		c   *CLI
+		cmd *cobra.Command
+	)
+
+	BeforeEach(func() {
+		c = &CLI{
+			commandName: "kubebuilder",
+			cliVersion:  "v4.0.0",
+			fs:          machinery.Filesystem{FS: afero.NewMemMapFs()},
+		}
+
+		root := &cobra.Command{Use: "kubebuilder"}
+		root.PersistentFlags().String(recordFlag, "", "")
+		root.PersistentFlags().String(outputFlag, textOutput, "")
+		root.PersistentFlags().StringSlice(pluginsFlag, nil, "")
+		create := &cobra.Command{Use: "create"}
+		cmd = &cobra.Command{Use: "api", Run: func(*cobra.Command, []string) {}}
+		cmd.Flags().String("group", "", "")
+		cmd.Flags().Bool("namespaced", true, "")
+		cmd.Flags().Bool("make", true, "")
+		cmd.Flags().StringSlice("watch", nil, "")
+		cmd.Flags().String("unset", "", "")
+		root.AddCommand(create)
+		create.AddCommand(cmd)
+	})
+
+	execute := func(args ...string) {
+		root := cmd.Root()
+		root.SetArgs(append([]string{"create", "api"}, args...))
+		Expect(root.Execute()).To(Succeed())
+	}
+
+	It("should record the provided, defaulted and resolved flags", func() {
+		Expect(setFlagDefaults(cmd.Flags(), map[string]interface{}{"make": false})).To(Succeed())
+		execute("--group=ship", "--watch", "apps/Deployment,core/ConfigMap:map", "--output=json",
+			"--plugins=go/v4", "--record=session.yaml")
+
+		Expect(invocationArgs(cmd, map[string]string{pluginsFlag: "go.kubebuilder.io/v4"})).To(Equal([]string{
+			"create", "api",
+			"--group=ship",
+			"--make=false",
+			"--plugins=go.kubebuilder.io/v4",
+			"--watch=apps/Deployment",
+			"--watch=core/ConfigMap:map",
+		}))
+	})
+
+	It("should append the invocations to the session", func() {
+		execute("--group=ship", "--record=session.yaml")
+		Expect(c.recordInvocation(cmd, nil)).To(Succeed())
+		Expect(c.recordInvocation(cmd, map[string]string{"unset": "value"})).To(Succeed())
+
+		s, err := loadSession(c.fs.FS, "session.yaml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s).To(Equal(session{
+			CLIVersion: "v4.0.0",
+			Commands: []sessionCommand{
+				{Args: []string{"create", "api", "--group=ship"}},
+				{Args: []string{"create", "api", "--group=ship", "--unset=value"}},
+			},
+		}))
+	})
+
+	It("should not record the invocation without --record", func() {
+		execute("--group=ship")
+		Expect(c.recordInvocation(cmd, nil)).To(Succeed())
+
+		files, err := afero.ReadDir(c.fs.FS, ".")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Replaying", func() {
+	var (
+		c        *CLI
+		dir      string
+		replayed string
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		c = &CLI{commandName: "kubebuilder", fs: machinery.Filesystem{FS: afero.NewOsFs()}}
+
+		binary := filepath.Join(dir, "kubebuilder")
+		Expect(os.WriteFile(binary, []byte(recordingBinary), 0o755)).To(Succeed())
+		executable = func() (string, error) { return binary, nil }
+		DeferCleanup(func() { executable = os.Executable })
+
+		replayed = filepath.Join(dir, "replayed")
+		GinkgoT().Setenv("REPLAYED", replayed)
+	})
+
+	writeSession := func(commands ...[]string) string {
+		s := session{}
+		for _, args := range commands {
+			s.Commands = append(s.Commands, sessionCommand{Args: args})
+		}
+		path := filepath.Join(dir, "session.yaml")
+		Expect(s.save(c.fs.FS, path)).To(Succeed())
+		return path
+	}
+
+	readReplayed := func() []string {
+		content, err := os.ReadFile(replayed)
+		Expect(err).NotTo(HaveOccurred())
+		return strings.Split(strings.TrimSpace(string(content)), "\n")
+	}
+
+	It("should run the invocations in order in the fresh directory", func() {
+		session := writeSession([]string{"init", "--domain=example.org"}, []string{"create", "api", "--group=ship"})
+		Expect(c.replay(session, filepath.Join(dir, "project"))).To(Succeed())
+
+		Expect(readReplayed()).To(Equal([]string{
+			"project init --domain=example.org",
+			"project create api --group=ship",
+		}))
+	})
+
+	It("should stop at the first invocation which fails", func() {
+		session := writeSession([]string{"init"}, []string{"edit", "--fail"}, []string{"create", "api"})
+		err := c.replay(session, filepath.Join(dir, "project"))
+		Expect(err).To(MatchError(ContainSubstring(`invocation 2 of the session, "edit --fail", failed`)))
+
+		Expect(readReplayed()).To(Equal([]string{"project init"}))
+	})
+
+	It("should fail when the directory is not empty", func() {
+		session := writeSession([]string{"init"})
+		Expect(c.replay(session, dir)).To(MatchError(ContainSubstring("is not empty")))
+	})
+
+	It("should replay the session in the directory named after the session file by default", func() {
+		wd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.Chdir, wd)
+		Expect(os.Chdir(dir)).To(Succeed())
+
+		session := writeSession([]string{"init"})
+		Expect(c.replay(session, "")).To(Succeed())
+
+		Expect(filepath.Join(dir, "session")).To(BeADirectory())
+		Expect(readReplayed()).To(Equal([]string{"session init"}))
+	})
+
+	DescribeTable("defaultReplayDir",
+		func(path, replayDir string) {
+			Expect(defaultReplayDir(path)).To(Equal(replayDir))
+		},
+		Entry("session file with an extension", "session.yaml", "session"),
+		Entry("session file in another directory", filepath.Join("sessions", "bug.yaml"), "bug"),
+		Entry("session file without an extension", "session", "session-replay"),
+		Entry("hidden session file", ".session", ".session-replay"),
+	)
+
+	It("should fail when the session has no invocation", func() {
+		Expect(c.replay(filepath.Join(dir, "missing.yaml"), filepath.Join(dir, "project"))).
+			To(MatchError(ContainSubstring("has no recorded invocation")))
+	})
+})
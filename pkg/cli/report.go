@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+const (
+	outputFlag = "output"
+
+	textOutput = "text"
+	jsonOutput = "json"
+)
+
+// commandReport is the structured report emitted by the init, create and edit subcommands
+// when the machine-readable output is requested.
+type commandReport struct {
+	// Command is the executed command, e.g. "kubebuilder create api"
+	Command string `json:"command"`
+	// Plugins are the keys of the plugins which executed the subcommand
+	Plugins []string `json:"plugins"`
+	// Created are the files created by the plugins
+	Created []string `json:"created"`
+	// Updated are the existing files overwritten or updated by the plugins
+	Updated []string `json:"updated"`
+	// Skipped are the existing files which were not updated by the plugins
+	Skipped []string `json:"skipped"`
+	// Warnings are the warnings logged during the execution
+	Warnings []string `json:"warnings"`
+	// Error is the error which made the command fail, if any
+	Error string `json:"error,omitempty"`
+}
+
+var _ machinery.Recorder = &reporter{}
+
+// reporter builds the commandReport of a subcommand execution.
+// While it is running, the standard output is redirected to the standard error so that
+// only the report is written to the standard output.
+type reporter struct {
+	report commandReport
+
+	files map[string]machinery.FileAction
+
+	stdout *os.File
+	hooks  log.LevelHooks
+}
+
+// newReporter starts building the report of the given command, capturing the warnings and the standard output.
+func newReporter(command string) *reporter {
+	r := &reporter{
+		report: commandReport{Command: command},
+		files:  make(map[string]machinery.FileAction),
+		stdout: os.Stdout,
+		hooks:  make(log.LevelHooks),
+	}
+
+	// Capture the warnings logged by the plugins
+	for level, hooks := range log.StandardLogger().Hooks {
+		r.hooks[level] = append(r.hooks[level], hooks...)
+	}
+	log.AddHook(warningsHook{report: &r.report})
+
+	// Keep the standard output for the report
+	os.Stdout = os.Stderr
+
+	return r
+}
+
+// RecordFile implements machinery.Recorder
+func (r *reporter) RecordFile(path string, action machinery.FileAction) {
+	// A file may be scaffolded several times, e.g. created and then updated by an inserter,
+	// so the most significant action is kept: created, then updated, then skipped
+	if previous, found := r.files[path]; found && fileActionRank[previous] >= fileActionRank[action] {
+		return
+	}
+	r.files[path] = action
+}
+
+var fileActionRank = map[machinery.FileAction]int{
+	machinery.FileSkipped: 0,
+	machinery.FileUpdated: 1,
+	machinery.FileCreated: 2,
+}
+
+// finish restores the standard output and the logger hooks and writes the report to the standard output.
+// The provided error is returned so that the command fails as it would without the report.
+func (r *reporter) finish(plugins []string, err error) error {
+	os.Stdout = r.stdout
+	log.StandardLogger().ReplaceHooks(r.hooks)
+
+	r.report.Plugins = plugins
+	r.report.Created, r.report.Updated, r.report.Skipped = []string{}, []string{}, []string{}
+	for path, action := range r.files {
+		switch action {
+		case machinery.FileCreated:
+			r.report.Created = append(r.report.Created, path)
+		case machinery.FileUpdated:
+			r.report.Updated = append(r.report.Updated, path)
+		case machinery.FileSkipped:
+			r.report.Skipped = append(r.report.Skipped, path)
+		}
+	}
+	sort.Strings(r.report.Created)
+	sort.Strings(r.report.Updated)
+	sort.Strings(r.report.Skipped)
+	if r.report.Warnings == nil {
+		r.report.Warnings = []string{}
+	}
+	if err != nil {
+		r.report.Error = err.Error()
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if encodeErr := encoder.Encode(r.report); encodeErr != nil && err == nil {
+		return fmt.Errorf("unable to write the report: %w", encodeErr)
+	}
+
+	return err
+}
+
+// warningsHook is a logrus hook which adds the warnings to the report
+type warningsHook struct {
+	report *commandReport
+}
+
+// Levels implements log.Hook
+func (warningsHook) Levels() []log.Level {
+	return []log.Level{log.WarnLevel}
+}
+
+// Fire implements log.Hook
+func (h warningsHook) Fire(entry *log.Entry) error {
+	h.report.Warnings = append(h.report.Warnings, entry.Message)
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ = Describe("reporter", func() {
+	var (
+		r *reporter
+
+		stdout      *os.File
+		read, write *os.File
+	)
+
+	BeforeEach(func() {
+		// Overwrite stdout to read the report and reset it afterwards
+		var err error
+		read, write, err = os.Pipe()
+		Expect(err).NotTo(HaveOccurred())
+		stdout = os.Stdout
+		os.Stdout = write
+
+		r = newReporter("kubebuilder create api")
+	})
+
+	AfterEach(func() {
+		os.Stdout = stdout
+	})
+
+	readReport := func() commandReport {
+		_ = write.Close()
+		printed, err := io.ReadAll(read)
+		Expect(err).NotTo(HaveOccurred())
+
+		var report commandReport
+		Expect(json.Unmarshal(printed, &report)).To(Succeed())
+		return report
+	}
+
+	It("should redirect the standard output until it finishes", func() {
+		Expect(os.Stdout).To(Equal(os.Stderr))
+		Expect(r.finish(nil, nil)).To(Succeed())
+		Expect(os.Stdout).To(Equal(write))
+	})
+
+	It("should report the files with their most significant action", func() {
+		r.RecordFile("created", machinery.FileCreated)
+		r.RecordFile("created", machinery.FileUpdated)
+		r.RecordFile("updated", machinery.FileSkipped)
+		r.RecordFile("updated", machinery.FileUpdated)
+		r.RecordFile("skipped", machinery.FileSkipped)
+		Expect(r.finish([]string{"go.kubebuilder.io/v4"}, nil)).To(Succeed())
+
+		report := readReport()
+		Expect(report.Command).To(Equal("kubebuilder create api"))
+		Expect(report.Plugins).To(Equal([]string{"go.kubebuilder.io/v4"}))
+		Expect(report.Created).To(Equal([]string{"created"}))
+		Expect(report.Updated).To(Equal([]string{"updated"}))
+		Expect(report.Skipped).To(Equal([]string{"skipped"}))
+		Expect(report.Warnings).To(BeEmpty())
+		Expect(report.Error).To(BeEmpty())
+	})
+
+	It("should report the warnings and the error", func() {
+		log.Warn("a warning")
+		err := errors.New("an error")
+		Expect(r.finish(nil, err)).To(MatchError(err))
+
+		report := readReport()
+		Expect(report.Warnings).To(Equal([]string{"a warning"}))
+		Expect(report.Error).To(Equal("an error"))
+	})
+})
@@ -45,6 +45,20 @@ func (c CLI) newRootCmd() *cobra.Command {
 
 	// Global flags for all subcommands.
 	cmd.PersistentFlags().StringSlice(pluginsFlag, nil, "plugin keys to be used for this subcommand execution")
+	_ = cmd.RegisterFlagCompletionFunc(pluginsFlag, c.completePluginKeys)
+	cmd.PersistentFlags().String(outputFlag, textOutput, fmt.Sprintf("output format of the init, create, edit "+
+		"and explain subcommands, one of %q or %q which writes a report of the scaffolded files, or the "+
+		"description of the plugins, to the standard output",
+		textOutput, jsonOutput))
+	_ = cmd.RegisterFlagCompletionFunc(outputFlag, cobra.FixedCompletions(
+		[]string{textOutput, jsonOutput}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.PersistentFlags().Bool(verboseFlag, false, "if true, the init, create and edit subcommands print the "+
+		"time spent, the bytes written and the files skipped by each type of builder of the plugins")
+	cmd.PersistentFlags().Bool(allowPluginVersionSkewFlag, false, "if true, scaffolds with the latest versions "+
+		"of the plugins of the CLI, with a warning, when the project uses newer ones")
+	cmd.PersistentFlags().String(recordFlag, "", "if set, the init, create and edit subcommands append their "+
+		"invocation, with their resolved flags, to the session recorded in this file, which can be replayed "+
+		"with the replay subcommand")
 
 	// Register --project-version on the root command so that it shows up in help.
 	cmd.Flags().String(projectVersionFlag, c.defaultProjectVersion.String(), "project version")
@@ -72,6 +86,10 @@ configuration please run:
 `,
 		c.commandName, c.getPluginTable())
 
+	if len(c.pluginAliases) != 0 {
+		str += fmt.Sprintf("\nThe following plugin aliases can also be used as plugin keys:\n%s\n", c.getPluginAliasList())
+	}
+
 	if len(c.defaultPlugins) != 0 {
 		if defaultPlugins, found := c.defaultPlugins[c.defaultProjectVersion]; found {
 			str += fmt.Sprintf("\nDefault plugin keys: %q\n", strings.Join(defaultPlugins, ","))
@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+// maxPluginSuggestions is the maximum number of plugins suggested for an unknown plugin key
+const maxPluginSuggestions = 3
+
+// pluginSuggestion is a plugin suggested for an unknown plugin key
+type pluginSuggestion struct {
+	// key is the shortest key which resolves the plugin, e.g. go/v4 for go.kubebuilder.io/v4
+	key string
+	// projectVersions are the project versions supported by the plugin
+	projectVersions []string
+	// distance is the edit distance between the unknown key and the key of the plugin
+	distance int
+}
+
+// suggestPlugins returns the plugins whose keys are the nearest to the unknown plugin key, i.e. the other versions
+// of the plugin and the plugins whose keys only differ by a few characters or separators, e.g. helm/v1-alpha for
+// helm.v1alpha, ordered from the nearest.
+func suggestPlugins(plugins []plugin.Plugin, pluginKey string) []pluginSuggestion {
+	name, version := plugin.SplitKey(pluginKey)
+	input := normalizePluginKey(pluginKey)
+	maxDistance := max(1, len(input)/3)
+
+	suggestions := make([]pluginSuggestion, 0, len(plugins))
+	for _, p := range plugins {
+		key := shortestPluginKey(plugins, p)
+		shortName := strings.SplitN(p.Name(), ".", 2)[0]
+		candidates := []string{key, plugin.KeyFor(p)}
+		if version == "" {
+			candidates = append(candidates, shortName, p.Name())
+		}
+
+		distance := -1
+		for _, candidate := range candidates {
+			if d := editDistance(input, normalizePluginKey(candidate)); distance < 0 || d < distance {
+				distance = d
+			}
+		}
+		// Another version of the plugin is always suggested
+		sameName := version != "" && (name == shortName || name == p.Name())
+		if distance > maxDistance && !sameName {
+			continue
+		}
+
+		projectVersions := make([]string, 0, len(p.SupportedProjectVersions()))
+		for _, projectVersion := range p.SupportedProjectVersions() {
+			projectVersions = append(projectVersions, fmt.Sprintf("%q", projectVersion))
+		}
+		suggestions = append(suggestions, pluginSuggestion{
+			key:             key,
+			projectVersions: projectVersions,
+			distance:        distance,
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].distance != suggestions[j].distance {
+			return suggestions[i].distance < suggestions[j].distance
+		}
+		return suggestions[i].key < suggestions[j].key
+	})
+	if len(suggestions) > maxPluginSuggestions {
+		suggestions = suggestions[:maxPluginSuggestions]
+	}
+	return suggestions
+}
+
+// didYouMean returns the message suggesting the plugins nearest to the unknown plugin key, if any, to be appended
+// to the error reporting it.
+func didYouMean(plugins []plugin.Plugin, pluginKey string) string {
+	suggestions := suggestPlugins(plugins, pluginKey)
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	suggested := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		suggested = append(suggested, fmt.Sprintf("%q (project versions: %s)",
+			suggestion.key, strings.Join(suggestion.projectVersions, ", ")))
+	}
+	if len(suggested) == 1 {
+		return fmt.Sprintf(", did you mean %s?", suggested[0])
+	}
+	return fmt.Sprintf(", did you mean one of %s?", strings.Join(suggested, ", "))
+}
+
+// shortestPluginKey returns the key of the plugin with the first label of its name, e.g. go/v4 for
+// go.kubebuilder.io/v4, if it only resolves this plugin, and its full key otherwise.
+func shortestPluginKey(plugins []plugin.Plugin, p plugin.Plugin) string {
+	shortKey := strings.SplitN(p.Name(), ".", 2)[0] + "/" + p.Version().String()
+	// We can omit the error because the version of the key is the version of a plugin
+	if matching, _ := plugin.FilterPluginsByKey(plugins, shortKey); len(matching) == 1 {
+		return shortKey
+	}
+	return plugin.KeyFor(p)
+}
+
+// normalizePluginKey lowercases the plugin key and removes its separators, so that the keys which only differ by
+// their separators, e.g. helm.v1alpha and helm/v1-alpha, are equal.
+func normalizePluginKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		default:
+			return -1
+		}
+	}, key)
+}
+
+// editDistance returns the Levenshtein distance between a and b, i.e. the minimum number of characters
+// to insert, delete or substitute to turn a into b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
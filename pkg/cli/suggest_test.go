@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+var _ = Describe("Plugin suggestions", func() {
+	var plugins = []plugin.Plugin{
+		newMockPlugin("go.kubebuilder.io", "v4", config.Version{Number: 3}),
+		newMockPlugin("helm.kubebuilder.io", "v1-alpha", config.Version{Number: 3}),
+		newMockPlugin("kustomize.common.kubebuilder.io", "v2", config.Version{Number: 2}, config.Version{Number: 3}),
+		newMockPlugin("foo.example.com", "v1", config.Version{Number: 3}),
+		newMockPlugin("foo.kubebuilder.io", "v1", config.Version{Number: 3}),
+	}
+
+	keysOf := func(suggestions []pluginSuggestion) []string {
+		keys := make([]string, 0, len(suggestions))
+		for _, suggestion := range suggestions {
+			keys = append(keys, suggestion.key)
+		}
+		return keys
+	}
+
+	DescribeTable("should suggest the nearest plugins",
+		func(key string, expected ...string) {
+			Expect(keysOf(suggestPlugins(plugins, key))).To(Equal(expected))
+		},
+		Entry("for the wrong separators", "helm.v1alpha", "helm/v1-alpha"),
+		Entry("for another version of the plugin", "go/v3", "go/v4"),
+		Entry("for a misspelled name", "kustomze/v2", "kustomize/v2"),
+		Entry("for a misspelled name without version", "kustomise", "kustomize/v2"),
+		Entry("for an uppercase key", "GO/V4", "go/v4"),
+		Entry("with the full keys of the ambiguous short keys", "foo/v2",
+			"foo.example.com/v1", "foo.kubebuilder.io/v1"),
+	)
+
+	It("should not suggest the unrelated plugins", func() {
+		Expect(suggestPlugins(plugins, "grafana/v1")).To(BeEmpty())
+		Expect(didYouMean(plugins, "grafana/v1")).To(BeEmpty())
+	})
+
+	It("should list the project versions supported by the suggested plugins", func() {
+		Expect(didYouMean(plugins, "kustomize.v2")).To(Equal(
+			`, did you mean "kustomize/v2" (project versions: "2", "3")?`))
+		Expect(didYouMean(plugins, "foo/v2")).To(Equal(`, did you mean one of ` +
+			`"foo.example.com/v1" (project versions: "3"), "foo.kubebuilder.io/v1" (project versions: "3")?`))
+	})
+
+	DescribeTable("should compute the edit distance",
+		func(a, b string, distance int) {
+			Expect(editDistance(a, b)).To(Equal(distance))
+		},
+		Entry("for equal strings", "gov4", "gov4", 0),
+		Entry("for an empty string", "", "helm", 4),
+		Entry("for a substitution", "gov3", "gov4", 1),
+		Entry("for insertions and deletions", "kitten", "sitting", 3),
+	)
+})
+
+var _ = Describe("resolvePlugins suggestions", func() {
+	It("should suggest the nearest plugin key when none can be resolved", func() {
+		c := CLI{
+			plugins:        makeMapFor(newMockPlugin("helm.kubebuilder.io", "v1-alpha", config.Version{Number: 3})),
+			pluginKeys:     []string{"helm.v1alpha"},
+			projectVersion: config.Version{Number: 3},
+		}
+		Expect(c.resolvePlugins()).To(MatchError(ContainSubstring(
+			`did you mean "helm/v1-alpha" (project versions: "3")?`)))
+	})
+})
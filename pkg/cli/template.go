@@ -0,0 +1,261 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+const (
+	fromTemplateFlag = "from-template"
+
+	// templateManifestFile is the file at the root of a template repository which names the template
+	templateManifestFile = "kubebuilder-template.yaml"
+	// templatePluginsDir is the directory of a template repository with its external plugins, which are laid out
+	// as in the external plugins root
+	templatePluginsDir = "plugins"
+)
+
+// retrieveTemplatesRoot and fetchTemplate are variables so that they can be replaced in the tests.
+var (
+	retrieveTemplatesRoot = getTemplatesRoot
+	fetchTemplate         = cloneTemplate
+)
+
+// templateManifest describes an organization template, whose plugins are bundled under its name and version.
+type templateManifest struct {
+	// Name of the bundle of the template, e.g. platform.example.com
+	Name string `json:"name"`
+	// Version of the bundle of the template, e.g. v1
+	Version string `json:"version"`
+}
+
+// templateProvenance is tracked in the project configuration file as the configuration of the bundle of
+// the template, so that the bundle can be built again by the later commands.
+type templateProvenance struct {
+	// Repository is the git URL of the template
+	Repository string `json:"repository"`
+	// Revision is the commit of the template the project was initialized from
+	Revision string `json:"revision"`
+}
+
+// projectTemplate is an organization template resolved by the CLI.
+type projectTemplate struct {
+	// key is the plugin key of the bundle of the template
+	key string
+	// provenance is the repository and revision of the template
+	provenance templateProvenance
+}
+
+// getTemplatesRoot returns the directory where the template repositories are cloned.
+func getTemplatesRoot() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("error retrieving the cache dir: %w", err)
+	}
+	return filepath.Join(cacheDir, "kubebuilder", "templates"), nil
+}
+
+// cloneTemplate clones the revision of the template repository, or its default branch if no revision is provided,
+// unless it was already cloned. It returns the directory of the clone and the commit it checked out.
+func cloneTemplate(repository, revision string) (dir, commit string, err error) {
+	templatesRoot, err := retrieveTemplatesRoot()
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha256.Sum256([]byte(repository))
+	repositoryRoot := filepath.Join(templatesRoot, hex.EncodeToString(sum[:8]))
+
+	if revision != "" {
+		dir = filepath.Join(repositoryRoot, revision)
+		if _, err := os.Stat(dir); err == nil {
+			return dir, revision, nil
+		}
+	}
+
+	if err := os.MkdirAll(repositoryRoot, 0755); err != nil {
+		return "", "", fmt.Errorf("unable to create the templates dir: %w", err)
+	}
+	cloneDir, err := os.MkdirTemp(repositoryRoot, "clone-")
+	if err != nil {
+		return "", "", fmt.Errorf("unable to create the clone dir: %w", err)
+	}
+	// The clone is moved to the directory of its commit unless this commit was already cloned
+	defer func() { _ = os.RemoveAll(cloneDir) }()
+
+	if revision == "" {
+		_, err = runGit("", "clone", "--quiet", "--depth", "1", "--", repository, cloneDir)
+	} else if _, err = runGit("", "clone", "--quiet", "--", repository, cloneDir); err == nil {
+		_, err = runGit(cloneDir, "checkout", "--quiet", "--detach", revision)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if commit, err = runGit(cloneDir, "rev-parse", "HEAD"); err != nil {
+		return "", "", err
+	}
+
+	dir = filepath.Join(repositoryRoot, commit)
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		if err := os.Rename(cloneDir, dir); err != nil {
+			return "", "", fmt.Errorf("unable to move the clone of the template: %w", err)
+		}
+	}
+	return dir, commit, nil
+}
+
+// validateTemplateRepository checks that the repository of a template is an https, ssh or file URL, an scp-like
+// ssh address or a local path, so that it can not be mistaken by git for an option or a remote helper.
+func validateTemplateRepository(repository string) error {
+	switch {
+	case repository == "":
+		return errors.New("the repository of the template is empty")
+	case strings.HasPrefix(repository, "-"):
+		return fmt.Errorf("invalid repository %q of the template: it can not start with '-'", repository)
+	case strings.Contains(repository, "::"):
+		return fmt.Errorf("invalid repository %q of the template: git remote helpers are not supported", repository)
+	}
+
+	if i := strings.Index(repository, "://"); i >= 0 {
+		switch scheme := strings.ToLower(repository[:i]); scheme {
+		case "https", "ssh", "file":
+		default:
+			return fmt.Errorf("invalid repository %q of the template: unsupported scheme %q, "+
+				"only https, ssh and file are supported", repository, scheme)
+		}
+	}
+	return nil
+}
+
+// runGit runs git with the provided arguments in the provided dir and returns its trimmed output.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// loadTemplate fetches the revision of the template repository, or its default branch if no revision is provided,
+// and registers the bundle of the default plugins of the project version layered with the external plugins
+// of the template.
+func (c *CLI) loadTemplate(repository, revision string, projectVersion config.Version) (*projectTemplate, error) {
+	if err := validateTemplateRepository(repository); err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(revision, "-") {
+		return nil, fmt.Errorf("invalid revision %q of the template: it can not start with '-'", revision)
+	}
+
+	dir, commit, err := fetchTemplate(repository, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestBytes, err := afero.ReadFile(c.fs.FS, filepath.Join(dir, templateManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the %s file of the template: %w", templateManifestFile, err)
+	}
+	var manifest templateManifest
+	if err := yaml.UnmarshalStrict(manifestBytes, &manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse the %s file of the template: %w", templateManifestFile, err)
+	}
+	var version plugin.Version
+	if err := version.Parse(manifest.Version); err != nil {
+		return nil, fmt.Errorf("invalid version of the template: %w", err)
+	}
+
+	externalPlugins, err := discoverExternalPluginsIn(c.fs.FS, filepath.Join(dir, templatePluginsDir))
+	if err != nil {
+		return nil, fmt.Errorf("unable to discover the plugins of the template: %w", err)
+	}
+	if len(externalPlugins) == 0 {
+		return nil, fmt.Errorf("the template has no plugin in its %s dir", templatePluginsDir)
+	}
+
+	defaultKeys := c.defaultPlugins[projectVersion]
+	if len(defaultKeys) == 0 {
+		return nil, fmt.Errorf("no default plugins for project version %q to layer the template on", projectVersion)
+	}
+	plugins := make([]plugin.Plugin, 0, len(defaultKeys)+len(externalPlugins))
+	for _, key := range defaultKeys {
+		plugins = append(plugins, c.plugins[key])
+	}
+	plugins = append(plugins, externalPlugins...)
+
+	bundle, err := plugin.NewBundleWithOptions(
+		plugin.WithName(manifest.Name),
+		plugin.WithVersion(version),
+		plugin.WithPlugins(plugins...),
+	)
+	if err != nil {
+		return nil, err
+	}
+	key := plugin.KeyFor(bundle)
+	if err := plugin.Validate(bundle); err != nil {
+		return nil, fmt.Errorf("invalid template %q: %w", key, err)
+	}
+	if _, isConflicting := c.plugins[key]; isConflicting {
+		return nil, fmt.Errorf("the template %q has the same key as a plugin of the CLI", key)
+	}
+	c.plugins[key] = bundle
+
+	return &projectTemplate{
+		key:        key,
+		provenance: templateProvenance{Repository: repository, Revision: commit},
+	}, nil
+}
+
+// loadProjectTemplates registers the bundles of the templates tracked in the project configuration, which are
+// not plugins of the CLI, from the revision of the template the project was initialized from.
+func (c *CLI) loadProjectTemplates(projectConfig config.Config) error {
+	for _, key := range c.pluginKeys {
+		if _, isRegistered := c.plugins[key]; isRegistered {
+			continue
+		}
+
+		var provenance templateProvenance
+		if err := projectConfig.DecodePluginConfig(key, &provenance); err != nil || provenance.Repository == "" {
+			continue
+		}
+		template, err := c.loadTemplate(provenance.Repository, provenance.Revision, c.projectVersion)
+		if err != nil {
+			return fmt.Errorf("unable to load the template %q of the project from %q: %w",
+				key, provenance.Repository, err)
+		}
+		if template.key != key {
+			return fmt.Errorf("the revision %s of %q is the template %q instead of %q",
+				provenance.Revision, provenance.Repository, template.key, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+var _ = Describe("Organization templates", func() {
+	const (
+		repository  = "https://github.com/acme/kubebuilder-template.git"
+		commit      = "0123456789abcdef"
+		templateDir = "/cache/template"
+		templateKey = "platform.acme.com/v1"
+	)
+
+	var (
+		c              *CLI
+		projectVersion = config.Version{Number: 3}
+		defaultPlugin  = newMockPlugin("go.test.domain", "v1", projectVersion)
+
+		fetchedRevisions []string
+		fetchFunc        func(string, string) (string, string, error)
+		args             []string
+	)
+
+	writeFile := func(path, content string, mode os.FileMode) {
+		Expect(c.fs.FS.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(afero.WriteFile(c.fs.FS, path, []byte(content), mode)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		c = &CLI{
+			fs:                    machinery.Filesystem{FS: afero.NewMemMapFs()},
+			plugins:               makeMapFor(defaultPlugin),
+			defaultPlugins:        map[config.Version][]string{projectVersion: {plugin.KeyFor(defaultPlugin)}},
+			defaultProjectVersion: projectVersion,
+		}
+		c.cmd = c.newRootCmd()
+
+		writeFile(filepath.Join(templateDir, templateManifestFile), "name: platform.acme.com\nversion: v1\n", 0o644)
+		writeFile(filepath.Join(templateDir, templatePluginsDir, "acme", "v1", "acme.sh"), "#!/bin/bash\n", 0o755)
+
+		fetchedRevisions = nil
+		fetchFunc = fetchTemplate
+		fetchTemplate = func(repo, revision string) (string, string, error) {
+			Expect(repo).To(Equal(repository))
+			fetchedRevisions = append(fetchedRevisions, revision)
+			return templateDir, commit, nil
+		}
+		args = os.Args
+	})
+
+	AfterEach(func() {
+		fetchTemplate = fetchFunc
+		os.Args = args
+	})
+
+	Context("loadTemplate", func() {
+		It("should register the bundle of the default plugins and of the plugins of the template", func() {
+			template, err := c.loadTemplate(repository, "", projectVersion)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(template.key).To(Equal(templateKey))
+			Expect(template.provenance).To(Equal(templateProvenance{Repository: repository, Revision: commit}))
+
+			bundle, isBundle := c.plugins[templateKey].(plugin.Bundle)
+			Expect(isBundle).To(BeTrue())
+			Expect(bundle.Plugins()).To(HaveLen(2))
+			Expect(plugin.KeyFor(bundle.Plugins()[0])).To(Equal(plugin.KeyFor(defaultPlugin)))
+			Expect(plugin.KeyFor(bundle.Plugins()[1])).To(Equal("acme/v1"))
+		})
+
+		It("should fail if the template has no manifest", func() {
+			Expect(c.fs.FS.Remove(filepath.Join(templateDir, templateManifestFile))).To(Succeed())
+			_, err := c.loadTemplate(repository, "", projectVersion)
+			Expect(err).To(MatchError(ContainSubstring(templateManifestFile)))
+		})
+
+		It("should fail if the template has no plugin", func() {
+			Expect(c.fs.FS.RemoveAll(filepath.Join(templateDir, templatePluginsDir))).To(Succeed())
+			_, err := c.loadTemplate(repository, "", projectVersion)
+			Expect(err).To(MatchError(ContainSubstring("has no plugin")))
+		})
+
+		It("should fail if the template has the key of a plugin of the CLI", func() {
+			c.plugins[templateKey] = newMockPlugin("platform.acme.com", "v1", projectVersion)
+			_, err := c.loadTemplate(repository, "", projectVersion)
+			Expect(err).To(MatchError(ContainSubstring("same key")))
+		})
+
+		It("should fail if the template fails to be fetched", func() {
+			fetchTemplate = func(string, string) (string, string, error) {
+				return "", "", errors.New("repository not found")
+			}
+			_, err := c.loadTemplate(repository, "", projectVersion)
+			Expect(err).To(MatchError("repository not found"))
+		})
+
+		It("should not fetch a template whose repository or revision looks like an option", func() {
+			_, err := c.loadTemplate("--upload-pack=touch /tmp/pwned", "", projectVersion)
+			Expect(err).To(MatchError(ContainSubstring("can not start with '-'")))
+
+			_, err = c.loadTemplate(repository, "--output=/tmp/pwned", projectVersion)
+			Expect(err).To(MatchError(ContainSubstring("can not start with '-'")))
+
+			Expect(fetchedRevisions).To(BeEmpty())
+		})
+	})
+
+	Context("validateTemplateRepository", func() {
+		DescribeTable("should accept the supported repositories",
+			func(repository string) {
+				Expect(validateTemplateRepository(repository)).To(Succeed())
+			},
+			Entry("https URL", "https://github.com/acme/kubebuilder-template.git"),
+			Entry("ssh URL", "ssh://git@github.com/acme/kubebuilder-template.git"),
+			Entry("scp-like ssh address", "git@github.com:acme/kubebuilder-template.git"),
+			Entry("file URL", "file:///srv/git/kubebuilder-template.git"),
+			Entry("absolute path", "/srv/git/kubebuilder-template"),
+			Entry("relative path", "../kubebuilder-template"),
+		)
+
+		DescribeTable("should reject the unsupported repositories",
+			func(repository string) {
+				Expect(validateTemplateRepository(repository)).NotTo(Succeed())
+			},
+			Entry("empty", ""),
+			Entry("option", "--upload-pack=touch /tmp/pwned"),
+			Entry("remote helper", "ext::sh -c touch% /tmp/pwned"),
+			Entry("http URL", "http://github.com/acme/kubebuilder-template.git"),
+			Entry("git URL", "git://github.com/acme/kubebuilder-template.git"),
+		)
+	})
+
+	Context("getInfoFromFlags", func() {
+		It("should use the bundle of the template as plugin", func() {
+			setFlag(fromTemplateFlag, repository)
+			Expect(c.getInfoFromFlags(false)).To(Succeed())
+			Expect(c.pluginKeys).To(Equal([]string{templateKey}))
+			Expect(c.template).NotTo(BeNil())
+			Expect(c.template.provenance.Revision).To(Equal(commit))
+			Expect(fetchedRevisions).To(Equal([]string{""}))
+		})
+
+		It("should fail if the plugins are also provided", func() {
+			setPluginsFlag(plugin.KeyFor(defaultPlugin))
+			setFlag(fromTemplateFlag, repository)
+			Expect(c.getInfoFromFlags(false)).NotTo(Succeed())
+		})
+	})
+
+	Context("getInfoFromConfig", func() {
+		It("should register the bundle of the template from its tracked revision", func() {
+			projectConfig := cfgv3.New()
+			Expect(projectConfig.SetPluginChain([]string{templateKey})).To(Succeed())
+			Expect(projectConfig.EncodePluginConfig(templateKey,
+				templateProvenance{Repository: repository, Revision: commit})).To(Succeed())
+
+			Expect(c.getInfoFromConfig(projectConfig)).To(Succeed())
+			Expect(c.plugins).To(HaveKey(templateKey))
+			Expect(fetchedRevisions).To(Equal([]string{commit}))
+		})
+
+		It("should fail if the tracked revision is another template", func() {
+			projectConfig := cfgv3.New()
+			Expect(projectConfig.SetPluginChain([]string{"other.acme.com/v1"})).To(Succeed())
+			Expect(projectConfig.EncodePluginConfig("other.acme.com/v1",
+				templateProvenance{Repository: repository, Revision: commit})).To(Succeed())
+
+			Expect(c.getInfoFromConfig(projectConfig)).NotTo(Succeed())
+		})
+	})
+})
@@ -76,6 +76,9 @@ type Config interface {
 	AddResource(res resource.Resource) error
 	// UpdateResource adds the provided resource if it was not present, modifies it if it was already present.
 	UpdateResource(res resource.Resource) error
+	// SetStorageVersion marks the API of the provided GVK as the storage version of its kind,
+	// unmarking the other versions of the same kind.
+	SetStorageVersion(gvk resource.GVK) error
 
 	// HasGroup checks if the provided group is the same as any of the tracked resources.
 	HasGroup(group string) bool
@@ -84,6 +87,23 @@ type Config interface {
 	// ListWebhookVersions returns a list of the webhook versions in use by the tracked resources.
 	ListWebhookVersions() []string
 
+	// GetResourcesByGroup returns the stored resources of the provided group.
+	GetResourcesByGroup(group string) ([]resource.Resource, error)
+	// GetResourcesByGroupVersion returns the stored resources of the provided group and version.
+	GetResourcesByGroupVersion(group, version string) ([]resource.Resource, error)
+	// GetResourcesWithWebhooks returns the stored resources with a defaulting, validation or conversion webhook.
+	GetResourcesWithWebhooks() ([]resource.Resource, error)
+	// GetExternalResources returns the stored resources whose API is defined by an external module.
+	GetExternalResources() ([]resource.Resource, error)
+	// GetCoreResources returns the stored resources whose API is defined by Kubernetes.
+	GetCoreResources() ([]resource.Resource, error)
+	// HasDefaultingWebhook checks if any of the tracked resources has a defaulting webhook.
+	HasDefaultingWebhook() bool
+	// HasValidationWebhook checks if any of the tracked resources has a validation webhook.
+	HasValidationWebhook() bool
+	// HasConversionWebhook checks if any of the tracked resources has a conversion webhook.
+	HasConversionWebhook() bool
+
 	/* Plugins */
 
 	// DecodePluginConfig decodes a plugin config stored in Config into configObj, which must be a pointer.
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schema provides the JSON schema of the PROJECT file and validates the PROJECT files against it.
+package schema
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+//go:embed project_v3.json
+var projectV3 []byte
+
+var (
+	compileOnce  sync.Once
+	compiledV3   *node
+	compileV3Err error
+)
+
+// ProjectV3 returns the JSON schema of the version 3 of the PROJECT file.
+func ProjectV3() []byte {
+	return append([]byte(nil), projectV3...)
+}
+
+// Violation is a value of the PROJECT file which does not comply with the schema.
+type Violation struct {
+	// Path is the path of the value in the PROJECT file, e.g. resources[0].kind, empty for the root
+	Path string
+	// Message describes the violation
+	Message string
+}
+
+// String implements fmt.Stringer
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// Validate validates the content of a PROJECT file, in YAML, against the schema of the version 3 of the
+// PROJECT file. It returns the violations of the schema, and an error if the content can not be parsed.
+func Validate(content []byte) ([]Violation, error) {
+	compileOnce.Do(func() {
+		compiledV3, compileV3Err = compile(projectV3)
+	})
+	if compileV3Err != nil {
+		return nil, fmt.Errorf("invalid schema of the PROJECT file: %w", compileV3Err)
+	}
+
+	var document interface{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("unable to parse the PROJECT file: %w", err)
+	}
+
+	v := &validator{root: compiledV3}
+	v.validate(compiledV3, document, "")
+	return v.violations, nil
+}
+
+// compile parses a JSON schema
+func compile(schema []byte) (*node, error) {
+	root := &node{}
+	if err := json.Unmarshal(schema, root); err != nil {
+		return nil, err
+	}
+	if err := root.compile(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
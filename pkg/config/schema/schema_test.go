@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+func TestSchema(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Config Schema Suite")
+}
+
+var _ = Describe("ProjectV3", func() {
+	It("should return a valid JSON schema", func() {
+		var schema map[string]interface{}
+		Expect(json.Unmarshal(ProjectV3(), &schema)).To(Succeed())
+		Expect(schema).To(HaveKeyWithValue("title", "Kubebuilder PROJECT file"))
+	})
+
+	It("should return a copy of the schema", func() {
+		schema := ProjectV3()
+		schema[0] = 'x'
+		Expect(ProjectV3()[0]).To(Equal(byte('{')))
+	})
+})
+
+var _ = Describe("Validate", func() {
+	It("should accept the PROJECT files written by the config", func() {
+		cfg := cfgv3.New()
+		Expect(cfg.SetDomain("my.domain")).To(Succeed())
+		Expect(cfg.SetRepository("github.com/example/project")).To(Succeed())
+		Expect(cfg.SetProjectName("project")).To(Succeed())
+		Expect(cfg.SetPluginChain([]string{"go.kubebuilder.io/v4"})).To(Succeed())
+		Expect(cfg.SetMultiGroup()).To(Succeed())
+		Expect(cfg.AddResource(resource.Resource{
+			GVK:        resource.GVK{Group: "crew", Domain: "my.domain", Version: "v1alpha1", Kind: "Captain"},
+			Plural:     "captains",
+			Path:       "github.com/example/project/api/crew/v1alpha1",
+			API:        &resource.API{CRDVersion: "v1", Namespaced: true, StorageVersion: true},
+			Controller: true,
+			Webhooks: &resource.Webhooks{
+				WebhookVersion: "v1",
+				Defaulting:     true,
+				Conversion:     true,
+				Spoke:          []string{"v1beta1"},
+			},
+		})).To(Succeed())
+		Expect(cfg.AddResource(resource.Resource{
+			GVK:        resource.GVK{Group: "apps", Version: "v1", Kind: "Deployment"},
+			Plural:     "deployments",
+			Path:       "k8s.io/api/apps/v1",
+			Controller: true,
+			Core:       true,
+		})).To(Succeed())
+		Expect(cfg.EncodePluginConfig("helm.kubebuilder.io/v1-alpha",
+			map[string]interface{}{"chartDir": "dist"})).To(Succeed())
+
+		content, err := cfg.MarshalYAML()
+		Expect(err).NotTo(HaveOccurred())
+		violations, err := Validate(content)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(violations).To(BeEmpty())
+	})
+
+	It("should accept the layout as a single plugin key", func() {
+		violations, err := Validate([]byte("version: \"3\"\nlayout: go.kubebuilder.io/v4\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(violations).To(BeEmpty())
+	})
+
+	DescribeTable("should report the violations of the schema",
+		func(content string, expected ...Violation) {
+			violations, err := Validate([]byte(content))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(violations).To(ConsistOf(expected))
+		},
+		Entry("without version", "domain: my.domain\n",
+			Violation{Message: `the field "version" is required`}),
+		Entry("with another version", "version: \"2\"\n",
+			Violation{Path: "version", Message: `must be one of "3", found "2"`}),
+		Entry("with an unknown field", "version: \"3\"\nrepository: github.com/example/project\n",
+			Violation{Path: "repository", Message: "unknown field"}),
+		Entry("with an invalid plugin key in the layout", "version: \"3\"\nlayout:\n- go.kubebuilder.io\n",
+			Violation{Path: "layout[0]", Message: `"go.kubebuilder.io" does not match the pattern ` +
+				`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/v[0-9]+(-(alpha|beta))?$`}),
+		Entry("with an invalid plugin key in the plugins", "version: \"3\"\nplugins:\n  Helm/v1: {}\n",
+			Violation{Path: "plugins.Helm/v1", Message: `"Helm/v1" does not match the pattern ` +
+				`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/v[0-9]+(-(alpha|beta))?$`}),
+		Entry("with a wrong type", "version: \"3\"\nmultigroup: \"yes\"\n",
+			Violation{Path: "multigroup", Message: "must be of type boolean, found string"}),
+		Entry("with invalid resources", `version: "3"
+resources:
+- group: crew
+  version: 1
+  kind: captain
+- group: crew
+  version: v1
+  kind: Captain
+  api:
+    crdVersion: v1beta1
+    namespaced: true
+  webhook:
+    defaulting: true
+`,
+			Violation{Path: "resources[0].version", Message: "must be of type string, found integer"},
+			Violation{Path: "resources[0].kind", Message: `"captain" does not match the pattern ^[A-Z][A-Za-z0-9]*$`},
+			Violation{Path: "resources[1].api.crdVersion", Message: `must be one of "v1", found "v1beta1"`},
+			Violation{Path: "resources[1].webhook", Message: "unknown field"},
+		),
+	)
+
+	It("should fail to parse an invalid YAML", func() {
+		_, err := Validate([]byte("version: [\n"))
+		Expect(err).To(HaveOccurred())
+	})
+})
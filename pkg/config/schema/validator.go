@@ -0,0 +1,272 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// node is a JSON schema. Only the keywords used by the schemas of the PROJECT file are supported.
+type node struct {
+	Ref                  string           `json:"$ref,omitempty"`
+	Defs                 map[string]*node `json:"$defs,omitempty"`
+	Type                 typeList         `json:"type,omitempty"`
+	Enum                 []interface{}    `json:"enum,omitempty"`
+	Pattern              string           `json:"pattern,omitempty"`
+	MinLength            *int             `json:"minLength,omitempty"`
+	Properties           map[string]*node `json:"properties,omitempty"`
+	Required             []string         `json:"required,omitempty"`
+	AdditionalProperties *additional      `json:"additionalProperties,omitempty"`
+	PropertyNames        *node            `json:"propertyNames,omitempty"`
+	Items                *node            `json:"items,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// compile compiles the patterns of the schema and of its subschemas
+func (n *node) compile() error {
+	if n == nil {
+		return nil
+	}
+	if n.Pattern != "" {
+		var err error
+		if n.pattern, err = regexp.Compile(n.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", n.Pattern, err)
+		}
+	}
+	subschemas := []*node{n.PropertyNames, n.Items}
+	if n.AdditionalProperties != nil {
+		subschemas = append(subschemas, n.AdditionalProperties.Schema)
+	}
+	for _, def := range n.Defs {
+		subschemas = append(subschemas, def)
+	}
+	for _, property := range n.Properties {
+		subschemas = append(subschemas, property)
+	}
+	for _, subschema := range subschemas {
+		if err := subschema.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// typeList is the type keyword, either a type or a list of types
+type typeList []string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *typeList) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*t = typeList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// additional is the additionalProperties keyword, either a boolean or a schema
+type additional struct {
+	Allowed bool
+	Schema  *node
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (a *additional) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &a.Allowed); err == nil {
+		return nil
+	}
+	a.Allowed = true
+	return json.Unmarshal(b, &a.Schema)
+}
+
+// validator collects the violations of a document
+type validator struct {
+	// root is the schema which defines the references
+	root *node
+
+	violations []Violation
+}
+
+func (v *validator) addf(path, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// validate validates the value against the schema, the path is the path of the value in the document
+func (v *validator) validate(schema *node, value interface{}, path string) {
+	if schema.Ref != "" {
+		ref, err := v.resolve(schema.Ref)
+		if err != nil {
+			v.addf(path, "%v", err)
+			return
+		}
+		v.validate(ref, value, path)
+	}
+
+	if len(schema.Type) != 0 && !matchesType(schema.Type, value) {
+		v.addf(path, "must be of type %s, found %s", strings.Join(schema.Type, " or "), typeOf(value))
+		return
+	}
+
+	if len(schema.Enum) != 0 && !inEnum(schema.Enum, value) {
+		v.addf(path, "must be one of %s, found %s", formatValues(schema.Enum), formatValue(value))
+	}
+
+	switch typed := value.(type) {
+	case string:
+		if schema.MinLength != nil && len(typed) < *schema.MinLength {
+			v.addf(path, "must have at least %d characters", *schema.MinLength)
+		}
+		if schema.pattern != nil && !schema.pattern.MatchString(typed) {
+			v.addf(path, "%q does not match the pattern %s", typed, schema.Pattern)
+		}
+	case []interface{}:
+		if schema.Items != nil {
+			for i, item := range typed {
+				v.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case map[string]interface{}:
+		v.validateObject(schema, typed, path)
+	}
+}
+
+// validateObject validates the properties of an object against the schema
+func (v *validator) validateObject(schema *node, object map[string]interface{}, path string) {
+	for _, name := range schema.Required {
+		if _, found := object[name]; !found {
+			v.addf(path, "the field %q is required", name)
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := joinPath(path, name)
+		if schema.PropertyNames != nil {
+			v.validate(schema.PropertyNames, name, propertyPath)
+		}
+		if property, found := schema.Properties[name]; found {
+			v.validate(property, object[name], propertyPath)
+			continue
+		}
+		if schema.AdditionalProperties == nil {
+			continue
+		}
+		if !schema.AdditionalProperties.Allowed {
+			v.addf(propertyPath, "unknown field")
+			continue
+		}
+		if schema.AdditionalProperties.Schema != nil {
+			v.validate(schema.AdditionalProperties.Schema, object[name], propertyPath)
+		}
+	}
+}
+
+// resolve returns the schema of a reference to the definitions of the root schema, i.e. #/$defs/<name>
+func (v *validator) resolve(ref string) (*node, error) {
+	name, found := strings.CutPrefix(ref, "#/$defs/")
+	if !found {
+		return nil, fmt.Errorf("unsupported reference %q", ref)
+	}
+	def, found := v.root.Defs[name]
+	if !found {
+		return nil, fmt.Errorf("unknown reference %q", ref)
+	}
+	return def, nil
+}
+
+// joinPath returns the path of a field of an object
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// typeOf returns the JSON type of a value
+func typeOf(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if typed == float64(int64(typed)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// matchesType returns true if the value is of one of the types
+func matchesType(types []string, value interface{}) bool {
+	valueType := typeOf(value)
+	for _, t := range types {
+		if t == valueType || (t == "number" && valueType == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatValue(value interface{}) string {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(content)
+}
+
+func formatValues(values []interface{}) string {
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		formatted = append(formatted, formatValue(value))
+	}
+	return strings.Join(formatted, ", ")
+}
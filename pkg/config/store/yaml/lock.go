@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// lockSuffix is appended to the path of the configuration file to get the path of its lock file
+const lockSuffix = ".lock"
+
+var (
+	// lockTimeout is the time waited for the lock held by another process before failing
+	lockTimeout = 10 * time.Second
+	// lockRetryInterval is the time waited between the attempts to take the lock
+	lockRetryInterval = 50 * time.Millisecond
+)
+
+// lockFile takes the advisory lock of the configuration file at path, so that the processes writing it, e.g. the
+// kubebuilder commands run concurrently by wrapper tools in a monorepo, do it one at a time. The lock is a file
+// next to the configuration file, created exclusively so that it works on any filesystem, which contains the
+// PID of its owner. It returns the function releasing the lock.
+func lockFile(fs afero.Fs, path string) (func(), error) {
+	lockPath := path + lockSuffix
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := fs.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_, _ = fmt.Fprintf(f, "%d\n", os.Getpid())
+			_ = f.Close()
+			return func() { _ = fs.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("unable to lock %q: %w", path, err)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("unable to lock %q: %q is held by another process, "+
+				"remove it if no kubebuilder command is running", path, lockPath)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
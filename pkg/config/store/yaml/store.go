@@ -17,8 +17,11 @@ limitations under the License.
 package yaml
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sync"
 
 	"github.com/spf13/afero"
 	"sigs.k8s.io/yaml"
@@ -42,12 +45,23 @@ const (
 
 // yamlStore implements store.Store using a YAML file as the storage backend
 // The key is translated into the YAML file path
+//
+// The store can be used by concurrent goroutines, and the file can be read and written by concurrent processes:
+// it is written atomically while holding its advisory lock, so that it is never read partially written, and
+// saving it fails if another process changed it since it was loaded instead of overwriting its changes.
 type yamlStore struct {
+	// mu guards the fields of the store
+	mu sync.RWMutex
+
 	// fs is the filesystem that will be used to store the config.Config
 	fs afero.Fs
 	// mustNotExist requires the file not to exist when saving it
 	mustNotExist bool
 
+	// loadedPath and loaded are the path and the content of the file the config was loaded from or saved to
+	loadedPath string
+	loaded     []byte
+
 	cfg config.Config
 }
 
@@ -63,8 +77,12 @@ func (s *yamlStore) New(version config.Version) error {
 		return err
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.cfg = cfg
 	s.mustNotExist = true
+	s.loadedPath, s.loaded = "", nil
 	return nil
 }
 
@@ -79,6 +97,9 @@ type versionedConfig struct {
 
 // LoadFrom implements store.Store interface
 func (s *yamlStore) LoadFrom(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.mustNotExist = false
 
 	// Read the file
@@ -106,21 +127,31 @@ func (s *yamlStore) LoadFrom(path string) error {
 	}
 
 	s.cfg = cfg
+	s.loadedPath, s.loaded = path, in
 	return nil
 }
 
 // Save implements store.Store interface
-func (s yamlStore) Save() error {
+func (s *yamlStore) Save() error {
 	return s.SaveTo(DefaultPath)
 }
 
 // SaveTo implements store.Store interface
-func (s yamlStore) SaveTo(path string) error {
+func (s *yamlStore) SaveTo(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	// If yamlStore is unset, none of New, Load, or LoadFrom were called successfully
 	if s.cfg == nil {
 		return store.SaveError{Err: fmt.Errorf("undefined config, use one of the initializers: New, Load, LoadFrom")}
 	}
 
+	unlock, err := lockFile(s.fs, path)
+	if err != nil {
+		return store.SaveError{Err: err}
+	}
+	defer unlock()
+
 	// If it is a new configuration, the path should not exist yet
 	if s.mustNotExist {
 		// Check that the file doesn't exist
@@ -132,6 +163,16 @@ func (s yamlStore) SaveTo(path string) error {
 			// Error occurred while checking file existence
 			return store.SaveError{Err: fmt.Errorf("unable to check for file prior existence: %w", err)}
 		}
+	} else if s.loadedPath == path {
+		// The changes of the file made by another process since it was loaded must not be overwritten
+		current, err := afero.ReadFile(s.fs, path)
+		if err != nil && !os.IsNotExist(err) {
+			return store.SaveError{Err: fmt.Errorf("unable to read %q file: %w", path, err)}
+		}
+		if err == nil && !bytes.Equal(current, s.loaded) {
+			return store.SaveError{Err: fmt.Errorf("%q was modified by another process since it was loaded, "+
+				"run the command again", path)}
+		}
 	}
 
 	// Marshall into YAML
@@ -143,16 +184,41 @@ func (s yamlStore) SaveTo(path string) error {
 	// Prepend warning comment for the 'PROJECT' file
 	content = append([]byte(commentStr), content...)
 
-	// Write the marshalled configuration
-	err = afero.WriteFile(s.fs, path, content, 0600)
-	if err != nil {
+	// Write the marshalled configuration to a temporary file renamed to the path, so that it is replaced atomically
+	if err := writeFileAtomically(s.fs, path, content); err != nil {
 		return store.SaveError{Err: fmt.Errorf("failed to save configuration to %q: %w", path, err)}
 	}
 
+	s.loadedPath, s.loaded = path, content
+	return nil
+}
+
+// writeFileAtomically writes the content to a temporary file in the directory of path, then renames it to path
+func writeFileAtomically(fs afero.Fs, path string, content []byte) error {
+	f, err := afero.TempFile(fs, filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		_ = f.Close()
+		_ = fs.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = fs.Remove(f.Name())
+		return err
+	}
+	if err := fs.Rename(f.Name(), path); err != nil {
+		_ = fs.Remove(f.Name())
+		return err
+	}
 	return nil
 }
 
 // Config implements store.Store interface
-func (s yamlStore) Config() config.Config {
+func (s *yamlStore) Config() config.Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	return s.cfg
 }
@@ -18,8 +18,11 @@ package yaml
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
 
@@ -234,4 +237,82 @@ layout: ""
 			Expect(errors.As(err, &store.SaveError{})).To(BeTrue())
 		})
 	})
+
+	Context("concurrency", func() {
+		BeforeEach(func() {
+			Expect(afero.WriteFile(s.fs, DefaultPath, []byte(commentStr+v3File), 0o600)).To(Succeed())
+			Expect(s.Load()).To(Succeed())
+		})
+
+		It("should save the file several times", func() {
+			Expect(s.Save()).To(Succeed())
+			Expect(s.cfg.SetDomain("example.com")).To(Succeed())
+			Expect(s.Save()).To(Succeed())
+
+			files, err := afero.ReadDir(s.fs, ".")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(HaveLen(1), "the lock and the temporary files should be removed")
+		})
+
+		It("should fail if the file was modified since it was loaded", func() {
+			Expect(afero.WriteFile(s.fs, DefaultPath, []byte(commentStr+v3File+"domain: example.com\n"),
+				0o600)).To(Succeed())
+
+			err := s.Save()
+			Expect(err).To(MatchError(ContainSubstring("was modified by another process")))
+			Expect(errors.As(err, &store.SaveError{})).To(BeTrue())
+		})
+
+		It("should wait for the lock held by another process", func() {
+			Expect(afero.WriteFile(s.fs, DefaultPath+lockSuffix, []byte("1\n"), 0o600)).To(Succeed())
+			go func() {
+				defer GinkgoRecover()
+				time.Sleep(2 * lockRetryInterval)
+				Expect(s.fs.Remove(DefaultPath + lockSuffix)).To(Succeed())
+			}()
+
+			Expect(s.Save()).To(Succeed())
+		})
+
+		It("should fail if the lock is not released", func() {
+			timeout := lockTimeout
+			lockTimeout = 2 * lockRetryInterval
+			DeferCleanup(func() { lockTimeout = timeout })
+			Expect(afero.WriteFile(s.fs, DefaultPath+lockSuffix, []byte("1\n"), 0o600)).To(Succeed())
+
+			err := s.Save()
+			Expect(err).To(MatchError(ContainSubstring("is held by another process")))
+			Expect(errors.As(err, &store.SaveError{})).To(BeTrue())
+		})
+
+		It("should only save the changes of one of the stores loaded concurrently", func() {
+			const count = 5
+			stores := make([]*yamlStore, count)
+			for i := range stores {
+				stores[i] = New(machinery.Filesystem{FS: s.fs}).(*yamlStore)
+				Expect(stores[i].Load()).To(Succeed())
+				Expect(stores[i].cfg.SetDomain(fmt.Sprintf("example%d.com", i))).To(Succeed())
+			}
+
+			var wg sync.WaitGroup
+			errs := make([]error, count)
+			for i := range stores {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					errs[i] = stores[i].Save()
+				}(i)
+			}
+			wg.Wait()
+
+			var saved int
+			for _, err := range errs {
+				if err == nil {
+					saved++
+				}
+			}
+			Expect(saved).To(Equal(1))
+			Expect(s.Load()).To(Succeed())
+		})
+	})
 })
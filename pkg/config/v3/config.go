@@ -192,8 +192,17 @@ func (c Cfg) GetResource(gvk resource.GVK) (resource.Resource, error) {
 
 // GetResources implements config.Config
 func (c Cfg) GetResources() ([]resource.Resource, error) {
+	return c.filterResources(func(resource.Resource) bool { return true }), nil
+}
+
+// filterResources returns a copy of the tracked resources which match the provided filter
+func (c Cfg) filterResources(matches func(resource.Resource) bool) []resource.Resource {
 	resources := make([]resource.Resource, 0, len(c.Resources))
 	for _, res := range c.Resources {
+		if !matches(res) {
+			continue
+		}
+
 		r := res.Copy()
 
 		// Plural is only stored if irregular, so if it is empty recover the regular form
@@ -204,7 +213,7 @@ func (c Cfg) GetResources() ([]resource.Resource, error) {
 		resources = append(resources, r)
 	}
 
-	return resources, nil
+	return resources
 }
 
 // AddResource implements config.Config
@@ -243,6 +252,28 @@ func (c *Cfg) UpdateResource(res resource.Resource) error {
 	return nil
 }
 
+// SetStorageVersion implements config.Config
+func (c *Cfg) SetStorageVersion(gvk resource.GVK) error {
+	found := false
+	for _, r := range c.Resources {
+		if gvk.IsEqualTo(r.GVK) && r.HasAPI() {
+			found = true
+		}
+	}
+	if !found {
+		return config.ResourceNotFoundError{GVK: gvk}
+	}
+
+	for i, r := range c.Resources {
+		if r.API == nil || r.Group != gvk.Group || r.Domain != gvk.Domain || r.Kind != gvk.Kind {
+			continue
+		}
+		c.Resources[i].API.StorageVersion = r.Version == gvk.Version
+	}
+
+	return nil
+}
+
 // HasGroup implements config.Config
 func (c Cfg) HasGroup(group string) bool {
 	// Return true if the target group is found in the tracked resources
@@ -292,6 +323,67 @@ func (c Cfg) ListWebhookVersions() []string {
 	return versions
 }
 
+// GetResourcesByGroup implements config.Config
+func (c Cfg) GetResourcesByGroup(group string) ([]resource.Resource, error) {
+	return c.filterResources(func(r resource.Resource) bool {
+		return strings.EqualFold(group, r.Group)
+	}), nil
+}
+
+// GetResourcesByGroupVersion implements config.Config
+func (c Cfg) GetResourcesByGroupVersion(group, version string) ([]resource.Resource, error) {
+	return c.filterResources(func(r resource.Resource) bool {
+		return strings.EqualFold(group, r.Group) && version == r.Version
+	}), nil
+}
+
+// GetResourcesWithWebhooks implements config.Config
+func (c Cfg) GetResourcesWithWebhooks() ([]resource.Resource, error) {
+	return c.filterResources(func(r resource.Resource) bool {
+		return r.HasDefaultingWebhook() || r.HasValidationWebhook() || r.HasConversionWebhook()
+	}), nil
+}
+
+// GetExternalResources implements config.Config
+func (c Cfg) GetExternalResources() ([]resource.Resource, error) {
+	return c.filterResources(resource.Resource.IsExternal), nil
+}
+
+// GetCoreResources implements config.Config
+func (c Cfg) GetCoreResources() ([]resource.Resource, error) {
+	return c.filterResources(func(r resource.Resource) bool { return r.Core }), nil
+}
+
+// HasDefaultingWebhook implements config.Config
+func (c Cfg) HasDefaultingWebhook() bool {
+	for _, r := range c.Resources {
+		if r.HasDefaultingWebhook() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasValidationWebhook implements config.Config
+func (c Cfg) HasValidationWebhook() bool {
+	for _, r := range c.Resources {
+		if r.HasValidationWebhook() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasConversionWebhook implements config.Config
+func (c Cfg) HasConversionWebhook() bool {
+	for _, r := range c.Resources {
+		if r.HasConversionWebhook() {
+			return true
+		}
+	}
+	return false
+}
+
 // DecodePluginConfig implements config.Config
 func (c Cfg) DecodePluginConfig(key string, configObj interface{}) error {
 	if len(c.Plugins) == 0 {
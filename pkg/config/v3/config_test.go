@@ -270,6 +270,25 @@ var _ = Describe("Cfg", func() {
 			checkResource(c.Resources[0], resWithoutPlural)
 		})
 
+		It("SetStorageVersion should fail for a non-existent resource", func() {
+			Expect(c.SetStorageVersion(res.GVK)).NotTo(Succeed())
+		})
+
+		It("SetStorageVersion should mark only the provided version of the kind as storage version", func() {
+			v2 := res.Copy()
+			v2.Version = "v2"
+			v2.API.StorageVersion = true
+			otherKind := res.Copy()
+			otherKind.Kind = "OtherKind"
+			otherKind.API.StorageVersion = true
+			c.Resources = append(c.Resources, res.Copy(), v2, otherKind)
+
+			Expect(c.SetStorageVersion(res.GVK)).To(Succeed())
+			Expect(c.Resources[0].API.StorageVersion).To(BeTrue())
+			Expect(c.Resources[1].API.StorageVersion).To(BeFalse())
+			Expect(c.Resources[2].API.StorageVersion).To(BeTrue())
+		})
+
 		It("HasGroup should return false with no tracked resources", func() {
 			Expect(c.HasGroup(res.Group)).To(BeFalse())
 		})
@@ -339,6 +358,107 @@ var _ = Describe("Cfg", func() {
 			sort.Strings(versions) // ListWebhookVersions has no order guarantee so sorting for reproducibility
 			Expect(versions).To(Equal([]string{"v1", "v1beta1"}))
 		})
+
+		Context("with resources of several groups, versions and origins", func() {
+			var (
+				v2         resource.Resource
+				otherGroup resource.Resource
+				external   resource.Resource
+				core       resource.Resource
+			)
+
+			BeforeEach(func() {
+				v2 = res.Copy()
+				v2.Version = "v2"
+				v2.Webhooks = nil
+
+				otherGroup = res.Copy()
+				otherGroup.Group = "other-group"
+				otherGroup.Webhooks = &resource.Webhooks{WebhookVersion: "v1", Conversion: true}
+
+				external = resource.Resource{
+					GVK:      resource.GVK{Group: "cert-manager", Domain: "io", Version: "v1", Kind: "Certificate"},
+					Plural:   "certificates",
+					Path:     "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1",
+					External: true,
+				}
+
+				core = resource.Resource{
+					GVK:    resource.GVK{Group: "apps", Version: "v1", Kind: "Deployment"},
+					Plural: "deployments",
+					Path:   "k8s.io/api/apps/v1",
+					Core:   true,
+				}
+
+				c.Resources = append(c.Resources, res.Copy(), v2, otherGroup, external, core)
+			})
+
+			It("GetResourcesByGroup should return the resources of the group", func() {
+				resources, err := c.GetResourcesByGroup(res.Group)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources).To(Equal([]resource.Resource{res, v2}))
+			})
+
+			It("GetResourcesByGroup should return an empty slice for an untracked group", func() {
+				resources, err := c.GetResourcesByGroup("untracked")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources).To(BeEmpty())
+			})
+
+			It("GetResourcesByGroupVersion should return the resources of the group and version", func() {
+				resources, err := c.GetResourcesByGroupVersion(res.Group, "v2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources).To(Equal([]resource.Resource{v2}))
+			})
+
+			It("GetResourcesWithWebhooks should return the resources with webhooks", func() {
+				resources, err := c.GetResourcesWithWebhooks()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources).To(Equal([]resource.Resource{res, otherGroup}))
+			})
+
+			It("GetExternalResources should return the external resources", func() {
+				resources, err := c.GetExternalResources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources).To(Equal([]resource.Resource{external}))
+			})
+
+			It("GetCoreResources should return the core resources", func() {
+				resources, err := c.GetCoreResources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources).To(Equal([]resource.Resource{core}))
+			})
+
+			It("should return copies of the tracked resources", func() {
+				resources, err := c.GetResourcesByGroup(res.Group)
+				Expect(err).NotTo(HaveOccurred())
+				resources[0].API.Namespaced = false
+				Expect(c.Resources[0].API.Namespaced).To(BeTrue())
+			})
+		})
+
+		It("HasDefaultingWebhook, HasValidationWebhook and HasConversionWebhook should return false "+
+			"with no tracked resources", func() {
+			Expect(c.HasDefaultingWebhook()).To(BeFalse())
+			Expect(c.HasValidationWebhook()).To(BeFalse())
+			Expect(c.HasConversionWebhook()).To(BeFalse())
+		})
+
+		It("HasDefaultingWebhook, HasValidationWebhook and HasConversionWebhook should check "+
+			"the webhooks of the tracked resources", func() {
+			c.Resources = append(c.Resources,
+				resource.Resource{
+					GVK:      resource.GVK{Group: res.Group, Version: res.Version, Kind: res.Kind},
+					Webhooks: &resource.Webhooks{WebhookVersion: "v1", Conversion: true},
+				},
+				resource.Resource{
+					GVK: resource.GVK{Group: res.Group, Version: res.Version, Kind: "OtherKind"},
+				},
+			)
+			Expect(c.HasDefaultingWebhook()).To(BeFalse())
+			Expect(c.HasValidationWebhook()).To(BeFalse())
+			Expect(c.HasConversionWebhook()).To(BeTrue())
+		})
 	})
 
 	Context("Plugins", func() {
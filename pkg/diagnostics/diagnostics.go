@@ -0,0 +1,377 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diagnostics inspects a project to find the issues which prevent the CLI from scaffolding it
+// correctly, such as an invalid PROJECT file, misaligned dependencies, missing scaffold markers or
+// manifests drifting from the resources tracked in the PROJECT file.
+package diagnostics
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	// Register the version of the PROJECT file scaffolded by the CLI
+	_ "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+// Severity is the severity of a finding
+type Severity string
+
+const (
+	// SeverityError is used for the findings which prevent the CLI from scaffolding the project
+	SeverityError Severity = "error"
+	// SeverityWarning is used for the findings which may result in an incorrect scaffold
+	SeverityWarning Severity = "warning"
+	// SeverityInfo is used for the findings which do not affect the scaffold
+	SeverityInfo Severity = "info"
+)
+
+// The checks which report the findings
+const (
+	ProjectConfigCheck = "project-config"
+	GoModCheck         = "go-mod"
+	MarkersCheck       = "markers"
+	ConfigDriftCheck   = "config-drift"
+)
+
+// Finding is an issue found in the project
+type Finding struct {
+	// Check is the check which reported the finding
+	Check string `json:"check"`
+	// Severity is the severity of the finding
+	Severity Severity `json:"severity"`
+	// Message describes the issue
+	Message string `json:"message"`
+	// Suggestion describes how to fix the issue
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// String implements fmt.Stringer
+func (f Finding) String() string {
+	s := fmt.Sprintf("[%s] %s: %s", f.Severity, f.Check, f.Message)
+	if f.Suggestion != "" {
+		s += "\n  " + f.Suggestion
+	}
+	return s
+}
+
+// Options configures the diagnosis of a project
+type Options struct {
+	// ControllerRuntimeVersion is the version of controller-runtime scaffolded by the CLI
+	ControllerRuntimeVersion string
+	// MainPath is the path of the entrypoint of the manager, which defaults to cmd/main.go
+	MainPath string
+}
+
+// Diagnose inspects the project in the filesystem and returns the findings of all the checks
+func Diagnose(fs afero.Fs, opts Options) []Finding {
+	if opts.MainPath == "" {
+		opts.MainPath = filepath.Join("cmd", "main.go")
+	}
+
+	cfg, findings := CheckProjectConfig(fs)
+	findings = append(findings, CheckGoMod(fs, opts.ControllerRuntimeVersion)...)
+	findings = append(findings, CheckMainMarkers(fs, opts.MainPath)...)
+	if cfg != nil {
+		findings = append(findings, CheckConfigDrift(fs, cfg)...)
+	}
+	return findings
+}
+
+// HasErrors returns true if any of the findings is an error
+func HasErrors(findings []Finding) bool {
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckProjectConfig checks that the PROJECT file can be loaded and returns its configuration,
+// which is nil when it can not be loaded
+func CheckProjectConfig(fs afero.Fs) (config.Config, []Finding) {
+	store := yaml.New(machinery.Filesystem{FS: fs})
+	if err := store.Load(); err != nil {
+		return nil, []Finding{{
+			Check:      ProjectConfigCheck,
+			Severity:   SeverityError,
+			Message:    fmt.Sprintf("unable to load the %s file: %v", yaml.DefaultPath, err),
+			Suggestion: "Fix the PROJECT file, see https://book.kubebuilder.io/reference/project-config.html",
+		}}
+	}
+
+	var findings []Finding
+	cfg := store.Config()
+	if len(cfg.GetPluginChain()) == 0 {
+		findings = append(findings, Finding{
+			Check:      ProjectConfigCheck,
+			Severity:   SeverityError,
+			Message:    "the PROJECT file has no layout",
+			Suggestion: "Set the plugins which scaffolded the project in the layout field, e.g. go.kubebuilder.io/v4",
+		})
+	}
+	if cfg.GetRepository() == "" {
+		findings = append(findings, Finding{
+			Check:      ProjectConfigCheck,
+			Severity:   SeverityWarning,
+			Message:    "the PROJECT file has no repo",
+			Suggestion: "Set the Go module of the project in the repo field",
+		})
+	}
+	return cfg, findings
+}
+
+// k8sModules are the Kubernetes modules which must have the same version
+var k8sModules = []string{"k8s.io/api", "k8s.io/apimachinery", "k8s.io/client-go"}
+
+const controllerRuntimeModule = "sigs.k8s.io/controller-runtime"
+
+// controllerRuntimeK8sMinorOffset is the difference between the minor versions of the Kubernetes modules
+// and of controller-runtime, e.g. controller-runtime v0.20 is built against the Kubernetes modules v0.32
+const controllerRuntimeK8sMinorOffset = 12
+
+// CheckGoMod checks that the versions of controller-runtime and of the Kubernetes modules of the go.mod
+// are aligned, and that controller-runtime is not older than the version scaffolded by the CLI
+func CheckGoMod(fs afero.Fs, controllerRuntimeVersion string) []Finding {
+	const goModPath = "go.mod"
+	content, err := afero.ReadFile(fs, goModPath)
+	if err != nil {
+		return []Finding{{
+			Check:    GoModCheck,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("unable to read %s: %v", goModPath, err),
+		}}
+	}
+	goMod, err := modfile.ParseLax(goModPath, content, nil)
+	if err != nil {
+		return []Finding{{
+			Check:    GoModCheck,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("unable to parse %s: %v", goModPath, err),
+		}}
+	}
+
+	versions := make(map[string]string, len(goMod.Require))
+	for _, require := range goMod.Require {
+		versions[require.Mod.Path] = require.Mod.Version
+	}
+
+	var findings []Finding
+	crVersion, found := versions[controllerRuntimeModule]
+	if !found {
+		return append(findings, Finding{
+			Check:    GoModCheck,
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("%s is not required by %s", controllerRuntimeModule, goModPath),
+		})
+	}
+
+	if controllerRuntimeVersion != "" && semver.Compare(crVersion, controllerRuntimeVersion) < 0 {
+		findings = append(findings, Finding{
+			Check:    GoModCheck,
+			Severity: SeverityInfo,
+			Message: fmt.Sprintf("%s %s is older than the version %s scaffolded by the CLI",
+				controllerRuntimeModule, crVersion, controllerRuntimeVersion),
+			Suggestion: fmt.Sprintf("Upgrade it with 'go get %s@%s', the scaffold may use newer APIs",
+				controllerRuntimeModule, controllerRuntimeVersion),
+		})
+	}
+
+	k8sVersion := ""
+	for _, module := range k8sModules {
+		version, found := versions[module]
+		if !found {
+			continue
+		}
+		if k8sVersion == "" {
+			k8sVersion = version
+		} else if semver.MajorMinor(version) != semver.MajorMinor(k8sVersion) {
+			findings = append(findings, Finding{
+				Check:    GoModCheck,
+				Severity: SeverityWarning,
+				Message: fmt.Sprintf("the Kubernetes modules have different versions, %s is %s while %s is %s",
+					k8sModules[0], k8sVersion, module, version),
+				Suggestion: "Align the versions of " + strings.Join(k8sModules, ", "),
+			})
+		}
+	}
+
+	if k8sVersion != "" {
+		if expected := expectedK8sMinor(crVersion); expected != "" && semver.MajorMinor(k8sVersion) != expected {
+			findings = append(findings, Finding{
+				Check:    GoModCheck,
+				Severity: SeverityWarning,
+				Message: fmt.Sprintf("%s %s is built against the Kubernetes modules %s, but they are %s",
+					controllerRuntimeModule, crVersion, expected, k8sVersion),
+				Suggestion: fmt.Sprintf("Run 'go get %s@%s' and 'go mod tidy' to align the Kubernetes modules",
+					controllerRuntimeModule, crVersion),
+			})
+		}
+	}
+	return findings
+}
+
+// expectedK8sMinor returns the major and minor versions of the Kubernetes modules which the version
+// of controller-runtime is built against, e.g. v0.32 for v0.20.2
+func expectedK8sMinor(crVersion string) string {
+	var major, minor int
+	if _, err := fmt.Sscanf(semver.MajorMinor(crVersion), "v%d.%d", &major, &minor); err != nil || major != 0 {
+		return ""
+	}
+	return fmt.Sprintf("v0.%d", minor+controllerRuntimeK8sMinorOffset)
+}
+
+// mainMarkers are the scaffold markers of the entrypoint used to wire the APIs and the controllers
+var mainMarkers = []string{"imports", "scheme", "builder"}
+
+// CheckMainMarkers checks that the entrypoint of the manager has the scaffold markers used by the CLI
+func CheckMainMarkers(fs afero.Fs, mainPath string) []Finding {
+	content, err := afero.ReadFile(fs, mainPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Finding{{
+			Check:    MarkersCheck,
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("%s does not exist, the APIs and controllers can not be wired", mainPath),
+		}}
+	} else if err != nil {
+		return []Finding{{
+			Check:    MarkersCheck,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("unable to read %s: %v", mainPath, err),
+		}}
+	}
+
+	found := make(map[string]bool, len(mainMarkers))
+	for _, marker := range ParseMarkers(mainPath, string(content)) {
+		found[marker] = true
+	}
+
+	var findings []Finding
+	for _, value := range mainMarkers {
+		if found[value] {
+			continue
+		}
+		findings = append(findings, Finding{
+			Check:    MarkersCheck,
+			Severity: SeverityWarning,
+			Message: fmt.Sprintf("%s is missing the marker %q, the code of the new APIs and controllers "+
+				"is inserted where the CLI finds the code of the previous ones when parsing the file",
+				mainPath, machinery.NewMarkerFor(mainPath, value).String()),
+			Suggestion: "Restore the marker where the code scaffolded by the CLI is inserted, " +
+				"or check where it was inserted after scaffolding a new API",
+		})
+	}
+	return findings
+}
+
+// ParseMarkers returns the values of the scaffold markers, e.g. "imports" for "// +kubebuilder:scaffold:imports",
+// found in the content of the file
+func ParseMarkers(path, content string) []string {
+	var markers []string
+	prefix := machinery.NewMarkerFor(path, "").String()
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		if value := strings.TrimSpace(strings.TrimPrefix(line, prefix)); value != "" {
+			markers = append(markers, value)
+		}
+	}
+	return markers
+}
+
+// CheckConfigDrift checks that the manifests under config/ match the APIs tracked in the PROJECT file
+func CheckConfigDrift(fs afero.Fs, cfg config.Config) []Finding {
+	resources, err := cfg.GetResources()
+	if err != nil {
+		return []Finding{{
+			Check:    ConfigDriftCheck,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("unable to get the resources of the PROJECT file: %v", err),
+		}}
+	}
+
+	crdKustomizationPath := filepath.Join("config", "crd", "kustomization.yaml")
+	crdKustomization, err := afero.ReadFile(fs, crdKustomizationPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return []Finding{{
+			Check:    ConfigDriftCheck,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("unable to read %s: %v", crdKustomizationPath, err),
+		}}
+	}
+
+	var findings []Finding
+	if crdKustomization == nil {
+		for _, res := range resources {
+			if res.HasAPI() && !res.IsExternal() {
+				findings = append(findings, Finding{
+					Check:      ConfigDriftCheck,
+					Severity:   SeverityWarning,
+					Message:    fmt.Sprintf("%s does not exist, so the CRDs are not installed", crdKustomizationPath),
+					Suggestion: "Restore it from the scaffold of the project, e.g. with 'kubebuilder alpha diff'",
+				})
+				break
+			}
+		}
+	}
+
+	for _, res := range resources {
+		// The CRDs of the external APIs are not scaffolded by the project
+		if !res.HasAPI() || res.IsExternal() {
+			continue
+		}
+		kind := fmt.Sprintf("%s/%s, Kind=%s", res.QualifiedGroup(), res.Version, res.Kind)
+
+		crd := fmt.Sprintf("bases/%s_%s.yaml", res.QualifiedGroup(), res.Plural)
+		if crdKustomization != nil && !strings.Contains(string(crdKustomization), crd) {
+			findings = append(findings, Finding{
+				Check:    ConfigDriftCheck,
+				Severity: SeverityWarning,
+				Message: fmt.Sprintf("the CRD %s of %s is not listed in %s, so it is not installed",
+					crd, kind, crdKustomizationPath),
+				Suggestion: fmt.Sprintf("Add '- %s' to the resources of %s", crd, crdKustomizationPath),
+			})
+		}
+
+		samplePath := filepath.Join("config", "samples", "%[group]_%[version]_%[kind].yaml")
+		if res.Group == "" {
+			samplePath = filepath.Join("config", "samples", "%[version]_%[kind].yaml")
+		}
+		samplePath = res.Replacer().Replace(samplePath)
+		if exists, err := afero.Exists(fs, samplePath); err == nil && !exists {
+			findings = append(findings, Finding{
+				Check:      ConfigDriftCheck,
+				Severity:   SeverityInfo,
+				Message:    fmt.Sprintf("the sample %s of %s does not exist", samplePath, kind),
+				Suggestion: "Add a sample of the custom resource to document its usage",
+			})
+		}
+	}
+	return findings
+}
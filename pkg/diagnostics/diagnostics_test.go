@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+)
+
+func TestDiagnostics(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Diagnostics Suite")
+}
+
+const projectFile = `domain: example.org
+layout:
+- go.kubebuilder.io/v4
+projectName: project
+repo: example.com/project
+resources:
+- api:
+    crdVersion: v1
+    namespaced: true
+  domain: example.org
+  group: crew
+  kind: Captain
+  path: example.com/project/api/v1
+  version: v1
+- domain: k8s.io
+  external: true
+  group: apps
+  kind: Deployment
+  path: k8s.io/api/apps/v1
+  version: v1
+  webhooks:
+    defaulting: true
+    webhookVersion: v1
+version: "3"
+`
+
+const mainFile = `package main
+
+import (
+	// +kubebuilder:scaffold:imports
+)
+
+func init() {
+	// +kubebuilder:scaffold:scheme
+}
+
+func main() {
+	// +kubebuilder:scaffold:builder
+}
+`
+
+const goModFile = `module example.com/project
+
+go 1.23.0
+
+require (
+	k8s.io/api v0.32.1
+	k8s.io/apimachinery v0.32.1
+	k8s.io/client-go v0.32.1
+	sigs.k8s.io/controller-runtime v0.20.2
+)
+`
+
+func writeFile(fs afero.Fs, path, content string) {
+	Expect(afero.WriteFile(fs, path, []byte(content), 0o644)).To(Succeed())
+}
+
+func checks(findings []Finding) []string {
+	names := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		names = append(names, finding.Check)
+	}
+	return names
+}
+
+var _ = Describe("Diagnose", func() {
+	var fs afero.Fs
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		writeFile(fs, "PROJECT", projectFile)
+		writeFile(fs, "go.mod", goModFile)
+		writeFile(fs, "cmd/main.go", mainFile)
+		writeFile(fs, "config/crd/kustomization.yaml", "resources:\n- bases/crew.example.org_captains.yaml\n")
+		writeFile(fs, "config/samples/crew_v1_captain.yaml", "kind: Captain\n")
+	})
+
+	It("should not find issues in a healthy project", func() {
+		Expect(Diagnose(fs, Options{ControllerRuntimeVersion: "v0.20.2"})).To(BeEmpty())
+	})
+
+	It("should report an error when the PROJECT file can not be loaded", func() {
+		writeFile(fs, "PROJECT", "version: [3")
+
+		findings := Diagnose(fs, Options{})
+		Expect(checks(findings)).To(ConsistOf(ProjectConfigCheck))
+		Expect(HasErrors(findings)).To(BeTrue())
+	})
+
+	It("should report the missing markers of the entrypoint", func() {
+		writeFile(fs, "cmd/main.go", "package main\n\n// +kubebuilder:scaffold:imports\n")
+
+		findings := CheckMainMarkers(fs, "cmd/main.go")
+		Expect(findings).To(HaveLen(2))
+		Expect(findings[0].Message).To(ContainSubstring("// +kubebuilder:scaffold:scheme"))
+		Expect(findings[1].Message).To(ContainSubstring("// +kubebuilder:scaffold:builder"))
+		Expect(HasErrors(findings)).To(BeFalse())
+	})
+
+	It("should report the drift of the manifests", func() {
+		writeFile(fs, "config/crd/kustomization.yaml", "resources: []\n")
+		Expect(fs.Remove("config/samples/crew_v1_captain.yaml")).To(Succeed())
+
+		findings := Diagnose(fs, Options{})
+		Expect(checks(findings)).To(Equal([]string{ConfigDriftCheck, ConfigDriftCheck}))
+		Expect(findings[0].Suggestion).To(ContainSubstring("bases/crew.example.org_captains.yaml"))
+		Expect(findings[1].Message).To(ContainSubstring("config/samples/crew_v1_captain.yaml"))
+	})
+
+	It("should report the missing kustomization of the CRDs once", func() {
+		Expect(fs.Remove("config/crd/kustomization.yaml")).To(Succeed())
+
+		findings := Diagnose(fs, Options{})
+		Expect(findings).To(HaveLen(1))
+		Expect(findings[0].Message).To(ContainSubstring("config/crd/kustomization.yaml does not exist"))
+	})
+})
+
+var _ = Describe("CheckGoMod", func() {
+	var fs afero.Fs
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+	})
+
+	It("should report an older controller-runtime than the scaffolded one", func() {
+		writeFile(fs, "go.mod", goModFile)
+
+		findings := CheckGoMod(fs, "v0.21.0")
+		Expect(findings).To(HaveLen(1))
+		Expect(findings[0].Severity).To(Equal(SeverityInfo))
+		Expect(findings[0].Suggestion).To(ContainSubstring("sigs.k8s.io/controller-runtime@v0.21.0"))
+	})
+
+	It("should report the Kubernetes modules which are not aligned with controller-runtime", func() {
+		writeFile(fs, "go.mod", `module example.com/project
+
+require (
+	k8s.io/api v0.31.0
+	k8s.io/apimachinery v0.32.1
+	sigs.k8s.io/controller-runtime v0.20.2
+)
+`)
+
+		findings := CheckGoMod(fs, "")
+		Expect(findings).To(HaveLen(2))
+		Expect(findings[0].Message).To(ContainSubstring("different versions"))
+		Expect(findings[1].Message).To(ContainSubstring("built against the Kubernetes modules v0.32"))
+	})
+
+	It("should report an error when go.mod does not exist", func() {
+		findings := CheckGoMod(fs, "")
+		Expect(HasErrors(findings)).To(BeTrue())
+	})
+})
+
+var _ = Describe("ParseMarkers", func() {
+	It("should return the values of the scaffold markers", func() {
+		Expect(ParseMarkers("cmd/main.go", mainFile)).To(Equal([]string{"imports", "scheme", "builder"}))
+	})
+
+	It("should use the comments of the file extension", func() {
+		content := "resources:\n# +kubebuilder:scaffold:crdkustomizeresource\n// +kubebuilder:scaffold:imports\n"
+		Expect(ParseMarkers("kustomization.yaml", content)).To(Equal([]string{"crdkustomizeresource"}))
+	})
+})
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// contextLines is the number of lines reported before and after the line of a template error
+const contextLines = 2
+
+// maxValueLength is the maximum length of the values of the fields reported by a template error
+const maxValueLength = 80
+
+var (
+	// templateLineRegex matches the line reported by the errors of text/template,
+	// e.g. template: *templates.Main:12:5: executing "*templates.Main" at <.Resource.Path>: ...
+	templateLineRegex = regexp.MustCompile(`^template: [^:]+:(\d+)`)
+	// templateActionRegex matches the action which failed to execute
+	templateActionRegex = regexp.MustCompile(`executing "[^"]*" at <(.*?)>: `)
+	// formatLineRegex matches the line reported by the errors formatting the go files, e.g. main.go:3:1: ...
+	formatLineRegex = regexp.MustCompile(`^[^:]*:(\d+):\d+: `)
+	// fieldChainRegex matches the actions which are a chain of fields, e.g. .Resource.Webhooks.Conversion
+	fieldChainRegex = regexp.MustCompile(`^(\.[A-Za-z_][A-Za-z0-9_]*)+$`)
+)
+
+// newTemplateError returns the error of a template which failed to be parsed or executed,
+// located in its body and with the value of the fields of the failed action.
+func newTemplateError(t Template, err error) TemplateError {
+	tErr := TemplateError{error: err, Builder: builderName(t), Path: t.GetPath()}
+	if matches := templateLineRegex.FindStringSubmatch(err.Error()); matches != nil {
+		tErr.Line, _ = strconv.Atoi(matches[1])
+		tErr.Context = lineContext(t.GetBody(), tErr.Line)
+	}
+	if matches := templateActionRegex.FindStringSubmatch(err.Error()); matches != nil {
+		tErr.Field = describeFields(t, matches[1])
+	}
+	return tErr
+}
+
+// newFormatError returns the error of a go file which failed to be formatted once rendered,
+// located in the rendered content.
+func newFormatError(t Template, content []byte, err error) TemplateError {
+	tErr := TemplateError{error: err, Builder: builderName(t), Path: t.GetPath()}
+	if matches := formatLineRegex.FindStringSubmatch(err.Error()); matches != nil {
+		tErr.Line, _ = strconv.Atoi(matches[1])
+		tErr.Context = lineContext(string(content), tErr.Line)
+	}
+	return tErr
+}
+
+// lineContext returns the lines of the content around the line, numbered and with the line marked
+func lineContext(content string, line int) string {
+	lines := strings.Split(content, "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	first, last := max(line-contextLines, 1), min(line+contextLines, len(lines))
+	width := len(strconv.Itoa(last))
+	context := make([]string, 0, last-first+1)
+	for i := first; i <= last; i++ {
+		marker := " "
+		if i == line {
+			marker = ">"
+		}
+		context = append(context, fmt.Sprintf("  %s %*d | %s", marker, width, i, lines[i-1]))
+	}
+	return strings.Join(context, "\n")
+}
+
+// describeFields describes the value of the chain of fields of the action evaluated on the template,
+// up to the first one which is nil or missing. Other actions, e.g. function calls, are not described.
+func describeFields(t Template, action string) string {
+	if !fieldChainRegex.MatchString(action) {
+		return ""
+	}
+
+	value := reflect.ValueOf(t)
+	evaluated := ""
+	for _, field := range strings.Split(strings.TrimPrefix(action, "."), ".") {
+		for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return fmt.Sprintf("%s is nil", evaluated)
+			}
+			value = value.Elem()
+		}
+
+		var next reflect.Value
+		switch value.Kind() {
+		case reflect.Struct:
+			next = value.FieldByName(field)
+		case reflect.Map:
+			if value.Type().Key().Kind() == reflect.String {
+				next = value.MapIndex(reflect.ValueOf(field).Convert(value.Type().Key()))
+			}
+		}
+		if !next.IsValid() {
+			return fmt.Sprintf("%s (%s) has no field or key %s", displayName(evaluated), value.Type(), field)
+		}
+		value = next
+		evaluated += "." + field
+	}
+
+	if !value.CanInterface() {
+		return ""
+	}
+	formatted := fmt.Sprintf("%#v", value.Interface())
+	if len(formatted) > maxValueLength {
+		formatted = formatted[:maxValueLength] + "..."
+	}
+	return fmt.Sprintf("%s is %s", evaluated, formatted)
+}
+
+// displayName returns the name of a chain of evaluated fields, which is the template itself when empty
+func displayName(evaluated string) string {
+	if evaluated == "" {
+		return "."
+	}
+	return evaluated
+}
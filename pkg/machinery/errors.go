@@ -18,6 +18,7 @@ package machinery
 
 import (
 	"fmt"
+	"strings"
 )
 
 // This file contains the errors returned by the scaffolding machinery
@@ -43,6 +44,26 @@ func (e SetTemplateDefaultsError) Unwrap() error {
 	return e.error
 }
 
+// SetAssetDefaultsError is a wrapper error that will be used for errors returned by Asset.SetAssetDefaults
+type SetAssetDefaultsError struct {
+	error
+}
+
+// Unwrap implements Wrapper interface
+func (e SetAssetDefaultsError) Unwrap() error {
+	return e.error
+}
+
+// ReadAssetError is a wrapper error that will be used for errors returned by Asset.GetContent
+type ReadAssetError struct {
+	error
+}
+
+// Unwrap implements Wrapper interface
+func (e ReadAssetError) Unwrap() error {
+	return e.error
+}
+
 // ExistsFileError is a wrapper error that will be used for errors when checking for a file existence
 type ExistsFileError struct {
 	error
@@ -113,6 +134,46 @@ func (e CloseFileError) Unwrap() error {
 	return e.error
 }
 
+// TemplateError is a wrapper error that will be used for errors when parsing, executing or formatting a template.
+// It locates the error in the template, so that it can be fixed without debugging the plugin.
+type TemplateError struct {
+	error
+
+	// Builder is the type of the template
+	Builder string
+	// Path is the path of the file scaffolded by the template
+	Path string
+	// Line is the line of the error in the body of the template, or in the rendered file for formatting errors.
+	// It is 0 if the error does not report a line.
+	Line int
+	// Context are the lines around the line of the error
+	Context string
+	// Field describes the value of the fields evaluated by the action which failed, if any
+	Field string
+}
+
+// Unwrap implements Wrapper interface
+func (e TemplateError) Unwrap() error {
+	return e.error
+}
+
+// Error implements error interface
+func (e TemplateError) Error() string {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "failed to render %s with %s", e.Path, e.Builder)
+	if e.Line > 0 {
+		fmt.Fprintf(&msg, " at line %d", e.Line)
+	}
+	fmt.Fprintf(&msg, ": %v", e.error)
+	if e.Context != "" {
+		msg.WriteString("\n" + e.Context)
+	}
+	if e.Field != "" {
+		msg.WriteString("\n  " + e.Field)
+	}
+	return msg.String()
+}
+
 // ModelAlreadyExistsError is returned if the file is expected not to exist but a previous model does
 type ModelAlreadyExistsError struct {
 	path string
@@ -123,6 +184,19 @@ func (e ModelAlreadyExistsError) Error() string {
 	return fmt.Sprintf("failed to create %s: model already exists", e.path)
 }
 
+// ConflictingBuildersError is returned if two builders require to overwrite the same file with different contents
+type ConflictingBuildersError struct {
+	path     string
+	previous string
+	builder  string
+}
+
+// Error implements error interface
+func (e ConflictingBuildersError) Error() string {
+	return fmt.Sprintf("failed to create %s: %s overwrites the model built by %s with different contents",
+		e.path, e.builder, e.previous)
+}
+
 // UnknownIfExistsActionError is returned if the if-exists-action is unknown
 type UnknownIfExistsActionError struct {
 	path           string
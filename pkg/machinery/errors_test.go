@@ -36,6 +36,8 @@ var _ = Describe("Errors", func() {
 		},
 		Entry("for validate errors", ValidateError{testErr}),
 		Entry("for set template defaults errors", SetTemplateDefaultsError{testErr}),
+		Entry("for set asset defaults errors", SetAssetDefaultsError{testErr}),
+		Entry("for asset reading errors", ReadAssetError{testErr}),
 		Entry("for file existence errors", ExistsFileError{testErr}),
 		Entry("for file opening errors", OpenFileError{testErr}),
 		Entry("for directory creation errors", CreateDirectoryError{testErr}),
@@ -43,12 +45,17 @@ var _ = Describe("Errors", func() {
 		Entry("for file reading errors", ReadFileError{testErr}),
 		Entry("for file writing errors", WriteFileError{testErr}),
 		Entry("for file closing errors", CloseFileError{testErr}),
+		Entry("for template errors", TemplateError{error: testErr}),
 	)
 
 	// NOTE: the following test increases coverage
 	It("should print a descriptive error message", func() {
 		Expect(ModelAlreadyExistsError{path}.Error()).To(ContainSubstring("model already exists"))
 		Expect(UnknownIfExistsActionError{path, -1}.Error()).To(ContainSubstring("unknown behavior if file exists"))
+		Expect(ConflictingBuildersError{path, "previous", "builder"}.Error()).
+			To(ContainSubstring("builder overwrites the model built by previous"))
 		Expect(FileAlreadyExistsError{path}.Error()).To(ContainSubstring("file already exists"))
+		Expect(TemplateError{error: testErr, Builder: "builder", Path: path, Line: 1}.Error()).
+			To(ContainSubstring("failed to render " + path + " with builder at line 1: test error"))
 	})
 })
@@ -16,6 +16,10 @@ limitations under the License.
 
 package machinery
 
+import (
+	"os"
+)
+
 // IfExistsAction determines what to do if the scaffold file already exists
 type IfExistsAction int
 
@@ -40,4 +44,10 @@ type File struct {
 
 	// IfExistsAction determines what to do if the file exists
 	IfExistsAction IfExistsAction
+
+	// Permissions are the permissions of the file, the permissions of the Scaffold are used if it is 0
+	Permissions os.FileMode
+
+	// builder is the type of the builder which built the model, used to report conflicting builders
+	builder string
 }
@@ -23,4 +23,10 @@ import (
 // Filesystem abstracts the underlying disk for scaffolding
 type Filesystem struct {
 	FS afero.Fs
+
+	// Recorder, if set, is notified of the files written or skipped by the Scaffolds using this Filesystem
+	Recorder Recorder
+
+	// Metrics, if set, collects the metrics of the builders executed by the Scaffolds using this Filesystem
+	Metrics *Metrics
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package machinery
 
 import (
+	"os"
 	"text/template"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
@@ -37,6 +38,13 @@ type RequiresValidation interface {
 	Validate() error
 }
 
+// HasPermissions is a file builder which sets the permissions of its file, e.g. to write executable scripts
+type HasPermissions interface {
+	Builder
+	// Permissions returns the permissions of the file, the permissions of the Scaffold are used if it is 0
+	Permissions() os.FileMode
+}
+
 // Template is file builder based on a file template
 type Template interface {
 	Builder
@@ -50,6 +58,15 @@ type Template interface {
 	GetDelim() (string, string)
 }
 
+// Asset is file builder based on static content, such as binary files, which is copied verbatim
+type Asset interface {
+	Builder
+	// GetContent returns the content of the file
+	GetContent() ([]byte, error)
+	// SetAssetDefaults sets the default values for assets
+	SetAssetDefaults() error
+}
+
 // Inserter is a file builder that inserts code fragments in marked positions
 type Inserter interface {
 	Builder
@@ -59,6 +76,15 @@ type Inserter interface {
 	GetCodeFragments() CodeFragmentsMap
 }
 
+// HasMarkerFallback is an Inserter which is able to insert the code fragments of the markers missing
+// from the file, e.g. because they were removed when the file was reformatted or reorganized
+type HasMarkerFallback interface {
+	Inserter
+	// InsertWithoutMarkers inserts the code fragments of the markers which are not found in the content
+	// and returns the updated content
+	InsertWithoutMarkers(content string, codeFragments CodeFragmentsMap) (string, error)
+}
+
 // HasDomain allows the domain to be used on a template
 type HasDomain interface {
 	// InjectDomain sets the template domain
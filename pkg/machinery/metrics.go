@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// BuilderMetrics are the metrics of the executions of a type of builder by the Scaffolds
+type BuilderMetrics struct {
+	// Builder is the name of the type of the builder, e.g. *templates.Main
+	Builder string
+	// Executions is the number of builders of this type executed
+	Executions int
+	// Duration is the time spent building the files of the builders, i.e. executing their templates,
+	// formatting and inserting their code fragments, and writing them
+	Duration time.Duration
+	// FilesWritten and BytesWritten are the number of files and bytes written for the builders
+	FilesWritten int
+	BytesWritten int
+	// FilesSkipped is the number of existing files which were not written because of their IfExistsAction
+	FilesSkipped int
+}
+
+// Metrics collects the metrics of the builders executed by the Scaffolds using a Filesystem, by type of
+// builder, to diagnose the slow plugin chains. It is safe for concurrent use.
+type Metrics struct {
+	mu       sync.Mutex
+	builders map[string]*BuilderMetrics
+}
+
+// NewMetrics returns empty Metrics
+func NewMetrics() *Metrics {
+	return &Metrics{builders: make(map[string]*BuilderMetrics)}
+}
+
+// Builders returns the metrics of each type of builder, the slowest first
+func (m *Metrics) Builders() []BuilderMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	builders := make([]BuilderMetrics, 0, len(m.builders))
+	for _, b := range m.builders {
+		builders = append(builders, *b)
+	}
+	sort.Slice(builders, func(i, j int) bool {
+		if builders[i].Duration != builders[j].Duration {
+			return builders[i].Duration > builders[j].Duration
+		}
+		return builders[i].Builder < builders[j].Builder
+	})
+	return builders
+}
+
+// WriteReport writes the metrics of each type of builder, followed by their total, as a table
+func (m *Metrics) WriteReport(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "BUILDER\tEXECUTIONS\tDURATION\tFILES WRITTEN\tBYTES WRITTEN\tFILES SKIPPED")
+
+	total := BuilderMetrics{Builder: "TOTAL"}
+	for _, b := range m.Builders() {
+		writeBuilderMetrics(tw, b)
+		total.Executions += b.Executions
+		total.Duration += b.Duration
+		total.FilesWritten += b.FilesWritten
+		total.BytesWritten += b.BytesWritten
+		total.FilesSkipped += b.FilesSkipped
+	}
+	writeBuilderMetrics(tw, total)
+
+	return tw.Flush()
+}
+
+func writeBuilderMetrics(w io.Writer, b BuilderMetrics) {
+	_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%d\t%d\n", b.Builder, b.Executions,
+		b.Duration.Round(time.Microsecond), b.FilesWritten, b.BytesWritten, b.FilesSkipped)
+}
+
+// builder returns the metrics of the type of builder, which must be called with the lock held
+func (m *Metrics) builder(name string) *BuilderMetrics {
+	b, found := m.builders[name]
+	if !found {
+		b = &BuilderMetrics{Builder: name}
+		m.builders[name] = b
+	}
+	return b
+}
+
+// observeBuild records the execution of a builder. The Metrics may be nil, then nothing is recorded.
+func (m *Metrics) observeBuild(name string, d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b := m.builder(name)
+	b.Executions++
+	b.Duration += d
+}
+
+// observeWrite records a file written for a builder. The Metrics may be nil, then nothing is recorded.
+func (m *Metrics) observeWrite(name string, bytes int, d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b := m.builder(name)
+	b.FilesWritten++
+	b.BytesWritten += bytes
+	b.Duration += d
+}
+
+// observeSkip records a file skipped for a builder. The Metrics may be nil, then nothing is recorded.
+func (m *Metrics) observeSkip(name string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.builder(name).FilesSkipped++
+}
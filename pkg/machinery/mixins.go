@@ -17,6 +17,9 @@ limitations under the License.
 package machinery
 
 import (
+	"io/fs"
+	"os"
+
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
 )
 
@@ -51,6 +54,14 @@ type TemplateMixin struct {
 	TemplateBody    string
 	parseDelimLeft  string
 	parseDelimRight string
+
+	// FilePermissions are the permissions of the file, the permissions of the Scaffold are used if it is 0
+	FilePermissions os.FileMode
+}
+
+// Permissions implements HasPermissions
+func (t *TemplateMixin) Permissions() os.FileMode {
+	return t.FilePermissions
 }
 
 // GetBody implements Template
@@ -69,6 +80,30 @@ func (t *TemplateMixin) GetDelim() (string, string) {
 	return t.parseDelimLeft, t.parseDelimRight
 }
 
+// AssetMixin is the mixin that should be embedded in Asset builders
+type AssetMixin struct {
+	PathMixin
+	IfExistsActionMixin
+
+	// FS is the file system, usually an embed.FS, which holds the asset
+	FS fs.FS
+	// Source is the path of the asset in FS
+	Source string
+
+	// FilePermissions are the permissions of the file, the permissions of the Scaffold are used if it is 0
+	FilePermissions os.FileMode
+}
+
+// Permissions implements HasPermissions
+func (t *AssetMixin) Permissions() os.FileMode {
+	return t.FilePermissions
+}
+
+// GetContent implements Asset
+func (t *AssetMixin) GetContent() ([]byte, error) {
+	return fs.ReadFile(t.FS, t.Source)
+}
+
 // InserterMixin is the mixin that should be embedded in Inserter builders
 type InserterMixin struct {
 	PathMixin
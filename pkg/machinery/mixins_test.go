@@ -17,6 +17,8 @@ limitations under the License.
 package machinery
 
 import (
+	"testing/fstest"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -33,6 +35,10 @@ type mockTemplate struct {
 	ResourceMixin
 }
 
+type mockAsset struct {
+	AssetMixin
+}
+
 type mockInserter struct {
 	// InserterMixin requires a different type because it collides with TemplateMixin
 	InserterMixin
@@ -50,6 +56,7 @@ var _ = Describe("TemplateMixin", func() {
 			PathMixin:           PathMixin{path},
 			IfExistsActionMixin: IfExistsActionMixin{ifExistsAction},
 			TemplateBody:        body,
+			FilePermissions:     ExecutableFilePermission,
 		},
 	}
 
@@ -70,6 +77,55 @@ var _ = Describe("TemplateMixin", func() {
 			Expect(tmp.GetBody()).To(Equal(body))
 		})
 	})
+
+	Context("Permissions", func() {
+		It("should return the file permissions", func() {
+			Expect(tmp.Permissions()).To(Equal(ExecutableFilePermission))
+		})
+	})
+})
+
+var _ = Describe("AssetMixin", func() {
+	const (
+		path           = "path/to/icon.png"
+		ifExistsAction = OverwriteFile
+		source         = "assets/icon.png"
+	)
+
+	content := []byte{0x89, 'P', 'N', 'G', 0xff, 0x00}
+
+	tmp := mockAsset{
+		AssetMixin: AssetMixin{
+			PathMixin:           PathMixin{path},
+			IfExistsActionMixin: IfExistsActionMixin{ifExistsAction},
+			FS:                  fstest.MapFS{source: &fstest.MapFile{Data: content}},
+			Source:              source,
+		},
+	}
+
+	Context("GetPath", func() {
+		It("should return the path", func() {
+			Expect(tmp.GetPath()).To(Equal(path))
+		})
+	})
+
+	Context("GetIfExistsAction", func() {
+		It("should return the if-exists action", func() {
+			Expect(tmp.GetIfExistsAction()).To(Equal(ifExistsAction))
+		})
+	})
+
+	Context("GetContent", func() {
+		It("should return the content of the source", func() {
+			Expect(tmp.GetContent()).To(Equal(content))
+		})
+
+		It("should fail if the source does not exist", func() {
+			missing := mockAsset{AssetMixin: AssetMixin{FS: fstest.MapFS{}, Source: source}}
+			_, err := missing.GetContent()
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
 
 var _ = Describe("InserterMixin", func() {
@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+// FileAction is the action performed by a Scaffold on a file
+type FileAction string
+
+const (
+	// FileCreated is reported when the file did not exist and was written
+	FileCreated FileAction = "created"
+	// FileUpdated is reported when the file existed and was overwritten or updated
+	FileUpdated FileAction = "updated"
+	// FileSkipped is reported when the file existed and was not written because of its IfExistsAction
+	FileSkipped FileAction = "skipped"
+)
+
+// Recorder is notified of the actions performed by a Scaffold on the files
+type Recorder interface {
+	// RecordFile is called once per file written or skipped by a Scaffold
+	RecordFile(path string, action FileAction)
+}
@@ -22,8 +22,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
 
 	"github.com/spf13/afero"
 	"golang.org/x/tools/imports"
@@ -37,6 +41,9 @@ const (
 
 	defaultDirectoryPermission os.FileMode = 0700
 	defaultFilePermission      os.FileMode = 0600
+
+	// ExecutableFilePermission are the permissions of the scaffolded files which must be executable, such as scripts
+	ExecutableFilePermission os.FileMode = 0700
 )
 
 var options = imports.Options{
@@ -57,6 +64,15 @@ type Scaffold struct {
 
 	// injector is used to provide several fields to the templates
 	injector injector
+
+	// recorder is notified of the actions performed on the files
+	recorder Recorder
+
+	// metrics collects the metrics of the executed builders
+	metrics *Metrics
+
+	// maxWorkers is the maximum number of templates executed concurrently
+	maxWorkers int
 }
 
 // ScaffoldOption allows to provide optional arguments to the Scaffold
@@ -65,9 +81,12 @@ type ScaffoldOption func(*Scaffold)
 // NewScaffold returns a new Scaffold with the provided plugins
 func NewScaffold(fs Filesystem, options ...ScaffoldOption) *Scaffold {
 	s := &Scaffold{
-		fs:       fs.FS,
-		dirPerm:  defaultDirectoryPermission,
-		filePerm: defaultFilePermission,
+		fs:         fs.FS,
+		recorder:   fs.Recorder,
+		metrics:    fs.Metrics,
+		dirPerm:    defaultDirectoryPermission,
+		filePerm:   defaultFilePermission,
+		maxWorkers: runtime.GOMAXPROCS(0),
 	}
 
 	for _, option := range options {
@@ -91,6 +110,14 @@ func WithFilePermissions(filePerm os.FileMode) ScaffoldOption {
 	}
 }
 
+// WithMaxWorkers sets the maximum number of templates executed concurrently, the templates are executed
+// one at a time if it is lower than 2
+func WithMaxWorkers(maxWorkers int) ScaffoldOption {
+	return func(s *Scaffold) {
+		s.maxWorkers = maxWorkers
+	}
+}
+
 // WithConfig provides the project configuration to the Scaffold
 func WithConfig(cfg config.Config) ScaffoldOption {
 	return func(s *Scaffold) {
@@ -116,25 +143,55 @@ func WithResource(resource *resource.Resource) ScaffoldOption {
 	}
 }
 
-// Execute writes to disk the provided files
+// builderExecution is the state of the execution of a builder
+type builderExecution struct {
+	// err is the error returned while setting up the builder
+	err error
+	// contents and templateErr are the result of the execution of the template of the builder
+	contents    []byte
+	templateErr error
+	// duration is the time spent setting up the builder and executing its template
+	duration time.Duration
+}
+
+// Execute writes to disk the provided files. The files are written in the order of the builders which
+// first build them, and two builders overwriting the same file with different contents are rejected.
+//
+// The builders are set up in order, then their templates, which are independent of each other, are executed
+// concurrently, and finally their models are built in order, so that the inserters update the files built
+// by the builders preceding them.
 func (s *Scaffold) Execute(builders ...Builder) error {
 	// Initialize the files
 	files := make(map[string]*File, len(builders))
+	paths := make([]string, 0, len(builders))
+
+	executions := make([]builderExecution, len(builders))
+	for i, builder := range builders {
+		start := time.Now()
+		executions[i].err = s.setUp(builder)
+		executions[i].duration = time.Since(start)
+		if executions[i].err != nil {
+			break
+		}
+	}
+	s.executeTemplates(builders, executions)
 
-	for _, builder := range builders {
-		// Inject common fields
-		s.injector.injectInto(builder)
-
-		// Validate file builders
-		if reqValBuilder, requiresValidation := builder.(RequiresValidation); requiresValidation {
-			if err := reqValBuilder.Validate(); err != nil {
-				return ValidateError{err}
-			}
+	for i, builder := range builders {
+		if executions[i].err != nil {
+			return executions[i].err
 		}
+		start := time.Now()
 
 		// Build models for Template builders
 		if t, isTemplate := builder.(Template); isTemplate {
-			if err := s.buildFileModel(t, files); err != nil {
+			if err := buildFileModel(t, executions[i].contents, executions[i].templateErr, files); err != nil {
+				return err
+			}
+		}
+
+		// Build models for Asset builders
+		if a, isAsset := builder.(Asset); isAsset {
+			if err := buildAssetModel(a, files); err != nil {
 				return err
 			}
 		}
@@ -145,11 +202,18 @@ func (s *Scaffold) Execute(builders ...Builder) error {
 				return err
 			}
 		}
+
+		// Keep the order in which the files are first built
+		if path := builder.GetPath(); files[path] != nil && !slices.Contains(paths, path) {
+			paths = append(paths, path)
+		}
+
+		s.metrics.observeBuild(builderName(builder), executions[i].duration+time.Since(start))
 	}
 
 	// Persist the files to disk
-	for _, f := range files {
-		if err := s.writeFile(f); err != nil {
+	for _, path := range paths {
+		if err := s.writeFile(files[path]); err != nil {
 			return err
 		}
 	}
@@ -157,30 +221,93 @@ func (s *Scaffold) Execute(builders ...Builder) error {
 	return nil
 }
 
-// buildFileModel scaffolds a single file
-func (Scaffold) buildFileModel(t Template, models map[string]*File) error {
+// setUp injects the common fields in the builder, validates it and sets its default values
+func (s *Scaffold) setUp(builder Builder) error {
+	// Inject common fields
+	s.injector.injectInto(builder)
+
+	// Validate file builders
+	if reqValBuilder, requiresValidation := builder.(RequiresValidation); requiresValidation {
+		if err := reqValBuilder.Validate(); err != nil {
+			return ValidateError{err}
+		}
+	}
+
 	// Set the template default values
-	if err := t.SetTemplateDefaults(); err != nil {
-		return SetTemplateDefaultsError{err}
+	if t, isTemplate := builder.(Template); isTemplate {
+		if err := t.SetTemplateDefaults(); err != nil {
+			return SetTemplateDefaultsError{err}
+		}
 	}
 
+	// Set the asset default values
+	if a, isAsset := builder.(Asset); isAsset {
+		if err := a.SetAssetDefaults(); err != nil {
+			return SetAssetDefaultsError{err}
+		}
+	}
+	return nil
+}
+
+// executeTemplates executes the templates of the builders which were set up, with at most maxWorkers
+// templates executed concurrently
+func (s *Scaffold) executeTemplates(builders []Builder, executions []builderExecution) {
+	indexes := make([]int, 0, len(builders))
+	for i, builder := range builders {
+		if executions[i].err != nil {
+			break
+		}
+		if _, isTemplate := builder.(Template); isTemplate {
+			indexes = append(indexes, i)
+		}
+	}
+
+	execute := func(i int) {
+		start := time.Now()
+		executions[i].contents, executions[i].templateErr = doTemplate(builders[i].(Template))
+		executions[i].duration += time.Since(start)
+	}
+
+	workers := min(s.maxWorkers, len(indexes))
+	if workers < 2 {
+		for _, i := range indexes {
+			execute(i)
+		}
+		return
+	}
+
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				execute(i)
+			}
+		}()
+	}
+	for _, i := range indexes {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// buildFileModel scaffolds a single file from the result of the execution of its template
+func buildFileModel(t Template, b []byte, templateErr error, models map[string]*File) error {
 	path := t.GetPath()
 
 	// Handle already existing models
-	if _, found := models[path]; found {
-		switch t.GetIfExistsAction() {
-		case SkipFile:
-			return nil
-		case Error:
-			return ModelAlreadyExistsError{path}
-		case OverwriteFile:
-		default:
-			return UnknownIfExistsActionError{path, t.GetIfExistsAction()}
-		}
+	if skip, err := skipExistingModel(t, models); skip || err != nil {
+		return err
 	}
 
-	b, err := doTemplate(t)
-	if err != nil {
+	if templateErr != nil {
+		return templateErr
+	}
+
+	if err := checkConflictingModel(t, string(b), models); err != nil {
 		return err
 	}
 
@@ -188,10 +315,85 @@ func (Scaffold) buildFileModel(t Template, models map[string]*File) error {
 		Path:           path,
 		Contents:       string(b),
 		IfExistsAction: t.GetIfExistsAction(),
+		Permissions:    getPermissions(t),
+		builder:        builderName(t),
 	}
 	return nil
 }
 
+// buildAssetModel copies the content of a single asset
+func buildAssetModel(a Asset, models map[string]*File) error {
+	// Handle already existing models
+	if skip, err := skipExistingModel(a, models); skip || err != nil {
+		return err
+	}
+
+	b, err := a.GetContent()
+	if err != nil {
+		return ReadAssetError{err}
+	}
+
+	if err := checkConflictingModel(a, string(b), models); err != nil {
+		return err
+	}
+
+	// The content is not formatted so that binary assets are copied verbatim
+	models[a.GetPath()] = &File{
+		Path:           a.GetPath(),
+		Contents:       string(b),
+		IfExistsAction: a.GetIfExistsAction(),
+		Permissions:    getPermissions(a),
+		builder:        builderName(a),
+	}
+	return nil
+}
+
+// getPermissions returns the permissions of the file of the builder, or 0 if it does not set them
+func getPermissions(b Builder) os.FileMode {
+	if p, hasPermissions := b.(HasPermissions); hasPermissions {
+		return p.Permissions()
+	}
+	return 0
+}
+
+// skipExistingModel returns true if the file builder must be skipped because a model was already
+// built for its path, or an error if the file builder does not allow to overwrite it
+func skipExistingModel(b Builder, models map[string]*File) (bool, error) {
+	path := b.GetPath()
+	if _, found := models[path]; !found {
+		return false, nil
+	}
+
+	switch b.GetIfExistsAction() {
+	case SkipFile:
+		return true, nil
+	case Error:
+		return false, ModelAlreadyExistsError{path}
+	case OverwriteFile:
+		return false, nil
+	default:
+		return false, UnknownIfExistsActionError{path, b.GetIfExistsAction()}
+	}
+}
+
+// checkConflictingModel returns an error if the file builder overwrites a model which was already built
+// for its path by another builder also requiring to overwrite it, with different contents
+func checkConflictingModel(b Builder, contents string, models map[string]*File) error {
+	m, found := models[b.GetPath()]
+	if !found || m.IfExistsAction != OverwriteFile || b.GetIfExistsAction() != OverwriteFile {
+		return nil
+	}
+	if m.Contents == contents {
+		return nil
+	}
+	return ConflictingBuildersError{path: b.GetPath(), previous: m.builder, builder: builderName(b)}
+}
+
+// builderName returns the name of the type of the builder, used to report conflicting builders
+func builderName(b Builder) string {
+	return fmt.Sprintf("%T", b)
+}
+
 // doTemplate executes the template for a file using the input
 func doTemplate(t Template) ([]byte, error) {
 	// Create a new template.Template using the type of the Template as the name
@@ -210,23 +412,24 @@ func doTemplate(t Template) ([]byte, error) {
 
 	// Set the template body
 	if _, err := temp.Parse(t.GetBody()); err != nil {
-		return nil, err
+		return nil, newTemplateError(t, err)
 	}
 
 	// Execute the template
 	out := &bytes.Buffer{}
 	if err := temp.Execute(out, t); err != nil {
-		return nil, err
+		return nil, newTemplateError(t, err)
 	}
 	b := out.Bytes()
 
 	// TODO(adirio): move go-formatting to write step
 	// gofmt the imports
 	if filepath.Ext(t.GetPath()) == ".go" {
-		var err error
-		if b, err = imports.Process(t.GetPath(), b, &options); err != nil {
-			return nil, err
+		formatted, err := imports.Process(t.GetPath(), b, &options)
+		if err != nil {
+			return nil, newFormatError(t, b, err)
 		}
+		b = formatted
 	}
 
 	return b, nil
@@ -253,7 +456,17 @@ func (s Scaffold) updateFileModel(i Inserter, models map[string]*File) error {
 		return nil
 	}
 
-	content, err := insertStrings(m.Contents, codeFragments)
+	contents := m.Contents
+	// The code fragments of the markers which were removed from the file are inserted by the inserter
+	if fallback, hasFallback := i.(HasMarkerFallback); hasFallback {
+		if missing := extractMissingMarkers(contents, codeFragments); len(missing) != 0 {
+			if contents, err = fallback.InsertWithoutMarkers(contents, missing); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := insertStrings(contents, codeFragments)
 	if err != nil {
 		return err
 	}
@@ -269,6 +482,9 @@ func (s Scaffold) updateFileModel(i Inserter, models map[string]*File) error {
 
 	m.Contents = string(formattedContent)
 	m.IfExistsAction = OverwriteFile
+	if m.builder == "" {
+		m.builder = builderName(i)
+	}
 	models[m.Path] = m
 	return nil
 }
@@ -448,6 +664,26 @@ func scanMultiline(content string, scanLines int, scanFunc func(contentGroup str
 	return scanner.Err()
 }
 
+// extractMissingMarkers removes the code fragments of the markers which are not found in the content
+// from the map, and returns them
+func extractMissingMarkers(content string, codeFragmentsMap CodeFragmentsMap) CodeFragmentsMap {
+	missing := make(CodeFragmentsMap)
+	for marker, codeFragments := range codeFragmentsMap {
+		found := false
+		for _, line := range strings.Split(content, "\n") {
+			if marker.EqualsLine(line) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing[marker] = codeFragments
+			delete(codeFragmentsMap, marker)
+		}
+	}
+	return missing
+}
+
 func insertStrings(content string, codeFragmentsMap CodeFragmentsMap) ([]byte, error) {
 	out := new(bytes.Buffer)
 
@@ -473,6 +709,8 @@ func insertStrings(content string, codeFragmentsMap CodeFragmentsMap) ([]byte, e
 }
 
 func (s Scaffold) writeFile(f *File) (err error) {
+	start := time.Now()
+
 	// Check if the file to write already exists
 	exists, err := afero.Exists(s.fs, f.Path)
 	if err != nil {
@@ -484,6 +722,8 @@ func (s Scaffold) writeFile(f *File) (err error) {
 			// By not returning, the file is written as if it didn't exist
 		case SkipFile:
 			// By returning nil, the file is not written but the process will carry on
+			s.record(f.Path, FileSkipped)
+			s.metrics.observeSkip(f.builder)
 			return nil
 		case Error:
 			// By returning an error, the file is not written and the process will fail
@@ -496,8 +736,13 @@ func (s Scaffold) writeFile(f *File) (err error) {
 		return CreateDirectoryError{err}
 	}
 
+	perm := s.filePerm
+	if f.Permissions != 0 {
+		perm = f.Permissions
+	}
+
 	// Create or truncate the file
-	writer, err := s.fs.OpenFile(f.Path, createOrUpdate, s.filePerm)
+	writer, err := s.fs.OpenFile(f.Path, createOrUpdate, perm)
 	if err != nil {
 		return CreateFileError{err}
 	}
@@ -511,5 +756,26 @@ func (s Scaffold) writeFile(f *File) (err error) {
 		return WriteFileError{err}
 	}
 
+	// The permissions are only set when the file is created, so they are changed for the existing files
+	if exists && f.Permissions != 0 {
+		if err := s.fs.Chmod(f.Path, f.Permissions); err != nil {
+			return WriteFileError{err}
+		}
+	}
+
+	if exists {
+		s.record(f.Path, FileUpdated)
+	} else {
+		s.record(f.Path, FileCreated)
+	}
+	s.metrics.observeWrite(f.builder, len(f.Contents), time.Since(start))
+
 	return nil
 }
+
+// record notifies the recorder, if any, of the action performed on the file
+func (s Scaffold) record(path string, action FileAction) {
+	if s.recorder != nil {
+		s.recorder.RecordFile(path, action)
+	}
+}
@@ -15,8 +15,11 @@ limitations under the License.
 package machinery
 
 import (
+	"bytes"
 	"errors"
 	"os"
+	"strconv"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -104,6 +107,18 @@ var _ = Describe("Scaffold", func() {
 			Expect(s.injector.resource).NotTo(BeNil())
 			Expect(s.injector.resource.GVK.IsEqualTo(res.GVK)).To(BeTrue())
 		})
+
+		It("should succeed with max workers option", func() {
+			s := NewScaffold(Filesystem{FS: afero.NewMemMapFs()}, WithMaxWorkers(3))
+			Expect(s.maxWorkers).To(Equal(3))
+		})
+
+		It("should collect the metrics of the filesystem", func() {
+			metrics := NewMetrics()
+
+			s := NewScaffold(Filesystem{FS: afero.NewMemMapFs(), Metrics: metrics})
+			Expect(s.metrics).To(BeIdenticalTo(metrics))
+		})
 	})
 
 	Describe("Scaffold.Execute", func() {
@@ -161,6 +176,25 @@ var _ = Describe("Scaffold", func() {
 				&fakeTemplate{fakeBuilder: fakeBuilder{path: path, TestField: "testValue"},
 					body: "package [[.TestField]]", parseDelimLeft: "[[", parseDelimRight: "]]"},
 			),
+			Entry("should copy an asset verbatim",
+				pathGo, "\x89PNG\xff{{.TestField}}",
+				&fakeAsset{fakeBuilder: fakeBuilder{path: pathGo}, content: []byte("\x89PNG\xff{{.TestField}}")},
+			),
+			Entry("should skip optional assets if already have one",
+				path, content,
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: content},
+				&fakeAsset{fakeBuilder: fakeBuilder{path: path}, content: []byte("other")},
+			),
+			Entry("should overwrite required assets if already have one",
+				path, content,
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: path}},
+				&fakeAsset{fakeBuilder: fakeBuilder{path: path, ifExistsAction: OverwriteFile}, content: []byte(content)},
+			),
+			Entry("should accept required models with the same contents",
+				path, content,
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: path, ifExistsAction: OverwriteFile}, body: content},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: path, ifExistsAction: OverwriteFile}, body: content},
+			),
 		)
 
 		DescribeTable("file builders related errors",
@@ -177,6 +211,19 @@ var _ = Describe("Scaffold", func() {
 				&SetTemplateDefaultsError{},
 				&fakeTemplate{err: testErr},
 			),
+			Entry("should fail if unable to set default values for an asset",
+				&SetAssetDefaultsError{},
+				&fakeAsset{err: testErr},
+			),
+			Entry("should fail if unable to read an asset",
+				&ReadAssetError{},
+				&fakeAsset{readErr: testErr},
+			),
+			Entry("should fail if an unexpected previous model is found for an asset",
+				&ModelAlreadyExistsError{},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: path}},
+				&fakeAsset{fakeBuilder: fakeBuilder{path: path, ifExistsAction: Error}},
+			),
 			Entry("should fail if an unexpected previous model is found",
 				&ModelAlreadyExistsError{},
 				&fakeTemplate{fakeBuilder: fakeBuilder{path: path}},
@@ -187,8 +234,29 @@ var _ = Describe("Scaffold", func() {
 				&fakeTemplate{fakeBuilder: fakeBuilder{path: path}},
 				&fakeTemplate{fakeBuilder: fakeBuilder{path: path, ifExistsAction: -1}},
 			),
+			Entry("should fail if two required models have different contents",
+				&ConflictingBuildersError{},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: path, ifExistsAction: OverwriteFile}, body: content},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: path, ifExistsAction: OverwriteFile}, body: "other"},
+			),
+			Entry("should fail if a required asset has different contents than a required model",
+				&ConflictingBuildersError{},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: path, ifExistsAction: OverwriteFile}, body: content},
+				&fakeAsset{fakeBuilder: fakeBuilder{path: path, ifExistsAction: OverwriteFile}, content: []byte("other")},
+			),
 		)
 
+		It("should name both builders when they conflict", func() {
+			err := s.Execute(
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: path, ifExistsAction: OverwriteFile}, body: content},
+				&fakeAsset{fakeBuilder: fakeBuilder{path: path, ifExistsAction: OverwriteFile}, content: []byte("other")},
+			)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(path))
+			Expect(err.Error()).To(ContainSubstring("*machinery.fakeTemplate"))
+			Expect(err.Error()).To(ContainSubstring("*machinery.fakeAsset"))
+		})
+
 		// Following errors are unwrapped, so we need to check for substrings
 		DescribeTable("template related errors",
 			func(errMsg string, files ...Builder) {
@@ -210,6 +278,44 @@ var _ = Describe("Scaffold", func() {
 			),
 		)
 
+		DescribeTable("template error diagnostics",
+			func(line int, expected []string, files ...Builder) {
+				err := s.Execute(files...)
+				Expect(err).To(HaveOccurred())
+
+				var tErr TemplateError
+				Expect(errors.As(err, &tErr)).To(BeTrue())
+				Expect(tErr.Builder).To(Equal("*machinery.fakeTemplate"))
+				Expect(tErr.Line).To(Equal(line))
+				for _, msg := range expected {
+					Expect(err.Error()).To(ContainSubstring(msg))
+				}
+			},
+			Entry("should locate the line of a broken template",
+				3,
+				[]string{"failed to render " + path + " with *machinery.fakeTemplate at line 3", "> 3 | {{ .Field }"},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: "first\nsecond\n{{ .Field }\nfourth"},
+			),
+			Entry("should report the missing field of the template",
+				2,
+				[]string{
+					"> 2 | {{ .Missing }}", "  1 | first", "  3 | third",
+					". (machinery.fakeTemplate) has no field or key Missing",
+				},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: "first\n{{ .Missing }}\nthird"},
+			),
+			Entry("should report the value of the evaluated fields",
+				1,
+				[]string{`.TestField (string) has no field or key Missing`},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: path, TestField: "value"}, body: "{{ .TestField.Missing }}"},
+			),
+			Entry("should locate the line of a go file which can not be formatted",
+				2,
+				[]string{"> 2 | func {"},
+				&fakeTemplate{fakeBuilder: fakeBuilder{path: pathGo}, body: "package file\nfunc {\n"},
+			),
+		)
+
 		DescribeTable("insert strings",
 			func(path, input, expected string, files ...Builder) {
 				Expect(afero.WriteFile(s.fs, path, []byte(input), 0o666)).To(Succeed())
@@ -401,6 +507,24 @@ func init() {
 					},
 				},
 			),
+			Entry("should insert the code fragments of missing markers with the fallback",
+				pathYaml,
+				`
+# +kubebuilder:scaffold:-
+`,
+				`
+1
+# +kubebuilder:scaffold:-
+2
+`,
+				fakeMarkerFallback{fakeInserter{
+					fakeBuilder: fakeBuilder{path: pathYaml},
+					codeFragments: CodeFragmentsMap{
+						NewMarkerFor(pathYaml, "-"):       {"1\n"},
+						NewMarkerFor(pathYaml, "missing"): {"2\n"},
+					},
+				}},
+			),
 		)
 
 		DescribeTable("insert strings related errors",
@@ -459,9 +583,220 @@ func init() {
 				Expect(errors.As(err, &FileAlreadyExistsError{})).To(BeTrue())
 			})
 		})
+
+		Context("with permissions", func() {
+			BeforeEach(func() {
+				s.filePerm = defaultFilePermission
+			})
+
+			It("should use the permissions of the scaffold by default", func() {
+				Expect(s.Execute(&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: content})).To(Succeed())
+
+				info, err := s.fs.Stat(path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(defaultFilePermission))
+			})
+
+			It("should use the permissions of the file builder when creating the file", func() {
+				Expect(s.Execute(&fakeTemplate{
+					fakeBuilder: fakeBuilder{path: path},
+					body:        content,
+					permissions: ExecutableFilePermission,
+				})).To(Succeed())
+
+				info, err := s.fs.Stat(path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(ExecutableFilePermission))
+			})
+
+			It("should change the permissions of the file when overwriting it", func() {
+				_ = afero.WriteFile(s.fs, path, []byte{}, defaultFilePermission)
+
+				Expect(s.Execute(&fakeAsset{
+					fakeBuilder: fakeBuilder{path: path, ifExistsAction: OverwriteFile},
+					content:     []byte(content),
+					permissions: ExecutableFilePermission,
+				})).To(Succeed())
+
+				info, err := s.fs.Stat(path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(ExecutableFilePermission))
+			})
+		})
+
+		Context("with a recorder", func() {
+			var recorder *fakeRecorder
+
+			BeforeEach(func() {
+				recorder = &fakeRecorder{actions: make(map[string]FileAction)}
+				s.recorder = recorder
+			})
+
+			It("should record the created, updated and skipped files", func() {
+				const (
+					updatedPath = path + "-updated"
+					skippedPath = path + "-skipped"
+				)
+				_ = afero.WriteFile(s.fs, updatedPath, []byte{}, 0o666)
+				_ = afero.WriteFile(s.fs, skippedPath, []byte{}, 0o666)
+
+				Expect(s.Execute(
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: content},
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: updatedPath, ifExistsAction: OverwriteFile}, body: content},
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: skippedPath}, body: content},
+				)).To(Succeed())
+
+				Expect(recorder.actions).To(Equal(map[string]FileAction{
+					path:        FileCreated,
+					updatedPath: FileUpdated,
+					skippedPath: FileSkipped,
+				}))
+			})
+
+			It("should write the files in the order of the builders", func() {
+				paths := []string{path + "-c", path + "-a", path + "-b"}
+				builders := make([]Builder, 0, len(paths))
+				for _, p := range paths {
+					builders = append(builders, &fakeTemplate{fakeBuilder: fakeBuilder{path: p}, body: content})
+				}
+				// A builder skipped because of a previous model does not change the order
+				builders = append(builders, &fakeTemplate{fakeBuilder: fakeBuilder{path: paths[0]}, body: content})
+
+				Expect(s.Execute(builders...)).To(Succeed())
+				Expect(recorder.order).To(Equal(paths))
+			})
+		})
+
+		Context("with concurrent workers", func() {
+			const files = 20
+
+			BeforeEach(func() {
+				s.maxWorkers = 4
+			})
+
+			It("should write the same files in the order of the builders", func() {
+				builders := make([]Builder, 0, files)
+				for i := range files {
+					builders = append(builders, &fakeTemplate{
+						fakeBuilder: fakeBuilder{path: path + strconv.Itoa(files-i), TestField: strconv.Itoa(i)},
+						body:        "file {{.TestField}}",
+					})
+				}
+				recorder := &fakeRecorder{actions: make(map[string]FileAction)}
+				s.recorder = recorder
+
+				Expect(s.Execute(builders...)).To(Succeed())
+
+				Expect(recorder.order).To(HaveLen(files))
+				for i, p := range recorder.order {
+					Expect(p).To(Equal(path + strconv.Itoa(files-i)))
+					b, err := afero.ReadFile(s.fs, p)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(b)).To(Equal("file " + strconv.Itoa(i)))
+				}
+			})
+
+			It("should return the error of the first failing builder", func() {
+				builders := make([]Builder, 0, files+2)
+				for i := range files {
+					builders = append(builders, &fakeTemplate{fakeBuilder: fakeBuilder{path: path + strconv.Itoa(i)}})
+				}
+				builders = append(builders,
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: pathGo}, body: "{{ .Missing }}"},
+					&fakeTemplate{err: testErr},
+				)
+
+				err := s.Execute(builders...)
+				Expect(err).To(HaveOccurred())
+				var tErr TemplateError
+				Expect(errors.As(err, &tErr)).To(BeTrue())
+				Expect(tErr.Path).To(Equal(pathGo))
+				_, err = s.fs.Stat(path + "0")
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+
+			It("should insert the code fragments in the files built by the preceding builders", func() {
+				Expect(s.Execute(
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: pathGo}, body: "package test\n\n// +kubebuilder:scaffold:-\n"},
+					fakeInserter{
+						fakeBuilder: fakeBuilder{path: pathGo},
+						codeFragments: CodeFragmentsMap{
+							NewMarkerFor(pathGo, "-"): {"var a int\n"},
+						},
+					},
+				)).To(Succeed())
+
+				b, err := afero.ReadFile(s.fs, pathGo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal("package test\n\nvar a int\n\n// +kubebuilder:scaffold:-\n"))
+			})
+		})
+
+		Context("with metrics", func() {
+			BeforeEach(func() {
+				s.metrics = NewMetrics()
+			})
+
+			It("should collect the metrics of the builders", func() {
+				const skippedPath = path + "-skipped"
+				_ = afero.WriteFile(s.fs, skippedPath, []byte{}, 0o666)
+
+				Expect(s.Execute(
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: content},
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: skippedPath}, body: content},
+					&fakeAsset{fakeBuilder: fakeBuilder{path: pathYaml}, content: []byte(content)},
+				)).To(Succeed())
+
+				builders := s.metrics.Builders()
+				Expect(builders).To(HaveLen(2))
+				for _, b := range builders {
+					Expect(b.Duration).To(BeNumerically(">", 0))
+					b.Duration = 0
+					switch b.Builder {
+					case "*machinery.fakeTemplate":
+						Expect(b).To(Equal(BuilderMetrics{Builder: b.Builder, Executions: 2,
+							FilesWritten: 1, BytesWritten: len(content), FilesSkipped: 1}))
+					case "*machinery.fakeAsset":
+						Expect(b).To(Equal(BuilderMetrics{Builder: b.Builder, Executions: 1,
+							FilesWritten: 1, BytesWritten: len(content)}))
+					default:
+						Fail("unexpected builder " + b.Builder)
+					}
+				}
+			})
+
+			It("should write the report of the metrics with their total", func() {
+				Expect(s.Execute(
+					&fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: content},
+					&fakeAsset{fakeBuilder: fakeBuilder{path: pathYaml}, content: []byte(content)},
+				)).To(Succeed())
+
+				out := &bytes.Buffer{}
+				Expect(s.metrics.WriteReport(out)).To(Succeed())
+				lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+				Expect(lines).To(HaveLen(4))
+				Expect(lines[0]).To(HavePrefix("BUILDER"))
+				Expect(strings.Fields(lines[3])).To(HaveExactElements(
+					"TOTAL", "2", Not(BeEmpty()), "2", strconv.Itoa(2*len(content)), "0"))
+			})
+		})
 	})
 })
 
+var _ Recorder = &fakeRecorder{}
+
+// fakeRecorder is used to mock a Recorder
+type fakeRecorder struct {
+	actions map[string]FileAction
+	order   []string
+}
+
+// RecordFile implements Recorder
+func (r *fakeRecorder) RecordFile(path string, action FileAction) {
+	r.actions[path] = action
+	r.order = append(r.order, path)
+}
+
 var _ Builder = fakeBuilder{}
 
 // fakeBuilder is used to mock a Builder
@@ -505,6 +840,12 @@ type fakeTemplate struct {
 	err             error
 	parseDelimLeft  string
 	parseDelimRight string
+	permissions     os.FileMode
+}
+
+// Permissions implements HasPermissions
+func (f *fakeTemplate) Permissions() os.FileMode {
+	return f.permissions
 }
 
 func (f *fakeTemplate) SetDelim(left, right string) {
@@ -530,6 +871,33 @@ func (f *fakeTemplate) SetTemplateDefaults() error {
 	return nil
 }
 
+var _ Asset = &fakeAsset{}
+
+// fakeAsset is used to mock an Asset in order to test Scaffold
+type fakeAsset struct {
+	fakeBuilder
+
+	content     []byte
+	err         error
+	readErr     error
+	permissions os.FileMode
+}
+
+// Permissions implements HasPermissions
+func (f *fakeAsset) Permissions() os.FileMode {
+	return f.permissions
+}
+
+// GetContent implements Asset
+func (f *fakeAsset) GetContent() ([]byte, error) {
+	return f.content, f.readErr
+}
+
+// SetAssetDefaults implements Asset
+func (f *fakeAsset) SetAssetDefaults() error {
+	return f.err
+}
+
 type fakeInserter struct {
 	fakeBuilder
 
@@ -554,3 +922,20 @@ func (f fakeInserter) GetMarkers() []Marker {
 func (f fakeInserter) GetCodeFragments() CodeFragmentsMap {
 	return f.codeFragments
 }
+
+var _ HasMarkerFallback = fakeMarkerFallback{}
+
+// fakeMarkerFallback is used to mock a HasMarkerFallback in order to test Scaffold
+type fakeMarkerFallback struct {
+	fakeInserter
+}
+
+// InsertWithoutMarkers implements HasMarkerFallback by appending the code fragments to the content
+func (f fakeMarkerFallback) InsertWithoutMarkers(content string, codeFragments CodeFragmentsMap) (string, error) {
+	for _, fragments := range codeFragments {
+		for _, fragment := range fragments {
+			content += fragment
+		}
+	}
+	return content, nil
+}
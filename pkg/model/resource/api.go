@@ -27,6 +27,9 @@ type API struct {
 
 	// Namespaced is true if the API is namespaced.
 	Namespaced bool `json:"namespaced,omitempty"`
+
+	// StorageVersion is true if this version of the API is the one stored in etcd.
+	StorageVersion bool `json:"storageVersion,omitempty"`
 }
 
 // Validate checks that the API is valid.
@@ -65,10 +68,13 @@ func (api *API) Update(other *API) error {
 	// Update the namespace.
 	api.Namespaced = api.Namespaced || other.Namespaced
 
+	// Update the storage version.
+	api.StorageVersion = api.StorageVersion || other.StorageVersion
+
 	return nil
 }
 
 // IsEmpty returns if the API's fields all contain zero-values.
 func (api API) IsEmpty() bool {
-	return api.CRDVersion == "" && !api.Namespaced
+	return api.CRDVersion == "" && !api.Namespaced && !api.StorageVersion
 }
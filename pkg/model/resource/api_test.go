@@ -112,6 +112,22 @@ var _ = Describe("API", func() {
 				Expect(api.Namespaced).To(BeFalse())
 			})
 		})
+
+		Context("StorageVersion", func() {
+			It("should set the storage version if provided and not previously set", func() {
+				api = API{}
+				other = API{StorageVersion: true}
+				Expect(api.Update(&other)).To(Succeed())
+				Expect(api.StorageVersion).To(BeTrue())
+			})
+
+			It("should keep the storage version if previously set", func() {
+				api = API{StorageVersion: true}
+				other = API{}
+				Expect(api.Update(&other)).To(Succeed())
+				Expect(api.StorageVersion).To(BeTrue())
+			})
+		})
 	})
 
 	Context("IsEmpty", func() {
@@ -134,6 +150,7 @@ var _ = Describe("API", func() {
 			func(api API) { Expect(api.IsEmpty()).To(BeFalse()) },
 			Entry("cluster-scope", cluster),
 			Entry("namespace-scope", namespaced),
+			Entry("storage version", API{StorageVersion: true}),
 		)
 	})
 })
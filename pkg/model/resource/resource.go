@@ -46,6 +46,10 @@ type Resource struct {
 	// External specifies if the resource is defined externally.
 	External bool `json:"external,omitempty"`
 
+	// Module is the Go module which provides the types of an external resource,
+	// optionally pinned to a version (e.g. example.com/module@v1.0.0).
+	Module string `json:"module,omitempty"`
+
 	// Core specifies if the resource is from Kubernetes API.
 	Core bool `json:"core,omitempty"`
 }
@@ -65,6 +69,17 @@ func (r Resource) Validate() error {
 
 	// TODO: validate the path
 
+	// Validate the Module
+	if r.Module != "" {
+		if !r.External {
+			return fmt.Errorf("invalid Module %q: only external resources can be provided by a module", r.Module)
+		}
+		modulePath, _, _ := strings.Cut(r.Module, "@")
+		if r.Path != modulePath && !strings.HasPrefix(r.Path, modulePath+"/") {
+			return fmt.Errorf("invalid Module %q: the path %q is not a package of the module", r.Module, r.Path)
+		}
+	}
+
 	// Validate the API
 	if r.API != nil && !r.API.IsEmpty() {
 		if err := r.API.Validate(); err != nil {
@@ -125,6 +140,11 @@ func (r Resource) HasConversionWebhook() bool {
 	return r.Webhooks != nil && r.Webhooks.Conversion
 }
 
+// HasValidatingAdmissionPolicy returns true if the resource has an associated ValidatingAdmissionPolicy.
+func (r Resource) HasValidatingAdmissionPolicy() bool {
+	return r.Webhooks != nil && r.Webhooks.ValidatingAdmissionPolicy
+}
+
 // IsExternal returns true if the resource was scaffold as external.
 func (r Resource) IsExternal() bool {
 	return r.External
@@ -175,6 +195,15 @@ func (r *Resource) Update(other Resource) error {
 		}
 	}
 
+	if other.Module != "" && r.Module != other.Module {
+		if r.Module == "" {
+			r.Module = other.Module
+		} else {
+			return fmt.Errorf("unable to update Resource (Module %q) with another with non-matching Module %q",
+				r.Module, other.Module)
+		}
+	}
+
 	// Update API.
 	if r.API == nil && other.API != nil {
 		r.API = &API{}
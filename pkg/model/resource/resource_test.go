@@ -49,6 +49,17 @@ var _ = Describe("Resource", func() {
 			Expect(res.Validate()).To(Succeed())
 		})
 
+		It("should succeed for an external Resource provided by a module", func() {
+			external := Resource{
+				GVK:      gvk,
+				Plural:   plural,
+				Path:     "example.com/module/api/v1",
+				External: true,
+				Module:   "example.com/module@v1.0.0",
+			}
+			Expect(external.Validate()).To(Succeed())
+		})
+
 		DescribeTable("should fail for invalid Resources",
 			func(res Resource) { Expect(res.Validate()).NotTo(Succeed()) },
 			// Ensure that the rest of the fields are valid to check each part
@@ -56,6 +67,12 @@ var _ = Describe("Resource", func() {
 			Entry("invalid Plural", Resource{GVK: gvk, Plural: "Plural"}),
 			Entry("invalid API", Resource{GVK: gvk, Plural: "plural", API: &API{CRDVersion: "1"}}),
 			Entry("invalid Webhooks", Resource{GVK: gvk, Plural: "plural", Webhooks: &Webhooks{WebhookVersion: "1"}}),
+			Entry("module of a non-external Resource", Resource{GVK: gvk, Plural: "plural",
+				Path: "example.com/module/api/v1", Module: "example.com/module"}),
+			Entry("path outside of the module", Resource{GVK: gvk, Plural: "plural",
+				Path: "example.com/other/api/v1", External: true, Module: "example.com/module@v1.0.0"}),
+			Entry("path sharing the prefix of the module", Resource{GVK: gvk, Plural: "plural",
+				Path: "example.com/module-api/v1", External: true, Module: "example.com/module"}),
 		)
 	})
 
@@ -181,6 +198,19 @@ var _ = Describe("Resource", func() {
 			)
 		})
 
+		Context("HasValidatingAdmissionPolicy", func() {
+			It("should return true if the validating admission policy is scaffolded", func() {
+				res := Resource{Webhooks: &Webhooks{ValidatingAdmissionPolicy: true}}
+				Expect(res.HasValidatingAdmissionPolicy()).To(BeTrue())
+			})
+
+			DescribeTable("should return false if the validating admission policy is not scaffolded",
+				func(res Resource) { Expect(res.HasValidatingAdmissionPolicy()).To(BeFalse()) },
+				Entry("nil webhooks", Resource{Webhooks: nil}),
+				Entry("no validating admission policy", Resource{Webhooks: &Webhooks{Validation: true}}),
+			)
+		})
+
 		Context("IsRegularPlural", func() {
 			It("should return true if the regular plural form is used", func() {
 				Expect(res.IsRegularPlural()).To(BeTrue())
@@ -328,6 +358,29 @@ var _ = Describe("Resource", func() {
 			Expect(r.Update(other)).NotTo(Succeed())
 		})
 
+		It("should work for a new module", func() {
+			const module = "example.com/module@v1.0.0"
+			r = Resource{GVK: gvk}
+			other = Resource{
+				GVK:    gvk,
+				Module: module,
+			}
+			Expect(r.Update(other)).To(Succeed())
+			Expect(r.Module).To(Equal(module))
+		})
+
+		It("should fail for different modules", func() {
+			r = Resource{
+				GVK:    gvk,
+				Module: "example.com/module@v1.0.0",
+			}
+			other = Resource{
+				GVK:    gvk,
+				Module: "example.com/module@v2.0.0",
+			}
+			Expect(r.Update(other)).NotTo(Succeed())
+		})
+
 		Context("API", func() {
 			It("should work with nil APIs", func() {
 				r = Resource{GVK: gvk}
@@ -34,6 +34,10 @@ type Webhooks struct {
 	// Conversion specifies if a conversion webhook is associated to the resource.
 	Conversion bool `json:"conversion,omitempty"`
 
+	// ValidatingAdmissionPolicy specifies if a ValidatingAdmissionPolicy, which validates the resource
+	// with CEL expressions in the API server instead of a webhook, is associated to the resource.
+	ValidatingAdmissionPolicy bool `json:"validatingAdmissionPolicy,omitempty"`
+
 	Spoke []string `json:"spoke,omitempty"`
 }
 
@@ -68,11 +72,12 @@ func (webhooks Webhooks) Copy() Webhooks {
 	}
 
 	return Webhooks{
-		WebhookVersion: webhooks.WebhookVersion,
-		Defaulting:     webhooks.Defaulting,
-		Validation:     webhooks.Validation,
-		Conversion:     webhooks.Conversion,
-		Spoke:          spokeCopy,
+		WebhookVersion:            webhooks.WebhookVersion,
+		Defaulting:                webhooks.Defaulting,
+		Validation:                webhooks.Validation,
+		Conversion:                webhooks.Conversion,
+		Spoke:                     spokeCopy,
+		ValidatingAdmissionPolicy: webhooks.ValidatingAdmissionPolicy,
 	}
 }
 
@@ -101,6 +106,9 @@ func (webhooks *Webhooks) Update(other *Webhooks) error {
 	// Update conversion.
 	webhooks.Conversion = webhooks.Conversion || other.Conversion
 
+	// Update validating admission policy.
+	webhooks.ValidatingAdmissionPolicy = webhooks.ValidatingAdmissionPolicy || other.ValidatingAdmissionPolicy
+
 	// Update Spoke (merge without duplicates)
 	if len(other.Spoke) > 0 {
 		existingSpokes := make(map[string]struct{})
@@ -121,7 +129,14 @@ func (webhooks *Webhooks) Update(other *Webhooks) error {
 func (webhooks Webhooks) IsEmpty() bool {
 	return webhooks.WebhookVersion == "" &&
 		!webhooks.Defaulting && !webhooks.Validation &&
-		!webhooks.Conversion && len(webhooks.Spoke) == 0
+		!webhooks.Conversion && len(webhooks.Spoke) == 0 &&
+		!webhooks.ValidatingAdmissionPolicy
+}
+
+// HasWebhookServer returns true if any of the webhooks is served by the webhook server of the manager.
+// The ValidatingAdmissionPolicy is evaluated by the API server instead.
+func (webhooks Webhooks) HasWebhookServer() bool {
+	return webhooks.Defaulting || webhooks.Validation || webhooks.Conversion
 }
 
 // AddSpoke adds a new spoke version to the Webhooks configuration.
@@ -191,6 +191,23 @@ var _ = Describe("Webhooks", func() {
 				Expect(webhook.Conversion).To(BeFalse())
 			})
 		})
+
+		Context("ValidatingAdmissionPolicy", func() {
+			It("should set the validating admission policy if provided and not previously set", func() {
+				webhook = Webhooks{}
+				other = Webhooks{ValidatingAdmissionPolicy: true}
+				Expect(webhook.Update(&other)).To(Succeed())
+				Expect(webhook.ValidatingAdmissionPolicy).To(BeTrue())
+			})
+
+			It("should keep the validating admission policy if previously set", func() {
+				webhook = Webhooks{ValidatingAdmissionPolicy: true}
+				other = Webhooks{Defaulting: true}
+				Expect(webhook.Update(&other)).To(Succeed())
+				Expect(webhook.ValidatingAdmissionPolicy).To(BeTrue())
+				Expect(webhook.Defaulting).To(BeTrue())
+			})
+		})
 	})
 
 	Context("IsEmpty", func() {
@@ -232,6 +249,10 @@ var _ = Describe("Webhooks", func() {
 				Validation:     true,
 				Conversion:     true,
 			}
+			validatingAdmissionPolicy = Webhooks{
+				WebhookVersion:            "v1",
+				ValidatingAdmissionPolicy: true,
+			}
 			all = Webhooks{
 				WebhookVersion: "v1",
 				Defaulting:     true,
@@ -253,6 +274,19 @@ var _ = Describe("Webhooks", func() {
 			Entry("defaulting and conversion", defaultingAndConversion),
 			Entry("validation and conversion", validationAndConversion),
 			Entry("defaulting and validation and conversion", all),
+			Entry("validating admission policy", validatingAdmissionPolicy),
 		)
 	})
+
+	Context("HasWebhookServer", func() {
+		It("should return true if a webhook is served by the manager", func() {
+			Expect(Webhooks{Validation: true, ValidatingAdmissionPolicy: true}.HasWebhookServer()).To(BeTrue())
+			Expect(Webhooks{Conversion: true}.HasWebhookServer()).To(BeTrue())
+		})
+
+		It("should return false if only the validating admission policy is scaffolded", func() {
+			Expect(Webhooks{ValidatingAdmissionPolicy: true}.HasWebhookServer()).To(BeFalse())
+			Expect(Webhooks{}.HasWebhookServer()).To(BeFalse())
+		})
+	})
 })
@@ -29,6 +29,7 @@ type bundle struct {
 
 	supportedProjectVersions []config.Version
 	deprecateWarning         string
+	replacedBy               string
 }
 
 // BundleOption define the options to create the bundle
@@ -63,6 +64,13 @@ func WithDeprecationMessage(msg string) BundleOption {
 
 }
 
+// WithReplacement allow set the key of the plugin which replaces the deprecated Bundle Plugin
+func WithReplacement(key string) BundleOption {
+	return func(opts *bundle) {
+		opts.replacedBy = key
+	}
+}
+
 // NewBundleWithOptions creates a new Bundle with the provided BundleOptions.
 // The list of supported project versions is computed from the provided plugins in options.
 func NewBundleWithOptions(opts ...BundleOption) (Bundle, error) {
@@ -95,6 +103,7 @@ func NewBundleWithOptions(opts ...BundleOption) (Bundle, error) {
 		plugins:                  allPlugins,
 		supportedProjectVersions: supportedProjectVersions,
 		deprecateWarning:         bundleOpts.deprecateWarning,
+		replacedBy:               bundleOpts.replacedBy,
 	}, nil
 }
 
@@ -122,3 +131,13 @@ func (b bundle) Plugins() []Plugin {
 func (b bundle) DeprecationWarning() string {
 	return b.deprecateWarning
 }
+
+// Deprecated implements HasDeprecation
+func (b bundle) Deprecated() (bool, string) {
+	return b.deprecateWarning != "", b.deprecateWarning
+}
+
+// ReplacedBy implements Replaceable
+func (b bundle) ReplacedBy() string {
+	return b.replacedBy
+}
@@ -207,5 +207,24 @@ var _ = Describe("Bundle", func() {
 				Expect(err).To(HaveOccurred())
 			}
 		})
+
+		It("should report the deprecation and the successor", func() {
+			const (
+				deprecation = "use another bundle"
+				replacement = "bundle.example.com/v2"
+			)
+
+			b, err := NewBundleWithOptions(WithName(name),
+				WithVersion(version),
+				WithDeprecationMessage(deprecation),
+				WithReplacement(replacement),
+				WithPlugins(p1, p2),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			deprecated, warning := IsDeprecated(b)
+			Expect(deprecated).To(BeTrue())
+			Expect(warning).To(Equal(deprecation))
+			Expect(ReplacedBy(b)).To(Equal(replacement))
+		})
 	})
 })
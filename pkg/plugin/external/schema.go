@@ -0,0 +1,40 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import _ "embed"
+
+// SchemaVersion is the version of the PluginRequest and PluginResponse described by the JSON schemas
+const SchemaVersion = "v1alpha1"
+
+//go:embed plugin_request.json
+var pluginRequestSchema []byte
+
+//go:embed plugin_response.json
+var pluginResponseSchema []byte
+
+// PluginRequestSchema returns the JSON schema of the PluginRequest, so that the external plugins written in
+// other languages than Go can validate the requests or generate their types.
+func PluginRequestSchema() []byte {
+	return append([]byte(nil), pluginRequestSchema...)
+}
+
+// PluginResponseSchema returns the JSON schema of the PluginResponse. The names of the properties of the flags
+// are lower-cased, as Kubebuilder decodes them regardless of their case.
+func PluginResponseSchema() []byte {
+	return append([]byte(nil), pluginResponseSchema...)
+}
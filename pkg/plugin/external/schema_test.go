@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// schemaProperties returns the names of the properties of a JSON schema
+func schemaProperties(schema []byte) []string {
+	var document struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	Expect(json.Unmarshal(schema, &document)).To(Succeed())
+
+	properties := make([]string, 0, len(document.Properties))
+	for name := range document.Properties {
+		properties = append(properties, name)
+	}
+	return properties
+}
+
+// jsonFields returns the names of the JSON fields of a struct
+func jsonFields(value interface{}) []string {
+	t := reflect.TypeOf(value)
+	fields := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+var _ = Describe("Schemas", func() {
+	It("should describe the fields of the PluginRequest", func() {
+		Expect(schemaProperties(PluginRequestSchema())).To(ConsistOf(jsonFields(PluginRequest{})))
+	})
+
+	It("should describe the fields of the PluginResponse", func() {
+		Expect(schemaProperties(PluginResponseSchema())).To(ConsistOf(jsonFields(PluginResponse{})))
+	})
+
+	It("should describe the version of the requests sent by Kubebuilder", func() {
+		Expect(string(PluginRequestSchema())).To(ContainSubstring(`"enum": ["` + SchemaVersion + `"]`))
+		Expect(string(PluginResponseSchema())).To(ContainSubstring(`"enum": ["` + SchemaVersion + `"]`))
+	})
+
+	It("should return a copy of the schemas", func() {
+		schema := PluginRequestSchema()
+		schema[0] = 'x'
+		Expect(PluginRequestSchema()[0]).To(Equal(byte('{')))
+	})
+})
@@ -40,6 +40,31 @@ func SplitKey(key string) (string, string) {
 	return keyParts[0], keyParts[1]
 }
 
+// IsDeprecated returns whether a Plugin is deprecated and its deprecation message.
+// Both the HasDeprecation and the Deprecated interfaces are supported.
+func IsDeprecated(p Plugin) (bool, string) {
+	if d, hasDeprecation := p.(HasDeprecation); hasDeprecation {
+		return d.Deprecated()
+	}
+	if d, isDeprecated := p.(Deprecated); isDeprecated {
+		warning := d.DeprecationWarning()
+		return warning != "", warning
+	}
+	return false, ""
+}
+
+// ReplacedBy returns the key of the plugin which replaces a deprecated Plugin,
+// or an empty string if the plugin is not deprecated or has no successor.
+func ReplacedBy(p Plugin) string {
+	if deprecated, _ := IsDeprecated(p); !deprecated {
+		return ""
+	}
+	if r, isReplaceable := p.(Replaceable); isReplaceable {
+		return r.ReplacedBy()
+	}
+	return ""
+}
+
 // Validate ensures a Plugin is valid.
 func Validate(p Plugin) error {
 	if err := validateName(p.Name()); err != nil {
@@ -101,6 +101,36 @@ var _ = Describe("Validate", func() {
 	)
 })
 
+var _ = Describe("IsDeprecated", func() {
+	const deprecation = "use another plugin"
+
+	DescribeTable("should report the deprecation",
+		func(p Plugin, deprecated bool, message string) {
+			isDeprecated, warning := IsDeprecated(p)
+			Expect(isDeprecated).To(Equal(deprecated))
+			Expect(warning).To(Equal(message))
+		},
+		Entry("for plugins without deprecation", mockPlugin{}, false, ""),
+		Entry("for deprecated plugins", mockDeprecatedPlugin{deprecated: true, deprecation: deprecation},
+			true, deprecation),
+		Entry("for non-deprecated plugins", mockDeprecatedPlugin{}, false, ""),
+		Entry("for deprecated plugins with a deprecation warning",
+			mockLegacyDeprecatedPlugin{deprecation: deprecation}, true, deprecation),
+		Entry("for non-deprecated plugins with a deprecation warning", mockLegacyDeprecatedPlugin{}, false, ""),
+	)
+})
+
+var _ = Describe("ReplacedBy", func() {
+	const replacement = "go.example.com/v2"
+
+	DescribeTable("should return the successor",
+		func(p Plugin, expected string) { Expect(ReplacedBy(p)).To(Equal(expected)) },
+		Entry("for deprecated plugins", mockDeprecatedPlugin{deprecated: true, replacedBy: replacement}, replacement),
+		Entry("for non-deprecated plugins", mockDeprecatedPlugin{replacedBy: replacement}, ""),
+		Entry("for plugins without successor", mockLegacyDeprecatedPlugin{deprecation: "deprecated"}, ""),
+	)
+})
+
 var _ = Describe("ValidateKey", func() {
 	It("should succeed for valid keys", func() {
 		Expect(ValidateKey(key)).To(Succeed())
@@ -16,10 +16,18 @@ limitations under the License.
 
 package plugin
 
+import (
+	"slices"
+
+	"github.com/spf13/pflag"
+)
+
 // CLIMetadata is the runtime meta-data of the CLI
 type CLIMetadata struct {
 	// CommandName is the root command name.
 	CommandName string
+	// CliVersion is the version of the CLI, e.g. v4.6.0. It may be empty when the CLI does not provide it.
+	CliVersion string
 }
 
 // SubcommandMetadata is the runtime meta-data for a subcommand
@@ -28,4 +36,56 @@ type SubcommandMetadata struct {
 	Description string
 	// Examples are one or more examples of the command-line usage of this command. It is used to display help.
 	Examples string
+	// Flags describe the allowed values and the deprecation of the flags bound by the plugins. The CLI completes
+	// them with the name, type, default and usage of the flags, renders the allowed values in the help, offers
+	// them as completions and marks the deprecated flags.
+	Flags []FlagMetadata
+}
+
+// FlagMetadata is the meta-data of a flag of a subcommand
+type FlagMetadata struct {
+	// Name is the name of the flag, without the leading dashes.
+	Name string `json:"name"`
+	// Type is the type of the value of the flag, e.g. string, bool or stringSlice.
+	Type string `json:"type,omitempty"`
+	// Default is the default value of the flag.
+	Default string `json:"default,omitempty"`
+	// Usage is the description of the flag.
+	Usage string `json:"usage,omitempty"`
+	// Enum are the allowed values of the flag, or of each of its elements for a list. Any value is allowed if empty.
+	Enum []string `json:"enum,omitempty"`
+	// Deprecated is the deprecation message of the flag. The flag is not deprecated if empty.
+	Deprecated string `json:"deprecated,omitempty"`
+}
+
+// FlagsMetadata returns the meta-data of the flags of the flag set, sorted by name, with the allowed values and
+// the deprecation of the declared flags. Hidden flags are omitted unless they are deprecated.
+func FlagsMetadata(fs *pflag.FlagSet, declared []FlagMetadata) []FlagMetadata {
+	var flags []FlagMetadata
+	fs.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden && f.Deprecated == "" {
+			return
+		}
+
+		flag := FlagMetadata{
+			Name:       f.Name,
+			Type:       f.Value.Type(),
+			Default:    f.DefValue,
+			Usage:      f.Usage,
+			Deprecated: f.Deprecated,
+		}
+		for _, d := range declared {
+			if d.Name != f.Name {
+				continue
+			}
+			if len(d.Enum) != 0 {
+				flag.Enum = slices.Clone(d.Enum)
+			}
+			if d.Deprecated != "" {
+				flag.Deprecated = d.Deprecated
+			}
+		}
+		flags = append(flags, flag)
+	})
+	return flags
 }
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("FlagsMetadata", func() {
+	var fs *pflag.FlagSet
+
+	BeforeEach(func() {
+		fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.String("license", "apache2", "license of the project")
+		fs.Bool("make", true, "run make")
+		fs.StringSlice("watch", nil, "watched resources")
+		fs.Bool("legacy", false, "use the legacy path")
+		fs.String("internal", "", "internal flag")
+		Expect(fs.MarkHidden("internal")).To(Succeed())
+	})
+
+	It("should describe the flags of the flag set sorted by name", func() {
+		Expect(FlagsMetadata(fs, nil)).To(Equal([]FlagMetadata{
+			{Name: "legacy", Type: "bool", Default: "false", Usage: "use the legacy path"},
+			{Name: "license", Type: "string", Default: "apache2", Usage: "license of the project"},
+			{Name: "make", Type: "bool", Default: "true", Usage: "run make"},
+			{Name: "watch", Type: "stringSlice", Default: "[]", Usage: "watched resources"},
+		}))
+	})
+
+	It("should add the allowed values and the deprecation of the declared flags", func() {
+		flags := FlagsMetadata(fs, []FlagMetadata{
+			{Name: "license", Enum: []string{"apache2", "none"}},
+			{Name: "legacy", Deprecated: "it will be removed"},
+			{Name: "unknown", Enum: []string{"a"}},
+		})
+		Expect(flags).To(HaveLen(4))
+		Expect(flags[0].Deprecated).To(Equal("it will be removed"))
+		Expect(flags[1].Enum).To(Equal([]string{"apache2", "none"}))
+		Expect(flags[2].Enum).To(BeEmpty())
+	})
+
+	It("should keep the hidden flags which are deprecated", func() {
+		Expect(fs.MarkDeprecated("internal", "use --other")).To(Succeed())
+
+		flags := FlagsMetadata(fs, nil)
+		Expect(flags).To(HaveLen(5))
+		Expect(flags[0]).To(Equal(FlagMetadata{
+			Name: "internal", Type: "string", Usage: "internal flag", Deprecated: "use --other",
+		}))
+	})
+})
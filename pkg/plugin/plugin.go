@@ -36,11 +36,26 @@ type Plugin interface {
 }
 
 // Deprecated is an interface that defines the messages for plugins that are deprecated.
+//
+// Plugins should implement HasDeprecation instead, which is preferred when both are implemented.
 type Deprecated interface {
 	// DeprecationWarning returns a string indicating a plugin is deprecated.
 	DeprecationWarning() string
 }
 
+// HasDeprecation is an interface for plugins that report whether they are deprecated.
+type HasDeprecation interface {
+	// Deprecated returns true if the plugin is deprecated, along with a message explaining
+	// why and how to migrate away from it.
+	Deprecated() (bool, string)
+}
+
+// Replaceable is an interface for deprecated plugins that have a successor.
+type Replaceable interface {
+	// ReplacedBy returns the key of the plugin that replaces this one, or an empty string if there is none.
+	ReplacedBy() string
+}
+
 // Init is an interface for plugins that provide an `init` subcommand.
 type Init interface {
 	Plugin
@@ -55,6 +70,13 @@ type CreateAPI interface {
 	GetCreateAPISubcommand() CreateAPISubcommand
 }
 
+// CreateController is an interface for plugins that provide a `create controller` subcommand.
+type CreateController interface {
+	Plugin
+	// GetCreateControllerSubcommand returns the underlying CreateControllerSubcommand interface.
+	GetCreateControllerSubcommand() CreateControllerSubcommand
+}
+
 // CreateWebhook is an interface for plugins that provide a `create webhook` subcommand.
 type CreateWebhook interface {
 	Plugin
@@ -82,6 +82,12 @@ type CreateAPISubcommand interface {
 	RequiresResource
 }
 
+// CreateControllerSubcommand is an interface that represents a `create controller` subcommand.
+type CreateControllerSubcommand interface {
+	Subcommand
+	RequiresResource
+}
+
 // CreateWebhookSubcommand is an interface that represents a `create wekbhook` subcommand.
 type CreateWebhookSubcommand interface {
 	Subcommand
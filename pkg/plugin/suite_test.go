@@ -39,3 +39,20 @@ type mockPlugin struct {
 func (p mockPlugin) Name() string                               { return p.name }
 func (p mockPlugin) Version() Version                           { return p.version }
 func (p mockPlugin) SupportedProjectVersions() []config.Version { return p.supportedProjectVersions }
+
+type mockLegacyDeprecatedPlugin struct {
+	mockPlugin
+	deprecation string
+}
+
+func (p mockLegacyDeprecatedPlugin) DeprecationWarning() string { return p.deprecation }
+
+type mockDeprecatedPlugin struct {
+	mockPlugin
+	deprecated  bool
+	deprecation string
+	replacedBy  string
+}
+
+func (p mockDeprecatedPlugin) Deprecated() (bool, string) { return p.deprecated, p.deprecation }
+func (p mockDeprecatedPlugin) ReplacedBy() string         { return p.replacedBy }
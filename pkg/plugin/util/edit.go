@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// EditOption configures how the file editing helpers, such as InsertCode, ReplaceInFile
+// and UncommentCode, find their target and apply the edit.
+type EditOption func(*editOptions)
+
+type editOptions struct {
+	// regex indicates whether the target is a regular expression instead of a literal string
+	regex bool
+	// idempotent indicates whether an edit already applied to the file is a no-op
+	idempotent bool
+	// dryRun is the writer of the diff of the edit, which is not written to the file when set
+	dryRun io.Writer
+}
+
+// WithRegex interprets the target of the edit as a regular expression instead of a literal string.
+// The edit is applied to the first match of the expression, or to all of them for ReplaceInFile,
+// whose new value can refer to the submatches with $1 or ${name}.
+func WithRegex() EditOption {
+	return func(o *editOptions) {
+		o.regex = true
+	}
+}
+
+// WithIdempotency makes an edit already applied to the file a no-op, so that it can be run again safely:
+// InsertCode does not insert the code again after the target, and ReplaceInFile and UncommentCode
+// do not fail when their target is not found anymore but their result is.
+func WithIdempotency() EditOption {
+	return func(o *editOptions) {
+		o.idempotent = true
+	}
+}
+
+// WithDryRun writes the unified diff of the edit to w instead of writing the file.
+func WithDryRun(w io.Writer) EditOption {
+	return func(o *editOptions) {
+		o.dryRun = w
+	}
+}
+
+// newEditOptions returns the options of an edit
+func newEditOptions(opts []EditOption) editOptions {
+	o := editOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// findTarget returns the indexes of the first match of the target in the content, or nil if it is not found
+func (o editOptions) findTarget(content, target string) ([]int, error) {
+	if o.regex {
+		matcher, err := regexp.Compile(target)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %w", target, err)
+		}
+		return matcher.FindStringIndex(content), nil
+	}
+
+	idx := strings.Index(content, target)
+	if idx < 0 {
+		return nil, nil
+	}
+	return []int{idx, idx + len(target)}, nil
+}
+
+// editFile applies the edit to the content of the file, and writes the result to the file,
+// keeping its permissions, or its diff to the dry-run writer
+func editFile(path string, o editOptions, edit func(content string) (string, error)) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	//nolint:gosec // false positive
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	out, err := edit(string(content))
+	if err != nil {
+		return err
+	}
+	if out == string(content) {
+		return nil
+	}
+
+	if o.dryRun != nil {
+		_, err := io.WriteString(o.dryRun, UnifiedDiff(path, string(content), out))
+		return err
+	}
+	return os.WriteFile(path, []byte(out), info.Mode())
+}
+
+// diffContextLines is the number of unchanged lines shown around the changes of a diff
+const diffContextLines = 3
+
+// UnifiedDiff returns the unified diff between the before and after contents of the file at path,
+// or an empty string if they are equal.
+func UnifiedDiff(path, before, after string) string {
+	if before == after {
+		return ""
+	}
+	a, b := splitLines(before), splitLines(after)
+	ops := diffLines(a, b)
+
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "--- %s\n+++ %s\n", path, path)
+
+	// Group the operations in hunks, with the unchanged lines around the changes as context
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+		first := max(start-diffContextLines, 0)
+		end := start
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			// Close the hunk when the next change is too far away
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*diffContextLines {
+				end = min(end+diffContextLines, len(ops))
+				break
+			}
+			end = next
+		}
+
+		aStart, bStart := ops[first].aLine, ops[first].bLine
+		aCount, bCount := 0, 0
+		for _, op := range ops[first:end] {
+			if op.kind != '+' {
+				aCount++
+			}
+			if op.kind != '-' {
+				bCount++
+			}
+		}
+		fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", aStart+1, aCount, bStart+1, bCount)
+		for _, op := range ops[first:end] {
+			fmt.Fprintf(out, "%c%s\n", op.kind, op.text)
+		}
+		start = end
+	}
+	return out.String()
+}
+
+// diffOp is a line of a diff, unchanged (' '), removed ('-') or added ('+')
+type diffOp struct {
+	kind  byte
+	text  string
+	aLine int
+	bLine int
+}
+
+// diffLines returns the operations transforming the lines a into the lines b,
+// from their longest common subsequence
+func diffLines(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', text: a[i], aLine: i, bLine: j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{kind: '-', text: a[i], aLine: i, bLine: j})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', text: b[j], aLine: i, bLine: j})
+			j++
+		}
+	}
+	return ops
+}
+
+// splitLines splits the content in lines, without the trailing empty line of a final newline
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
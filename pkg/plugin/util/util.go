@@ -63,19 +63,24 @@ func GetNonEmptyLines(output string) []string {
 }
 
 // InsertCode searches target content in the file and insert `toInsert` after the target.
-func InsertCode(filename, target, code string) error {
-	//nolint:gosec // false positive
-	contents, err := os.ReadFile(filename)
-	if err != nil {
-		return err
-	}
-	idx := strings.Index(string(contents), target)
-	if idx == -1 {
-		return fmt.Errorf("string %s not found in %s", target, string(contents))
-	}
-	out := string(contents[:idx+len(target)]) + code + string(contents[idx+len(target):])
-	//nolint:gosec // false positive
-	return os.WriteFile(filename, []byte(out), 0644)
+// The target is a literal string unless WithRegex is set, and the code is not inserted
+// again if it already follows the target when WithIdempotency is set.
+func InsertCode(filename, target, code string, opts ...EditOption) error {
+	o := newEditOptions(opts)
+	return editFile(filename, o, func(content string) (string, error) {
+		match, err := o.findTarget(content, target)
+		if err != nil {
+			return "", err
+		}
+		if match == nil {
+			return "", fmt.Errorf("string %s not found in %s", target, content)
+		}
+		idx := match[1]
+		if o.idempotent && strings.HasPrefix(content[idx:], code) {
+			return content, nil
+		}
+		return content[:idx] + code + content[idx:], nil
+	})
 }
 
 // InsertCodeIfNotExist insert code if it does not already exists
@@ -126,49 +131,32 @@ func AppendCodeAtTheEnd(filename, code string) error {
 
 // UncommentCode searches for target in the file and remove the comment prefix
 // of the target content. The target content may span multiple lines.
-func UncommentCode(filename, target, prefix string) error {
-	//nolint:gosec // false positive
-	content, err := os.ReadFile(filename)
-	if err != nil {
-		return err
-	}
-	strContent := string(content)
-
-	idx := strings.Index(strContent, target)
-	if idx < 0 {
-		return fmt.Errorf("unable to find the code %s to be uncomment", target)
-	}
-
-	out := new(bytes.Buffer)
-	_, err = out.Write(content[:idx])
-	if err != nil {
-		return err
-	}
-
-	scanner := bufio.NewScanner(bytes.NewBufferString(target))
-	if !scanner.Scan() {
-		return nil
-	}
-	for {
-		_, err := out.WriteString(strings.TrimPrefix(scanner.Text(), prefix))
+// The target is a literal string unless WithRegex is set, and an already uncommented
+// target is not an error when WithIdempotency is set.
+func UncommentCode(filename, target, prefix string, opts ...EditOption) error {
+	o := newEditOptions(opts)
+	return editFile(filename, o, func(content string) (string, error) {
+		match, err := o.findTarget(content, target)
 		if err != nil {
-			return err
-		}
-		// Avoid writing a newline in case the previous line was the last in target.
-		if !scanner.Scan() {
-			break
+			return "", err
 		}
-		if _, err := out.WriteString("\n"); err != nil {
-			return err
+		if match == nil {
+			if o.idempotent && (o.regex || strings.Contains(content, uncomment(target, prefix))) {
+				return content, nil
+			}
+			return "", fmt.Errorf("unable to find the code %s to be uncomment", target)
 		}
-	}
+		return content[:match[0]] + uncomment(content[match[0]:match[1]], prefix) + content[match[1]:], nil
+	})
+}
 
-	_, err = out.Write(content[idx+len(target):])
-	if err != nil {
-		return err
+// uncomment removes the comment prefix of each line of the code
+func uncomment(code, prefix string) string {
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, prefix)
 	}
-	//nolint:gosec // false positive
-	return os.WriteFile(filename, out.Bytes(), 0644)
+	return strings.Join(lines, "\n")
 }
 
 // CommentCode searches for target in the file and adds the comment prefix
@@ -222,25 +210,27 @@ func EnsureExistAndReplace(input, match, replace string) (string, error) {
 }
 
 // ReplaceInFile replaces all instances of old with new in the file at path.
-func ReplaceInFile(path, oldValue, newValue string) error {
-	info, err := os.Stat(path)
-	if err != nil {
-		return err
-	}
-	//nolint:gosec // false positive
-	b, err := os.ReadFile(path)
-	if err != nil {
-		return err
-	}
-	if !strings.Contains(string(b), oldValue) {
-		return errors.New("unable to find the content to be replaced")
-	}
-	s := strings.Replace(string(b), oldValue, newValue, -1)
-	err = os.WriteFile(path, []byte(s), info.Mode())
-	if err != nil {
-		return err
-	}
-	return nil
+// The old value is a literal string unless WithRegex is set, in which case the new value
+// can refer to its submatches. A file which already has the new value instead of the old one
+// is not an error when WithIdempotency is set.
+func ReplaceInFile(path, oldValue, newValue string, opts ...EditOption) error {
+	o := newEditOptions(opts)
+	return editFile(path, o, func(content string) (string, error) {
+		match, err := o.findTarget(content, oldValue)
+		if err != nil {
+			return "", err
+		}
+		if match == nil {
+			if o.idempotent && (o.regex || strings.Contains(content, newValue)) {
+				return content, nil
+			}
+			return "", errors.New("unable to find the content to be replaced")
+		}
+		if o.regex {
+			return regexp.MustCompile(oldValue).ReplaceAllString(content, newValue), nil
+		}
+		return strings.ReplaceAll(content, oldValue, newValue), nil
+	})
 }
 
 // ReplaceRegexInFile finds all strings that match `match` and replaces them
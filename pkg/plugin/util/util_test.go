@@ -17,6 +17,8 @@ limitations under the License.
 package util
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -65,6 +67,116 @@ var _ = Describe("Cover plugin util helpers", func() {
 		)
 	})
 
+	Describe("file editing", func() {
+		var path string
+
+		writeFile := func(content string) {
+			Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		}
+		readFile := func() string {
+			content, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			return string(content)
+		}
+
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "main.go")
+		})
+
+		Context("InsertCode", func() {
+			It("should insert the code after the first match of a regular expression", func() {
+				writeFile("func main() {\n\tsetup := 1\n}\n")
+				Expect(InsertCode(path, `setup := \d+\n`, "\tsetup++\n", WithRegex())).To(Succeed())
+				Expect(readFile()).To(Equal("func main() {\n\tsetup := 1\n\tsetup++\n}\n"))
+			})
+
+			It("should not insert the code twice with idempotency", func() {
+				writeFile("// +marker\n")
+				for range 2 {
+					Expect(InsertCode(path, "// +marker\n", "code\n", WithIdempotency())).To(Succeed())
+				}
+				Expect(readFile()).To(Equal("// +marker\ncode\n"))
+			})
+
+			It("should fail with an invalid regular expression", func() {
+				writeFile("content")
+				Expect(InsertCode(path, "(", "code", WithRegex())).NotTo(Succeed())
+			})
+
+			It("should keep the permissions of the file", func() {
+				writeFile("target")
+				Expect(os.Chmod(path, 0o755)).To(Succeed())
+				Expect(InsertCode(path, "target", "code")).To(Succeed())
+				info, err := os.Stat(path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o755)))
+			})
+		})
+
+		Context("ReplaceInFile", func() {
+			It("should replace all the matches of a regular expression with its submatches", func() {
+				writeFile("image: controller:v1\nother: controller:v2\n")
+				Expect(ReplaceInFile(path, `controller:(v\d)`, "manager:$1", WithRegex())).To(Succeed())
+				Expect(readFile()).To(Equal("image: manager:v1\nother: manager:v2\n"))
+			})
+
+			It("should fail if the old value is not found", func() {
+				writeFile("new")
+				Expect(ReplaceInFile(path, "old", "new")).NotTo(Succeed())
+			})
+
+			It("should succeed if the old value was already replaced with idempotency", func() {
+				writeFile("new")
+				Expect(ReplaceInFile(path, "old", "new", WithIdempotency())).To(Succeed())
+				Expect(readFile()).To(Equal("new"))
+			})
+		})
+
+		Context("UncommentCode", func() {
+			It("should uncomment each line of the target", func() {
+				writeFile("resources:\n#- ../crd\n#- ../rbac\n")
+				Expect(UncommentCode(path, "#- ../crd\n#- ../rbac", "#")).To(Succeed())
+				Expect(readFile()).To(Equal("resources:\n- ../crd\n- ../rbac\n"))
+			})
+
+			It("should uncomment the first match of a regular expression", func() {
+				writeFile("#- ../prometheus\n#- ../network-policy\n")
+				Expect(UncommentCode(path, `#- \.\./network-\w+`, "#", WithRegex())).To(Succeed())
+				Expect(readFile()).To(Equal("#- ../prometheus\n- ../network-policy\n"))
+			})
+
+			It("should succeed if the code was already uncommented with idempotency", func() {
+				writeFile("- ../crd\n")
+				Expect(UncommentCode(path, "#- ../crd", "#")).NotTo(Succeed())
+				Expect(UncommentCode(path, "#- ../crd", "#", WithIdempotency())).To(Succeed())
+				Expect(readFile()).To(Equal("- ../crd\n"))
+			})
+		})
+
+		It("should write the diff of the edit instead of the file with dry run", func() {
+			writeFile("line 1\nline 2\nline 3\n")
+			out := &bytes.Buffer{}
+			Expect(ReplaceInFile(path, "line 2", "second line", WithDryRun(out))).To(Succeed())
+			Expect(readFile()).To(Equal("line 1\nline 2\nline 3\n"))
+			Expect(out.String()).To(Equal(fmt.Sprintf("--- %[1]s\n+++ %[1]s\n@@ -1,3 +1,3 @@\n"+
+				" line 1\n-line 2\n+second line\n line 3\n", path)))
+		})
+	})
+
+	Describe("UnifiedDiff", func() {
+		It("should return an empty diff for equal contents", func() {
+			Expect(UnifiedDiff("file", "a\nb\n", "a\nb\n")).To(BeEmpty())
+		})
+
+		It("should split the changes which are far apart in several hunks", func() {
+			before := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+			after := "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n"
+			Expect(UnifiedDiff("file", before, after)).To(Equal("--- file\n+++ file\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+				"@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n"))
+		})
+	})
+
 	Describe("RandomSuffix", func() {
 		It("should return a string with 4 caracteres", func() {
 			suffix, err := RandomSuffix()
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render builds the kustomizations of a project, such as config/default, so that the plugins
+// which need the manifests of the project, e.g. to package or validate them, get the same output as
+// 'kustomize build' instead of reading the files under config/ themselves.
+package render
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultKustomization is the kustomization which deploys the project
+var DefaultKustomization = filepath.Join("config", "default")
+
+// Renderer builds the kustomizations of a project in-process with the kustomize API, so that no kustomize
+// binary is required
+type Renderer struct {
+	// Dir is the root directory of the project, the current directory is used if it is empty
+	Dir string
+	// FS is the filesystem the kustomizations are read from, the disk is used if it is nil
+	FS filesys.FileSystem
+}
+
+// Object is a Kubernetes object rendered by kustomize
+type Object struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace,omitempty"`
+	} `json:"metadata"`
+
+	// Manifest is the YAML manifest of the object
+	Manifest string `json:"-"`
+}
+
+// Build returns the manifests rendered from the kustomization of the directory, relative to the root of
+// the project, e.g. config/default. They are built with the same options as 'kustomize build'.
+func (r Renderer) Build(kustomization string) ([]byte, error) {
+	fSys := r.FS
+	if fSys == nil {
+		fSys = filesys.MakeFsOnDisk()
+	}
+
+	resources, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fSys, filepath.Join(r.Dir, kustomization))
+	if err != nil {
+		return nil, fmt.Errorf("unable to build %s: %w", kustomization, err)
+	}
+	manifests, err := resources.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode the manifests of %s: %w", kustomization, err)
+	}
+	return manifests, nil
+}
+
+// BuildObjects returns the objects rendered from the kustomization of the directory, relative to the root
+// of the project, e.g. config/default
+func (r Renderer) BuildObjects(kustomization string) ([]Object, error) {
+	manifests, err := r.Build(kustomization)
+	if err != nil {
+		return nil, err
+	}
+	return Objects(manifests)
+}
+
+// Objects splits the manifests rendered by kustomize into their objects
+func Objects(manifests []byte) ([]Object, error) {
+	var objects []Object
+	for _, document := range strings.Split("\n"+string(manifests), "\n---") {
+		// The separator may be followed by a comment or spaces
+		if i := strings.Index(document, "\n"); i >= 0 {
+			document = document[i+1:]
+		} else {
+			document = ""
+		}
+		if strings.TrimSpace(document) == "" {
+			continue
+		}
+
+		var object Object
+		if err := yaml.Unmarshal([]byte(document), &object); err != nil {
+			return nil, fmt.Errorf("unable to decode the rendered object: %w", err)
+		}
+		if object.Kind == "" {
+			continue
+		}
+		object.Manifest = strings.TrimSpace(document) + "\n"
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
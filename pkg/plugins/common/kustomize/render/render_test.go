@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+func TestRender(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Kustomize Render Suite")
+}
+
+const manifests = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: project-system
+---
+# Source: config/rbac
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: project-controller-manager
+  namespace: project-system
+---
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: project-controller-manager
+  namespace: project-system
+`
+
+var _ = Describe("Objects", func() {
+	It("should split the manifests into their objects", func() {
+		objects, err := Objects([]byte(manifests))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(3))
+
+		Expect(objects[0].APIVersion).To(Equal("v1"))
+		Expect(objects[0].Kind).To(Equal("Namespace"))
+		Expect(objects[0].Metadata.Name).To(Equal("project-system"))
+		Expect(objects[0].Metadata.Namespace).To(BeEmpty())
+
+		Expect(objects[1].Kind).To(Equal("ServiceAccount"))
+		Expect(objects[1].Metadata.Namespace).To(Equal("project-system"))
+		Expect(objects[1].Manifest).To(HavePrefix("# Source: config/rbac\n"))
+
+		Expect(objects[2].APIVersion).To(Equal("apps/v1"))
+		Expect(objects[2].Kind).To(Equal("Deployment"))
+		Expect(objects[2].Manifest).To(HaveSuffix("namespace: project-system\n"))
+	})
+
+	It("should return no objects for empty manifests", func() {
+		objects, err := Objects([]byte("---\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(BeEmpty())
+	})
+
+	It("should fail for invalid manifests", func() {
+		_, err := Objects([]byte("kind: [Namespace"))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Renderer", func() {
+	const (
+		kustomization = `namespace: project-system
+namePrefix: project-
+resources:
+- ../manager
+`
+		manager = `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: controller-manager
+  namespace: system
+`
+	)
+
+	writeFile := func(fSys filesys.FileSystem, path, content string) {
+		Expect(fSys.MkdirAll(filepath.Dir(path))).To(Succeed())
+		Expect(fSys.WriteFile(path, []byte(content))).To(Succeed())
+	}
+
+	It("should build the kustomization from the disk", func() {
+		dir := GinkgoT().TempDir()
+		fSys := filesys.MakeFsOnDisk()
+		writeFile(fSys, filepath.Join(dir, "config", "default", "kustomization.yaml"), kustomization)
+		writeFile(fSys, filepath.Join(dir, "config", "manager", "kustomization.yaml"), "resources:\n- manager.yaml\n")
+		writeFile(fSys, filepath.Join(dir, "config", "manager", "manager.yaml"), manager)
+
+		objects, err := Renderer{Dir: dir}.BuildObjects(DefaultKustomization)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(1))
+		Expect(objects[0].Kind).To(Equal("ServiceAccount"))
+		Expect(objects[0].Metadata.Name).To(Equal("project-controller-manager"))
+		Expect(objects[0].Metadata.Namespace).To(Equal("project-system"))
+	})
+
+	It("should build the kustomization from the given filesystem", func() {
+		fSys := filesys.MakeFsInMemory()
+		writeFile(fSys, filepath.Join("/project", "config", "default", "kustomization.yaml"), kustomization)
+		writeFile(fSys, filepath.Join("/project", "config", "manager", "kustomization.yaml"), "resources:\n- manager.yaml\n")
+		writeFile(fSys, filepath.Join("/project", "config", "manager", "manager.yaml"), manager)
+
+		manifests, err := Renderer{Dir: "/project", FS: fSys}.Build(DefaultKustomization)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(manifests)).To(Equal(`apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: project-controller-manager
+  namespace: project-system
+`))
+	})
+
+	It("should report the errors of kustomize", func() {
+		fSys := filesys.MakeFsInMemory()
+		writeFile(fSys, filepath.Join("/project", "config", "default", "kustomization.yaml"), kustomization)
+
+		_, err := Renderer{Dir: "/project", FS: fSys}.Build(DefaultKustomization)
+		Expect(err).To(MatchError(ContainSubstring("unable to build " + DefaultKustomization)))
+		Expect(err).To(MatchError(ContainSubstring("manager")))
+	})
+})
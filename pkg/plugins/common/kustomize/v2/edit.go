@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
+)
+
+var _ plugin.EditSubcommand = &editSubcommand{}
+
+type editSubcommand struct {
+	config config.Config
+
+	enableComponents  []string
+	disableComponents []string
+}
+
+func (p *editSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	subcmdMeta.Description = fmt.Sprintf(`Enable or disable the optional kustomize components listed in
+config/default/kustomization.yaml.
+
+Available components: %s
+`, strings.Join(scaffolds.Components, ", "))
+	subcmdMeta.Examples = fmt.Sprintf(`  # Enable cert-manager and the Prometheus ServiceMonitor
+  %[1]s edit --plugins=%[2]s --enable-components=certmanager,prometheus
+
+  # Disable the NetworkPolicy which protects the metrics endpoint
+  %[1]s edit --plugins=%[2]s --disable-components=network-policy/metrics
+`, cliMeta.CommandName, plugin.KeyFor(Plugin{}))
+}
+
+func (p *editSubcommand) BindFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&p.enableComponents, "enable-components", nil,
+		"comma-separated list of the kustomize components to enable in config/default/kustomization.yaml")
+	fs.StringSliceVar(&p.disableComponents, "disable-components", nil,
+		"comma-separated list of the kustomize components to disable in config/default/kustomization.yaml")
+}
+
+func (p *editSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+
+	for _, component := range append(slices.Clone(p.enableComponents), p.disableComponents...) {
+		if !slices.Contains(scaffolds.Components, component) {
+			return fmt.Errorf("unknown component %q, available components: %s",
+				component, strings.Join(scaffolds.Components, ", "))
+		}
+	}
+	for _, component := range p.enableComponents {
+		if slices.Contains(p.disableComponents, component) {
+			return fmt.Errorf("component %q cannot be both enabled and disabled", component)
+		}
+	}
+
+	return nil
+}
+
+func (p *editSubcommand) Scaffold(fs machinery.Filesystem) error {
+	scaffolder := scaffolds.NewEditScaffolder(p.config, p.enableComponents, p.disableComponents)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/spf13/pflag"
@@ -37,8 +38,12 @@ type initSubcommand struct {
 	config config.Config
 
 	// config options
-	domain string
-	name   string
+	domain          string
+	name            string
+	namespaced      bool
+	webhookOnly     bool
+	componentConfig bool
+	managers        []string
 }
 
 func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
@@ -53,12 +58,33 @@ NOTE: This plugin requires kustomize version v5 and kubectl >= 1.22.
 
   # Initialize a common project defining a specific project version
   %[1]s init --plugins %[2]s --project-version 3
+
+  # Initialize a common project whose manager only watches the namespace where it runs
+  %[1]s init --plugins %[2]s --namespaced
+
+  # Initialize a project which only serves admission webhooks for core or external types
+  %[1]s init --plugins %[2]s --webhook-only
+
+  # Initialize a common project whose manager loads its options from a configuration file
+  %[1]s init --plugins %[2]s --component-config
+
+  # Initialize a common project with two additional managers, which run the controllers assigned to them
+  %[1]s init --plugins %[2]s --managers manager-a,manager-b
 `, cliMeta.CommandName, plugin.KeyFor(Plugin{}))
 }
 
 func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&p.domain, "domain", "my.domain", "domain for groups")
 	fs.StringVar(&p.name, "project-name", "", "name of this project")
+	fs.BoolVar(&p.namespaced, "namespaced", false, "if set, the manager is granted namespace-scoped permissions "+
+		"(Role) and the config/overlays/namespaced overlay restricts it to the namespace where it runs")
+	fs.BoolVar(&p.webhookOnly, "webhook-only", false, "if set, the project only serves admission webhooks "+
+		"for core or external types: it has no CRDs nor controllers and its manager does not use leader election")
+	fs.BoolVar(&p.componentConfig, "component-config", false, "if set, the manager loads its options from a "+
+		"ControllerManagerConfig configuration file mounted from a ConfigMap, instead of most of its command line flags")
+	fs.StringSliceVar(&p.managers, "managers", nil, "names of the additional managers of the project, "+
+		"each one built from cmd/<name>/main.go and deployed with its own Deployment. The controllers are assigned "+
+		"to them with 'create api --manager', the other ones run in the main manager of cmd/main.go")
 }
 
 func (p *initSubcommand) InjectConfig(c config.Config) error {
@@ -80,7 +106,49 @@ func (p *initSubcommand) InjectConfig(c config.Config) error {
 	if err := validation.IsDNS1123Label(p.name); err != nil {
 		return fmt.Errorf("project name (%s) is invalid: %v", p.name, err)
 	}
-	return p.config.SetProjectName(p.name)
+	if err := p.config.SetProjectName(p.name); err != nil {
+		return err
+	}
+
+	if err := p.validateManagers(); err != nil {
+		return err
+	}
+
+	if p.namespaced || p.webhookOnly || p.componentConfig || len(p.managers) > 0 {
+		return scaffolds.SavePluginConfig(p.config, scaffolds.PluginConfig{
+			Namespaced:      p.namespaced,
+			WebhookOnly:     p.webhookOnly,
+			ComponentConfig: p.componentConfig,
+			Managers:        p.managers,
+		})
+	}
+	return nil
+}
+
+// reservedManagerNames are the names of the binary and of the Deployment of the main manager
+var reservedManagerNames = []string{"manager", "controller-manager"}
+
+// validateManagers verifies that the names of the additional managers can be used for their directory
+// under cmd/ and for the name of their Deployment
+func (p *initSubcommand) validateManagers() error {
+	if len(p.managers) > 0 && p.webhookOnly {
+		return fmt.Errorf("--managers can not be used with --webhook-only, since the project has no controllers")
+	}
+
+	names := make(map[string]struct{}, len(p.managers))
+	for _, name := range p.managers {
+		if err := validation.IsDNS1123Label(name); err != nil {
+			return fmt.Errorf("invalid manager name %q: %v", name, err)
+		}
+		if slices.Contains(reservedManagerNames, name) {
+			return fmt.Errorf("invalid manager name %q: it is the name of the main manager", name)
+		}
+		if _, duplicated := names[name]; duplicated {
+			return fmt.Errorf("the manager %q is informed more than once", name)
+		}
+		names[name] = struct{}{}
+	}
+	return nil
 }
 
 func (p *initSubcommand) Scaffold(fs machinery.Filesystem) error {
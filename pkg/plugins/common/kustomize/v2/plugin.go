@@ -35,9 +35,12 @@ var (
 )
 
 var (
-	_ plugin.Init          = Plugin{}
-	_ plugin.CreateAPI     = Plugin{}
-	_ plugin.CreateWebhook = Plugin{}
+	_ plugin.Init           = Plugin{}
+	_ plugin.CreateAPI      = Plugin{}
+	_ plugin.CreateWebhook  = Plugin{}
+	_ plugin.Edit           = Plugin{}
+	_ plugin.Deprecated     = Plugin{}
+	_ plugin.HasDeprecation = Plugin{}
 )
 
 // Plugin implements the plugin.Full interface
@@ -45,6 +48,7 @@ type Plugin struct {
 	initSubcommand
 	createAPISubcommand
 	createWebhookSubcommand
+	editSubcommand
 }
 
 // Name returns the name of the plugin
@@ -67,7 +71,16 @@ func (p Plugin) GetCreateWebhookSubcommand() plugin.CreateWebhookSubcommand {
 	return &p.createWebhookSubcommand
 }
 
+// GetEditSubcommand will return the subcommand which is responsible for enabling and disabling components
+func (p Plugin) GetEditSubcommand() plugin.EditSubcommand { return &p.editSubcommand }
+
+// Deprecated define whether the plugin is deprecated and its deprecation message
+func (p Plugin) Deprecated() (bool, string) {
+	return false, ""
+}
+
 // DeprecationWarning define the deprecation message or return empty when plugin is not deprecated
 func (p Plugin) DeprecationWarning() string {
-	return ""
+	_, warning := p.Deprecated()
+	return warning
 }
@@ -22,6 +22,7 @@ import (
 
 	pluginutil "sigs.k8s.io/kubebuilder/v4/pkg/plugin/util"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/crd"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/overlays"
 
 	log "github.com/sirupsen/logrus"
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
@@ -129,11 +130,39 @@ func (s *apiScaffolder) Scaffold() error {
 			log.Errorf("Unable to append empty line at the end of the file"+
 				"%s.", rbacKustomizeFilePath)
 		}
+
+		if err := s.scaffoldClusterScopedRole(scaffold, crdName); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// scaffoldClusterScopedRole grants the manager of the namespaced overlay the permissions on a
+// cluster-scoped resource, which its namespace-scoped Role can not grant.
+func (s *apiScaffolder) scaffoldClusterScopedRole(scaffold *machinery.Scaffold, crdName string) error {
+	pluginConfig, err := LoadPluginConfig(s.config)
+	if err != nil {
+		return err
+	}
+	if !pluginConfig.Namespaced || s.resource.API.Namespaced {
+		return nil
+	}
+
+	if err := scaffold.Execute(&overlays.ClusterScopedRole{}); err != nil {
+		return fmt.Errorf("error scaffolding the cluster role of the namespaced overlay: %v", err)
+	}
+
+	overlayKustomizeFilePath := "config/overlays/namespaced/kustomization.yaml"
+	err = pluginutil.InsertCodeIfNotExist(overlayKustomizeFilePath, "- ../../default",
+		fmt.Sprintf("\n- %s_cluster_role.yaml", crdName))
+	if err != nil {
+		log.Errorf("Unable to add the cluster role of %s in the file %s.", s.resource.Kind, overlayKustomizeFilePath)
+	}
+	return nil
+}
+
 const adminEditViewRulesCommentFragment = `# For each CRD, "Admin", "Editor" and "Viewer" roles are scaffolded by
 # default, aiding admins in cluster management. Those roles are
 # not used by the {{ .ProjectName }} itself. You can comment the following lines
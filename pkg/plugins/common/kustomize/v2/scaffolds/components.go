@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"strings"
+
+	pluginutil "sigs.k8s.io/kubebuilder/v4/pkg/plugin/util"
+)
+
+const defaultKustomizationPath = "config/default/kustomization.yaml"
+
+const (
+	// WebhookComponent deploys the webhook server
+	WebhookComponent = "webhook"
+	// PrometheusComponent exports the metrics with a Prometheus ServiceMonitor
+	PrometheusComponent = "prometheus"
+	// CertManagerComponent provisions the certificates with cert-manager
+	CertManagerComponent = "certmanager"
+	// MetricsNetworkPolicyComponent protects the metrics endpoint with a NetworkPolicy
+	MetricsNetworkPolicyComponent = "network-policy/metrics"
+	// WebhookNetworkPolicyComponent protects the webhook server with a NetworkPolicy
+	WebhookNetworkPolicyComponent = "network-policy/webhook"
+	// PoliciesComponent validates the custom resources with ValidatingAdmissionPolicies
+	PoliciesComponent = "policies"
+)
+
+// Components are the optional kustomize components listed in config/default/kustomization.yaml
+var Components = []string{
+	WebhookComponent,
+	PrometheusComponent,
+	CertManagerComponent,
+	MetricsNetworkPolicyComponent,
+	WebhookNetworkPolicyComponent,
+	PoliciesComponent,
+}
+
+// hasComponentsLayout returns true when config/default/kustomization.yaml lists the optional features
+// as kustomize components. Projects scaffolded before list them as commented resources instead.
+func hasComponentsLayout() bool {
+	hasComponents, err := pluginutil.HasFileContentWith(defaultKustomizationPath, "components:")
+	return err == nil && hasComponents
+}
+
+// isComponentEnabled returns true when the component is listed in config/default/kustomization.yaml
+func isComponentEnabled(name string) (bool, error) {
+	return pluginutil.HasFileContentWith(defaultKustomizationPath, "\n"+componentEntry(name)+"\n")
+}
+
+// enableComponent uncomments the component in config/default/kustomization.yaml
+func enableComponent(name string) error {
+	enabled, err := isComponentEnabled(name)
+	if err != nil {
+		return err
+	}
+	if enabled {
+		return nil
+	}
+
+	hasComponentsField, err := pluginutil.HasFileContentWith(defaultKustomizationPath, "\ncomponents:")
+	if err != nil {
+		return err
+	}
+	if !hasComponentsField {
+		if err := pluginutil.UncommentCode(defaultKustomizationPath, "\n#components:", "#"); err != nil {
+			return err
+		}
+	}
+
+	if err := pluginutil.UncommentCode(defaultKustomizationPath, "\n#"+componentEntry(name), "#"); err != nil {
+		return fmt.Errorf("unable to find the component %q in %s: %w", name, defaultKustomizationPath, err)
+	}
+	return nil
+}
+
+// disableComponent comments out the component in config/default/kustomization.yaml
+func disableComponent(name string) error {
+	enabled, err := isComponentEnabled(name)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	return pluginutil.CommentCode(defaultKustomizationPath, componentEntry(name)+"\n", "#")
+}
+
+// componentEntry returns the entry of the component in the components field of config/default/kustomization.yaml
+func componentEntry(name string) string {
+	return "- ../" + strings.TrimPrefix(name, "../")
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+	"slices"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+)
+
+// PluginKey is the key used to track the kustomize/v2 options in the PROJECT file.
+// It must match plugin.KeyFor(v2.Plugin{}), which cannot be used here to avoid an import cycle.
+const PluginKey = "kustomize.common.kubebuilder.io/v2"
+
+// PluginConfig defines the kustomize/v2 options which are tracked in the PROJECT file
+type PluginConfig struct {
+	// Namespaced indicates that the manager only watches the namespace where it runs, and that its
+	// permissions are granted with a Role instead of a ClusterRole
+	Namespaced bool `json:"namespaced,omitempty"`
+	// WebhookOnly indicates that the project only serves admission webhooks for core or external types,
+	// so it has no CRDs and its manager does not use leader election
+	WebhookOnly bool `json:"webhookOnly,omitempty"`
+	// ComponentConfig indicates that the manager loads its options from a ControllerManagerConfig
+	// configuration file mounted from a ConfigMap, instead of most of its command line flags
+	ComponentConfig bool `json:"componentConfig,omitempty"`
+	// Managers are the names of the additional managers of the project, each one built from cmd/<name>/main.go
+	// and deployed with its own Deployment, which run the controllers assigned to them
+	Managers []string `json:"managers,omitempty"`
+}
+
+// HasManager returns true if the project has an additional manager with the given name
+func (c PluginConfig) HasManager(name string) bool {
+	return slices.Contains(c.Managers, name)
+}
+
+// LoadPluginConfig returns the kustomize/v2 options tracked in the PROJECT file.
+// An empty PluginConfig is returned when nothing was tracked.
+func LoadPluginConfig(cfg config.Config) (PluginConfig, error) {
+	pluginCfg := PluginConfig{}
+	err := cfg.DecodePluginConfig(PluginKey, &pluginCfg)
+	if err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) &&
+		!errors.As(err, &config.UnsupportedFieldError{}) {
+		return pluginCfg, err
+	}
+	return pluginCfg, nil
+}
+
+// SavePluginConfig tracks the kustomize/v2 options in the PROJECT file
+func SavePluginConfig(cfg config.Config, pluginCfg PluginConfig) error {
+	if err := cfg.EncodePluginConfig(PluginKey, pluginCfg); err != nil &&
+		!errors.As(err, &config.UnsupportedFieldError{}) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+)
+
+var _ plugins.Scaffolder = &editScaffolder{}
+
+type editScaffolder struct {
+	config config.Config
+
+	enableComponents  []string
+	disableComponents []string
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+}
+
+// NewEditScaffolder returns a new Scaffolder which enables and disables the optional kustomize components
+func NewEditScaffolder(config config.Config, enableComponents, disableComponents []string) plugins.Scaffolder {
+	return &editScaffolder{
+		config:            config,
+		enableComponents:  enableComponents,
+		disableComponents: disableComponents,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *editScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *editScaffolder) Scaffold() error {
+	if len(s.enableComponents) == 0 && len(s.disableComponents) == 0 {
+		return nil
+	}
+
+	if !hasComponentsLayout() {
+		return fmt.Errorf("%s does not list the optional features as kustomize components; "+
+			"re-scaffold the project with 'kubebuilder alpha generate' to use them", defaultKustomizationPath)
+	}
+
+	for _, component := range s.enableComponents {
+		log.Printf("Enabling the %s component in %s", component, defaultKustomizationPath)
+		if err := enableComponent(component); err != nil {
+			return err
+		}
+	}
+	for _, component := range s.disableComponents {
+		log.Printf("Disabling the %s component in %s", component, defaultKustomizationPath)
+		if err := disableComponent(component); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
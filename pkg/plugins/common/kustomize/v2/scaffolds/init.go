@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/kdefault"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/manager"
 	network_policy "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/network-policy"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/overlays"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/prometheus"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/rbac"
 )
@@ -58,35 +59,71 @@ func (s *initScaffolder) InjectFS(fs machinery.Filesystem) {
 func (s *initScaffolder) Scaffold() error {
 	log.Println("Writing kustomize manifests for you to edit...")
 
+	pluginConfig, err := LoadPluginConfig(s.config)
+	if err != nil {
+		return err
+	}
+
 	// Initialize the machinery.Scaffold that will write the files to disk
 	scaffold := machinery.NewScaffold(s.fs,
 		machinery.WithConfig(s.config),
 	)
 
 	templates := []machinery.Builder{
-		&rbac.Kustomization{},
+		&rbac.Kustomization{WebhookOnly: pluginConfig.WebhookOnly},
 		&kdefault.MetricsService{},
-		&rbac.RoleBinding{},
+		&rbac.RoleBinding{Namespaced: pluginConfig.Namespaced},
 		// We need to create a Role because if the project
 		// has not CRD define the controller-gen will not generate this file
-		&rbac.Role{},
+		&rbac.Role{Namespaced: pluginConfig.Namespaced},
 		&rbac.MetricsAuthRole{},
 		&rbac.MetricsAuthRoleBinding{},
 		&rbac.MetricsReaderRole{},
-		&rbac.LeaderElectionRole{},
-		&rbac.LeaderElectionRoleBinding{},
 		&rbac.ServiceAccount{},
-		&manager.Kustomization{},
+		&manager.Kustomization{
+			ComponentConfig: pluginConfig.ComponentConfig,
+			Managers:        pluginConfig.Managers,
+		},
 		&kdefault.ManagerMetricsPatch{},
 		&kdefault.CertManagerMetricsPatch{},
-		&manager.Config{Image: imageName},
+		&manager.Config{
+			Image:           imageName,
+			WebhookOnly:     pluginConfig.WebhookOnly,
+			ComponentConfig: pluginConfig.ComponentConfig,
+		},
 		&kdefault.Kustomization{},
-		&network_policy.Kustomization{},
+		&network_policy.Kustomization{Component: network_policy.MetricsComponent},
 		&network_policy.PolicyAllowMetrics{},
+		&network_policy.Kustomization{Component: network_policy.WebhookComponent},
+		&network_policy.PolicyAllowWebhooks{},
 		&prometheus.Kustomization{},
 		&prometheus.Monitor{},
 		&prometheus.ServiceMonitorPatch{},
 	}
 
+	// The webhook servers of all the replicas serve the admission requests, so the
+	// webhook-only projects do not need the leader election
+	if !pluginConfig.WebhookOnly {
+		templates = append(templates,
+			&rbac.LeaderElectionRole{},
+			&rbac.LeaderElectionRoleBinding{},
+		)
+	}
+
+	if pluginConfig.ComponentConfig {
+		templates = append(templates, &manager.ControllerManagerConfig{WebhookOnly: pluginConfig.WebhookOnly})
+	}
+
+	for _, name := range pluginConfig.Managers {
+		templates = append(templates, &manager.AdditionalManager{Name: name, Image: imageName})
+	}
+
+	if pluginConfig.Namespaced {
+		templates = append(templates,
+			&overlays.NamespacedKustomization{Managers: pluginConfig.Managers},
+			&overlays.ManagerWatchNamespacePatch{},
+		)
+	}
+
 	return scaffold.Execute(templates...)
 }
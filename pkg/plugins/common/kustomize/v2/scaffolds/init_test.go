@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/render"
+)
+
+var _ = Describe("Additional managers", func() {
+	var (
+		dir string
+		fs  machinery.Filesystem
+		cfg config.Config
+	)
+
+	read := func(path string) string {
+		content, err := afero.ReadFile(fs.FS, path)
+		Expect(err).NotTo(HaveOccurred())
+		return string(content)
+	}
+
+	// deployments returns the names of the Deployments rendered from the kustomization
+	deployments := func(kustomization string) []string {
+		objects, err := render.Renderer{Dir: dir}.BuildObjects(kustomization)
+		Expect(err).NotTo(HaveOccurred())
+
+		var names []string
+		for _, object := range objects {
+			if object.Kind == "Deployment" {
+				names = append(names, object.Metadata.Name)
+			}
+		}
+		return names
+	}
+
+	scaffold := func(pluginConfig PluginConfig) {
+		Expect(SavePluginConfig(cfg, pluginConfig)).To(Succeed())
+
+		scaffolder := NewInitScaffolder(cfg)
+		scaffolder.InjectFS(fs)
+		Expect(scaffolder.Scaffold()).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		fs = machinery.Filesystem{FS: afero.NewBasePathFs(afero.NewOsFs(), dir)}
+
+		cfg = cfgv3.New()
+		Expect(cfg.SetProjectName("project")).To(Succeed())
+	})
+
+	It("should scaffold a Deployment per manager and add it to the manager kustomization", func() {
+		scaffold(PluginConfig{Managers: []string{"manager-a", "manager-b"}})
+
+		deployment := read(filepath.Join("config", "manager", "manager-a.yaml"))
+		Expect(deployment).To(ContainSubstring("name: manager-a\n"))
+		Expect(deployment).To(ContainSubstring("control-plane: manager-a\n"))
+		Expect(deployment).To(ContainSubstring("- /manager-a\n"))
+		Expect(read(filepath.Join("config", "manager", "kustomization.yaml"))).
+			To(HavePrefix("resources:\n- manager.yaml\n- manager-a.yaml\n- manager-b.yaml\n"))
+
+		Expect(deployments(filepath.Join("config", "manager"))).
+			To(ConsistOf("controller-manager", "manager-a", "manager-b"))
+	})
+
+	It("should only scaffold the main manager by default", func() {
+		scaffold(PluginConfig{Namespaced: true})
+
+		Expect(afero.Glob(fs.FS, filepath.Join("config", "manager", "manager-*.yaml"))).To(BeEmpty())
+		Expect(read(filepath.Join("config", "overlays", "namespaced", "kustomization.yaml"))).
+			To(ContainSubstring(`labelSelector: "control-plane=controller-manager"`))
+	})
+
+	It("should restrict every manager to the namespace with the namespaced overlay", func() {
+		scaffold(PluginConfig{Namespaced: true, Managers: []string{"manager-a", "manager-b"}})
+
+		Expect(read(filepath.Join("config", "overlays", "namespaced", "kustomization.yaml"))).
+			To(ContainSubstring(`labelSelector: "control-plane in (controller-manager,manager-a,manager-b)"`))
+
+		objects, err := render.Renderer{Dir: dir}.BuildObjects(filepath.Join("config", "overlays", "namespaced"))
+		Expect(err).NotTo(HaveOccurred())
+		var patched []string
+		for _, object := range objects {
+			if object.Kind == "Deployment" {
+				Expect(object.Manifest).To(ContainSubstring("WATCH_NAMESPACE"), object.Metadata.Name)
+				patched = append(patched, object.Metadata.Name)
+			}
+		}
+		Expect(patched).To(ConsistOf("project-controller-manager", "project-manager-a", "project-manager-b"))
+	})
+})
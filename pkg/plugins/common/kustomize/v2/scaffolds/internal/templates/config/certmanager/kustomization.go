@@ -24,9 +24,13 @@ import (
 
 var _ machinery.Template = &Kustomization{}
 
-// Kustomization scaffolds a file that defines the kustomization scheme for the certmanager folder
+// Kustomization scaffolds a file that defines the kustomize component for the certmanager folder
 type Kustomization struct {
 	machinery.TemplateMixin
+
+	// Legacy scaffolds a kustomization instead of a component, for the projects whose
+	// config/default/kustomization.yaml lists the certmanager folder as a resource
+	Legacy bool
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -35,7 +39,11 @@ func (f *Kustomization) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "certmanager", "kustomization.yaml")
 	}
 
-	f.TemplateBody = kustomizationTemplate
+	if f.Legacy {
+		f.TemplateBody = legacyKustomizationTemplate
+	} else {
+		f.TemplateBody = kustomizationTemplate
+	}
 
 	// If file exists (ex. because a webhook was already created), skip creation.
 	f.IfExistsAction = machinery.SkipFile
@@ -43,11 +51,205 @@ func (f *Kustomization) SetTemplateDefaults() error {
 	return nil
 }
 
-const kustomizationTemplate = `resources:
+const legacyKustomizationTemplate = `resources:
+- issuer.yaml
+- certificate-webhook.yaml
+- certificate-metrics.yaml
+
+configurations:
+- kustomizeconfig.yaml
+`
+
+//nolint:lll
+const kustomizationTemplate = `apiVersion: kustomize.config.k8s.io/v1alpha1
+kind: Component
+
+resources:
 - issuer.yaml
 - certificate-webhook.yaml
 - certificate-metrics.yaml
 
 configurations:
 - kustomizeconfig.yaml
+
+# The following replacements add the cert-manager CA injection annotations
+replacements:
+- source: # Set the DNS names of the webhook server certificate
+    kind: Service
+    version: v1
+    name: webhook-service
+    fieldPath: .metadata.name # Name of the service
+  targets:
+    - select:
+        kind: Certificate
+        group: cert-manager.io
+        version: v1
+        name: serving-cert
+      fieldPaths:
+        - .spec.dnsNames.0
+        - .spec.dnsNames.1
+      options:
+        delimiter: '.'
+        index: 0
+        create: true
+- source:
+    kind: Service
+    version: v1
+    name: webhook-service
+    fieldPath: .metadata.namespace # Namespace of the service
+  targets:
+    - select:
+        kind: Certificate
+        group: cert-manager.io
+        version: v1
+        name: serving-cert
+      fieldPaths:
+        - .spec.dnsNames.0
+        - .spec.dnsNames.1
+      options:
+        delimiter: '.'
+        index: 1
+        create: true
+
+- source: # Inject the CA in the ValidatingWebhookConfiguration (--programmatic-validation)
+    kind: Certificate
+    group: cert-manager.io
+    version: v1
+    name: serving-cert # This name should match the one in certificate.yaml
+    fieldPath: .metadata.namespace # Namespace of the certificate CR
+  targets:
+    - select:
+        kind: ValidatingWebhookConfiguration
+      fieldPaths:
+        - .metadata.annotations.[cert-manager.io/inject-ca-from]
+      options:
+        delimiter: '/'
+        index: 0
+        create: true
+- source:
+    kind: Certificate
+    group: cert-manager.io
+    version: v1
+    name: serving-cert
+    fieldPath: .metadata.name
+  targets:
+    - select:
+        kind: ValidatingWebhookConfiguration
+      fieldPaths:
+        - .metadata.annotations.[cert-manager.io/inject-ca-from]
+      options:
+        delimiter: '/'
+        index: 1
+        create: true
+
+- source: # Inject the CA in the MutatingWebhookConfiguration (--defaulting)
+    kind: Certificate
+    group: cert-manager.io
+    version: v1
+    name: serving-cert
+    fieldPath: .metadata.namespace # Namespace of the certificate CR
+  targets:
+    - select:
+        kind: MutatingWebhookConfiguration
+      fieldPaths:
+        - .metadata.annotations.[cert-manager.io/inject-ca-from]
+      options:
+        delimiter: '/'
+        index: 0
+        create: true
+- source:
+    kind: Certificate
+    group: cert-manager.io
+    version: v1
+    name: serving-cert
+    fieldPath: .metadata.name
+  targets:
+    - select:
+        kind: MutatingWebhookConfiguration
+      fieldPaths:
+        - .metadata.annotations.[cert-manager.io/inject-ca-from]
+      options:
+        delimiter: '/'
+        index: 1
+        create: true
+
+# [CONVERSION] The following blocks inject the CA in the CRDs with a ConversionWebhook (--conversion).
+# They are uncommented when a conversion webhook is created.
+#- source:
+#    kind: Certificate
+#    group: cert-manager.io
+#    version: v1
+#    name: serving-cert
+#    fieldPath: .metadata.namespace # Namespace of the certificate CR
+#  targets: # Do not remove the following scaffold marker; required to generate code for target CRD.
+  # +kubebuilder:scaffold:crdkustomizecainjectionns
+#- source:
+#    kind: Certificate
+#    group: cert-manager.io
+#    version: v1
+#    name: serving-cert
+#    fieldPath: .metadata.name
+#  targets: # Do not remove the following scaffold marker; required to generate code for target CRD.
+  # +kubebuilder:scaffold:crdkustomizecainjectionname
+
+# [METRICS-WITH-CERTS] To enable metrics protected with certManager, uncomment the following blocks
+# and the [METRICS-WITH-CERTS] patch in config/default/kustomization.yaml.
+#- source:
+#    kind: Service
+#    version: v1
+#    name: controller-manager-metrics-service
+#    fieldPath: metadata.name
+#  targets:
+#    - select:
+#        kind: Certificate
+#        group: cert-manager.io
+#        version: v1
+#        name: metrics-certs
+#      fieldPaths:
+#        - spec.dnsNames.0
+#        - spec.dnsNames.1
+#      options:
+#        delimiter: '.'
+#        index: 0
+#        create: true
+#    - select: # Set the Service name for TLS config in Prometheus ServiceMonitor
+#        kind: ServiceMonitor
+#        group: monitoring.coreos.com
+#        version: v1
+#        name: controller-manager-metrics-monitor
+#      fieldPaths:
+#        - spec.endpoints.0.tlsConfig.serverName
+#      options:
+#        delimiter: '.'
+#        index: 0
+#        create: true
+#- source:
+#    kind: Service
+#    version: v1
+#    name: controller-manager-metrics-service
+#    fieldPath: metadata.namespace
+#  targets:
+#    - select:
+#        kind: Certificate
+#        group: cert-manager.io
+#        version: v1
+#        name: metrics-certs
+#      fieldPaths:
+#        - spec.dnsNames.0
+#        - spec.dnsNames.1
+#      options:
+#        delimiter: '.'
+#        index: 1
+#        create: true
+#    - select: # Set the Service namespace for TLS in Prometheus ServiceMonitor
+#        kind: ServiceMonitor
+#        group: monitoring.coreos.com
+#        version: v1
+#        name: controller-manager-metrics-monitor
+#      fieldPaths:
+#        - spec.endpoints.0.tlsConfig.serverName
+#      options:
+#        delimiter: '.'
+#        index: 1
+#        create: true
 `
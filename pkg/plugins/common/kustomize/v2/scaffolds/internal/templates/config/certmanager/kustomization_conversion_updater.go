@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package kdefault
+package certmanager
 
 import (
 	"fmt"
@@ -30,12 +30,20 @@ const caName = "crdkustomizecainjectionname"
 type KustomizationCAConversionUpdater struct {
 	machinery.TemplateMixin
 	machinery.ResourceMixin
+
+	// Legacy appends the targets as comments to config/default/kustomization.yaml, for the projects
+	// which define the cert-manager replacements there instead of in the certmanager component
+	Legacy bool
 }
 
 // SetTemplateDefaults defines the file path and behavior for existing files
 func (f *KustomizationCAConversionUpdater) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", "default", "kustomization.yaml")
+		if f.Legacy {
+			f.Path = filepath.Join("config", "default", "kustomization.yaml")
+		} else {
+			f.Path = filepath.Join("config", "certmanager", "kustomization.yaml")
+		}
 	}
 	f.IfExistsAction = machinery.SkipFile // Only append to the existing file, don’t overwrite it
 	return nil
@@ -50,7 +58,7 @@ func (f *KustomizationCAConversionUpdater) GetMarkers() []machinery.Marker {
 	}
 }
 
-// GetCodeFragments appends CA injection targets for the CRD with --conversion as comments
+// GetCodeFragments appends CA injection targets for the CRD with --conversion
 func (f *KustomizationCAConversionUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 	fragments := make(machinery.CodeFragmentsMap)
 
@@ -58,29 +66,16 @@ func (f *KustomizationCAConversionUpdater) GetCodeFragments() machinery.CodeFrag
 	crdName := fmt.Sprintf("%s.%s", f.Resource.Plural, f.Resource.QualifiedGroup())
 
 	if !f.Resource.Webhooks.IsEmpty() && f.Resource.Webhooks.Conversion {
-		// Commented CA injection configuration for the namespace part
-		caInjectionNamespace := fmt.Sprintf(`#     - select:
-#         kind: CustomResourceDefinition
-#         name: %s
-#       fieldPaths:
-#         - .metadata.annotations.[cert-manager.io/inject-ca-from]
-#       options:
-#         delimiter: '/'
-#         index: 0
-#         create: true
-`, crdName)
+		fragment := caInjectionTargetFragment
+		if f.Legacy {
+			// The legacy replacements are commented out until cert-manager is enabled
+			fragment = legacyCAInjectionTargetFragment
+		}
 
-		// Commented CA injection configuration for the name part
-		caInjectionName := fmt.Sprintf(`#     - select:
-#         kind: CustomResourceDefinition
-#         name: %s
-#       fieldPaths:
-#         - .metadata.annotations.[cert-manager.io/inject-ca-from]
-#       options:
-#         delimiter: '/'
-#         index: 1
-#         create: true
-`, crdName)
+		// CA injection configuration for the namespace part
+		caInjectionNamespace := fmt.Sprintf(fragment, crdName, 0)
+		// CA injection configuration for the name part
+		caInjectionName := fmt.Sprintf(fragment, crdName, 1)
 
 		// Append to the correct markers to prevent duplication
 		namespaceMarker := machinery.NewMarkerFor(f.Path, caNamespace)
@@ -97,3 +92,25 @@ func (f *KustomizationCAConversionUpdater) GetCodeFragments() machinery.CodeFrag
 
 	return fragments
 }
+
+const caInjectionTargetFragment = `    - select:
+        kind: CustomResourceDefinition
+        name: %s
+      fieldPaths:
+        - .metadata.annotations.[cert-manager.io/inject-ca-from]
+      options:
+        delimiter: '/'
+        index: %d
+        create: true
+`
+
+const legacyCAInjectionTargetFragment = `#     - select:
+#         kind: CustomResourceDefinition
+#         name: %s
+#       fieldPaths:
+#         - .metadata.annotations.[cert-manager.io/inject-ca-from]
+#       options:
+#         delimiter: '/'
+#         index: %d
+#         create: true
+`
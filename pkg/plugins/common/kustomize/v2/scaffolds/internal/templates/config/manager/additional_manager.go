@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &AdditionalManager{}
+
+// AdditionalManager scaffolds a file that defines the Deployment of an additional manager of the project
+type AdditionalManager struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+
+	// Name is the name of the additional manager, which is also the name of its binary
+	Name string
+
+	// Image is controller manager image name
+	Image string
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *AdditionalManager) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("config", "manager", f.Name+".yaml")
+	}
+
+	f.TemplateBody = additionalManagerTemplate
+
+	return nil
+}
+
+const additionalManagerTemplate = `# The {{ .Name }} manager runs the controllers assigned to it
+# in cmd/{{ .Name }}/main.go.
+# It is built in the same image as the main manager, and shares its ServiceAccount and its RBAC.
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Name }}
+  namespace: system
+  labels:
+    control-plane: {{ .Name }}
+    app.kubernetes.io/name: {{ .ProjectName }}
+    app.kubernetes.io/managed-by: kustomize
+spec:
+  selector:
+    matchLabels:
+      control-plane: {{ .Name }}
+      app.kubernetes.io/name: {{ .ProjectName }}
+  replicas: 1
+  template:
+    metadata:
+      annotations:
+        kubectl.kubernetes.io/default-container: manager
+      labels:
+        control-plane: {{ .Name }}
+        app.kubernetes.io/name: {{ .ProjectName }}
+    spec:
+      securityContext:
+        runAsNonRoot: true
+        seccompProfile:
+          type: RuntimeDefault
+      containers:
+      - command:
+        - /{{ .Name }}
+        args:
+          - --leader-elect
+          - --health-probe-bind-address=:8081
+        image: {{ .Image }}
+        name: manager
+        ports: []
+        securityContext:
+          allowPrivilegeEscalation: false
+          capabilities:
+            drop:
+            - "ALL"
+        livenessProbe:
+          httpGet:
+            path: /healthz
+            port: 8081
+          initialDelaySeconds: 15
+          periodSeconds: 20
+        readinessProbe:
+          httpGet:
+            path: /readyz
+            port: 8081
+          initialDelaySeconds: 5
+          periodSeconds: 10
+        # TODO(user): Configure the resources accordingly based on the controllers of the manager.
+        # More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+        resources:
+          limits:
+            cpu: 500m
+            memory: 128Mi
+          requests:
+            cpu: 10m
+            memory: 64Mi
+      serviceAccountName: controller-manager
+      terminationGracePeriodSeconds: 10
+`
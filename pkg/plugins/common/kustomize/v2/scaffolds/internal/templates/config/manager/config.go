@@ -31,6 +31,12 @@ type Config struct {
 
 	// Image is controller manager image name
 	Image string
+
+	// WebhookOnly omits the leader election, which is not used by webhook-only projects
+	WebhookOnly bool
+
+	// ComponentConfig mounts the configuration file of the manager, which replaces most of its arguments
+	ComponentConfig bool
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -107,8 +113,14 @@ spec:
       - command:
         - /manager
         args:
+          {{- if .ComponentConfig }}
+          - --config=/etc/manager/controller_manager_config.yaml
+          {{- else }}
+          {{- if not .WebhookOnly }}
           - --leader-elect
+          {{- end }}
           - --health-probe-bind-address=:8081
+          {{- end }}
         image: {{ .Image }}
         name: manager
         ports: []
@@ -138,8 +150,19 @@ spec:
           requests:
             cpu: 10m
             memory: 64Mi
+        {{- if .ComponentConfig }}
+        volumeMounts:
+        - name: manager-config
+          mountPath: /etc/manager
+          readOnly: true
+      volumes:
+      - name: manager-config
+        configMap:
+          name: manager-config
+        {{- else }}
         volumeMounts: []
       volumes: []
+        {{- end }}
       serviceAccountName: controller-manager
       terminationGracePeriodSeconds: 10
 `
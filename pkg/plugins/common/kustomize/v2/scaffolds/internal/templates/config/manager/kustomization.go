@@ -27,6 +27,12 @@ var _ machinery.Template = &Kustomization{}
 // Kustomization scaffolds a file that defines the kustomization scheme for the manager folder
 type Kustomization struct {
 	machinery.TemplateMixin
+
+	// ComponentConfig generates the ConfigMap of the configuration file of the manager
+	ComponentConfig bool
+
+	// Managers are the names of the additional managers, whose Deployments are added to the resources
+	Managers []string
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -44,4 +50,16 @@ func (f *Kustomization) SetTemplateDefaults() error {
 
 const kustomizeManagerTemplate = `resources:
 - manager.yaml
+{{- range .Managers }}
+- {{ . }}.yaml
+{{- end }}
+{{- if .ComponentConfig }}
+
+# The ConfigMap of the configuration file of the manager. The hash appended to its name
+# rolls out the manager when the configuration changes.
+configMapGenerator:
+- name: manager-config
+  files:
+  - controller_manager_config.yaml
+{{- end }}
 `
@@ -34,7 +34,7 @@ type PolicyAllowMetrics struct {
 // SetTemplateDefaults implements machinery.Template
 func (f *PolicyAllowMetrics) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", "network-policy", "allow-metrics-traffic.yaml")
+		f.Path = filepath.Join("config", "network-policy", MetricsComponent, "allow-metrics-traffic.yaml")
 	}
 
 	f.TemplateBody = metricsNetworkPolicyTemplate
@@ -24,8 +24,8 @@ import (
 
 var _ machinery.Template = &PolicyAllowWebhooks{}
 
-// PolicyAllowWebhooks in scaffolds a file that defines the NetworkPolicy
-// to allow the webhook server can communicate
+// PolicyAllowWebhooks scaffolds a file that defines the NetworkPolicy
+// to allow the API server to communicate with the webhook server
 type PolicyAllowWebhooks struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
@@ -34,7 +34,7 @@ type PolicyAllowWebhooks struct {
 // SetTemplateDefaults implements machinery.Template
 func (f *PolicyAllowWebhooks) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", "network-policy", "allow-webhook-traffic.yaml")
+		f.Path = filepath.Join("config", "network-policy", WebhookComponent, "allow-webhook-traffic.yaml")
 	}
 
 	f.TemplateBody = webhooksNetworkPolicyTemplate
@@ -42,9 +42,10 @@ func (f *PolicyAllowWebhooks) SetTemplateDefaults() error {
 	return nil
 }
 
-const webhooksNetworkPolicyTemplate = `# This NetworkPolicy allows ingress traffic to your webhook server running
-# as part of the controller-manager from specific namespaces and pods. CR(s) which uses webhooks
-# will only work when applied in namespaces labeled with 'webhook: enabled'
+const webhooksNetworkPolicyTemplate = `# This NetworkPolicy allows the API server to reach the webhook server running as part
+# of the controller-manager. The API server usually runs on the host network of the control plane
+# nodes, so it cannot be selected with a namespaceSelector or a podSelector: ingress traffic is
+# allowed from any source, but only on the port of the webhook server.
 apiVersion: networking.k8s.io/v1
 kind: NetworkPolicy
 metadata:
@@ -61,12 +62,13 @@ spec:
   policyTypes:
     - Ingress
   ingress:
-    # This allows ingress traffic from any namespace with the label webhook: enabled
-    - from:
-      - namespaceSelector:
-          matchLabels:
-            webhook: enabled # Only from namespaces with this label
-      ports:
-        - port: 443
+    # This allows ingress traffic to the webhook server port from the API server
+    - ports:
+        - port: 9443
           protocol: TCP
+      # TODO(user): Uncomment the following lines to only allow the traffic from
+      # the addresses of your control plane nodes.
+      # from:
+      #   - ipBlock:
+      #       cidr: 10.0.0.0/24
 `
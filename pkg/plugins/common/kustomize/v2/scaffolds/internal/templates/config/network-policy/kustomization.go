@@ -22,17 +22,27 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 )
 
+const (
+	// MetricsComponent is the component which protects the metrics endpoint
+	MetricsComponent = "metrics"
+	// WebhookComponent is the component which protects the webhook server
+	WebhookComponent = "webhook"
+)
+
 var _ machinery.Template = &Kustomization{}
 
-// Kustomization scaffolds a file that defines the kustomization scheme for the prometheus folder
+// Kustomization scaffolds a file that defines the kustomize component for a NetworkPolicy
 type Kustomization struct {
 	machinery.TemplateMixin
+
+	// Component is the name of the component, which is either MetricsComponent or WebhookComponent
+	Component string
 }
 
 // SetTemplateDefaults implements machinery.Template
 func (f *Kustomization) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", "network-policy", "kustomization.yaml")
+		f.Path = filepath.Join("config", "network-policy", f.Component, "kustomization.yaml")
 	}
 
 	f.TemplateBody = kustomizationTemplate
@@ -40,6 +50,9 @@ func (f *Kustomization) SetTemplateDefaults() error {
 	return nil
 }
 
-const kustomizationTemplate = `resources:
-- allow-metrics-traffic.yaml
+const kustomizationTemplate = `apiVersion: kustomize.config.k8s.io/v1alpha1
+kind: Component
+
+resources:
+- allow-{{ .Component }}-traffic.yaml
 `
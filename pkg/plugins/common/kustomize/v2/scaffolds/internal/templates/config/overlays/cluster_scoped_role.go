@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overlays
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &ClusterScopedRole{}
+
+// ClusterScopedRole scaffolds a file that grants the manager of the namespaced overlay the permissions
+// on a cluster-scoped resource, which can not be granted by the namespace-scoped Role of the manager
+type ClusterScopedRole struct {
+	machinery.TemplateMixin
+	machinery.MultiGroupMixin
+	machinery.ResourceMixin
+	machinery.ProjectNameMixin
+
+	RoleName string
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *ClusterScopedRole) SetTemplateDefaults() error {
+	if f.Path == "" {
+		if f.MultiGroup && f.Resource.Group != "" {
+			f.Path = filepath.Join("config", "overlays", "namespaced", "%[group]_%[kind]_cluster_role.yaml")
+		} else {
+			f.Path = filepath.Join("config", "overlays", "namespaced", "%[kind]_cluster_role.yaml")
+		}
+	}
+	f.Path = f.Resource.Replacer().Replace(f.Path)
+
+	// The resources of the overlay are not prefixed by the kustomization of config/default
+	if f.RoleName == "" {
+		if f.MultiGroup && f.Resource.Group != "" {
+			f.RoleName = fmt.Sprintf("%s-%s-%s-cluster-role", f.ProjectName,
+				strings.ToLower(f.Resource.Group), strings.ToLower(f.Resource.Kind))
+		} else {
+			f.RoleName = fmt.Sprintf("%s-%s-cluster-role", f.ProjectName, strings.ToLower(f.Resource.Kind))
+		}
+	}
+
+	f.TemplateBody = clusterScopedRoleTemplate
+
+	f.IfExistsAction = machinery.SkipFile
+
+	return nil
+}
+
+const clusterScopedRoleTemplate = `# {{ .Resource.Kind }} is cluster-scoped, so the namespace-scoped Role of the manager of this overlay
+# can not grant the permissions on it. This ClusterRole grants them across the cluster instead.
+# Keep its rules in sync with the RBAC markers of the {{ .Resource.Kind }} controller.
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  labels:
+    app.kubernetes.io/name: {{ .ProjectName }}
+    app.kubernetes.io/managed-by: kustomize
+  name: {{ .RoleName }}
+rules:
+- apiGroups:
+  - {{ .Resource.QualifiedGroup }}
+  resources:
+  - {{ .Resource.Plural }}
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - {{ .Resource.QualifiedGroup }}
+  resources:
+  - {{ .Resource.Plural }}/finalizers
+  verbs:
+  - update
+- apiGroups:
+  - {{ .Resource.QualifiedGroup }}
+  resources:
+  - {{ .Resource.Plural }}/status
+  verbs:
+  - get
+  - patch
+  - update
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  labels:
+    app.kubernetes.io/name: {{ .ProjectName }}
+    app.kubernetes.io/managed-by: kustomize
+  name: {{ .RoleName }}binding
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: {{ .RoleName }}
+subjects:
+- kind: ServiceAccount
+  name: {{ .ProjectName }}-controller-manager
+  namespace: {{ .ProjectName }}-system
+`
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overlays
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &NamespacedKustomization{}
+
+// NamespacedKustomization scaffolds a file that defines the kustomization scheme for the overlay which
+// restricts the manager to the namespace where it runs
+type NamespacedKustomization struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+
+	// Managers are the names of the additional managers, which are also restricted to the namespace
+	Managers []string
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *NamespacedKustomization) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("config", "overlays", "namespaced", "kustomization.yaml")
+	}
+
+	f.TemplateBody = namespacedKustomizationTemplate
+
+	f.IfExistsAction = machinery.Error
+
+	return nil
+}
+
+const namespacedKustomizationTemplate = `# This overlay deploys the manager with namespace-scoped permissions, so that it only
+# watches and manages the resources of the namespace where it runs.
+# Deploy it with: kubectl apply -k config/overlays/namespaced
+resources:
+- ../../default
+
+patches:
+# Sets the WATCH_NAMESPACE env var, which restricts the cache of the manager to its own namespace.
+- path: manager_watch_namespace_patch.yaml
+  target:
+    kind: Deployment
+    {{- if .Managers }}
+    labelSelector: "control-plane in (controller-manager{{ range .Managers }},{{ . }}{{ end }})"
+    {{- else }}
+    labelSelector: "control-plane=controller-manager"
+    {{- end }}
+# controller-gen generates a ClusterRole in config/rbac/role.yaml unless all the RBAC markers
+# define a namespace. The following patches grant the same rules with a Role instead, and
+# are no-ops when config/rbac/role.yaml already defines a Role.
+- target:
+    kind: ClusterRole
+    name: ".*manager-role"
+  options:
+    allowKindChange: true
+  patch: |-
+    - op: replace
+      path: /kind
+      value: Role
+    - op: add
+      path: /metadata/namespace
+      value: {{ .ProjectName }}-system
+- target:
+    kind: ClusterRoleBinding
+    name: ".*manager-rolebinding"
+  options:
+    allowKindChange: true
+  patch: |-
+    - op: replace
+      path: /kind
+      value: RoleBinding
+    - op: replace
+      path: /roleRef/kind
+      value: Role
+    - op: add
+      path: /metadata/namespace
+      value: {{ .ProjectName }}-system
+`
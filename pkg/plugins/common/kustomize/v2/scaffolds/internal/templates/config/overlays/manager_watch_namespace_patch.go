@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overlays
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &ManagerWatchNamespacePatch{}
+
+// ManagerWatchNamespacePatch scaffolds a file that defines the patch which sets the WATCH_NAMESPACE
+// env var of the manager to the namespace where it runs
+type ManagerWatchNamespacePatch struct {
+	machinery.TemplateMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *ManagerWatchNamespacePatch) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("config", "overlays", "namespaced", "manager_watch_namespace_patch.yaml")
+	}
+
+	f.TemplateBody = managerWatchNamespacePatchTemplate
+
+	f.IfExistsAction = machinery.Error
+
+	return nil
+}
+
+const managerWatchNamespacePatchTemplate = `# This patch restricts the manager to the namespace where it runs.
+# To watch other namespaces, set a comma-separated list of namespaces as value instead, and
+# make sure that the manager is granted the required permissions in each of them.
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller-manager
+  namespace: system
+spec:
+  template:
+    spec:
+      containers:
+      - name: manager
+        env:
+        - name: WATCH_NAMESPACE
+          valueFrom:
+            fieldRef:
+              fieldPath: metadata.namespace
+`
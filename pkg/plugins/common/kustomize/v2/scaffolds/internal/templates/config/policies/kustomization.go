@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var (
+	_ machinery.Template = &Kustomization{}
+	_ machinery.Inserter = &Kustomization{}
+)
+
+// Kustomization scaffolds the kustomize component which deploys the ValidatingAdmissionPolicies
+type Kustomization struct {
+	machinery.TemplateMixin
+	machinery.ResourceMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *Kustomization) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("config", "policies", "kustomization.yaml")
+	}
+
+	f.TemplateBody = fmt.Sprintf(kustomizationTemplate, machinery.NewMarkerFor(f.Path, policiesMarker))
+
+	return nil
+}
+
+const policiesMarker = "policieskustomizeresource"
+
+// GetMarkers implements file.Inserter
+func (f *Kustomization) GetMarkers() []machinery.Marker {
+	return []machinery.Marker{machinery.NewMarkerFor(f.Path, policiesMarker)}
+}
+
+const policyCodeFragment = `- %s
+`
+
+// GetCodeFragments implements file.Inserter
+func (f *Kustomization) GetCodeFragments() machinery.CodeFragmentsMap {
+	return machinery.CodeFragmentsMap{
+		machinery.NewMarkerFor(f.Path, policiesMarker): []string{
+			fmt.Sprintf(policyCodeFragment, filepath.Base(policyPath(f.Resource))),
+		},
+	}
+}
+
+const kustomizationTemplate = `# The ValidatingAdmissionPolicies validate the custom resources with CEL expressions evaluated
+# by the API server (Kubernetes 1.30+), instead of validating webhooks served by the manager.
+# More info: https://kubernetes.io/docs/reference/access-authn-authz/validating-admission-policy/
+apiVersion: kustomize.config.k8s.io/v1alpha1
+kind: Component
+
+resources:
+%s
+
+# The following config is for teaching kustomize how to reference the policies from their bindings.
+configurations:
+- kustomizeconfig.yaml
+`
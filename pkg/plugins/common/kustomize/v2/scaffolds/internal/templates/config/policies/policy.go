@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+var _ machinery.Template = &Policy{}
+
+// Validation is a CEL expression of a ValidatingAdmissionPolicy and the message returned when it fails
+type Validation struct {
+	Expression string
+	Message    string
+}
+
+// Policy scaffolds the ValidatingAdmissionPolicy of a resource and its binding
+type Policy struct {
+	machinery.TemplateMixin
+	machinery.ResourceMixin
+	machinery.ProjectNameMixin
+
+	// Validations are the CEL expressions stubbed from the validation markers of the kind
+	Validations []Validation
+
+	Force bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *Policy) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = policyPath(f.Resource)
+	}
+
+	f.TemplateBody = policyTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.Error
+	}
+
+	return nil
+}
+
+// policyPath returns the path of the ValidatingAdmissionPolicy of the resource
+func policyPath(res *resource.Resource) string {
+	if res.Group != "" {
+		return res.Replacer().Replace(filepath.Join("config", "policies", "%[group]_%[version]_%[kind].yaml"))
+	}
+	return res.Replacer().Replace(filepath.Join("config", "policies", "%[version]_%[kind].yaml"))
+}
+
+//nolint:lll
+const policyTemplate = `# This ValidatingAdmissionPolicy validates the {{ .Resource.Kind }} resources with CEL expressions,
+# which are evaluated by the API server (Kubernetes 1.30+) when the resources are created or updated.
+{{- if .Validations }}
+# The expressions below were stubbed from the validation markers of {{ .Resource.Kind }}Spec, which are
+# already enforced by the schema of the CRD.
+{{- end }}
+# TODO(user): Add the validation rules of your API, e.g. across fields or comparing with oldObject on updates.
+# More info: https://kubernetes.io/docs/reference/access-authn-authz/validating-admission-policy/
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingAdmissionPolicy
+metadata:
+  labels:
+    app.kubernetes.io/name: {{ .ProjectName }}
+    app.kubernetes.io/managed-by: kustomize
+  name: {{ lower .Resource.Kind }}-{{ .Resource.Version }}-validation
+spec:
+  failurePolicy: Fail
+  matchConstraints:
+    resourceRules:
+    - apiGroups: ["{{ .Resource.QualifiedGroup }}"]
+      apiVersions: ["{{ .Resource.Version }}"]
+      operations: ["CREATE", "UPDATE"]
+      resources: ["{{ .Resource.Plural }}"]
+  validations:
+  {{- range .Validations }}
+  - expression: {{ printf "%q" .Expression }}
+    message: {{ printf "%q" .Message }}
+  {{- else }}
+  # e.g. - expression: "object.spec.replicas <= 5"
+  #        message: "spec.replicas must be no greater than 5"
+  - expression: "true"
+  {{- end }}
+---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingAdmissionPolicyBinding
+metadata:
+  labels:
+    app.kubernetes.io/name: {{ .ProjectName }}
+    app.kubernetes.io/managed-by: kustomize
+  name: {{ lower .Resource.Kind }}-{{ .Resource.Version }}-validation-binding
+spec:
+  policyName: {{ lower .Resource.Kind }}-{{ .Resource.Version }}-validation
+  validationActions: ["Deny"]
+`
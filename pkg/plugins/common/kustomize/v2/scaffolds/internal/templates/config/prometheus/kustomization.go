@@ -24,7 +24,7 @@ import (
 
 var _ machinery.Template = &Kustomization{}
 
-// Kustomization scaffolds a file that defines the kustomization scheme for the prometheus folder
+// Kustomization scaffolds a file that defines the kustomize component for the prometheus folder
 type Kustomization struct {
 	machinery.TemplateMixin
 }
@@ -40,13 +40,16 @@ func (f *Kustomization) SetTemplateDefaults() error {
 	return nil
 }
 
-const kustomizationTemplate = `resources:
+const kustomizationTemplate = `apiVersion: kustomize.config.k8s.io/v1alpha1
+kind: Component
+
+resources:
 - monitor.yaml
 
 # [PROMETHEUS-WITH-CERTS] The following patch configures the ServiceMonitor in ../prometheus
 # to securely reference certificates created and managed by cert-manager.
-# Additionally, ensure that you uncomment the [METRICS WITH CERTMANAGER] patch under config/default/kustomization.yaml
-# to mount the "metrics-server-cert" secret in the Manager Deployment.
+# Additionally, ensure that you uncomment the [METRICS-WITH-CERTS] sections under config/default/kustomization.yaml
+# and config/certmanager/kustomization.yaml to mount the "metrics-server-cert" secret in the Manager Deployment.
 #patches:
 #  - path: monitor_tls_patch.yaml
 #    target:
@@ -27,6 +27,9 @@ var _ machinery.Template = &Kustomization{}
 // Kustomization scaffolds a file that defines the kustomization scheme for the rbac folder
 type Kustomization struct {
 	machinery.TemplateMixin
+
+	// WebhookOnly omits the leader election permissions, which are not used by webhook-only projects
+	WebhookOnly bool
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -51,8 +54,10 @@ const kustomizeRBACTemplate = `resources:
 - service_account.yaml
 - role.yaml
 - role_binding.yaml
+{{- if not .WebhookOnly }}
 - leader_election_role.yaml
 - leader_election_role_binding.yaml
+{{- end }}
 # The following RBAC configurations are used to protect
 # the metrics endpoint with authn/authz. These configurations
 # ensure that only authorized users and service accounts
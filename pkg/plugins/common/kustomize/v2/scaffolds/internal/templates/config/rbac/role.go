@@ -28,6 +28,9 @@ var _ machinery.Template = &Role{}
 type Role struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+
+	// Namespaced scaffolds a Role instead of a ClusterRole
+	Namespaced bool
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -44,12 +47,19 @@ func (f *Role) SetTemplateDefaults() error {
 }
 
 const managerRoleTemplate = `apiVersion: rbac.authorization.k8s.io/v1
+{{- if .Namespaced }}
+kind: Role
+{{- else }}
 kind: ClusterRole
+{{- end }}
 metadata:
   labels:
     app.kubernetes.io/name: {{ .ProjectName }}
     app.kubernetes.io/managed-by: kustomize
   name: manager-role
+{{- if .Namespaced }}
+  namespace: system
+{{- end }}
 rules:
 - apiGroups: [""]
   resources: ["pods"]
@@ -28,6 +28,9 @@ var _ machinery.Template = &RoleBinding{}
 type RoleBinding struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+
+	// Namespaced scaffolds a RoleBinding to the manager Role instead of a ClusterRoleBinding
+	Namespaced bool
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -42,15 +45,26 @@ func (f *RoleBinding) SetTemplateDefaults() error {
 }
 
 const managerBindingTemplate = `apiVersion: rbac.authorization.k8s.io/v1
+{{- if .Namespaced }}
+kind: RoleBinding
+{{- else }}
 kind: ClusterRoleBinding
+{{- end }}
 metadata:
   labels:
     app.kubernetes.io/name: {{ .ProjectName }}
     app.kubernetes.io/managed-by: kustomize
   name: manager-rolebinding
+{{- if .Namespaced }}
+  namespace: system
+{{- end }}
 roleRef:
   apiGroup: rbac.authorization.k8s.io
+{{- if .Namespaced }}
+  kind: Role
+{{- else }}
   kind: ClusterRole
+{{- end }}
   name: manager-role
 subjects:
 - kind: ServiceAccount
@@ -24,12 +24,16 @@ import (
 
 var _ machinery.Template = &Kustomization{}
 
-// Kustomization scaffolds a file that defines the kustomization scheme for the webhook folder
+// Kustomization scaffolds a file that defines the kustomize component for the webhook folder
 type Kustomization struct {
 	machinery.TemplateMixin
 	machinery.ResourceMixin
 
 	Force bool
+
+	// Legacy scaffolds a kustomization instead of a component, for the projects whose
+	// config/default/kustomization.yaml lists the webhook folder as a resource
+	Legacy bool
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -38,7 +42,11 @@ func (f *Kustomization) SetTemplateDefaults() error {
 		f.Path = filepath.Join("config", "webhook", "kustomization.yaml")
 	}
 
-	f.TemplateBody = kustomizeWebhookTemplate
+	if f.Legacy {
+		f.TemplateBody = legacyKustomizeWebhookTemplate
+	} else {
+		f.TemplateBody = kustomizeWebhookTemplate
+	}
 
 	if f.Force {
 		f.IfExistsAction = machinery.OverwriteFile
@@ -50,7 +58,24 @@ func (f *Kustomization) SetTemplateDefaults() error {
 	return nil
 }
 
-const kustomizeWebhookTemplate = `resources:
+const kustomizeWebhookTemplate = `apiVersion: kustomize.config.k8s.io/v1alpha1
+kind: Component
+
+resources:
+- manifests{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}.{{ .Resource.Webhooks.WebhookVersion }}{{ end }}.yaml
+- service.yaml
+
+configurations:
+- kustomizeconfig.yaml
+
+patches:
+# The following patch mounts the webhook certificates and exposes the webhook server port in the manager
+- path: manager_webhook_patch.yaml
+  target:
+    kind: Deployment
+`
+
+const legacyKustomizeWebhookTemplate = `resources:
 - manifests{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}.{{ .Resource.Webhooks.WebhookVersion }}{{ end }}.yaml
 - service.yaml
 
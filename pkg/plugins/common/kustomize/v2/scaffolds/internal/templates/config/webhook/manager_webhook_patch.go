@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package kdefault
+package webhook
 
 import (
 	"path/filepath"
@@ -35,7 +35,7 @@ type ManagerWebhookPatch struct {
 // SetTemplateDefaults implements machinery.Template
 func (f *ManagerWebhookPatch) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", "default", "manager_webhook_patch.yaml")
+		f.Path = filepath.Join("config", "webhook", "manager_webhook_patch.yaml")
 	}
 
 	f.TemplateBody = managerWebhookPatchTemplate
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/policies"
+)
+
+const validationMarkerPrefix = "// +kubebuilder:validation:"
+
+var (
+	jsonTagRegex       = regexp.MustCompile(`json:"([^,"]*)([^"]*)"`)
+	celIdentifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// specValidations returns the CEL expressions of the ValidatingAdmissionPolicy of the resource, stubbed
+// from the validation markers of the fields of its spec. No expressions are returned when the types of
+// the resource can not be found, e.g. for the external APIs.
+func specValidations(fs afero.Fs, cfg config.Config, res resource.Resource) ([]policies.Validation, error) {
+	if res.External || res.Core {
+		return nil, nil
+	}
+
+	dir := strings.TrimPrefix(strings.TrimPrefix(res.Path, cfg.GetRepository()), "/")
+	typesFile := filepath.Join(dir, strings.ToLower(res.Kind)+"_types.go")
+	content, err := afero.ReadFile(fs, typesFile)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read %s: %w", typesFile, err)
+	}
+
+	var (
+		validations []policies.Validation
+		markers     []string
+		optional    bool
+		depth       int
+		inSpec      bool
+	)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if !inSpec {
+			if line == fmt.Sprintf("type %sSpec struct {", res.Kind) {
+				inSpec, depth = true, 1
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, validationMarkerPrefix):
+			marker := strings.TrimPrefix(line, validationMarkerPrefix)
+			if marker == "Optional" {
+				optional = true
+			} else {
+				markers = append(markers, marker)
+			}
+			continue
+		case line == "// +optional":
+			optional = true
+			continue
+		case strings.HasPrefix(line, "//") || line == "":
+			continue
+		}
+
+		// Only the fields of the spec itself are validated, not the fields of the nested structs
+		if depth == 1 {
+			if matches := jsonTagRegex.FindStringSubmatch(line); matches != nil {
+				field := matches[1]
+				if celIdentifierRegex.MatchString(field) {
+					optional = optional || strings.Contains(matches[2], "omitempty")
+					validations = append(validations, fieldValidations(field, markers, optional)...)
+				}
+			}
+		}
+		markers, optional = nil, false
+
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		if depth == 0 {
+			break
+		}
+	}
+	return validations, nil
+}
+
+// fieldValidations returns the CEL expressions which check the validation markers of a field of the spec
+func fieldValidations(field string, markers []string, optional bool) []policies.Validation {
+	path := "object.spec." + field
+	name := "spec." + field
+
+	var validations []policies.Validation
+	for _, marker := range markers {
+		key, value, _ := strings.Cut(marker, "=")
+		value = strings.TrimSpace(value)
+
+		var expression, message string
+		switch key {
+		case "Required":
+			validations = append(validations, policies.Validation{
+				Expression: fmt.Sprintf("has(%s)", path),
+				Message:    fmt.Sprintf("%s is required", name),
+			})
+			continue
+		case "Minimum":
+			expression = fmt.Sprintf("%s >= %s", path, value)
+			message = fmt.Sprintf("%s must be greater than or equal to %s", name, value)
+		case "Maximum":
+			expression = fmt.Sprintf("%s <= %s", path, value)
+			message = fmt.Sprintf("%s must be less than or equal to %s", name, value)
+		case "MultipleOf":
+			expression = fmt.Sprintf("%s %% %s == 0", path, value)
+			message = fmt.Sprintf("%s must be a multiple of %s", name, value)
+		case "MinLength":
+			expression = fmt.Sprintf("size(%s) >= %s", path, value)
+			message = fmt.Sprintf("%s must be at least %s characters long", name, value)
+		case "MaxLength":
+			expression = fmt.Sprintf("size(%s) <= %s", path, value)
+			message = fmt.Sprintf("%s must be at most %s characters long", name, value)
+		case "MinItems":
+			expression = fmt.Sprintf("size(%s) >= %s", path, value)
+			message = fmt.Sprintf("%s must have at least %s items", name, value)
+		case "MaxItems":
+			expression = fmt.Sprintf("size(%s) <= %s", path, value)
+			message = fmt.Sprintf("%s must have at most %s items", name, value)
+		case "Pattern":
+			pattern := strings.Trim(value, "`\"")
+			if strings.Contains(pattern, "'") {
+				continue
+			}
+			expression = fmt.Sprintf("%s.matches(r'%s')", path, pattern)
+			message = fmt.Sprintf("%s must match the pattern %s", name, pattern)
+		case "Enum":
+			values := strings.Split(value, ";")
+			for i, v := range values {
+				v = strings.Trim(strings.TrimSpace(v), "`\"")
+				if _, err := strconv.ParseFloat(v, 64); err != nil {
+					v = "'" + v + "'"
+				}
+				values[i] = v
+			}
+			expression = fmt.Sprintf("%s in [%s]", path, strings.Join(values, ", "))
+			message = fmt.Sprintf("%s must be one of %s", name, strings.Join(values, ", "))
+		default:
+			continue
+		}
+
+		if value == "" {
+			continue
+		}
+		if optional {
+			expression = fmt.Sprintf("!has(%s) || %s", path, expression)
+		}
+		validations = append(validations, policies.Validation{Expression: expression, Message: message})
+	}
+	return validations
+}
@@ -18,6 +18,8 @@ package scaffolds
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
@@ -28,8 +30,8 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/certmanager"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/crd"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/crd/patches"
-	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/kdefault"
 	network_policy "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/network-policy"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/policies"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds/internal/templates/config/webhook"
 )
 
@@ -78,23 +80,57 @@ func (s *webhookScaffolder) Scaffold() error {
 		return fmt.Errorf("error updating resource: %w", err)
 	}
 
+	// Projects scaffolded before the optional features were provided as kustomize components
+	// list them as commented resources in config/default/kustomization.yaml
+	legacy := !hasComponentsLayout()
+
+	if s.resource.HasValidatingAdmissionPolicy() {
+		if err := s.scaffoldValidatingAdmissionPolicy(scaffold, legacy); err != nil {
+			return fmt.Errorf("error scaffolding the ValidatingAdmissionPolicy: %w", err)
+		}
+	}
+
+	// The ValidatingAdmissionPolicy is evaluated by the API server, so the webhook server is not deployed for it
+	if !s.resource.Webhooks.HasWebhookServer() {
+		return nil
+	}
+
+	// Projects scaffolded before the NetworkPolicies were split into kustomize components
+	// list all of them in a single kustomization file
+	_, err := s.fs.FS.Stat(legacyPolicyKustomizeFilePath)
+	hasLegacyPolicyLayout := err == nil
+
+	policyAllowWebhooks := &network_policy.PolicyAllowWebhooks{}
+	if hasLegacyPolicyLayout {
+		policyAllowWebhooks.Path = filepath.Join("config", "network-policy", "allow-webhook-traffic.yaml")
+	}
+
+	managerWebhookPatch := &webhook.ManagerWebhookPatch{}
+	if legacy {
+		managerWebhookPatch.Path = filepath.Join("config", "default", "manager_webhook_patch.yaml")
+	}
+
 	buildScaffold := []machinery.Builder{
-		&kdefault.ManagerWebhookPatch{},
-		&webhook.Kustomization{Force: s.force},
+		managerWebhookPatch,
+		&webhook.Kustomization{Force: s.force, Legacy: legacy},
 		&webhook.KustomizeConfig{},
 		&webhook.Service{},
 		&certmanager.Certificate{},
 		&certmanager.Issuer{},
 		&certmanager.MetricsCertificate{},
-		&certmanager.Kustomization{},
+		&certmanager.Kustomization{Legacy: legacy},
 		&certmanager.KustomizeConfig{},
-		&network_policy.PolicyAllowWebhooks{},
+		policyAllowWebhooks,
+	}
+
+	if !hasLegacyPolicyLayout {
+		buildScaffold = append(buildScaffold, &network_policy.Kustomization{Component: network_policy.WebhookComponent})
 	}
 
 	// Only scaffold the following patches if is a conversion webhook
 	if s.resource.Webhooks.Conversion {
 		buildScaffold = append(buildScaffold, &patches.EnableWebhookPatch{})
-		buildScaffold = append(buildScaffold, &kdefault.KustomizationCAConversionUpdater{})
+		buildScaffold = append(buildScaffold, &certmanager.KustomizationCAConversionUpdater{Legacy: legacy})
 	}
 
 	if !s.resource.External && !s.resource.Core {
@@ -105,66 +141,122 @@ func (s *webhookScaffolder) Scaffold() error {
 		return fmt.Errorf("error scaffolding kustomize webhook manifests: %v", err)
 	}
 
-	policyKustomizeFilePath := "config/network-policy/kustomization.yaml"
-	err := pluginutil.InsertCodeIfNotExist(policyKustomizeFilePath,
-		"resources:", allowWebhookTrafficFragment)
+	if hasLegacyPolicyLayout {
+		err = pluginutil.InsertCodeIfNotExist(legacyPolicyKustomizeFilePath,
+			"resources:", allowWebhookTrafficFragment)
+		if err != nil {
+			log.Errorf("Unable to add the line '- allow-webhook-traffic.yaml' at the end of the file"+
+				"%s to allow webhook traffic.", legacyPolicyKustomizeFilePath)
+		}
+	}
+
+	if legacy {
+		enableLegacyWebhook()
+	} else if err := enableComponent(WebhookComponent); err != nil {
+		log.Errorf("Unable to enable the webhook component in the file %s: %v", defaultKustomizationPath, err)
+	}
+
+	if s.resource.Webhooks.Conversion {
+		crdKustomizationsFilePath := "config/crd/kustomization.yaml"
+		err = pluginutil.UncommentCode(crdKustomizationsFilePath, "#configurations:\n#- kustomizeconfig.yaml", `#`)
+		if err != nil {
+			hasWebHookUncommented, err := pluginutil.HasFileContentWith(crdKustomizationsFilePath,
+				"configurations:\n- kustomizeconfig.yaml")
+			if !hasWebHookUncommented || err != nil {
+				log.Warningf("Unable to find the target(s) configurations.kustomizeconfig.yaml "+
+					"to uncomment in the file "+
+					"%s.", crdKustomizationsFilePath)
+			}
+		}
+
+		if !legacy {
+			enableConversionCAInjection()
+		}
+	}
+
+	return nil
+}
+
+// scaffoldValidatingAdmissionPolicy scaffolds the ValidatingAdmissionPolicy of the resource and its binding,
+// stubbed from the validation markers of the kind, and enables the policies component
+func (s *webhookScaffolder) scaffoldValidatingAdmissionPolicy(scaffold *machinery.Scaffold, legacy bool) error {
+	validations, err := specValidations(s.fs.FS, s.config, s.resource)
 	if err != nil {
-		log.Errorf("Unable to add the line '- allow-webhook-traffic.yaml' at the end of the file"+
-			"%s to allow webhook traffic.", policyKustomizeFilePath)
+		return err
 	}
 
-	kustomizeFilePath := "config/default/kustomization.yaml"
-	err = pluginutil.UncommentCode(kustomizeFilePath, "#- ../webhook", `#`)
+	if err := scaffold.Execute(
+		&policies.Policy{Validations: validations, Force: s.force},
+		&policies.Kustomization{},
+		&policies.KustomizeConfig{},
+	); err != nil {
+		return err
+	}
+
+	if legacy {
+		log.Warnf("Add the component ../policies to %s to deploy the ValidatingAdmissionPolicies",
+			defaultKustomizationPath)
+	} else if err := enableComponent(PoliciesComponent); err != nil {
+		log.Errorf("Unable to enable the policies component in the file %s, add '- ../policies' to its "+
+			"components: %v", defaultKustomizationPath, err)
+	}
+	return nil
+}
+
+// enableConversionCAInjection uncomments the replacements which inject the CA in the CRDs
+// with conversion webhooks in the certmanager component
+func enableConversionCAInjection() {
+	certManagerKustomizationPath := "config/certmanager/kustomization.yaml"
+	for _, target := range []string{conversionCAInjectionNamespaceSource, conversionCAInjectionNameSource} {
+		if err := pluginutil.UncommentCode(certManagerKustomizationPath, target, `#`); err != nil {
+			hasUncommented, err := pluginutil.HasFileContentWith(certManagerKustomizationPath,
+				strings.ReplaceAll(target, "\n#", "\n"))
+			if !hasUncommented || err != nil {
+				log.Errorf("Unable to find the CA injection replacement for conversion webhooks "+
+					"to uncomment in the file %s.", certManagerKustomizationPath)
+			}
+		}
+	}
+}
+
+// enableLegacyWebhook uncomments the webhook sections of config/default/kustomization.yaml for the projects
+// which list the optional features as commented resources
+func enableLegacyWebhook() {
+	err := pluginutil.UncommentCode(defaultKustomizationPath, "#- ../webhook", `#`)
 	if err != nil {
-		hasWebHookUncommented, err := pluginutil.HasFileContentWith(kustomizeFilePath, "- ../webhook")
+		hasWebHookUncommented, err := pluginutil.HasFileContentWith(defaultKustomizationPath, "- ../webhook")
 		if !hasWebHookUncommented || err != nil {
 			log.Errorf("Unable to find the target #- ../webhook to uncomment in the file "+
-				"%s.", kustomizeFilePath)
+				"%s.", defaultKustomizationPath)
 		}
 	}
 
-	err = pluginutil.UncommentCode(kustomizeFilePath, "#patches:", `#`)
+	err = pluginutil.UncommentCode(defaultKustomizationPath, "#patches:", `#`)
 	if err != nil {
-		hasWebHookUncommented, err := pluginutil.HasFileContentWith(kustomizeFilePath, "patches:")
+		hasWebHookUncommented, err := pluginutil.HasFileContentWith(defaultKustomizationPath, "patches:")
 		if !hasWebHookUncommented || err != nil {
 			log.Errorf("Unable to find the line '#patches:' to uncomment in the file "+
-				"%s.", kustomizeFilePath)
+				"%s.", defaultKustomizationPath)
 		}
 	}
 
-	err = pluginutil.UncommentCode(kustomizeFilePath, `#- path: manager_webhook_patch.yaml
+	err = pluginutil.UncommentCode(defaultKustomizationPath, `#- path: manager_webhook_patch.yaml
 #  target:
 #    kind: Deployment`, `#`)
 	if err != nil {
-		hasWebHookUncommented, err := pluginutil.HasFileContentWith(kustomizeFilePath, "- path: manager_webhook_patch.yaml")
+		hasWebHookUncommented, err := pluginutil.HasFileContentWith(defaultKustomizationPath,
+			"- path: manager_webhook_patch.yaml")
 		if !hasWebHookUncommented || err != nil {
 			log.Errorf("Unable to find the target #- path: manager_webhook_patch.yaml to uncomment in the file "+
-				"%s.", kustomizeFilePath)
+				"%s.", defaultKustomizationPath)
 		}
 	}
-
-	if s.resource.Webhooks.Conversion {
-		crdKustomizationsFilePath := "config/crd/kustomization.yaml"
-		err = pluginutil.UncommentCode(crdKustomizationsFilePath, "#configurations:\n#- kustomizeconfig.yaml", `#`)
-		if err != nil {
-			hasWebHookUncommented, err := pluginutil.HasFileContentWith(crdKustomizationsFilePath,
-				"configurations:\n- kustomizeconfig.yaml")
-			if !hasWebHookUncommented || err != nil {
-				log.Warningf("Unable to find the target(s) configurations.kustomizeconfig.yaml "+
-					"to uncomment in the file "+
-					"%s.", crdKustomizationsFilePath)
-			}
-		}
-	}
-
-	return nil
 }
 
 // Deprecated: remove it when go/v4 and/or kustomize/v2 be removed
 // validateScaffoldedProject will output a message to help users fix their scaffold
 func validateScaffoldedProject() {
-	kustomizeFilePath := "config/default/kustomization.yaml"
-	hasCertManagerPatch, _ := pluginutil.HasFileContentWith(kustomizeFilePath,
+	hasCertManagerPatch, _ := pluginutil.HasFileContentWith(defaultKustomizationPath,
 		"crdkustomizecainjectionpatch")
 
 	if hasCertManagerPatch {
@@ -223,5 +315,23 @@ the latest bug fixes and enhancements.
 	}
 }
 
+const legacyPolicyKustomizeFilePath = "config/network-policy/kustomization.yaml"
+
+const conversionCAInjectionNamespaceSource = `#- source:
+#    kind: Certificate
+#    group: cert-manager.io
+#    version: v1
+#    name: serving-cert
+#    fieldPath: .metadata.namespace # Namespace of the certificate CR
+#  targets: # Do not remove the following scaffold marker; required to generate code for target CRD.`
+
+const conversionCAInjectionNameSource = `#- source:
+#    kind: Certificate
+#    group: cert-manager.io
+#    version: v1
+#    name: serving-cert
+#    fieldPath: .metadata.name
+#  targets: # Do not remove the following scaffold marker; required to generate code for target CRD.`
+
 const allowWebhookTrafficFragment = `
 - allow-webhook-traffic.yaml`
@@ -369,6 +369,8 @@ func setExternalPluginMetadata(subcommand, path string, subcmdMeta *plugin.Subco
 		if res.Examples != "" {
 			subcmdMeta.Examples = res.Examples
 		}
+
+		subcmdMeta.Flags = append(subcmdMeta.Flags, res.Flags...)
 	}
 }
 
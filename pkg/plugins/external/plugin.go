@@ -21,7 +21,11 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 )
 
-var _ plugin.Full = Plugin{}
+var (
+	_ plugin.Full           = Plugin{}
+	_ plugin.Deprecated     = Plugin{}
+	_ plugin.HasDeprecation = Plugin{}
+)
 
 // Plugin implements the plugin.Full interface
 type Plugin struct {
@@ -74,7 +78,13 @@ func (p Plugin) GetEditSubcommand() plugin.EditSubcommand {
 	}
 }
 
+// Deprecated define whether the plugin is deprecated and its deprecation message
+func (p Plugin) Deprecated() (bool, string) {
+	return false, ""
+}
+
 // DeprecationWarning define the deprecation message or return empty when plugin is not deprecated
 func (p Plugin) DeprecationWarning() string {
-	return ""
+	_, warning := p.Deprecated()
+	return warning
 }
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package golang
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+// ControllerLayout is the placement of the controllers in the project
+type ControllerLayout string
+
+const (
+	// InternalControllerLayout places the controllers under internal/controller, and under
+	// internal/controller/<group> in multi-group projects. It is the default layout.
+	InternalControllerLayout ControllerLayout = "internal"
+	// ControllersLayout places the controllers under controllers, and under controllers/<group>
+	// in multi-group projects
+	ControllersLayout ControllerLayout = "controllers"
+	// GroupControllerLayout places the controllers of each group under internal/<group>/controller
+	GroupControllerLayout ControllerLayout = "group"
+)
+
+// ControllerLayouts are the supported placements of the controllers
+var ControllerLayouts = []ControllerLayout{InternalControllerLayout, ControllersLayout, GroupControllerLayout}
+
+// ControllerLayoutNames returns the names of the supported placements of the controllers
+func ControllerLayoutNames() []string {
+	names := make([]string, 0, len(ControllerLayouts))
+	for _, layout := range ControllerLayouts {
+		names = append(names, string(layout))
+	}
+	return names
+}
+
+// ValidateControllerLayout returns an error if the layout is not supported
+func ValidateControllerLayout(layout string) error {
+	names := ControllerLayoutNames()
+	if !slices.Contains(names, layout) {
+		return fmt.Errorf("invalid controller layout %q, must be one of '%s'", layout, strings.Join(names, "', '"))
+	}
+	return nil
+}
+
+// OrDefault returns the layout, or the default layout when it is empty
+func (l ControllerLayout) OrDefault() ControllerLayout {
+	if l == "" {
+		return InternalControllerLayout
+	}
+	return l
+}
+
+// IsGrouped returns true if the controllers of the resource are placed in the package of its group,
+// which is then imported with the alias returned by ImportAlias
+func (l ControllerLayout) IsGrouped(res resource.Resource, multiGroup bool) bool {
+	return l.OrDefault() == GroupControllerLayout || (multiGroup && res.Group != "")
+}
+
+// Dir returns the directory of the controllers of the resource, relative to the root of the project
+func (l ControllerLayout) Dir(res resource.Resource, multiGroup bool) string {
+	switch l.OrDefault() {
+	case ControllersLayout:
+		if multiGroup && res.Group != "" {
+			return path.Join("controllers", res.Group)
+		}
+		return "controllers"
+	case GroupControllerLayout:
+		return path.Join("internal", res.PackageName(), "controller")
+	default:
+		if multiGroup && res.Group != "" {
+			return path.Join("internal", "controller", res.Group)
+		}
+		return path.Join("internal", "controller")
+	}
+}
+
+// PackageName returns the name of the package of the controllers of the resource
+func (l ControllerLayout) PackageName(res resource.Resource, multiGroup bool) string {
+	switch {
+	case l.OrDefault() == GroupControllerLayout:
+		return "controller"
+	case multiGroup && res.Group != "":
+		return res.PackageName()
+	case l.OrDefault() == ControllersLayout:
+		return "controllers"
+	default:
+		return "controller"
+	}
+}
+
+// ImportAlias returns the alias of the package of the controllers of the resource in cmd/main.go,
+// which tells apart the packages of the groups, or its package name when the project has one package
+func (l ControllerLayout) ImportAlias(res resource.Resource, multiGroup bool) string {
+	if l.IsGrouped(res, multiGroup) {
+		return res.PackageName() + "controller"
+	}
+	return l.PackageName(res, multiGroup)
+}
+
+// RootRelativePath returns the path from the directory of the controllers of the resource
+// to the root of the project, e.g. "../.." for internal/controller
+func (l ControllerLayout) RootRelativePath(res resource.Resource, multiGroup bool) string {
+	depth := strings.Count(l.Dir(res, multiGroup), "/") + 1
+	return strings.TrimSuffix(strings.Repeat("../", depth), "/")
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package golang
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+var _ = Describe("ControllerLayout", func() {
+	var (
+		crew = resource.Resource{GVK: resource.GVK{Group: "crew", Domain: "test.io", Version: "v1", Kind: "Captain"}}
+		core = resource.Resource{GVK: resource.GVK{Group: "", Domain: "core", Version: "v1", Kind: "Pod"}}
+	)
+
+	DescribeTable("should place the controllers",
+		func(layout ControllerLayout, res resource.Resource, multiGroup bool,
+			dir, packageName, alias, rootPath string,
+		) {
+			Expect(layout.Dir(res, multiGroup)).To(Equal(dir))
+			Expect(layout.PackageName(res, multiGroup)).To(Equal(packageName))
+			Expect(layout.ImportAlias(res, multiGroup)).To(Equal(alias))
+			Expect(layout.RootRelativePath(res, multiGroup)).To(Equal(rootPath))
+		},
+		Entry("under internal/controller by default", ControllerLayout(""), crew, false,
+			"internal/controller", "controller", "controller", "../.."),
+		Entry("under internal/controller/<group> in multi-group projects", InternalControllerLayout, crew, true,
+			"internal/controller/crew", "crew", "crewcontroller", "../../.."),
+		Entry("under internal/controller for the core types in multi-group projects", InternalControllerLayout,
+			core, true, "internal/controller", "controller", "controller", "../.."),
+		Entry("under controllers", ControllersLayout, crew, false,
+			"controllers", "controllers", "controllers", ".."),
+		Entry("under controllers/<group> in multi-group projects", ControllersLayout, crew, true,
+			"controllers/crew", "crew", "crewcontroller", "../.."),
+		Entry("under internal/<group>/controller", GroupControllerLayout, crew, false,
+			"internal/crew/controller", "controller", "crewcontroller", "../../.."),
+		Entry("under internal/<domain>/controller for the core types", GroupControllerLayout, core, true,
+			"internal/core/controller", "controller", "corecontroller", "../../.."),
+	)
+
+	It("should validate the layout", func() {
+		for _, layout := range ControllerLayoutNames() {
+			Expect(ValidateControllerLayout(layout)).To(Succeed())
+		}
+		Expect(ValidateControllerLayout("")).NotTo(Succeed())
+		Expect(ValidateControllerLayout("pkg")).To(MatchError(ContainSubstring("must be one of")))
+	})
+})
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -30,6 +31,7 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/util"
+	kustomizecommonv2scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
 	goPlugin "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1/scaffolds"
 )
@@ -60,8 +62,26 @@ type createAPISubcommand struct {
 
 	// runAsUser indicates the user-id used for running the container
 	runAsUser string
+
+	// imagePullPolicy indicates the pull policy of the image of the container
+	imagePullPolicy string
+
+	// imageCPULimit indicates the default CPU limit of the container
+	imageCPULimit string
+
+	// imageMemoryLimit indicates the default memory limit of the container
+	imageMemoryLimit string
+
+	// livenessProbePath indicates the default HTTP path of the liveness probe of the container
+	livenessProbePath string
+
+	// readinessProbePath indicates the default HTTP path of the readiness probe of the container
+	readinessProbePath string
 }
 
+// quantityRegex matches the resource quantities, such as 500m, 0.5 or 128Mi
+var quantityRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?(m|k|M|G|T|P|E|Ki|Mi|Gi|Ti|Pi|Ei)?$`)
+
 func (p *createAPISubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
 	//nolint:lll
 	subcmdMeta.Description = `Scaffold the code implementation to deploy and manage your Operand which is represented by the API informed and will be reconciled by its controller. This plugin will generate the code implementation to help you out.
@@ -87,9 +107,15 @@ func (p *createAPISubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdM
 			Name:          "Memcached",
 		},
 
+	- By informing the resource limits (--image-cpu-limit and --image-memory-limit) and the probe paths
+	(--liveness-probe-path and --readiness-probe-path), the container will be scaffold with, i.e.:
+
+		Resources: Memcached.Spec.Resources,
+		LivenessProbe: &corev1.Probe{ ... Path: Memcached.Spec.LivenessProbePath ... },
+
 	Therefore, the default values informed will be used to scaffold specs for the API.
 
-  %[1]s create api --group example.com --version v1alpha1 --kind Memcached --image=memcached:1.6.15-alpine --image-container-command="memcached --memory-limit=64 modern -v" --image-container-port="11211" --plugins="%[2]s" --make=false --namespaced=false
+  %[1]s create api --group example.com --version v1alpha1 --kind Memcached --image=memcached:1.6.15-alpine --image-container-command="memcached --memory-limit=64 modern -v" --image-container-port="11211" --plugins="%[2]s" --make=false
 
   # Generate the manifests
   make manifests
@@ -100,6 +126,11 @@ func (p *createAPISubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdM
   # Regenerate code and run against the Kubernetes cluster configured by ~/.kube/config
   make run
 `, cliMeta.CommandName, plugin.KeyFor(Plugin{}))
+
+	subcmdMeta.Flags = append(subcmdMeta.Flags, plugin.FlagMetadata{
+		Name: "image-pull-policy",
+		Enum: []string{"Always", "IfNotPresent", "Never"},
+	})
 }
 
 func (p *createAPISubcommand) BindFlags(fs *pflag.FlagSet) {
@@ -114,6 +145,21 @@ func (p *createAPISubcommand) BindFlags(fs *pflag.FlagSet) {
 		"will be used to scaffold the container port that should be used by container image in "+
 		"the controller and its spec in the API (CRD/CR). (i.e --image-container-port=\"11211\") ")
 	fs.StringVar(&p.runAsUser, "run-as-user", "", "User-Id for the container formed will be set to this value")
+	fs.StringVar(&p.imagePullPolicy, "image-pull-policy", "IfNotPresent", "[Optional] "+
+		"the pull policy of the image of the container scaffolded in the controller. "+
+		"(i.e --image-pull-policy=\"Always\" for images with the latest tag)")
+	fs.StringVar(&p.imageCPULimit, "image-cpu-limit", "", "[Optional] if informed, "+
+		"will be used to scaffold the CPU limit of the container in the Resources spec of the API (CRD/CR) "+
+		"and the controller. (i.e --image-cpu-limit=\"500m\")")
+	fs.StringVar(&p.imageMemoryLimit, "image-memory-limit", "", "[Optional] if informed, "+
+		"will be used to scaffold the memory limit of the container in the Resources spec of the API (CRD/CR) "+
+		"and the controller. (i.e --image-memory-limit=\"128Mi\")")
+	fs.StringVar(&p.livenessProbePath, "liveness-probe-path", "", "[Optional] if informed, "+
+		"will be used to scaffold an HTTP liveness probe of the container with this path as the default "+
+		"of its spec in the API (CRD/CR). Requires --image-container-port. (i.e --liveness-probe-path=\"/healthz\")")
+	fs.StringVar(&p.readinessProbePath, "readiness-probe-path", "", "[Optional] if informed, "+
+		"will be used to scaffold an HTTP readiness probe of the container with this path as the default "+
+		"of its spec in the API (CRD/CR). Requires --image-container-port. (i.e --readiness-probe-path=\"/readyz\")")
 
 	fs.BoolVar(&p.runMake, "make", true, "if true, run `make generate` after generating files")
 	fs.BoolVar(&p.runManifests, "manifests", true, "if true, run `make manifests` after generating files")
@@ -126,6 +172,17 @@ func (p *createAPISubcommand) BindFlags(fs *pflag.FlagSet) {
 func (p *createAPISubcommand) InjectConfig(c config.Config) error {
 	p.config = c
 
+	// Webhook-only projects have no CRDs nor controllers
+	kustomizeCfg, err := kustomizecommonv2scaffolds.LoadPluginConfig(c)
+	if err != nil {
+		return fmt.Errorf("error loading the kustomize plugin configuration: %w", err)
+	}
+	if kustomizeCfg.WebhookOnly {
+		return errors.New("APIs and controllers cannot be created in a project initialized with '--webhook-only', " +
+			"use 'create webhook' with '--external-api-path' or a core type (i.e. '--group core --version v1 " +
+			"--kind Pod') instead")
+	}
+
 	return nil
 }
 
@@ -133,8 +190,12 @@ func (p *createAPISubcommand) InjectResource(res *resource.Resource) error {
 	p.resource = res
 	p.options.DoAPI = true
 	p.options.DoController = true
+	// The API is always namespaced since the Deployment is created in the namespace of the custom resource
 	p.options.Namespaced = true
 
+	if err := p.options.ValidatePlural(p.resource.GVK, p.config); err != nil {
+		return err
+	}
 	p.options.UpdateResource(p.resource, p.config)
 
 	if err := p.resource.Validate(); err != nil {
@@ -155,6 +216,10 @@ func (p *createAPISubcommand) PreScaffold(machinery.Filesystem) error {
 		return fmt.Errorf("you MUST inform the image that will be used in the reconciliation")
 	}
 
+	if err := p.validateContainerSpecs(); err != nil {
+		return err
+	}
+
 	isGoV3 := false
 	for _, pluginKey := range p.config.GetPluginChain() {
 		if strings.Contains(pluginKey, "go.kubebuilder.io/v3") {
@@ -174,15 +239,59 @@ func (p *createAPISubcommand) PreScaffold(machinery.Filesystem) error {
 	return nil
 }
 
+// validateContainerSpecs checks the resource limits, probes and pull policy informed for the container
+func (p *createAPISubcommand) validateContainerSpecs() error {
+	switch p.imagePullPolicy {
+	case "Always", "IfNotPresent", "Never":
+	default:
+		return fmt.Errorf("invalid value %q for --image-pull-policy: it must be one of Always, IfNotPresent or Never",
+			p.imagePullPolicy)
+	}
+
+	for _, limit := range []struct{ flag, value string }{
+		{"image-cpu-limit", p.imageCPULimit},
+		{"image-memory-limit", p.imageMemoryLimit},
+	} {
+		if len(limit.value) > 0 && !quantityRegex.MatchString(limit.value) {
+			return fmt.Errorf("invalid value %q for --%s: it must be a resource quantity (i.e. 500m or 128Mi)",
+				limit.value, limit.flag)
+		}
+	}
+
+	for _, probe := range []struct{ flag, path string }{
+		{"liveness-probe-path", p.livenessProbePath},
+		{"readiness-probe-path", p.readinessProbePath},
+	} {
+		if len(probe.path) == 0 {
+			continue
+		}
+		if !strings.HasPrefix(probe.path, "/") {
+			return fmt.Errorf("invalid value %q for --%s: the path must start with \"/\"", probe.path, probe.flag)
+		}
+		if len(p.imageContainerPort) == 0 {
+			return fmt.Errorf("--%s requires --image-container-port to know the port probed", probe.flag)
+		}
+	}
+
+	return nil
+}
+
 func (p *createAPISubcommand) Scaffold(fs machinery.Filesystem) error {
 	log.Println("updating scaffold with deploy-image/v1alpha1 plugin...")
 
 	scaffolder := scaffolds.NewDeployImageScaffolder(p.config,
 		*p.resource,
 		p.image,
-		p.imageContainerCommand,
-		p.imageContainerPort,
-		p.runAsUser)
+		scaffolds.ContainerOptions{
+			Command:            p.imageContainerCommand,
+			Port:               p.imageContainerPort,
+			RunAsUser:          p.runAsUser,
+			ImagePullPolicy:    p.imagePullPolicy,
+			CPULimit:           p.imageCPULimit,
+			MemoryLimit:        p.imageMemoryLimit,
+			LivenessProbePath:  p.livenessProbePath,
+			ReadinessProbePath: p.readinessProbePath,
+		})
 	scaffolder.InjectFS(fs)
 	err := scaffolder.Scaffold()
 	if err != nil {
@@ -200,10 +309,15 @@ func (p *createAPISubcommand) Scaffold(fs machinery.Filesystem) error {
 	}
 
 	configDataOptions := options{
-		Image:            p.image,
-		ContainerCommand: p.imageContainerCommand,
-		ContainerPort:    p.imageContainerPort,
-		RunAsUser:        p.runAsUser,
+		Image:              p.image,
+		ContainerCommand:   p.imageContainerCommand,
+		ContainerPort:      p.imageContainerPort,
+		RunAsUser:          p.runAsUser,
+		ImagePullPolicy:    p.imagePullPolicy,
+		CPULimit:           p.imageCPULimit,
+		MemoryLimit:        p.imageMemoryLimit,
+		LivenessProbePath:  p.livenessProbePath,
+		ReadinessProbePath: p.readinessProbePath,
 	}
 	cfg.Resources = append(cfg.Resources, ResourceData{
 		Group:   p.resource.GVK.Group,
@@ -32,7 +32,11 @@ var (
 	pluginKey                = plugin.KeyFor(Plugin{})
 )
 
-var _ plugin.CreateAPI = Plugin{}
+var (
+	_ plugin.CreateAPI      = Plugin{}
+	_ plugin.Deprecated     = Plugin{}
+	_ plugin.HasDeprecation = Plugin{}
+)
 
 // Plugin implements the plugin.Full interface
 type Plugin struct {
@@ -66,13 +70,24 @@ type ResourceData struct {
 }
 
 type options struct {
-	Image            string `json:"image,omitempty"`
-	ContainerCommand string `json:"containerCommand,omitempty"`
-	ContainerPort    string `json:"containerPort,omitempty"`
-	RunAsUser        string `json:"runAsUser,omitempty"`
+	Image              string `json:"image,omitempty"`
+	ContainerCommand   string `json:"containerCommand,omitempty"`
+	ContainerPort      string `json:"containerPort,omitempty"`
+	RunAsUser          string `json:"runAsUser,omitempty"`
+	ImagePullPolicy    string `json:"imagePullPolicy,omitempty"`
+	CPULimit           string `json:"cpuLimit,omitempty"`
+	MemoryLimit        string `json:"memoryLimit,omitempty"`
+	LivenessProbePath  string `json:"livenessProbePath,omitempty"`
+	ReadinessProbePath string `json:"readinessProbePath,omitempty"`
+}
+
+// Deprecated define whether the plugin is deprecated and its deprecation message
+func (p Plugin) Deprecated() (bool, string) {
+	return false, ""
 }
 
 // DeprecationWarning define the deprecation message or return empty when plugin is not deprecated
 func (p Plugin) DeprecationWarning() string {
-	return ""
+	_, warning := p.Deprecated()
+	return warning
 }
@@ -17,13 +17,11 @@ limitations under the License.
 package scaffolds
 
 import (
-	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
-	"github.com/spf13/afero"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
@@ -42,28 +40,60 @@ var _ plugins.Scaffolder = &apiScaffolder{}
 // apiScaffolder contains configuration for generating scaffolding for Go type
 // representing the API and controller that implements the behavior for the API.
 type apiScaffolder struct {
-	config    config.Config
-	resource  resource.Resource
-	image     string
-	command   string
-	port      string
-	runAsUser string
+	config   config.Config
+	resource resource.Resource
+	image    string
+
+	// containerOptions defines the optional specs scaffolded for the container of the Operand
+	containerOptions ContainerOptions
 
 	// fs is the filesystem that will be used by the scaffolder
 	fs machinery.Filesystem
 }
 
+// ContainerOptions defines the optional specs which can be scaffolded for the container of the Operand
+type ContainerOptions struct {
+	// Command is the comma separated command used to init the container
+	Command string
+	// Port is the port exposed by the container, surfaced through the ContainerPort spec of the API
+	Port string
+	// RunAsUser is the user-id used for running the container, which can be overridden by
+	// the <KIND>_RUN_AS_USER environment variable of the manager
+	RunAsUser string
+	// ImagePullPolicy is the pull policy of the image, which can be overridden by
+	// the <KIND>_IMAGE_PULL_POLICY environment variable of the manager
+	ImagePullPolicy string
+	// CPULimit is the default CPU limit of the container, surfaced through the Resources spec of the API
+	CPULimit string
+	// MemoryLimit is the default memory limit of the container, surfaced through the Resources spec of the API
+	MemoryLimit string
+	// LivenessProbePath is the default HTTP path of the liveness probe, surfaced through the
+	// LivenessProbePath spec of the API
+	LivenessProbePath string
+	// ReadinessProbePath is the default HTTP path of the readiness probe, surfaced through the
+	// ReadinessProbePath spec of the API
+	ReadinessProbePath string
+}
+
+// hasResources returns true when a resource limit of the container is informed
+func (o ContainerOptions) hasResources() bool {
+	return len(o.CPULimit) > 0 || len(o.MemoryLimit) > 0
+}
+
+// hasProbes returns true when a probe of the container is informed
+func (o ContainerOptions) hasProbes() bool {
+	return len(o.LivenessProbePath) > 0 || len(o.ReadinessProbePath) > 0
+}
+
 // NewDeployImageScaffolder returns a new Scaffolder for declarative
-func NewDeployImageScaffolder(config config.Config, res resource.Resource, image,
-	command, port, runAsUser string,
+func NewDeployImageScaffolder(config config.Config, res resource.Resource, image string,
+	containerOptions ContainerOptions,
 ) plugins.Scaffolder {
 	return &apiScaffolder{
-		config:    config,
-		resource:  res,
-		image:     image,
-		command:   command,
-		port:      port,
-		runAsUser: runAsUser,
+		config:           config,
+		resource:         res,
+		image:            image,
+		containerOptions: containerOptions,
 	}
 }
 
@@ -80,45 +110,54 @@ func (s *apiScaffolder) Scaffold() error {
 		return err
 	}
 
-	// Define the boilerplate file path
-	boilerplatePath := filepath.Join("hack", "boilerplate.go.txt")
-
 	// Load the boilerplate
-	boilerplate, err := afero.ReadFile(s.fs.FS, boilerplatePath)
+	boilerplate, err := golangv4scaffolds.LoadBoilerplate(s.fs, s.config)
 	if err != nil {
-		if errors.Is(err, afero.ErrFileNotFound) {
-			log.Warnf("Unable to find %s : %s .\n"+
-				"This file is used to generate the license header in the project.\n"+
-				"Note that controller-gen will also use this. Therefore, ensure that you "+
-				"add the license file or configure your project accordingly.",
-				boilerplatePath, err)
-			boilerplate = []byte("")
-		} else {
-			return fmt.Errorf("error scaffolding API/controller: unable to load boilerplate: %w", err)
-		}
+		return fmt.Errorf("error scaffolding API/controller: %w", err)
 	}
 
 	// Initialize the machinery.Scaffold that will write the files to disk
 	scaffold := machinery.NewScaffold(s.fs,
 		machinery.WithConfig(s.config),
-		machinery.WithBoilerplate(string(boilerplate)),
+		machinery.WithBoilerplate(boilerplate),
 		machinery.WithResource(&s.resource),
 	)
 
 	if err := scaffold.Execute(
-		&api.Types{Port: s.port},
+		&api.Types{
+			Port:               s.containerOptions.Port,
+			Resources:          s.containerOptions.hasResources(),
+			LivenessProbePath:  s.containerOptions.LivenessProbePath,
+			ReadinessProbePath: s.containerOptions.ReadinessProbePath,
+		},
 	); err != nil {
 		return fmt.Errorf("error updating APIs: %v", err)
 	}
 
 	if err := scaffold.Execute(
-		&samples.CRDSample{Port: s.port},
+		&samples.CRDSample{
+			Port:               s.containerOptions.Port,
+			CPULimit:           s.containerOptions.CPULimit,
+			MemoryLimit:        s.containerOptions.MemoryLimit,
+			LivenessProbePath:  s.containerOptions.LivenessProbePath,
+			ReadinessProbePath: s.containerOptions.ReadinessProbePath,
+		},
 	); err != nil {
 		return fmt.Errorf("error updating config/samples: %v", err)
 	}
 
+	goPluginCfg, err := golangv4scaffolds.LoadPluginConfig(s.config)
+	if err != nil {
+		return fmt.Errorf("error loading the go/v4 plugin configuration: %w", err)
+	}
+	layout := goPluginCfg.GetControllerLayout()
+
 	controller := &controllers.Controller{
 		ControllerRuntimeVersion: golangv4scaffolds.ControllerRuntimeVersion,
+		ControllerLayout:         layout,
+		Port:                     s.containerOptions.Port,
+		RunAsUser:                s.containerOptions.RunAsUser,
+		ImagePullPolicy:          s.containerOptions.ImagePullPolicy,
 	}
 
 	if err := scaffold.Execute(
@@ -137,7 +176,7 @@ func (s *apiScaffolder) Scaffold() error {
 	}
 
 	if err := scaffold.Execute(
-		&controllers.ControllerTest{Port: s.port},
+		&controllers.ControllerTest{Port: s.containerOptions.Port, ControllerLayout: layout},
 	); err != nil {
 		return fmt.Errorf("error creating controller/**_controller_test.go: %v", err)
 	}
@@ -203,7 +242,8 @@ func (s *apiScaffolder) updateControllerCode(controller controllers.Controller)
 		controller.Path,
 		"//TODO: scaffold container",
 		fmt.Sprintf(containerTemplate, // value for the image
-			strings.ToLower(s.resource.Kind), // value for the name of the container
+			strings.ToLower(s.resource.Kind), // value for the name of the container and the custom resource
+			s.resource.Kind,                  // value for the helpers reading the environment of the manager
 		),
 	); err != nil {
 		return fmt.Errorf("error scaffolding container in the controller path (%s): %v",
@@ -211,11 +251,11 @@ func (s *apiScaffolder) updateControllerCode(controller controllers.Controller)
 	}
 
 	// Scaffold the command if informed
-	if len(s.command) > 0 {
+	if len(s.containerOptions.Command) > 0 {
 		// TODO: improve it to be an spec in the sample and api instead so that
 		// users can change the values
 		var res string
-		for _, value := range strings.Split(s.command, ",") {
+		for _, value := range strings.Split(s.containerOptions.Command, ",") {
 			res += fmt.Sprintf(" \"%s\",", strings.TrimSpace(value))
 		}
 		// remove the latest ,
@@ -238,7 +278,7 @@ func (s *apiScaffolder) updateControllerCode(controller controllers.Controller)
 	}
 
 	// Scaffold the port if informed
-	if len(s.port) > 0 {
+	if len(s.containerOptions.Port) > 0 {
 		if err := util.InsertCode(
 			controller.Path,
 			`SecurityContext: &corev1.SecurityContext{
@@ -252,6 +292,7 @@ func (s *apiScaffolder) updateControllerCode(controller controllers.Controller)
 						},`,
 			fmt.Sprintf(
 				portTemplate,
+				s.resource.Kind,
 				strings.ToLower(s.resource.Kind),
 				strings.ToLower(s.resource.Kind)),
 		); err != nil {
@@ -261,17 +302,54 @@ func (s *apiScaffolder) updateControllerCode(controller controllers.Controller)
 		}
 	}
 
-	if len(s.runAsUser) > 0 {
+	if len(s.containerOptions.RunAsUser) > 0 {
 		if err := util.InsertCode(
 			controller.Path,
 			`RunAsNonRoot:             ptr.To(true),`,
-			fmt.Sprintf(runAsUserTemplate, s.runAsUser),
+			fmt.Sprintf(runAsUserTemplate, s.resource.Kind),
 		); err != nil {
 			return fmt.Errorf("error scaffolding user-id in the controller path (%s): %v",
 				controller.Path, err)
 		}
 	}
 
+	// Scaffold the resources and the probes if informed. Their values are read from the
+	// spec of the custom resource, so that users can change them without rebuilding the manager.
+	var containerSpecs string
+	if s.containerOptions.hasResources() {
+		containerSpecs += fmt.Sprintf(resourcesTemplate, strings.ToLower(s.resource.Kind))
+	}
+	if len(s.containerOptions.LivenessProbePath) > 0 {
+		containerSpecs += fmt.Sprintf(probeTemplate, "LivenessProbe",
+			strings.ToLower(s.resource.Kind), "LivenessProbePath", s.resource.Kind)
+	}
+	if len(s.containerOptions.ReadinessProbePath) > 0 {
+		containerSpecs += fmt.Sprintf(probeTemplate, "ReadinessProbe",
+			strings.ToLower(s.resource.Kind), "ReadinessProbePath", s.resource.Kind)
+	}
+	if len(containerSpecs) > 0 {
+		if err := util.InsertCode(
+			controller.Path,
+			fmt.Sprintf(`ImagePullPolicy: imagePullPolicyFor%s(),`, s.resource.Kind),
+			containerSpecs,
+		); err != nil {
+			return fmt.Errorf("error scaffolding resources and probes in the controller path (%s): %v",
+				controller.Path, err)
+		}
+	}
+
+	// The probes use the intstr package for their port, which is not imported by the template
+	if s.containerOptions.hasProbes() {
+		if err := util.InsertCode(
+			controller.Path,
+			`"k8s.io/apimachinery/pkg/api/meta"`,
+			intstrImportTemplate,
+		); err != nil {
+			return fmt.Errorf("error scaffolding imports in the controller path (%s): %v",
+				controller.Path, err)
+		}
+	}
+
 	return nil
 }
 
@@ -293,15 +371,16 @@ func (s *apiScaffolder) scaffoldCreateAPIFromKustomize() error {
 
 func (s *apiScaffolder) scaffoldCreateAPIFromGolang() error {
 	golangV4Scaffolder := golangv4scaffolds.NewAPIScaffolder(s.config,
-		s.resource, true)
+		s.resource, true, golangv4scaffolds.ControllerOptions{})
 	golangV4Scaffolder.InjectFS(s.fs)
 	return golangV4Scaffolder.Scaffold()
 }
 
 const containerTemplate = `Containers: []corev1.Container{{
 						Image:           image,
-						Name:            "%s",
-						ImagePullPolicy: corev1.PullIfNotPresent,
+						Name:            "%[1]s",
+						ImagePullPolicy: imagePullPolicyFor%[2]s(),
+						Env:             %[1]s.Spec.Env,
 						// Ensure restrictive context for the container
 						// More info: https://kubernetes.io/docs/concepts/security/pod-security-standards/#restricted
 						SecurityContext: &corev1.SecurityContext{
@@ -316,17 +395,33 @@ const containerTemplate = `Containers: []corev1.Container{{
 					}}`
 
 const runAsUserTemplate = `
-							RunAsUser:                ptr.To(int64(%s)),`
+							RunAsUser:                ptr.To(runAsUserFor%s()),`
 
 const commandTemplate = `
 						Command: []string{%s},`
 
 const portTemplate = `
 						Ports: []corev1.ContainerPort{{
-							ContainerPort: %s.Spec.ContainerPort,
+							ContainerPort: containerPortFor%s(%s),
 							Name:          "%s",
 						}},`
 
+const resourcesTemplate = `
+						Resources:       %s.Spec.Resources,`
+
+const probeTemplate = `
+						%[1]s: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{
+									Path: %[2]s.Spec.%[3]s,
+									Port: intstr.FromInt32(containerPortFor%[4]s(%[2]s)),
+								},
+							},
+						},`
+
+const intstrImportTemplate = `
+	"k8s.io/apimachinery/pkg/util/intstr"`
+
 const recorderTemplate = `
 		Recorder: mgr.GetEventRecorderFor("%s-controller"),`
 
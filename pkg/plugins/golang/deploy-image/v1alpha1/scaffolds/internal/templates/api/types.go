@@ -37,6 +37,15 @@ type Types struct {
 
 	// Port if informed we will create the scaffold with this spec
 	Port string
+
+	// Resources if true we will create the scaffold with the Resources spec
+	Resources bool
+
+	// LivenessProbePath if informed we will create the scaffold with this spec as default value
+	LivenessProbePath string
+
+	// ReadinessProbePath if informed we will create the scaffold with this spec as default value
+	ReadinessProbePath string
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -65,6 +74,8 @@ const typesTemplate = `{{ .Boilerplate }}
 package {{ .Resource.Version }}
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -76,18 +87,62 @@ type {{ .Resource.Kind }}Spec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	// Size defines the number of {{ .Resource.Kind }} instances
+	// Replicas defines the number of {{ .Resource.Kind }} instances
 	// The following markers will use OpenAPI v3 schema to validate the value
 	// More info: https://book.kubebuilder.io/reference/markers/crd-validation.html
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=3
 	// +kubebuilder:validation:ExclusiveMaximum=false
-	Size int32 ` + "`" + `json:"size,omitempty"` + "`" + `
+	// +kubebuilder:default=1
+	// +optional
+	Replicas int32 ` + "`" + `json:"replicas,omitempty"` + "`" + `
+
+	// Env defines the environment variables of the container with the image
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Env []corev1.EnvVar ` + "`" + `json:"env,omitempty"` + "`" + `
+
+	// PodLabels defines the labels added to the Pods, in addition to the ones used by the
+	// controller to select them, which cannot be overridden
+	// +kubebuilder:validation:MaxProperties=32
+	// +optional
+	PodLabels map[string]string ` + "`" + `json:"podLabels,omitempty"` + "`" + `
+
+	// Strategy defines how the Pods of the Deployment are replaced by new ones, e.g. the maxSurge
+	// and maxUnavailable of a RollingUpdate. The default strategy of the Deployment is used when it is not set
+	// More info: https://kubernetes.io/docs/concepts/workloads/controllers/deployment/#strategy
+	// +optional
+	Strategy appsv1.DeploymentStrategy ` + "`" + `json:"strategy,omitempty"` + "`" + `
 
 	{{ if not (isEmptyStr .Port) -}}
 	// Port defines the port that will be used to init the container with the image
 	ContainerPort int32 ` + "`" + `json:"containerPort,omitempty"` + "`" + `
 	{{- end }}
+	{{- if .Resources }}
+
+	// Resources defines the compute resources of the container with the image
+	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Resources corev1.ResourceRequirements ` + "`" + `json:"resources,omitempty"` + "`" + `
+	{{- end }}
+	{{- if not (isEmptyStr .LivenessProbePath) }}
+
+	// LivenessProbePath defines the HTTP path used to check if the container with the image is alive
+	// +kubebuilder:default="{{ .LivenessProbePath }}"
+	// +kubebuilder:validation:Pattern="^/"
+	// +optional
+	LivenessProbePath string ` + "`" + `json:"livenessProbePath,omitempty"` + "`" + `
+	{{- end }}
+	{{- if not (isEmptyStr .ReadinessProbePath) }}
+
+	// ReadinessProbePath defines the HTTP path used to check if the container with the image is ready
+	// +kubebuilder:default="{{ .ReadinessProbePath }}"
+	// +kubebuilder:validation:Pattern="^/"
+	// +optional
+	ReadinessProbePath string ` + "`" + `json:"readinessProbePath,omitempty"` + "`" + `
+	{{- end }}
 }
 
 // {{ .Resource.Kind }}Status defines the observed state of {{ .Resource.Kind }}
@@ -102,10 +157,30 @@ type {{ .Resource.Kind }}Status struct {
 	// For further information see: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#typical-status-properties
 
 	Conditions []metav1.Condition ` + "`" + `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"` + "`" + `
+
+	// ObservedGeneration is the generation of the {{ .Resource.Kind }} whose rollout is reported by the status
+	// +optional
+	ObservedGeneration int64 ` + "`" + `json:"observedGeneration,omitempty"` + "`" + `
+
+	// Replicas is the number of Pods of the Deployment
+	// +optional
+	Replicas int32 ` + "`" + `json:"replicas,omitempty"` + "`" + `
+
+	// UpdatedReplicas is the number of Pods of the Deployment which run the desired Pod template
+	// +optional
+	UpdatedReplicas int32 ` + "`" + `json:"updatedReplicas,omitempty"` + "`" + `
+
+	// AvailableReplicas is the number of available Pods of the Deployment
+	// +optional
+	AvailableReplicas int32 ` + "`" + `json:"availableReplicas,omitempty"` + "`" + `
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=".spec.replicas"
+// +kubebuilder:printcolumn:name="Up-to-date",type=integer,JSONPath=".status.updatedReplicas"
+// +kubebuilder:printcolumn:name="Available",type=integer,JSONPath=".status.availableReplicas"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
 {{- if and (not .Resource.API.Namespaced) (not .Resource.IsRegularPlural) }}
 // +kubebuilder:resource:path={{ .Resource.Plural }},scope=Cluster
 {{- else if not .Resource.API.Namespaced }}
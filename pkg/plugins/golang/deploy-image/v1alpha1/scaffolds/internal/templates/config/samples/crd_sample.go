@@ -34,6 +34,18 @@ type CRDSample struct {
 
 	// Port if informed we will create the scaffold with this spec
 	Port string
+
+	// CPULimit if informed we will create the scaffold with this resource limit
+	CPULimit string
+
+	// MemoryLimit if informed we will create the scaffold with this resource limit
+	MemoryLimit string
+
+	// LivenessProbePath if informed we will create the scaffold with this spec
+	LivenessProbePath string
+
+	// ReadinessProbePath if informed we will create the scaffold with this spec
+	ReadinessProbePath string
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -65,9 +77,44 @@ metadata:
 spec:
   # TODO(user): edit the following value to ensure the number
   # of Pods/Instances your Operand must have on cluster
-  size: 1
+  replicas: 1
+
+  # TODO(user): uncomment the following values to set environment variables in the container
+  # env:
+  # - name: LOG_LEVEL
+  #   value: info
+
+  # TODO(user): uncomment the following values to add labels to the Pods
+  # podLabels:
+  #   tier: backend
+
+  # TODO(user): uncomment the following values to tune how the Pods are replaced by new ones
+  # strategy:
+  #   type: RollingUpdate
+  #   rollingUpdate:
+  #     maxSurge: 1
+  #     maxUnavailable: 0
 {{ if not (isEmptyStr .Port) }}
   # TODO(user): edit the following value to ensure the container has the right port to be initialized
   containerPort: {{ .Port }}
 {{ end -}}
+{{ if or (not (isEmptyStr .CPULimit)) (not (isEmptyStr .MemoryLimit)) }}
+  # TODO(user): edit the following values to ensure the container has the right compute resources
+  resources:
+    limits:
+{{- if not (isEmptyStr .CPULimit) }}
+      cpu: {{ .CPULimit }}
+{{- end }}
+{{- if not (isEmptyStr .MemoryLimit) }}
+      memory: {{ .MemoryLimit }}
+{{- end }}
+{{ end -}}
+{{ if not (isEmptyStr .LivenessProbePath) }}
+  # TODO(user): edit the following value to ensure the liveness probe checks the right path
+  livenessProbePath: {{ .LivenessProbePath }}
+{{ end -}}
+{{ if not (isEmptyStr .ReadinessProbePath) }}
+  # TODO(user): edit the following value to ensure the readiness probe checks the right path
+  readinessProbePath: {{ .ReadinessProbePath }}
+{{ end -}}
 `
@@ -22,6 +22,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
 )
 
 var _ machinery.Template = &Controller{}
@@ -38,22 +39,33 @@ type Controller struct {
 
 	ControllerRuntimeVersion string
 
+	// ControllerLayout is the placement of the controllers in the project
+	ControllerLayout golang.ControllerLayout
+	// PackageName is the name of the package of the controllers, set from the layout
 	PackageName string
+
+	// Port is the default port of the container of the Operand
+	Port string
+	// RunAsUser is the default user-id used for running the container of the Operand
+	RunAsUser string
+	// ImagePullPolicy is the default pull policy of the image of the Operand
+	ImagePullPolicy string
 }
 
 // SetTemplateDefaults implements machinery.Template
 func (f *Controller) SetTemplateDefaults() error {
 	if f.Path == "" {
-		if f.MultiGroup && f.Resource.Group != "" {
-			f.Path = filepath.Join("internal", "controller", "%[group]", "%[kind]_controller.go")
-		} else {
-			f.Path = filepath.Join("internal", "controller", "%[kind]_controller.go")
-		}
+		dir := f.ControllerLayout.Dir(*f.Resource, f.MultiGroup)
+		f.Path = filepath.Join(filepath.FromSlash(dir), "%[kind]_controller.go")
 	}
 	f.Path = f.Resource.Replacer().Replace(f.Path)
 	log.Println(f.Path)
 
-	f.PackageName = "controller"
+	f.PackageName = f.ControllerLayout.PackageName(*f.Resource, f.MultiGroup)
+
+	if f.ImagePullPolicy == "" {
+		f.ImagePullPolicy = "IfNotPresent"
+	}
 
 	log.Println("creating import for %", f.Resource.Path)
 	f.TemplateBody = controllerTemplate
@@ -67,10 +79,11 @@ func (f *Controller) SetTemplateDefaults() error {
 //nolint:lll
 const controllerTemplate = `{{ .Boilerplate }}
 
-package {{ if and .MultiGroup .Resource.Group }}{{ .Resource.PackageName }}{{ else }}{{ .PackageName }}{{ end }}
+package {{ .PackageName }}
 
 import (
 	"context"
+	"maps"
 	"strings"
 	"time"
 	"fmt"
@@ -78,6 +91,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -99,8 +113,11 @@ const {{ lower .Resource.Kind }}Finalizer = "{{ .Resource.Group }}.{{ .Resource.
 
 // Definitions to manage status conditions
 const (
-	// typeAvailable{{ .Resource.Kind }} represents the status of the Deployment reconciliation
+	// typeAvailable{{ .Resource.Kind }} represents the status of the Deployment reconciliation,
+	// mirrored from the Available condition of the Deployment once it is reconciled
 	typeAvailable{{ .Resource.Kind }} = "Available"
+	// typeProgressing{{ .Resource.Kind }} is mirrored from the Progressing condition of the Deployment
+	typeProgressing{{ .Resource.Kind }} = "Progressing"
 	// typeDegraded{{ .Resource.Kind }} represents the status used when the custom resource is deleted and the finalizer operations are yet to occur.
 	typeDegraded{{ .Resource.Kind }} = "Degraded"
 )
@@ -288,15 +305,34 @@ func (r *{{ .Resource.Kind }}Reconciler) Reconcile(ctx context.Context, req ctrl
 		return ctrl.Result{}, err
 	}
 
-	// The CRD API defines that the {{ .Resource.Kind }} type have a {{ .Resource.Kind }}Spec.Size field
-	// to set the quantity of Deployment instances to the desired state on the cluster.
-	// Therefore, the following code will ensure the Deployment size is the same as defined
-	// via the Size spec of the Custom Resource which we are reconciling.
-	size := {{ lower .Resource.Kind }}.Spec.Size
-	if *found.Spec.Replicas != size {
-		found.Spec.Replicas = &size
-		if err = r.Update(ctx, found); err != nil {
-			log.Error(err, "Failed to update Deployment",
+	// The CRD API defines that the {{ .Resource.Kind }} type have the {{ .Resource.Kind }}Spec.Replicas,
+	// {{ .Resource.Kind }}Spec.Env, {{ .Resource.Kind }}Spec.PodLabels and {{ .Resource.Kind }}Spec.Strategy fields
+	// to set the desired state of the Deployment on the cluster, which runs the Operand image of the manager.
+	// Therefore, the following code will detect when the Deployment drifted from the spec of the Custom Resource
+	// which we are reconciling, or from the image, and will patch it.
+	desired, err := r.deploymentFor{{ .Resource.Kind }}({{ lower .Resource.Kind }})
+	if err != nil {
+		log.Error(err, "Failed to define the desired Deployment resource for {{ .Resource.Kind }}")
+		return ctrl.Result{}, err
+	}
+	// The selector of a Deployment is immutable, so its Pods keep the labels of the selector
+	// found on the cluster, e.g. the version of the image the Deployment was created with
+	if found.Spec.Selector != nil {
+		maps.Copy(desired.Spec.Template.Labels, found.Spec.Selector.MatchLabels)
+	}
+	if deploymentDriftedFor{{ .Resource.Kind }}(found, desired) {
+		log.Info("Patching the Deployment which drifted from the custom resource",
+			"Deployment.Namespace", found.Namespace, "Deployment.Name", found.Name)
+		patch := client.MergeFrom(found.DeepCopy())
+		found.Spec.Replicas = desired.Spec.Replicas
+		if desired.Spec.Strategy.Type != "" {
+			found.Spec.Strategy = desired.Spec.Strategy
+		}
+		found.Spec.Template.Labels = desired.Spec.Template.Labels
+		found.Spec.Template.Spec.Containers[0].Image = desired.Spec.Template.Spec.Containers[0].Image
+		found.Spec.Template.Spec.Containers[0].Env = desired.Spec.Template.Spec.Containers[0].Env
+		if err = r.Patch(ctx, found, patch); err != nil {
+			log.Error(err, "Failed to patch Deployment",
 				"Deployment.Namespace", found.Namespace, "Deployment.Name", found.Name)
 
 			// Re-fetch the {{ lower .Resource.Kind }} Custom Resource before updating the status
@@ -310,8 +346,8 @@ func (r *{{ .Resource.Kind }}Reconciler) Reconcile(ctx context.Context, req ctrl
 
 			// The following implementation will update the status
 			meta.SetStatusCondition(&{{ lower .Resource.Kind }}.Status.Conditions, metav1.Condition{Type: typeAvailable{{ .Resource.Kind }},
-				Status: metav1.ConditionFalse, Reason: "Resizing",
-				Message: fmt.Sprintf("Failed to update the size for the custom resource (%s): (%s)", {{ lower .Resource.Kind }}.Name, err)})
+				Status: metav1.ConditionFalse, Reason: "Updating",
+				Message: fmt.Sprintf("Failed to update the Deployment for the custom resource (%s): (%s)", {{ lower .Resource.Kind }}.Name, err)})
 
 			if err := r.Status().Update(ctx, {{ lower .Resource.Kind }}); err != nil {
 				log.Error(err, "Failed to update {{ .Resource.Kind }} status")
@@ -321,22 +357,38 @@ func (r *{{ .Resource.Kind }}Reconciler) Reconcile(ctx context.Context, req ctrl
 			return ctrl.Result{}, err
 		}
 
-		// Now, that we update the size we want to requeue the reconciliation
+		// Now, that we update the Deployment we want to requeue the reconciliation
 		// so that we can ensure that we have the latest state of the resource before
 		// update. Also, it will help ensure the desired state on the cluster
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	// The following implementation will update the status
-	meta.SetStatusCondition(&{{ lower .Resource.Kind }}.Status.Conditions, metav1.Condition{Type: typeAvailable{{ .Resource.Kind }},
-		Status: metav1.ConditionTrue, Reason: "Reconciling",
-		Message: fmt.Sprintf("Deployment for custom resource (%s) with %d replicas created successfully", {{ lower .Resource.Kind }}.Name, size)})
+	// The Available and Progressing conditions of the Deployment are mirrored into the status of the
+	// custom resource. The Deployment is owned by the custom resource, so the changes of its status
+	// trigger a new reconciliation.
+	setDeploymentConditionsFor{{ .Resource.Kind }}({{ lower .Resource.Kind }}, found)
+
+	// The progress of the rollout of the Deployment is also reported in the status of the custom resource
+	{{ lower .Resource.Kind }}.Status.ObservedGeneration = {{ lower .Resource.Kind }}.Generation
+	{{ lower .Resource.Kind }}.Status.Replicas = found.Status.Replicas
+	{{ lower .Resource.Kind }}.Status.UpdatedReplicas = found.Status.UpdatedReplicas
+	{{ lower .Resource.Kind }}.Status.AvailableReplicas = found.Status.AvailableReplicas
 
 	if err := r.Status().Update(ctx, {{ lower .Resource.Kind }}); err != nil {
 		log.Error(err, "Failed to update {{ .Resource.Kind }} status")
 		return ctrl.Result{}, err
 	}
 
+	// The reconciliation is requeued until the rollout of the Deployment is complete, so that its progress
+	// is reported even when the changes of the status of the Deployment are not enough to trigger it,
+	// e.g. while the new Pods are not ready yet
+	if !deploymentRolledOutFor{{ .Resource.Kind }}(found) {
+		log.Info("Waiting for the rollout of the Deployment to complete",
+			"Deployment.Namespace", found.Namespace, "Deployment.Name", found.Name,
+			"UpdatedReplicas", found.Status.UpdatedReplicas, "Replicas", ptr.Deref(found.Spec.Replicas, 1))
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -364,7 +416,13 @@ func (r *{{ .Resource.Kind }}Reconciler) doFinalizerOperationsFor{{ .Resource.Ki
 func (r *{{ .Resource.Kind }}Reconciler) deploymentFor{{ .Resource.Kind }}(
 	{{ lower .Resource.Kind }} *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) (*appsv1.Deployment, error) {
 	ls := labelsFor{{ .Resource.Kind }}()
-	replicas := {{ lower .Resource.Kind }}.Spec.Size
+	replicas := {{ lower .Resource.Kind }}.Spec.Replicas
+
+	// The Pods have the labels defined in the custom resource in addition to the ones used
+	// by the selector of the Deployment, which cannot be overridden
+	podLabels := map[string]string{}
+	maps.Copy(podLabels, {{ lower .Resource.Kind }}.Spec.PodLabels)
+	maps.Copy(podLabels, ls)
 
 	// Get the Operand image
 	image, err := imageFor{{ .Resource.Kind }}()
@@ -379,12 +437,13 @@ func (r *{{ .Resource.Kind }}Reconciler) deploymentFor{{ .Resource.Kind }}(
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &replicas,
+			Strategy: {{ lower .Resource.Kind }}.Spec.Strategy,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: ls,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: ls,
+					Labels: podLabels,
 				},
 				Spec: corev1.PodSpec{
 					// TODO(user): Uncomment the following code to configure the nodeAffinity expression
@@ -438,6 +497,86 @@ func (r *{{ .Resource.Kind }}Reconciler) deploymentFor{{ .Resource.Kind }}(
 	return dep, nil
 }
 
+// deploymentDriftedFor{{ .Resource.Kind }} returns true when the replicas, the strategy, the Pod labels, the image
+// or the environment variables of the Deployment found on the cluster differ from the desired ones.
+// Note that the environment variables are compared after the defaulting of the Kubernetes API,
+// e.g. the apiVersion of a fieldRef should be set in the custom resource to not be seen as a drift.
+func deploymentDriftedFor{{ .Resource.Kind }}(found, desired *appsv1.Deployment) bool {
+	if len(found.Spec.Template.Spec.Containers) == 0 {
+		return true
+	}
+	return ptr.Deref(found.Spec.Replicas, 1) != ptr.Deref(desired.Spec.Replicas, 1) ||
+		strategyDriftedFor{{ .Resource.Kind }}(found.Spec.Strategy, desired.Spec.Strategy) ||
+		!equality.Semantic.DeepEqual(found.Spec.Template.Labels, desired.Spec.Template.Labels) ||
+		found.Spec.Template.Spec.Containers[0].Image != desired.Spec.Template.Spec.Containers[0].Image ||
+		!equality.Semantic.DeepEqual(found.Spec.Template.Spec.Containers[0].Env,
+			desired.Spec.Template.Spec.Containers[0].Env)
+}
+
+// strategyDriftedFor{{ .Resource.Kind }} returns true when the strategy of the Deployment found on the cluster
+// differs from the desired one. Only the fields set in the custom resource are compared, since the
+// Kubernetes API defaults the others, e.g. the maxSurge and maxUnavailable of a RollingUpdate to 25%.
+func strategyDriftedFor{{ .Resource.Kind }}(found, desired appsv1.DeploymentStrategy) bool {
+	if desired.Type == "" {
+		return false
+	}
+	if found.Type != desired.Type {
+		return true
+	}
+	if desired.RollingUpdate == nil {
+		return false
+	}
+	if found.RollingUpdate == nil {
+		return true
+	}
+	return (desired.RollingUpdate.MaxSurge != nil &&
+		!equality.Semantic.DeepEqual(found.RollingUpdate.MaxSurge, desired.RollingUpdate.MaxSurge)) ||
+		(desired.RollingUpdate.MaxUnavailable != nil &&
+			!equality.Semantic.DeepEqual(found.RollingUpdate.MaxUnavailable, desired.RollingUpdate.MaxUnavailable))
+}
+
+// deploymentRolledOutFor{{ .Resource.Kind }} returns true when the rollout of the Deployment is complete,
+// i.e. when the Deployment controller observed its latest spec and all its Pods run the desired Pod template
+// and are available, as checked by kubectl rollout status.
+func deploymentRolledOutFor{{ .Resource.Kind }}(dep *appsv1.Deployment) bool {
+	replicas := ptr.Deref(dep.Spec.Replicas, 1)
+	return dep.Status.ObservedGeneration >= dep.Generation &&
+		dep.Status.UpdatedReplicas == replicas &&
+		dep.Status.Replicas == dep.Status.UpdatedReplicas &&
+		dep.Status.AvailableReplicas == dep.Status.UpdatedReplicas
+}
+
+// setDeploymentConditionsFor{{ .Resource.Kind }} mirrors the Available and Progressing conditions of the
+// Deployment into the status of the custom resource. The conditions are Unknown until the Deployment
+// reports them, e.g. while its controller has not observed it yet.
+func setDeploymentConditionsFor{{ .Resource.Kind }}(
+	{{ lower .Resource.Kind }} *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}, dep *appsv1.Deployment) {
+	mirrored := map[string]appsv1.DeploymentConditionType{
+		typeAvailable{{ .Resource.Kind }}:   appsv1.DeploymentAvailable,
+		typeProgressing{{ .Resource.Kind }}: appsv1.DeploymentProgressing,
+	}
+	for conditionType, deploymentConditionType := range mirrored {
+		condition := metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "DeploymentStatusUnknown",
+			Message:            fmt.Sprintf("The Deployment %s has not reported its %s condition", dep.Name, deploymentConditionType),
+			ObservedGeneration: {{ lower .Resource.Kind }}.Generation,
+		}
+		for _, deploymentCondition := range dep.Status.Conditions {
+			if deploymentCondition.Type != deploymentConditionType {
+				continue
+			}
+			condition.Status = metav1.ConditionStatus(deploymentCondition.Status)
+			if deploymentCondition.Reason != "" {
+				condition.Reason = deploymentCondition.Reason
+			}
+			condition.Message = deploymentCondition.Message
+		}
+		meta.SetStatusCondition(&{{ lower .Resource.Kind }}.Status.Conditions, condition)
+	}
+}
+
 // labelsFor{{ .Resource.Kind }} returns the labels for selecting the resources
 // More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/common-labels/
 func labelsFor{{ .Resource.Kind }}() map[string]string {
@@ -464,6 +603,38 @@ func imageFor{{ .Resource.Kind }}() (string, error) {
     return image, nil
 }
 
+// imagePullPolicyFor{{ .Resource.Kind }} gets the pull policy of the Operand image from the
+// {{ upper .Resource.Kind }}_IMAGE_PULL_POLICY environment variable, which defaults to {{ .ImagePullPolicy }}
+func imagePullPolicyFor{{ .Resource.Kind }}() corev1.PullPolicy {
+	if policy := os.Getenv("{{ upper .Resource.Kind }}_IMAGE_PULL_POLICY"); policy != "" {
+		return corev1.PullPolicy(policy)
+	}
+	return corev1.Pull{{ .ImagePullPolicy }}
+}
+{{- if not (isEmptyStr .Port) }}
+
+// containerPortFor{{ .Resource.Kind }} gets the port of the Operand from the spec of the custom resource,
+// or from the {{ upper .Resource.Kind }}_CONTAINER_PORT environment variable when it is not set, which defaults to {{ .Port }}
+func containerPortFor{{ .Resource.Kind }}({{ lower .Resource.Kind }} *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) int32 {
+	if {{ lower .Resource.Kind }}.Spec.ContainerPort != 0 {
+		return {{ lower .Resource.Kind }}.Spec.ContainerPort
+	}
+	port := int32({{ .Port }})
+	_, _ = fmt.Sscan(os.Getenv("{{ upper .Resource.Kind }}_CONTAINER_PORT"), &port)
+	return port
+}
+{{- end }}
+{{- if not (isEmptyStr .RunAsUser) }}
+
+// runAsUserFor{{ .Resource.Kind }} gets the user-id running the container of the Operand from the
+// {{ upper .Resource.Kind }}_RUN_AS_USER environment variable, which defaults to {{ .RunAsUser }}
+func runAsUserFor{{ .Resource.Kind }}() int64 {
+	user := int64({{ .RunAsUser }})
+	_, _ = fmt.Sscan(os.Getenv("{{ upper .Resource.Kind }}_RUN_AS_USER"), &user)
+	return user
+}
+{{- end }}
+
 // SetupWithManager sets up the controller with the Manager.
 // The whole idea is to be watching the resources that matter for the controller.
 // When a resource that the controller is interested in changes, the Watch triggers
@@ -17,7 +17,10 @@ limitations under the License.
 package golang
 
 import (
+	"errors"
+	"fmt"
 	"path"
+	"strings"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
@@ -63,9 +66,16 @@ type Options struct {
 	// to generate the RBAC markers
 	ExternalAPIDomain string
 
+	// ExternalAPIModule allows to inform the Go module, optionally with its version (module@version),
+	// which provides the external API so that it is added to the go.mod of the project
+	ExternalAPIModule string
+
 	// Namespaced is true if the resource should be namespaced.
 	Namespaced bool
 
+	// StorageVersion is true if the version of the resource should be the storage version of its kind.
+	StorageVersion bool
+
 	// Flags that define which parts should be scaffolded
 	DoAPI        bool
 	DoController bool
@@ -73,22 +83,73 @@ type Options struct {
 	DoValidation bool
 	DoConversion bool
 
+	// DoValidatingAdmissionPolicy scaffolds a ValidatingAdmissionPolicy which validates the resource
+	// with CEL expressions evaluated by the API server
+	DoValidatingAdmissionPolicy bool
+
 	// Spoke versions for conversion webhook
 	Spoke []string
 }
 
+// ValidateExternalAPI checks that the external API options are only used together with the external API path
+func (opts Options) ValidateExternalAPI() error {
+	if opts.ExternalAPIPath == "" {
+		if opts.ExternalAPIDomain != "" || opts.ExternalAPIModule != "" {
+			return errors.New("'--external-api-domain' and '--external-api-module' require '--external-api-path'")
+		}
+		return nil
+	}
+
+	if strings.Contains(opts.ExternalAPIPath, "@") {
+		return errors.New("'--external-api-path' must be a Go package import path without version, " +
+			"use '--external-api-module' to pin the version of the module")
+	}
+	return nil
+}
+
+// ValidatePlural checks that the plural is the one of the versions of the kind already tracked in the project,
+// since all the versions of a kind are served by the same CRD
+func (opts Options) ValidatePlural(gvk resource.GVK, c config.Config) error {
+	if opts.Plural == "" {
+		return nil
+	}
+	if plural, found := trackedPlural(gvk, c); found && plural != opts.Plural {
+		return fmt.Errorf("the kind %s is tracked in the project with the plural %q, "+
+			"'--plural' can not set another plural", gvk.Kind, plural)
+	}
+	return nil
+}
+
+// trackedPlural returns the plural of the versions of the kind already tracked in the project
+func trackedPlural(gvk resource.GVK, c config.Config) (string, bool) {
+	resources, err := c.GetResources()
+	if err != nil {
+		return "", false
+	}
+	for _, res := range resources {
+		if res.Group == gvk.Group && res.Domain == gvk.Domain && res.Kind == gvk.Kind && res.Plural != "" {
+			return res.Plural, true
+		}
+	}
+	return "", false
+}
+
 // UpdateResource updates the provided resource with the options
 func (opts Options) UpdateResource(res *resource.Resource, c config.Config) {
 	if opts.Plural != "" {
 		res.Plural = opts.Plural
+	} else if plural, found := trackedPlural(res.GVK, c); found {
+		// The irregular plural of a kind is kept by its other versions and by its webhooks and controllers
+		res.Plural = plural
 	}
 
 	if opts.DoAPI {
 		res.Path = resource.APIPackagePath(c.GetRepository(), res.Group, res.Version, c.IsMultiGroup())
 
 		res.API = &resource.API{
-			CRDVersion: "v1",
-			Namespaced: opts.Namespaced,
+			CRDVersion:     "v1",
+			Namespaced:     opts.Namespaced,
+			StorageVersion: opts.StorageVersion,
 		}
 
 	}
@@ -97,9 +158,10 @@ func (opts Options) UpdateResource(res *resource.Resource, c config.Config) {
 		res.Controller = true
 	}
 
-	if opts.DoDefaulting || opts.DoValidation || opts.DoConversion {
+	if opts.DoDefaulting || opts.DoValidation || opts.DoConversion || opts.DoValidatingAdmissionPolicy {
 		res.Path = resource.APIPackagePath(c.GetRepository(), res.Group, res.Version, c.IsMultiGroup())
 
+		// The ValidatingAdmissionPolicy is also part of the admissionregistration.k8s.io/v1 API
 		res.Webhooks.WebhookVersion = "v1"
 		if opts.DoDefaulting {
 			res.Webhooks.Defaulting = true
@@ -111,6 +173,9 @@ func (opts Options) UpdateResource(res *resource.Resource, c config.Config) {
 			res.Webhooks.Conversion = true
 			res.Webhooks.Spoke = opts.Spoke
 		}
+		if opts.DoValidatingAdmissionPolicy {
+			res.Webhooks.ValidatingAdmissionPolicy = true
+		}
 	}
 
 	if len(opts.ExternalAPIPath) > 0 {
@@ -130,6 +195,7 @@ func (opts Options) UpdateResource(res *resource.Resource, c config.Config) {
 			if res.External {
 				res.Path = opts.ExternalAPIPath
 				res.Domain = opts.ExternalAPIDomain
+				res.Module = opts.ExternalAPIModule
 			} else {
 				// Handle core types
 				if domain, found := coreGroups[res.Group]; found {
@@ -73,7 +73,8 @@ var _ = Describe("Options", func() {
 					if options.Plural != "" {
 						Expect(res.Plural).To(Equal(options.Plural))
 					}
-					if options.DoAPI || options.DoDefaulting || options.DoValidation || options.DoConversion {
+					if options.DoAPI || options.DoDefaulting || options.DoValidation || options.DoConversion ||
+						options.DoValidatingAdmissionPolicy {
 						if multiGroup {
 							Expect(res.Path).To(Equal(
 								path.Join(cfg.GetRepository(), "api", gvk.Group, gvk.Version)))
@@ -87,16 +88,19 @@ var _ = Describe("Options", func() {
 					Expect(res.API).NotTo(BeNil())
 					if options.DoAPI {
 						Expect(res.API.Namespaced).To(Equal(options.Namespaced))
+						Expect(res.API.StorageVersion).To(Equal(options.StorageVersion))
 						Expect(res.API.IsEmpty()).To(BeFalse())
 					} else {
 						Expect(res.API.IsEmpty()).To(BeTrue())
 					}
 					Expect(res.Controller).To(Equal(options.DoController))
 					Expect(res.Webhooks).NotTo(BeNil())
-					if options.DoDefaulting || options.DoValidation || options.DoConversion {
+					if options.DoDefaulting || options.DoValidation || options.DoConversion ||
+						options.DoValidatingAdmissionPolicy {
 						Expect(res.Webhooks.Defaulting).To(Equal(options.DoDefaulting))
 						Expect(res.Webhooks.Validation).To(Equal(options.DoValidation))
 						Expect(res.Webhooks.Conversion).To(Equal(options.DoConversion))
+						Expect(res.Webhooks.ValidatingAdmissionPolicy).To(Equal(options.DoValidatingAdmissionPolicy))
 						Expect(res.Webhooks.Spoke).To(Equal(options.Spoke))
 						Expect(res.Webhooks.IsEmpty()).To(BeFalse())
 					} else {
@@ -110,6 +114,12 @@ var _ = Describe("Options", func() {
 			Entry("when updating nothing", Options{}),
 			Entry("when updating the plural", Options{Plural: "mates"}),
 			Entry("when updating the Controller", Options{DoController: true}),
+			Entry("when updating the API as storage version", Options{DoAPI: true, StorageVersion: true}),
+			Entry("when updating the validating admission policy", Options{DoValidatingAdmissionPolicy: true}),
+			Entry("when updating the validating admission policy and webhook", Options{
+				DoValidation:                true,
+				DoValidatingAdmissionPolicy: true,
+			}),
 		)
 
 		DescribeTable("should use core apis",
@@ -185,5 +195,93 @@ var _ = Describe("Options", func() {
 			Entry("for `apps`", "apps", "apps"),
 			Entry("for `authentication`", "authentication", "authentication.k8s.io"),
 		)
+
+		It("should use external apis", func() {
+			options := Options{
+				DoController:      true,
+				ExternalAPIPath:   "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1",
+				ExternalAPIDomain: "io",
+				ExternalAPIModule: "github.com/cert-manager/cert-manager@v1.17.0",
+			}
+			res := resource.Resource{
+				GVK: resource.GVK{
+					Group:   "cert-manager",
+					Domain:  domain,
+					Version: version,
+					Kind:    "Certificate",
+				},
+				Plural:   "certificates",
+				API:      &resource.API{},
+				Webhooks: &resource.Webhooks{},
+			}
+
+			options.UpdateResource(&res, cfg)
+			Expect(res.Validate()).To(Succeed())
+
+			Expect(res.IsExternal()).To(BeTrue())
+			Expect(res.HasAPI()).To(BeFalse())
+			Expect(res.Path).To(Equal(options.ExternalAPIPath))
+			Expect(res.Module).To(Equal(options.ExternalAPIModule))
+			Expect(res.QualifiedGroup()).To(Equal("cert-manager.io"))
+		})
+	})
+
+	Context("plural of the kinds tracked in the project", func() {
+		var cfg config.Config
+
+		gvk := func(version string) resource.GVK {
+			return resource.GVK{Group: "crew", Domain: "test.io", Version: version, Kind: "FirstMate"}
+		}
+
+		BeforeEach(func() {
+			cfg = cfgv3.New()
+			_ = cfg.SetRepository("test")
+			Expect(cfg.AddResource(resource.Resource{GVK: gvk("v1"), Plural: "mates"})).To(Succeed())
+		})
+
+		It("should keep the plural of the other versions of the kind", func() {
+			res := resource.Resource{
+				GVK:      gvk("v2"),
+				Plural:   "firstmates",
+				API:      &resource.API{},
+				Webhooks: &resource.Webhooks{},
+			}
+			Options{DoAPI: true}.UpdateResource(&res, cfg)
+			Expect(res.Plural).To(Equal("mates"))
+		})
+
+		DescribeTable("should validate the plural",
+			func(options Options, version string, succeed bool) {
+				if succeed {
+					Expect(options.ValidatePlural(gvk(version), cfg)).To(Succeed())
+				} else {
+					Expect(options.ValidatePlural(gvk(version), cfg)).NotTo(Succeed())
+				}
+			},
+			Entry("when it is not set", Options{}, "v2", true),
+			Entry("when it is the tracked plural", Options{Plural: "mates"}, "v2", true),
+			Entry("when it is another plural", Options{Plural: "firstmates"}, "v2", false),
+			Entry("when it is another plural of the same version", Options{Plural: "crewmates"}, "v1", false),
+		)
+	})
+
+	Context("ValidateExternalAPI", func() {
+		DescribeTable("should succeed",
+			func(options Options) { Expect(options.ValidateExternalAPI()).To(Succeed()) },
+			Entry("without external api", Options{}),
+			Entry("with the path only", Options{ExternalAPIPath: "example.com/module/api/v1"}),
+			Entry("with the path, domain and module", Options{
+				ExternalAPIPath:   "example.com/module/api/v1",
+				ExternalAPIDomain: "example.com",
+				ExternalAPIModule: "example.com/module@v1.0.0",
+			}),
+		)
+
+		DescribeTable("should fail",
+			func(options Options) { Expect(options.ValidateExternalAPI()).NotTo(Succeed()) },
+			Entry("with the domain only", Options{ExternalAPIDomain: "example.com"}),
+			Entry("with the module only", Options{ExternalAPIModule: "example.com/module@v1.0.0"}),
+			Entry("with a versioned path", Options{ExternalAPIPath: "example.com/module/api/v1@v1.0.0"}),
+		)
 	})
 })
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package golang
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	gomodule "golang.org/x/mod/module"
+)
+
+// Tool is a Go tool installed by the Makefile of the project with 'go install'
+type Tool struct {
+	// Name is the name of the binary of the tool
+	Name string
+	// Module is the Go module which provides the tool
+	Module string
+	// Version is the version of the module, or the name of its release branch
+	Version string
+}
+
+// ToolMirror is a mirror of the tools of the project, for the environments without access to the internet.
+// It serves the Go modules of the tools as a Go module proxy under <URL>/go, and the index of the binaries
+// of envtest under <URL>/envtest.
+type ToolMirror struct {
+	// URL is the base URL of the mirror
+	URL string
+}
+
+// ValidateToolMirrorURL checks that the base URL of a tool mirror is an absolute HTTP(S) URL
+func ValidateToolMirrorURL(mirror string) error {
+	u, err := url.Parse(mirror)
+	if err != nil {
+		return fmt.Errorf("invalid tool mirror %q: %w", mirror, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid tool mirror %q: it must be an absolute http or https URL", mirror)
+	}
+	return nil
+}
+
+// GoProxy returns the URL of the Go module proxy of the mirror
+func (m ToolMirror) GoProxy() string {
+	return strings.TrimSuffix(m.URL, "/") + "/go"
+}
+
+// EnvtestIndex returns the URL of the index of the binaries of envtest of the mirror
+func (m ToolMirror) EnvtestIndex() string {
+	return strings.TrimSuffix(m.URL, "/") + "/envtest/envtest-releases.yaml"
+}
+
+// Check checks that the mirror serves the versions of the tools and the index of the binaries of envtest.
+// It returns an error for each of them which is not available.
+func (m ToolMirror) Check(ctx context.Context, client *http.Client, tools []Tool) []error {
+	var errs []error
+	for _, tool := range tools {
+		escapedPath, err := gomodule.EscapePath(tool.Module)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid module %q of %s: %w", tool.Module, tool.Name, err))
+			continue
+		}
+		escapedVersion, err := gomodule.EscapeVersion(tool.Version)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid version %q of %s: %w", tool.Version, tool.Name, err))
+			continue
+		}
+		infoURL := fmt.Sprintf("%s/%s/@v/%s.info", m.GoProxy(), escapedPath, escapedVersion)
+		if err := checkURL(ctx, client, infoURL); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s is not available: %w", tool.Name, tool.Version, err))
+		}
+	}
+	if err := checkURL(ctx, client, m.EnvtestIndex()); err != nil {
+		errs = append(errs, fmt.Errorf("the index of the binaries of envtest is not available: %w", err))
+	}
+	return errs
+}
+
+// checkURL checks that the URL can be fetched
+func checkURL(ctx context.Context, client *http.Client, target string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %s", target, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ToolMirror", func() {
+	DescribeTable("should validate the URL of the mirror",
+		func(mirror string, valid bool) {
+			err := ValidateToolMirrorURL(mirror)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("for an https URL", "https://mirror.example.com/tools", true),
+		Entry("for an http URL", "http://10.0.0.1:8080", true),
+		Entry("for a URL without scheme", "mirror.example.com", false),
+		Entry("for a URL with another scheme", "file:///srv/mirror", false),
+	)
+
+	It("should build the URLs of the Go module proxy and the envtest index", func() {
+		mirror := ToolMirror{URL: "https://mirror.example.com/tools/"}
+		Expect(mirror.GoProxy()).To(Equal("https://mirror.example.com/tools/go"))
+		Expect(mirror.EnvtestIndex()).To(Equal("https://mirror.example.com/tools/envtest/envtest-releases.yaml"))
+	})
+
+	Context("Check", func() {
+		var (
+			server    *httptest.Server
+			requested []string
+		)
+
+		BeforeEach(func() {
+			requested = nil
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requested = append(requested, r.URL.Path)
+				switch r.URL.Path {
+				case "/go/sigs.k8s.io/controller-tools/@v/v0.17.2.info",
+					"/go/github.com/!burnt!sushi/toml/@v/v1.4.0.info",
+					"/envtest/envtest-releases.yaml":
+					w.WriteHeader(http.StatusOK)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should succeed when the mirror serves the tools", func() {
+			errs := ToolMirror{URL: server.URL}.Check(context.Background(), server.Client(), []Tool{
+				{Name: "controller-gen", Module: "sigs.k8s.io/controller-tools", Version: "v0.17.2"},
+				{Name: "tomlv", Module: "github.com/BurntSushi/toml", Version: "v1.4.0"},
+			})
+			Expect(errs).To(BeEmpty())
+			Expect(requested).To(ContainElement("/go/github.com/!burnt!sushi/toml/@v/v1.4.0.info"))
+		})
+
+		It("should report the tools which are not served", func() {
+			errs := ToolMirror{URL: server.URL}.Check(context.Background(), server.Client(), []Tool{
+				{Name: "controller-gen", Module: "sigs.k8s.io/controller-tools", Version: "v0.17.2"},
+				{Name: "kustomize", Module: "sigs.k8s.io/kustomize/kustomize/v5", Version: "v5.6.0"},
+			})
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(ContainSubstring("kustomize v5.6.0 is not available"))
+			Expect(errs[0].Error()).To(ContainSubstring("404"))
+		})
+	})
+})
@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
@@ -30,6 +32,7 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/util"
+	kustomizecommonv2scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
 	goPlugin "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds"
 )
@@ -55,6 +58,12 @@ type createAPISubcommand struct {
 
 	// runMake indicates whether to run make or not after scaffolding APIs
 	runMake bool
+
+	// controllerOptions defines the optional features scaffolded in the controller
+	controllerOptions scaffolds.ControllerOptions
+
+	// watches are the secondary resources watched by the controller, parsed into the controller options
+	watches []string
 }
 
 func (p *createAPISubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
@@ -69,6 +78,30 @@ make generate will be run.
 	subcmdMeta.Examples = fmt.Sprintf(`  # Create a frigates API with Group: ship, Version: v1beta1 and Kind: Frigate
   %[1]s create api --group ship --version v1beta1 --kind Frigate
 
+  # Create a frigates API with a controller which filters its events with predicates
+  %[1]s create api --group ship --version v1beta1 --kind Frigate --with-predicates
+
+  # Create a frigates API with a controller which handles its deletion with a finalizer
+  %[1]s create api --group ship --version v1beta1 --kind Frigate --with-finalizer
+
+  # Create a frigates API which reports its state with status conditions
+  %[1]s create api --group ship --version v1beta1 --kind Frigate --with-status-conditions
+
+  # Create a frigates API whose controller classifies its errors as terminal or transient errors
+  %[1]s create api --group ship --version v1beta1 --kind Frigate --with-error-handling --with-status-conditions
+
+  # Create a frigates API with table-driven controller tests against the fake client instead of envtest
+  %[1]s create api --group ship --version v1beta1 --kind Frigate --unit-tests=fake
+
+  # Create a frigates API with a controller which owns Deployments and watches the ConfigMaps it references
+  %[1]s create api --group ship --version v1beta1 --kind Frigate --watch apps/Deployment,core/ConfigMap:map
+
+  # Create a frigates API whose controller runs in the manager-a manager, informed with 'init --managers'
+  %[1]s create api --group ship --version v1beta1 --kind Frigate --manager manager-a
+
+  # Create the version v1 of the Frigate kind and make it the version stored in etcd
+  %[1]s create api --group ship --version v1 --kind Frigate --storage-version
+
   # Edit the API Scheme
 
   nano api/v1beta1/frigate_types.go
@@ -104,6 +137,9 @@ func (p *createAPISubcommand) BindFlags(fs *pflag.FlagSet) {
 		"if set, generate the resource without prompting the user")
 	p.resourceFlag = fs.Lookup("resource")
 	fs.BoolVar(&p.options.Namespaced, "namespaced", true, "resource is namespaced")
+	fs.BoolVar(&p.options.StorageVersion, "storage-version", false,
+		"if set, the version of the resource is the version of its kind stored in etcd. The storage version "+
+			"marker is removed from the other versions of the kind. Requires '--resource=true'")
 
 	fs.BoolVar(&p.options.DoController, "controller", true,
 		"if set, generate the controller without prompting the user")
@@ -117,10 +153,88 @@ func (p *createAPISubcommand) BindFlags(fs *pflag.FlagSet) {
 		"Specify the domain name for the external API. This domain is used to generate accurate RBAC "+
 			"markers and permissions for the external resources (e.g., cert-manager.io).")
 
+	fs.StringVar(&p.options.ExternalAPIModule, "external-api-module", "",
+		"Specify the Go module which provides the external API, optionally with its version "+
+			"(e.g., github.com/cert-manager/cert-manager@v1.17.0). It is added to the go.mod of the project.")
+
+	fs.BoolVar(&p.controllerOptions.WithPredicates, "with-predicates", false,
+		"if set, scaffold the controller with event predicates (GenerationChangedPredicate and an optional "+
+			"label selector) and a tunable MaxConcurrentReconciles option in SetupWithManager")
+
+	fs.BoolVar(&p.controllerOptions.WithFinalizer, "with-finalizer", false,
+		"if set, scaffold the controller with a finalizer, the logic to add and remove it and "+
+			"a reconcileDelete function to implement the cleanup operations")
+
+	fs.BoolVar(&p.controllerOptions.WithStatusConditions, "with-status-conditions", false,
+		"if set, scaffold the API with a Conditions status field, its helper functions and printer columns, "+
+			"and the controller setting the Ready condition. Requires '--resource=true'")
+
+	fs.BoolVar(&p.controllerOptions.WithRateLimiter, "with-rate-limiter", false,
+		"if set, scaffold the controller with the rate limiter of its workqueue, an exponential backoff per "+
+			"request and an overall token bucket tuned with the --rate-limiter-* flags of the manager")
+
+	fs.BoolVar(&p.controllerOptions.WithErrorHandling, "with-error-handling", false,
+		"if set, scaffold the internal/errors helpers classifying the errors of the reconciliation as terminal "+
+			"or transient errors, and the controller returning its result with them. With --with-status-conditions, "+
+			"the terminal errors are reported in the Ready condition")
+
+	fs.StringSliceVar(&p.controllerOptions.UnitTests, "unit-tests", []string{scaffolds.EnvtestUnitTests},
+		fmt.Sprintf("kinds of unit tests scaffolded for the controller, any of %q: envtest runs the tests against "+
+			"a local control plane, fake runs table-driven tests against the fake client of controller-runtime",
+			scaffolds.UnitTestKinds))
+
+	fs.StringSliceVar(&p.watches, "watch", nil,
+		"secondary resources watched by the controller, formatted as <group>/[<version>/]<kind>[:<mode>], "+
+			"e.g. apps/Deployment or core/v1/ConfigMap:map. The mode owns (default) watches the objects controlled "+
+			"by the reconciled object with Owns(), the mode map watches them with Watches() and a mapping function. "+
+			"The RBAC markers of the watched resources are scaffolded")
+
+	fs.StringVar(&p.controllerOptions.Manager, "manager", "",
+		"name of the additional manager, informed with 'init --managers', which runs the controller. "+
+			"The controller is wired in its cmd/<name>/main.go instead of cmd/main.go")
 }
 
 func (p *createAPISubcommand) InjectConfig(c config.Config) error {
 	p.config = c
+
+	if err := checkNotWebhookOnly(c); err != nil {
+		return err
+	}
+	return checkManager(c, p.controllerOptions.Manager)
+}
+
+// checkNotWebhookOnly returns an error for the projects initialized with '--webhook-only',
+// which have no CRDs nor controllers
+func checkNotWebhookOnly(c config.Config) error {
+	kustomizeCfg, err := kustomizecommonv2scaffolds.LoadPluginConfig(c)
+	if err != nil {
+		return fmt.Errorf("error loading the kustomize plugin configuration: %w", err)
+	}
+	if kustomizeCfg.WebhookOnly {
+		return errors.New("APIs and controllers cannot be created in a project initialized with '--webhook-only', " +
+			"use 'create webhook' with '--external-api-path' or a core type (i.e. '--group core --version v1 " +
+			"--kind Pod') instead")
+	}
+	return nil
+}
+
+// checkManager returns an error if the manager informed with '--manager' is not one of the additional
+// managers of the project, informed with 'init --managers'
+func checkManager(c config.Config, manager string) error {
+	if manager == "" {
+		return nil
+	}
+	kustomizeCfg, err := kustomizecommonv2scaffolds.LoadPluginConfig(c)
+	if err != nil {
+		return fmt.Errorf("error loading the kustomize plugin configuration: %w", err)
+	}
+	if !kustomizeCfg.HasManager(manager) {
+		if len(kustomizeCfg.Managers) == 0 {
+			return fmt.Errorf("invalid value %q of '--manager', the project has no additional managers", manager)
+		}
+		return fmt.Errorf("invalid value %q of '--manager', must be one of the additional managers of the "+
+			"project: %s", manager, strings.Join(kustomizeCfg.Managers, ", "))
+	}
 	return nil
 }
 
@@ -137,21 +251,73 @@ func (p *createAPISubcommand) InjectResource(res *resource.Resource) error {
 		p.options.DoController = util.YesNo(reader)
 	}
 
+	if !p.options.DoAPI && p.controllerOptions.WithStatusConditions {
+		return errors.New("'--with-status-conditions' can only be used when creating an API in the project " +
+			"with '--resource=true'")
+	}
+	if !p.options.DoAPI && p.options.StorageVersion {
+		return errors.New("'--storage-version' can only be used when creating an API in the project " +
+			"with '--resource=true'")
+	}
+
+	if err := p.options.ValidateExternalAPI(); err != nil {
+		return err
+	}
+	if err := p.options.ValidatePlural(p.resource.GVK, p.config); err != nil {
+		return err
+	}
+
 	// Ensure that external API options cannot be used when creating an API in the project.
 	if p.options.DoAPI {
-		if len(p.options.ExternalAPIPath) != 0 || len(p.options.ExternalAPIDomain) != 0 {
-			return errors.New("Cannot use '--external-api-path' or '--external-api-domain' " +
+		if len(p.options.ExternalAPIPath) != 0 || len(p.options.ExternalAPIDomain) != 0 ||
+			len(p.options.ExternalAPIModule) != 0 {
+			return errors.New("Cannot use '--external-api-path', '--external-api-domain' or '--external-api-module' " +
 				"when creating an API in the project with '--resource=true'. " +
 				"Use '--resource=false' when referencing an external API.")
 		}
 	}
 
+	if !p.options.DoController {
+		if p.controllerOptions.WithPredicates {
+			return errors.New("'--with-predicates' can only be used when scaffolding a controller " +
+				"with '--controller=true'")
+		}
+		if p.controllerOptions.WithFinalizer {
+			return errors.New("'--with-finalizer' can only be used when scaffolding a controller " +
+				"with '--controller=true'")
+		}
+		if p.controllerOptions.WithRateLimiter {
+			return errors.New("'--with-rate-limiter' can only be used when scaffolding a controller " +
+				"with '--controller=true'")
+		}
+		if p.controllerOptions.WithErrorHandling {
+			return errors.New("'--with-error-handling' can only be used when scaffolding a controller " +
+				"with '--controller=true'")
+		}
+		if len(p.watches) != 0 {
+			return errors.New("'--watch' can only be used when scaffolding a controller with '--controller=true'")
+		}
+		if p.controllerOptions.Manager != "" {
+			return errors.New("'--manager' can only be used when scaffolding a controller with '--controller=true'")
+		}
+	}
+
+	if err := validateUnitTests(p.controllerOptions.UnitTests); err != nil {
+		return err
+	}
+
 	p.options.UpdateResource(p.resource, p.config)
 
 	if err := p.resource.Validate(); err != nil {
 		return err
 	}
 
+	watches, err := parseWatches(p.watches, p.config, p.resource)
+	if err != nil {
+		return err
+	}
+	p.controllerOptions.Watches = watches
+
 	// In case we want to scaffold a resource API we need to do some checks
 	if p.options.DoAPI {
 		// Check that resource doesn't have the API scaffolded or flag force was set
@@ -169,6 +335,50 @@ func (p *createAPISubcommand) InjectResource(res *resource.Resource) error {
 	return nil
 }
 
+// validateUnitTests checks the kinds of unit tests scaffolded for the controller
+func validateUnitTests(unitTests []string) error {
+	if len(unitTests) == 0 {
+		return fmt.Errorf("'--unit-tests' requires at least one of %q", scaffolds.UnitTestKinds)
+	}
+	for _, kind := range unitTests {
+		if !slices.Contains(scaffolds.UnitTestKinds, kind) {
+			return fmt.Errorf("invalid value %q of '--unit-tests', must be any of %q", kind, scaffolds.UnitTestKinds)
+		}
+	}
+	return nil
+}
+
+// parseWatches parses the secondary resources watched by the controller of the resource
+func parseWatches(values []string, c config.Config, res *resource.Resource) ([]goPlugin.Watch, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	if res.Path == "" {
+		return nil, fmt.Errorf("'--watch' requires the types of the kind %s, use '--external-api-path' "+
+			"to reference an external API", res.Kind)
+	}
+
+	watches := make([]goPlugin.Watch, 0, len(values))
+	kinds := make(map[string]string, len(values))
+	for _, value := range values {
+		watch, err := goPlugin.ParseWatch(value, c)
+		if err != nil {
+			return nil, err
+		}
+		if watch.Resource.IsEqualTo(res.GVK) {
+			return nil, fmt.Errorf("the kind %s cannot watch itself with '--watch', it is already watched by "+
+				"its controller", res.Kind)
+		}
+		// The kinds name the mapping functions of the controller
+		if previous, found := kinds[watch.Resource.Kind]; found {
+			return nil, fmt.Errorf("the kind %s is watched twice, with %q and %q", watch.Resource.Kind, previous, value)
+		}
+		kinds[watch.Resource.Kind] = value
+		watches = append(watches, watch)
+	}
+	return watches, nil
+}
+
 func (p *createAPISubcommand) PreScaffold(machinery.Filesystem) error {
 	// check if main.go is present in the root directory
 	if _, err := os.Stat(DefaultMainPath); os.IsNotExist(err) {
@@ -179,13 +389,29 @@ func (p *createAPISubcommand) PreScaffold(machinery.Filesystem) error {
 }
 
 func (p *createAPISubcommand) Scaffold(fs machinery.Filesystem) error {
-	scaffolder := scaffolds.NewAPIScaffolder(p.config, *p.resource, p.force)
+	scaffolder := scaffolds.NewAPIScaffolder(p.config, *p.resource, p.force, p.controllerOptions)
 	scaffolder.InjectFS(fs)
 	return scaffolder.Scaffold()
 }
 
 func (p *createAPISubcommand) PostScaffold() error {
-	err := util.RunCmd("Update dependencies", "go", "mod", "tidy")
+	pluginCfg, err := scaffolds.LoadPluginConfig(p.config)
+	if err != nil {
+		return err
+	}
+	if pluginCfg.MultiGroupModules && p.resource.HasAPI() && p.resource.Group != "" {
+		err = util.RunCmd("Update group module dependencies", "go", "-C",
+			scaffolds.GroupModulePath(p.resource.Group), "mod", "tidy")
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := getExternalAPIModule(p.resource); err != nil {
+		return err
+	}
+
+	err = util.RunCmd("Update dependencies", "go", "mod", "tidy")
 	if err != nil {
 		return err
 	}
@@ -199,3 +425,12 @@ func (p *createAPISubcommand) PostScaffold() error {
 
 	return nil
 }
+
+// getExternalAPIModule adds the module which provides the types of an external resource to the go.mod,
+// at the version informed with --external-api-module, before the dependencies are tidied.
+func getExternalAPIModule(res *resource.Resource) error {
+	if !res.IsExternal() || res.Module == "" {
+		return nil
+	}
+	return util.RunCmd("Get the module of the external API", "go", "get", res.Module)
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v4
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/util"
+	goPlugin "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds"
+)
+
+var _ plugin.CreateControllerSubcommand = &createControllerSubcommand{}
+
+// controllerNameRegex matches the names of the controllers, which prefix the name of their reconciler type
+var controllerNameRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+type createControllerSubcommand struct {
+	config config.Config
+
+	options *goPlugin.Options
+
+	resource *resource.Resource
+
+	// name is the name of the controller, the kind of the resource by default
+	name string
+
+	// force indicates that the controller should be created even if it already exists
+	force bool
+
+	// controllerOptions defines the optional features scaffolded in the controller
+	controllerOptions scaffolds.ControllerOptions
+
+	// watches are the secondary resources watched by the controller, parsed into the controller options
+	watches []string
+}
+
+func (p *createControllerSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	subcmdMeta.Description = `Scaffold a controller, its RBAC markers, its tests and its wiring in cmd/main.go for a kind
+whose types are not scaffolded: a core type, an external type or an API already present in the project.
+
+The controller is named after the kind unless '--name' is set, which allows to scaffold several
+controllers reconciling the same kind. The controllers named with '--name' are tracked in the PROJECT file.
+`
+	subcmdMeta.Examples = fmt.Sprintf(`  # Create a controller for the Frigate API of the project
+  %[1]s create controller --group ship --version v1beta1 --kind Frigate
+
+  # Create a second controller for the Frigate kind, reconciled by the FrigateAuditReconciler
+  %[1]s create controller --group ship --version v1beta1 --kind Frigate --name FrigateAudit
+
+  # Create a controller for the Deployment core type
+  %[1]s create controller --group apps --version v1 --kind Deployment
+
+  # Create a controller for the Deployment core type which watches the ConfigMaps it mounts
+  %[1]s create controller --group apps --version v1 --kind Deployment --name DeploymentConfig \
+    --watch core/ConfigMap:map
+
+  # Create a controller for the Certificate kind of cert-manager
+  %[1]s create controller --group certmanager --version v1 --kind Certificate \
+    --external-api-path github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1 \
+    --external-api-domain cert-manager.io
+
+  # Edit the Controller
+  nano internal/controller/frigateaudit_controller.go
+
+  # Edit the Controller Test
+  nano internal/controller/frigateaudit_controller_test.go
+`, cliMeta.CommandName)
+}
+
+func (p *createControllerSubcommand) BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&p.force, "force", false,
+		"attempt to create the controller even if it already exists")
+
+	fs.StringVar(&p.name, "name", "",
+		"name of the controller, which prefixes its reconciler and its files (e.g. FrigateAudit). "+
+			"Defaults to the kind, set it to scaffold another controller for a kind which already has one")
+
+	p.options = &goPlugin.Options{}
+
+	fs.StringVar(&p.options.Plural, "plural", "", "resource irregular plural form")
+
+	fs.StringVar(&p.options.ExternalAPIPath, "external-api-path", "",
+		"Specify the Go package import path for the external API. This is used to scaffold controllers for resources "+
+			"defined outside this project (e.g., github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1).")
+
+	fs.StringVar(&p.options.ExternalAPIDomain, "external-api-domain", "",
+		"Specify the domain name for the external API. This domain is used to generate accurate RBAC "+
+			"markers and permissions for the external resources (e.g., cert-manager.io).")
+
+	fs.StringVar(&p.options.ExternalAPIModule, "external-api-module", "",
+		"Specify the Go module which provides the external API, optionally with its version "+
+			"(e.g., github.com/cert-manager/cert-manager@v1.17.0). It is added to the go.mod of the project.")
+
+	fs.BoolVar(&p.controllerOptions.WithPredicates, "with-predicates", false,
+		"if set, scaffold the controller with event predicates (GenerationChangedPredicate and an optional "+
+			"label selector) and a tunable MaxConcurrentReconciles option in SetupWithManager")
+
+	fs.BoolVar(&p.controllerOptions.WithFinalizer, "with-finalizer", false,
+		"if set, scaffold the controller with a finalizer, the logic to add and remove it and "+
+			"a reconcileDelete function to implement the cleanup operations")
+
+	fs.BoolVar(&p.controllerOptions.WithRateLimiter, "with-rate-limiter", false,
+		"if set, scaffold the controller with the rate limiter of its workqueue, an exponential backoff per "+
+			"request and an overall token bucket tuned with the --rate-limiter-* flags of the manager")
+
+	fs.BoolVar(&p.controllerOptions.WithErrorHandling, "with-error-handling", false,
+		"if set, scaffold the internal/errors helpers classifying the errors of the reconciliation as terminal "+
+			"or transient errors, and the controller returning its result with them")
+
+	fs.StringSliceVar(&p.controllerOptions.UnitTests, "unit-tests", []string{scaffolds.EnvtestUnitTests},
+		fmt.Sprintf("kinds of unit tests scaffolded for the controller, any of %q: envtest runs the tests against "+
+			"a local control plane, fake runs table-driven tests against the fake client of controller-runtime",
+			scaffolds.UnitTestKinds))
+
+	fs.StringSliceVar(&p.watches, "watch", nil,
+		"secondary resources watched by the controller, formatted as <group>/[<version>/]<kind>[:<mode>], "+
+			"e.g. apps/Deployment or core/v1/ConfigMap:map. The mode owns (default) watches the objects controlled "+
+			"by the reconciled object with Owns(), the mode map watches them with Watches() and a mapping function. "+
+			"The RBAC markers of the watched resources are scaffolded")
+
+	fs.StringVar(&p.controllerOptions.Manager, "manager", "",
+		"name of the additional manager, informed with 'init --managers', which runs the controller. "+
+			"The controller is wired in its cmd/<name>/main.go instead of cmd/main.go")
+}
+
+func (p *createControllerSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+
+	if err := checkNotWebhookOnly(c); err != nil {
+		return err
+	}
+	return checkManager(c, p.controllerOptions.Manager)
+}
+
+func (p *createControllerSubcommand) InjectResource(res *resource.Resource) error {
+	p.resource = res
+
+	if p.name == "" {
+		p.name = p.resource.Kind
+	}
+	if !controllerNameRegex.MatchString(p.name) {
+		return fmt.Errorf("invalid value %q of '--name', must be alphanumeric and start with an uppercase "+
+			"character (e.g. FrigateAudit)", p.name)
+	}
+
+	if err := p.options.ValidateExternalAPI(); err != nil {
+		return err
+	}
+	if err := p.options.ValidatePlural(p.resource.GVK, p.config); err != nil {
+		return err
+	}
+	if err := validateUnitTests(p.controllerOptions.UnitTests); err != nil {
+		return err
+	}
+
+	// The types of an API already scaffolded in the project are reused, not regenerated
+	loadedRes, err := p.config.GetResource(p.resource.GVK)
+	alreadyTracked := err == nil
+	if alreadyTracked && loadedRes.HasAPI() {
+		if len(p.options.ExternalAPIPath) != 0 {
+			return fmt.Errorf("the API of the kind %s is scaffolded in the project, "+
+				"'--external-api-path' cannot be used to reference it", p.resource.Kind)
+		}
+		p.resource.Path = loadedRes.Path
+		p.resource.Plural = loadedRes.Plural
+	}
+
+	p.options.DoController = true
+	p.options.UpdateResource(p.resource, p.config)
+
+	if err := p.resource.Validate(); err != nil {
+		return err
+	}
+
+	if p.resource.Path == "" {
+		return fmt.Errorf("the kind %s is neither an API of the project nor a core type, use 'create api' to "+
+			"scaffold its types or '--external-api-path' to reference an external API", p.resource.Kind)
+	}
+
+	watches, err := parseWatches(p.watches, p.config, p.resource)
+	if err != nil {
+		return err
+	}
+	p.controllerOptions.Watches = watches
+
+	if p.force {
+		return nil
+	}
+	if p.name == p.resource.Kind {
+		if alreadyTracked && loadedRes.HasController() {
+			return fmt.Errorf("the controller of the kind %s already exists, "+
+				"use '--name' to scaffold another controller for the kind", p.resource.Kind)
+		}
+		return nil
+	}
+	pluginCfg, err := scaffolds.LoadPluginConfig(p.config)
+	if err != nil {
+		return fmt.Errorf("error loading the plugin configuration: %w", err)
+	}
+	if pluginCfg.HasController(p.resource.GVK, p.name) {
+		return fmt.Errorf("the controller %s of the kind %s already exists", p.name, p.resource.Kind)
+	}
+
+	return nil
+}
+
+func (p *createControllerSubcommand) PreScaffold(machinery.Filesystem) error {
+	// check if main.go is present in the root directory
+	if _, err := os.Stat(DefaultMainPath); os.IsNotExist(err) {
+		return fmt.Errorf("%s file should present in the root directory", DefaultMainPath)
+	}
+
+	return nil
+}
+
+func (p *createControllerSubcommand) Scaffold(fs machinery.Filesystem) error {
+	scaffolder := scaffolds.NewControllerScaffolder(p.config, *p.resource, p.name, p.force, p.controllerOptions)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
+
+func (p *createControllerSubcommand) PostScaffold() error {
+	if err := getExternalAPIModule(p.resource); err != nil {
+		return err
+	}
+
+	return util.RunCmd("Update dependencies", "go", "mod", "tidy")
+}
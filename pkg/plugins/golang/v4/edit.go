@@ -18,6 +18,8 @@ package v4
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/spf13/pflag"
 
@@ -33,33 +35,121 @@ type editSubcommand struct {
 	config config.Config
 
 	multigroup bool
+	pprof      bool
+	cache      bool
+
+	// boilerplate options
+	license         string
+	owner           string
+	boilerplatePath string
+
+	// flagSet is used to know if the multigroup layout, the pprof endpoint and the cache options must be toggled
+	flagSet *pflag.FlagSet
 }
 
 func (p *editSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
 	subcmdMeta.Description = `This command will edit the project configuration.
 Features supported:
   - Toggle between single or multi group projects.
+  - Enable or disable the pprof profiling endpoint of the manager.
+  - Enable or disable the flags which restrict the objects cached by the manager.
+  - Change the license of the project: the boilerplate file, the license header of all the Go files
+    and the boilerplate used by controller-gen are rewritten.
 `
 	subcmdMeta.Examples = fmt.Sprintf(`  # Enable the multigroup layout
   %[1]s edit --multigroup
 
   # Disable the multigroup layout
   %[1]s edit --multigroup=false
+
+  # Scaffold the --pprof-bind-address flag which exposes the pprof endpoint of the manager
+  %[1]s edit --pprof
+
+  # Scaffold the --cache-namespaces and --cache-label-selector flags which restrict the cache of the manager
+  %[1]s edit --cache-options
+
+  # Change the license header of all the Go files to the Apache 2.0 license with a new owner
+  %[1]s edit --license apache2 --owner "The Example Authors"
+
+  # Use the license header of the existing file hack/license.txt
+  %[1]s edit --license custom --boilerplate-path hack/license.txt
+
+  # Remove the license header of all the Go files
+  %[1]s edit --license none
 `, cliMeta.CommandName)
+
+	subcmdMeta.Flags = append(subcmdMeta.Flags, plugin.FlagMetadata{Name: "license", Enum: scaffolds.Licenses})
 }
 
 func (p *editSubcommand) BindFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&p.multigroup, "multigroup", false, "enable or disable multigroup layout")
+	fs.BoolVar(&p.pprof, "pprof", false, "enable or disable the pprof profiling endpoint of the manager")
+	fs.BoolVar(&p.cache, "cache-options", false,
+		"enable or disable the flags which restrict the namespaces and the labels of the objects cached by the manager")
+
+	// boilerplate args
+	fs.StringVar(&p.license, "license", "",
+		fmt.Sprintf("if set, change the license of the project, the '%s' license uses the existing file set "+
+			"with --boilerplate-path", scaffolds.CustomLicense))
+	fs.StringVar(&p.owner, "owner", "", "if set with --license, owner to add to the copyright")
+	fs.StringVar(&p.boilerplatePath, "boilerplate-path", "",
+		"if set, change the path to the boilerplate file used as the license header of the Go files")
+
+	p.flagSet = fs
 }
 
 func (p *editSubcommand) InjectConfig(c config.Config) error {
 	p.config = c
 
+	if p.license != "" && !slices.Contains(scaffolds.Licenses, p.license) {
+		return fmt.Errorf("invalid --license %q, must be one of '%s'",
+			p.license, strings.Join(scaffolds.Licenses, "', '"))
+	}
+	if p.owner != "" && p.license == "" {
+		return fmt.Errorf("--owner can only be set with --license")
+	}
+
 	return nil
 }
 
 func (p *editSubcommand) Scaffold(fs machinery.Filesystem) error {
-	scaffolder := scaffolds.NewEditScaffolder(p.config, p.multigroup)
-	scaffolder.InjectFS(fs)
-	return scaffolder.Scaffold()
+	changesLicense := p.license != "" || p.boilerplatePath != ""
+	changesPprof := p.flagSet.Changed("pprof")
+	changesCache := p.flagSet.Changed("cache-options")
+
+	// The multigroup layout is toggled by default, unless only the license, the pprof endpoint or the cache
+	// options are changed
+	if (!changesLicense && !changesPprof && !changesCache) || p.flagSet.Changed("multigroup") {
+		scaffolder := scaffolds.NewEditScaffolder(p.config, p.multigroup)
+		scaffolder.InjectFS(fs)
+		if err := scaffolder.Scaffold(); err != nil {
+			return err
+		}
+	}
+
+	if changesLicense {
+		scaffolder := scaffolds.NewLicenseScaffolder(p.config, p.license, p.owner, p.boilerplatePath)
+		scaffolder.InjectFS(fs)
+		if err := scaffolder.Scaffold(); err != nil {
+			return fmt.Errorf("error changing the license: %w", err)
+		}
+	}
+
+	if changesPprof {
+		scaffolder := scaffolds.NewPprofScaffolder(p.config, p.pprof)
+		scaffolder.InjectFS(fs)
+		if err := scaffolder.Scaffold(); err != nil {
+			return fmt.Errorf("error toggling the pprof endpoint: %w", err)
+		}
+	}
+
+	if changesCache {
+		scaffolder := scaffolds.NewCacheOptionsScaffolder(p.config, p.cache)
+		scaffolder.InjectFS(fs)
+		if err := scaffolder.Scaffold(); err != nil {
+			return fmt.Errorf("error toggling the cache options: %w", err)
+		}
+	}
+
+	return nil
 }
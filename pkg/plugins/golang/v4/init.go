@@ -17,10 +17,14 @@ limitations under the License.
 package v4
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 	"unicode"
 
 	log "github.com/sirupsen/logrus"
@@ -48,8 +52,9 @@ type initSubcommand struct {
 	commandName string
 
 	// boilerplate options
-	license string
-	owner   string
+	license         string
+	owner           string
+	boilerplatePath string
 
 	// go config options
 	repo string
@@ -57,6 +62,18 @@ type initSubcommand struct {
 	// flags
 	fetchDeps          bool
 	skipGoVersionCheck bool
+	multigroupModules  bool
+	controllerLayout   string
+	withTracing        bool
+	withPprof          bool
+	withHAOptions      bool
+	withCacheOptions   bool
+	e2eFramework       string
+	toolMirror         string
+	useWorkspace       bool
+
+	// workspace is the Go workspace which holds the project, if any
+	workspace *golang.Workspace
 }
 
 func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
@@ -74,7 +91,46 @@ func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *
 
   # Initialize a new project defining a specific project version
   %[1]s init --plugins go/v4 --project-version 3
+
+  # Initialize a new project instrumented with OpenTelemetry tracing
+  %[1]s init --plugins go/v4 --domain example.org --with-tracing
+
+  # Initialize a new project whose manager can expose the pprof profiling endpoint
+  %[1]s init --plugins go/v4 --domain example.org --with-pprof
+
+  # Initialize a new project whose manager tunes its graceful shutdown and leader election lease for HA deployments
+  %[1]s init --plugins go/v4 --domain example.org --with-ha-options
+
+  # Initialize a new project whose manager can restrict the namespaces and the labels of the cached objects
+  %[1]s init --plugins go/v4 --domain example.org --with-cache-options
+
+  # Initialize a new multi-group project where each API group is its own Go module
+  %[1]s init --plugins go/v4 --domain example.org --multigroup-modules
+
+  # Initialize a new project whose controllers are placed under internal/<group>/controller
+  %[1]s init --plugins go/v4 --domain example.org --controller-layout group
+
+  # Initialize a new project with declarative Chainsaw e2e tests instead of the Ginkgo suite
+  %[1]s init --plugins go/v4 --domain example.org --e2e-framework chainsaw
+
+  # Initialize a new project with the license header of the existing file hack/license.txt
+  %[1]s init --plugins go/v4 --domain example.org --license custom --boilerplate-path hack/license.txt
+
+  # Initialize a new project as a module of the Go workspace (go.work) of a monorepo
+  %[1]s init --plugins go/v4 --domain example.org --use-workspace
+
+  # Initialize a new project whose tools are downloaded from a mirror, e.g. in an air-gapped environment
+  %[1]s init --plugins go/v4 --domain example.org --tool-mirror https://artifacts.example.org/kubebuilder
 `, cliMeta.CommandName)
+
+	subcmdMeta.Flags = append(subcmdMeta.Flags,
+		plugin.FlagMetadata{Name: "license", Enum: scaffolds.Licenses},
+		plugin.FlagMetadata{Name: "controller-layout", Enum: golang.ControllerLayoutNames()},
+		plugin.FlagMetadata{
+			Name: "e2e-framework",
+			Enum: []string{scaffolds.GinkgoE2EFramework, scaffolds.ChainsawE2EFramework},
+		},
+	)
 }
 
 func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
@@ -85,18 +141,98 @@ func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&p.fetchDeps, "fetch-deps", true, "ensure dependencies are downloaded")
 
 	// boilerplate args
-	fs.StringVar(&p.license, "license", "apache2",
-		"license to use to boilerplate, may be one of 'apache2', 'none'")
+	fs.StringVar(&p.license, "license", scaffolds.ApacheLicense,
+		fmt.Sprintf("license to use to boilerplate, the '%s' license uses the existing file set with "+
+			"--boilerplate-path", scaffolds.CustomLicense))
 	fs.StringVar(&p.owner, "owner", "", "owner to add to the copyright")
+	fs.StringVar(&p.boilerplatePath, "boilerplate-path", scaffolds.DefaultBoilerplatePath,
+		"path to the boilerplate file used as the license header of the Go files")
 
 	// project args
 	fs.StringVar(&p.repo, "repo", "", "name to use for go module (e.g., github.com/user/repo), "+
 		"defaults to the go package of the current working directory.")
+
+	// layout args
+	fs.BoolVar(&p.multigroupModules, "multigroup-modules", false, "if set, enable the multigroup layout "+
+		"and scaffold each API group as its own Go module under api/<group>, wired into the project "+
+		"go.mod with replace directives")
+	fs.BoolVar(&p.useWorkspace, "use-workspace", false, "if set, scaffold the project as a module of the Go "+
+		"workspace (go.work) found in the current directory or in its parents: the module is added to the "+
+		"workspace and the image is built from the root of the workspace")
+	fs.StringVar(&p.controllerLayout, "controller-layout", string(golang.InternalControllerLayout),
+		"placement of the controllers: 'internal' under internal/controller, 'controllers' under controllers/, "+
+			"or 'group' under internal/<group>/controller. In multi-group projects, the 'internal' and "+
+			"'controllers' layouts place the controllers of each group in a subdirectory")
+
+	// observability args
+	fs.BoolVar(&p.withTracing, "with-tracing", false, "if set, scaffold the OpenTelemetry tracing setup "+
+		"with the OTLP exporter flags in cmd/main.go and the span instrumentation in the controllers")
+	fs.BoolVar(&p.withPprof, "with-pprof", false, "if set, scaffold the --pprof-bind-address flag "+
+		"which exposes the pprof profiling endpoint of the manager")
+
+	// high availability args
+	fs.BoolVar(&p.withHAOptions, "with-ha-options", false, "if set, scaffold the --graceful-shutdown-timeout "+
+		"and --leader-election-lease-duration, --leader-election-renew-deadline and --leader-election-retry-period "+
+		"flags of the manager, which are tuned by highly available deployments")
+
+	// cache args
+	fs.BoolVar(&p.withCacheOptions, "with-cache-options", false, "if set, scaffold the --cache-namespaces and "+
+		"--cache-label-selector flags of the manager, which restrict the namespaces and the labels of the "+
+		"cached objects")
+
+	// test args
+	fs.StringVar(&p.e2eFramework, "e2e-framework", scaffolds.GinkgoE2EFramework,
+		"framework used to scaffold the e2e tests")
+
+	// tool args
+	fs.StringVar(&p.toolMirror, "tool-mirror", "", "base URL of the mirror from which the Makefile downloads "+
+		"the tools, e.g. in air-gapped environments. It serves the Go modules of the tools as a Go module proxy "+
+		"under <url>/go and the index of the envtest binaries under <url>/envtest/envtest-releases.yaml")
 }
 
 func (p *initSubcommand) InjectConfig(c config.Config) error {
 	p.config = c
 
+	if p.e2eFramework != scaffolds.GinkgoE2EFramework && p.e2eFramework != scaffolds.ChainsawE2EFramework {
+		return fmt.Errorf("invalid --e2e-framework %q, must be one of '%s', '%s'",
+			p.e2eFramework, scaffolds.GinkgoE2EFramework, scaffolds.ChainsawE2EFramework)
+	}
+
+	if err := golang.ValidateControllerLayout(p.controllerLayout); err != nil {
+		return err
+	}
+
+	if !slices.Contains(scaffolds.Licenses, p.license) {
+		return fmt.Errorf("invalid --license %q, must be one of '%s'",
+			p.license, strings.Join(scaffolds.Licenses, "', '"))
+	}
+	if p.boilerplatePath == "" {
+		return fmt.Errorf("--boilerplate-path can not be empty")
+	}
+	if p.toolMirror != "" {
+		if err := golang.ValidateToolMirrorURL(p.toolMirror); err != nil {
+			return err
+		}
+	}
+
+	workspace, err := golang.FindWorkspace(".")
+	if err != nil {
+		return fmt.Errorf("error finding the Go workspace: %w", err)
+	}
+	if p.useWorkspace {
+		if workspace == nil {
+			return fmt.Errorf("--use-workspace requires a go.work file in the current directory or in its parents")
+		}
+		p.workspace = workspace
+		if p.repo == "" {
+			p.repo = workspace.GuessModulePath()
+		}
+	} else if workspace != nil && !workspace.UsesProject() {
+		log.Warnf("The project is part of the Go workspace of %s, whose go commands fail until the project is "+
+			"added to it. Use --use-workspace to scaffold the project as a module of the workspace, or set GOWORK=off",
+			workspace.Dir)
+	}
+
 	// Try to guess repository if flag is not set.
 	if p.repo == "" {
 		repoPath, err := golang.FindCurrentRepo()
@@ -106,7 +242,51 @@ func (p *initSubcommand) InjectConfig(c config.Config) error {
 		p.repo = repoPath
 	}
 
-	return p.config.SetRepository(p.repo)
+	if err := p.config.SetRepository(p.repo); err != nil {
+		return err
+	}
+
+	if p.multigroupModules {
+		if err := p.config.SetMultiGroup(); err != nil {
+			return err
+		}
+	}
+
+	usesChainsaw := p.e2eFramework == scaffolds.ChainsawE2EFramework
+	customBoilerplate := p.license != scaffolds.NoLicense && p.boilerplatePath != scaffolds.DefaultBoilerplatePath
+	customControllerLayout := p.controllerLayout != string(golang.InternalControllerLayout)
+	if p.multigroupModules || customControllerLayout || p.withTracing || p.withPprof || p.withHAOptions ||
+		p.withCacheOptions || usesChainsaw || p.license != scaffolds.ApacheLicense || customBoilerplate ||
+		p.toolMirror != "" || p.useWorkspace {
+		pluginCfg := scaffolds.PluginConfig{
+			MultiGroupModules: p.multigroupModules,
+			Tracing:           p.withTracing,
+			Pprof:             p.withPprof,
+			HAOptions:         p.withHAOptions,
+			CacheOptions:      p.withCacheOptions,
+			ToolMirror:        p.toolMirror,
+		}
+		if usesChainsaw {
+			pluginCfg.E2EFramework = scaffolds.ChainsawE2EFramework
+		}
+		if p.license != scaffolds.ApacheLicense {
+			pluginCfg.License = p.license
+		}
+		if customBoilerplate {
+			pluginCfg.BoilerplatePath = p.boilerplatePath
+		}
+		if customControllerLayout {
+			pluginCfg.ControllerLayout = p.controllerLayout
+		}
+		if p.useWorkspace {
+			pluginCfg.WorkspacePath = p.workspace.ProjectPath
+		}
+		if err := scaffolds.SavePluginConfig(p.config, pluginCfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (p *initSubcommand) PreScaffold(machinery.Filesystem) error {
@@ -117,8 +297,50 @@ func (p *initSubcommand) PreScaffold(machinery.Filesystem) error {
 		}
 	}
 
+	if p.license == scaffolds.CustomLicense {
+		if _, err := os.Stat(p.boilerplatePath); err != nil {
+			return fmt.Errorf("unable to find the boilerplate %s of the custom license: %w", p.boilerplatePath, err)
+		}
+	}
+
 	// Check if the current directory has not files or directories which does not allow to init the project
-	return checkDir()
+	if err := checkDir(p.customBoilerplatePath()); err != nil {
+		return err
+	}
+
+	if p.toolMirror != "" {
+		p.checkToolMirror()
+	}
+	return nil
+}
+
+// toolMirrorTimeout is the timeout of the requests checking the availability of the tools on the mirror
+const toolMirrorTimeout = 30 * time.Second
+
+// checkToolMirror warns about the tools which are not available on the mirror. The project is scaffolded
+// anyway, since the mirror may only be reachable from the CI of the project.
+func (p *initSubcommand) checkToolMirror() {
+	pluginCfg, err := scaffolds.LoadPluginConfig(p.config)
+	if err != nil {
+		log.Warnf("Unable to load the configuration of the plugin to check the tool mirror: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), toolMirrorTimeout)
+	defer cancel()
+	mirror := golang.ToolMirror{URL: p.toolMirror}
+	for _, err := range mirror.Check(ctx, http.DefaultClient, scaffolds.Tools(pluginCfg)) {
+		log.Warnf("The targets of the Makefile downloading the tools from the mirror %s will fail: %v",
+			p.toolMirror, err)
+	}
+}
+
+// customBoilerplatePath returns the path to the boilerplate provided by the user, if any
+func (p *initSubcommand) customBoilerplatePath() string {
+	if p.license != scaffolds.CustomLicense {
+		return ""
+	}
+	return filepath.Clean(p.boilerplatePath)
 }
 
 func (p *initSubcommand) Scaffold(fs machinery.Filesystem) error {
@@ -129,6 +351,13 @@ func (p *initSubcommand) Scaffold(fs machinery.Filesystem) error {
 		return err
 	}
 
+	// The go commands of a module of a workspace fail until it is used by the workspace
+	if p.workspace != nil && !p.workspace.UsesProject() {
+		if err := util.RunCmd("Add the module to the Go workspace", "go", "work", "use", "."); err != nil {
+			return err
+		}
+	}
+
 	if !p.fetchDeps {
 		log.Println("Skipping fetching dependencies.")
 		return nil
@@ -158,12 +387,18 @@ func (p *initSubcommand) PostScaffold() error {
 // checkDir will return error if the current directory has files which are not allowed.
 // Note that, it is expected that the directory to scaffold the project is cleaned.
 // Otherwise, it might face issues to do the scaffold.
-func checkDir() error {
+func checkDir(allowedFiles ...string) error {
 	err := filepath.Walk(".",
 		func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
+			// Allow the files provided by the user, e.g. the boilerplate of a custom license, and their directories
+			for _, allowedFile := range allowedFiles {
+				if path == allowedFile || (info.IsDir() && strings.HasPrefix(allowedFile, path+string(filepath.Separator))) {
+					return nil
+				}
+			}
 			// Allow directory trees starting with '.'
 			if info.IsDir() && strings.HasPrefix(info.Name(), ".") && info.Name() != "." {
 				return filepath.SkipDir
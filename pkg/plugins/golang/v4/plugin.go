@@ -31,12 +31,18 @@ var (
 	supportedProjectVersions = []config.Version{cfgv3.Version}
 )
 
-var _ plugin.Full = Plugin{}
+var (
+	_ plugin.Full             = Plugin{}
+	_ plugin.CreateController = Plugin{}
+	_ plugin.Deprecated       = Plugin{}
+	_ plugin.HasDeprecation   = Plugin{}
+)
 
-// Plugin implements the plugin.Full interface
+// Plugin implements the plugin.Full and plugin.CreateController interfaces
 type Plugin struct {
 	initSubcommand
 	createAPISubcommand
+	createControllerSubcommand
 	createWebhookSubcommand
 	editSubcommand
 }
@@ -56,6 +62,11 @@ func (p Plugin) GetInitSubcommand() plugin.InitSubcommand { return &p.initSubcom
 // GetCreateAPISubcommand will return the subcommand which is responsible for scaffolding apis
 func (p Plugin) GetCreateAPISubcommand() plugin.CreateAPISubcommand { return &p.createAPISubcommand }
 
+// GetCreateControllerSubcommand will return the subcommand which is responsible for scaffolding controllers
+func (p Plugin) GetCreateControllerSubcommand() plugin.CreateControllerSubcommand {
+	return &p.createControllerSubcommand
+}
+
 // GetCreateWebhookSubcommand will return the subcommand which is responsible for scaffolding webhooks
 func (p Plugin) GetCreateWebhookSubcommand() plugin.CreateWebhookSubcommand {
 	return &p.createWebhookSubcommand
@@ -64,7 +75,13 @@ func (p Plugin) GetCreateWebhookSubcommand() plugin.CreateWebhookSubcommand {
 // GetEditSubcommand will return the subcommand which is responsible for editing the scaffold of the project
 func (p Plugin) GetEditSubcommand() plugin.EditSubcommand { return &p.editSubcommand }
 
+// Deprecated define whether the plugin is deprecated and its deprecation message
+func (p Plugin) Deprecated() (bool, string) {
+	return false, ""
+}
+
 // DeprecationWarning define the deprecation message or return empty when plugin is not deprecated
 func (p Plugin) DeprecationWarning() string {
-	return ""
+	_, warning := p.Deprecated()
+	return warning
 }
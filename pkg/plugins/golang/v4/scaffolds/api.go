@@ -17,20 +17,22 @@ limitations under the License.
 package scaffolds
 
 import (
-	"errors"
 	"fmt"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
-	"github.com/spf13/afero"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/api"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/cmd"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/controllers"
+	templateserrors "sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/errors"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/hack"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/test/chainsaw"
 )
 
 var _ plugins.Scaffolder = &apiScaffolder{}
@@ -46,17 +48,103 @@ type apiScaffolder struct {
 
 	// force indicates whether to scaffold controller files even if it exists or not
 	force bool
+
+	// controllerOptions defines the optional features scaffolded in the controller
+	controllerOptions ControllerOptions
+
+	// controllerName is the name of the controller, the kind of the resource when empty
+	controllerName string
+}
+
+// ControllerOptions defines the optional features which can be scaffolded in the controller
+type ControllerOptions struct {
+	// WithPredicates scaffolds event predicates and a tunable MaxConcurrentReconciles in the controller setup
+	WithPredicates bool
+	// WithFinalizer scaffolds a finalizer with its add/remove logic and a reconcileDelete stub in the controller
+	WithFinalizer bool
+	// WithStatusConditions scaffolds the status conditions in the API and reports the Ready condition in the controller
+	WithStatusConditions bool
+	// WithRateLimiter scaffolds the rate limiter of the workqueue of the controller, tuned with the flags of the manager
+	WithRateLimiter bool
+	// WithErrorHandling scaffolds the helpers classifying the errors of the reconciliation as terminal or transient
+	// errors, and the reconciler returning its result, and reporting its terminal errors, with them
+	WithErrorHandling bool
+	// UnitTests are the kinds of unit tests scaffolded for the controller, EnvtestUnitTests when empty
+	UnitTests []string
+	// Watches are the secondary resources watched by the controller
+	Watches []golang.Watch
+	// Manager is the additional manager which runs the controller, the main manager when empty
+	Manager string
+}
+
+const (
+	// EnvtestUnitTests scaffolds the unit tests of the controller running against envtest
+	EnvtestUnitTests = "envtest"
+	// FakeUnitTests scaffolds the table-driven unit tests of the controller running against the fake client
+	FakeUnitTests = "fake"
+)
+
+// UnitTestKinds are the kinds of unit tests which can be scaffolded for the controller
+var UnitTestKinds = []string{EnvtestUnitTests, FakeUnitTests}
+
+// watchedResources returns the secondary resources owned by the reconciled objects, watched with Owns(),
+// and the other watched resources, watched with Watches()
+func (o ControllerOptions) watchedResources() (owns, watches []resource.Resource) {
+	for _, watch := range o.Watches {
+		if watch.Owns() {
+			owns = append(owns, watch.Resource)
+		} else {
+			watches = append(watches, watch.Resource)
+		}
+	}
+	return owns, watches
+}
+
+// hasUnitTests returns true if the given kind of unit tests is scaffolded for the controller
+func (o ControllerOptions) hasUnitTests(kind string) bool {
+	if len(o.UnitTests) == 0 {
+		return kind == EnvtestUnitTests
+	}
+	for _, unitTests := range o.UnitTests {
+		if unitTests == kind {
+			return true
+		}
+	}
+	return false
 }
 
 // NewAPIScaffolder returns a new Scaffolder for API/controller creation operations
-func NewAPIScaffolder(config config.Config, res resource.Resource, force bool) plugins.Scaffolder {
+func NewAPIScaffolder(config config.Config, res resource.Resource, force bool,
+	controllerOptions ControllerOptions,
+) plugins.Scaffolder {
+	return &apiScaffolder{
+		config:            config,
+		resource:          res,
+		force:             force,
+		controllerOptions: controllerOptions,
+	}
+}
+
+// NewControllerScaffolder returns a new Scaffolder for the creation of a controller whose API is not scaffolded,
+// i.e. for a core type, an external type or an API already present in the project. The controller is named after
+// the kind of the resource unless another name is given, so that several controllers can reconcile the same kind.
+func NewControllerScaffolder(config config.Config, res resource.Resource, name string, force bool,
+	controllerOptions ControllerOptions,
+) plugins.Scaffolder {
 	return &apiScaffolder{
-		config:   config,
-		resource: res,
-		force:    force,
+		config:            config,
+		resource:          res,
+		force:             force,
+		controllerOptions: controllerOptions,
+		controllerName:    name,
 	}
 }
 
+// isNamedController returns true if the controller is scaffolded in addition to the controller of the kind
+func (s *apiScaffolder) isNamedController() bool {
+	return s.controllerName != "" && s.controllerName != s.resource.Kind
+}
+
 // InjectFS implements cmdutil.Scaffolder
 func (s *apiScaffolder) InjectFS(fs machinery.Filesystem) {
 	s.fs = fs
@@ -67,59 +155,281 @@ func (s *apiScaffolder) Scaffold() error {
 	log.Println("Writing scaffold for you to edit...")
 
 	// Load the boilerplate
-	boilerplate, err := afero.ReadFile(s.fs.FS, hack.DefaultBoilerplatePath)
+	boilerplate, err := LoadBoilerplate(s.fs, s.config)
 	if err != nil {
-		if errors.Is(err, afero.ErrFileNotFound) {
-			log.Warnf("Unable to find %s: %s.\n"+
-				"This file is used to generate the license header in the project.\n"+
-				"Note that controller-gen will also use this. Therefore, ensure that you "+
-				"add the license file or configure your project accordingly.",
-				hack.DefaultBoilerplatePath, err)
-			boilerplate = []byte("")
-		} else {
-			return fmt.Errorf("error scaffolding API/controller: unable to load boilerplate: %w", err)
-		}
+		return fmt.Errorf("error scaffolding API/controller: %w", err)
 	}
 
 	// Initialize the machinery.Scaffold that will write the files to disk
 	scaffold := machinery.NewScaffold(s.fs,
 		machinery.WithConfig(s.config),
-		machinery.WithBoilerplate(string(boilerplate)),
+		machinery.WithBoilerplate(boilerplate),
 		machinery.WithResource(&s.resource),
 	)
 
+	pluginCfg, err := LoadPluginConfig(s.config)
+	if err != nil {
+		return fmt.Errorf("error loading the plugin configuration: %w", err)
+	}
+	layout := pluginCfg.GetControllerLayout()
+
 	// Keep track of these values before the update
 	doAPI := s.resource.HasAPI()
 	doController := s.resource.HasController()
 
-	if err := s.config.UpdateResource(s.resource); err != nil {
+	// The resource tracks the controller named after its kind, the other ones are tracked by the plugin
+	if s.isNamedController() {
+		if err := s.trackNamedController(); err != nil {
+			return fmt.Errorf("error tracking the controller %s: %w", s.controllerName, err)
+		}
+	} else if err := s.config.UpdateResource(s.resource); err != nil {
 		return fmt.Errorf("error updating resource: %w", err)
 	}
 
 	if doAPI {
 		if err := scaffold.Execute(
-			&api.Types{Force: s.force},
+			&api.Types{Force: s.force, WithStatusConditions: s.controllerOptions.WithStatusConditions},
 			&api.Group{},
 		); err != nil {
 			return fmt.Errorf("error scaffolding APIs: %v", err)
 		}
+
+		if s.controllerOptions.WithStatusConditions {
+			if err := scaffold.Execute(
+				&api.Conditions{Force: s.force},
+			); err != nil {
+				return fmt.Errorf("error scaffolding status conditions: %v", err)
+			}
+		}
+
+		if err := s.scaffoldGroupModule(scaffold); err != nil {
+			return fmt.Errorf("error scaffolding the Go module for the group %q: %v", s.resource.Group, err)
+		}
+
+		if err := s.scaffoldChainsawTest(scaffold); err != nil {
+			return fmt.Errorf("error scaffolding the chainsaw e2e test: %v", err)
+		}
+
+		if err := s.scaffoldStorageVersion(scaffold); err != nil {
+			return fmt.Errorf("error scaffolding the storage version: %v", err)
+		}
 	}
 
 	if doController {
+		if s.controllerOptions.WithRateLimiter {
+			if err := scaffoldRateLimiterOptions(s.fs, scaffold); err != nil {
+				return fmt.Errorf("error scaffolding the options of the rate limiter: %v", err)
+			}
+		}
+
+		if s.controllerOptions.WithErrorHandling {
+			if err := scaffold.Execute(
+				&templateserrors.Errors{},
+				&templateserrors.ErrorsTest{},
+				&templateserrors.SuiteTest{},
+			); err != nil {
+				return fmt.Errorf("error scaffolding the errors of the reconcilers: %v", err)
+			}
+		}
+
+		owns, watches := s.controllerOptions.watchedResources()
 		if err := scaffold.Execute(
-			&controllers.SuiteTest{Force: s.force},
-			&controllers.Controller{ControllerRuntimeVersion: ControllerRuntimeVersion, Force: s.force},
-			&controllers.ControllerTest{Force: s.force, DoAPI: doAPI},
+			&controllers.Controller{
+				ControllerRuntimeVersion: ControllerRuntimeVersion,
+				WithPredicates:           s.controllerOptions.WithPredicates,
+				WithFinalizer:            s.controllerOptions.WithFinalizer,
+				WithStatusConditions:     s.controllerOptions.WithStatusConditions,
+				WithRateLimiter:          s.controllerOptions.WithRateLimiter,
+				WithTracing:              pluginCfg.Tracing,
+				WithErrorHandling:        s.controllerOptions.WithErrorHandling,
+				Owns:                     owns,
+				Watches:                  watches,
+				ControllerName:           s.controllerName,
+				ControllerLayout:         layout,
+				Force:                    s.force,
+			},
 		); err != nil {
 			return fmt.Errorf("error scaffolding controller: %v", err)
 		}
+
+		if err := s.scaffoldControllerTests(scaffold, doAPI, layout); err != nil {
+			return fmt.Errorf("error scaffolding the controller tests: %v", err)
+		}
 	}
 
 	if err := scaffold.Execute(
-		&cmd.MainUpdater{WireResource: doAPI, WireController: doController},
+		&cmd.MainUpdater{
+			WireResource:     doAPI,
+			WireController:   doController && s.controllerOptions.Manager == "",
+			ControllerName:   s.controllerName,
+			ControllerLayout: layout,
+			WithRateLimiter:  s.controllerOptions.WithRateLimiter,
+		},
 	); err != nil {
 		return fmt.Errorf("error updating cmd/main.go: %v", err)
 	}
 
+	if doController && s.controllerOptions.Manager != "" {
+		return s.wireInManager(scaffold, layout)
+	}
+	return nil
+}
+
+// wireInManager wires the controller in the additional manager it is assigned to. The API is also
+// registered in the scheme of the main manager, which serves the webhooks of the project.
+func (s *apiScaffolder) wireInManager(scaffold *machinery.Scaffold, layout golang.ControllerLayout) error {
+	hasAPI := s.resource.HasAPI()
+	if res, err := s.config.GetResource(s.resource.GVK); err == nil {
+		hasAPI = hasAPI || res.HasAPI()
+	}
+
+	mainUpdater := &cmd.MainUpdater{
+		ManagerName:      s.controllerOptions.Manager,
+		WireResource:     hasAPI,
+		WireController:   true,
+		ControllerName:   s.controllerName,
+		ControllerLayout: layout,
+		WithRateLimiter:  s.controllerOptions.WithRateLimiter,
+	}
+	if err := scaffold.Execute(mainUpdater); err != nil {
+		return fmt.Errorf("error updating %s: %v", mainUpdater.GetPath(), err)
+	}
+	return nil
+}
+
+// scaffoldControllerTests creates the unit tests of the controller, running against envtest
+// and/or against the fake client of controller-runtime
+func (s *apiScaffolder) scaffoldControllerTests(scaffold *machinery.Scaffold, doAPI bool,
+	layout golang.ControllerLayout,
+) error {
+	var builders []machinery.Builder
+	if s.controllerOptions.hasUnitTests(EnvtestUnitTests) {
+		builders = append(builders,
+			&controllers.SuiteTest{ControllerLayout: layout, Force: s.force},
+			&controllers.ControllerTest{
+				ControllerLayout:     layout,
+				Force:                s.force,
+				ControllerName:       s.controllerName,
+				DoAPI:                doAPI,
+				WithPredicates:       s.controllerOptions.WithPredicates,
+				WithFinalizer:        s.controllerOptions.WithFinalizer,
+				WithStatusConditions: s.controllerOptions.WithStatusConditions,
+			},
+		)
+	}
+	if s.controllerOptions.hasUnitTests(FakeUnitTests) {
+		builders = append(builders, &controllers.ControllerFakeTest{
+			ControllerLayout:     layout,
+			Force:                s.force,
+			ControllerName:       s.controllerName,
+			WithFinalizer:        s.controllerOptions.WithFinalizer,
+			WithStatusConditions: s.controllerOptions.WithStatusConditions,
+		})
+	}
+
+	return scaffold.Execute(builders...)
+}
+
+// scaffoldGroupModule creates the Go module of the resource group and wires it into the root go.mod
+// when the project is configured to use one Go module per API group
+func (s *apiScaffolder) scaffoldGroupModule(scaffold *machinery.Scaffold) error {
+	pluginCfg, err := LoadPluginConfig(s.config)
+	if err != nil {
+		return err
+	}
+	if !pluginCfg.MultiGroupModules || s.resource.Group == "" {
+		return nil
+	}
+
+	if err := scaffold.Execute(
+		&api.GroupGoMod{ControllerRuntimeVersion: ControllerRuntimeVersion},
+	); err != nil {
+		return err
+	}
+
+	return addGroupModuleToGoMod(s.fs, s.config.GetRepository(), s.resource.Group)
+}
+
+// scaffoldStorageVersion tracks the storage version of the kind when the resource is created with
+// --storage-version, and scaffolds the helper to migrate the objects stored with the previous versions
+func (s *apiScaffolder) scaffoldStorageVersion(scaffold *machinery.Scaffold) error {
+	versions, err := kindVersions(s.config, s.resource)
+	if err != nil {
+		return err
+	}
+
+	if !s.resource.API.StorageVersion {
+		hasStorageVersion := false
+		for _, version := range versions {
+			hasStorageVersion = hasStorageVersion || version.API.StorageVersion
+		}
+		if len(versions) > 0 && !hasStorageVersion {
+			log.Warnf("The kind %s has several versions but none of them is the storage version. "+
+				"Scaffold the conversion webhook of the version which should be stored in etcd with "+
+				"'create webhook --conversion', or add the marker %q to its types.",
+				s.resource.Kind, strings.TrimSpace(storageVersionMarker))
+		}
+		return nil
+	}
+
+	if err := updateStorageVersion(s.fs, s.config, s.resource); err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return nil
+	}
+
+	if err := scaffold.Execute(&hack.ConvertStorageVersion{}); err != nil {
+		return err
+	}
+
+	spokes := make([]string, 0, len(versions))
+	for _, version := range versions {
+		spokes = append(spokes, version.Version)
+	}
+	log.Printf(`The version %[1]s is now the storage version of the kind %[2]s.
+The objects are converted between the versions by a conversion webhook, which can be scaffolded with:
+  create webhook --group %[3]s --version %[1]s --kind %[2]s --conversion --spoke %[4]s
+Once the manager is deployed, migrate the objects stored with the previous versions with:
+  bash hack/convert-storage-version.sh %[5]s.%[6]s`,
+		s.resource.Version, s.resource.Kind, s.resource.Group, strings.Join(spokes, ","),
+		s.resource.Plural, s.resource.QualifiedGroup())
 	return nil
 }
+
+// scaffoldChainsawTest creates the Chainsaw e2e test of the resource when the project
+// is configured to use Chainsaw for the e2e tests
+func (s *apiScaffolder) scaffoldChainsawTest(scaffold *machinery.Scaffold) error {
+	pluginCfg, err := LoadPluginConfig(s.config)
+	if err != nil {
+		return err
+	}
+	if !pluginCfg.UsesChainsaw() {
+		return nil
+	}
+
+	return scaffold.Execute(&chainsaw.ResourceTest{Force: s.force})
+}
+
+// trackNamedController tracks in the PROJECT file the controller scaffolded in addition to the controller of the kind
+func (s *apiScaffolder) trackNamedController() error {
+	pluginCfg, err := LoadPluginConfig(s.config)
+	if err != nil {
+		return err
+	}
+	if pluginCfg.HasController(s.resource.GVK, s.controllerName) {
+		return nil
+	}
+
+	controller := NamedController{
+		Name:   s.controllerName,
+		GVK:    s.resource.GVK,
+		Plural: s.resource.Plural,
+	}
+	if s.resource.IsExternal() {
+		controller.ExternalAPIPath = s.resource.Path
+		controller.ExternalAPIModule = s.resource.Module
+	}
+	pluginCfg.Controllers = append(pluginCfg.Controllers, controller)
+	return SavePluginConfig(s.config, pluginCfg)
+}
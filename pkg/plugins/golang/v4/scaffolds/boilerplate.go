@@ -0,0 +1,296 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/hack"
+)
+
+// LoadBoilerplate returns the boilerplate used as the header of the Go files scaffolded in the project.
+// An empty boilerplate is returned when the project has no license header or when the boilerplate
+// file can not be found, in which case a warning is logged.
+func LoadBoilerplate(fs machinery.Filesystem, cfg config.Config) (string, error) {
+	pluginCfg, err := LoadPluginConfig(cfg)
+	if err != nil {
+		return "", fmt.Errorf("error loading the plugin configuration: %w", err)
+	}
+
+	boilerplatePath := pluginCfg.GetBoilerplatePath()
+	if boilerplatePath == "" {
+		return "", nil
+	}
+
+	boilerplate, err := afero.ReadFile(fs.FS, boilerplatePath)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			log.Warnf("Unable to find %s: %s.\n"+
+				"This file is used to generate the license header in the project.\n"+
+				"Note that controller-gen will also use this. Therefore, ensure that you "+
+				"add the license file or configure your project accordingly.",
+				boilerplatePath, err)
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to load boilerplate: %w", err)
+	}
+	return string(boilerplate), nil
+}
+
+var _ plugins.Scaffolder = &licenseScaffolder{}
+
+type licenseScaffolder struct {
+	config          config.Config
+	license         string
+	owner           string
+	boilerplatePath string
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+}
+
+// NewLicenseScaffolder returns a new Scaffolder which changes the license of an existing project.
+// It rewrites the boilerplate file, the license header of all the Go files of the project and the
+// boilerplate used by controller-gen in the Makefile. Empty values keep the current ones.
+func NewLicenseScaffolder(config config.Config, license, owner, boilerplatePath string) plugins.Scaffolder {
+	return &licenseScaffolder{
+		config:          config,
+		license:         license,
+		owner:           owner,
+		boilerplatePath: boilerplatePath,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *licenseScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *licenseScaffolder) Scaffold() error {
+	pluginCfg, err := LoadPluginConfig(s.config)
+	if err != nil {
+		return fmt.Errorf("error loading the plugin configuration: %w", err)
+	}
+
+	// Load the current boilerplate to replace it in the headers of the Go files
+	oldLicense, oldPath := pluginCfg.GetLicense(), pluginCfg.GetBoilerplatePath()
+	var oldHeader string
+	if oldPath != "" {
+		content, err := afero.ReadFile(s.fs.FS, oldPath)
+		if err != nil && !errors.Is(err, afero.ErrFileNotFound) {
+			return fmt.Errorf("unable to load boilerplate: %w", err)
+		}
+		oldHeader = strings.TrimSpace(string(content))
+	}
+
+	license := s.license
+	if license == "" {
+		license = oldLicense
+	}
+	boilerplatePath := s.boilerplatePath
+	if boilerplatePath == "" {
+		boilerplatePath = pluginCfg.BoilerplatePath
+	}
+	if boilerplatePath == "" {
+		boilerplatePath = DefaultBoilerplatePath
+	}
+
+	header, err := s.scaffoldBoilerplate(license, boilerplatePath, oldHeader)
+	if err != nil {
+		return err
+	}
+
+	// The boilerplate file which was scaffolded for the previous license is not used anymore
+	if oldPath != "" && oldLicense != CustomLicense && (license == NoLicense || oldPath != boilerplatePath) {
+		if err := s.fs.FS.Remove(oldPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("unable to remove the previous boilerplate %s: %w", oldPath, err)
+		}
+	}
+
+	if err := s.rewriteHeaders(oldHeader, header); err != nil {
+		return err
+	}
+
+	pluginCfg.License, pluginCfg.BoilerplatePath = "", ""
+	if license != ApacheLicense {
+		pluginCfg.License = license
+	}
+	if license != NoLicense && boilerplatePath != DefaultBoilerplatePath {
+		pluginCfg.BoilerplatePath = boilerplatePath
+	}
+	if err := SavePluginConfig(s.config, pluginCfg); err != nil {
+		return fmt.Errorf("error saving the plugin configuration: %w", err)
+	}
+
+	if license == NoLicense {
+		boilerplatePath = ""
+	}
+	return s.updateMakefile(boilerplatePath)
+}
+
+// copyrightRegex matches the copyright line of the scaffolded boilerplates to preserve its year and owner
+var copyrightRegex = regexp.MustCompile(`(?m)^Copyright (\d+)(?: (.+))?\.$`)
+
+// scaffoldBoilerplate writes the boilerplate file of the license and returns the header of the Go files.
+func (s *licenseScaffolder) scaffoldBoilerplate(license, boilerplatePath, oldHeader string) (string, error) {
+	switch license {
+	case NoLicense:
+		return "", nil
+	case CustomLicense:
+		content, err := afero.ReadFile(s.fs.FS, boilerplatePath)
+		if err != nil {
+			return "", fmt.Errorf("unable to load the boilerplate %s of the custom license: %w", boilerplatePath, err)
+		}
+		return strings.TrimSpace(string(content)), nil
+	}
+
+	bpFile := &hack.Boilerplate{
+		License: license,
+		Owner:   s.owner,
+	}
+	// Keep the year and the owner of the current copyright
+	if matches := copyrightRegex.FindStringSubmatch(oldHeader); matches != nil {
+		bpFile.Year = matches[1]
+		if bpFile.Owner == "" {
+			bpFile.Owner = matches[2]
+		}
+	}
+	bpFile.Path = boilerplatePath
+	bpFile.IfExistsAction = machinery.OverwriteFile
+
+	if err := machinery.NewScaffold(s.fs, machinery.WithConfig(s.config)).Execute(bpFile); err != nil {
+		return "", fmt.Errorf("error scaffolding the boilerplate: %w", err)
+	}
+
+	content, err := afero.ReadFile(s.fs.FS, boilerplatePath)
+	if err != nil {
+		return "", fmt.Errorf("unable to load boilerplate: %w", err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// rewriteHeaders replaces the license header of all the Go files of the project.
+func (s *licenseScaffolder) rewriteHeaders(oldHeader, header string) error {
+	return afero.Walk(s.fs.FS, ".", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// Skip the hidden directories, the dependencies and the binaries
+			name := info.Name()
+			if path != "." && (strings.HasPrefix(name, ".") || name == "vendor" || name == "bin") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+
+		content, err := afero.ReadFile(s.fs.FS, path)
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", path, err)
+		}
+		replaced := replaceHeader(string(content), oldHeader, header)
+		if replaced == string(content) {
+			return nil
+		}
+		if err := afero.WriteFile(s.fs.FS, path, []byte(replaced), info.Mode()); err != nil {
+			return fmt.Errorf("unable to write %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// replaceHeader replaces the license header of the content of a Go file. The header is either the previous
+// boilerplate or a leading block comment with a copyright. The build constraints preceding the header, such
+// as the ones of the e2e tests, are kept at the top of the file.
+func replaceHeader(content, oldHeader, header string) string {
+	constraints, body := splitBuildConstraints(content)
+	if oldHeader != "" && strings.HasPrefix(body, oldHeader) {
+		body = strings.TrimPrefix(body, oldHeader)
+	} else if strings.HasPrefix(body, "/*") {
+		if end := strings.Index(body, "*/"); end >= 0 && strings.Contains(body[:end], "Copyright") {
+			body = body[end+len("*/"):]
+		}
+	}
+	body = strings.TrimLeft(body, "\n")
+
+	if header != "" {
+		body = header + "\n\n" + body
+	}
+	if constraints != "" {
+		body = constraints + "\n\n" + body
+	}
+	return body
+}
+
+// splitBuildConstraints splits the leading build constraints and blank lines of the content of a Go file
+// from the rest of the file.
+func splitBuildConstraints(content string) (string, string) {
+	lines := strings.SplitAfter(content, "\n")
+	i := 0
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line != "" && !strings.HasPrefix(line, "//go:build") && !strings.HasPrefix(line, "// +build") {
+			break
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines[:i], "")), strings.Join(lines[i:], "")
+}
+
+// controllerGenObjectRegex matches the controller-gen calls generating the DeepCopy methods in the Makefile
+var controllerGenObjectRegex = regexp.MustCompile(`\$\(CONTROLLER_GEN\) object(:headerFile="[^"]*")?`)
+
+// updateMakefile sets the boilerplate used by controller-gen in the Makefile.
+func (s *licenseScaffolder) updateMakefile(boilerplatePath string) error {
+	const makefile = "Makefile"
+	content, err := afero.ReadFile(s.fs.FS, makefile)
+	if errors.Is(err, afero.ErrFileNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to read %s: %w", makefile, err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		headerFile := ""
+		if boilerplatePath != "" {
+			// The API modules of the multigroup-modules layout are generated from their own directory
+			if strings.Contains(line, "cd $$mod") {
+				headerFile = fmt.Sprintf(":headerFile=%q", "$(CURDIR)/"+boilerplatePath)
+			} else {
+				headerFile = fmt.Sprintf(":headerFile=%q", boilerplatePath)
+			}
+		}
+		lines[i] = controllerGenObjectRegex.ReplaceAllLiteralString(line, "$(CONTROLLER_GEN) object"+headerFile)
+	}
+
+	return afero.WriteFile(s.fs.FS, makefile, []byte(strings.Join(lines, "\n")), 0o644)
+}
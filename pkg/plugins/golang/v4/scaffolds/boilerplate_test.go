@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("replaceHeader", func() {
+	const (
+		oldHeader = "/*\nCopyright 2025 The Kubernetes Authors.\n\nLicensed under the Apache License.\n*/"
+		header    = "/*\nCopyright 2025 Example Corp.\n\nLicensed under the MIT License.\n*/"
+		body      = "package v1\n\nimport \"fmt\"\n"
+		tags      = "//go:build e2e\n// +build e2e"
+	)
+
+	DescribeTable("should replace the license header",
+		func(content, oldHeader, header, expected string) {
+			Expect(replaceHeader(content, oldHeader, header)).To(Equal(expected))
+		},
+		Entry("of a plain file",
+			oldHeader+"\n\n"+body, oldHeader, header,
+			header+"\n\n"+body),
+		Entry("of a file with build constraints, keeping them at the top",
+			tags+"\n\n"+oldHeader+"\n\n"+body, oldHeader, header,
+			tags+"\n\n"+header+"\n\n"+body),
+		Entry("of a file whose header differs from the previous boilerplate",
+			"/*\nCopyright 2024 Someone.\n*/\n\n"+body, oldHeader, header,
+			header+"\n\n"+body),
+		Entry("of a file without a previous header",
+			body, "", header,
+			header+"\n\n"+body),
+		Entry("of a file with build constraints and without a previous header",
+			tags+"\n\n"+body, "", header,
+			tags+"\n\n"+header+"\n\n"+body),
+		Entry("by removing it when the new header is empty",
+			oldHeader+"\n\n"+body, oldHeader, "",
+			body),
+		Entry("by removing it from a file with build constraints when the new header is empty",
+			tags+"\n\n"+oldHeader+"\n\n"+body, oldHeader, "",
+			tags+"\n\n"+body),
+	)
+
+	It("should not remove a leading comment which is not a license header", func() {
+		content := "/*\nPackage v1 contains the API.\n*/\n" + body
+
+		Expect(replaceHeader(content, oldHeader, header)).To(Equal(header + "\n\n" + content))
+	})
+})
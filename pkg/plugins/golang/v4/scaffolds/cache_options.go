@@ -0,0 +1,307 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/spf13/afero"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/imports"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	kustomizecommonv2scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/cmd"
+)
+
+// cacheFragment is a piece of code which is scaffolded next to the anchor of a file to enable the cache
+// options. The fragments must match the code scaffolded by init --with-cache-options.
+type cacheFragment struct {
+	anchor string
+	code   string
+	// before scaffolds the code before the anchor instead of after it
+	before bool
+}
+
+var cacheOptionsFragments = []cacheFragment{
+	{
+		anchor: "\tProbeBindAddress string\n",
+		code: `
+	// CacheNamespaces are the namespaces cached by the manager, as a comma-separated list.
+	CacheNamespaces string
+	// CacheLabelSelector is the label selector of the Secrets and ConfigMaps cached by the manager.
+	CacheLabelSelector string
+`,
+	},
+	{
+		anchor: "\t\t\"The address the probe endpoint binds to.\")\n",
+		code: `	fs.StringVar(&o.CacheNamespaces, "cache-namespaces", o.CacheNamespaces,
+		"The comma-separated list of the namespaces whose objects are cached. Leave it empty to cache all of them.")
+	fs.StringVar(&o.CacheLabelSelector, "cache-label-selector", o.CacheLabelSelector,
+		"The label selector of the Secrets and ConfigMaps which are cached, "+
+			"e.g. app.kubernetes.io/managed-by=my-operator. Leave it empty to cache all of them.")
+`,
+	},
+}
+
+var cacheConfigFragments = []cacheFragment{
+	{
+		anchor: "\tLeaderElection LeaderElectionConfig `json:\"leaderElection,omitempty\"`\n",
+		code: "\t// Cache configures the objects cached by the manager.\n" +
+			"\tCache CacheConfig `json:\"cache,omitempty\"`\n",
+	},
+	{
+		anchor: "\n// LoadConfigFile loads",
+		code: `
+// CacheConfig configures the objects cached by the manager.
+type CacheConfig struct {
+	// Namespaces are the namespaces cached by the manager, as a comma-separated list.
+	Namespaces *string ` + "`json:\"namespaces,omitempty\"`" + `
+	// LabelSelector is the label selector of the Secrets and ConfigMaps cached by the manager.
+	LabelSelector *string ` + "`json:\"labelSelector,omitempty\"`" + `
+}
+`,
+		before: true,
+	},
+	{
+		anchor: "\tsetBool(\"enable-http2\", c.EnableHTTP2)\n",
+		code: "\tsetString(\"cache-namespaces\", c.Cache.Namespaces)\n" +
+			"\tsetString(\"cache-label-selector\", c.Cache.LabelSelector)\n",
+		before: true,
+	},
+}
+
+// cacheImport is an import of cmd/main.go which is scaffolded after the anchor when it is missing.
+// The imports which are no longer used once the cache options are disabled are removed.
+type cacheImport struct {
+	anchor string
+	name   string
+	path   string
+}
+
+// spec returns the import as it is scaffolded in the import declaration
+func (i cacheImport) spec() string {
+	if i.name == "" {
+		return fmt.Sprintf("\t%q\n", i.path)
+	}
+	return fmt.Sprintf("\t%s %q\n", i.name, i.path)
+}
+
+var cacheMainImports = []cacheImport{
+	{anchor: "\t\"path/filepath\"\n", path: "strings"},
+	{anchor: "\tclientgoscheme \"k8s.io/client-go/kubernetes/scheme\"\n", name: "corev1", path: "k8s.io/api/core/v1"},
+	{anchor: "\tclientgoscheme \"k8s.io/client-go/kubernetes/scheme\"\n", path: "k8s.io/apimachinery/pkg/labels"},
+	{anchor: "\tctrl \"sigs.k8s.io/controller-runtime\"\n", path: "sigs.k8s.io/controller-runtime/pkg/cache"},
+	{anchor: "\tctrl \"sigs.k8s.io/controller-runtime\"\n", path: "sigs.k8s.io/controller-runtime/pkg/client"},
+}
+
+// importsOptions only format the file and sort its imports, the imports are added and removed
+// by the scaffolder since goimports can move the comments of the import declaration
+var importsOptions = imports.Options{
+	Comments:   true,
+	TabIndent:  true,
+	TabWidth:   8,
+	FormatOnly: true,
+}
+
+// cacheMainFragments returns the fragments of cmd/main.go. The cache.Options are already declared by
+// the namespaced projects to watch the namespaces of the WATCH_NAMESPACE env var.
+func cacheMainFragments(namespaced bool) []cacheFragment {
+	declaration := "\tvar cacheOptions cache.Options\n"
+	if namespaced {
+		declaration = ""
+	}
+
+	fragments := []cacheFragment{
+		{
+			anchor: "\tmgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{\n",
+			code: `	// By default the manager caches every object it reads, e.g. all the Secrets and ConfigMaps of the
+	// cluster once a controller reads one of them, which can lead to a high memory usage. The cached
+	// namespaces are restricted with --cache-namespaces, and the cached Secrets and ConfigMaps with
+	// --cache-label-selector. The transform funcs of the cache modify the objects before they are
+	// stored, the managed fields are stripped since the controllers seldom read them. More info:
+	// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@` + ControllerRuntimeVersion + `/pkg/cache#Options
+` + declaration + `	cacheOptions.DefaultTransform = cache.TransformStripManagedFields()
+	if opts.CacheNamespaces != "" {
+		cacheOptions.DefaultNamespaces = map[string]cache.Config{}
+		for _, namespace := range strings.Split(opts.CacheNamespaces, ",") {
+			cacheOptions.DefaultNamespaces[strings.TrimSpace(namespace)] = cache.Config{}
+		}
+	}
+	if opts.CacheLabelSelector != "" {
+		selector, err := labels.Parse(opts.CacheLabelSelector)
+		if err != nil {
+			setupLog.Error(err, "unable to parse the label selector of the cache")
+			os.Exit(1)
+		}
+		// Only the Secrets and ConfigMaps matching the selector are cached. Read the others
+		// with the client returned by mgr.GetAPIReader(), which is not backed by the cache.
+		cacheOptions.ByObject = map[client.Object]cache.ByObject{
+			&corev1.Secret{}:    {Label: selector},
+			&corev1.ConfigMap{}: {Label: selector},
+		}
+	}
+
+`,
+			before: true,
+		},
+	}
+	if !namespaced {
+		fragments = append(fragments, cacheFragment{
+			anchor: "\t\tScheme:                 scheme,\n",
+			code:   "\t\tCache:                  cacheOptions,\n",
+		})
+	}
+	return fragments
+}
+
+var _ plugins.Scaffolder = &cacheOptionsScaffolder{}
+
+type cacheOptionsScaffolder struct {
+	config  config.Config
+	enabled bool
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+}
+
+// NewCacheOptionsScaffolder returns a new Scaffolder which enables or disables the cache options of the
+// manager of an existing project, by adding or removing the --cache-namespaces and --cache-label-selector
+// flags in internal/options/options.go and the cache.Options built from them in the main.go of the managers.
+func NewCacheOptionsScaffolder(config config.Config, enabled bool) plugins.Scaffolder {
+	return &cacheOptionsScaffolder{
+		config:  config,
+		enabled: enabled,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *cacheOptionsScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *cacheOptionsScaffolder) Scaffold() error {
+	pluginCfg, err := LoadPluginConfig(s.config)
+	if err != nil {
+		return fmt.Errorf("error loading the plugin configuration: %w", err)
+	}
+	kustomizeCfg, err := kustomizecommonv2scaffolds.LoadPluginConfig(s.config)
+	if err != nil {
+		return fmt.Errorf("error loading the kustomize plugin configuration: %w", err)
+	}
+
+	if err := s.toggle("internal/options/options.go", cacheOptionsFragments); err != nil {
+		return err
+	}
+	if kustomizeCfg.ComponentConfig {
+		if err := s.toggle("internal/options/config.go", cacheConfigFragments); err != nil {
+			return err
+		}
+	}
+
+	mainFragments := cacheMainFragments(kustomizeCfg.Namespaced)
+	for _, name := range append([]string{""}, kustomizeCfg.Managers...) {
+		path := cmd.MainPath(name)
+		if err := s.toggle(path, mainFragments); err != nil {
+			return err
+		}
+		if err := s.updateImports(path); err != nil {
+			return err
+		}
+	}
+
+	pluginCfg.CacheOptions = s.enabled
+	if err := SavePluginConfig(s.config, pluginCfg); err != nil {
+		return fmt.Errorf("error saving the plugin configuration: %w", err)
+	}
+	return nil
+}
+
+// toggle adds or removes the fragments of the file
+func (s *cacheOptionsScaffolder) toggle(path string, fragments []cacheFragment) error {
+	content, err := afero.ReadFile(s.fs.FS, path)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	str := string(content)
+
+	for _, fragment := range fragments {
+		withCode := fragment.anchor + fragment.code
+		if fragment.before {
+			withCode = fragment.code + fragment.anchor
+		}
+
+		switch {
+		case s.enabled && !strings.Contains(str, withCode):
+			if !strings.Contains(str, fragment.anchor) {
+				return fmt.Errorf("unable to find %q in %s, the cache options can only be toggled in the code "+
+					"scaffolded by init", strings.TrimSpace(fragment.anchor), path)
+			}
+			str = strings.Replace(str, fragment.anchor, withCode, 1)
+		case !s.enabled:
+			str = strings.Replace(str, withCode, fragment.anchor, 1)
+		}
+	}
+
+	if err := afero.WriteFile(s.fs.FS, path, []byte(str), 0o644); err != nil {
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// updateImports adds the missing imports used by the cache options, or removes the unused ones
+func (s *cacheOptionsScaffolder) updateImports(path string) error {
+	content, err := afero.ReadFile(s.fs.FS, path)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	str := string(content)
+
+	if s.enabled {
+		for _, imp := range cacheMainImports {
+			if !strings.Contains(str, imp.spec()) {
+				str = strings.Replace(str, imp.anchor, imp.anchor+imp.spec(), 1)
+			}
+		}
+	} else {
+		file, err := parser.ParseFile(token.NewFileSet(), path, str, 0)
+		if err != nil {
+			return fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+		for _, imp := range cacheMainImports {
+			if !astutil.UsesImport(file, imp.path) {
+				str = strings.Replace(str, imp.spec(), "", 1)
+			}
+		}
+	}
+
+	formatted, err := imports.Process(path, []byte(str), &importsOptions)
+	if err != nil {
+		return fmt.Errorf("unable to format %s: %w", path, err)
+	}
+
+	if err := afero.WriteFile(s.fs.FS, path, formatted, 0o644); err != nil {
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+	return nil
+}
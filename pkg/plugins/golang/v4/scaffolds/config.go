@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/hack"
+)
+
+// PluginKey is the key used to track the go/v4 options in the PROJECT file.
+// It must match plugin.KeyFor(v4.Plugin{}), which cannot be used here to avoid an import cycle.
+const PluginKey = "base.go.kubebuilder.io/v4"
+
+const (
+	// GinkgoE2EFramework scaffolds the e2e tests as a Go test suite written with Ginkgo
+	GinkgoE2EFramework = "ginkgo"
+	// ChainsawE2EFramework scaffolds the e2e tests as declarative Chainsaw test suites
+	ChainsawE2EFramework = "chainsaw"
+)
+
+const (
+	// ApacheLicense scaffolds the boilerplate with the copyright and the Apache 2.0 license header
+	ApacheLicense = "apache2"
+	// CopyrightLicense scaffolds the boilerplate with the copyright only
+	CopyrightLicense = "copyright"
+	// NoLicense scaffolds the Go files without boilerplate
+	NoLicense = "none"
+	// CustomLicense uses the boilerplate file provided by the user
+	CustomLicense = "custom"
+)
+
+// Licenses are the supported values of the license of the boilerplate
+var Licenses = []string{ApacheLicense, CopyrightLicense, NoLicense, CustomLicense}
+
+// DefaultBoilerplatePath is the default path to the boilerplate file
+var DefaultBoilerplatePath = hack.DefaultBoilerplatePath
+
+// PluginConfig defines the go/v4 options which are tracked in the PROJECT file
+type PluginConfig struct {
+	// MultiGroupModules indicates that each API group is scaffolded as its own Go module under api/<group>
+	MultiGroupModules bool `json:"multigroupModules,omitempty"`
+	// ControllerLayout is the placement of the controllers. It is only tracked when it is not internal/controller
+	ControllerLayout string `json:"controllerLayout,omitempty"`
+	// WorkspacePath is the path of the project relative to the root of the Go workspace (go.work) which holds it.
+	// It is only tracked when the project is scaffolded with --use-workspace, "." if it is at the root
+	WorkspacePath string `json:"workspacePath,omitempty"`
+	// Tracing indicates that the manager and the controllers are instrumented with OpenTelemetry
+	Tracing bool `json:"tracing,omitempty"`
+	// Pprof indicates that the manager exposes the pprof endpoint when --pprof-bind-address is set
+	Pprof bool `json:"pprof,omitempty"`
+	// HAOptions indicates that the graceful shutdown timeout and the leader election lease of the manager
+	// are tuned with its flags
+	HAOptions bool `json:"haOptions,omitempty"`
+	// CacheOptions indicates that the objects cached by the manager are restricted with its flags
+	CacheOptions bool `json:"cacheOptions,omitempty"`
+	// ToolMirror is the base URL of the mirror from which the tools of the Makefile are downloaded,
+	// e.g. in air-gapped environments
+	ToolMirror string `json:"toolMirror,omitempty"`
+	// E2EFramework is the framework used by the e2e tests. It is only tracked when it is not Ginkgo
+	E2EFramework string `json:"e2eFramework,omitempty"`
+	// License is the license of the boilerplate. It is only tracked when it is not the Apache 2.0 license
+	License string `json:"license,omitempty"`
+	// BoilerplatePath is the path to the boilerplate file. It is only tracked when it is not the default one
+	BoilerplatePath string `json:"boilerplatePath,omitempty"`
+	// Controllers are the controllers scaffolded with 'create controller --name' in addition to the controller
+	// of their kind, which is tracked by the resource
+	Controllers []NamedController `json:"controllers,omitempty"`
+}
+
+// NamedController is a controller scaffolded for a kind in addition to the controller named after the kind
+type NamedController struct {
+	// Name is the name of the controller, which prefixes its reconciler
+	Name string `json:"name"`
+	// GVK is the kind reconciled by the controller
+	resource.GVK `json:",inline"`
+	// Plural is the plural of the kind, used by the RBAC markers
+	Plural string `json:"plural,omitempty"`
+	// ExternalAPIPath is the import path of the API when the kind is defined outside the project
+	ExternalAPIPath string `json:"externalApiPath,omitempty"`
+	// ExternalAPIModule is the Go module which provides the external API, optionally with its version
+	ExternalAPIModule string `json:"externalApiModule,omitempty"`
+}
+
+// HasController returns true if a controller with the given name was scaffolded for the kind
+func (c PluginConfig) HasController(gvk resource.GVK, name string) bool {
+	for _, controller := range c.Controllers {
+		if controller.Name == name && controller.IsEqualTo(gvk) {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesChainsaw returns true if the e2e tests are scaffolded as Chainsaw test suites
+func (c PluginConfig) UsesChainsaw() bool {
+	return c.E2EFramework == ChainsawE2EFramework
+}
+
+// UsesWorkspace returns true if the project is a module of a Go workspace, built from the root of the workspace
+func (c PluginConfig) UsesWorkspace() bool {
+	return c.WorkspacePath != ""
+}
+
+// WorkspaceRoot returns the path of the root of the Go workspace relative to the project
+func (c PluginConfig) WorkspaceRoot() string {
+	if !c.UsesWorkspace() {
+		return "."
+	}
+	return golang.Workspace{ProjectPath: c.WorkspacePath}.RootPath()
+}
+
+// GetControllerLayout returns the placement of the controllers
+func (c PluginConfig) GetControllerLayout() golang.ControllerLayout {
+	return golang.ControllerLayout(c.ControllerLayout).OrDefault()
+}
+
+// GetLicense returns the license of the boilerplate
+func (c PluginConfig) GetLicense() string {
+	if c.License == "" {
+		return ApacheLicense
+	}
+	return c.License
+}
+
+// GetBoilerplatePath returns the path to the boilerplate file, or an empty string when the project has no boilerplate
+func (c PluginConfig) GetBoilerplatePath() string {
+	if c.License == NoLicense {
+		return ""
+	}
+	if c.BoilerplatePath == "" {
+		return DefaultBoilerplatePath
+	}
+	return c.BoilerplatePath
+}
+
+// LoadPluginConfig returns the go/v4 options tracked in the PROJECT file.
+// An empty PluginConfig is returned when nothing was tracked.
+func LoadPluginConfig(cfg config.Config) (PluginConfig, error) {
+	pluginCfg := PluginConfig{}
+	err := cfg.DecodePluginConfig(PluginKey, &pluginCfg)
+	if err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) &&
+		!errors.As(err, &config.UnsupportedFieldError{}) {
+		return pluginCfg, err
+	}
+	return pluginCfg, nil
+}
+
+// SavePluginConfig tracks the go/v4 options in the PROJECT file
+func SavePluginConfig(cfg config.Config, pluginCfg PluginConfig) error {
+	if err := cfg.EncodePluginConfig(PluginKey, pluginCfg); err != nil &&
+		!errors.As(err, &config.UnsupportedFieldError{}) {
+		return err
+	}
+	return nil
+}
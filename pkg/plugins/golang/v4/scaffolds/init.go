@@ -17,23 +17,27 @@ limitations under the License.
 package scaffolds
 
 import (
-	"errors"
 	"fmt"
+	"path"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
-	"github.com/spf13/afero"
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
 	kustomizecommonv2 "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2"
+	kustomizecommonv2scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/cmd"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/github"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/hack"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/options"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/test/chainsaw"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/test/e2e"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/test/utils"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/tracing"
 )
 
 const (
@@ -43,6 +47,8 @@ const (
 	ControllerRuntimeVersion = "v0.20.2"
 	// ControllerToolsVersion is the kubernetes-sigs/controller-tools version to be used in the project
 	ControllerToolsVersion = "v0.17.2"
+	// ChainsawVersion is the kyverno/chainsaw version to be used in the project
+	ChainsawVersion = "v0.2.12"
 
 	imageName = "controller:latest"
 )
@@ -65,11 +71,10 @@ type initScaffolder struct {
 // NewInitScaffolder returns a new Scaffolder for project initialization operations
 func NewInitScaffolder(config config.Config, license, owner, commandName string) plugins.Scaffolder {
 	return &initScaffolder{
-		config:          config,
-		boilerplatePath: hack.DefaultBoilerplatePath,
-		license:         license,
-		owner:           owner,
-		commandName:     commandName,
+		config:      config,
+		license:     license,
+		owner:       owner,
+		commandName: commandName,
 	}
 }
 
@@ -92,10 +97,34 @@ func getControllerRuntimeReleaseBranch() string {
 	return releaseBranch
 }
 
+// Tools returns the Go tools installed by the Makefile of the project
+func Tools(pluginCfg PluginConfig) []golang.Tool {
+	tools := []golang.Tool{
+		{Name: "kustomize", Module: "sigs.k8s.io/kustomize/kustomize/v5", Version: kustomizecommonv2.KustomizeVersion},
+		{Name: "controller-gen", Module: "sigs.k8s.io/controller-tools", Version: ControllerToolsVersion},
+		{
+			Name:    "setup-envtest",
+			Module:  "sigs.k8s.io/controller-runtime/tools/setup-envtest",
+			Version: getControllerRuntimeReleaseBranch(),
+		},
+		{Name: "golangci-lint", Module: "github.com/golangci/golangci-lint", Version: GolangciLintVersion},
+	}
+	if pluginCfg.UsesChainsaw() {
+		tools = append(tools, golang.Tool{Name: "chainsaw", Module: "github.com/kyverno/chainsaw", Version: ChainsawVersion})
+	}
+	return tools
+}
+
 // Scaffold implements cmdutil.Scaffolder
 func (s *initScaffolder) Scaffold() error {
 	log.Println("Writing scaffold for you to edit...")
 
+	pluginCfg, err := LoadPluginConfig(s.config)
+	if err != nil {
+		return fmt.Errorf("error loading the plugin configuration: %w", err)
+	}
+	s.boilerplatePath = pluginCfg.GetBoilerplatePath()
+
 	// Initialize the machinery.Scaffold that will write the boilerplate file to disk
 	// The boilerplate file needs to be scaffolded as a separate step as it is going to
 	// be used by the rest of the files, even those scaffolded in this command call.
@@ -103,7 +132,8 @@ func (s *initScaffolder) Scaffold() error {
 		machinery.WithConfig(s.config),
 	)
 
-	if s.license != "none" {
+	// The boilerplate of the custom license is provided by the user
+	if s.license != NoLicense && s.license != CustomLicense {
 		bpFile := &hack.Boilerplate{
 			License: s.license,
 			Owner:   s.owner,
@@ -112,32 +142,19 @@ func (s *initScaffolder) Scaffold() error {
 		if err := scaffold.Execute(bpFile); err != nil {
 			return err
 		}
+	}
 
-		boilerplate, err := afero.ReadFile(s.fs.FS, s.boilerplatePath)
-		if err != nil {
-			if errors.Is(err, afero.ErrFileNotFound) {
-				log.Warnf("Unable to find %s: %s.\n"+"This file is used to generate the license header in the project.\n"+
-					"Note that controller-gen will also use this. Therefore, ensure that you "+
-					"add the license file or configure your project accordingly.",
-					s.boilerplatePath, err)
-				boilerplate = []byte("")
-			} else {
-				return fmt.Errorf("unable to load boilerplate: %w", err)
-			}
-		}
-		// Initialize the machinery.Scaffold that will write the files to disk
-		scaffold = machinery.NewScaffold(s.fs,
-			machinery.WithConfig(s.config),
-			machinery.WithBoilerplate(string(boilerplate)),
-		)
-	} else {
-		s.boilerplatePath = ""
-		// Initialize the machinery.Scaffold without boilerplate
-		scaffold = machinery.NewScaffold(s.fs,
-			machinery.WithConfig(s.config),
-		)
+	boilerplate, err := LoadBoilerplate(s.fs, s.config)
+	if err != nil {
+		return err
 	}
 
+	// Initialize the machinery.Scaffold that will write the files to disk
+	scaffold = machinery.NewScaffold(s.fs,
+		machinery.WithConfig(s.config),
+		machinery.WithBoilerplate(boilerplate),
+	)
+
 	// If the KustomizeV2 was used to do the scaffold then
 	// we need to ensure that we use its supported Kustomize Version
 	// in order to support it
@@ -152,10 +169,92 @@ func (s *initScaffolder) Scaffold() error {
 		}
 	}
 
-	return scaffold.Execute(
-		&cmd.Main{
+	kustomizeCfg, err := kustomizecommonv2scaffolds.LoadPluginConfig(s.config)
+	if err != nil {
+		return fmt.Errorf("error loading the kustomize plugin configuration: %w", err)
+	}
+
+	if pluginCfg.Tracing {
+		if err := scaffold.Execute(&tracing.Tracing{}); err != nil {
+			return fmt.Errorf("error scaffolding tracing: %w", err)
+		}
+	}
+
+	if kustomizeCfg.ComponentConfig {
+		if err := scaffold.Execute(
+			&options.Config{
+				WithTracing:      pluginCfg.Tracing,
+				WithPprof:        pluginCfg.Pprof,
+				WithHAOptions:    pluginCfg.HAOptions,
+				WithCacheOptions: pluginCfg.CacheOptions,
+			},
+		); err != nil {
+			return fmt.Errorf("error scaffolding the configuration file of the manager: %w", err)
+		}
+	}
+
+	if pluginCfg.UsesChainsaw() {
+		if err := scaffold.Execute(
+			&chainsaw.Configuration{},
+			&chainsaw.ManagerTest{},
+		); err != nil {
+			return fmt.Errorf("error scaffolding chainsaw e2e tests: %w", err)
+		}
+	} else {
+		if err := scaffold.Execute(
+			&e2e.Test{WebhookOnly: kustomizeCfg.WebhookOnly},
+			&e2e.WebhookTestUpdater{WireWebhook: false},
+			&e2e.SuiteTest{},
+			&e2e.SamplesTest{},
+			&utils.Utils{},
+			&utils.Helpers{},
+			&utils.HelpersTest{},
+			&utils.SuiteTest{},
+		); err != nil {
+			return fmt.Errorf("error scaffolding e2e tests: %w", err)
+		}
+	}
+
+	newMain := func(managerName string) *cmd.Main {
+		return &cmd.Main{
 			ControllerRuntimeVersion: ControllerRuntimeVersion,
+			WithTracing:              pluginCfg.Tracing,
+			WithPprof:                pluginCfg.Pprof,
+			WithHAOptions:            pluginCfg.HAOptions,
+			WithComponentConfig:      kustomizeCfg.ComponentConfig,
+			WithCacheOptions:         pluginCfg.CacheOptions,
+			Namespaced:               kustomizeCfg.Namespaced,
+			ManagerName:              managerName,
+		}
+	}
+
+	// The image of a module of a Go workspace is built from the root of the workspace
+	var workspaceRoot string
+	if pluginCfg.UsesWorkspace() {
+		workspaceRoot = pluginCfg.WorkspaceRoot()
+	}
+
+	// The additional managers run the controllers assigned to them with 'create api --manager'
+	for _, name := range kustomizeCfg.Managers {
+		if err := scaffold.Execute(newMain(name)); err != nil {
+			return fmt.Errorf("error scaffolding the %s manager: %w", name, err)
+		}
+	}
+
+	return scaffold.Execute(
+		&options.Options{
+			WithTracing:         pluginCfg.Tracing,
+			WithPprof:           pluginCfg.Pprof,
+			WithHAOptions:       pluginCfg.HAOptions,
+			WithCacheOptions:    pluginCfg.CacheOptions,
+			WithComponentConfig: kustomizeCfg.ComponentConfig,
 		},
+		&options.OptionsTest{
+			WithComponentConfig: kustomizeCfg.ComponentConfig,
+			WithHAOptions:       pluginCfg.HAOptions,
+		},
+		&options.SuiteTest{},
+		newMain(""),
 		&templates.GoMod{
 			ControllerRuntimeVersion: ControllerRuntimeVersion,
 		},
@@ -168,21 +267,29 @@ func (s *initScaffolder) Scaffold() error {
 			GolangciLintVersion:      GolangciLintVersion,
 			ControllerRuntimeVersion: ControllerRuntimeVersion,
 			EnvtestVersion:           getControllerRuntimeReleaseBranch(),
+			MultiGroupModules:        pluginCfg.MultiGroupModules,
+			Chainsaw:                 pluginCfg.UsesChainsaw(),
+			ChainsawVersion:          ChainsawVersion,
+			WebhookOnly:              kustomizeCfg.WebhookOnly,
+			ToolMirror:               strings.TrimSuffix(pluginCfg.ToolMirror, "/"),
+			Managers:                 kustomizeCfg.Managers,
+			WorkspaceRoot:            workspaceRoot,
+		},
+		&templates.Dockerfile{
+			MultiGroupModules: pluginCfg.MultiGroupModules,
+			ControllersLayout: pluginCfg.GetControllerLayout() == golang.ControllersLayout,
+			Managers:          kustomizeCfg.Managers,
+			Workspace:         pluginCfg.UsesWorkspace(),
+			WorkDir:           path.Join("/workspace", pluginCfg.WorkspacePath),
 		},
-		&templates.Dockerfile{},
 		&templates.DockerIgnore{},
-		&templates.Readme{CommandName: s.commandName},
-		&templates.Golangci{},
-		&e2e.Test{},
-		&e2e.WebhookTestUpdater{WireWebhook: false},
-		&e2e.SuiteTest{},
+		&templates.Readme{CommandName: s.commandName, WebhookOnly: kustomizeCfg.WebhookOnly},
+		&templates.Golangci{ControllersLayout: pluginCfg.GetControllerLayout() == golang.ControllersLayout},
 		&github.E2eTestCi{},
 		&github.TestCi{},
+		&github.PublishImageCi{},
 		&github.LintCi{
 			GolangciLintVersion: GolangciLintVersion,
 		},
-		&utils.Utils{},
-		&templates.DevContainer{},
-		&templates.DevContainerPostInstallScript{},
 	)
 }
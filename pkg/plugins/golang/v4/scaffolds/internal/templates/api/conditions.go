@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &Conditions{}
+
+// Conditions scaffolds the file that defines the status conditions helpers of a CRD
+type Conditions struct {
+	machinery.TemplateMixin
+	machinery.MultiGroupMixin
+	machinery.BoilerplateMixin
+	machinery.ResourceMixin
+
+	Force bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *Conditions) SetTemplateDefaults() error {
+	if f.Path == "" {
+		if f.MultiGroup && f.Resource.Group != "" {
+			f.Path = filepath.Join("api", "%[group]", "%[version]", "%[kind]_conditions.go")
+		} else {
+			f.Path = filepath.Join("api", "%[version]", "%[kind]_conditions.go")
+		}
+	}
+
+	f.Path = f.Resource.Replacer().Replace(f.Path)
+	log.Println(f.Path)
+
+	f.TemplateBody = conditionsTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.Error
+	}
+
+	return nil
+}
+
+const conditionsTemplate = `{{ .Boilerplate }}
+
+package {{ .Resource.Version }}
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types and reasons used to report the status of the {{ .Resource.Kind }}.
+// TODO(user): Add the condition types and reasons which describe the state of your resource.
+const (
+	// {{ .Resource.Kind }}ConditionReady indicates whether the {{ .Resource.Kind }} was successfully reconciled.
+	{{ .Resource.Kind }}ConditionReady = "Ready"
+
+	// {{ .Resource.Kind }}ReasonReconciling is used while the reconciliation of the {{ .Resource.Kind }} is in progress.
+	{{ .Resource.Kind }}ReasonReconciling = "Reconciling"
+	// {{ .Resource.Kind }}ReasonReconciled is used when the {{ .Resource.Kind }} was successfully reconciled.
+	{{ .Resource.Kind }}ReasonReconciled = "Reconciled"
+	// {{ .Resource.Kind }}ReasonFailed is used when the reconciliation of the {{ .Resource.Kind }} failed.
+	{{ .Resource.Kind }}ReasonFailed = "Failed"
+)
+
+// SetReadyCondition sets the Ready condition of the {{ .Resource.Kind }} with the given status, reason and message.
+func (in *{{ .Resource.Kind }}) SetReadyCondition(status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&in.Status.Conditions, metav1.Condition{
+		Type:               {{ .Resource.Kind }}ConditionReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: in.GetGeneration(),
+	})
+}
+
+// GetReadyCondition returns the Ready condition of the {{ .Resource.Kind }}, or nil when it is not set.
+func (in *{{ .Resource.Kind }}) GetReadyCondition() *metav1.Condition {
+	return meta.FindStatusCondition(in.Status.Conditions, {{ .Resource.Kind }}ConditionReady)
+}
+
+// IsReady returns true when the Ready condition of the {{ .Resource.Kind }} is True.
+func (in *{{ .Resource.Kind }}) IsReady() bool {
+	return meta.IsStatusConditionTrue(in.Status.Conditions, {{ .Resource.Kind }}ConditionReady)
+}
+`
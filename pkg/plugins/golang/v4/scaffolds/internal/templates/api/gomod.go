@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &GroupGoMod{}
+
+// GroupGoMod scaffolds the go.mod file of the Go module which holds the APIs of a group
+type GroupGoMod struct {
+	machinery.TemplateMixin
+	machinery.RepositoryMixin
+	machinery.ResourceMixin
+
+	ControllerRuntimeVersion string
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *GroupGoMod) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("api", "%[group]", "go.mod")
+	}
+
+	f.Path = f.Resource.Replacer().Replace(f.Path)
+
+	f.TemplateBody = groupGoModTemplate
+
+	// The module is shared by all the versions of the group
+	f.IfExistsAction = machinery.SkipFile
+
+	return nil
+}
+
+const groupGoModTemplate = `module {{ .Repo }}/api/{{ .Resource.Group }}
+
+go 1.23.0
+
+godebug default=go1.23
+
+require (
+	sigs.k8s.io/controller-runtime {{ .ControllerRuntimeVersion }}
+)
+`
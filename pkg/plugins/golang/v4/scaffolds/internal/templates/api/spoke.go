@@ -81,6 +81,7 @@ func (src *{{ .Resource.Kind }}) ConvertTo(dstRaw conversion.Hub) error {
 		"source: %s/%s, target: %s/%s", src.Namespace, src.Name, dst.Namespace, dst.Name)
 
 	// TODO(user): Implement conversion logic from {{ .SpokeVersion }} to {{ .Resource.Version }}
+	dst.ObjectMeta = src.ObjectMeta
 	return nil
 }
 
@@ -91,6 +92,7 @@ func (dst *{{ .Resource.Kind }}) ConvertFrom(srcRaw conversion.Hub) error {
 		"source: %s/%s, target: %s/%s", src.Namespace, src.Name, dst.Namespace, dst.Name)
 
 	// TODO(user): Implement conversion logic from {{ .Resource.Version }} to {{ .SpokeVersion }}
+	dst.ObjectMeta = src.ObjectMeta
 	return nil
 }
 `
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &SpokeTest{}
+
+// SpokeTest scaffolds the file that tests the conversion between a spoke version and the hub
+type SpokeTest struct {
+	machinery.TemplateMixin
+	machinery.MultiGroupMixin
+	machinery.BoilerplateMixin
+	machinery.ResourceMixin
+
+	Force        bool
+	SpokeVersion string
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *SpokeTest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		if f.MultiGroup && f.Resource.Group != "" {
+			f.Path = filepath.Join("api", f.Resource.Group, f.SpokeVersion, "%[kind]_conversion_test.go")
+		} else {
+			f.Path = filepath.Join("api", f.SpokeVersion, "%[kind]_conversion_test.go")
+		}
+	}
+
+	f.Path = f.Resource.Replacer().Replace(f.Path)
+	log.Printf("Creating spoke conversion test file at: %s", f.Path)
+
+	f.TemplateBody = spokeTestTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+
+	return nil
+}
+
+//nolint:lll
+const spokeTestTemplate = `{{ .Boilerplate }}
+
+package {{ .SpokeVersion }}
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
+)
+
+// Ensure that the {{ .SpokeVersion }} version can be converted to and from the Hub version ({{ .Resource.Version }}).
+var _ conversion.Convertible = &{{ .Resource.Kind }}{}
+
+// Test{{ .Resource.Kind }}HubRoundTrip verifies that converting a Hub ({{ .Resource.Version }}) object to the
+// {{ .SpokeVersion }} version and back does not lose any data.
+func Test{{ .Resource.Kind }}HubRoundTrip(t *testing.T) {
+	hub := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-resource",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "test"},
+		},
+		// TODO(user): Set the spec fields which must be preserved by the conversion.
+	}
+
+	spoke := &{{ .Resource.Kind }}{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("failed to convert from the Hub version: %v", err)
+	}
+
+	restored := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
+	if err := spoke.ConvertTo(restored); err != nil {
+		t.Fatalf("failed to convert to the Hub version: %v", err)
+	}
+
+	if !equality.Semantic.DeepEqual(hub, restored) {
+		t.Errorf("the round trip conversion lost data:\nexpected: %+v\ngot: %+v", hub, restored)
+	}
+}
+
+// Test{{ .Resource.Kind }}SpokeRoundTrip verifies that converting a {{ .SpokeVersion }} object to the
+// Hub version ({{ .Resource.Version }}) and back does not lose any data.
+func Test{{ .Resource.Kind }}SpokeRoundTrip(t *testing.T) {
+	spoke := &{{ .Resource.Kind }}{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-resource",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "test"},
+		},
+		// TODO(user): Set the spec fields which must be preserved by the conversion.
+	}
+
+	hub := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
+	if err := spoke.ConvertTo(hub); err != nil {
+		t.Fatalf("failed to convert to the Hub version: %v", err)
+	}
+
+	restored := &{{ .Resource.Kind }}{}
+	if err := restored.ConvertFrom(hub); err != nil {
+		t.Fatalf("failed to convert from the Hub version: %v", err)
+	}
+
+	if !equality.Semantic.DeepEqual(spoke, restored) {
+		t.Errorf("the round trip conversion lost data:\nexpected: %+v\ngot: %+v", spoke, restored)
+	}
+}
+`
@@ -35,6 +35,9 @@ type Types struct {
 	machinery.BoilerplateMixin
 	machinery.ResourceMixin
 
+	// WithStatusConditions scaffolds the Conditions field in the status and the printer columns for the Ready condition
+	WithStatusConditions bool
+
 	Force bool
 }
 
@@ -86,10 +89,27 @@ type {{ .Resource.Kind }}Spec struct {
 type {{ .Resource.Kind }}Status struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
+	{{- if .WithStatusConditions }}
+
+	// Conditions represent the latest available observations of the {{ .Resource.Kind }}'s state.
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#typical-status-properties
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition ` + "`" + `json:"conditions,omitempty"` + "`" + `
+	{{- end }}
 }
 
 // +kubebuilder:object:root=true
+{{- if .Resource.API.StorageVersion }}
+// +kubebuilder:storageversion
+{{- end }}
 // +kubebuilder:subresource:status
+{{- if .WithStatusConditions }}
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+{{- end }}
 {{- if and (not .Resource.API.Namespaced) (not .Resource.IsRegularPlural) }}
 // +kubebuilder:resource:path={{ .Resource.Plural }},scope=Cluster
 {{- else if not .Resource.API.Namespaced }}
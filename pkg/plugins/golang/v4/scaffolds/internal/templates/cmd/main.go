@@ -18,13 +18,24 @@ package cmd
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
 )
 
 const defaultMainPath = "cmd/main.go"
 
+// MainPath returns the path to the main.go file of the manager with the given name,
+// or to cmd/main.go for the main manager, whose name is empty
+func MainPath(managerName string) string {
+	if managerName == "" {
+		return defaultMainPath
+	}
+	return filepath.Join("cmd", managerName, "main.go")
+}
+
 var _ machinery.Template = &Main{}
 
 // Main scaffolds a file that defines the controller manager entry point
@@ -35,12 +46,35 @@ type Main struct {
 	machinery.RepositoryMixin
 
 	ControllerRuntimeVersion string
+
+	// WithTracing scaffolds the flags and the setup to export OpenTelemetry traces with OTLP
+	WithTracing bool
+
+	// WithPprof scaffolds the setup of the pprof endpoint of the manager
+	WithPprof bool
+
+	// WithHAOptions scaffolds the graceful shutdown timeout and the leader election lease of the manager
+	WithHAOptions bool
+
+	// WithComponentConfig scaffolds the loading of the configuration file of the manager
+	WithComponentConfig bool
+
+	// WithCacheOptions scaffolds the setup that restricts the objects cached by the manager
+	WithCacheOptions bool
+
+	// Namespaced scaffolds the setup that restricts the cache of the manager to the namespaces
+	// defined in the WATCH_NAMESPACE env var
+	Namespaced bool
+
+	// ManagerName is the name of an additional manager of the project, scaffolded in cmd/<name>/main.go,
+	// which runs the controllers assigned to it. The main manager is scaffolded when it is empty
+	ManagerName string
 }
 
 // SetTemplateDefaults implements machinery.Template
 func (f *Main) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join(defaultMainPath)
+		f.Path = MainPath(f.ManagerName)
 	}
 
 	f.TemplateBody = fmt.Sprintf(mainTemplate,
@@ -60,9 +94,22 @@ type MainUpdater struct { //nolint:maligned
 	machinery.MultiGroupMixin
 	machinery.ResourceMixin
 
+	// ManagerName is the name of the additional manager whose cmd/<name>/main.go is updated,
+	// cmd/main.go is updated when it is empty
+	ManagerName string
+
 	// Flags to indicate which parts need to be included when updating the file
 	WireResource, WireController, WireWebhook bool
 
+	// ControllerName is the name of the wired controller, the kind of the resource by default
+	ControllerName string
+
+	// ControllerLayout is the placement of the wired controller in the project
+	ControllerLayout golang.ControllerLayout
+
+	// WithRateLimiter sets the rate limiter of the wired controller from the options of the manager
+	WithRateLimiter bool
+
 	// Deprecated - The flag should be removed from go/v5
 	// IsLegacyPath indicates if webhooks should be scaffolded under the API.
 	// Webhooks are now decoupled from APIs based on controller-runtime updates and community feedback.
@@ -71,8 +118,8 @@ type MainUpdater struct { //nolint:maligned
 }
 
 // GetPath implements file.Builder
-func (*MainUpdater) GetPath() string {
-	return defaultMainPath
+func (f *MainUpdater) GetPath() string {
+	return MainPath(f.ManagerName)
 }
 
 // GetIfExistsAction implements file.Builder
@@ -89,41 +136,35 @@ const (
 // GetMarkers implements file.Inserter
 func (f *MainUpdater) GetMarkers() []machinery.Marker {
 	return []machinery.Marker{
-		machinery.NewMarkerFor(defaultMainPath, importMarker),
-		machinery.NewMarkerFor(defaultMainPath, addSchemeMarker),
-		machinery.NewMarkerFor(defaultMainPath, setupMarker),
+		machinery.NewMarkerFor(f.GetPath(), importMarker),
+		machinery.NewMarkerFor(f.GetPath(), addSchemeMarker),
+		machinery.NewMarkerFor(f.GetPath(), setupMarker),
 	}
 }
 
 const (
 	apiImportCodeFragment = `%s "%s"
 `
-	controllerImportCodeFragment = `"%s/internal/controller"
+	controllerImportCodeFragment = `"%s"
 `
 	webhookImportCodeFragment = `%s "%s/internal/webhook/%s"
 `
 	multiGroupWebhookImportCodeFragment = `%s "%s/internal/webhook/%s/%s"
 `
-	multiGroupControllerImportCodeFragment = `%scontroller "%s/internal/controller/%s"
+	multiGroupControllerImportCodeFragment = `%s "%s"
 `
 	addschemeCodeFragment = `utilruntime.Must(%s.AddToScheme(scheme))
 `
-	reconcilerSetupCodeFragment = `if err = (&controller.%sReconciler{
+	reconcilerSetupCodeFragment = `if err = (&%s.%sReconciler{
 		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "%s")
-		os.Exit(1)
-	}
-`
-	multiGroupReconcilerSetupCodeFragment = `if err = (&%scontroller.%sReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Scheme: mgr.GetScheme(),%s
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "%s")
 		os.Exit(1)
 	}
 `
+	rateLimiterFieldCodeFragment = `
+		RateLimiter: opts.RateLimiter.NewRateLimiter(),`
 	webhookSetupCodeFragmentLegacy = `// nolint:goconst
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		if err = (&%s.%s{}).SetupWebhookWithManager(mgr); err != nil {
@@ -168,11 +209,12 @@ func (f *MainUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 	}
 
 	if f.WireController {
-		if !f.MultiGroup || f.Resource.Group == "" {
-			imports = append(imports, fmt.Sprintf(controllerImportCodeFragment, f.Repo))
-		} else {
+		importPath := path.Join(f.Repo, f.ControllerLayout.Dir(*f.Resource, f.MultiGroup))
+		if f.ControllerLayout.IsGrouped(*f.Resource, f.MultiGroup) {
 			imports = append(imports, fmt.Sprintf(multiGroupControllerImportCodeFragment,
-				f.Resource.PackageName(), f.Repo, f.Resource.Group))
+				f.ControllerLayout.ImportAlias(*f.Resource, f.MultiGroup), importPath))
+		} else {
+			imports = append(imports, fmt.Sprintf(controllerImportCodeFragment, importPath))
 		}
 	}
 
@@ -185,13 +227,16 @@ func (f *MainUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 	// Generate setup code fragments
 	setup := make([]string, 0)
 	if f.WireController {
-		if !f.MultiGroup || f.Resource.Group == "" {
-			setup = append(setup, fmt.Sprintf(reconcilerSetupCodeFragment,
-				f.Resource.Kind, f.Resource.Kind))
-		} else {
-			setup = append(setup, fmt.Sprintf(multiGroupReconcilerSetupCodeFragment,
-				f.Resource.PackageName(), f.Resource.Kind, f.Resource.Kind))
+		controllerName := f.ControllerName
+		if controllerName == "" {
+			controllerName = f.Resource.Kind
 		}
+		var fields string
+		if f.WithRateLimiter {
+			fields = rateLimiterFieldCodeFragment
+		}
+		setup = append(setup, fmt.Sprintf(reconcilerSetupCodeFragment,
+			f.ControllerLayout.ImportAlias(*f.Resource, f.MultiGroup), controllerName, fields, controllerName))
 	}
 	if f.WireWebhook {
 		if f.IsLegacyPath {
@@ -205,13 +250,13 @@ func (f *MainUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 
 	// Only store code fragments in the map if the slices are non-empty
 	if len(imports) != 0 {
-		fragments[machinery.NewMarkerFor(defaultMainPath, importMarker)] = imports
+		fragments[machinery.NewMarkerFor(f.GetPath(), importMarker)] = imports
 	}
 	if len(addScheme) != 0 {
-		fragments[machinery.NewMarkerFor(defaultMainPath, addSchemeMarker)] = addScheme
+		fragments[machinery.NewMarkerFor(f.GetPath(), addSchemeMarker)] = addScheme
 	}
 	if len(setup) != 0 {
-		fragments[machinery.NewMarkerFor(defaultMainPath, setupMarker)] = setup
+		fragments[machinery.NewMarkerFor(f.GetPath(), setupMarker)] = setup
 	}
 
 	return fragments
@@ -223,25 +268,46 @@ var mainTemplate = `{{ .Boilerplate }}
 package main
 
 import (
+	{{- if .WithTracing }}
+	"context"
+	{{- end }}
 	"crypto/tls"
 	"flag"
 	"os"
 	"path/filepath"
+	{{- if or .Namespaced .WithCacheOptions }}
+	"strings"
+	{{- end }}
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	{{ if .WithCacheOptions -}}
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	{{ end -}}
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	{{- if or .Namespaced .WithCacheOptions }}
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	{{- end }}
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	{{- if .WithCacheOptions }}
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	{{- end }}
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"{{ .Repo }}/internal/options"
+	{{- if .WithTracing }}
+	"{{ .Repo }}/internal/tracing"
+	{{- end }}
 	%s
 )
 
@@ -258,38 +324,48 @@ func init() {
 
 // nolint:gocyclo
 func main() {
-	var metricsAddr string
-	var metricsCertPath, metricsCertName, metricsCertKey string
-	var webhookCertPath, webhookCertName, webhookCertKey string
-	var enableLeaderElection bool
-	var probeAddr string
-	var secureMetrics bool
-	var enableHTTP2 bool
-	var tlsOpts []func(*tls.Config)
-	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. " +
-		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
-	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
-	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
-		"Enable leader election for controller manager. " +
-		"Enabling this will ensure there is only one active controller manager.")
-	flag.BoolVar(&secureMetrics, "metrics-secure", true,
-		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
-	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
-	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
-	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
-	flag.StringVar(&metricsCertPath, "metrics-cert-path", "", 
-		"The directory that contains the metrics server certificate.")
-	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
-	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
-	flag.BoolVar(&enableHTTP2, "enable-http2", false,
-		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
-	opts := zap.Options{
-		Development: true,
-	}
+	// The flags of the manager are parsed into the options defined in internal/options.
+	opts := options.New()
+	{{- if .ManagerName }}
+	// The {{ .ManagerName }} manager runs other controllers than the other managers of the project,
+	// so it elects its own leader.
+	opts.LeaderElectionID = "{{ .ManagerName }}." + opts.LeaderElectionID
+	{{- end }}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
+	{{- if .WithComponentConfig }}
+	// The options of the configuration file informed with --config, defined in
+	// internal/options/config.go, replace the defaults of the flags.
+	if err := opts.LoadConfigFile(flag.CommandLine); err != nil {
+		setupLog.Error(err, "unable to load the configuration file", "config", opts.ConfigFile)
+		os.Exit(1)
+	}
+	{{- end }}
+	if err := opts.Validate(); err != nil {
+		setupLog.Error(err, "invalid flags")
+		os.Exit(1)
+	}
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts.Zap)))
+	{{- if .WithTracing }}
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	// Tracing is enabled when the OTLP endpoint is informed. Otherwise, the spans created
+	// in the reconcilers are not recorded.
+	// More info: https://opentelemetry.io/docs/specs/otel/protocol/
+	if len(opts.OTLPEndpoint) > 0 {
+		setupLog.Info("Initializing tracing", "otlp-endpoint", opts.OTLPEndpoint)
+		shutdownTracing, err := tracing.Setup(context.Background(), opts.OTLPEndpoint, opts.OTLPInsecure)
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				setupLog.Error(err, "unable to shut down tracing")
+			}
+		}()
+	}
+	{{- end }}
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -297,12 +373,13 @@ func main() {
 	// Rapid Reset CVEs. For more information see:
 	// - https://github.com/advisories/GHSA-qppj-fm5r-hxr3
 	// - https://github.com/advisories/GHSA-4374-p667-p6c8
+	var tlsOpts []func(*tls.Config)
 	disableHTTP2 := func(c *tls.Config) {
 		setupLog.Info("disabling http/2")
 		c.NextProtos = []string{"http/1.1"}
 	}
 
-	if !enableHTTP2 {
+	if !opts.EnableHTTP2 {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
 
@@ -312,14 +389,15 @@ func main() {
 	// Initial webhook TLS options
 	webhookTLSOpts := tlsOpts
 
-	if len(webhookCertPath) > 0 {
+	if len(opts.WebhookCertPath) > 0 {
 		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
-			"webhook-cert-path", webhookCertPath, "webhook-cert-name", webhookCertName, "webhook-cert-key", webhookCertKey)
+			"webhook-cert-path", opts.WebhookCertPath, "webhook-cert-name", opts.WebhookCertName,
+			"webhook-cert-key", opts.WebhookCertKey)
 	
 		var err error
 		webhookCertWatcher, err = certwatcher.New(
-			filepath.Join(webhookCertPath, webhookCertName),
-			filepath.Join(webhookCertPath, webhookCertKey),
+			filepath.Join(opts.WebhookCertPath, opts.WebhookCertName),
+			filepath.Join(opts.WebhookCertPath, opts.WebhookCertKey),
 		)
 		if err != nil {
 			setupLog.Error(err, "Failed to initialize webhook certificate watcher")
@@ -332,6 +410,7 @@ func main() {
 	}
 
 	webhookServer := webhook.NewServer(webhook.Options{
+		Port:    opts.WebhookPort,
 		TLSOpts: webhookTLSOpts,
 	})
 
@@ -340,12 +419,12 @@ func main() {
 	// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@{{ .ControllerRuntimeVersion }}/pkg/metrics/server
 	// - https://book.kubebuilder.io/reference/metrics.html
 	metricsServerOptions := metricsserver.Options{
-		BindAddress:   metricsAddr,
-		SecureServing: secureMetrics,
+		BindAddress:   opts.MetricsBindAddress,
+		SecureServing: opts.SecureMetrics,
 		TLSOpts: tlsOpts,
 	}
 
-	if secureMetrics {
+	if opts.SecureMetrics {
 		// FilterProvider is used to protect the metrics endpoint with authn/authz.
 		// These configurations ensure that only authorized users and service accounts
 		// can access the metrics endpoint. The RBAC are configured in 'config/rbac/kustomization.yaml'. More info:
@@ -358,17 +437,18 @@ func main() {
 	// this setup is not recommended for production.
 	//
 	// TODO(user): If you enable certManager, uncomment the following lines:
-	// - [METRICS-WITH-CERTS] at config/default/kustomization.yaml to generate and use certificates
-	// managed by cert-manager for the metrics server.
+	// - [METRICS-WITH-CERTS] at config/default/kustomization.yaml and config/certmanager/kustomization.yaml
+	// to generate and use certificates managed by cert-manager for the metrics server.
 	// - [PROMETHEUS-WITH-CERTS] at config/prometheus/kustomization.yaml for TLS certification.
-	if len(metricsCertPath) > 0 {
+	if len(opts.MetricsCertPath) > 0 {
 		setupLog.Info("Initializing metrics certificate watcher using provided certificates",
-			"metrics-cert-path", metricsCertPath, "metrics-cert-name", metricsCertName, "metrics-cert-key", metricsCertKey)
+			"metrics-cert-path", opts.MetricsCertPath, "metrics-cert-name", opts.MetricsCertName,
+			"metrics-cert-key", opts.MetricsCertKey)
 
 		var err error
 		metricsCertWatcher, err = certwatcher.New(
-			filepath.Join(metricsCertPath, metricsCertName),
-			filepath.Join(metricsCertPath, metricsCertKey),
+			filepath.Join(opts.MetricsCertPath, opts.MetricsCertName),
+			filepath.Join(opts.MetricsCertPath, opts.MetricsCertKey),
 		)
 		if err != nil {
 			setupLog.Error(err, "to initialize metrics certificate watcher", "error", err)
@@ -380,17 +460,61 @@ func main() {
 		})
 	}
 
+	{{- if .Namespaced }}
+
+	// The manager only watches the namespaces defined in the WATCH_NAMESPACE env var, as a
+	// comma-separated list. All the namespaces are watched when it is not set.
+	var cacheOptions cache.Options
+	if watchNamespace := os.Getenv("WATCH_NAMESPACE"); watchNamespace != "" {
+		cacheOptions.DefaultNamespaces = map[string]cache.Config{}
+		for _, namespace := range strings.Split(watchNamespace, ",") {
+			cacheOptions.DefaultNamespaces[strings.TrimSpace(namespace)] = cache.Config{}
+		}
+	}
+	{{- end }}
+	{{- if .WithCacheOptions }}
+
+	// By default the manager caches every object it reads, e.g. all the Secrets and ConfigMaps of the
+	// cluster once a controller reads one of them, which can lead to a high memory usage. The cached
+	// namespaces are restricted with --cache-namespaces, and the cached Secrets and ConfigMaps with
+	// --cache-label-selector. The transform funcs of the cache modify the objects before they are
+	// stored, the managed fields are stripped since the controllers seldom read them. More info:
+	// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@{{ .ControllerRuntimeVersion }}/pkg/cache#Options
+	{{- if not .Namespaced }}
+	var cacheOptions cache.Options
+	{{- end }}
+	cacheOptions.DefaultTransform = cache.TransformStripManagedFields()
+	if opts.CacheNamespaces != "" {
+		cacheOptions.DefaultNamespaces = map[string]cache.Config{}
+		for _, namespace := range strings.Split(opts.CacheNamespaces, ",") {
+			cacheOptions.DefaultNamespaces[strings.TrimSpace(namespace)] = cache.Config{}
+		}
+	}
+	if opts.CacheLabelSelector != "" {
+		selector, err := labels.Parse(opts.CacheLabelSelector)
+		if err != nil {
+			setupLog.Error(err, "unable to parse the label selector of the cache")
+			os.Exit(1)
+		}
+		// Only the Secrets and ConfigMaps matching the selector are cached. Read the others
+		// with the client returned by mgr.GetAPIReader(), which is not backed by the cache.
+		cacheOptions.ByObject = map[client.Object]cache.ByObject{
+			&corev1.Secret{}:    {Label: selector},
+			&corev1.ConfigMap{}: {Label: selector},
+		}
+	}
+	{{- end }}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
+		{{- if or .Namespaced .WithCacheOptions }}
+		Cache:                  cacheOptions,
+		{{- end }}
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		{{- if not .Domain }}
-		LeaderElectionID:        "{{ hashFNV .Repo }}",
-		{{- else }}
-		LeaderElectionID:        "{{ hashFNV .Repo }}.{{ .Domain }}",
-		{{- end }}
+		HealthProbeBindAddress: opts.ProbeBindAddress,
+		LeaderElection:         opts.LeaderElection,
+		LeaderElectionID:       opts.LeaderElectionID,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -402,6 +526,24 @@ func main() {
 		// if you are doing or is intended to do any operation such as perform cleanups
 		// after the manager stops then its usage might be unsafe.
 		// LeaderElectionReleaseOnCancel: true,
+		{{- if .WithHAOptions }}
+
+		// The leader election lease is tuned with the --leader-election-* flags. A new leader takes over
+		// at most LeaseDuration after the loss of the previous one, while shorter durations increase
+		// the requests of the candidates to the API server.
+		LeaseDuration: &opts.LeaseDuration,
+		RenewDeadline: &opts.RenewDeadline,
+		RetryPeriod:   &opts.RetryPeriod,
+		// GracefulShutdownTimeout is the duration given to the controllers and the servers to stop
+		// once the manager is signaled to terminate, see --graceful-shutdown-timeout.
+		GracefulShutdownTimeout: &opts.GracefulShutdownTimeout,
+		{{- end }}
+		{{- if .WithPprof }}
+
+		// The pprof endpoint is disabled unless --pprof-bind-address is set. It is not protected by
+		// authn/authz, bind it to the loopback interface and reach it with 'kubectl port-forward'.
+		PprofBindAddress: opts.PprofBindAddress,
+		{{- end }}
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -437,6 +579,13 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
+	{{- if .WithHAOptions }}
+	// The signal handler cancels the context of the manager on SIGTERM, e.g. when the Pod is deleted
+	// during a rollout, or on SIGINT. The manager then stops the controllers and the servers, waiting
+	// for them up to --graceful-shutdown-timeout, and releases the leader election lease if
+	// LeaderElectionReleaseOnCancel is enabled. A second signal terminates the manager immediately.
+	// Keep the graceful shutdown timeout below the terminationGracePeriodSeconds of the Pod.
+	{{- end }}
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.HasMarkerFallback = &MainUpdater{}
+
+// insertion is a code fragment inserted at an offset of the content of a file
+type insertion struct {
+	offset int
+	code   string
+}
+
+// InsertWithoutMarkers implements machinery.HasMarkerFallback. It locates with go/ast where the imports, the
+// registration of the schemes and the setup of the controllers and the webhooks are inserted in the main.go file,
+// so that they are wired even when the markers were removed or the file was reorganized.
+func (f *MainUpdater) InsertWithoutMarkers(content string, codeFragments machinery.CodeFragmentsMap) (string, error) {
+	fset := token.NewFileSet()
+	path := f.GetPath()
+	file, err := parser.ParseFile(fset, path, content, parser.ParseComments)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	insertions := make([]insertion, 0, len(codeFragments))
+	for marker, fragments := range codeFragments {
+		code := strings.Join(fragments, "")
+
+		var i insertion
+		switch marker {
+		case machinery.NewMarkerFor(path, importMarker):
+			i = importsInsertion(fset, file, content, code)
+		case machinery.NewMarkerFor(path, addSchemeMarker):
+			i, err = schemeInsertion(fset, file, content, code)
+		case machinery.NewMarkerFor(path, setupMarker):
+			i, err = setupInsertion(fset, file, code)
+		default:
+			return "", fmt.Errorf("unable to insert the code of the marker %q in %s", marker, path)
+		}
+		if err != nil {
+			return "", fmt.Errorf("%w in %s", err, path)
+		}
+		insertions = append(insertions, i)
+	}
+
+	// Insert the code from the end of the file, so that the offsets of the previous insertions are kept
+	sort.Slice(insertions, func(i, j int) bool { return insertions[i].offset > insertions[j].offset })
+	for _, i := range insertions {
+		content = content[:i.offset] + i.code + content[i.offset:]
+	}
+	return content, nil
+}
+
+// importsInsertion inserts the imports at the end of the last import declaration with parentheses,
+// or in a new import declaration after the package clause
+func importsInsertion(fset *token.FileSet, file *ast.File, content, code string) insertion {
+	for i := len(file.Decls) - 1; i >= 0; i-- {
+		if decl, isGenDecl := file.Decls[i].(*ast.GenDecl); isGenDecl && decl.Tok == token.IMPORT && decl.Lparen.IsValid() {
+			return beforeClosing(content, fset.Position(decl.Rparen).Offset, code)
+		}
+	}
+	return insertion{offset: fset.Position(file.Name.End()).Offset, code: "\n\nimport (\n" + code + ")\n"}
+}
+
+// schemeInsertion inserts the registration of the schemes after the last one of the init function
+func schemeInsertion(fset *token.FileSet, file *ast.File, content, code string) (insertion, error) {
+	body := funcBody(file, "init")
+	if body == nil {
+		return insertion{}, errors.New("unable to find the init function registering the schemes")
+	}
+
+	for i := len(body.List) - 1; i >= 0; i-- {
+		if callsFunc(body.List[i], func(name string) bool { return name == "AddToScheme" }) {
+			return insertion{offset: fset.Position(body.List[i].End()).Offset, code: "\n" + code}, nil
+		}
+	}
+	return beforeClosing(content, fset.Position(body.Rbrace).Offset, code), nil
+}
+
+// beforeClosing inserts the code before the closing parenthesis or brace at the offset. The code is inserted
+// at the beginning of its line when the closing parenthesis or brace is the first character of the line.
+func beforeClosing(content string, offset int, code string) insertion {
+	lineStart := strings.LastIndex(content[:offset], "\n") + 1
+	if strings.TrimSpace(content[lineStart:offset]) == "" {
+		return insertion{offset: lineStart, code: code}
+	}
+	return insertion{offset: offset, code: "\n" + code}
+}
+
+// setupFuncRegex matches the functions setting up the controllers and the webhooks with the manager
+var setupFuncRegex = regexp.MustCompile(`^Setup(\w*)WithManager$`)
+
+// setupInsertion inserts the setup of the controllers and the webhooks after the last one of the main function.
+// When there are none, it is inserted after the creation of the manager, or before its checks and its start.
+func setupInsertion(fset *token.FileSet, file *ast.File, code string) (insertion, error) {
+	body := funcBody(file, "main")
+	if body == nil {
+		return insertion{}, errors.New("unable to find the main function")
+	}
+
+	var after ast.Stmt
+	for i, stmt := range body.List {
+		switch {
+		case callsFunc(stmt, setupFuncRegex.MatchString):
+			after = stmt
+		case after == nil && callsFunc(stmt, func(name string) bool { return name == "NewManager" }):
+			after = stmt
+			// The error of the creation of the manager is checked by the next statement
+			if i+1 < len(body.List) {
+				if _, isIf := body.List[i+1].(*ast.IfStmt); isIf {
+					after = body.List[i+1]
+				}
+			}
+		}
+	}
+	if after != nil {
+		return insertion{offset: fset.Position(after.End()).Offset, code: "\n\n" + code}, nil
+	}
+
+	isStart := func(name string) bool {
+		return name == "AddHealthzCheck" || name == "AddReadyzCheck" || name == "Start"
+	}
+	for _, stmt := range body.List {
+		if callsFunc(stmt, isStart) {
+			return insertion{offset: fset.Position(stmt.Pos()).Offset, code: code + "\n"}, nil
+		}
+	}
+	return insertion{}, errors.New("unable to find where the manager is set up in the main function")
+}
+
+// funcBody returns the body of the function of the file with the name, or nil if it is not found
+func funcBody(file *ast.File, name string) *ast.BlockStmt {
+	for _, decl := range file.Decls {
+		if fn, isFunc := decl.(*ast.FuncDecl); isFunc && fn.Recv == nil && fn.Name.Name == name {
+			return fn.Body
+		}
+	}
+	return nil
+}
+
+// callsFunc returns true if the node calls a function or a method whose name matches
+func callsFunc(node ast.Node, matches func(string) bool) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		call, isCall := n.(*ast.CallExpr)
+		if !isCall || found {
+			return !found
+		}
+		switch fun := call.Fun.(type) {
+		case *ast.SelectorExpr:
+			found = matches(fun.Sel.Name)
+		case *ast.Ident:
+			found = matches(fun.Name)
+		}
+		return !found
+	})
+	return found
+}
@@ -18,10 +18,13 @@ package controllers
 
 import (
 	"path/filepath"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
 )
 
 var _ machinery.Template = &Controller{}
@@ -34,27 +37,78 @@ type Controller struct {
 	machinery.MultiGroupMixin
 	machinery.BoilerplateMixin
 	machinery.ResourceMixin
+	machinery.RepositoryMixin
+
+	// ControllerLayout is the placement of the controllers in the project
+	ControllerLayout golang.ControllerLayout
+	// PackageName is the name of the package of the controllers, set from the layout
+	PackageName string
+	// ControllerDir is the directory of the package of the controllers, set from the layout
+	ControllerDir string
 
 	ControllerRuntimeVersion string
 
+	// ControllerName is the name of the controller, which prefixes its reconciler and its files.
+	// It is the kind of the resource unless several controllers are scaffolded for the kind.
+	ControllerName string
+
+	// WithPredicates scaffolds the event predicates and the MaxConcurrentReconciles option in the controller setup
+	WithPredicates bool
+
+	// WithFinalizer scaffolds a finalizer with the logic to add and remove it in the reconciliation
+	WithFinalizer bool
+
+	// WithRateLimiter scaffolds the rate limiter of the workqueue of the controller in its setup
+	WithRateLimiter bool
+
+	// WithStatusConditions scaffolds the reconciliation logic which reports the Ready status condition
+	WithStatusConditions bool
+
+	// WithTracing scaffolds the OpenTelemetry span instrumentation in the reconciliation
+	WithTracing bool
+
+	// WithErrorHandling scaffolds the reconciliation logic which classifies its errors as terminal or transient
+	// errors, with the helpers of internal/errors
+	WithErrorHandling bool
+
+	// Owns are the secondary resources owned by the reconciled objects, watched with Owns()
+	Owns []resource.Resource
+
+	// Watches are the secondary resources which are not owned by the reconciled objects, watched with Watches()
+	// and mapped to the reconcile requests with a function
+	Watches []resource.Resource
+
+	// WatchImports are the packages of the watched resources, by import alias, except the package of the resource
+	WatchImports map[string]string
+
 	Force bool
 }
 
 // SetTemplateDefaults implements machinery.Template
 func (f *Controller) SetTemplateDefaults() error {
+	if f.ControllerName == "" {
+		f.ControllerName = f.Resource.Kind
+	}
+
+	f.ControllerDir = f.ControllerLayout.Dir(*f.Resource, f.MultiGroup)
 	if f.Path == "" {
-		if f.MultiGroup && f.Resource.Group != "" {
-			f.Path = filepath.Join("internal", "controller", "%[group]", "%[kind]_controller.go")
-		} else {
-			f.Path = filepath.Join("internal", "controller", "%[kind]_controller.go")
-		}
+		fileName := strings.ToLower(f.ControllerName) + "_controller.go"
+		f.Path = filepath.Join(filepath.FromSlash(f.ControllerDir), fileName)
 	}
+	f.PackageName = f.ControllerLayout.PackageName(*f.Resource, f.MultiGroup)
 
 	f.Path = f.Resource.Replacer().Replace(f.Path)
 	log.Println(f.Path)
 
 	f.TemplateBody = controllerTemplate
 
+	f.WatchImports = make(map[string]string)
+	for _, res := range append(append([]resource.Resource{}, f.Owns...), f.Watches...) {
+		if res.Path != f.Resource.Path {
+			f.WatchImports[res.ImportAlias()] = res.Path
+		}
+	}
+
 	if f.Force {
 		f.IfExistsAction = machinery.OverwriteFile
 	} else {
@@ -67,28 +121,94 @@ func (f *Controller) SetTemplateDefaults() error {
 //nolint:lll
 const controllerTemplate = `{{ .Boilerplate }}
 
-package {{ if and .MultiGroup .Resource.Group }}{{ .Resource.PackageName }}{{ else }}controller{{ end }}
+package {{ .PackageName }}
 
 import (
 	"context"
+	{{- if .WithStatusConditions }}
+	"k8s.io/apimachinery/pkg/api/meta"
+	{{- end }}
+	{{- if or .WithPredicates .WithStatusConditions }}
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	{{- end }}
+	{{- if .WithTracing }}
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	{{- end }}
 	"k8s.io/apimachinery/pkg/runtime"
+	{{- if .WithRateLimiter }}
+	"k8s.io/client-go/util/workqueue"
+	{{- end }}
 	ctrl "sigs.k8s.io/controller-runtime"
+	{{- if and .WithPredicates (not (isEmptyStr .Resource.Path)) }}
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	{{- end }}
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	{{- if or .WithPredicates .WithRateLimiter }}
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	{{- end }}
+	{{- if .WithFinalizer }}
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	{{- end }}
+	{{- if .Watches }}
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	{{- end }}
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	{{- if .WithPredicates }}
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	{{- end }}
+	{{- if or .WithRateLimiter .Watches }}
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	{{- end }}
 	{{ if not (isEmptyStr .Resource.Path) -}}
 	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
 	{{- end }}
+	{{- range $alias, $path := .WatchImports }}
+	{{ $alias }} "{{ $path }}"
+	{{- end }}
+	{{- if .WithErrorHandling }}
+	reconcileerrors "{{ .Repo }}/internal/errors"
+	{{- end }}
 )
 
-// {{ .Resource.Kind }}Reconciler reconciles a {{ .Resource.Kind }} object
-type {{ .Resource.Kind }}Reconciler struct {
+{{ if .WithFinalizer -}}
+// {{ lower .ControllerName }}Finalizer is the finalizer added to the {{ .Resource.Kind }} objects so that
+// the controller can perform the cleanup operations before they are removed from the cluster.
+const {{ lower .ControllerName }}Finalizer = "{{ .Resource.QualifiedGroup }}/
+{{- if eq .ControllerName .Resource.Kind }}finalizer{{ else }}{{ lower .ControllerName }}-finalizer{{ end }}"
+
+{{ end -}}
+// {{ .ControllerName }}Reconciler reconciles a {{ .Resource.Kind }} object
+type {{ .ControllerName }}Reconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	{{- if .WithPredicates }}
+
+	// MaxConcurrentReconciles is the maximum number of concurrent Reconciles which can be run.
+	// Defaults to 1 when not set.
+	MaxConcurrentReconciles int
+
+	// LabelSelector, when set, restricts the reconciliation to the objects matching it.
+	LabelSelector *metav1.LabelSelector
+	{{- end }}
+	{{- if .WithRateLimiter }}
+
+	// RateLimiter delays the requeue of the requests after an error or a requeue, see
+	// options.RateLimiterOptions. Defaults to the rate limiter of controller-runtime when not set.
+	RateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+	{{- end }}
 }
 
 // +kubebuilder:rbac:groups={{ .Resource.QualifiedGroup }},resources={{ .Resource.Plural }},verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups={{ .Resource.QualifiedGroup }},resources={{ .Resource.Plural }}/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups={{ .Resource.QualifiedGroup }},resources={{ .Resource.Plural }}/finalizers,verbs=update
+{{- range .Owns }}
+// +kubebuilder:rbac:groups={{ .QualifiedGroup }},resources={{ .Plural }},verbs=get;list;watch;create;update;patch;delete
+{{- end }}
+{{- range .Watches }}
+// +kubebuilder:rbac:groups={{ .QualifiedGroup }},resources={{ .Plural }},verbs=get;list;watch
+{{- end }}
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -99,27 +219,251 @@ type {{ .Resource.Kind }}Reconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@{{ .ControllerRuntimeVersion }}/pkg/reconcile
-func (r *{{ .Resource.Kind }}Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *{{ .ControllerName }}Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	{{- if .WithTracing }}
+	// Trace the reconciliation. The spans are only exported when tracing is enabled in the manager.
+	// TODO(user): Create child spans for the expensive operations and record the failures
+	// with span.RecordError(err).
+	ctx, span := otel.Tracer("{{ .Repo }}/{{ .ControllerDir }}").Start(ctx, "{{ .ControllerName }}Reconciler.Reconcile",
+		trace.WithAttributes(
+			attribute.String("namespace", req.Namespace),
+			attribute.String("name", req.Name),
+		))
+	defer span.End()
+
+	// Add the trace ID to the logger so that the logs can be correlated with the trace
+	if span.SpanContext().IsValid() {
+		ctx = log.IntoContext(ctx, log.FromContext(ctx).WithValues("traceID", span.SpanContext().TraceID().String()))
+	}
+
+	{{ end -}}
+	{{- if or .WithFinalizer .WithStatusConditions .WithErrorHandling }}
+	{{- if .WithFinalizer }}
+	log := log.FromContext(ctx)
+	{{- else }}
+	_ = log.FromContext(ctx)
+	{{- end }}
+
+	{{ lower .Resource.Kind }} := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
+	if err := r.Get(ctx, req.NamespacedName, {{ lower .Resource.Kind }}); err != nil {
+		// The object might have been deleted after the reconcile request was queued,
+		// in which case there is nothing left to do.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	{{- if .WithFinalizer }}
+
+	// Perform the cleanup operations when the object is marked to be deleted
+	if !{{ lower .Resource.Kind }}.GetDeletionTimestamp().IsZero() {
+		return r.reconcileDelete(ctx, {{ lower .Resource.Kind }})
+	}
+
+	// Add the finalizer so that the object is not removed before the cleanup operations are performed
+	// More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/finalizers
+	if controllerutil.AddFinalizer({{ lower .Resource.Kind }}, {{ lower .ControllerName }}Finalizer) {
+		log.Info("Adding finalizer to {{ .Resource.Kind }}")
+		if err := r.Update(ctx, {{ lower .Resource.Kind }}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	{{- end }}
+	{{- if .WithStatusConditions }}
+
+	// Set the Ready condition as Unknown when the reconciliation starts for the first time
+	if meta.FindStatusCondition({{ lower .Resource.Kind }}.Status.Conditions, {{ .Resource.ImportAlias }}.{{ .Resource.Kind }}ConditionReady) == nil {
+		meta.SetStatusCondition(&{{ lower .Resource.Kind }}.Status.Conditions, metav1.Condition{
+			Type:               {{ .Resource.ImportAlias }}.{{ .Resource.Kind }}ConditionReady,
+			Status:             metav1.ConditionUnknown,
+			Reason:             {{ .Resource.ImportAlias }}.{{ .Resource.Kind }}ReasonReconciling,
+			Message:            "Starting reconciliation",
+			ObservedGeneration: {{ lower .Resource.Kind }}.GetGeneration(),
+		})
+		if err := r.Status().Update(ctx, {{ lower .Resource.Kind }}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	{{- end }}
+	{{- else }}
 	_ = log.FromContext(ctx)
+	{{- end }}
+
+	{{- if .WithErrorHandling }}
+
+	if err := r.reconcileNormal(ctx, {{ lower .Resource.Kind }}); err != nil {
+		return r.handleError(ctx, {{ if .WithStatusConditions }}{{ lower .Resource.Kind }}, {{ end }}err)
+	}
+	{{- else }}
+
+	// TODO(user): your logic here
+	{{- end }}
+	{{- if .WithStatusConditions }}
+	{{- if not .WithErrorHandling }}
+	// When the reconciliation fails, report it in the Ready condition before returning the error, i.e.:
+	// {{ lower .Resource.Kind }}.SetReadyCondition(metav1.ConditionFalse, {{ .Resource.ImportAlias }}.{{ .Resource.Kind }}ReasonFailed, err.Error())
+	{{- end }}
+
+	{{ lower .Resource.Kind }}.SetReadyCondition(metav1.ConditionTrue, {{ .Resource.ImportAlias }}.{{ .Resource.Kind }}ReasonReconciled,
+		"The {{ .Resource.Kind }} was successfully reconciled")
+	if err := r.Status().Update(ctx, {{ lower .Resource.Kind }}); err != nil {
+		return ctrl.Result{}, err
+	}
+	{{- end }}
+
+	return ctrl.Result{}, nil
+}
+{{- if .WithErrorHandling }}
 
+// reconcileNormal reconciles the {{ .Resource.Kind }} which is not being deleted.
+// TODO(user): Wrap the errors which are not solved by retrying, such as an invalid spec, with
+// reconcileerrors.Terminal, and the errors which are solved after a while, such as a dependency
+// which is not ready yet, with reconcileerrors.Transient. The other errors are retried with
+// the backoff of the controller.
+func (r *{{ .ControllerName }}Reconciler) reconcileNormal(ctx context.Context, {{ lower .Resource.Kind }} *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) error {
 	// TODO(user): your logic here
 
+	return nil
+}
+
+// handleError returns the result of the reconciliation for its error, see reconcileerrors.Result.
+{{- if .WithStatusConditions }}
+// A terminal error is reported in the Ready condition, since the reconciliation is not retried
+// until the {{ .Resource.Kind }} changes.
+{{- end }}
+func (r *{{ .ControllerName }}Reconciler) handleError(ctx context.Context, {{ if .WithStatusConditions }}{{ lower .Resource.Kind }} *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}, {{ end }}err error) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	{{- if .WithStatusConditions }}
+
+	if reconcileerrors.IsTerminal(err) {
+		{{ lower .Resource.Kind }}.SetReadyCondition(metav1.ConditionFalse, {{ .Resource.ImportAlias }}.{{ .Resource.Kind }}ReasonFailed, err.Error())
+		if updateErr := r.Status().Update(ctx, {{ lower .Resource.Kind }}); updateErr != nil {
+			return ctrl.Result{}, updateErr
+		}
+	}
+	{{- end }}
+
+	result, resultErr := reconcileerrors.Result(err)
+	if resultErr == nil {
+		log.Info("Requeuing the {{ .Resource.Kind }} after a transient error", "requeueAfter", result.RequeueAfter, "reason", err.Error())
+	}
+	return result, resultErr
+}
+{{- end }}
+{{- if .WithFinalizer }}
+
+// reconcileDelete performs the cleanup operations required before the {{ .Resource.Kind }} is deleted
+// and removes the finalizer so that the Kubernetes API can remove the object.
+func (r *{{ .ControllerName }}Reconciler) reconcileDelete(ctx context.Context, {{ lower .Resource.Kind }} *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer({{ lower .Resource.Kind }}, {{ lower .ControllerName }}Finalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("Performing finalizer operations for {{ .Resource.Kind }}")
+
+	// TODO(user): Add the cleanup steps that the controller needs to perform before the object
+	// is deleted, such as removing the external resources which are not owned by it.
+	// Note that the resources owned by the object (i.e. with the ownerRef set) are removed
+	// by the garbage collector and do not require a finalizer.
+
+	controllerutil.RemoveFinalizer({{ lower .Resource.Kind }}, {{ lower .ControllerName }}Finalizer)
+	if err := r.Update(ctx, {{ lower .Resource.Kind }}); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	return ctrl.Result{}, nil
 }
+{{- end }}
+
+{{ if .WithPredicates -}}
+// eventPredicates returns the predicates used to filter the events which trigger a reconciliation.
+// TODO(user): Add or remove predicates as needed.
+// More info: https://pkg.go.dev/sigs.k8s.io/controller-runtime@{{ .ControllerRuntimeVersion }}/pkg/predicate
+func (r *{{ .ControllerName }}Reconciler) eventPredicates() ([]predicate.Predicate, error) {
+	predicates := []predicate.Predicate{
+		// Ignore the updates which do not change the spec, such as status or metadata only changes.
+		predicate.GenerationChangedPredicate{},
+	}
+
+	if r.LabelSelector != nil {
+		labelPredicate, err := predicate.LabelSelectorPredicate(*r.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, labelPredicate)
+	}
+
+	return predicates, nil
+}
 
+{{ end -}}
+{{ range .Watches -}}
+// map{{ .Kind }}ToRequests maps the events of a {{ .Kind }} to the reconcile requests of the {{ $.Resource.Kind }}
+// objects which depend on it.
+// TODO(user): Only enqueue the {{ $.Resource.Kind }} objects which reference the {{ .Kind }}, e.g. listed with a
+// field index set up with mgr.GetFieldIndexer().
+// More info: https://pkg.go.dev/sigs.k8s.io/controller-runtime@{{ $.ControllerRuntimeVersion }}/pkg/handler#EnqueueRequestsFromMapFunc
+func (r *{{ $.ControllerName }}Reconciler) map{{ .Kind }}ToRequests(ctx context.Context, obj client.Object) []reconcile.Request {
+	{{ lower $.Resource.Kind }}List := &{{ $.Resource.ImportAlias }}.{{ $.Resource.Kind }}List{}
+	if err := r.List(ctx, {{ lower $.Resource.Kind }}List, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list the {{ $.Resource.Kind }} objects to map the {{ .Kind }}",
+			"{{ lower .Kind }}", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len({{ lower $.Resource.Kind }}List.Items))
+	for i := range {{ lower $.Resource.Kind }}List.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&{{ lower $.Resource.Kind }}List.Items[i]),
+		})
+	}
+	return requests
+}
+
+{{ end -}}
 // SetupWithManager sets up the controller with the Manager.
-func (r *{{ .Resource.Kind }}Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *{{ .ControllerName }}Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	{{- if .WithPredicates }}
+	predicates, err := r.eventPredicates()
+	if err != nil {
+		return err
+	}
+
+	{{ end -}}
 	return ctrl.NewControllerManagedBy(mgr).
 		{{ if not (isEmptyStr .Resource.Path) -}}
+		{{ if .WithPredicates -}}
+		For(&{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}, builder.WithPredicates(predicates...)).
+		{{- else -}}
 		For(&{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}).
+		{{- end }}
 		{{- else -}}
 		// Uncomment the following line adding a pointer to an instance of the controlled resource as an argument
 		// For().
+		{{- if .WithPredicates }}
+		WithEventFilter(predicate.And(predicates...)).
+		{{- end }}
+		{{- end }}
+		{{- range .Owns }}
+		// Reconcile the {{ $.Resource.Kind }} when a {{ .Kind }} it controls changes
+		Owns(&{{ .ImportAlias }}.{{ .Kind }}{}).
+		{{- end }}
+		{{- range .Watches }}
+		Watches(&{{ .ImportAlias }}.{{ .Kind }}{}, handler.EnqueueRequestsFromMapFunc(r.map{{ .Kind }}ToRequests)).
+		{{- end }}
+		{{- if and .WithPredicates .WithRateLimiter }}
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+			RateLimiter:             r.RateLimiter,
+		}).
+		{{- else if .WithPredicates }}
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		{{- else if .WithRateLimiter }}
+		WithOptions(controller.Options{RateLimiter: r.RateLimiter}).
 		{{- end }}
 		{{- if and (.MultiGroup) (not (isEmptyStr .Resource.Group)) }}
-		Named("{{ lower .Resource.Group }}-{{ lower .Resource.Kind }}").
+		Named("{{ lower .Resource.Group }}-{{ lower .ControllerName }}").
 		{{- else }}
-		Named("{{ lower .Resource.Kind }}").
+		Named("{{ lower .ControllerName }}").
 		{{- end }}
 		Complete(r)
 }
@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
+)
+
+var _ machinery.Template = &ControllerFakeTest{}
+
+// ControllerFakeTest scaffolds the table-driven unit tests of the controller, which run the reconciliation
+// against the fake client of controller-runtime instead of envtest
+//
+//nolint:maligned
+type ControllerFakeTest struct {
+	machinery.TemplateMixin
+	machinery.MultiGroupMixin
+	machinery.BoilerplateMixin
+	machinery.ResourceMixin
+
+	// ControllerLayout is the placement of the controllers in the project
+	ControllerLayout golang.ControllerLayout
+	// PackageName is the name of the package of the controllers, set from the layout
+	PackageName string
+
+	Force bool
+
+	// ControllerName is the name of the controller under test, the kind of the resource by default
+	ControllerName string
+
+	// Namespaced indicates that the objects of the resource are stored in a namespace
+	Namespaced bool
+
+	// WithFinalizer scaffolds the test cases covering the finalizer and the deletion of the resource
+	WithFinalizer bool
+
+	// WithStatusConditions scaffolds the assertions on the Ready status condition
+	WithStatusConditions bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *ControllerFakeTest) SetTemplateDefaults() error {
+	if f.ControllerName == "" {
+		f.ControllerName = f.Resource.Kind
+	}
+
+	if f.Path == "" {
+		fileName := strings.ToLower(f.ControllerName) + "_controller_fake_test.go"
+		dir := f.ControllerLayout.Dir(*f.Resource, f.MultiGroup)
+		f.Path = filepath.Join(filepath.FromSlash(dir), fileName)
+	}
+	f.PackageName = f.ControllerLayout.PackageName(*f.Resource, f.MultiGroup)
+
+	f.Path = f.Resource.Replacer().Replace(f.Path)
+	log.Println(f.Path)
+
+	f.TemplateBody = controllerFakeTestTemplate
+
+	// The APIs of the project and the builtin types are namespaced unless they are created with --namespaced=false
+	f.Namespaced = !f.Resource.HasAPI() || f.Resource.API.Namespaced
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	}
+
+	return nil
+}
+
+//nolint:lll
+const controllerFakeTestTemplate = `{{ .Boilerplate }}
+
+package {{ .PackageName }}
+
+import (
+	"context"
+	"testing"
+
+	{{- if and .WithFinalizer (not (isEmptyStr .Resource.Path)) }}
+	"k8s.io/apimachinery/pkg/api/errors"
+	{{- end }}
+	{{- if not (isEmptyStr .Resource.Path) }}
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	{{- end }}
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	{{- if and .WithFinalizer (not (isEmptyStr .Resource.Path)) }}
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	{{- end }}
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	{{ if not (isEmptyStr .Resource.Path) -}}
+	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
+	{{- end }}
+)
+
+// Test{{ .ControllerName }}ReconcilerWithFakeClient runs the reconciliation of the {{ .ControllerName }} controller
+// against the fake client of controller-runtime. These tests do not require envtest, so they are fast
+// enough to cover each branch of the reconciliation with a case of the table.
+// TODO(user): Add the cases and the assertions of your reconciliation logic.
+func Test{{ .ControllerName }}ReconcilerWithFakeClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	{{- if not (isEmptyStr .Resource.Path) }}
+	utilruntime.Must({{ .Resource.ImportAlias }}.AddToScheme(scheme))
+	{{- end }}
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      "test-resource",
+		{{- if .Namespaced }}
+		Namespace: "default",
+		{{- end }}
+	}
+	{{- if and .WithFinalizer (not (isEmptyStr .Resource.Path)) }}
+	now := metav1.Now()
+	{{- end }}
+
+	tests := []struct {
+		name string
+		// objects are stored in the fake client before the reconciliation
+		objects []client.Object
+		wantErr bool
+		// check asserts the state of the objects after the reconciliation
+		check func(t *testing.T, c client.Client)
+	}{
+		{
+			name: "the resource does not exist",
+		},
+		{{- if not (isEmptyStr .Resource.Path) }}
+		{
+			name: "the resource exists",
+			objects: []client.Object{
+				&{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      typeNamespacedName.Name,
+						{{- if .Namespaced }}
+						Namespace: typeNamespacedName.Namespace,
+						{{- end }}
+					},
+					// TODO(user): Specify other spec details if needed.
+				},
+			},
+			{{- if or .WithFinalizer .WithStatusConditions }}
+			check: func(t *testing.T, c client.Client) {
+				resource := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
+				if err := c.Get(context.Background(), typeNamespacedName, resource); err != nil {
+					t.Fatalf("failed to get the resource: %v", err)
+				}
+				{{- if .WithFinalizer }}
+				if !controllerutil.ContainsFinalizer(resource, {{ lower .ControllerName }}Finalizer) {
+					t.Errorf("the finalizer %s was not added", {{ lower .ControllerName }}Finalizer)
+				}
+				{{- end }}
+				{{- if .WithStatusConditions }}
+				if !resource.IsReady() {
+					t.Errorf("the Ready condition was not set: %v", resource.GetReadyCondition())
+				}
+				{{- end }}
+			},
+			{{- end }}
+		},
+		{{- if .WithFinalizer }}
+		{
+			name: "the resource is being deleted",
+			objects: []client.Object{
+				&{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              typeNamespacedName.Name,
+						{{- if .Namespaced }}
+						Namespace:         typeNamespacedName.Namespace,
+						{{- end }}
+						Finalizers:        []string{ {{- lower .ControllerName }}Finalizer},
+						DeletionTimestamp: &now,
+					},
+				},
+			},
+			check: func(t *testing.T, c client.Client) {
+				resource := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
+				if err := c.Get(context.Background(), typeNamespacedName, resource); !errors.IsNotFound(err) {
+					t.Errorf("the resource was not removed once its finalizer was removed: %v", err)
+				}
+			},
+		},
+		{{- end }}
+		{{- end }}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.objects...).
+				{{- if not (isEmptyStr .Resource.Path) }}
+				WithStatusSubresource(&{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}).
+				{{- end }}
+				Build()
+
+			controllerReconciler := &{{ .ControllerName }}Reconciler{
+				Client: fakeClient,
+				Scheme: scheme,
+			}
+
+			_, err := controllerReconciler.Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.check != nil {
+				tt.check(t, fakeClient)
+			}
+		})
+	}
+}
+`
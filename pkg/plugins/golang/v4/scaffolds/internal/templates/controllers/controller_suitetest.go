@@ -19,10 +19,12 @@ package controllers
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
 )
 
 var _ machinery.Template = &SuiteTest{}
@@ -37,6 +39,11 @@ type SuiteTest struct {
 	machinery.BoilerplateMixin
 	machinery.ResourceMixin
 
+	// ControllerLayout is the placement of the controllers in the project
+	ControllerLayout golang.ControllerLayout
+	// PackageName is the name of the package of the controllers, set from the layout
+	PackageName string
+
 	// CRDDirectoryRelativePath define the Path for the CRD
 	CRDDirectoryRelativePath string
 
@@ -46,12 +53,10 @@ type SuiteTest struct {
 // SetTemplateDefaults implements machinery.Template
 func (f *SuiteTest) SetTemplateDefaults() error {
 	if f.Path == "" {
-		if f.MultiGroup && f.Resource.Group != "" {
-			f.Path = filepath.Join("internal", "controller", "%[group]", "suite_test.go")
-		} else {
-			f.Path = filepath.Join("internal", "controller", "suite_test.go")
-		}
+		dir := f.ControllerLayout.Dir(*f.Resource, f.MultiGroup)
+		f.Path = filepath.Join(filepath.FromSlash(dir), "suite_test.go")
 	}
+	f.PackageName = f.ControllerLayout.PackageName(*f.Resource, f.MultiGroup)
 
 	f.Path = f.Resource.Replacer().Replace(f.Path)
 	log.Println(f.Path)
@@ -61,12 +66,10 @@ func (f *SuiteTest) SetTemplateDefaults() error {
 		machinery.NewMarkerFor(f.Path, addSchemeMarker),
 	)
 
-	// If is multigroup the path needs to be ../../ since it has
-	// the group dir.
-	f.CRDDirectoryRelativePath = `"..",".."`
-	if f.MultiGroup && f.Resource.Group != "" {
-		f.CRDDirectoryRelativePath = `"..", "..",".."`
-	}
+	// The CRDs are found from the directory of the controllers, e.g. ../../config/crd/bases
+	// for internal/controller
+	rootPath := f.ControllerLayout.RootRelativePath(*f.Resource, f.MultiGroup)
+	f.CRDDirectoryRelativePath = `"` + strings.Join(strings.Split(rootPath, "/"), `", "`) + `"`
 
 	if f.Force {
 		f.IfExistsAction = machinery.OverwriteFile
@@ -126,11 +129,7 @@ func (f *SuiteTest) GetCodeFragments() machinery.CodeFragmentsMap {
 
 const controllerSuiteTestTemplate = `{{ .Boilerplate }}
 
-{{if and .MultiGroup .Resource.Group }}
-package {{ .Resource.PackageName }}
-{{else}}
-package controller
-{{end}}
+package {{ .PackageName }}
 
 import (
 	"context"
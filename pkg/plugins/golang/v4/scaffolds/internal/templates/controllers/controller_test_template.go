@@ -18,10 +18,12 @@ package controllers
 
 import (
 	"path/filepath"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang"
 )
 
 var _ machinery.Template = &ControllerTest{}
@@ -35,20 +37,40 @@ type ControllerTest struct {
 	machinery.BoilerplateMixin
 	machinery.ResourceMixin
 
+	// ControllerLayout is the placement of the controllers in the project
+	ControllerLayout golang.ControllerLayout
+	// PackageName is the name of the package of the controllers, set from the layout
+	PackageName string
+
 	Force bool
 
+	// ControllerName is the name of the controller under test, the kind of the resource by default
+	ControllerName string
+
 	DoAPI bool
+
+	// WithPredicates scaffolds the tests for the event predicates of the controller
+	WithPredicates bool
+
+	// WithFinalizer scaffolds the tests covering the finalizer and the deletion of the resource
+	WithFinalizer bool
+
+	// WithStatusConditions scaffolds the assertions on the Ready status condition
+	WithStatusConditions bool
 }
 
 // SetTemplateDefaults implements machinery.Template
 func (f *ControllerTest) SetTemplateDefaults() error {
+	if f.ControllerName == "" {
+		f.ControllerName = f.Resource.Kind
+	}
+
 	if f.Path == "" {
-		if f.MultiGroup && f.Resource.Group != "" {
-			f.Path = filepath.Join("internal", "controller", "%[group]", "%[kind]_controller_test.go")
-		} else {
-			f.Path = filepath.Join("internal", "controller", "%[kind]_controller_test.go")
-		}
+		fileName := strings.ToLower(f.ControllerName) + "_controller_test.go"
+		dir := f.ControllerLayout.Dir(*f.Resource, f.MultiGroup)
+		f.Path = filepath.Join(filepath.FromSlash(dir), fileName)
 	}
+	f.PackageName = f.ControllerLayout.PackageName(*f.Resource, f.MultiGroup)
 
 	f.Path = f.Resource.Replacer().Replace(f.Path)
 	log.Println(f.Path)
@@ -64,11 +86,7 @@ func (f *ControllerTest) SetTemplateDefaults() error {
 
 const controllerTestTemplate = `{{ .Boilerplate }}
 
-{{if and .MultiGroup .Resource.Group }}
-package {{ .Resource.PackageName }}
-{{else}}
-package controller
-{{end}}
+package {{ .PackageName }}
 
 import (
 	{{ if .DoAPI -}}
@@ -80,6 +98,12 @@ import (
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	{{- if .WithFinalizer }}
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	{{- end }}
+	{{- if .WithPredicates }}
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	{{- end }}
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -89,7 +113,7 @@ import (
 	{{- end }}
 )
 
-var _ = Describe("{{ .Resource.Kind }} Controller", func() {
+var _ = Describe("{{ .ControllerName }} Controller", func() {
 	Context("When reconciling a resource", func() {
 		{{ if .DoAPI -}}
 		const resourceName = "test-resource"
@@ -98,7 +122,9 @@ var _ = Describe("{{ .Resource.Kind }} Controller", func() {
 
 		typeNamespacedName := types.NamespacedName{
 			Name:      resourceName,
+			{{- if .Resource.API.Namespaced }}
 			Namespace: "default",  // TODO(user):Modify as needed
+			{{- end }}
 		}
 		{{ lower .Resource.Kind }} := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
 
@@ -109,7 +135,9 @@ var _ = Describe("{{ .Resource.Kind }} Controller", func() {
 				resource := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      resourceName,
+						{{- if .Resource.API.Namespaced }}
 						Namespace: "default",
+						{{- end }}
 					},
 					// TODO(user): Specify other spec details if needed.
 				}
@@ -121,16 +149,35 @@ var _ = Describe("{{ .Resource.Kind }} Controller", func() {
 			// TODO(user): Cleanup logic after each test, like removing the resource instance.
 			resource := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			{{- if .WithFinalizer }}
+			if errors.IsNotFound(err) {
+				// The resource was already removed by the test
+				return
+			}
+			{{- end }}
 			Expect(err).NotTo(HaveOccurred())
 
 			By("Cleanup the specific resource instance {{ .Resource.Kind }}")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			{{- if .WithFinalizer }}
+
+			By("Reconciling the deletion to remove the finalizer")
+			controllerReconciler := &{{ .ControllerName }}Reconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, resource))).To(BeTrue())
+			{{- end }}
 		})
 		{{- end }}
 		It("should successfully reconcile the resource", func() {
 			{{ if .DoAPI -}}
 			By("Reconciling the created resource")
-			controllerReconciler := &{{ .Resource.Kind }}Reconciler{
+			controllerReconciler := &{{ .ControllerName }}Reconciler{
 				Client: k8sClient,
 				Scheme: k8sClient.Scheme(),
 			}
@@ -139,10 +186,123 @@ var _ = Describe("{{ .Resource.Kind }} Controller", func() {
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
+			{{- if .WithStatusConditions }}
+
+			By("Checking that the Ready condition was set")
+			resource := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.IsReady()).To(BeTrue())
+			Expect(resource.GetReadyCondition().Reason).To(Equal({{ .Resource.ImportAlias }}.{{ .Resource.Kind }}ReasonReconciled))
+			{{- end }}
 			{{- end }}
 			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
+		{{- if and .DoAPI .WithFinalizer }}
+
+		It("should add the finalizer to the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &{{ .ControllerName }}Reconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Checking that the finalizer was added")
+			resource := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(controllerutil.ContainsFinalizer(resource, {{ lower .ControllerName }}Finalizer)).To(BeTrue())
+		})
+
+		It("should remove the finalizer when the resource is deleted", func() {
+			controllerReconciler := &{{ .ControllerName }}Reconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			By("Reconciling the created resource to add the finalizer")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Deleting the resource")
+			resource := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			By("Checking that the resource is kept until the finalizer is removed")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.GetDeletionTimestamp().IsZero()).To(BeFalse())
+
+			By("Reconciling the deletion")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Checking that the resource was removed")
+			err = k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+		{{- end }}
 	})
+	{{- if and .DoAPI .WithPredicates }}
+
+	Context("When filtering events", func() {
+		It("should only reconcile the updates which change the generation", func() {
+			controllerReconciler := &{{ .ControllerName }}Reconciler{}
+			predicates, err := controllerReconciler.eventPredicates()
+			Expect(err).NotTo(HaveOccurred())
+
+			oldObj := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-resource", Namespace: "default", Generation: 1},
+			}
+			newObj := oldObj.DeepCopy()
+			newObj.Labels = map[string]string{"changed": "true"}
+
+			By("ignoring updates which do not change the generation")
+			for _, p := range predicates {
+				Expect(p.Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})).To(BeFalse())
+			}
+
+			By("accepting updates which change the generation")
+			newObj.Generation = 2
+			for _, p := range predicates {
+				Expect(p.Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})).To(BeTrue())
+			}
+		})
+
+		It("should only reconcile the objects matching the label selector", func() {
+			controllerReconciler := &{{ .ControllerName }}Reconciler{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"managed": "true"}},
+			}
+			predicates, err := controllerReconciler.eventPredicates()
+			Expect(err).NotTo(HaveOccurred())
+
+			matching := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{
+				ObjectMeta: metav1.ObjectMeta{Name: "matching", Labels: map[string]string{"managed": "true"}},
+			}
+			notMatching := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{
+				ObjectMeta: metav1.ObjectMeta{Name: "not-matching"},
+			}
+
+			accepted := func(obj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) bool {
+				for _, p := range predicates {
+					if !p.Create(event.CreateEvent{Object: obj}) {
+						return false
+					}
+				}
+				return true
+			}
+			Expect(accepted(matching)).To(BeTrue())
+			Expect(accepted(notMatching)).To(BeFalse())
+		})
+	})
+	{{- end }}
 })
 `
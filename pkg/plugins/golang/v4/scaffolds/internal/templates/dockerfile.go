@@ -25,6 +25,23 @@ var _ machinery.Template = &Dockerfile{}
 // Dockerfile scaffolds a file that defines the containerized build process
 type Dockerfile struct {
 	machinery.TemplateMixin
+
+	// MultiGroupModules indicates that each API group is its own Go module which
+	// must be available before the dependencies are downloaded
+	MultiGroupModules bool
+
+	// ControllersLayout indicates that the controllers are placed under the controllers directory
+	ControllersLayout bool
+
+	// Managers are the names of the additional managers, whose binaries are added to the image
+	Managers []string
+
+	// Workspace indicates that the project is a module of a Go workspace (go.work), so the build context is
+	// the root of the workspace
+	Workspace bool
+
+	// WorkDir is the directory of the project in the builder
+	WorkDir string
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -35,26 +52,53 @@ func (f *Dockerfile) SetTemplateDefaults() error {
 
 	f.TemplateBody = dockerfileTemplate
 
+	if f.WorkDir == "" {
+		f.WorkDir = "/workspace"
+	}
+
 	return nil
 }
 
 const dockerfileTemplate = `# Build the manager binary
-FROM docker.io/golang:1.23 AS builder
+# The builder runs on the platform of the host (BUILDPLATFORM) and cross-compiles the manager for the
+# target platform (TARGETOS/TARGETARCH), so that multi-platform images are built without emulation.
+FROM --platform=${BUILDPLATFORM:-linux/amd64} docker.io/golang:1.23 AS builder
 ARG TARGETOS
 ARG TARGETARCH
 
 WORKDIR /workspace
+{{- if .Workspace }}
+# The build context is the root of the Go workspace (go.work): copy the workspace with all its modules,
+# since the project may depend on any of them, and build the project from its directory
+COPY . .
+WORKDIR {{ .WorkDir }}
+RUN go mod download
+{{- else }}
 # Copy the Go Modules manifests
 COPY go.mod go.mod
 COPY go.sum go.sum
+{{- if .MultiGroupModules }}
+# Copy the API group modules which are replaced by local paths in go.mod
+COPY api/ api/
+{{- end }}
 # cache deps before building and copying source so that we don't need to re-download as much
 # and so that source changes don't invalidate our downloaded layer
 RUN go mod download
 
 # Copy the go source
+{{- if .Managers }}
+COPY cmd/ cmd/
+{{- else }}
 COPY cmd/main.go cmd/main.go
+{{- end }}
+{{- if not .MultiGroupModules }}
 COPY api/ api/
+{{- end }}
 COPY internal/ internal/
+{{- if .ControllersLayout }}
+COPY controllers/ controllers/
+{{- end }}
+{{- end }}
 
 # Build
 # the GOARCH has not a default value to allow the binary be built according to the host where the command
@@ -62,12 +106,18 @@ COPY internal/ internal/
 # the docker BUILDPLATFORM arg will be linux/arm64 when for Apple x86 it will be linux/amd64. Therefore,
 # by leaving it empty we can ensure that the container and binary shipped on it will have the same platform.
 RUN CGO_ENABLED=0 GOOS=${TARGETOS:-linux} GOARCH=${TARGETARCH} go build -a -o manager cmd/main.go
+{{- range .Managers }}
+RUN CGO_ENABLED=0 GOOS=${TARGETOS:-linux} GOARCH=${TARGETARCH} go build -a -o {{ . }} cmd/{{ . }}/main.go
+{{- end }}
 
 # Use distroless as minimal base image to package the manager binary
 # Refer to https://github.com/GoogleContainerTools/distroless for more details
 FROM gcr.io/distroless/static:nonroot
 WORKDIR /
-COPY --from=builder /workspace/manager .
+COPY --from=builder {{ .WorkDir }}/manager .
+{{- range .Managers }}
+COPY --from=builder {{ $.WorkDir }}/{{ . }} .
+{{- end }}
 USER 65532:65532
 
 ENTRYPOINT ["/manager"]
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &Errors{}
+
+// Errors scaffolds the file that defines the terminal and transient errors of the reconcilers
+type Errors struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *Errors) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("internal", "errors", "errors.go")
+	}
+
+	f.TemplateBody = errorsTemplate
+
+	return nil
+}
+
+const errorsTemplate = `{{ .Boilerplate }}
+
+// Package errors classifies the errors of the reconcilers as terminal or transient errors,
+// and turns them into the result returned by Reconcile.
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TerminalError is an error which is not solved by retrying the reconciliation, e.g. an invalid spec
+// which must be fixed by the user. The request is not requeued: the object is reconciled again when it changes.
+type TerminalError struct {
+	Err error
+}
+
+// Error implements error.
+func (e *TerminalError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *TerminalError) Unwrap() error {
+	return e.Err
+}
+
+// TransientError is an error which is solved by retrying the reconciliation later, e.g. a dependency
+// which is not ready yet. The request is requeued after RequeueAfter, or with the backoff of the
+// controller when it is zero.
+type TransientError struct {
+	Err          error
+	RequeueAfter time.Duration
+}
+
+// Error implements error.
+func (e *TransientError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// Terminal wraps err in a TerminalError, it returns nil when err is nil.
+func Terminal(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &TerminalError{Err: err}
+}
+
+// Terminalf returns a TerminalError with the formatted message.
+func Terminalf(format string, args ...any) error {
+	return Terminal(fmt.Errorf(format, args...))
+}
+
+// Transient wraps err in a TransientError requeued after requeueAfter, it returns nil when err is nil.
+func Transient(err error, requeueAfter time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &TransientError{Err: err, RequeueAfter: requeueAfter}
+}
+
+// IsTerminal returns true if err is or wraps a TerminalError.
+func IsTerminal(err error) bool {
+	var terminal *TerminalError
+	return errors.As(err, &terminal)
+}
+
+// IsTransient returns true if err is or wraps a TransientError.
+func IsTransient(err error) bool {
+	var transient *TransientError
+	return errors.As(err, &transient)
+}
+
+// Result returns the result and the error to return from Reconcile for the error of the reconciliation:
+//   - a TerminalError is returned as a terminal error, which controller-runtime logs without requeuing the request;
+//   - a TransientError with a delay requeues the request after the delay, without error;
+//   - any other error is returned as is, and the request is requeued with the backoff of the controller.
+func Result(err error) (ctrl.Result, error) {
+	var transient *TransientError
+	switch {
+	case err == nil:
+		return ctrl.Result{}, nil
+	case IsTerminal(err):
+		return ctrl.Result{}, reconcile.TerminalError(err)
+	case errors.As(err, &transient) && transient.RequeueAfter > 0:
+		return ctrl.Result{RequeueAfter: transient.RequeueAfter}, nil
+	default:
+		return ctrl.Result{}, err
+	}
+}
+`
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &SuiteTest{}
+
+// SuiteTest scaffolds the file that sets up the test suite of the errors of the reconcilers
+type SuiteTest struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *SuiteTest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("internal", "errors", "suite_test.go")
+	}
+
+	f.TemplateBody = suiteTestTemplate
+
+	return nil
+}
+
+const suiteTestTemplate = `{{ .Boilerplate }}
+
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// TestErrors runs the tests of the errors of the reconcilers, which do not require a cluster.
+func TestErrors(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting errors suite\n")
+	RunSpecs(t, "Errors Suite")
+}
+`
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &ErrorsTest{}
+
+// ErrorsTest scaffolds the file that tests the classification of the errors of the reconcilers
+type ErrorsTest struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *ErrorsTest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("internal", "errors", "errors_test.go")
+	}
+
+	f.TemplateBody = errorsTestTemplate
+
+	return nil
+}
+
+const errorsTestTemplate = `{{ .Boilerplate }}
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Errors", func() {
+	errFailed := errors.New("failed")
+
+	It("should return nil when wrapping a nil error", func() {
+		Expect(Terminal(nil)).To(Succeed())
+		Expect(Transient(nil, time.Minute)).To(Succeed())
+	})
+
+	It("should classify the wrapped errors", func() {
+		terminal := fmt.Errorf("reconciling: %w", Terminal(errFailed))
+		Expect(IsTerminal(terminal)).To(BeTrue())
+		Expect(IsTransient(terminal)).To(BeFalse())
+		Expect(terminal).To(MatchError(errFailed))
+
+		transient := fmt.Errorf("reconciling: %w", Transient(errFailed, time.Minute))
+		Expect(IsTransient(transient)).To(BeTrue())
+		Expect(IsTerminal(transient)).To(BeFalse())
+		Expect(transient).To(MatchError(errFailed))
+
+		Expect(IsTerminal(errFailed)).To(BeFalse())
+		Expect(IsTransient(errFailed)).To(BeFalse())
+	})
+
+	It("should not requeue the request on a terminal error", func() {
+		result, err := Result(Terminalf("invalid spec: %s", "replicas must be positive"))
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("replicas must be positive")))
+	})
+
+	It("should requeue the request after the delay of a transient error", func() {
+		result, err := Result(Transient(errFailed, time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+
+		result, err = Result(Transient(errFailed, 0))
+		Expect(err).To(MatchError(errFailed))
+		Expect(result).To(Equal(ctrl.Result{}))
+	})
+
+	It("should return the other errors as is", func() {
+		result, err := Result(errFailed)
+		Expect(err).To(Equal(errFailed))
+		Expect(result).To(Equal(ctrl.Result{}))
+
+		result, err = Result(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+	})
+})
+`
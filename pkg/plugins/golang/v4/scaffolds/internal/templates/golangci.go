@@ -26,6 +26,9 @@ var _ machinery.Template = &Golangci{}
 type Golangci struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+
+	// ControllersLayout indicates that the controllers are placed under the controllers directory
+	ControllersLayout bool
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -59,6 +62,12 @@ issues:
       linters:
         - dupl
         - lll
+{{- if .ControllersLayout }}
+    - path: "controllers/*"
+      linters:
+        - dupl
+        - lll
+{{- end }}
 linters:
   disable-all: true
   enable:
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hack
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &ConvertStorageVersion{}
+
+// ConvertStorageVersion scaffolds the script which migrates the objects of a CRD stored in etcd
+// to its storage version
+type ConvertStorageVersion struct {
+	machinery.TemplateMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *ConvertStorageVersion) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("hack", "convert-storage-version.sh")
+	}
+
+	f.TemplateBody = convertStorageVersionTemplate
+
+	f.IfExistsAction = machinery.SkipFile
+	f.FilePermissions = machinery.ExecutableFilePermission
+
+	return nil
+}
+
+const convertStorageVersionTemplate = `#!/usr/bin/env bash
+
+# Migrates the objects of a CRD stored in etcd to the storage version of the CRD, and then removes the
+# previous versions from the storedVersions of its status so that they can be dropped from the CRD.
+# Run it once the manager with the new storage version (and its conversion webhook) is deployed:
+#
+#   bash hack/convert-storage-version.sh <plural>.<group>
+#
+# Set KUBECTL to use another kubectl binary.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+CRD="${1:?usage: $0 <crd name, e.g. cronjobs.batch.example.com>}"
+KUBECTL="${KUBECTL:-kubectl}"
+
+STORAGE_VERSION="$(${KUBECTL} get crd "${CRD}" -o jsonpath='{.spec.versions[?(@.storage==true)].name}')"
+echo "Migrating the objects of ${CRD} to the storage version ${STORAGE_VERSION}"
+
+# Updating the objects without changes makes the API server store them with the storage version
+${KUBECTL} get "${CRD}" --all-namespaces \
+  -o jsonpath='{range .items[*]}{.metadata.namespace}{"/"}{.metadata.name}{"\n"}{end}' |
+while read -r object; do
+  namespace="${object%%/*}"
+  name="${object#*/}"
+  if [[ -n "${namespace}" ]]; then
+    ${KUBECTL} get "${CRD}" "${name}" -n "${namespace}" -o json | ${KUBECTL} replace -f -
+  else
+    ${KUBECTL} get "${CRD}" "${name}" -o json | ${KUBECTL} replace -f -
+  fi
+done
+
+${KUBECTL} patch crd "${CRD}" --subresource=status --type=merge \
+  -p "{\"status\":{\"storedVersions\":[\"${STORAGE_VERSION}\"]}}"
+echo "The objects of ${CRD} are stored with the version ${STORAGE_VERSION}"
+`
@@ -41,6 +41,21 @@ type Makefile struct {
 	ControllerRuntimeVersion string
 	// EnvtestVersion store the name of the verions to be used to install setup-envtest
 	EnvtestVersion string
+	// MultiGroupModules indicates that each API group is its own Go module under api/<group>
+	MultiGroupModules bool
+	// Chainsaw indicates that the e2e tests are declarative Chainsaw test suites
+	Chainsaw bool
+	// ChainsawVersion is the chainsaw version to use in the project
+	ChainsawVersion string
+	// WebhookOnly omits the targets which install the CRDs, which webhook-only projects do not have
+	WebhookOnly bool
+	// ToolMirror is the base URL of the mirror from which the tools are downloaded, if any
+	ToolMirror string
+	// Managers are the names of the additional managers, built from cmd/<name>/main.go
+	Managers []string
+	// WorkspaceRoot is the path of the root of the Go workspace (go.work) which holds the project, if any.
+	// It is the context of the image build
+	WorkspaceRoot string
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -88,6 +103,18 @@ endif
 # scaffolded by default. However, you might want to replace it to use other
 # tools. (i.e. podman)
 CONTAINER_TOOL ?= docker
+{{- if .WorkspaceRoot }}
+
+# DOCKER_BUILD_CONTEXT is the context of the image build. The project is a module of a Go workspace (go.work),
+# so the context is the root of the workspace, which holds the modules the project may depend on.
+DOCKER_BUILD_CONTEXT ?= {{ .WorkspaceRoot }}
+{{- end }}
+{{- if .MultiGroupModules }}
+
+# API_MODULES lists the directories of the Go modules which hold the APIs of each group.
+# They are not part of the root module, so the code generators need to run on them separately.
+API_MODULES = $(shell find api -mindepth 2 -maxdepth 2 -name go.mod -exec dirname {} \; 2>/dev/null)
+{{- end }}
 
 # Setting SHELL to bash allows bash commands to be executed by recipes.
 # Options are set to exit when a recipe line exits non-zero or a piped command fails.
@@ -119,6 +146,11 @@ help: ## Display this help.
 .PHONY: manifests
 manifests: controller-gen ## Generate WebhookConfiguration, ClusterRole and CustomResourceDefinition objects.
 	$(CONTROLLER_GEN) rbac:roleName=manager-role crd webhook paths="./..." output:crd:artifacts:config=config/crd/bases
+	{{- if .MultiGroupModules }}
+	@for mod in $(API_MODULES); do \
+		(cd $$mod && $(CONTROLLER_GEN) crd paths="./..." output:crd:artifacts:config=$(CURDIR)/config/crd/bases) || exit 1; \
+	done
+	{{- end }}
 
 .PHONY: generate
 generate: controller-gen ## Generate code containing DeepCopy, DeepCopyInto, and DeepCopyObject method implementations.
@@ -127,6 +159,11 @@ generate: controller-gen ## Generate code containing DeepCopy, DeepCopyInto, and
 	{{- else -}}
 	$(CONTROLLER_GEN) object paths="./..."
 	{{- end }}
+	{{- if .MultiGroupModules }}
+	@for mod in $(API_MODULES); do \
+		(cd $$mod && $(CONTROLLER_GEN) object{{ if .BoilerplatePath }}:headerFile="$(CURDIR)/{{ .BoilerplatePath }}"{{ end }} paths="./...") || exit 1; \
+	done
+	{{- end }}
 
 .PHONY: fmt
 fmt: ## Run go fmt against code.
@@ -138,23 +175,54 @@ vet: ## Run go vet against code.
 
 .PHONY: test
 test: manifests generate fmt vet setup-envtest ## Run tests.
-	KUBEBUILDER_ASSETS="$(shell $(ENVTEST) use $(ENVTEST_K8S_VERSION) --bin-dir $(LOCALBIN) -p path)" go test $$(go list ./... | grep -v /e2e) -coverprofile cover.out
+	KUBEBUILDER_ASSETS="$(shell $(ENVTEST) use $(ENVTEST_K8S_VERSION) --bin-dir $(LOCALBIN){{ if .ToolMirror }} --index $(ENVTEST_INDEX){{ end }} -p path)" go test $$(go list ./... | grep -v /e2e) -coverprofile cover.out
+
+{{ if .Chainsaw -}}
+# The Chainsaw e2e tests under 'test/e2e/chainsaw' run against the controller deployed on the Kind cluster
+# KIND_CLUSTER, which must be running. The Manager image E2E_IMG is built and loaded on the cluster,
+# and the project is undeployed after the tests.
+# CertManager must be installed on the cluster if the project uses it.
+KIND_CLUSTER ?= kind
+E2E_IMG ?= example.com/{{ .ProjectName }}:v0.0.1
 
-# TODO(user): To use a different vendor for e2e tests, modify the setup under 'tests/e2e'.
-# The default setup assumes Kind is pre-installed and builds/loads the Manager Docker image locally.
-# CertManager is installed by default; skip with:
-# - CERT_MANAGER_INSTALL_SKIP=true
 .PHONY: test-e2e
-test-e2e: manifests generate fmt vet ## Run the e2e tests. Expected an isolated environment using Kind.
+test-e2e: manifests generate fmt vet chainsaw ## Run the Chainsaw e2e tests. Expected an isolated environment using Kind.
 	@command -v kind >/dev/null 2>&1 || { \
 		echo "Kind is not installed. Please install Kind manually."; \
 		exit 1; \
 	}
-	@kind get clusters | grep -q 'kind' || { \
-		echo "No Kind cluster is running. Please start a Kind cluster before running the e2e tests."; \
+	@kind get clusters | grep -qx '$(KIND_CLUSTER)' || { \
+		echo "No Kind cluster named $(KIND_CLUSTER) is running. Please start it before running the e2e tests."; \
 		exit 1; \
 	}
-	go test ./test/e2e/ -v -ginkgo.v
+	$(MAKE) docker-build IMG=$(E2E_IMG)
+	kind load docker-image $(E2E_IMG) --name $(KIND_CLUSTER)
+	$(MAKE) {{ if not .WebhookOnly }}install {{ end }}deploy IMG=$(E2E_IMG)
+	$(CHAINSAW) test --test-dir test/e2e/chainsaw; status=$$?; \
+		$(MAKE) undeploy{{ if not .WebhookOnly }} uninstall{{ end }} ignore-not-found=true; \
+		exit $$status
+{{- else -}}
+# The e2e tests run on a cluster managed by the provider set in CLUSTER_PROVIDER:
+# - kind (default) or k3d: the cluster is created if it is not running and deleted after the tests.
+# - existing: the cluster of the current KUBECONFIG context is used, the image must be pushed to a registry.
+# The setup can be customized with:
+# - CLUSTER_NAME=<name>: name of the kind or k3d cluster
+# - SKIP_CLUSTER_CREATION=true / SKIP_CLUSTER_DELETION=true: reuse or keep the cluster
+# - IMG=<image>: image built and loaded to the cluster
+# - CERT_MANAGER_INSTALL_SKIP=true: skip the CertManager installation
+# - PROMETHEUS_INSTALL=true: install the Prometheus Operator
+CLUSTER_PROVIDER ?= kind
+
+.PHONY: test-e2e
+test-e2e: manifests generate fmt vet ## Run the e2e tests. Expected an isolated environment using Kind.
+	@if [ "$(CLUSTER_PROVIDER)" != "existing" ]; then \
+		command -v $(CLUSTER_PROVIDER) >/dev/null 2>&1 || { \
+			echo "$(CLUSTER_PROVIDER) is not installed. Please install $(CLUSTER_PROVIDER) manually."; \
+			exit 1; \
+		}; \
+	fi
+	CLUSTER_PROVIDER=$(CLUSTER_PROVIDER) go test ./test/e2e/ -v -ginkgo.v
+{{- end }}
 
 .PHONY: lint
 lint: golangci-lint ## Run golangci-lint linter
@@ -173,17 +241,26 @@ lint-config: golangci-lint ## Verify golangci-lint linter configuration
 .PHONY: build
 build: manifests generate fmt vet ## Build manager binary.
 	go build -o bin/manager cmd/main.go
+	{{- range .Managers }}
+	go build -o bin/{{ . }} cmd/{{ . }}/main.go
+	{{- end }}
 
 .PHONY: run
 run: manifests generate fmt vet ## Run a controller from your host.
 	go run ./cmd/main.go
+{{- range .Managers }}
+
+.PHONY: run-{{ . }}
+run-{{ . }}: manifests generate fmt vet ## Run the controllers of the {{ . }} manager from your host.
+	go run ./cmd/{{ . }}/main.go
+{{- end }}
 
 # If you wish to build the manager image targeting other platforms you can use the --platform flag.
 # (i.e. docker build --platform linux/arm64). However, you must enable docker buildKit for it.
 # More info: https://docs.docker.com/develop/develop-images/build_enhancements/
 .PHONY: docker-build
 docker-build: ## Build docker image with the manager.
-	$(CONTAINER_TOOL) build -t ${IMG} .
+	$(CONTAINER_TOOL) build -t ${IMG} {{ if .WorkspaceRoot }}-f Dockerfile $(DOCKER_BUILD_CONTEXT){{ else }}.{{ end }}
 
 .PHONY: docker-push
 docker-push: ## Push docker image with the manager.
@@ -195,16 +272,14 @@ docker-push: ## Push docker image with the manager.
 # - have enabled BuildKit. More info: https://docs.docker.com/develop/develop-images/build_enhancements/
 # - be able to push the image to your registry (i.e. if you do not set a valid value via IMG=<myregistry/image:<tag>> then the export will fail)
 # To adequately provide solutions that are compatible with multiple platforms, you should consider using this option.
+# The Dockerfile cross-compiles the manager for each platform, see the builder stage.
 PLATFORMS ?= linux/arm64,linux/amd64,linux/s390x,linux/ppc64le
 .PHONY: docker-buildx
 docker-buildx: ## Build and push docker image for the manager for cross-platform support
-	# copy existing Dockerfile and insert --platform=${BUILDPLATFORM} into Dockerfile.cross, and preserve the original Dockerfile
-	sed -e '1 s/\(^FROM\)/FROM --platform=\$$\{BUILDPLATFORM\}/; t' -e ' 1,// s//FROM --platform=\$$\{BUILDPLATFORM\}/' Dockerfile > Dockerfile.cross
 	- $(CONTAINER_TOOL) buildx create --name {{ .ProjectName }}-builder
 	$(CONTAINER_TOOL) buildx use {{ .ProjectName }}-builder
-	- $(CONTAINER_TOOL) buildx build --push --platform=$(PLATFORMS) --tag ${IMG} -f Dockerfile.cross .
+	- $(CONTAINER_TOOL) buildx build --push --platform=$(PLATFORMS) --tag ${IMG} -f Dockerfile {{ if .WorkspaceRoot }}$(DOCKER_BUILD_CONTEXT){{ else }}.{{ end }}
 	- $(CONTAINER_TOOL) buildx rm {{ .ProjectName }}-builder
-	rm Dockerfile.cross
 
 .PHONY: build-installer
 build-installer: manifests generate kustomize ## Generate a consolidated YAML with CRDs and deployment.
@@ -217,7 +292,7 @@ build-installer: manifests generate kustomize ## Generate a consolidated YAML wi
 ifndef ignore-not-found
   ignore-not-found = false
 endif
-
+{{ if not .WebhookOnly }}
 .PHONY: install
 install: manifests kustomize ## Install CRDs into the K8s cluster specified in ~/.kube/config.
 	$(KUSTOMIZE) build config/crd | $(KUBECTL) apply -f -
@@ -225,7 +300,7 @@ install: manifests kustomize ## Install CRDs into the K8s cluster specified in ~
 .PHONY: uninstall
 uninstall: manifests kustomize ## Uninstall CRDs from the K8s cluster specified in ~/.kube/config. Call with ignore-not-found=true to ignore resource not found errors during deletion.
 	$(KUSTOMIZE) build config/crd | $(KUBECTL) delete --ignore-not-found=$(ignore-not-found) -f -
-
+{{ end }}
 .PHONY: deploy
 deploy: manifests kustomize ## Deploy controller to the K8s cluster specified in ~/.kube/config.
 	cd config/manager && $(KUSTOMIZE) edit set image controller=${IMG}
@@ -248,6 +323,9 @@ KUSTOMIZE ?= $(LOCALBIN)/kustomize
 CONTROLLER_GEN ?= $(LOCALBIN)/controller-gen
 ENVTEST ?= $(LOCALBIN)/setup-envtest
 GOLANGCI_LINT = $(LOCALBIN)/golangci-lint
+{{- if .Chainsaw }}
+CHAINSAW ?= $(LOCALBIN)/chainsaw
+{{- end }}
 
 ## Tool Versions
 KUSTOMIZE_VERSION ?= {{ .KustomizeVersion }}
@@ -257,6 +335,18 @@ ENVTEST_VERSION ?= $(shell go list -m -f "{{ "{{ .Version }}" }}" sigs.k8s.io/co
 #ENVTEST_K8S_VERSION is the version of Kubernetes to use for setting up ENVTEST binaries (i.e. 1.31)
 ENVTEST_K8S_VERSION ?= $(shell go list -m -f "{{ "{{ .Version }}" }}" k8s.io/api | awk -F'[v.]' '{printf "1.%d", $$3}')
 GOLANGCI_LINT_VERSION ?= {{ .GolangciLintVersion }}
+{{- if .Chainsaw }}
+CHAINSAW_VERSION ?= {{ .ChainsawVersion }}
+{{- end }}
+{{- if .ToolMirror }}
+
+## Tool Mirror
+# The tools are downloaded from the mirror TOOL_MIRROR instead of the internet: the Go tools are installed
+# through its Go module proxy TOOL_GOPROXY, and the binaries of envtest are listed by its index ENVTEST_INDEX.
+TOOL_MIRROR ?= {{ .ToolMirror }}
+TOOL_GOPROXY ?= $(TOOL_MIRROR)/go
+ENVTEST_INDEX ?= $(TOOL_MIRROR)/envtest/envtest-releases.yaml
+{{- end }}
 
 .PHONY: kustomize
 kustomize: $(KUSTOMIZE) ## Download kustomize locally if necessary.
@@ -271,7 +361,7 @@ $(CONTROLLER_GEN): $(LOCALBIN)
 .PHONY: setup-envtest
 setup-envtest: envtest ## Download the binaries required for ENVTEST in the local bin directory.
 	@echo "Setting up envtest binaries for Kubernetes version $(ENVTEST_K8S_VERSION)..."
-	@$(ENVTEST) use $(ENVTEST_K8S_VERSION) --bin-dir $(LOCALBIN) -p path || { \
+	@$(ENVTEST) use $(ENVTEST_K8S_VERSION) --bin-dir $(LOCALBIN){{ if .ToolMirror }} --index $(ENVTEST_INDEX){{ end }} -p path || { \
 		echo "Error: Failed to set up envtest binaries for version $(ENVTEST_K8S_VERSION)."; \
 		exit 1; \
 	}
@@ -285,6 +375,13 @@ $(ENVTEST): $(LOCALBIN)
 golangci-lint: $(GOLANGCI_LINT) ## Download golangci-lint locally if necessary.
 $(GOLANGCI_LINT): $(LOCALBIN)
 	$(call go-install-tool,$(GOLANGCI_LINT),github.com/golangci/golangci-lint/cmd/golangci-lint,$(GOLANGCI_LINT_VERSION))
+{{- if .Chainsaw }}
+
+.PHONY: chainsaw
+chainsaw: $(CHAINSAW) ## Download chainsaw locally if necessary.
+$(CHAINSAW): $(LOCALBIN)
+	$(call go-install-tool,$(CHAINSAW),github.com/kyverno/chainsaw,$(CHAINSAW_VERSION))
+{{- end }}
 
 # go-install-tool will 'go install' any package with custom target and name of binary, if it doesn't exist
 # $1 - target path with name of binary
@@ -296,7 +393,7 @@ set -e; \
 package=$(2)@$(3) ;\
 echo "Downloading $${package}" ;\
 rm -f $(1) || true ;\
-GOBIN=$(LOCALBIN) go install $${package} ;\
+GOBIN=$(LOCALBIN) {{ if .ToolMirror }}GOPROXY=$(TOOL_GOPROXY) {{ end }}go install $${package} ;\
 mv $(1) $(1)-$(3) ;\
 } ;\
 ln -sf $(1)-$(3) $(1)
@@ -0,0 +1,266 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &Config{}
+
+// Config scaffolds the file that defines the ControllerManagerConfig type of the configuration file of the manager
+type Config struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.DomainMixin
+
+	// WithTracing scaffolds the options to export OpenTelemetry traces with OTLP
+	WithTracing bool
+
+	// WithPprof scaffolds the option which enables the pprof endpoint of the manager
+	WithPprof bool
+
+	// WithHAOptions scaffolds the options of the graceful shutdown and of the leader election lease
+	WithHAOptions bool
+
+	// WithCacheOptions scaffolds the options which restrict the objects cached by the manager
+	WithCacheOptions bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *Config) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("internal", "options", "config.go")
+	}
+
+	f.TemplateBody = configTemplate
+
+	return nil
+}
+
+const configTemplate = `{{ .Boilerplate }}
+
+package options
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConfigAPIVersion is the API version of the configuration file of the manager.
+	ConfigAPIVersion = "{{ if .Domain }}config.{{ .Domain }}{{ else }}config{{ end }}/v1alpha1"
+	// ConfigKind is the kind of the configuration file of the manager.
+	ConfigKind = "ControllerManagerConfig"
+)
+
+// ControllerManagerConfig is the configuration file of the manager, loaded with --config.
+// The options which are not set keep the values of their flags.
+type ControllerManagerConfig struct {
+	APIVersion string ` + "`json:\"apiVersion\"`" + `
+	Kind       string ` + "`json:\"kind\"`" + `
+
+	// Metrics configures the metrics endpoint.
+	Metrics MetricsConfig ` + "`json:\"metrics,omitempty\"`" + `
+	// Webhook configures the webhook server.
+	Webhook WebhookConfig ` + "`json:\"webhook,omitempty\"`" + `
+	// Health configures the health probe endpoint.
+	Health HealthConfig ` + "`json:\"health,omitempty\"`" + `
+	// LeaderElection configures the leader election.
+	LeaderElection LeaderElectionConfig ` + "`json:\"leaderElection,omitempty\"`" + `
+	{{- if .WithCacheOptions }}
+	// Cache configures the objects cached by the manager.
+	Cache CacheConfig ` + "`json:\"cache,omitempty\"`" + `
+	{{- end }}
+
+	// EnableHTTP2 enables HTTP/2 for the metrics and webhook servers.
+	EnableHTTP2 *bool ` + "`json:\"enableHTTP2,omitempty\"`" + `
+	{{- if .WithHAOptions }}
+	// GracefulShutdownTimeout is the duration given to the controllers and the servers to stop, e.g. 30s.
+	GracefulShutdownTimeout *string ` + "`json:\"gracefulShutdownTimeout,omitempty\"`" + `
+	{{- end }}
+	{{- if .WithPprof }}
+	// PprofBindAddress is the address the pprof endpoint binds to, empty or "0" disables it.
+	PprofBindAddress *string ` + "`json:\"pprofBindAddress,omitempty\"`" + `
+	{{- end }}
+	{{- if .WithTracing }}
+	// Tracing configures the export of the traces.
+	Tracing TracingConfig ` + "`json:\"tracing,omitempty\"`" + `
+	{{- end }}
+}
+
+// MetricsConfig configures the metrics endpoint.
+type MetricsConfig struct {
+	// BindAddress is the address the metrics endpoint binds to, "0" disables it.
+	BindAddress *string ` + "`json:\"bindAddress,omitempty\"`" + `
+	// Secure serves the metrics endpoint securely via HTTPS.
+	Secure *bool ` + "`json:\"secure,omitempty\"`" + `
+	// CertPath is the directory that contains the metrics server certificate.
+	CertPath *string ` + "`json:\"certPath,omitempty\"`" + `
+	// CertName is the name of the metrics server certificate file.
+	CertName *string ` + "`json:\"certName,omitempty\"`" + `
+	// CertKey is the name of the metrics server key file.
+	CertKey *string ` + "`json:\"certKey,omitempty\"`" + `
+}
+
+// WebhookConfig configures the webhook server.
+type WebhookConfig struct {
+	// Port is the port the webhook server listens on.
+	Port *int ` + "`json:\"port,omitempty\"`" + `
+	// CertPath is the directory that contains the webhook certificate.
+	CertPath *string ` + "`json:\"certPath,omitempty\"`" + `
+	// CertName is the name of the webhook certificate file.
+	CertName *string ` + "`json:\"certName,omitempty\"`" + `
+	// CertKey is the name of the webhook key file.
+	CertKey *string ` + "`json:\"certKey,omitempty\"`" + `
+}
+
+// HealthConfig configures the health probe endpoint.
+type HealthConfig struct {
+	// HealthProbeBindAddress is the address the health probe endpoint binds to.
+	HealthProbeBindAddress *string ` + "`json:\"healthProbeBindAddress,omitempty\"`" + `
+}
+
+// LeaderElectionConfig configures the leader election.
+type LeaderElectionConfig struct {
+	// LeaderElect ensures there is only one active manager.
+	LeaderElect *bool ` + "`json:\"leaderElect,omitempty\"`" + `
+	// ResourceName is the name of the resource used as lock for the leader election.
+	ResourceName *string ` + "`json:\"resourceName,omitempty\"`" + `
+	{{- if .WithHAOptions }}
+	// LeaseDuration is the duration the non-leader candidates wait before forcing to acquire the leadership.
+	LeaseDuration *string ` + "`json:\"leaseDuration,omitempty\"`" + `
+	// RenewDeadline is the duration the leader retries to refresh the leadership before giving it up.
+	RenewDeadline *string ` + "`json:\"renewDeadline,omitempty\"`" + `
+	// RetryPeriod is the duration the candidates wait between the actions of the leader election.
+	RetryPeriod *string ` + "`json:\"retryPeriod,omitempty\"`" + `
+	{{- end }}
+}
+{{- if .WithTracing }}
+
+// TracingConfig configures the export of the traces.
+type TracingConfig struct {
+	// OTLPEndpoint is the OTLP gRPC endpoint (host:port) the traces are exported to.
+	OTLPEndpoint *string ` + "`json:\"otlpEndpoint,omitempty\"`" + `
+	// OTLPInsecure exports the traces to the OTLP endpoint without TLS.
+	OTLPInsecure *bool ` + "`json:\"otlpInsecure,omitempty\"`" + `
+}
+{{- end }}
+{{- if .WithCacheOptions }}
+
+// CacheConfig configures the objects cached by the manager.
+type CacheConfig struct {
+	// Namespaces are the namespaces cached by the manager, as a comma-separated list.
+	Namespaces *string ` + "`json:\"namespaces,omitempty\"`" + `
+	// LabelSelector is the label selector of the Secrets and ConfigMaps cached by the manager.
+	LabelSelector *string ` + "`json:\"labelSelector,omitempty\"`" + `
+}
+{{- end }}
+
+// LoadConfigFile loads the configuration file informed with --config into the Options. The values
+// of the file replace the defaults of the flags, but the flags set on the command line take precedence.
+func (o *Options) LoadConfigFile(fs *flag.FlagSet) error {
+	if o.ConfigFile == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(o.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("unable to read the configuration file: %w", err)
+	}
+	cfg := ControllerManagerConfig{}
+	if err := yaml.UnmarshalStrict(content, &cfg); err != nil {
+		return fmt.Errorf("unable to decode the configuration file: %w", err)
+	}
+	if cfg.APIVersion != ConfigAPIVersion || cfg.Kind != ConfigKind {
+		return fmt.Errorf("unsupported configuration file %s/%s: expected %s/%s",
+			cfg.APIVersion, cfg.Kind, ConfigAPIVersion, ConfigKind)
+	}
+
+	setFlags := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = true
+	})
+	// The values of the file are set through the flags, so that they are parsed as the flags are
+	for name, value := range cfg.flagValues() {
+		if setFlags[name] {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("invalid value %q of the option %s of the configuration file: %w", value, name, err)
+		}
+	}
+	return nil
+}
+
+// flagValues returns the values set in the configuration file, indexed by the name of their flag.
+func (c ControllerManagerConfig) flagValues() map[string]string {
+	values := map[string]string{}
+	setString := func(name string, value *string) {
+		if value != nil {
+			values[name] = *value
+		}
+	}
+	setBool := func(name string, value *bool) {
+		if value != nil {
+			values[name] = strconv.FormatBool(*value)
+		}
+	}
+
+	setString("metrics-bind-address", c.Metrics.BindAddress)
+	setBool("metrics-secure", c.Metrics.Secure)
+	setString("metrics-cert-path", c.Metrics.CertPath)
+	setString("metrics-cert-name", c.Metrics.CertName)
+	setString("metrics-cert-key", c.Metrics.CertKey)
+	if c.Webhook.Port != nil {
+		values["webhook-port"] = strconv.Itoa(*c.Webhook.Port)
+	}
+	setString("webhook-cert-path", c.Webhook.CertPath)
+	setString("webhook-cert-name", c.Webhook.CertName)
+	setString("webhook-cert-key", c.Webhook.CertKey)
+	setString("health-probe-bind-address", c.Health.HealthProbeBindAddress)
+	setBool("leader-elect", c.LeaderElection.LeaderElect)
+	setString("leader-election-id", c.LeaderElection.ResourceName)
+	{{- if .WithHAOptions }}
+	setString("leader-election-lease-duration", c.LeaderElection.LeaseDuration)
+	setString("leader-election-renew-deadline", c.LeaderElection.RenewDeadline)
+	setString("leader-election-retry-period", c.LeaderElection.RetryPeriod)
+	{{- end }}
+	{{- if .WithCacheOptions }}
+	setString("cache-namespaces", c.Cache.Namespaces)
+	setString("cache-label-selector", c.Cache.LabelSelector)
+	{{- end }}
+	setBool("enable-http2", c.EnableHTTP2)
+	{{- if .WithHAOptions }}
+	setString("graceful-shutdown-timeout", c.GracefulShutdownTimeout)
+	{{- end }}
+	{{- if .WithPprof }}
+	setString("pprof-bind-address", c.PprofBindAddress)
+	{{- end }}
+	{{- if .WithTracing }}
+	setString("otlp-endpoint", c.Tracing.OTLPEndpoint)
+	setBool("otlp-insecure", c.Tracing.OTLPInsecure)
+	{{- end }}
+	return values
+}
+`
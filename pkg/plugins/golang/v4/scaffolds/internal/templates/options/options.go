@@ -0,0 +1,273 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &Options{}
+
+// Options scaffolds the file that parses the flags of the manager into a typed struct
+type Options struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.DomainMixin
+	machinery.RepositoryMixin
+
+	// WithTracing scaffolds the flags to export OpenTelemetry traces with OTLP
+	WithTracing bool
+
+	// WithPprof scaffolds the flag which enables the pprof endpoint of the manager
+	WithPprof bool
+
+	// WithHAOptions scaffolds the flags of the graceful shutdown and of the leader election lease
+	WithHAOptions bool
+
+	// WithCacheOptions scaffolds the flags which restrict the objects cached by the manager
+	WithCacheOptions bool
+
+	// WithComponentConfig scaffolds the flag of the configuration file of the manager
+	WithComponentConfig bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *Options) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("internal", "options", "options.go")
+	}
+
+	f.TemplateBody = optionsTemplate
+
+	return nil
+}
+
+const optionsTemplate = `{{ .Boilerplate }}
+
+package options
+
+import (
+	"flag"
+	"fmt"
+	{{- if .WithHAOptions }}
+	"time"
+	{{- end }}
+
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// Options are the options of the manager, which are parsed from its command line flags.
+// Add the options of your project here, so that cmd/main.go can be scaffolded again
+// without losing them.
+type Options struct {
+	{{- if .WithComponentConfig }}
+	// ConfigFile is the path to the configuration file of the manager, see ControllerManagerConfig.
+	ConfigFile string
+	{{ end }}
+	// MetricsBindAddress is the address the metrics endpoint binds to, "0" disables it.
+	MetricsBindAddress string
+	// SecureMetrics serves the metrics endpoint securely via HTTPS.
+	SecureMetrics bool
+	// MetricsCertPath is the directory that contains the metrics server certificate.
+	MetricsCertPath string
+	// MetricsCertName is the name of the metrics server certificate file.
+	MetricsCertName string
+	// MetricsCertKey is the name of the metrics server key file.
+	MetricsCertKey string
+
+	// WebhookPort is the port the webhook server listens on.
+	WebhookPort int
+	// WebhookCertPath is the directory that contains the webhook certificate.
+	WebhookCertPath string
+	// WebhookCertName is the name of the webhook certificate file.
+	WebhookCertName string
+	// WebhookCertKey is the name of the webhook key file.
+	WebhookCertKey string
+
+	// ProbeBindAddress is the address the health probe endpoint binds to.
+	ProbeBindAddress string
+	{{- if .WithCacheOptions }}
+
+	// CacheNamespaces are the namespaces cached by the manager, as a comma-separated list.
+	CacheNamespaces string
+	// CacheLabelSelector is the label selector of the Secrets and ConfigMaps cached by the manager.
+	CacheLabelSelector string
+	{{- end }}
+
+	// LeaderElection ensures there is only one active manager.
+	LeaderElection bool
+	// LeaderElectionID is the name of the resource used as lock for the leader election.
+	LeaderElectionID string
+	{{- if .WithHAOptions }}
+	// LeaseDuration is the duration the non-leader candidates wait before forcing to acquire the leadership.
+	LeaseDuration time.Duration
+	// RenewDeadline is the duration the leader retries to refresh the leadership before giving it up.
+	RenewDeadline time.Duration
+	// RetryPeriod is the duration the candidates wait between the actions of the leader election.
+	RetryPeriod time.Duration
+	{{- end }}
+
+	// EnableHTTP2 enables HTTP/2 for the metrics and webhook servers.
+	EnableHTTP2 bool
+	{{- if .WithHAOptions }}
+
+	// GracefulShutdownTimeout is the duration given to the controllers and the servers to stop once the
+	// manager is signaled to terminate. A negative duration waits for them indefinitely.
+	GracefulShutdownTimeout time.Duration
+	{{- end }}
+	{{- if .WithPprof }}
+
+	// PprofBindAddress is the address the pprof endpoint binds to, empty or "0" disables it.
+	PprofBindAddress string
+	{{- end }}
+	{{- if .WithTracing }}
+
+	// OTLPEndpoint is the OTLP gRPC endpoint (host:port) the traces are exported to.
+	OTLPEndpoint string
+	// OTLPInsecure exports the traces to the OTLP endpoint without TLS.
+	OTLPInsecure bool
+	{{- end }}
+
+	// Zap are the options of the logger.
+	Zap zap.Options
+}
+
+// New returns the Options with their default values.
+func New() *Options {
+	return &Options{
+		MetricsBindAddress: "0",
+		SecureMetrics:      true,
+		MetricsCertName:    "tls.crt",
+		MetricsCertKey:     "tls.key",
+		WebhookPort:        9443,
+		WebhookCertName:    "tls.crt",
+		WebhookCertKey:     "tls.key",
+		ProbeBindAddress:   ":8081",
+		{{- if not .Domain }}
+		LeaderElectionID:   "{{ hashFNV .Repo }}",
+		{{- else }}
+		LeaderElectionID:   "{{ hashFNV .Repo }}.{{ .Domain }}",
+		{{- end }}
+		{{- if .WithHAOptions }}
+
+		// The defaults of controller-runtime for the leader election, and a graceful shutdown timeout
+		// below the terminationGracePeriodSeconds of 10s of the Pod of the manager
+		LeaseDuration:           15 * time.Second,
+		RenewDeadline:           10 * time.Second,
+		RetryPeriod:             2 * time.Second,
+		GracefulShutdownTimeout: 8 * time.Second,
+		{{- end }}
+		Zap: zap.Options{
+			Development: true,
+		},
+	}
+}
+
+// BindFlags binds the Options to the flags of the flag set, using their current values as defaults.
+func (o *Options) BindFlags(fs *flag.FlagSet) {
+	{{- if .WithComponentConfig }}
+	fs.StringVar(&o.ConfigFile, "config", o.ConfigFile,
+		"The path to the configuration file of the manager. Its options replace the defaults of the flags, "+
+			"but the flags set on the command line take precedence.")
+	{{- end }}
+	fs.StringVar(&o.MetricsBindAddress, "metrics-bind-address", o.MetricsBindAddress,
+		"The address the metrics endpoint binds to. "+
+			"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
+	fs.BoolVar(&o.SecureMetrics, "metrics-secure", o.SecureMetrics,
+		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
+	fs.StringVar(&o.MetricsCertPath, "metrics-cert-path", o.MetricsCertPath,
+		"The directory that contains the metrics server certificate.")
+	fs.StringVar(&o.MetricsCertName, "metrics-cert-name", o.MetricsCertName,
+		"The name of the metrics server certificate file.")
+	fs.StringVar(&o.MetricsCertKey, "metrics-cert-key", o.MetricsCertKey, "The name of the metrics server key file.")
+	fs.IntVar(&o.WebhookPort, "webhook-port", o.WebhookPort, "The port the webhook server listens on.")
+	fs.StringVar(&o.WebhookCertPath, "webhook-cert-path", o.WebhookCertPath,
+		"The directory that contains the webhook certificate.")
+	fs.StringVar(&o.WebhookCertName, "webhook-cert-name", o.WebhookCertName, "The name of the webhook certificate file.")
+	fs.StringVar(&o.WebhookCertKey, "webhook-cert-key", o.WebhookCertKey, "The name of the webhook key file.")
+	fs.StringVar(&o.ProbeBindAddress, "health-probe-bind-address", o.ProbeBindAddress,
+		"The address the probe endpoint binds to.")
+	{{- if .WithCacheOptions }}
+	fs.StringVar(&o.CacheNamespaces, "cache-namespaces", o.CacheNamespaces,
+		"The comma-separated list of the namespaces whose objects are cached. Leave it empty to cache all of them.")
+	fs.StringVar(&o.CacheLabelSelector, "cache-label-selector", o.CacheLabelSelector,
+		"The label selector of the Secrets and ConfigMaps which are cached, "+
+			"e.g. app.kubernetes.io/managed-by=my-operator. Leave it empty to cache all of them.")
+	{{- end }}
+	fs.BoolVar(&o.LeaderElection, "leader-elect", o.LeaderElection,
+		"Enable leader election for controller manager. "+
+			"Enabling this will ensure there is only one active controller manager.")
+	fs.StringVar(&o.LeaderElectionID, "leader-election-id", o.LeaderElectionID,
+		"The name of the resource used as lock for the leader election.")
+	{{- if .WithHAOptions }}
+	fs.DurationVar(&o.LeaseDuration, "leader-election-lease-duration", o.LeaseDuration,
+		"The duration the non-leader candidates wait before forcing to acquire the leadership.")
+	fs.DurationVar(&o.RenewDeadline, "leader-election-renew-deadline", o.RenewDeadline,
+		"The duration the leader retries to refresh the leadership before giving it up.")
+	fs.DurationVar(&o.RetryPeriod, "leader-election-retry-period", o.RetryPeriod,
+		"The duration the candidates wait between the actions of the leader election.")
+	{{- end }}
+	fs.BoolVar(&o.EnableHTTP2, "enable-http2", o.EnableHTTP2,
+		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	{{- if .WithHAOptions }}
+	fs.DurationVar(&o.GracefulShutdownTimeout, "graceful-shutdown-timeout", o.GracefulShutdownTimeout,
+		"The duration given to the controllers and the servers to stop once the manager is signaled to terminate. "+
+			"Keep it below the terminationGracePeriodSeconds of the Pod.")
+	{{- end }}
+	{{- if .WithPprof }}
+	fs.StringVar(&o.PprofBindAddress, "pprof-bind-address", o.PprofBindAddress,
+		"The address the pprof endpoint binds to, e.g. 127.0.0.1:8082. Leave it empty to disable it.")
+	{{- end }}
+	{{- if .WithTracing }}
+	fs.StringVar(&o.OTLPEndpoint, "otlp-endpoint", o.OTLPEndpoint,
+		"The OTLP gRPC endpoint (host:port) the traces are exported to. Leave it empty to disable tracing.")
+	fs.BoolVar(&o.OTLPInsecure, "otlp-insecure", o.OTLPInsecure,
+		"If set, the traces are exported to the OTLP endpoint without TLS.")
+	{{- end }}
+	o.Zap.BindFlags(fs)
+}
+
+// Validate checks the Options parsed from the flags.
+func (o *Options) Validate() error {
+	if o.WebhookPort < 1 || o.WebhookPort > 65535 {
+		return fmt.Errorf("invalid webhook port %d: it must be between 1 and 65535", o.WebhookPort)
+	}
+	if o.LeaderElection && o.LeaderElectionID == "" {
+		return fmt.Errorf("the leader election ID is required when the leader election is enabled")
+	}
+	{{- if .WithHAOptions }}
+	// The leader election of client-go fails to start unless the lease duration is greater than
+	// the renew deadline, which is greater than the retry period with its jitter
+	if o.LeaderElection {
+		if o.RetryPeriod <= 0 {
+			return fmt.Errorf("invalid leader election retry period %s: it must be positive", o.RetryPeriod)
+		}
+		if float64(o.RenewDeadline) <= 1.2*float64(o.RetryPeriod) {
+			return fmt.Errorf("invalid leader election renew deadline %s: it must be greater than 1.2 times "+
+				"the retry period %s", o.RenewDeadline, o.RetryPeriod)
+		}
+		if o.LeaseDuration <= o.RenewDeadline {
+			return fmt.Errorf("invalid leader election lease duration %s: it must be greater than "+
+				"the renew deadline %s", o.LeaseDuration, o.RenewDeadline)
+		}
+	}
+	{{- end }}
+	return nil
+}
+`
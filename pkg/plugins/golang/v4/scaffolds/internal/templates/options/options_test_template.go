@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &OptionsTest{}
+
+// OptionsTest scaffolds the file that tests the parsing of the flags of the manager
+type OptionsTest struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.DomainMixin
+
+	// WithComponentConfig scaffolds the tests of the configuration file of the manager
+	WithComponentConfig bool
+
+	// WithHAOptions scaffolds the tests of the graceful shutdown and of the leader election lease options
+	WithHAOptions bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *OptionsTest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("internal", "options", "options_test.go")
+	}
+
+	f.TemplateBody = optionsTestTemplate
+
+	return nil
+}
+
+const optionsTestTemplate = `{{ .Boilerplate }}
+
+package options
+
+import (
+	"flag"
+	{{- if .WithComponentConfig }}
+	"os"
+	"path/filepath"
+	{{- end }}
+	{{- if .WithHAOptions }}
+	"time"
+	{{- end }}
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Options", func() {
+	var (
+		opts *Options
+		fs   *flag.FlagSet
+	)
+
+	BeforeEach(func() {
+		opts = New()
+		fs = flag.NewFlagSet("manager", flag.ContinueOnError)
+		opts.BindFlags(fs)
+	})
+
+	It("should keep the default values when no flag is set", func() {
+		Expect(fs.Parse(nil)).To(Succeed())
+		Expect(opts).To(Equal(New()))
+		Expect(opts.Validate()).To(Succeed())
+	})
+
+	It("should parse the flags", func() {
+		Expect(fs.Parse([]string{
+			"--metrics-bind-address=:8443",
+			"--metrics-secure=false",
+			"--webhook-port=9444",
+			"--health-probe-bind-address=:8082",
+			"--leader-elect",
+			"--leader-election-id=example",
+		})).To(Succeed())
+
+		Expect(opts.MetricsBindAddress).To(Equal(":8443"))
+		Expect(opts.SecureMetrics).To(BeFalse())
+		Expect(opts.WebhookPort).To(Equal(9444))
+		Expect(opts.ProbeBindAddress).To(Equal(":8082"))
+		Expect(opts.LeaderElection).To(BeTrue())
+		Expect(opts.LeaderElectionID).To(Equal("example"))
+		Expect(opts.Validate()).To(Succeed())
+	})
+
+	It("should bind the flags of the logger", func() {
+		Expect(fs.Parse([]string{"--zap-devel=false"})).To(Succeed())
+		Expect(opts.Zap.Development).To(BeFalse())
+	})
+
+	It("should fail to validate an invalid webhook port", func() {
+		Expect(fs.Parse([]string{"--webhook-port=0"})).To(Succeed())
+		Expect(opts.Validate()).NotTo(Succeed())
+	})
+
+	It("should fail to validate the leader election without ID", func() {
+		Expect(fs.Parse([]string{"--leader-elect", "--leader-election-id="})).To(Succeed())
+		Expect(opts.Validate()).NotTo(Succeed())
+	})
+	{{- if .WithHAOptions }}
+
+	It("should parse the graceful shutdown timeout and the leader election lease", func() {
+		Expect(fs.Parse([]string{
+			"--graceful-shutdown-timeout=1m",
+			"--leader-elect",
+			"--leader-election-lease-duration=60s",
+			"--leader-election-renew-deadline=40s",
+			"--leader-election-retry-period=5s",
+		})).To(Succeed())
+
+		Expect(opts.GracefulShutdownTimeout).To(Equal(time.Minute))
+		Expect(opts.LeaseDuration).To(Equal(60 * time.Second))
+		Expect(opts.RenewDeadline).To(Equal(40 * time.Second))
+		Expect(opts.RetryPeriod).To(Equal(5 * time.Second))
+		Expect(opts.Validate()).To(Succeed())
+	})
+
+	It("should fail to validate a lease duration shorter than the renew deadline", func() {
+		Expect(fs.Parse([]string{"--leader-elect", "--leader-election-lease-duration=5s"})).To(Succeed())
+		Expect(opts.Validate()).NotTo(Succeed())
+	})
+
+	It("should fail to validate a renew deadline too short for the retry period", func() {
+		Expect(fs.Parse([]string{"--leader-elect", "--leader-election-retry-period=9s"})).To(Succeed())
+		Expect(opts.Validate()).NotTo(Succeed())
+	})
+
+	It("should not validate the leader election lease when the leader election is disabled", func() {
+		Expect(fs.Parse([]string{"--leader-election-retry-period=0"})).To(Succeed())
+		Expect(opts.Validate()).To(Succeed())
+	})
+	{{- end }}
+	{{- if .WithComponentConfig }}
+
+	Context("with a configuration file", func() {
+		writeConfig := func(content string) string {
+			path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+			Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+			return path
+		}
+
+		It("should load the options of the file", func() {
+			path := writeConfig(` + "`" + `apiVersion: {{ if .Domain }}config.{{ .Domain }}{{ else }}config{{ end }}/v1alpha1
+kind: ControllerManagerConfig
+health:
+  healthProbeBindAddress: :8082
+webhook:
+  port: 9444
+leaderElection:
+  leaderElect: true
+` + "`" + `)
+			Expect(fs.Parse([]string{"--config=" + path})).To(Succeed())
+			Expect(opts.LoadConfigFile(fs)).To(Succeed())
+
+			Expect(opts.ProbeBindAddress).To(Equal(":8082"))
+			Expect(opts.WebhookPort).To(Equal(9444))
+			Expect(opts.LeaderElection).To(BeTrue())
+			Expect(opts.MetricsBindAddress).To(Equal(New().MetricsBindAddress))
+			Expect(opts.Validate()).To(Succeed())
+		})
+
+		{{- if .WithHAOptions }}
+
+		It("should load the graceful shutdown timeout and the leader election lease of the file", func() {
+			path := writeConfig(` + "`" + `apiVersion: {{ if .Domain }}config.{{ .Domain }}{{ else }}config{{ end }}/v1alpha1
+kind: ControllerManagerConfig
+gracefulShutdownTimeout: 1m
+leaderElection:
+  leaderElect: true
+  leaseDuration: 60s
+  renewDeadline: 40s
+  retryPeriod: 5s
+` + "`" + `)
+			Expect(fs.Parse([]string{"--config=" + path})).To(Succeed())
+			Expect(opts.LoadConfigFile(fs)).To(Succeed())
+
+			Expect(opts.GracefulShutdownTimeout).To(Equal(time.Minute))
+			Expect(opts.LeaseDuration).To(Equal(60 * time.Second))
+			Expect(opts.RenewDeadline).To(Equal(40 * time.Second))
+			Expect(opts.RetryPeriod).To(Equal(5 * time.Second))
+			Expect(opts.Validate()).To(Succeed())
+		})
+		{{- end }}
+
+		It("should give precedence to the flags set on the command line", func() {
+			path := writeConfig(` + "`" + `apiVersion: {{ if .Domain }}config.{{ .Domain }}{{ else }}config{{ end }}/v1alpha1
+kind: ControllerManagerConfig
+webhook:
+  port: 9444
+` + "`" + `)
+			Expect(fs.Parse([]string{"--config=" + path, "--webhook-port=9445"})).To(Succeed())
+			Expect(opts.LoadConfigFile(fs)).To(Succeed())
+			Expect(opts.WebhookPort).To(Equal(9445))
+		})
+
+		It("should fail to load a file of another kind", func() {
+			path := writeConfig(` + "`" + `apiVersion: v1
+kind: ConfigMap
+` + "`" + `)
+			Expect(fs.Parse([]string{"--config=" + path})).To(Succeed())
+			Expect(opts.LoadConfigFile(fs)).NotTo(Succeed())
+		})
+
+		It("should fail to load a file with unknown options", func() {
+			path := writeConfig(` + "`" + `apiVersion: {{ if .Domain }}config.{{ .Domain }}{{ else }}config{{ end }}/v1alpha1
+kind: ControllerManagerConfig
+unknown: true
+` + "`" + `)
+			Expect(fs.Parse([]string{"--config=" + path})).To(Succeed())
+			Expect(opts.LoadConfigFile(fs)).NotTo(Succeed())
+		})
+	})
+	{{- end }}
+})
+`
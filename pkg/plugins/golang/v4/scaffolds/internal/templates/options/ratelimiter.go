@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &RateLimiter{}
+
+// RateLimiter scaffolds the file that defines the options of the rate limiter of the controllers
+type RateLimiter struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *RateLimiter) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("internal", "options", "ratelimiter.go")
+	}
+
+	f.TemplateBody = rateLimiterTemplate
+
+	return nil
+}
+
+const rateLimiterTemplate = `{{ .Boilerplate }}
+
+package options
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// RateLimiterOptions are the options of the rate limiter of the workqueues of the controllers, which
+// delays the requeue of the requests after an error or a requeue.
+type RateLimiterOptions struct {
+	// BaseDelay is the delay of the first requeue of a request, doubled for each of its consecutive failures.
+	BaseDelay time.Duration
+	// MaxDelay is the maximum delay of the requeue of a request.
+	MaxDelay time.Duration
+	// QPS is the overall number of requeues per second allowed for a controller.
+	QPS float64
+	// Burst is the number of requeues allowed above the QPS in a burst.
+	Burst int
+}
+
+// NewRateLimiterOptions returns the RateLimiterOptions with the defaults of controller-runtime.
+func NewRateLimiterOptions() RateLimiterOptions {
+	return RateLimiterOptions{
+		BaseDelay: 5 * time.Millisecond,
+		MaxDelay:  1000 * time.Second,
+		QPS:       10,
+		Burst:     100,
+	}
+}
+
+// BindFlags binds the RateLimiterOptions to the flags of the flag set, using their current values as defaults.
+func (o *RateLimiterOptions) BindFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&o.BaseDelay, "rate-limiter-base-delay", o.BaseDelay,
+		"The delay of the first requeue of a request, doubled for each of its consecutive failures.")
+	fs.DurationVar(&o.MaxDelay, "rate-limiter-max-delay", o.MaxDelay,
+		"The maximum delay of the requeue of a request.")
+	fs.Float64Var(&o.QPS, "rate-limiter-qps", o.QPS,
+		"The overall number of requeues per second allowed for a controller.")
+	fs.IntVar(&o.Burst, "rate-limiter-burst", o.Burst,
+		"The number of requeues allowed above the rate limiter QPS in a burst.")
+}
+
+// Validate checks the RateLimiterOptions parsed from the flags.
+func (o *RateLimiterOptions) Validate() error {
+	if o.BaseDelay <= 0 || o.MaxDelay < o.BaseDelay {
+		return fmt.Errorf("invalid rate limiter delays: the base delay %s must be positive "+
+			"and not greater than the max delay %s", o.BaseDelay, o.MaxDelay)
+	}
+	if o.QPS <= 0 || o.Burst < 1 {
+		return fmt.Errorf("invalid rate limiter bucket: the QPS %v and the burst %d must be positive", o.QPS, o.Burst)
+	}
+	return nil
+}
+
+// NewRateLimiter returns a rate limiter for the workqueue of a controller. Like the default rate limiter
+// of controller-runtime, it delays each request with the longest of an exponential backoff per request
+// and of an overall token bucket. Each controller must be given its own rate limiter.
+func (o *RateLimiterOptions) NewRateLimiter() workqueue.TypedRateLimiter[reconcile.Request] {
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](o.BaseDelay, o.MaxDelay),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(o.QPS), o.Burst)},
+	)
+}
+`
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &RateLimiterTest{}
+
+// RateLimiterTest scaffolds the file that tests the options of the rate limiter and its requeue behavior
+type RateLimiterTest struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *RateLimiterTest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("internal", "options", "ratelimiter_test.go")
+	}
+
+	f.TemplateBody = rateLimiterTestTemplate
+
+	return nil
+}
+
+const rateLimiterTestTemplate = `{{ .Boilerplate }}
+
+package options
+
+import (
+	"flag"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("RateLimiterOptions", func() {
+	var (
+		opts RateLimiterOptions
+		fs   *flag.FlagSet
+	)
+
+	BeforeEach(func() {
+		opts = NewRateLimiterOptions()
+		fs = flag.NewFlagSet("manager", flag.ContinueOnError)
+		opts.BindFlags(fs)
+	})
+
+	It("should keep the default values when no flag is set", func() {
+		Expect(fs.Parse(nil)).To(Succeed())
+		Expect(opts).To(Equal(NewRateLimiterOptions()))
+		Expect(opts.Validate()).To(Succeed())
+	})
+
+	It("should parse the flags", func() {
+		Expect(fs.Parse([]string{
+			"--rate-limiter-base-delay=10ms",
+			"--rate-limiter-max-delay=5m",
+			"--rate-limiter-qps=50",
+			"--rate-limiter-burst=500",
+		})).To(Succeed())
+
+		Expect(opts.BaseDelay).To(Equal(10 * time.Millisecond))
+		Expect(opts.MaxDelay).To(Equal(5 * time.Minute))
+		Expect(opts.QPS).To(Equal(50.0))
+		Expect(opts.Burst).To(Equal(500))
+		Expect(opts.Validate()).To(Succeed())
+	})
+
+	It("should fail to validate a max delay lower than the base delay", func() {
+		Expect(fs.Parse([]string{"--rate-limiter-base-delay=1s", "--rate-limiter-max-delay=10ms"})).To(Succeed())
+		Expect(opts.Validate()).NotTo(Succeed())
+	})
+
+	It("should fail to validate a bucket which does not allow any requeue", func() {
+		Expect(fs.Parse([]string{"--rate-limiter-qps=0"})).To(Succeed())
+		Expect(opts.Validate()).NotTo(Succeed())
+	})
+
+	Context("when requeuing the requests", func() {
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test"}}
+
+		BeforeEach(func() {
+			// The bucket is large enough to not delay the requests
+			opts.QPS, opts.Burst = 1000, 1000
+			opts.BaseDelay, opts.MaxDelay = 10*time.Millisecond, 40*time.Millisecond
+		})
+
+		It("should back off exponentially up to the max delay", func() {
+			rateLimiter := opts.NewRateLimiter()
+			Expect(rateLimiter.When(request)).To(Equal(10 * time.Millisecond))
+			Expect(rateLimiter.When(request)).To(Equal(20 * time.Millisecond))
+			Expect(rateLimiter.When(request)).To(Equal(40 * time.Millisecond))
+			Expect(rateLimiter.When(request)).To(Equal(40 * time.Millisecond))
+			Expect(rateLimiter.NumRequeues(request)).To(Equal(4))
+		})
+
+		It("should reset the backoff of a request once it is forgotten", func() {
+			rateLimiter := opts.NewRateLimiter()
+			rateLimiter.When(request)
+			rateLimiter.When(request)
+			rateLimiter.Forget(request)
+			Expect(rateLimiter.NumRequeues(request)).To(BeZero())
+			Expect(rateLimiter.When(request)).To(Equal(10 * time.Millisecond))
+		})
+
+		It("should back off each request independently", func() {
+			other := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "other"}}
+			rateLimiter := opts.NewRateLimiter()
+			rateLimiter.When(request)
+			rateLimiter.When(request)
+			Expect(rateLimiter.When(other)).To(Equal(10 * time.Millisecond))
+		})
+
+		It("should limit the overall rate of the requeues with the bucket", func() {
+			opts.QPS, opts.Burst = 1, 1
+			other := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "other"}}
+			rateLimiter := opts.NewRateLimiter()
+			Expect(rateLimiter.When(request)).To(Equal(10 * time.Millisecond))
+			Expect(rateLimiter.When(other)).To(BeNumerically(">", 500*time.Millisecond))
+		})
+	})
+})
+`
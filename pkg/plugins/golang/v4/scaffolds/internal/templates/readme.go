@@ -35,6 +35,9 @@ type Readme struct {
 
 	// CommandName stores the name of the bin used
 	CommandName string
+
+	// WebhookOnly omits the steps for the CRDs and their samples, which webhook-only projects do not have
+	WebhookOnly bool
 }
 
 // SetTemplateDefaults implements machinery.Template
@@ -86,26 +89,27 @@ const readmeFileTemplate = `# {{ .ProjectName }}
 **NOTE:** This image ought to be published in the personal registry you specified.
 And it is required to have access to pull the image from the working environment.
 Make sure you have the proper permission to the registry if the above commands don’t work.
-
+{{ if not .WebhookOnly }}
 **Install the CRDs into the cluster:**
 
 %s
-
+{{ end }}
 **Deploy the Manager to the cluster with the image specified by ` + "`IMG`" + `:**
 
 %s
 
 > **NOTE**: If you encounter RBAC errors, you may need to grant yourself cluster-admin
 privileges or be logged in as admin.
-
+{{ if not .WebhookOnly }}
 **Create instances of your solution**
 You can apply the samples (examples) from the config/sample:
 
 %s
 
 >**NOTE**: Ensure that the samples has default values to test it out.
-
+{{ end }}
 ### To Uninstall
+{{- if not .WebhookOnly }}
 **Delete the instances (CRs) from the cluster:**
 
 %s
@@ -113,7 +117,7 @@ You can apply the samples (examples) from the config/sample:
 **Delete the APIs(CRDs) from the cluster:**
 
 %s
-
+{{ end }}
 **UnDeploy the controller from the cluster:**
 
 %s
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chainsaw
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &Configuration{}
+
+// Configuration scaffolds the Chainsaw configuration used to run the e2e tests
+type Configuration struct {
+	machinery.TemplateMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *Configuration) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = ".chainsaw.yaml"
+	}
+
+	f.TemplateBody = configurationTemplate
+
+	f.IfExistsAction = machinery.SkipFile
+
+	return nil
+}
+
+const configurationTemplate = `# Chainsaw runs the declarative e2e tests found under test/e2e/chainsaw.
+# More info: https://kyverno.github.io/chainsaw/latest/configuration/
+apiVersion: chainsaw.kyverno.io/v1alpha2
+kind: Configuration
+metadata:
+  name: configuration
+spec:
+  discovery:
+    testFile: chainsaw-test
+  execution:
+    # The tests share the controller deployed on the cluster, so they are not run in parallel.
+    parallel: 1
+  timeouts:
+    apply: 30s
+    assert: 2m
+    cleanup: 1m
+    delete: 1m
+    error: 1m
+    exec: 1m
+`
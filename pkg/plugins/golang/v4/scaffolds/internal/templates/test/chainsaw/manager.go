@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chainsaw
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &ManagerTest{}
+
+// ManagerTest scaffolds the Chainsaw test which checks that the controller manager is available
+type ManagerTest struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *ManagerTest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("test", "e2e", "chainsaw", "manager", "chainsaw-test.yaml")
+	}
+
+	f.TemplateBody = managerTestTemplate
+
+	f.IfExistsAction = machinery.SkipFile
+
+	return nil
+}
+
+const managerTestTemplate = `# This test expects the controller manager to be deployed with 'make deploy',
+# which is done by 'make test-e2e' before running Chainsaw.
+apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: manager
+spec:
+  steps:
+  - name: controller-manager-is-available
+    try:
+    - assert:
+        resource:
+          apiVersion: apps/v1
+          kind: Deployment
+          metadata:
+            name: {{ .ProjectName }}-controller-manager
+            namespace: {{ .ProjectName }}-system
+          status:
+            (conditions[?type == 'Available']):
+            - status: "True"
+    catch:
+    - podLogs:
+        namespace: {{ .ProjectName }}-system
+        selector: control-plane=controller-manager
+    - events:
+        namespace: {{ .ProjectName }}-system
+`
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chainsaw
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &ResourceTest{}
+
+// ResourceTest scaffolds the Chainsaw test which creates the sample of a resource and asserts its state
+type ResourceTest struct {
+	machinery.TemplateMixin
+	machinery.ResourceMixin
+
+	// SamplePath is the path of the sample of the resource, relative to the test directory
+	SamplePath string
+
+	Force bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *ResourceTest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		if f.Resource.Group != "" {
+			f.Path = filepath.Join("test", "e2e", "chainsaw", "%[group]-%[version]-%[kind]", "chainsaw-test.yaml")
+		} else {
+			f.Path = filepath.Join("test", "e2e", "chainsaw", "%[version]-%[kind]", "chainsaw-test.yaml")
+		}
+	}
+	f.Path = f.Resource.Replacer().Replace(f.Path)
+
+	// The samples are scaffolded by the kustomize plugin in config/samples
+	samplePath := filepath.Join("config", "samples", "%[version]_%[kind].yaml")
+	if f.Resource.Group != "" {
+		samplePath = filepath.Join("config", "samples", "%[group]_%[version]_%[kind].yaml")
+	}
+	f.SamplePath = filepath.ToSlash(filepath.Join("..", "..", "..", "..", f.Resource.Replacer().Replace(samplePath)))
+
+	f.TemplateBody = resourceTestTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+
+	return nil
+}
+
+const resourceTestTemplate = `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: {{ if .Resource.Group }}{{ .Resource.Group }}-{{ end }}{{ .Resource.Version }}-{{ lower .Resource.Kind }}
+spec:
+  steps:
+  - name: create-{{ lower .Resource.Kind }}
+    try:
+    {{- if not .Resource.API.Namespaced }}
+    # {{ .Resource.Kind }} is cluster-scoped: the sample is created at the cluster level instead of
+    # the ephemeral namespace of the test, and is deleted by Chainsaw when the test ends.
+    {{- end }}
+    - apply:
+        file: {{ .SamplePath }}
+    - assert:
+        resource:
+          apiVersion: {{ .Resource.QualifiedGroup }}/{{ .Resource.Version }}
+          kind: {{ .Resource.Kind }}
+          metadata:
+            name: {{ lower .Resource.Kind }}-sample
+          # TODO(user): Assert the status set by the controller once the resource is reconciled, e.g.:
+          # status:
+          #   (conditions[?type == 'Available']):
+          #   - status: "True"
+    catch:
+    - describe:
+        apiVersion: {{ .Resource.QualifiedGroup }}/{{ .Resource.Version }}
+        kind: {{ .Resource.Kind }}
+`
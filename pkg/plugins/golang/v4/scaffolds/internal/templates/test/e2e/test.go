@@ -34,6 +34,9 @@ type Test struct {
 	machinery.BoilerplateMixin
 	machinery.RepositoryMixin
 	machinery.ProjectNameMixin
+
+	// WebhookOnly omits the installation of the CRDs, which webhook-only projects do not have
+	WebhookOnly bool
 }
 
 // SetTemplateDefaults set defaults for this template
@@ -81,7 +84,7 @@ func (f *WebhookTestUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 	}
 	codeFragments[machinery.NewMarkerFor(f.GetPath(), webhookChecksMarker)] = append(
 		codeFragments[machinery.NewMarkerFor(f.GetPath(), webhookChecksMarker)],
-		webhookChecksFragment,
+		fmt.Sprintf(webhookChecksFragment, f.ProjectName),
 	)
 
 	if f.Resource != nil && f.Resource.HasDefaultingWebhook() {
@@ -112,31 +115,43 @@ func (f *WebhookTestUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 		)
 	}
 
+	// The webhooks are exercised once the CA is injected in their configuration
+	codeFragments[machinery.NewMarkerFor(f.GetPath(), webhookChecksMarker)] = append(
+		codeFragments[machinery.NewMarkerFor(f.GetPath(), webhookChecksMarker)],
+		webhookTestsFragment,
+	)
+
 	return codeFragments
 }
 
 const webhookChecksFragment = `It("should provisioned cert-manager", func() {
 	By("validating that cert-manager has the certificate Secret")
 	verifyCertManager := func(g Gomega) {
-		cmd := exec.Command("kubectl", "get", "secrets", "webhook-server-cert", "-n", namespace)
-		_, err := utils.Run(cmd)
-		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(utils.VerifyCertificateSecret("webhook-server-cert", namespace)).To(Succeed())
 	}
 	Eventually(verifyCertManager).Should(Succeed())
 })
 
+It("should serve the webhooks", func() {
+	By("validating that the webhook service has ready endpoints")
+	verifyWebhookServiceReady := func(g Gomega) {
+		g.Expect(utils.VerifyServiceReady("%s-webhook-service", namespace)).To(Succeed())
+	}
+	Eventually(verifyWebhookServiceReady).Should(Succeed())
+})
+
+`
+
+const webhookTestsFragment = `// The webhooks of the APIs are exercised with their samples by webhooks_test.go
+webhookChecks()
+
 `
 
 const mutatingWebhookChecksFragment = `It("should have CA injection for mutating webhooks", func() {
 	By("checking CA injection for mutating webhooks")
 	verifyCAInjection := func(g Gomega) {
-		cmd := exec.Command("kubectl", "get",
-			"mutatingwebhookconfigurations.admissionregistration.k8s.io",
-			"%s-mutating-webhook-configuration",
-			"-o", "go-template={{ range .webhooks }}{{ .clientConfig.caBundle }}{{ end }}")
-		mwhOutput, err := utils.Run(cmd)
-		g.Expect(err).NotTo(HaveOccurred())
-		g.Expect(len(mwhOutput)).To(BeNumerically(">", 10))
+		g.Expect(utils.VerifyCAInjection("mutatingwebhookconfigurations.admissionregistration.k8s.io",
+			"%s-mutating-webhook-configuration", utils.WebhookCABundlePath)).To(Succeed())
 	}
 	Eventually(verifyCAInjection).Should(Succeed())
 })
@@ -146,13 +161,8 @@ const mutatingWebhookChecksFragment = `It("should have CA injection for mutating
 const validatingWebhookChecksFragment = `It("should have CA injection for validating webhooks", func() {
 	By("checking CA injection for validating webhooks")
 	verifyCAInjection := func(g Gomega) {
-		cmd := exec.Command("kubectl", "get",
-			"validatingwebhookconfigurations.admissionregistration.k8s.io",
-			"%s-validating-webhook-configuration",
-			"-o", "go-template={{ range .webhooks }}{{ .clientConfig.caBundle }}{{ end }}")
-		vwhOutput, err := utils.Run(cmd)
-		g.Expect(err).NotTo(HaveOccurred())
-		g.Expect(len(vwhOutput)).To(BeNumerically(">", 10))
+		g.Expect(utils.VerifyCAInjection("validatingwebhookconfigurations.admissionregistration.k8s.io",
+			"%s-validating-webhook-configuration", utils.WebhookCABundlePath)).To(Succeed())
 	}
 	Eventually(verifyCAInjection).Should(Succeed())
 })
@@ -162,13 +172,8 @@ const validatingWebhookChecksFragment = `It("should have CA injection for valida
 const conversionWebhookChecksFragment = `It("should have CA injection for %[1]s conversion webhook", func() {
 	By("checking CA injection for %[1]s conversion webhook")
 	verifyCAInjection := func(g Gomega) {
-		cmd := exec.Command("kubectl", "get",
-			"customresourcedefinitions.apiextensions.k8s.io",
-			"%[2]s",
-			"-o", "go-template={{ .spec.conversion.webhook.clientConfig.caBundle }}")
-		vwhOutput, err := utils.Run(cmd)
-		g.Expect(err).NotTo(HaveOccurred())
-		g.Expect(len(vwhOutput)).To(BeNumerically(">", 10))
+		g.Expect(utils.VerifyCAInjection("customresourcedefinitions.apiextensions.k8s.io",
+			"%[2]s", utils.ConversionCABundlePath)).To(Succeed())
 	}
 	Eventually(verifyCAInjection).Should(Succeed())
 })
@@ -181,11 +186,8 @@ var testCodeTemplate = `{{ .Boilerplate }}
 package e2e
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"os/exec"
-	"path/filepath"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -207,7 +209,7 @@ var _ = Describe("Manager", Ordered, func() {
 	var controllerPodName string
 
 	// Before running the tests, set up the environment by creating the namespace,
-	// enforce the restricted security policy to the namespace, installing CRDs,
+	// enforce the restricted security policy to the namespace,{{ if not .WebhookOnly }} installing CRDs,{{ end }}
 	// and deploying the controller.
 	BeforeAll(func() {
 		By("creating manager namespace")
@@ -220,19 +222,19 @@ var _ = Describe("Manager", Ordered, func() {
 			"pod-security.kubernetes.io/enforce=restricted")
 		_, err = utils.Run(cmd)
 		Expect(err).NotTo(HaveOccurred(), "Failed to label namespace with restricted policy")
-
+{{ if not .WebhookOnly }}
 		By("installing CRDs")
 		cmd = exec.Command("make", "install")
 		_, err = utils.Run(cmd)
 		Expect(err).NotTo(HaveOccurred(), "Failed to install CRDs")
-
+{{ end }}
 		By("deploying the controller-manager")
 		cmd = exec.Command("make", "deploy", fmt.Sprintf("IMG=%s", projectImage))
 		_, err = utils.Run(cmd)
 		Expect(err).NotTo(HaveOccurred(), "Failed to deploy the controller-manager")
 	})
 
-	// After all tests have been executed, clean up by undeploying the controller, uninstalling CRDs,
+	// After all tests have been executed, clean up by undeploying the controller,{{ if not .WebhookOnly }} uninstalling CRDs,{{ end }}
 	// and deleting the namespace.
 	AfterAll(func() {
 		By("cleaning up the curl pod for metrics")
@@ -242,11 +244,11 @@ var _ = Describe("Manager", Ordered, func() {
 		By("undeploying the controller-manager")
 		cmd = exec.Command("make", "undeploy")
 		_, _ = utils.Run(cmd)
-
+{{ if not .WebhookOnly }}
 		By("uninstalling CRDs")
 		cmd = exec.Command("make", "uninstall")
 		_, _ = utils.Run(cmd)
-
+{{ end }}
 		By("removing manager namespace")
 		cmd = exec.Command("kubectl", "delete", "ns", namespace)
 		_, _ = utils.Run(cmd)
@@ -346,9 +348,13 @@ var _ = Describe("Manager", Ordered, func() {
 			Expect(err).NotTo(HaveOccurred(), "Metrics service should exist")
 
 			By("getting the service account token")
-			token, err := serviceAccountToken()
-			Expect(err).NotTo(HaveOccurred())
-			Expect(token).NotTo(BeEmpty())
+			var token string
+			verifyTokenCreation := func(g Gomega) {
+				var err error
+				token, err = utils.ServiceAccountToken(namespace, serviceAccountName)
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			Eventually(verifyTokenCreation).Should(Succeed())
 
 			By("waiting for the metrics endpoint to be ready")
 			verifyMetricsEndpointReady := func(g Gomega) {
@@ -370,33 +376,8 @@ var _ = Describe("Manager", Ordered, func() {
 			Eventually(verifyMetricsServerStarted).Should(Succeed())
 
 			By("creating the curl-metrics pod to access the metrics endpoint")
-			cmd = exec.Command("kubectl", "run", "curl-metrics", "--restart=Never",
-				"--namespace", namespace,
-				"--image=curlimages/curl:latest",
-				"--overrides",
-				fmt.Sprintf(` + "`" + `{
-					"spec": {
-						"containers": [{
-							"name": "curl",
-							"image": "curlimages/curl:latest",
-							"command": ["/bin/sh", "-c"],
-							"args": ["curl -v -k -H 'Authorization: Bearer %s' https://%s.%s.svc.cluster.local:8443/metrics"],
-							"securityContext": {
-								"allowPrivilegeEscalation": false,
-								"capabilities": {
-									"drop": ["ALL"]
-								},
-								"runAsNonRoot": true,
-								"runAsUser": 1000,
-								"seccompProfile": {
-									"type": "RuntimeDefault"
-								}
-							}
-						}],
-						"serviceAccount": "%s"
-					}
-				}` + "`" + `, token, metricsServiceName, namespace, serviceAccountName))
-			_, err = utils.Run(cmd)
+			err = utils.CreateCurlMetricsPod("curl-metrics", namespace, serviceAccountName, token,
+				fmt.Sprintf("https://%s.%s.svc.cluster.local:8443/metrics", metricsServiceName, namespace))
 			Expect(err).NotTo(HaveOccurred(), "Failed to create curl-metrics pod")
 
 			By("waiting for the curl-metrics pod to complete.")
@@ -418,6 +399,24 @@ var _ = Describe("Manager", Ordered, func() {
 		})
 
 		// +kubebuilder:scaffold:e2e-webhooks-checks
+{{ if not .WebhookOnly }}
+		It("should accept the samples", func() {
+			By("applying the samples of config/samples with a server-side dry run")
+			samples, err := sampleFiles()
+			Expect(err).NotTo(HaveOccurred(), "Failed to list the samples")
+			for _, sample := range samples {
+				// The webhooks may not be serving yet right after the deployment
+				Eventually(func(g Gomega) {
+					verifySample(g, sample)
+				}).Should(Succeed())
+			}
+
+			// TODO: Assert the fields set by the defaulting of your APIs in the samples, i.e.:
+			// Eventually(func(g Gomega) {
+			//    g.Expect(verifySample(g, "<path of the sample>")).To(ContainSubstring("<defaulted field>"))
+			// }).Should(Succeed())
+		})
+{{ end }}
 
 		// TODO: Customize the e2e test suite with scenarios specific to your project.
 		// Consider applying sample/CR(s) and check their status and/or verifying
@@ -430,62 +429,11 @@ var _ = Describe("Manager", Ordered, func() {
 	})
 })
 
-// serviceAccountToken returns a token for the specified service account in the given namespace.
-// It uses the Kubernetes TokenRequest API to generate a token by directly sending a request
-// and parsing the resulting token from the API response.
-func serviceAccountToken() (string, error) {
-	const tokenRequestRawString = ` + "`" + `{
-		"apiVersion": "authentication.k8s.io/v1",
-		"kind": "TokenRequest"
-	}` + "`" + `
-
-	// Temporary file to store the token request
-	secretName := fmt.Sprintf("%s-token-request", serviceAccountName)
-	tokenRequestFile := filepath.Join("/tmp", secretName)
-	err := os.WriteFile(tokenRequestFile, []byte(tokenRequestRawString), os.FileMode(0o644))
-	if err != nil {
-		return "", err
-	}
-
-	var out string
-	verifyTokenCreation := func(g Gomega) {
-		// Execute kubectl command to create the token
-		cmd := exec.Command("kubectl", "create", "--raw", fmt.Sprintf(
-			"/api/v1/namespaces/%s/serviceaccounts/%s/token",
-			namespace,
-			serviceAccountName,
-		), "-f", tokenRequestFile)
-
-		output, err := cmd.CombinedOutput()
-		g.Expect(err).NotTo(HaveOccurred())
-
-		// Parse the JSON output to extract the token
-		var token tokenRequest
-		err = json.Unmarshal(output, &token)
-		g.Expect(err).NotTo(HaveOccurred())
-
-		out = token.Status.Token
-	}
-	Eventually(verifyTokenCreation).Should(Succeed())
-
-	return out, err
-}
-
 // getMetricsOutput retrieves and returns the logs from the curl pod used to access the metrics endpoint.
 func getMetricsOutput() string {
 	By("getting the curl-metrics logs")
-	cmd := exec.Command("kubectl", "logs", "curl-metrics", "-n", namespace)
-	metricsOutput, err := utils.Run(cmd)
-	Expect(err).NotTo(HaveOccurred(), "Failed to retrieve logs from curl pod")
-	Expect(metricsOutput).To(ContainSubstring("< HTTP/1.1 200 OK"))
+	metricsOutput, err := utils.MetricsOutput("curl-metrics", namespace)
+	Expect(err).NotTo(HaveOccurred(), "Failed to retrieve the metrics from curl pod")
 	return metricsOutput
 }
-
-// tokenRequest is a simplified representation of the Kubernetes TokenRequest API response,
-// containing only the token field that we need to extract.
-type tokenRequest struct {
-	Status struct {
-		Token string ` + "`json:\"token\"`" + `
-	} ` + "`json:\"status\"`" + `
-}
 `
@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+var _ machinery.Template = &WebhooksTest{}
+
+// WebhooksTest scaffolds the checks of the e2e test which exercise the webhooks of the APIs of the project
+type WebhooksTest struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.RepositoryMixin
+
+	// Resources are the APIs of the project with a defaulting, validation or conversion webhook
+	Resources []resource.Resource
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *WebhooksTest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("test", "e2e", "webhooks_test.go")
+	}
+
+	f.TemplateBody = webhooksTestTemplate
+
+	// The checks are generated from the PROJECT file, so they are updated when a webhook is created
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+//nolint:lll
+const webhooksTestTemplate = `{{ .Boilerplate }}
+
+// Code generated by kubebuilder from the PROJECT file. DO NOT EDIT.
+// The checks are regenerated when a webhook is created, add the checks specific to your
+// webhooks in another file.
+
+package e2e
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"{{ .Repo }}/test/utils"
+)
+
+// webhookTest defines the webhooks of a version of an API, exercised with its sample of config/samples
+type webhookTest struct {
+	kind    string
+	version string
+	// plural and group are the resource of the API, e.g. cronjobs and batch.example.com
+	plural string
+	group  string
+	// sample is the manifest of the sample of the API, relative to config/samples
+	sample string
+	// defaulting and validation are true when the API has a defaulting or a validation webhook
+	defaulting bool
+	validation bool
+	// spokes are the versions converted from and to this version, the hub, by the conversion webhook
+	spokes []string
+}
+
+// webhookTests are the APIs of the project with webhooks
+var webhookTests = []webhookTest{
+{{- range .Resources }}
+	{
+		kind:       "{{ .Kind }}",
+		version:    "{{ .Version }}",
+		plural:     "{{ .Plural }}",
+		group:      "{{ .QualifiedGroup }}",
+		sample:     "{{ if .Group }}{{ .Group }}_{{ end }}{{ .Version }}_{{ lower .Kind }}.yaml",
+		defaulting: {{ .HasDefaultingWebhook }},
+		validation: {{ .HasValidationWebhook }},
+		{{- if .HasConversionWebhook }}
+		spokes:     []string{ {{- range $i, $spoke := .Webhooks.Spoke }}{{ if $i }}, {{ end }}"{{ $spoke }}"{{ end -}} },
+		{{- end }}
+	},
+{{- end }}
+}
+
+// webhookChecks declares the checks of the webhooks of each API, with the sample of the API:
+//   - the defaulting and validation webhooks are called when the sample is applied with a server-side dry run
+//   - the validation webhook rejects the invalid sample of the API under test/e2e/testdata, if any,
+//     e.g. test/e2e/testdata/invalid_batch_v1_cronjob.yaml
+//   - the sample is converted to each spoke version and back by the conversion webhook
+//
+// The checks run once the manager is deployed, and the webhooks serving.
+func webhookChecks() {
+	for _, webhook := range webhookTests {
+		sample := filepath.Join("config", "samples", webhook.sample)
+
+		Context(fmt.Sprintf("with the webhooks of %s %s", webhook.kind, webhook.version), func() {
+			if webhook.defaulting {
+				It("should default the sample", func() {
+					verifyWebhookCalled(sample, fmt.Sprintf("Defaulting for %s", webhook.kind))
+				})
+			}
+
+			if webhook.validation {
+				It("should validate the sample", func() {
+					verifyWebhookCalled(sample, fmt.Sprintf("Validation for %s", webhook.kind))
+				})
+
+				It("should reject the invalid sample", func() {
+					invalidSample := filepath.Join("test", "e2e", "testdata", "invalid_"+webhook.sample)
+					projectDir, err := utils.GetProjectDir()
+					Expect(err).NotTo(HaveOccurred())
+					if _, err := os.Stat(filepath.Join(projectDir, invalidSample)); os.IsNotExist(err) {
+						Skip(fmt.Sprintf("add the sample %s, rejected by the validation webhook, to check it", invalidSample))
+					}
+
+					By("applying the invalid sample with a server-side dry run")
+					Eventually(func(g Gomega) {
+						cmd := exec.Command("kubectl", "apply", "--dry-run=server", "-f", invalidSample, "-n", namespace)
+						_, err := utils.Run(cmd)
+						g.Expect(err).To(HaveOccurred(), "The invalid sample was accepted")
+						g.Expect(err.Error()).To(ContainSubstring("denied the request"))
+					}).Should(Succeed())
+				})
+			}
+
+			if len(webhook.spokes) > 0 {
+				It("should convert the sample to the spoke versions and back", func() {
+					By("creating the sample")
+					var name string
+					Eventually(func(g Gomega) {
+						cmd := exec.Command("kubectl", "apply", "-f", sample, "-n", namespace,
+							"-o", "jsonpath={.metadata.name}")
+						var err error
+						name, err = utils.Run(cmd)
+						g.Expect(err).NotTo(HaveOccurred())
+					}).Should(Succeed())
+					DeferCleanup(func() {
+						cmd := exec.Command("kubectl", "delete", "-f", sample, "-n", namespace, "--ignore-not-found")
+						_, _ = utils.Run(cmd)
+					})
+
+					for _, spoke := range webhook.spokes {
+						By(fmt.Sprintf("getting the sample in the version %s", spoke))
+						resource := fmt.Sprintf("%s.%s.%s", webhook.plural, spoke, webhook.group)
+						cmd := exec.Command("kubectl", "get", resource, name, "-n", namespace, "-o", "yaml")
+						converted, err := utils.Run(cmd)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(converted).To(ContainSubstring(fmt.Sprintf("apiVersion: %s/%s", webhook.group, spoke)))
+
+						By(fmt.Sprintf("applying the sample in the version %s with a server-side dry run", spoke))
+						cmd = exec.Command("kubectl", "apply", "--dry-run=server", "-f", "-", "-n", namespace)
+						cmd.Stdin = strings.NewReader(converted)
+						_, err = utils.Run(cmd)
+						Expect(err).NotTo(HaveOccurred())
+					}
+				})
+			}
+		})
+	}
+}
+
+// verifyWebhookCalled applies the sample with a server-side dry run until the manager logs the
+// message of the webhook, which the scaffolded webhooks log when they handle a request.
+func verifyWebhookCalled(sample, message string) {
+	Eventually(func(g Gomega) {
+		verifySample(g, sample)
+
+		cmd := exec.Command("kubectl", "logs", "-l", "control-plane=controller-manager",
+			"-n", namespace, "--tail=-1")
+		output, err := utils.Run(cmd)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(output).To(ContainSubstring(message), "The webhook was not called")
+	}).Should(Succeed())
+}
+`
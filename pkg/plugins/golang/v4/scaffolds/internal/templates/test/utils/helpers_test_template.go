@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &HelpersTest{}
+
+// HelpersTest scaffolds the file that tests the helpers of the e2e tests
+type HelpersTest struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *HelpersTest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("test", "utils", "helpers_test.go")
+	}
+
+	f.TemplateBody = helpersTestTemplate
+
+	return nil
+}
+
+const helpersTestTemplate = `{{ .Boilerplate }}
+
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Helpers", func() {
+	Context("parseTokenRequest", func() {
+		It("should return the token of the response", func() {
+			token, err := parseTokenRequest([]byte(` + "`" + `{"status": {"token": "my-token"}}` + "`" + `))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("my-token"))
+		})
+
+		It("should fail when the response has no token", func() {
+			_, err := parseTokenRequest([]byte(` + "`" + `{"status": {}}` + "`" + `))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail when the response is not JSON", func() {
+			_, err := parseTokenRequest([]byte("error: unauthorized"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("curlPodOverrides", func() {
+		It("should request the metrics endpoint with the token as the service account", func() {
+			overrides, err := curlPodOverrides("controller-manager", "my-token", "https://metrics:8443/metrics")
+			Expect(err).NotTo(HaveOccurred())
+
+			var pod struct {
+				Spec struct {
+					ServiceAccount string ` + "`json:\"serviceAccount\"`" + `
+					Containers     []struct {
+						Args            []string ` + "`json:\"args\"`" + `
+						SecurityContext struct {
+							RunAsNonRoot bool ` + "`json:\"runAsNonRoot\"`" + `
+						} ` + "`json:\"securityContext\"`" + `
+					} ` + "`json:\"containers\"`" + `
+				} ` + "`json:\"spec\"`" + `
+			}
+			Expect(json.Unmarshal([]byte(overrides), &pod)).To(Succeed())
+			Expect(pod.Spec.ServiceAccount).To(Equal("controller-manager"))
+			Expect(pod.Spec.Containers).To(HaveLen(1))
+			Expect(pod.Spec.Containers[0].Args).To(ConsistOf(
+				"curl -v -k -H 'Authorization: Bearer my-token' https://metrics:8443/metrics"))
+			Expect(pod.Spec.Containers[0].SecurityContext.RunAsNonRoot).To(BeTrue())
+		})
+	})
+
+	Context("hasCABundle", func() {
+		certificate := base64.StdEncoding.EncodeToString(
+			[]byte("-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"))
+
+		It("should be false when no CA bundle was injected", func() {
+			Expect(hasCABundle("")).To(BeFalse())
+		})
+
+		It("should be true when the CA bundles are certificates", func() {
+			Expect(hasCABundle(certificate)).To(BeTrue())
+			Expect(hasCABundle(certificate + " " + certificate)).To(BeTrue())
+		})
+
+		It("should be false when a CA bundle is not a certificate", func() {
+			Expect(hasCABundle("not-base64")).To(BeFalse())
+			Expect(hasCABundle(certificate + " " + base64.StdEncoding.EncodeToString([]byte("key")))).To(BeFalse())
+		})
+	})
+})
+`
@@ -17,12 +17,14 @@ limitations under the License.
 package webhooks
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
 )
 
 var _ machinery.Template = &Webhook{}
@@ -40,8 +42,16 @@ type Webhook struct { //nolint:maligned
 	// Define value for AdmissionReviewVersions marker
 	AdmissionReviewVersions string
 
+	// Paths served by the mutating and validating webhooks
+	MutatingWebhookPath   string
+	ValidatingWebhookPath string
+
 	Force bool
 
+	// Programmatic indicates that the webhooks should be scaffolded as admission.Handler implementations
+	// which decode the raw admission request instead of implementing CustomDefaulter and CustomValidator
+	Programmatic bool
+
 	// Deprecated - The flag should be removed from go/v5
 	// IsLegacyPath indicates if webhooks should be scaffolded under the API.
 	// Webhooks are now decoupled from APIs based on controller-runtime updates and community feedback.
@@ -70,11 +80,22 @@ func (f *Webhook) SetTemplateDefaults() error {
 	log.Println(f.Path)
 
 	webhookTemplate := webhookTemplate
+	if f.Programmatic {
+		webhookTemplate = programmaticWebhookTemplate
+	}
 	if f.Resource.HasDefaultingWebhook() {
-		webhookTemplate = webhookTemplate + defaultingWebhookTemplate
+		if f.Programmatic {
+			webhookTemplate += programmaticDefaultingWebhookTemplate
+		} else {
+			webhookTemplate += defaultingWebhookTemplate
+		}
 	}
 	if f.Resource.HasValidationWebhook() {
-		webhookTemplate = webhookTemplate + validatingWebhookTemplate
+		if f.Programmatic {
+			webhookTemplate += programmaticValidatingWebhookTemplate
+		} else {
+			webhookTemplate += validatingWebhookTemplate
+		}
 	}
 	f.TemplateBody = webhookTemplate
 
@@ -86,11 +107,32 @@ func (f *Webhook) SetTemplateDefaults() error {
 
 	f.AdmissionReviewVersions = "v1"
 	f.QualifiedGroupWithDash = strings.Replace(f.Resource.QualifiedGroup(), ".", "-", -1)
+	f.MutatingWebhookPath, f.ValidatingWebhookPath = webhookPaths(f.Resource)
 
 	return nil
 }
 
+// webhookPaths returns the paths served by the mutating and validating webhooks of the resource
+func webhookPaths(res *resource.Resource) (string, string) {
+	// The core group has no name, so its webhook paths are in the form /mutate--<version>-<kind>
+	pathGroup := strings.Replace(res.QualifiedGroup(), ".", "-", -1)
+	if res.Core && res.QualifiedGroup() == "core" {
+		pathGroup = ""
+	}
+	pathSuffix := fmt.Sprintf("%s-%s-%s", pathGroup, res.Version, strings.ToLower(res.Kind))
+	return "/mutate-" + pathSuffix, "/validate-" + pathSuffix
+}
+
 const (
+	//nolint:lll
+	defaultingWebhookMarker = `// +kubebuilder:webhook:{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}webhookVersions={{"{"}}{{ .Resource.Webhooks.WebhookVersion }}{{"}"}},{{ end }}path={{ .MutatingWebhookPath }},mutating=true,failurePolicy=fail,sideEffects=None,groups={{ if and .Resource.Core (eq .Resource.QualifiedGroup "core") }}""{{ else }}{{ .Resource.QualifiedGroup }}{{ end }},resources={{ .Resource.Plural }},verbs=create;update,versions={{ .Resource.Version }},name=m{{ lower .Resource.Kind }}-{{ .Resource.Version }}.kb.io,admissionReviewVersions={{ .AdmissionReviewVersions }}`
+
+	//nolint:lll
+	validatingWebhookMarker = `// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}webhookVersions={{"{"}}{{ .Resource.Webhooks.WebhookVersion }}{{"}"}},{{ end }}path={{ .ValidatingWebhookPath }},mutating=false,failurePolicy=fail,sideEffects=None,groups={{ if and .Resource.Core (eq .Resource.QualifiedGroup "core") }}""{{ else }}{{ .Resource.QualifiedGroup }}{{ end }},resources={{ .Resource.Plural }},verbs=create;update,versions={{ .Resource.Version }},name=v{{ lower .Resource.Kind }}-{{ .Resource.Version }}.kb.io,admissionReviewVersions={{ .AdmissionReviewVersions }}`
+
 	webhookTemplate = `{{ .Boilerplate }}
 
 package {{ .Resource.Version }}
@@ -156,9 +198,8 @@ func Setup{{ .Resource.Kind }}WebhookWithManager(mgr ctrl.Manager) error {
 // TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 `
 
-	//nolint:lll
 	defaultingWebhookTemplate = `
-// +kubebuilder:webhook:{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}webhookVersions={{"{"}}{{ .Resource.Webhooks.WebhookVersion }}{{"}"}},{{ end }}path=/mutate-{{ if and .Resource.Core (eq .Resource.QualifiedGroup "core") }}-{{ else }}{{ .QualifiedGroupWithDash }}-{{ end }}{{ .Resource.Version }}-{{ lower .Resource.Kind }},mutating=true,failurePolicy=fail,sideEffects=None,groups={{ if and .Resource.Core (eq .Resource.QualifiedGroup "core") }}""{{ else }}{{ .Resource.QualifiedGroup }}{{ end }},resources={{ .Resource.Plural }},verbs=create;update,versions={{ .Resource.Version }},name=m{{ lower .Resource.Kind }}-{{ .Resource.Version }}.kb.io,admissionReviewVersions={{ .AdmissionReviewVersions }}
+` + defaultingWebhookMarker + `
 
 {{ if .IsLegacyPath -}}
 // +kubebuilder:object:generate=false
@@ -193,12 +234,8 @@ func (d *{{ .Resource.Kind }}CustomDefaulter) Default(ctx context.Context, obj r
 }
 `
 
-	//nolint:lll
 	validatingWebhookTemplate = `
-// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
-// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
-// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
-// +kubebuilder:webhook:{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}webhookVersions={{"{"}}{{ .Resource.Webhooks.WebhookVersion }}{{"}"}},{{ end }}path=/validate-{{ if and .Resource.Core (eq .Resource.QualifiedGroup "core") }}-{{ else }}{{ .QualifiedGroupWithDash }}-{{ end }}{{ .Resource.Version }}-{{ lower .Resource.Kind }},mutating=false,failurePolicy=fail,sideEffects=None,groups={{ if and .Resource.Core (eq .Resource.QualifiedGroup "core") }}""{{ else }}{{ .Resource.QualifiedGroup }}{{ end }},resources={{ .Resource.Plural }},verbs=create;update,versions={{ .Resource.Version }},name=v{{ lower .Resource.Kind }}-{{ .Resource.Version }}.kb.io,admissionReviewVersions={{ .AdmissionReviewVersions }}
+` + validatingWebhookMarker + `
 
 {{ if .IsLegacyPath -}}
 // +kubebuilder:object:generate=false
@@ -227,6 +264,8 @@ func (v *{{ .Resource.Kind }}CustomValidator) ValidateCreate(ctx context.Context
 	{{ lower .Resource.Kind }}log.Info("Validation for {{ .Resource.Kind }} upon creation", "name", {{ lower .Resource.Kind }}.GetName())
 
 	// TODO(user): fill in your validation logic upon object creation.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.replicas above 10 may exhaust the cluster quota"}, nil
 
 	return nil, nil
 }
@@ -244,6 +283,8 @@ func (v *{{ .Resource.Kind }}CustomValidator) ValidateUpdate(ctx context.Context
 	{{ lower .Resource.Kind }}log.Info("Validation for {{ .Resource.Kind }} upon update", "name", {{ lower .Resource.Kind }}.GetName())
 
 	// TODO(user): fill in your validation logic upon object update.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.foo is deprecated and will be removed in a future version"}, nil
 
 	return nil, nil
 }
@@ -264,5 +305,139 @@ func (v *{{ .Resource.Kind }}CustomValidator) ValidateDelete(ctx context.Context
 
 	return nil, nil
 }
+`
+
+	programmaticWebhookTemplate = `{{ .Boilerplate }}
+
+package {{ .Resource.Version }}
+
+import (
+	"context"
+	{{- if .Resource.HasDefaultingWebhook }}
+	"encoding/json"
+	{{- end }}
+	"net/http"
+
+	{{- if .Resource.HasValidationWebhook }}
+	admissionv1 "k8s.io/api/admission/v1"
+	{{- end }}
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var {{ lower .Resource.Kind }}log = logf.Log.WithName("{{ lower .Resource.Kind }}-resource")
+
+// Setup{{ .Resource.Kind }}WebhookWithManager registers the webhook for {{ .Resource.Kind }} in the manager.
+// The handlers receive the raw admission requests and decode the objects with the decoder built from
+// the scheme of the manager.
+func Setup{{ .Resource.Kind }}WebhookWithManager(mgr ctrl.Manager) error {
+	decoder := admission.NewDecoder(mgr.GetScheme())
+
+	{{- if .Resource.HasDefaultingWebhook }}
+	mgr.GetWebhookServer().Register("{{ .MutatingWebhookPath }}", &webhook.Admission{
+		Handler: &{{ .Resource.Kind }}Defaulter{decoder: decoder},
+	})
+	{{- end }}
+	{{- if .Resource.HasValidationWebhook }}
+	mgr.GetWebhookServer().Register("{{ .ValidatingWebhookPath }}", &webhook.Admission{
+		Handler: &{{ .Resource.Kind }}Validator{decoder: decoder},
+	})
+	{{- end }}
+
+	return nil
+}
+
+// TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+`
+
+	programmaticDefaultingWebhookTemplate = `
+` + defaultingWebhookMarker + `
+
+// {{ .Resource.Kind }}Defaulter handles the admission requests of the mutating webhook of the Kind {{ .Resource.Kind }}.
+// Use it instead of a CustomDefaulter when you need to access the raw admission request, e.g. to check the
+// user who sent it or the dry-run option.
+type {{ .Resource.Kind }}Defaulter struct {
+	decoder admission.Decoder
+	// TODO(user): Add more fields as needed for defaulting
+}
+
+var _ admission.Handler = &{{ .Resource.Kind }}Defaulter{}
+
+// Handle implements admission.Handler and returns the JSON patch which sets the default values
+// on the {{ .Resource.Kind }} sent in the request.
+func (d *{{ .Resource.Kind }}Defaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	{{ lower .Resource.Kind }} := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
+	if err := d.decoder.Decode(req, {{ lower .Resource.Kind }}); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	{{ lower .Resource.Kind }}log.Info("Defaulting for {{ .Resource.Kind }}", "name", {{ lower .Resource.Kind }}.GetName(),
+		"operation", req.Operation, "user", req.UserInfo.Username)
+
+	// TODO(user): fill in your defaulting logic.
+
+	marshaled, err := json.Marshal({{ lower .Resource.Kind }})
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+`
+
+	programmaticValidatingWebhookTemplate = `
+` + validatingWebhookMarker + `
+
+// {{ .Resource.Kind }}Validator handles the admission requests of the validating webhook of the Kind {{ .Resource.Kind }}.
+// Use it instead of a CustomValidator when you need to access the raw admission request, e.g. to check the
+// user who sent it or the dry-run option.
+type {{ .Resource.Kind }}Validator struct {
+	decoder admission.Decoder
+	// TODO(user): Add more fields as needed for validation
+}
+
+var _ admission.Handler = &{{ .Resource.Kind }}Validator{}
+
+// Handle implements admission.Handler and allows or denies the operation on the {{ .Resource.Kind }} sent in the request.
+func (v *{{ .Resource.Kind }}Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	{{ lower .Resource.Kind }} := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
+	if req.Operation == admissionv1.Delete {
+		// The object being deleted is only sent in the OldObject field of the request.
+		if err := v.decoder.DecodeRaw(req.OldObject, {{ lower .Resource.Kind }}); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	} else if err := v.decoder.Decode(req, {{ lower .Resource.Kind }}); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	{{ lower .Resource.Kind }}log.Info("Validation for {{ .Resource.Kind }}", "name", {{ lower .Resource.Kind }}.GetName(),
+		"operation", req.Operation, "user", req.UserInfo.Username)
+
+	// Warnings are shown to the client without rejecting the request.
+	var warnings admission.Warnings
+
+	switch req.Operation {
+	case admissionv1.Create:
+		// TODO(user): fill in your validation logic upon object creation.
+	case admissionv1.Update:
+		// TODO(user): fill in your validation logic upon object update.
+		// The previous state of the object can be decoded from the request, e.g.:
+		// old{{ .Resource.Kind }} := &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
+		// if err := v.decoder.DecodeRaw(req.OldObject, old{{ .Resource.Kind }}); err != nil {
+		//     return admission.Errored(http.StatusBadRequest, err)
+		// }
+	case admissionv1.Delete:
+		// TODO(user): fill in your validation logic upon object deletion.
+	}
+
+	// TODO(user): return admission.Denied("reason") to reject the request, or add warnings, e.g.:
+	// warnings = append(warnings, "spec.foo is deprecated and will be removed in a future version")
+
+	return admission.Allowed("").WithWarnings(warnings...)
+}
 `
 )
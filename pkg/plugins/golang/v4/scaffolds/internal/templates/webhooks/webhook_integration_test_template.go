@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &WebhookIntegrationTest{}
+
+// WebhookIntegrationTest scaffolds the file that tests the admission of the resource through the API server
+// of the test environment, which calls the webhooks served by the manager of the webhook suite
+type WebhookIntegrationTest struct {
+	machinery.TemplateMixin
+	machinery.MultiGroupMixin
+	machinery.BoilerplateMixin
+	machinery.ResourceMixin
+
+	// Paths served by the mutating and validating webhooks
+	MutatingWebhookPath   string
+	ValidatingWebhookPath string
+
+	// Namespaced is true if the objects of the resource are created in a namespace
+	Namespaced bool
+
+	Force bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *WebhookIntegrationTest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		if f.MultiGroup && f.Resource.Group != "" {
+			f.Path = filepath.Join("internal", "webhook", "%[group]", "%[version]", "%[kind]_webhook_integration_test.go")
+		} else {
+			f.Path = filepath.Join("internal", "webhook", "%[version]", "%[kind]_webhook_integration_test.go")
+		}
+	}
+	f.Path = f.Resource.Replacer().Replace(f.Path)
+	log.Println(f.Path)
+
+	f.TemplateBody = webhookIntegrationTestTemplate
+
+	f.MutatingWebhookPath, f.ValidatingWebhookPath = webhookPaths(f.Resource)
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	}
+
+	return nil
+}
+
+//nolint:lll
+const webhookIntegrationTestTemplate = `{{ .Boilerplate }}
+
+package {{ .Resource.Version }}
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
+)
+
+// These tests send the {{ .Resource.Kind }} objects to the API server of the test environment. envtest installs the
+// webhook configurations of config/webhook pointing to the webhook server of the manager started by the suite,
+// which serves them with self-signed certificates, so the admission is verified end-to-end as in a cluster
+// rather than by calling the webhooks directly. Run 'make manifests' after changing the webhook markers.
+var _ = Describe("{{ .Resource.Kind }} Webhook Integration", func() {
+	var obj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}
+
+	BeforeEach(func() {
+		obj = &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-{{ lower .Resource.Kind }}-",
+				{{- if .Namespaced }}
+				Namespace:    "default",
+				{{- end }}
+			},
+		}
+		// TODO (user): Set the fields required to create a valid {{ .Resource.Kind }}
+	})
+	{{- if .Resource.HasDefaultingWebhook }}
+
+	Context("When creating {{ .Resource.Kind }} through the API server under Defaulting Webhook", func() {
+		It("Should register the mutating webhook in the API server", func() {
+			path := "{{ .MutatingWebhookPath }}"
+			Expect(testEnv.WebhookInstallOptions.MutatingWebhooks).To(ContainElement(
+				HaveField("Webhooks", ContainElement(HaveField("ClientConfig.URL", HaveValue(HaveSuffix(path)))))))
+		})
+
+		It("Should admit the creation and apply the defaults", func() {
+			By("creating the {{ .Resource.Kind }} with a dry run, which calls the webhooks without persisting it")
+			Expect(k8sClient.Create(ctx, obj, client.DryRunAll)).To(Succeed())
+			// TODO (user): Check the defaults set by the webhook in the object returned by the API server, e.g.:
+			// Expect(obj.Spec.SomeFieldWithDefault).To(Equal("default_value"))
+		})
+	})
+	{{- end }}
+	{{- if .Resource.HasValidationWebhook }}
+
+	Context("When creating or updating {{ .Resource.Kind }} through the API server under Validating Webhook", func() {
+		It("Should register the validating webhook in the API server", func() {
+			path := "{{ .ValidatingWebhookPath }}"
+			Expect(testEnv.WebhookInstallOptions.ValidatingWebhooks).To(ContainElement(
+				HaveField("Webhooks", ContainElement(HaveField("ClientConfig.URL", HaveValue(HaveSuffix(path)))))))
+		})
+
+		It("Should admit the creation", func() {
+			By("creating the {{ .Resource.Kind }} with a dry run, which calls the webhooks without persisting it")
+			Expect(k8sClient.Create(ctx, obj, client.DryRunAll)).To(Succeed())
+		})
+
+		It("Should admit the update", func() {
+			By("creating the {{ .Resource.Kind }}")
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, obj))).To(Succeed())
+			})
+
+			By("updating the {{ .Resource.Kind }} with a dry run")
+			obj.Labels = map[string]string{"updated": "true"}
+			Expect(k8sClient.Update(ctx, obj, client.DryRunAll)).To(Succeed())
+		})
+
+		// TODO (user): Add the cases where the API server must deny the request, e.g.:
+		// It("Should deny creation if a required field is missing", func() {
+		//     obj.Spec.SomeRequiredField = ""
+		//     Expect(k8sClient.Create(ctx, obj, client.DryRunAll)).To(MatchError(ContainSubstring("someRequiredField")))
+		// })
+	})
+	{{- end }}
+})
+`
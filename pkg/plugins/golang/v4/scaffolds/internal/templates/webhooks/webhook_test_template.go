@@ -37,6 +37,10 @@ type WebhookTest struct { //nolint:maligned
 
 	Force bool
 
+	// Programmatic indicates that the webhooks are scaffolded as admission.Handler implementations,
+	// so the tests send raw admission requests to them
+	Programmatic bool
+
 	// Deprecated - The flag should be removed from go/v5
 	// IsLegacyPath indicates if webhooks should be scaffolded under the API.
 	// Webhooks are now decoupled from APIs based on controller-runtime updates and community feedback.
@@ -65,10 +69,18 @@ func (f *WebhookTest) SetTemplateDefaults() error {
 	webhookTestTemplate := webhookTestTemplate
 	templates := make([]string, 0)
 	if f.Resource.HasDefaultingWebhook() {
-		templates = append(templates, defaultWebhookTestTemplate)
+		if f.Programmatic {
+			templates = append(templates, programmaticDefaultWebhookTestTemplate)
+		} else {
+			templates = append(templates, defaultWebhookTestTemplate)
+		}
 	}
 	if f.Resource.HasValidationWebhook() {
-		templates = append(templates, validateWebhookTestTemplate)
+		if f.Programmatic {
+			templates = append(templates, programmaticValidateWebhookTestTemplate)
+		} else {
+			templates = append(templates, validateWebhookTestTemplate)
+		}
 	}
 	if f.Resource.HasConversionWebhook() {
 		templates = append(templates, conversionWebhookTestTemplate)
@@ -87,8 +99,18 @@ const webhookTestTemplate = `{{ .Boilerplate }}
 package {{ .Resource.Version }}
 
 import (
+	{{- if .Programmatic }}
+	"encoding/json"
+	{{- end }}
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	{{- if .Programmatic }}
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	{{- end }}
 
 	{{ if not .IsLegacyPath -}}
 	{{ if not (isEmptyStr .Resource.Path) -}}
@@ -106,10 +128,10 @@ var _ = Describe("{{ .Resource.Kind }} Webhook", func() {
 		obj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}
 		oldObj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}
 		{{- if .Resource.HasValidationWebhook }}
-		validator {{ .Resource.Kind }}CustomValidator
+		validator {{ .Resource.Kind }}{{ if not .Programmatic }}Custom{{ end }}Validator
 		{{- end }}
 		{{- if .Resource.HasDefaultingWebhook }}
-		defaulter {{ .Resource.Kind }}CustomDefaulter
+		defaulter {{ .Resource.Kind }}{{ if not .Programmatic }}Custom{{ end }}Defaulter
 		{{- end }}
 		{{- end }}
 	)
@@ -121,11 +143,19 @@ var _ = Describe("{{ .Resource.Kind }} Webhook", func() {
 		obj = &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
 		oldObj = &{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}
 		{{- if .Resource.HasValidationWebhook }}
+		{{- if .Programmatic }}
+		validator = {{ .Resource.Kind }}Validator{decoder: admission.NewDecoder(scheme.Scheme)}
+		{{- else }}
 		validator = {{ .Resource.Kind }}CustomValidator{}
+		{{- end }}
 		Expect(validator).NotTo(BeNil(), "Expected validator to be initialized")
 		{{- end }}
 		{{- if .Resource.HasDefaultingWebhook }}
+		{{- if .Programmatic }}
+		defaulter = {{ .Resource.Kind }}Defaulter{decoder: admission.NewDecoder(scheme.Scheme)}
+		{{- else }}
 		defaulter = {{ .Resource.Kind }}CustomDefaulter{}
+		{{- end }}
 		Expect(defaulter).NotTo(BeNil(), "Expected defaulter to be initialized")
 		{{- end }}
 		Expect(oldObj).NotTo(BeNil(), "Expected oldObj to be initialized")
@@ -140,6 +170,26 @@ var _ = Describe("{{ .Resource.Kind }} Webhook", func() {
 
 	%s
 })
+{{- if .Programmatic }}
+
+// new{{ .Resource.Kind }}AdmissionRequest builds the admission request which the API server sends to the
+// webhooks for the given operation on the {{ .Resource.Kind }}.
+func new{{ .Resource.Kind }}AdmissionRequest(operation admissionv1.Operation,
+	obj, oldObj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) admission.Request {
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: operation}}
+	if obj != nil {
+		raw, err := json.Marshal(obj)
+		Expect(err).NotTo(HaveOccurred())
+		req.Object = runtime.RawExtension{Raw: raw}
+	}
+	if oldObj != nil {
+		raw, err := json.Marshal(oldObj)
+		Expect(err).NotTo(HaveOccurred())
+		req.OldObject = runtime.RawExtension{Raw: raw}
+	}
+	return req
+}
+{{- end }}
 `
 
 const conversionWebhookTestTemplate = `
@@ -217,3 +267,50 @@ Context("When creating {{ .Resource.Kind }} under Defaulting Webhook", func() {
 	// })
 })
 `
+
+const programmaticDefaultWebhookTestTemplate = `
+Context("When creating {{ .Resource.Kind }} under Defaulting Webhook", func() {
+	It("Should allow the request and return the patches with the default values", func() {
+		By("sending a create request to the handler")
+		resp := defaulter.Handle(ctx, new{{ .Resource.Kind }}AdmissionRequest(admissionv1.Create, obj, nil))
+		Expect(resp.Allowed).To(BeTrue())
+		// TODO (user): Check the patches which set the default values, e.g.:
+		// Expect(resp.Patches).To(ContainElement(HaveField("Path", "/spec/someFieldWithDefault")))
+	})
+})
+`
+
+const programmaticValidateWebhookTestTemplate = `
+Context("When creating, updating or deleting {{ .Resource.Kind }} under Validating Webhook", func() {
+	It("Should admit the creation", func() {
+		By("sending a create request to the handler")
+		resp := validator.Handle(ctx, new{{ .Resource.Kind }}AdmissionRequest(admissionv1.Create, obj, nil))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("Should admit the update", func() {
+		By("sending an update request to the handler")
+		resp := validator.Handle(ctx, new{{ .Resource.Kind }}AdmissionRequest(admissionv1.Update, obj, oldObj))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("Should admit the deletion", func() {
+		By("sending a delete request to the handler")
+		resp := validator.Handle(ctx, new{{ .Resource.Kind }}AdmissionRequest(admissionv1.Delete, nil, obj))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	// TODO (user): Add the cases where the request must be denied or warned, e.g.:
+	// It("Should deny creation if a required field is missing", func() {
+	//     obj.SomeRequiredField = ""
+	//     resp := validator.Handle(ctx, new{{ .Resource.Kind }}AdmissionRequest(admissionv1.Create, obj, nil))
+	//     Expect(resp.Allowed).To(BeFalse())
+	// })
+	//
+	// It("Should warn when a deprecated field is set", func() {
+	//     obj.SomeDeprecatedField = "value"
+	//     resp := validator.Handle(ctx, new{{ .Resource.Kind }}AdmissionRequest(admissionv1.Create, obj, nil))
+	//     Expect(resp.Warnings).NotTo(BeEmpty())
+	// })
+})
+`
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	kustomizecommonv2scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
+)
+
+var _ = Describe("Additional managers", func() {
+	const (
+		repo   = "example.com/project"
+		domain = "example.com"
+
+		reconcilerSetup = "(&controller.CaptainReconciler{"
+		addToScheme     = "utilruntime.Must(crewv1.AddToScheme(scheme))"
+	)
+
+	var (
+		mainA = filepath.Join("cmd", "manager-a", "main.go")
+		mainB = filepath.Join("cmd", "manager-b", "main.go")
+	)
+
+	var (
+		fs  machinery.Filesystem
+		cfg config.Config
+	)
+
+	read := func(path string) string {
+		content, err := afero.ReadFile(fs.FS, path)
+		Expect(err).NotTo(HaveOccurred())
+		return string(content)
+	}
+
+	scaffoldAPI := func(manager string) {
+		res := resource.Resource{
+			GVK: resource.GVK{
+				Group:   "crew",
+				Domain:  domain,
+				Version: "v1",
+				Kind:    "Captain",
+			},
+			Plural: "captains",
+			API: &resource.API{
+				CRDVersion: "v1",
+				Namespaced: true,
+			},
+			Controller: true,
+		}
+		res.Path = resource.APIPackagePath(repo, res.Group, res.Version, false)
+
+		scaffolder := NewAPIScaffolder(cfg, res, false, ControllerOptions{Manager: manager})
+		scaffolder.InjectFS(fs)
+		Expect(scaffolder.Scaffold()).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+
+		cfg = cfgv3.New()
+		Expect(cfg.SetRepository(repo)).To(Succeed())
+		Expect(cfg.SetDomain(domain)).To(Succeed())
+		Expect(cfg.SetProjectName("project")).To(Succeed())
+		Expect(kustomizecommonv2scaffolds.SavePluginConfig(cfg, kustomizecommonv2scaffolds.PluginConfig{
+			Managers: []string{"manager-a", "manager-b"},
+		})).To(Succeed())
+
+		initScaffolder := NewInitScaffolder(cfg, "apache2", "The Kubernetes authors", "kubebuilder")
+		initScaffolder.InjectFS(fs)
+		Expect(initScaffolder.Scaffold()).To(Succeed())
+	})
+
+	It("should scaffold the entry point of each manager", func() {
+		for _, path := range []string{"cmd/main.go", mainA, mainB} {
+			Expect(afero.Exists(fs.FS, path)).To(BeTrue(), path)
+		}
+	})
+
+	It("should build each manager with the Makefile and in the image", func() {
+		makefile := read("Makefile")
+		Expect(makefile).To(ContainSubstring("go build -o bin/manager-a cmd/manager-a/main.go"))
+		Expect(makefile).To(ContainSubstring("go build -o bin/manager-b cmd/manager-b/main.go"))
+		Expect(makefile).To(ContainSubstring("run-manager-a: manifests generate fmt vet"))
+
+		dockerfile := read("Dockerfile")
+		Expect(dockerfile).To(ContainSubstring("go build -a -o manager-a cmd/manager-a/main.go"))
+		Expect(dockerfile).To(ContainSubstring("COPY --from=builder /workspace/manager-b ."))
+	})
+
+	It("should wire the controller in the manager it is assigned to", func() {
+		scaffoldAPI("manager-a")
+
+		Expect(read(mainA)).To(ContainSubstring(reconcilerSetup))
+		Expect(read(mainA)).To(ContainSubstring(addToScheme))
+		Expect(read(mainB)).NotTo(ContainSubstring(reconcilerSetup))
+
+		// The API is registered in the main manager, which serves the webhooks of the project
+		Expect(read("cmd/main.go")).To(ContainSubstring(addToScheme))
+		Expect(read("cmd/main.go")).NotTo(ContainSubstring(reconcilerSetup))
+	})
+
+	It("should wire the controller in the main manager by default", func() {
+		scaffoldAPI("")
+
+		Expect(read("cmd/main.go")).To(ContainSubstring(reconcilerSetup))
+		Expect(read(mainA)).NotTo(ContainSubstring(reconcilerSetup))
+		Expect(read(mainA)).NotTo(ContainSubstring(addToScheme))
+	})
+})
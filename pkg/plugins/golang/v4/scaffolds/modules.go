@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+// GroupModulePath returns the directory of the Go module which holds the APIs of the given group
+func GroupModulePath(group string) string {
+	return path.Join("api", group)
+}
+
+// addGroupModuleToGoMod requires the group module from the root go.mod and replaces it with
+// its local directory, so that the project always builds against the APIs in the tree.
+func addGroupModuleToGoMod(fs machinery.Filesystem, repo, group string) error {
+	const goModPath = "go.mod"
+
+	bs, err := afero.ReadFile(fs.FS, goModPath)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", goModPath, err)
+	}
+	content := string(bs)
+
+	modulePath := path.Join(repo, GroupModulePath(group))
+	if strings.Contains(content, fmt.Sprintf("replace %s =>", modulePath)) {
+		return nil
+	}
+
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += fmt.Sprintf("\nrequire %s v0.0.0\n\nreplace %s => ./%s\n",
+		modulePath, modulePath, GroupModulePath(group))
+
+	// TODO: instead of writing it directly, we should use the scaffolding machinery for consistency
+	return afero.WriteFile(fs.FS, goModPath, []byte(content), 0644)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+var _ = Describe("Group modules", func() {
+	const (
+		repo   = "example.com/project"
+		domain = "example.com"
+	)
+
+	var (
+		fs  machinery.Filesystem
+		cfg config.Config
+	)
+
+	BeforeEach(func() {
+		fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+
+		cfg = cfgv3.New()
+		Expect(cfg.SetRepository(repo)).To(Succeed())
+		Expect(cfg.SetDomain(domain)).To(Succeed())
+	})
+
+	Context("addGroupModuleToGoMod", func() {
+		BeforeEach(func() {
+			Expect(afero.WriteFile(fs.FS, "go.mod", []byte("module "+repo+"\n\ngo 1.23.0\n"), 0o644)).To(Succeed())
+		})
+
+		It("should require the group module and replace it with its local directory", func() {
+			Expect(addGroupModuleToGoMod(fs, repo, "crew")).To(Succeed())
+
+			content, err := afero.ReadFile(fs.FS, "go.mod")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("module " + repo + "\n\ngo 1.23.0\n" +
+				"\nrequire example.com/project/api/crew v0.0.0\n" +
+				"\nreplace example.com/project/api/crew => ./api/crew\n"))
+		})
+
+		It("should not add the group module twice", func() {
+			Expect(addGroupModuleToGoMod(fs, repo, "crew")).To(Succeed())
+			first, err := afero.ReadFile(fs.FS, "go.mod")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(addGroupModuleToGoMod(fs, repo, "crew")).To(Succeed())
+			second, err := afero.ReadFile(fs.FS, "go.mod")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second).To(Equal(first))
+		})
+
+		It("should add a module per group", func() {
+			Expect(addGroupModuleToGoMod(fs, repo, "crew")).To(Succeed())
+			Expect(addGroupModuleToGoMod(fs, repo, "ship")).To(Succeed())
+
+			content, err := afero.ReadFile(fs.FS, "go.mod")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("replace example.com/project/api/crew => ./api/crew\n"))
+			Expect(string(content)).To(ContainSubstring("replace example.com/project/api/ship => ./api/ship\n"))
+		})
+
+		It("should fail without a go.mod", func() {
+			Expect(fs.FS.Remove("go.mod")).To(Succeed())
+			Expect(addGroupModuleToGoMod(fs, repo, "crew")).NotTo(Succeed())
+		})
+	})
+
+	Context("API scaffolder", func() {
+		newResource := func(group, kind string) resource.Resource {
+			res := resource.Resource{
+				GVK: resource.GVK{
+					Group:   group,
+					Domain:  domain,
+					Version: "v1",
+					Kind:    kind,
+				},
+				Plural: resource.RegularPlural(kind),
+				API: &resource.API{
+					CRDVersion: "v1",
+					Namespaced: true,
+				},
+				Controller: true,
+			}
+			res.Path = resource.APIPackagePath(repo, group, "v1", true)
+			return res
+		}
+
+		scaffoldAPI := func(res resource.Resource) {
+			scaffolder := NewAPIScaffolder(cfg, res, false, ControllerOptions{})
+			scaffolder.InjectFS(fs)
+			Expect(scaffolder.Scaffold()).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			Expect(cfg.SetMultiGroup()).To(Succeed())
+
+			initScaffolder := NewInitScaffolder(cfg, "apache2", "The Kubernetes authors", "kubebuilder")
+			initScaffolder.InjectFS(fs)
+			Expect(initScaffolder.Scaffold()).To(Succeed())
+		})
+
+		It("should keep the APIs in the root module by default", func() {
+			scaffoldAPI(newResource("crew", "Captain"))
+
+			exists, err := afero.Exists(fs.FS, filepath.Join("api", "crew", "go.mod"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			content, err := afero.ReadFile(fs.FS, "go.mod")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).NotTo(ContainSubstring("api/crew"))
+		})
+
+		When("the APIs of each group are scaffolded in their own module", func() {
+			BeforeEach(func() {
+				Expect(SavePluginConfig(cfg, PluginConfig{MultiGroupModules: true})).To(Succeed())
+			})
+
+			It("should scaffold a go.mod for the group and wire it in the root go.mod", func() {
+				scaffoldAPI(newResource("crew", "Captain"))
+
+				content, err := afero.ReadFile(fs.FS, filepath.Join("api", "crew", "go.mod"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(HavePrefix("module example.com/project/api/crew\n"))
+				Expect(string(content)).To(ContainSubstring("sigs.k8s.io/controller-runtime " + ControllerRuntimeVersion))
+
+				exists, err := afero.Exists(fs.FS, filepath.Join("api", "crew", "v1", "captain_types.go"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exists).To(BeTrue())
+
+				content, err = afero.ReadFile(fs.FS, "go.mod")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(ContainSubstring("require example.com/project/api/crew v0.0.0\n"))
+				Expect(string(content)).To(ContainSubstring("replace example.com/project/api/crew => ./api/crew\n"))
+			})
+
+			It("should keep the go.mod of the group when another kind is added to it", func() {
+				scaffoldAPI(newResource("crew", "Captain"))
+				first, err := afero.ReadFile(fs.FS, "go.mod")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(afero.WriteFile(fs.FS, filepath.Join("api", "crew", "go.mod"),
+					[]byte("module example.com/project/api/crew\n\n// edited\n"), 0o644)).To(Succeed())
+
+				scaffoldAPI(newResource("crew", "FirstMate"))
+
+				content, err := afero.ReadFile(fs.FS, filepath.Join("api", "crew", "go.mod"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(ContainSubstring("// edited"))
+
+				second, err := afero.ReadFile(fs.FS, "go.mod")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(second).To(Equal(first))
+			})
+		})
+	})
+})
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+)
+
+// pprofFragment is a piece of code which is scaffolded after the anchor of a file to enable pprof.
+// The fragments must match the code scaffolded by init --with-pprof.
+type pprofFragment struct {
+	path   string
+	anchor string
+	code   string
+}
+
+var pprofFragments = []pprofFragment{
+	{
+		path:   "internal/options/options.go",
+		anchor: "\tEnableHTTP2 bool\n",
+		code: `
+	// PprofBindAddress is the address the pprof endpoint binds to, empty or "0" disables it.
+	PprofBindAddress string
+`,
+	},
+	{
+		path:   "internal/options/options.go",
+		anchor: "\t\t\"If set, HTTP/2 will be enabled for the metrics and webhook servers\")\n",
+		code: `	fs.StringVar(&o.PprofBindAddress, "pprof-bind-address", o.PprofBindAddress,
+		"The address the pprof endpoint binds to, e.g. 127.0.0.1:8082. Leave it empty to disable it.")
+`,
+	},
+	{
+		path:   "cmd/main.go",
+		anchor: "\t\t// LeaderElectionReleaseOnCancel: true,\n",
+		code: `
+		// The pprof endpoint is disabled unless --pprof-bind-address is set. It is not protected by
+		// authn/authz, bind it to the loopback interface and reach it with 'kubectl port-forward'.
+		PprofBindAddress: opts.PprofBindAddress,
+`,
+	},
+}
+
+var _ plugins.Scaffolder = &pprofScaffolder{}
+
+type pprofScaffolder struct {
+	config  config.Config
+	enabled bool
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+}
+
+// NewPprofScaffolder returns a new Scaffolder which enables or disables the pprof endpoint of the manager
+// of an existing project, by adding or removing the --pprof-bind-address flag in internal/options/options.go
+// and its setup in cmd/main.go.
+func NewPprofScaffolder(config config.Config, enabled bool) plugins.Scaffolder {
+	return &pprofScaffolder{
+		config:  config,
+		enabled: enabled,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *pprofScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *pprofScaffolder) Scaffold() error {
+	pluginCfg, err := LoadPluginConfig(s.config)
+	if err != nil {
+		return fmt.Errorf("error loading the plugin configuration: %w", err)
+	}
+
+	for _, fragment := range pprofFragments {
+		content, err := afero.ReadFile(s.fs.FS, fragment.path)
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", fragment.path, err)
+		}
+		str := string(content)
+
+		withCode := fragment.anchor + fragment.code
+		switch {
+		case s.enabled && !strings.Contains(str, withCode):
+			if !strings.Contains(str, fragment.anchor) {
+				return fmt.Errorf("unable to find %q in %s, the flags of the manager must be parsed "+
+					"in internal/options", strings.TrimSpace(fragment.anchor), fragment.path)
+			}
+			str = strings.Replace(str, fragment.anchor, withCode, 1)
+		case !s.enabled:
+			str = strings.Replace(str, withCode, fragment.anchor, 1)
+		}
+
+		if err := afero.WriteFile(s.fs.FS, fragment.path, []byte(str), 0o644); err != nil {
+			return fmt.Errorf("unable to write %s: %w", fragment.path, err)
+		}
+	}
+
+	pluginCfg.Pprof = s.enabled
+	if err := SavePluginConfig(s.config, pluginCfg); err != nil {
+		return fmt.Errorf("error saving the plugin configuration: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/options"
+)
+
+const optionsPath = "internal/options/options.go"
+
+// rateLimiterFragment is a piece of code which is scaffolded in internal/options/options.go, before or after
+// its anchor, to add the options of the rate limiter of the controllers to the options of the manager
+type rateLimiterFragment struct {
+	anchor string
+	code   string
+	before bool
+}
+
+var rateLimiterFragments = []rateLimiterFragment{
+	{
+		anchor: "\t// Zap are the options of the logger.\n",
+		code: `	// RateLimiter are the options of the rate limiter of the controllers.
+	RateLimiter RateLimiterOptions
+
+`,
+		before: true,
+	},
+	{
+		anchor: "\t\tZap: zap.Options{\n",
+		code:   "\t\tRateLimiter:        NewRateLimiterOptions(),\n",
+		before: true,
+	},
+	{
+		anchor: "\to.Zap.BindFlags(fs)\n",
+		code:   "\to.RateLimiter.BindFlags(fs)\n",
+		before: true,
+	},
+	{
+		anchor: "\t\treturn fmt.Errorf(\"the leader election ID is required when the leader election is enabled\")\n\t}\n",
+		code: `	if err := o.RateLimiter.Validate(); err != nil {
+		return err
+	}
+`,
+	},
+}
+
+// scaffoldRateLimiterOptions scaffolds the options of the rate limiter of the controllers and their tests,
+// and adds them to the options of the manager, so that the rate limiter can be tuned with its flags
+func scaffoldRateLimiterOptions(fs machinery.Filesystem, scaffold *machinery.Scaffold) error {
+	content, err := afero.ReadFile(fs.FS, optionsPath)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", optionsPath, err)
+	}
+	str := string(content)
+
+	if !strings.Contains(str, "RateLimiter RateLimiterOptions") {
+		for _, fragment := range rateLimiterFragments {
+			if !strings.Contains(str, fragment.anchor) {
+				return fmt.Errorf("unable to find %q in %s, the flags of the manager must be parsed "+
+					"in internal/options", strings.TrimSpace(fragment.anchor), optionsPath)
+			}
+			withCode := fragment.anchor + fragment.code
+			if fragment.before {
+				withCode = fragment.code + fragment.anchor
+			}
+			str = strings.Replace(str, fragment.anchor, withCode, 1)
+		}
+
+		if err := afero.WriteFile(fs.FS, optionsPath, []byte(str), 0o644); err != nil {
+			return fmt.Errorf("unable to write %s: %w", optionsPath, err)
+		}
+	}
+
+	return scaffold.Execute(
+		&options.RateLimiter{},
+		&options.RateLimiterTest{},
+	)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+const (
+	// storageVersionMarker is the controller-gen marker of the version of a kind which is stored in etcd
+	storageVersionMarker = "// +kubebuilder:storageversion\n"
+	// objectRootMarker is the marker which precedes the markers of the kind in its types file
+	objectRootMarker = "// +kubebuilder:object:root=true\n"
+)
+
+// typesFilePath returns the path of the file which defines the types of the resource
+func typesFilePath(cfg config.Config, res resource.Resource) string {
+	fileName := strings.ToLower(res.Kind) + "_types.go"
+	if cfg.IsMultiGroup() && res.Group != "" {
+		return filepath.Join("api", res.Group, res.Version, fileName)
+	}
+	return filepath.Join("api", res.Version, fileName)
+}
+
+// kindVersions returns the other versions of the kind of the resource which have an API in the project
+func kindVersions(cfg config.Config, res resource.Resource) ([]resource.Resource, error) {
+	resources, err := cfg.GetResourcesByGroup(res.Group)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []resource.Resource
+	for _, r := range resources {
+		if r.HasAPI() && r.Domain == res.Domain && r.Kind == res.Kind && r.Version != res.Version {
+			versions = append(versions, r)
+		}
+	}
+	return versions, nil
+}
+
+// updateStorageVersion tracks the version of the resource as the storage version of its kind in the PROJECT
+// file, adds the storage version marker to its types and removes it from the types of the other versions
+func updateStorageVersion(fs machinery.Filesystem, cfg config.Config, res resource.Resource) error {
+	if err := cfg.SetStorageVersion(res.GVK); err != nil {
+		return fmt.Errorf("error tracking the storage version: %w", err)
+	}
+
+	path := typesFilePath(cfg, res)
+	content, err := afero.ReadFile(fs.FS, path)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	if !strings.Contains(string(content), storageVersionMarker) {
+		if !strings.Contains(string(content), objectRootMarker) {
+			return fmt.Errorf("unable to find the marker %q in %s", strings.TrimSpace(objectRootMarker), path)
+		}
+		content = []byte(strings.Replace(string(content), objectRootMarker, objectRootMarker+storageVersionMarker, 1))
+		if err := afero.WriteFile(fs.FS, path, content, 0o644); err != nil {
+			return fmt.Errorf("unable to write %s: %w", path, err)
+		}
+	}
+
+	versions, err := kindVersions(cfg, res)
+	if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		path := typesFilePath(cfg, version)
+		content, err := afero.ReadFile(fs.FS, path)
+		if errors.Is(err, afero.ErrFileNotFound) {
+			log.Warnf("Unable to find %s to remove the storage version marker of the version %s",
+				path, version.Version)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("unable to read %s: %w", path, err)
+		}
+		if !strings.Contains(string(content), storageVersionMarker) {
+			continue
+		}
+		content = []byte(strings.ReplaceAll(string(content), storageVersionMarker, ""))
+		if err := afero.WriteFile(fs.FS, path, content, 0o644); err != nil {
+			return fmt.Errorf("unable to write %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
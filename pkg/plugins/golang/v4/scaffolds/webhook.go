@@ -17,14 +17,11 @@ limitations under the License.
 package scaffolds
 
 import (
-	"errors"
 	"fmt"
-	"strings"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/api"
 
 	log "github.com/sirupsen/logrus"
-	"github.com/spf13/afero"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
@@ -32,7 +29,6 @@ import (
 	pluginutil "sigs.k8s.io/kubebuilder/v4/pkg/plugin/util"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/cmd"
-	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/hack"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/test/e2e"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/v4/scaffolds/internal/templates/webhooks"
 )
@@ -52,16 +48,27 @@ type webhookScaffolder struct {
 	// Deprecated - TODO: remove it for go/v5
 	// isLegacy indicates that the resource should be created in the legacy path under the api
 	isLegacy bool
+
+	// webhookOptions defines the optional features scaffolded in the webhooks
+	webhookOptions WebhookOptions
+}
+
+// WebhookOptions defines the optional features which can be scaffolded in the webhooks
+type WebhookOptions struct {
+	// Programmatic scaffolds the defaulting and validating webhooks as admission.Handler implementations
+	// which receive the raw admission request and decode the object with an injected admission.Decoder
+	Programmatic bool
 }
 
 // NewWebhookScaffolder returns a new Scaffolder for v2 webhook creation operations
 func NewWebhookScaffolder(config config.Config, resource resource.Resource,
-	force bool, isLegacy bool) plugins.Scaffolder {
+	force bool, isLegacy bool, webhookOptions WebhookOptions) plugins.Scaffolder {
 	return &webhookScaffolder{
-		config:   config,
-		resource: resource,
-		force:    force,
-		isLegacy: isLegacy,
+		config:         config,
+		resource:       resource,
+		force:          force,
+		isLegacy:       isLegacy,
+		webhookOptions: webhookOptions,
 	}
 }
 
@@ -75,24 +82,15 @@ func (s *webhookScaffolder) Scaffold() error {
 	log.Println("Writing scaffold for you to edit...")
 
 	// Load the boilerplate
-	boilerplate, err := afero.ReadFile(s.fs.FS, hack.DefaultBoilerplatePath)
+	boilerplate, err := LoadBoilerplate(s.fs, s.config)
 	if err != nil {
-		if errors.Is(err, afero.ErrFileNotFound) {
-			log.Warnf("Unable to find %s : %s .\n"+
-				"This file is used to generate the license header in the project.\n"+
-				"Note that controller-gen will also use this. Therefore, ensure that you "+
-				"add the license file or configure your project accordingly.",
-				hack.DefaultBoilerplatePath, err)
-			boilerplate = []byte("")
-		} else {
-			return fmt.Errorf("error scaffolding webhook: unable to load boilerplate: %w", err)
-		}
+		return fmt.Errorf("error scaffolding webhook: %w", err)
 	}
 
 	// Initialize the machinery.Scaffold that will write the files to disk
 	scaffold := machinery.NewScaffold(s.fs,
 		machinery.WithConfig(s.config),
-		machinery.WithBoilerplate(string(boilerplate)),
+		machinery.WithBoilerplate(boilerplate),
 		machinery.WithResource(&s.resource),
 	)
 
@@ -105,30 +103,50 @@ func (s *webhookScaffolder) Scaffold() error {
 		return fmt.Errorf("error updating resource: %w", err)
 	}
 
-	if err := scaffold.Execute(
-		&webhooks.Webhook{Force: s.force, IsLegacyPath: s.isLegacy},
-		&e2e.WebhookTestUpdater{WireWebhook: true},
+	// The ValidatingAdmissionPolicy is evaluated by the API server, so no webhook is served by the manager
+	if !doDefaulting && !doValidation && !doConversion {
+		return nil
+	}
+
+	pluginCfg, err := LoadPluginConfig(s.config)
+	if err != nil {
+		return fmt.Errorf("error loading the plugin configuration: %w", err)
+	}
+
+	builders := []machinery.Builder{
+		&webhooks.Webhook{Force: s.force, IsLegacyPath: s.isLegacy, Programmatic: s.webhookOptions.Programmatic},
 		&cmd.MainUpdater{WireWebhook: true, IsLegacyPath: s.isLegacy},
-		&webhooks.WebhookTest{Force: s.force, IsLegacyPath: s.isLegacy},
-	); err != nil {
+		&webhooks.WebhookTest{Force: s.force, IsLegacyPath: s.isLegacy, Programmatic: s.webhookOptions.Programmatic},
+	}
+	// The webhook checks are only added to the Ginkgo e2e suite
+	if !pluginCfg.UsesChainsaw() {
+		webhookResources, err := e2eWebhookResources(s.config)
+		if err != nil {
+			return err
+		}
+		builders = append(builders,
+			&e2e.WebhookTestUpdater{WireWebhook: true},
+			&e2e.WebhooksTest{Resources: webhookResources},
+		)
+	}
+
+	if err := scaffold.Execute(builders...); err != nil {
 		return err
 	}
 
 	if doConversion {
-		resourceFilePath := fmt.Sprintf("api/%s/%s_types.go",
-			s.resource.Version, strings.ToLower(s.resource.Kind))
-		if s.config.IsMultiGroup() {
-			resourceFilePath = fmt.Sprintf("api/%s/%s/%s_types.go",
-				s.resource.Group, s.resource.Version,
-				strings.ToLower(s.resource.Kind))
+		// The hub of the conversion is the storage version of the kind
+		if err := updateStorageVersion(s.fs, s.config, s.resource); err != nil {
+			log.Errorf("Unable to set the version %s as the storage version of the kind %s: %v",
+				s.resource.Version, s.resource.Kind, err)
 		}
 
+		resourceFilePath := typesFilePath(s.config, s.resource)
 		err = pluginutil.InsertCodeIfNotExist(resourceFilePath,
-			"// +kubebuilder:object:root=true",
-			"\n// +kubebuilder:storageversion\n// +kubebuilder:conversion:hub")
+			"// +kubebuilder:storageversion",
+			"\n// +kubebuilder:conversion:hub")
 		if err != nil {
-			log.Errorf("Unable to insert storage version marker "+
-				"(// +kubebuilder:storageversion) and the hub conversion (// +kubebuilder:conversion:hub) "+
+			log.Errorf("Unable to insert the hub conversion marker (// +kubebuilder:conversion:hub) "+
 				"in file %s: %v", resourceFilePath, err)
 		}
 
@@ -142,6 +160,7 @@ func (s *webhookScaffolder) Scaffold() error {
 			log.Printf("Scaffolding for spoke version: %s\n", spoke)
 			if err := scaffold.Execute(
 				&api.Spoke{Force: s.force, SpokeVersion: spoke},
+				&api.SpokeTest{Force: s.force, SpokeVersion: spoke},
 			); err != nil {
 				return fmt.Errorf("failed to scaffold spoke %s: %w", spoke, err)
 			}
@@ -151,11 +170,18 @@ func (s *webhookScaffolder) Scaffold() error {
 You need to implement the conversion.Hub and conversion.Convertible interfaces for your CRD types.`)
 	}
 
-	// TODO: Add test suite for conversion webhook after #1664 has been merged & conversion tests supported in envtest.
 	if doDefaulting || doValidation {
-		if err := scaffold.Execute(
-			&webhooks.WebhookSuite{IsLegacyPath: s.isLegacy},
-		); err != nil {
+		builders := []machinery.Builder{&webhooks.WebhookSuite{IsLegacyPath: s.isLegacy}}
+		// The objects of the CRDs of the project are sent to the API server of the test environment
+		if !s.isLegacy && !s.resource.Core && !s.resource.External {
+			// The scope of the kind is tracked by its API, which the resource of the webhook does not contain
+			namespaced := true
+			if res, err := s.config.GetResource(s.resource.GVK); err == nil && res.HasAPI() {
+				namespaced = res.API.Namespaced
+			}
+			builders = append(builders, &webhooks.WebhookIntegrationTest{Namespaced: namespaced, Force: s.force})
+		}
+		if err := scaffold.Execute(builders...); err != nil {
 			return err
 		}
 	}
@@ -175,3 +201,24 @@ You need to implement the conversion.Hub and conversion.Convertible interfaces f
 	}
 	return nil
 }
+
+// e2eWebhookResources returns the APIs of the project with a defaulting, validation or conversion webhook,
+// which are exercised with their samples by the e2e tests
+func e2eWebhookResources(cfg config.Config) ([]resource.Resource, error) {
+	resources, err := cfg.GetResources()
+	if err != nil {
+		return nil, fmt.Errorf("error getting the resources of the project: %w", err)
+	}
+
+	var webhookResources []resource.Resource
+	for _, res := range resources {
+		// The core and external types have no sample in config/samples
+		if !res.HasAPI() || res.IsExternal() {
+			continue
+		}
+		if res.HasDefaultingWebhook() || res.HasValidationWebhook() || res.HasConversionWebhook() {
+			webhookResources = append(webhookResources, res)
+		}
+	}
+	return webhookResources, nil
+}
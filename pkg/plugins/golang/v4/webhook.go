@@ -52,6 +52,9 @@ type createWebhookSubcommand struct {
 
 	// runMake indicates whether to run make or not after scaffolding APIs
 	runMake bool
+
+	// webhookOptions defines the optional features scaffolded in the webhooks
+	webhookOptions scaffolds.WebhookOptions
 }
 
 func (p *createWebhookSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
@@ -59,15 +62,39 @@ func (p *createWebhookSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, sub
 
 	subcmdMeta.Description = `Scaffold a webhook for an API resource. You can choose to scaffold defaulting,
 validating and/or conversion webhooks.
+
+When scaffolding a conversion webhook, the version informed is used as the Hub (storage version)
+and each version passed in --spoke gets the ConvertTo/ConvertFrom stubs and a round-trip
+conversion test.
+
+On clusters running Kubernetes 1.30+, the validation can be implemented with a ValidatingAdmissionPolicy
+(--validating-admission-policy) instead of a validating webhook. Its CEL expressions are evaluated
+by the API server, so the manager does not need to serve webhooks and manage their certificates.
+The policy and its binding are scaffolded under config/policies, with CEL expressions stubbed
+from the validation markers of the kind.
 `
 	subcmdMeta.Examples = fmt.Sprintf(`  # Create defaulting and validating webhooks for Group: ship, Version: v1beta1
   # and Kind: Frigate
   %[1]s create webhook --group ship --version v1beta1 --kind Frigate --defaulting --programmatic-validation
 
+  # Create defaulting and validating webhooks which handle the raw admission requests
+  # for Group: ship, Version: v1beta1 and Kind: Frigate
+  %[1]s create webhook --group ship --version v1beta1 --kind Frigate --defaulting --programmatic-validation \
+    --programmatic
+
   # Create conversion webhook for Group: ship, Version: v1beta1
   # and Kind: Frigate
   %[1]s create webhook --group ship --version v1beta1 --kind Frigate --conversion --spoke v1
+
+  # Create a ValidatingAdmissionPolicy instead of a validating webhook for Group: ship,
+  # Version: v1beta1 and Kind: Frigate
+  %[1]s create webhook --group ship --version v1beta1 --kind Frigate --validating-admission-policy
 `, cliMeta.CommandName)
+
+	subcmdMeta.Flags = append(subcmdMeta.Flags, plugin.FlagMetadata{
+		Name:       "legacy",
+		Deprecated: "the resources are created under the internal directory, this flag will be removed in go/v5",
+	})
 }
 
 func (p *createWebhookSubcommand) BindFlags(fs *pflag.FlagSet) {
@@ -83,6 +110,12 @@ func (p *createWebhookSubcommand) BindFlags(fs *pflag.FlagSet) {
 		"if set, scaffold the validating webhook")
 	fs.BoolVar(&p.options.DoConversion, "conversion", false,
 		"if set, scaffold the conversion webhook")
+	fs.BoolVar(&p.options.DoValidatingAdmissionPolicy, "validating-admission-policy", false,
+		"if set, scaffold a ValidatingAdmissionPolicy and its binding, whose CEL expressions are evaluated "+
+			"by the API server (Kubernetes 1.30+), instead of a validating webhook")
+	fs.BoolVar(&p.webhookOptions.Programmatic, "programmatic", false,
+		"if set, scaffold the defaulting and validating webhooks as admission handlers which decode "+
+			"the raw admission request instead of implementing the CustomDefaulter and CustomValidator interfaces")
 
 	fs.StringSliceVar(&p.options.Spoke, "spoke",
 		nil,
@@ -90,8 +123,7 @@ func (p *createWebhookSubcommand) BindFlags(fs *pflag.FlagSet) {
 
 	// TODO: remove for go/v5
 	fs.BoolVar(&p.isLegacyPath, "legacy", false,
-		"[DEPRECATED] Attempts to create resource under the API directory (legacy path). "+
-			"This option will be removed in future versions.")
+		"Attempts to create resource under the API directory (legacy path)")
 
 	fs.StringVar(&p.options.ExternalAPIPath, "external-api-path", "",
 		"Specify the Go package import path for the external API. This is used to scaffold controllers for resources "+
@@ -101,6 +133,10 @@ func (p *createWebhookSubcommand) BindFlags(fs *pflag.FlagSet) {
 		"Specify the domain name for the external API. This domain is used to generate accurate RBAC "+
 			"markers and permissions for the external resources (e.g., cert-manager.io).")
 
+	fs.StringVar(&p.options.ExternalAPIModule, "external-api-module", "",
+		"Specify the Go module which provides the external API, optionally with its version "+
+			"(e.g., github.com/cert-manager/cert-manager@v1.17.0). It is added to the go.mod of the project.")
+
 	fs.BoolVar(&p.force, "force", false,
 		"attempt to create resource even if it already exists")
 }
@@ -113,11 +149,27 @@ func (p *createWebhookSubcommand) InjectConfig(c config.Config) error {
 func (p *createWebhookSubcommand) InjectResource(res *resource.Resource) error {
 	p.resource = res
 
+	if err := p.options.ValidateExternalAPI(); err != nil {
+		return err
+	}
+	if err := p.options.ValidatePlural(p.resource.GVK, p.config); err != nil {
+		return err
+	}
+
 	if len(p.options.ExternalAPIPath) != 0 && len(p.options.ExternalAPIDomain) != 0 && p.isLegacyPath {
 		return errors.New("You cannot scaffold webhooks for external types " +
 			"using the legacy path")
 	}
 
+	if p.webhookOptions.Programmatic {
+		if p.isLegacyPath {
+			return errors.New("--programmatic cannot be used with --legacy")
+		}
+		if !p.options.DoDefaulting && !p.options.DoValidation {
+			return errors.New("--programmatic requires --defaulting and/or --programmatic-validation")
+		}
+	}
+
 	for _, spoke := range p.options.Spoke {
 		spoke = strings.TrimSpace(spoke)
 		if !isValidVersion(spoke, res, p.config) {
@@ -132,9 +184,10 @@ func (p *createWebhookSubcommand) InjectResource(res *resource.Resource) error {
 		return err
 	}
 
-	if !p.resource.HasDefaultingWebhook() && !p.resource.HasValidationWebhook() && !p.resource.HasConversionWebhook() {
+	if !p.resource.HasDefaultingWebhook() && !p.resource.HasValidationWebhook() &&
+		!p.resource.HasConversionWebhook() && !p.resource.HasValidatingAdmissionPolicy() {
 		return fmt.Errorf("%s create webhook requires at least one of --defaulting,"+
-			" --programmatic-validation and --conversion to be true", p.commandName)
+			" --programmatic-validation, --conversion and --validating-admission-policy to be true", p.commandName)
 	}
 
 	// check if resource exist to create webhook
@@ -155,12 +208,17 @@ func (p *createWebhookSubcommand) InjectResource(res *resource.Resource) error {
 }
 
 func (p *createWebhookSubcommand) Scaffold(fs machinery.Filesystem) error {
-	scaffolder := scaffolds.NewWebhookScaffolder(p.config, *p.resource, p.force, p.isLegacyPath)
+	scaffolder := scaffolds.NewWebhookScaffolder(p.config, *p.resource, p.force, p.isLegacyPath,
+		p.webhookOptions)
 	scaffolder.InjectFS(fs)
 	return scaffolder.Scaffold()
 }
 
 func (p *createWebhookSubcommand) PostScaffold() error {
+	if err := getExternalAPIModule(p.resource); err != nil {
+		return err
+	}
+
 	err := pluginutil.RunCmd("Update dependencies", "go", "mod", "tidy")
 	if err != nil {
 		return err
@@ -173,6 +231,11 @@ func (p *createWebhookSubcommand) PostScaffold() error {
 		}
 	}
 
+	if !p.resource.Webhooks.HasWebhookServer() {
+		fmt.Print("Next: implement the CEL expressions of the ValidatingAdmissionPolicy under config/policies\n")
+		return nil
+	}
+
 	fmt.Print("Next: implement your new Webhook and generate the manifests with:\n$ make manifests\n")
 
 	return nil
@@ -180,15 +243,15 @@ func (p *createWebhookSubcommand) PostScaffold() error {
 
 // Helper function to validate spoke versions
 func isValidVersion(version string, res *resource.Resource, config config.Config) bool {
-	// Fetch all resources in the config
-	resources, err := config.GetResources()
+	// Fetch the resources of the given version in the same Group
+	resources, err := config.GetResourcesByGroupVersion(res.Group, version)
 	if err != nil {
 		return false
 	}
 
-	// Iterate through resources and validate if the given version exists for the same Group and Kind
+	// Validate if the given version exists for the same Kind
 	for _, r := range resources {
-		if r.Group == res.Group && r.Kind == res.Kind && r.Version == version {
+		if r.Kind == res.Kind {
 			return true
 		}
 	}
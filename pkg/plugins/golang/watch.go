@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package golang
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+const (
+	// WatchOwns watches the objects owned by the reconciled object, i.e. with its controller reference
+	WatchOwns = "owns"
+	// WatchMap watches objects which are not owned by the reconciled object, mapped to the reconcile requests
+	// with a function
+	WatchMap = "map"
+
+	// defaultWatchVersion is the version of the watched kind when it is not informed
+	defaultWatchVersion = "v1"
+)
+
+// WatchModes are the ways a secondary resource can be watched by a controller
+var WatchModes = []string{WatchOwns, WatchMap}
+
+// Watch is a secondary resource watched by a controller, whose events trigger the reconciliation of the
+// objects of the primary resource
+type Watch struct {
+	// Resource is the watched resource, either a core type or an API of the project
+	Resource resource.Resource
+
+	// Mode is either WatchOwns or WatchMap
+	Mode string
+}
+
+// ParseWatch parses a watched resource, formatted as <group>/[<version>/]<kind>[:<mode>]. The version defaults
+// to v1 for the core types and to the first version of the kind for the APIs of the project, and the mode
+// defaults to WatchOwns.
+func ParseWatch(value string, c config.Config) (Watch, error) {
+	watch := Watch{Mode: WatchOwns}
+
+	gvk := value
+	if i := strings.LastIndex(value, ":"); i >= 0 {
+		gvk, watch.Mode = value[:i], value[i+1:]
+		if watch.Mode != WatchOwns && watch.Mode != WatchMap {
+			return Watch{}, fmt.Errorf("invalid mode %q of the watch %q, must be one of %q", watch.Mode, value, WatchModes)
+		}
+	}
+
+	var group, version, kind string
+	switch parts := strings.Split(gvk, "/"); len(parts) {
+	case 2:
+		group, kind = parts[0], parts[1]
+	case 3:
+		group, version, kind = parts[0], parts[1], parts[2]
+	default:
+		return Watch{}, fmt.Errorf("invalid watch %q, must be formatted as <group>/[<version>/]<kind>[:<mode>]", value)
+	}
+	if group == "" || kind == "" {
+		return Watch{}, fmt.Errorf("invalid watch %q, the group and the kind are required", value)
+	}
+
+	// The APIs of the project, including the external APIs it references, take precedence over the core types
+	resources, err := c.GetResources()
+	if err != nil {
+		return Watch{}, fmt.Errorf("unable to get the resources of the project: %w", err)
+	}
+	for _, res := range resources {
+		if res.Group != group || res.Kind != kind || (version != "" && res.Version != version) || res.Path == "" {
+			continue
+		}
+		if res.Plural == "" {
+			res.Plural = resource.RegularPlural(res.Kind)
+		}
+		watch.Resource = res
+		return watch, nil
+	}
+
+	domain, found := coreGroups[group]
+	if !found {
+		return Watch{}, fmt.Errorf("the kind %s of the watch %q is neither a core type nor an API of the project",
+			kind, value)
+	}
+	if version == "" {
+		version = defaultWatchVersion
+	}
+	watch.Resource = resource.Resource{
+		GVK: resource.GVK{
+			Group:   group,
+			Domain:  domain,
+			Version: version,
+			Kind:    kind,
+		},
+		Plural: resource.RegularPlural(kind),
+		// The packages of the core groups are named after the first label of the group, e.g. rbac
+		Path: path.Join("k8s.io", "api", strings.Split(group, ".")[0], version),
+		Core: true,
+	}
+	return watch, nil
+}
+
+// Owns returns true if the watched objects are owned by the reconciled object
+func (w Watch) Owns() bool {
+	return w.Mode == WatchOwns
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package golang
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+var _ = Describe("ParseWatch", func() {
+	var cfg config.Config
+
+	BeforeEach(func() {
+		cfg = cfgv3.New()
+		Expect(cfg.SetRepository("test")).To(Succeed())
+		Expect(cfg.SetDomain("test.io")).To(Succeed())
+		Expect(cfg.AddResource(resource.Resource{
+			GVK:    resource.GVK{Group: "crew", Domain: "test.io", Version: "v1beta1", Kind: "Captain"},
+			Plural: "captains",
+			Path:   "test/api/v1beta1",
+			API:    &resource.API{CRDVersion: "v1", Namespaced: true},
+		})).To(Succeed())
+	})
+
+	DescribeTable("should parse the watched resources",
+		func(value, mode, qualifiedGroup, version, kind, plural, path string) {
+			watch, err := ParseWatch(value, cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(watch.Mode).To(Equal(mode))
+			Expect(watch.Owns()).To(Equal(mode == WatchOwns))
+			Expect(watch.Resource.QualifiedGroup()).To(Equal(qualifiedGroup))
+			Expect(watch.Resource.Version).To(Equal(version))
+			Expect(watch.Resource.Kind).To(Equal(kind))
+			Expect(watch.Resource.Plural).To(Equal(plural))
+			Expect(watch.Resource.Path).To(Equal(path))
+		},
+		Entry("for a core type", "apps/Deployment",
+			WatchOwns, "apps", "v1", "Deployment", "deployments", "k8s.io/api/apps/v1"),
+		Entry("for a core type with its version and a mode", "core/v1/ConfigMap:map",
+			WatchMap, "core", "v1", "ConfigMap", "configmaps", "k8s.io/api/core/v1"),
+		Entry("for a core type of a qualified group", "networking/Ingress:owns",
+			WatchOwns, "networking.k8s.io", "v1", "Ingress", "ingresses", "k8s.io/api/networking/v1"),
+		Entry("for a core type of a group with several labels", "rbac.authorization/RoleBinding",
+			WatchOwns, "rbac.authorization.k8s.io", "v1", "RoleBinding", "rolebindings", "k8s.io/api/rbac/v1"),
+		Entry("for an API of the project", "crew/Captain:map",
+			WatchMap, "crew.test.io", "v1beta1", "Captain", "captains", "test/api/v1beta1"),
+	)
+
+	DescribeTable("should fail",
+		func(value string) {
+			_, err := ParseWatch(value, cfg)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("without group", "Deployment"),
+		Entry("with an empty kind", "apps/"),
+		Entry("with too many parts", "apps/v1/Deployment/extra"),
+		Entry("with an invalid mode", "apps/Deployment:watch"),
+		Entry("for an unknown group", "ship/Frigate"),
+		Entry("for another version of an API of the project", "crew/v1/Captain"),
+	)
+})
@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package golang
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// goWorkFile is the name of the file which defines a Go workspace
+const goWorkFile = "go.work"
+
+// Workspace is a Go workspace, defined by a go.work file, which holds the project
+type Workspace struct {
+	// Dir is the directory of the go.work file
+	Dir string
+	// ProjectPath is the slash-separated path of the project relative to Dir, "." when they are the same
+	ProjectPath string
+	// Modules maps the slash-separated directories of the modules used by the workspace, relative to Dir,
+	// to their module paths
+	Modules map[string]string
+}
+
+// FindWorkspace finds the Go workspace which holds the given directory. As the go command does, it uses the
+// go.work file set with the GOWORK environment variable, if any, or the first one found in the directory or
+// in its parents. It returns nil if the directory is not part of a workspace.
+func FindWorkspace(dir string) (*Workspace, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	goWork := os.Getenv("GOWORK")
+	switch goWork {
+	case "off":
+		return nil, nil
+	case "":
+		goWork = findGoWork(absDir)
+		if goWork == "" {
+			return nil, nil
+		}
+	}
+
+	data, err := os.ReadFile(goWork)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the Go workspace: %w", err)
+	}
+	workFile, err := modfile.ParseWork(goWork, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the Go workspace: %w", err)
+	}
+
+	ws := &Workspace{
+		Dir:     filepath.Dir(goWork),
+		Modules: make(map[string]string, len(workFile.Use)),
+	}
+	projectPath, err := filepath.Rel(ws.Dir, absDir)
+	if err != nil {
+		return nil, err
+	}
+	ws.ProjectPath = filepath.ToSlash(projectPath)
+	if ws.ProjectPath == ".." || strings.HasPrefix(ws.ProjectPath, "../") {
+		return nil, fmt.Errorf("the directory %s is not part of the Go workspace %s", absDir, goWork)
+	}
+
+	for _, use := range workFile.Use {
+		moduleDir := use.Path
+		if !filepath.IsAbs(moduleDir) {
+			moduleDir = filepath.Join(ws.Dir, moduleDir)
+		}
+		// The modules which are not scaffolded yet, or were removed, are listed without module path
+		var modulePath string
+		if goMod, err := os.ReadFile(filepath.Join(moduleDir, "go.mod")); err == nil {
+			modulePath = modfile.ModulePath(goMod)
+		}
+		if rel, err := filepath.Rel(ws.Dir, moduleDir); err == nil {
+			moduleDir = rel
+		}
+		ws.Modules[filepath.ToSlash(moduleDir)] = modulePath
+	}
+
+	return ws, nil
+}
+
+// findGoWork returns the path of the first go.work file found in the directory or in its parents, if any
+func findGoWork(dir string) string {
+	for {
+		if info, err := os.Stat(filepath.Join(dir, goWorkFile)); err == nil && !info.IsDir() {
+			return filepath.Join(dir, goWorkFile)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// UsesProject returns true if the project is one of the modules used by the workspace
+func (ws Workspace) UsesProject() bool {
+	_, found := ws.Modules[ws.ProjectPath]
+	return found
+}
+
+// RootPath returns the slash-separated path of the root of the workspace relative to the project
+func (ws Workspace) RootPath() string {
+	if ws.ProjectPath == "." {
+		return "."
+	}
+	return strings.TrimSuffix(strings.Repeat("../", strings.Count(ws.ProjectPath, "/")+1), "/")
+}
+
+// GuessModulePath guesses the module path of the project from the modules used by the workspace: the path of
+// the project is appended to the module path of the root of the workspace, or of the first module whose path
+// ends with its directory. It returns an empty string if the module path can not be guessed.
+func (ws Workspace) GuessModulePath() string {
+	if rootModule := ws.Modules["."]; rootModule != "" {
+		return path.Join(rootModule, ws.ProjectPath)
+	}
+
+	dirs := make([]string, 0, len(ws.Modules))
+	for dir := range ws.Modules {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		modulePath := ws.Modules[dir]
+		if dir == ws.ProjectPath || strings.HasPrefix(dir, "../") || !strings.HasSuffix(modulePath, "/"+dir) {
+			continue
+		}
+		return path.Join(strings.TrimSuffix(modulePath, "/"+dir), ws.ProjectPath)
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package golang
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Workspace", func() {
+	var root string
+
+	writeFile := func(name, content string) {
+		name = filepath.Join(root, name)
+		Expect(os.MkdirAll(filepath.Dir(name), 0o755)).To(Succeed())
+		Expect(os.WriteFile(name, []byte(content), 0o644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		root, err = os.MkdirTemp("", "workspace")
+		Expect(err).NotTo(HaveOccurred())
+		// Resolve the symbolic links of the temporary directory, e.g. on macOS, as filepath.Abs does not
+		root, err = filepath.EvalSymlinks(root)
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoT().Setenv("GOWORK", "")
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(root)).To(Succeed())
+	})
+
+	Context("FindWorkspace", func() {
+		It("should return nil outside of a workspace", func() {
+			Expect(FindWorkspace(root)).To(BeNil())
+		})
+
+		It("should find the go.work file in a parent directory", func() {
+			writeFile("go.work", "go 1.23\n\nuse (\n\t./libs/common\n\t./services/api\n)\n")
+			writeFile("libs/common/go.mod", "module github.com/example/mono/libs/common\n\ngo 1.23\n")
+			writeFile("services/api/go.mod", "module github.com/example/mono/services/api\n\ngo 1.23\n")
+			Expect(os.MkdirAll(filepath.Join(root, "operators", "memcached"), 0o755)).To(Succeed())
+
+			ws, err := FindWorkspace(filepath.Join(root, "operators", "memcached"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ws).NotTo(BeNil())
+			Expect(ws.Dir).To(Equal(root))
+			Expect(ws.ProjectPath).To(Equal("operators/memcached"))
+			Expect(ws.Modules).To(Equal(map[string]string{
+				"libs/common":  "github.com/example/mono/libs/common",
+				"services/api": "github.com/example/mono/services/api",
+			}))
+			Expect(ws.UsesProject()).To(BeFalse())
+			Expect(ws.RootPath()).To(Equal("../.."))
+			Expect(ws.GuessModulePath()).To(Equal("github.com/example/mono/operators/memcached"))
+		})
+
+		It("should use the go.work file set with GOWORK", func() {
+			writeFile("work/go.work", "go 1.23\n\nuse ..\n")
+			writeFile("go.mod", "module github.com/example/mono\n\ngo 1.23\n")
+			GinkgoT().Setenv("GOWORK", filepath.Join(root, "work", "go.work"))
+
+			_, err := FindWorkspace(root)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return nil when GOWORK is off", func() {
+			writeFile("go.work", "go 1.23\n")
+			GinkgoT().Setenv("GOWORK", "off")
+
+			Expect(FindWorkspace(root)).To(BeNil())
+		})
+
+		It("should fail when the go.work file is invalid", func() {
+			writeFile("go.work", "use (\n")
+
+			_, err := FindWorkspace(root)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("GuessModulePath", func() {
+		It("should append the path of the project to the module path of the root", func() {
+			ws := Workspace{
+				ProjectPath: "operator",
+				Modules:     map[string]string{".": "example.com/mono", "operator": ""},
+			}
+			Expect(ws.UsesProject()).To(BeTrue())
+			Expect(ws.GuessModulePath()).To(Equal("example.com/mono/operator"))
+		})
+
+		It("should not guess the module path when the modules are not named after their directories", func() {
+			ws := Workspace{
+				ProjectPath: "operator",
+				Modules:     map[string]string{"api": "example.com/apis"},
+			}
+			Expect(ws.GuessModulePath()).To(BeEmpty())
+		})
+
+		It("should return the root of the workspace for a project at the root", func() {
+			ws := Workspace{ProjectPath: "."}
+			Expect(ws.RootPath()).To(Equal("."))
+		})
+	})
+})
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"errors"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+)
+
+// trackCliVersion records the version of the CLI in the plugin configuration. Only the releases are recorded,
+// since the update script downloads the version to update from: the version recorded by a previous release
+// is kept when the project is scaffolded with a development build.
+func trackCliVersion(target config.Config, cliVersion string) error {
+	cfg := pluginConfig{}
+	if isRelease(cliVersion) {
+		cfg.CliVersion = cliVersion
+	} else if err := target.DecodePluginConfig(pluginKey, &cfg); err != nil &&
+		!errors.As(err, &config.PluginKeyNotFoundError{}) && !errors.As(err, &config.UnsupportedFieldError{}) {
+		return err
+	}
+
+	return insertPluginMetaToConfig(target, cfg)
+}
+
+// isRelease returns true if the version is a Kubebuilder release, and not a pseudo-version
+// or a dirty build (e.g. v4.0.0-20250101000000-abcdef123456+dirty)
+func isRelease(version string) bool {
+	return semver.IsValid(version) && !module.IsPseudoVersion(version) && semver.Build(version) == ""
+}
+
+// insertPluginMetaToConfig will insert the metadata to the plugin configuration
+func insertPluginMetaToConfig(target config.Config, cfg pluginConfig) error {
+	err := target.DecodePluginConfig(pluginKey, &pluginConfig{})
+	if !errors.As(err, &config.UnsupportedFieldError{}) {
+		if err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
+			return err
+		}
+		if err = target.EncodePluginConfig(pluginKey, cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
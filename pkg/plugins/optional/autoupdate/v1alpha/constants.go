@@ -0,0 +1,33 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+//nolint:lll
+const metaDataDescription = `This command will add a GitHub Action to the project which keeps it up to date with the latest Kubebuilder release:
+  - A workflow which runs periodically, or manually, the script below.
+	('.github/workflows/auto-update.yml')
+  - A script which runs 'kubebuilder alpha update' when a new Kubebuilder release is available and opens a Pull Request with the result.
+	('hack/auto-update.sh')
+
+The Kubebuilder release used to scaffold the project is tracked in the PROJECT file
+(in the 'cliVersion' field of this plugin) and it is used as the version to update from.
+The development builds are not tracked, since they can not be downloaded by the script.
+
+NOTE: This plugin requires:
+- The project to be hosted on GitHub.
+- The GitHub Actions to be allowed to create Pull Requests (Settings > Actions > General > Workflow permissions).
+`
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/autoupdate/v1alpha/scaffolds"
+)
+
+var _ plugin.EditSubcommand = &editSubcommand{}
+
+type editSubcommand struct {
+	config config.Config
+	force  bool
+
+	// cliVersion is the version of the CLI which is tracked in the PROJECT file
+	cliVersion string
+}
+
+func (p *editSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	p.cliVersion = cliMeta.CliVersion
+
+	subcmdMeta.Description = metaDataDescription
+
+	subcmdMeta.Examples = fmt.Sprintf(`  # Add the auto update workflow to a project, or record the current Kubebuilder version after an update
+  %[1]s edit --plugins=%[2]s
+
+  # Overwrite the workflow and the script with the latest scaffold
+  %[1]s edit --plugins=%[2]s --force
+`, cliMeta.CommandName, pluginKey)
+}
+
+func (p *editSubcommand) BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&p.force, "force", false, "if true, overwrites the workflow and the script")
+}
+
+func (p *editSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+	return nil
+}
+
+func (p *editSubcommand) Scaffold(fs machinery.Filesystem) error {
+	if err := trackCliVersion(p.config, p.cliVersion); err != nil {
+		return err
+	}
+
+	scaffolder := scaffolds.NewInitScaffolder(p.force)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+var _ = Describe("editSubcommand", func() {
+	const (
+		workflow = ".github/workflows/auto-update.yml"
+		script   = "hack/auto-update.sh"
+	)
+
+	var (
+		fs     machinery.Filesystem
+		cfg    config.Config
+		subCmd *editSubcommand
+		flags  *pflag.FlagSet
+	)
+
+	edit := func(cliVersion string, args ...string) error {
+		subCmd = &editSubcommand{}
+		subCmd.UpdateMetadata(plugin.CLIMetadata{CommandName: "kubebuilder", CliVersion: cliVersion},
+			&plugin.SubcommandMetadata{})
+		flags = pflag.NewFlagSet("edit", pflag.ContinueOnError)
+		subCmd.BindFlags(flags)
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if err := subCmd.InjectConfig(cfg); err != nil {
+			return err
+		}
+		return subCmd.Scaffold(fs)
+	}
+
+	BeforeEach(func() {
+		fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+		cfg = cfgv3.New()
+	})
+
+	It("should scaffold the workflow and track the version of the CLI in the PROJECT file", func() {
+		Expect(edit("v4.6.0")).To(Succeed())
+
+		Expect(afero.Exists(fs.FS, workflow)).To(BeTrue())
+		Expect(afero.Exists(fs.FS, script)).To(BeTrue())
+
+		var pluginCfg pluginConfig
+		Expect(cfg.DecodePluginConfig(pluginKey, &pluginCfg)).To(Succeed())
+		Expect(pluginCfg).To(Equal(pluginConfig{CliVersion: "v4.6.0"}))
+	})
+
+	It("should record the version of the CLI which runs the latest edit", func() {
+		Expect(edit("v4.6.0")).To(Succeed())
+		Expect(edit("v4.7.0")).To(Succeed())
+
+		var pluginCfg pluginConfig
+		Expect(cfg.DecodePluginConfig(pluginKey, &pluginCfg)).To(Succeed())
+		Expect(pluginCfg.CliVersion).To(Equal("v4.7.0"))
+	})
+
+	It("should keep the recorded release when the project is edited with a development build", func() {
+		Expect(edit("v4.6.0")).To(Succeed())
+		Expect(edit("v4.0.0-20250101000000-abcdef123456+dirty")).To(Succeed())
+
+		var pluginCfg pluginConfig
+		Expect(cfg.DecodePluginConfig(pluginKey, &pluginCfg)).To(Succeed())
+		Expect(pluginCfg.CliVersion).To(Equal("v4.6.0"))
+	})
+
+	DescribeTable("should not record the version of a development build",
+		func(cliVersion string) {
+			Expect(edit(cliVersion)).To(Succeed())
+
+			var pluginCfg pluginConfig
+			Expect(cfg.DecodePluginConfig(pluginKey, &pluginCfg)).To(Succeed())
+			Expect(pluginCfg).To(Equal(pluginConfig{}))
+		},
+		Entry("pseudo-version", "v4.0.0-20250101000000-abcdef123456"),
+		Entry("dirty build", "v4.6.0+dirty"),
+		Entry("local build", "(devel)"),
+		Entry("unknown version", "unknown"),
+	)
+
+	It("should only overwrite the scaffolded files with --force", func() {
+		Expect(afero.WriteFile(fs.FS, script, []byte("custom"), 0o755)).To(Succeed())
+
+		Expect(edit("v4.6.0")).To(Succeed())
+		Expect(flags.Lookup("force").Value.String()).To(Equal("false"))
+		Expect(afero.ReadFile(fs.FS, script)).To(BeEquivalentTo("custom"))
+
+		Expect(edit("v4.6.0", "--force")).To(Succeed())
+		Expect(afero.ReadFile(fs.FS, script)).NotTo(BeEquivalentTo("custom"))
+	})
+
+	It("should reject an invalid value of --force", func() {
+		Expect(edit("v4.6.0", "--force=maybe")).To(MatchError(ContainSubstring("invalid argument")))
+	})
+})
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/stage"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+)
+
+const pluginName = "autoupdate." + plugins.DefaultNameQualifier
+
+var (
+	pluginVersion            = plugin.Version{Number: 1, Stage: stage.Alpha}
+	supportedProjectVersions = []config.Version{cfgv3.Version}
+	pluginKey                = plugin.KeyFor(Plugin{})
+)
+
+// Plugin implements the plugin.Full interface
+type Plugin struct {
+	initSubcommand
+	editSubcommand
+}
+
+var (
+	_ plugin.Init           = Plugin{}
+	_ plugin.Edit           = Plugin{}
+	_ plugin.Deprecated     = Plugin{}
+	_ plugin.HasDeprecation = Plugin{}
+)
+
+type pluginConfig struct {
+	// CliVersion is the Kubebuilder release which last scaffolded the project.
+	// It is used by the workflow to know from which version the project must be updated.
+	CliVersion string `json:"cliVersion,omitempty"`
+}
+
+// Name returns the name of the plugin
+func (Plugin) Name() string { return pluginName }
+
+// Version returns the version of the autoupdate plugin
+func (Plugin) Version() plugin.Version { return pluginVersion }
+
+// SupportedProjectVersions returns an array with all project versions supported by the plugin
+func (Plugin) SupportedProjectVersions() []config.Version { return supportedProjectVersions }
+
+// GetInitSubcommand will return the subcommand which is responsible for scaffolding the auto update workflow
+func (p Plugin) GetInitSubcommand() plugin.InitSubcommand { return &p.initSubcommand }
+
+// GetEditSubcommand will return the subcommand which is responsible for adding or updating the auto update workflow
+func (p Plugin) GetEditSubcommand() plugin.EditSubcommand { return &p.editSubcommand }
+
+// Deprecated define whether the plugin is deprecated and its deprecation message
+func (p Plugin) Deprecated() (bool, string) {
+	return false, ""
+}
+
+// DeprecationWarning define the deprecation message or return empty when plugin is not deprecated
+func (p Plugin) DeprecationWarning() string {
+	_, warning := p.Deprecated()
+	return warning
+}
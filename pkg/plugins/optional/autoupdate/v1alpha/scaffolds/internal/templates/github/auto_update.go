@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &AutoUpdate{}
+
+// AutoUpdate scaffolds the GitHub Action which updates the project with the latest Kubebuilder release
+type AutoUpdate struct {
+	machinery.TemplateMixin
+
+	Force bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *AutoUpdate) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(".github", "workflows", "auto-update.yml")
+	}
+
+	f.TemplateBody = autoUpdateTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+
+	return nil
+}
+
+// The GitHub expressions are written as template strings, since they use the same delimiters
+const autoUpdateTemplate = `name: Auto Update
+
+# Opens a Pull Request which updates the project to the latest Kubebuilder release.
+# The changes made on top of the scaffold are kept with a three-way merge, see:
+# https://book.kubebuilder.io/reference/rescaffold
+on:
+  schedule:
+    - cron: "0 0 * * 2" # Every Tuesday at 00:00 UTC
+  workflow_dispatch:
+
+permissions:
+  contents: write
+  pull-requests: write
+
+jobs:
+  auto-update:
+    name: Update the project with the latest Kubebuilder release
+    runs-on: ubuntu-latest
+    steps:
+      - name: Clone the code
+        uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+
+      - name: Setup Go
+        uses: actions/setup-go@v5
+        with:
+          go-version-file: go.mod
+
+      - name: Configure git
+        run: |
+          git config user.name "github-actions[bot]"
+          git config user.email "41898282+github-actions[bot]@users.noreply.github.com"
+
+      - name: Update the project
+        env:
+          GH_TOKEN: {{ "${{ secrets.GITHUB_TOKEN }}" }}
+          BASE_BRANCH: {{ "${{ github.event.repository.default_branch }}" }}
+        run: bash hack/auto-update.sh
+`
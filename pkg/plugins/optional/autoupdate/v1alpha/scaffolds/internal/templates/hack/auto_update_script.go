@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hack
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &AutoUpdateScript{}
+
+// AutoUpdateScript scaffolds the script which runs 'kubebuilder alpha update' and opens a Pull Request
+// with the result
+type AutoUpdateScript struct {
+	machinery.TemplateMixin
+
+	Force bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *AutoUpdateScript) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("hack", "auto-update.sh")
+	}
+
+	f.TemplateBody = autoUpdateScriptTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+	f.FilePermissions = machinery.ExecutableFilePermission
+
+	return nil
+}
+
+const autoUpdateScriptTemplate = `#!/usr/bin/env bash
+
+# Updates the project with the latest Kubebuilder release and opens a Pull Request with the result.
+# The version used to scaffold the project is read from the cliVersion field of the PROJECT file.
+# Set TO_VERSION to update to a specific release instead of the latest one.
+#
+# It requires git, curl and the GitHub CLI (gh) authenticated with the GH_TOKEN environment variable.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+BASE_BRANCH="${BASE_BRANCH:-main}"
+
+FROM_VERSION="$(awk '/cliVersion:/ {print $2; exit}' PROJECT)"
+if [[ -z "${FROM_VERSION}" ]]; then
+  echo "The Kubebuilder version is not tracked in the PROJECT file. Run 'kubebuilder edit --plugins=autoupdate.kubebuilder.io/v1-alpha'."
+  exit 1
+fi
+
+TO_VERSION="${TO_VERSION:-$(curl -fsSL https://api.github.com/repos/kubernetes-sigs/kubebuilder/releases/latest | awk -F '"' '/"tag_name":/ {print $4; exit}')}"
+if [[ "${FROM_VERSION}" == "${TO_VERSION}" ]]; then
+  echo "The project is already scaffolded with the latest Kubebuilder release ${TO_VERSION}."
+  exit 0
+fi
+
+BRANCH="kubebuilder-update-from-${FROM_VERSION}-to-${TO_VERSION}"
+if git ls-remote --exit-code --heads origin "${BRANCH}" > /dev/null; then
+  echo "The update from ${FROM_VERSION} to ${TO_VERSION} was already proposed in the branch ${BRANCH}."
+  exit 0
+fi
+
+# The new release provides the alpha update command and the scaffold to update to.
+BIN_DIR="$(mktemp -d)"
+trap 'rm -rf "${BIN_DIR}"' EXIT
+curl -fsSL -o "${BIN_DIR}/kubebuilder" \
+  "https://github.com/kubernetes-sigs/kubebuilder/releases/download/${TO_VERSION}/kubebuilder_$(go env GOOS)_$(go env GOARCH)"
+chmod +x "${BIN_DIR}/kubebuilder"
+export PATH="${BIN_DIR}:${PATH}"
+
+git checkout "${BASE_BRANCH}"
+kubebuilder alpha update --from-version "${FROM_VERSION}" --to-version "${TO_VERSION}" --from-branch "${BASE_BRANCH}" \
+  --output-branch "${BRANCH}"
+git checkout "${BRANCH}"
+
+# Track the new version, so that the next update starts from it
+sed -i "s/cliVersion: .*/cliVersion: ${TO_VERSION}/" PROJECT
+git add PROJECT
+git commit --no-verify -m "Track Kubebuilder ${TO_VERSION} in the PROJECT file" || true
+
+CONFLICTS="$(git grep -l -e '^<<<<<<< ' || true)"
+BODY="This Pull Request updates the project from Kubebuilder ${FROM_VERSION} to ${TO_VERSION}
+with 'kubebuilder alpha update'. See the release notes: https://github.com/kubernetes-sigs/kubebuilder/releases/tag/${TO_VERSION}"
+if [[ -n "${CONFLICTS}" ]]; then
+  BODY="${BODY}
+
+The following files have conflicts which must be resolved before merging:
+${CONFLICTS}"
+fi
+
+git push origin "${BRANCH}"
+gh pr create --base "${BASE_BRANCH}" --head "${BRANCH}" \
+  --title "Update to Kubebuilder ${TO_VERSION}" --body "${BODY}"
+`
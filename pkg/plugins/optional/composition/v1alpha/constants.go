@@ -0,0 +1,33 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+//nolint:lll
+const metaDataDescription = `This command will export the CRDs and the manager of the project as a building block of a platform
+composed with kro or Crossplane, under the dist/ directory by default:
+  - kro: the CRDs, applied once on the cluster, and a ResourceGraphDefinition whose instances deploy the manager,
+    with its ServiceAccount, in their namespace ('dist/kro/').
+  - crossplane: a Provider package with the CRDs, whose permission requests are the rules of the manager role,
+    and a DeploymentRuntimeConfig with the args, probes and security context of the manager ('dist/crossplane/').
+
+The manifests are rendered from config/default with kustomize, the exported files are regenerated on
+each run. The target and the directory are tracked in the PROJECT file (in the 'target' and 'directory'
+fields of this plugin).
+
+**NOTE** Before running the edit command, ensure you first execute 'make manifests'.
+This plugin is experimental: the webhooks and the metrics Service of the project are not exported.
+`
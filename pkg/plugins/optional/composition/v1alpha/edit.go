@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/composition/v1alpha/scaffolds"
+)
+
+var _ plugin.EditSubcommand = &editSubcommand{}
+
+type editSubcommand struct {
+	config config.Config
+
+	// options are the target and the directory of the composition
+	options pluginConfig
+
+	flagSet *pflag.FlagSet
+}
+
+func (p *editSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	subcmdMeta.Description = metaDataDescription
+
+	subcmdMeta.Examples = fmt.Sprintf(`  # Export the project as a ResourceGraphDefinition of kro under the dist/ directory
+  %[1]s edit --plugins=%[2]s
+
+  # Export the project as a Provider package of Crossplane
+  %[1]s edit --plugins=%[2]s --target=crossplane
+
+  # Export the project under the deploy/ directory
+  %[1]s edit --plugins=%[2]s --directory=deploy
+`, cliMeta.CommandName, pluginKey)
+
+	subcmdMeta.Flags = append(subcmdMeta.Flags, plugin.FlagMetadata{Name: "target", Enum: scaffolds.Targets})
+}
+
+func (p *editSubcommand) BindFlags(fs *pflag.FlagSet) {
+	p.flagSet = fs
+	fs.StringVar(&p.options.Target, "target", scaffolds.KroTarget,
+		"composition engine the project is exported to")
+	fs.StringVar(&p.options.Directory, "directory", scaffolds.DefaultDirectory,
+		"directory the composition is exported to")
+}
+
+func (p *editSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+
+	// Keep the target and the directory tracked in the PROJECT file unless others are requested
+	cfg, found, err := loadPluginConfig(c)
+	if err != nil {
+		return err
+	}
+	if found && !p.flagSet.Changed("target") && cfg.Target != "" {
+		p.options.Target = cfg.Target
+	}
+	if found && !p.flagSet.Changed("directory") && cfg.Directory != "" {
+		p.options.Directory = cfg.Directory
+	}
+	if p.options.Directory == "" {
+		return fmt.Errorf("--directory can not be empty")
+	}
+
+	return validateTarget(p.options.Target)
+}
+
+func (p *editSubcommand) Scaffold(fs machinery.Filesystem) error {
+	if err := insertPluginMetaToConfig(p.config, p.options); err != nil {
+		return err
+	}
+
+	scaffolder := scaffolds.NewExportScaffolder(p.config, p.options.Target, p.options.Directory)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"path/filepath"
+)
+
+// crossplaneRuntimeContainer is the name of the container of the providers deployed by Crossplane
+const crossplaneRuntimeContainer = "package-runtime"
+
+// crossplaneFiles returns the files of the Crossplane composition: a Provider package with the CRDs, built
+// with 'crossplane xpkg build', and the DeploymentRuntimeConfig of the manager, referenced by the Provider
+func crossplaneFiles(projectName, repo string, manifests projectManifests) (map[string]object, error) {
+	files := map[string]object{}
+	for _, crd := range manifests.crds {
+		files[filepath.Join("package", "crds", nestedString(crd, "metadata", "name")+".yaml")] = crd
+	}
+
+	// Crossplane grants the provider the access to its own CRDs, the other rules of the manager are requested
+	controller := object{}
+	if manifests.managerRole != nil {
+		if rules, ok := manifests.managerRole["rules"].([]interface{}); ok && len(rules) > 0 {
+			controller["permissionRequests"] = rules
+		}
+	}
+	files[filepath.Join("package", "crossplane.yaml")] = object{
+		"apiVersion": "meta.pkg.crossplane.io/v1",
+		"kind":       "Provider",
+		"metadata": object{
+			"name": projectName,
+			"annotations": object{
+				"meta.crossplane.io/source": repo,
+			},
+		},
+		"spec": object{
+			"controller": controller,
+		},
+	}
+
+	// The image of the manager is embedded in the package, the container of the provider keeps the
+	// args, the probes, the resources and the security context of the manager
+	container, err := managerContainer(manifests.deployment)
+	if err != nil {
+		return nil, err
+	}
+	container["name"] = crossplaneRuntimeContainer
+	delete(container, "image")
+	delete(container, "volumeMounts")
+
+	podSpec := object{"containers": []interface{}{container}}
+	deploymentPodSpec := nestedMap(manifests.deployment, "spec", "template", "spec")
+	for _, field := range []string{"securityContext", "terminationGracePeriodSeconds"} {
+		if value, ok := deploymentPodSpec[field]; ok {
+			podSpec[field] = value
+		}
+	}
+	files["deploymentruntimeconfig.yaml"] = object{
+		"apiVersion": "pkg.crossplane.io/v1beta1",
+		"kind":       "DeploymentRuntimeConfig",
+		"metadata": object{
+			"name": projectName,
+		},
+		"spec": object{
+			"deploymentTemplate": object{
+				"spec": object{
+					"selector": object{},
+					"template": object{
+						"spec": podSpec,
+					},
+				},
+			},
+		},
+	}
+	return files, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("crossplaneFiles", func() {
+	const repo = "example.com/project"
+
+	collect := func(excludedKinds ...string) projectManifests {
+		manifests, err := collectManifests(renderObjects(excludedKinds...))
+		Expect(err).NotTo(HaveOccurred())
+		return manifests
+	}
+
+	It("should export a Provider package with the CRDs requesting the permissions of the manager", func() {
+		files, err := crossplaneFiles("project", repo, collect())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(4))
+		Expect(files).To(HaveKey("package/crds/captains.crew.example.com.yaml"))
+		Expect(files).To(HaveKey("package/crds/firstmates.crew.example.com.yaml"))
+
+		provider := files["package/crossplane.yaml"]
+		Expect(provider["apiVersion"]).To(Equal("meta.pkg.crossplane.io/v1"))
+		Expect(provider["kind"]).To(Equal("Provider"))
+		Expect(nestedString(provider, "metadata", "name")).To(Equal("project"))
+		Expect(nestedString(provider, "metadata", "annotations", "meta.crossplane.io/source")).To(Equal(repo))
+		Expect(nestedMap(provider, "spec", "controller")["permissionRequests"]).To(Equal([]interface{}{
+			map[string]interface{}{
+				"apiGroups": []interface{}{"crew.example.com"},
+				"resources": []interface{}{"captains"},
+				"verbs":     []interface{}{"get", "list", "watch"},
+			},
+		}))
+	})
+
+	It("should export a DeploymentRuntimeConfig with the container of the manager", func() {
+		files, err := crossplaneFiles("project", repo, collect())
+		Expect(err).NotTo(HaveOccurred())
+
+		runtimeConfig := files["deploymentruntimeconfig.yaml"]
+		Expect(runtimeConfig["apiVersion"]).To(Equal("pkg.crossplane.io/v1beta1"))
+		Expect(runtimeConfig["kind"]).To(Equal("DeploymentRuntimeConfig"))
+		Expect(nestedString(runtimeConfig, "metadata", "name")).To(Equal("project"))
+
+		podSpec := nestedMap(runtimeConfig, "spec", "deploymentTemplate", "spec", "template", "spec")
+		Expect(podSpec).To(HaveKeyWithValue("securityContext", map[string]interface{}{"runAsNonRoot": true}))
+		Expect(podSpec).To(HaveKeyWithValue("terminationGracePeriodSeconds", BeEquivalentTo(10)))
+		Expect(podSpec["containers"]).To(Equal([]interface{}{
+			object{
+				"name": crossplaneRuntimeContainer,
+				"args": []interface{}{"--leader-elect"},
+			},
+		}))
+	})
+
+	It("should not request permissions without the role of the manager", func() {
+		files, err := crossplaneFiles("project", repo, collect("ClusterRole"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nestedMap(files["package/crossplane.yaml"], "spec", "controller")).To(BeEmpty())
+	})
+})
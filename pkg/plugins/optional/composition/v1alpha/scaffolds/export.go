@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/render"
+)
+
+const (
+	// KroTarget exports the project as a ResourceGraphDefinition of kro
+	KroTarget = "kro"
+	// CrossplaneTarget exports the project as a Provider package of Crossplane
+	CrossplaneTarget = "crossplane"
+)
+
+// Targets are the composition engines the project can be exported to
+var Targets = []string{KroTarget, CrossplaneTarget}
+
+// DefaultDirectory is the directory the composition is exported to by default
+const DefaultDirectory = "dist"
+
+var _ plugins.Scaffolder = &exportScaffolder{}
+
+type exportScaffolder struct {
+	config config.Config
+
+	// target is the composition engine the project is exported to
+	target string
+	// directory is the directory the composition is exported to
+	directory string
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+}
+
+// NewExportScaffolder returns a new Scaffolder which exports the CRDs and the manager of the project
+// as a composition of the target
+func NewExportScaffolder(config config.Config, target, directory string) plugins.Scaffolder {
+	return &exportScaffolder{
+		config:    config,
+		target:    target,
+		directory: directory,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *exportScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *exportScaffolder) Scaffold() error {
+	targetDir := filepath.Join(s.directory, s.target)
+	log.Printf("Exporting the project as a %s composition under %s...", s.target, targetDir)
+
+	objects, err := (render.Renderer{}).BuildObjects(render.DefaultKustomization)
+	if err != nil {
+		return fmt.Errorf("error rendering the manifests of the project, run 'make manifests' first: %w", err)
+	}
+	manifests, err := collectManifests(objects)
+	if err != nil {
+		return err
+	}
+
+	var files map[string]object
+	switch s.target {
+	case KroTarget:
+		files, err = kroFiles(s.config.GetProjectName(), manifests)
+	case CrossplaneTarget:
+		files, err = crossplaneFiles(s.config.GetProjectName(), s.config.GetRepository(), manifests)
+	default:
+		err = fmt.Errorf("unsupported target %q", s.target)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The composition is regenerated, so that the CRDs of the removed APIs are no longer exported
+	if err := s.fs.FS.RemoveAll(targetDir); err != nil {
+		return fmt.Errorf("unable to remove %s: %w", targetDir, err)
+	}
+	for path, obj := range files {
+		content, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("unable to encode %s: %w", path, err)
+		}
+
+		path = filepath.Join(targetDir, path)
+		if err := s.fs.FS.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("unable to create the directory of %s: %w", path, err)
+		}
+		if err := afero.WriteFile(s.fs.FS, path, content, 0o644); err != nil {
+			return fmt.Errorf("unable to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+)
+
+var _ = Describe("Export", func() {
+	var fs machinery.Filesystem
+
+	scaffolder := func(target, directory string) plugins.Scaffolder {
+		cfg := cfgv3.New()
+		Expect(cfg.SetProjectName("project")).To(Succeed())
+		Expect(cfg.SetRepository("example.com/project")).To(Succeed())
+
+		scaffolder := NewExportScaffolder(cfg, target, directory)
+		scaffolder.InjectFS(fs)
+		return scaffolder
+	}
+
+	writeFile := func(path, content string) {
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		wd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.Chdir, wd)
+
+		// The manifests are rendered from config/default in the working directory
+		Expect(os.Chdir(GinkgoT().TempDir())).To(Succeed())
+		fs = machinery.Filesystem{FS: afero.NewOsFs()}
+
+		writeFile(filepath.Join("config", "default", "manifests.yaml"), renderedManifests)
+		writeFile(filepath.Join("config", "default", "kustomization.yaml"), "resources:\n- manifests.yaml\n")
+	})
+
+	It("should export the project as a kro composition", func() {
+		Expect(scaffolder(KroTarget, DefaultDirectory).Scaffold()).To(Succeed())
+
+		Expect(filepath.Join("dist", "kro", "resourcegraphdefinition.yaml")).To(BeAnExistingFile())
+		Expect(filepath.Join("dist", "kro", "crds", "captains.crew.example.com.yaml")).To(BeAnExistingFile())
+		Expect(os.ReadFile(filepath.Join("dist", "kro", "resourcegraphdefinition.yaml"))).
+			To(ContainSubstring("kind: ResourceGraphDefinition"))
+	})
+
+	It("should export the project as a Crossplane composition under the given directory", func() {
+		Expect(scaffolder(CrossplaneTarget, "deploy").Scaffold()).To(Succeed())
+
+		Expect(filepath.Join("deploy", "crossplane", "package", "crossplane.yaml")).To(BeAnExistingFile())
+		Expect(filepath.Join("deploy", "crossplane", "deploymentruntimeconfig.yaml")).To(BeAnExistingFile())
+		Expect(filepath.Join("dist")).NotTo(BeADirectory())
+	})
+
+	It("should regenerate the directory of the target only", func() {
+		stale := filepath.Join("dist", "kro", "crds", "removed.crew.example.com.yaml")
+		other := filepath.Join("dist", "crossplane", "package", "crossplane.yaml")
+		writeFile(stale, "kind: CustomResourceDefinition\n")
+		writeFile(other, "kind: Provider\n")
+
+		Expect(scaffolder(KroTarget, DefaultDirectory).Scaffold()).To(Succeed())
+
+		Expect(stale).NotTo(BeAnExistingFile())
+		Expect(other).To(BeAnExistingFile())
+		Expect(filepath.Join("dist", "kro", "crds", "captains.crew.example.com.yaml")).To(BeAnExistingFile())
+	})
+
+	It("should fail when the manifests of the project can not be rendered", func() {
+		Expect(os.RemoveAll("config")).To(Succeed())
+
+		Expect(scaffolder(KroTarget, DefaultDirectory).Scaffold()).To(MatchError(ContainSubstring("make manifests")))
+	})
+})
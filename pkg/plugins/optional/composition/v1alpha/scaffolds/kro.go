@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/gobuffalo/flect"
+)
+
+// kroFiles returns the files of the kro composition: the CRDs, which are applied once on the cluster,
+// and a ResourceGraphDefinition whose instances deploy the manager in their namespace
+func kroFiles(projectName string, manifests projectManifests) (map[string]object, error) {
+	files := map[string]object{}
+	for _, crd := range manifests.crds {
+		files[filepath.Join("crds", nestedString(crd, "metadata", "name")+".yaml")] = crd
+	}
+
+	deployment := manifests.deployment
+	container, err := managerContainer(deployment)
+	if err != nil {
+		return nil, err
+	}
+	image := container["image"]
+	replicas, ok := nestedMap(deployment, "spec")["replicas"]
+	if !ok {
+		replicas = 1
+	}
+
+	// The namespace, the image and the replicas of the manager are set by the instances
+	const namespace = "${schema.metadata.namespace}"
+	nestedMap(deployment, "metadata")["namespace"] = namespace
+	nestedMap(deployment, "spec")["replicas"] = "${schema.spec.replicas}"
+	container["image"] = "${schema.spec.image}"
+
+	var resources []interface{}
+	if manifests.serviceAccount != nil {
+		nestedMap(manifests.serviceAccount, "metadata")["namespace"] = namespace
+		resources = append(resources, object{"id": "serviceAccount", "template": manifests.serviceAccount})
+	}
+	resources = append(resources, object{"id": "controllerManager", "template": deployment})
+
+	files["resourcegraphdefinition.yaml"] = object{
+		"apiVersion": "kro.run/v1alpha1",
+		"kind":       "ResourceGraphDefinition",
+		"metadata": object{
+			"name": projectName,
+		},
+		"spec": object{
+			"schema": object{
+				"apiVersion": "v1alpha1",
+				"kind":       flect.Pascalize(projectName),
+				"spec": object{
+					"image":    fmt.Sprintf("string | default=%q", image),
+					"replicas": fmt.Sprintf("integer | default=%v", replicas),
+				},
+				"status": object{
+					"availableReplicas": "${controllerManager.status.availableReplicas}",
+				},
+			},
+			"resources": resources,
+		},
+	}
+	return files, nil
+}
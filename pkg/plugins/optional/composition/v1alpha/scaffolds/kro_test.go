@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("kroFiles", func() {
+	collect := func(excludedKinds ...string) projectManifests {
+		manifests, err := collectManifests(renderObjects(excludedKinds...))
+		Expect(err).NotTo(HaveOccurred())
+		return manifests
+	}
+
+	It("should export the CRDs and a ResourceGraphDefinition deploying the manager", func() {
+		files, err := kroFiles("project-v4", collect())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(3))
+		Expect(files).To(HaveKey("crds/captains.crew.example.com.yaml"))
+		Expect(files).To(HaveKey("crds/firstmates.crew.example.com.yaml"))
+
+		rgd := files["resourcegraphdefinition.yaml"]
+		Expect(rgd["apiVersion"]).To(Equal("kro.run/v1alpha1"))
+		Expect(rgd["kind"]).To(Equal("ResourceGraphDefinition"))
+		Expect(nestedString(rgd, "metadata", "name")).To(Equal("project-v4"))
+
+		schema := nestedMap(rgd, "spec", "schema")
+		Expect(schema["kind"]).To(Equal("ProjectV4"))
+		Expect(nestedMap(schema, "spec")).To(Equal(object{
+			"image":    `string | default="controller:latest"`,
+			"replicas": "integer | default=2",
+		}))
+		Expect(nestedString(schema, "status", "availableReplicas")).
+			To(Equal("${controllerManager.status.availableReplicas}"))
+	})
+
+	It("should let the instances set the namespace, the image and the replicas of the manager", func() {
+		files, err := kroFiles("project", collect())
+		Expect(err).NotTo(HaveOccurred())
+
+		resources, _ := nestedMap(files["resourcegraphdefinition.yaml"], "spec")["resources"].([]interface{})
+		Expect(resources).To(HaveLen(2))
+		serviceAccount, _ := resources[0].(object)
+		Expect(serviceAccount["id"]).To(Equal("serviceAccount"))
+		Expect(nestedString(serviceAccount, "template", "metadata", "namespace")).
+			To(Equal("${schema.metadata.namespace}"))
+
+		controllerManager, _ := resources[1].(object)
+		Expect(controllerManager["id"]).To(Equal("controllerManager"))
+		deployment := nestedMap(controllerManager, "template")
+		Expect(nestedString(deployment, "metadata", "namespace")).To(Equal("${schema.metadata.namespace}"))
+		Expect(nestedString(deployment, "spec", "replicas")).To(Equal("${schema.spec.replicas}"))
+		container, err := managerContainer(deployment)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(container["image"]).To(Equal("${schema.spec.image}"))
+	})
+
+	It("should only deploy the manager when its ServiceAccount is not rendered", func() {
+		files, err := kroFiles("project", collect("ServiceAccount"))
+		Expect(err).NotTo(HaveOccurred())
+
+		resources, _ := nestedMap(files["resourcegraphdefinition.yaml"], "spec")["resources"].([]interface{})
+		Expect(resources).To(HaveLen(1))
+		Expect(resources[0]).To(HaveKeyWithValue("id", "controllerManager"))
+	})
+
+	It("should fail without the manager container", func() {
+		manifests := collect()
+		container, err := managerContainer(manifests.deployment)
+		Expect(err).NotTo(HaveOccurred())
+		container["name"] = "proxy"
+
+		_, err = kroFiles("project", manifests)
+		Expect(err).To(MatchError(ContainSubstring("unable to find the manager container")))
+	})
+})
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/render"
+)
+
+// object is a Kubernetes object decoded from the manifests rendered by kustomize
+type object = map[string]interface{}
+
+// projectManifests are the rendered manifests of the project which are exported
+type projectManifests struct {
+	// crds are the CustomResourceDefinitions of the APIs of the project
+	crds []object
+	// deployment is the Deployment of the manager
+	deployment object
+	// serviceAccount is the ServiceAccount of the manager, if it is rendered with the manager
+	serviceAccount object
+	// managerRole is the ClusterRole of the manager, generated by controller-gen from the RBAC markers
+	managerRole object
+}
+
+// collectManifests decodes the rendered objects which are exported
+func collectManifests(objects []render.Object) (projectManifests, error) {
+	var manifests projectManifests
+	decoded := make([]object, 0, len(objects))
+	for _, o := range objects {
+		obj := object{}
+		if err := yaml.Unmarshal([]byte(o.Manifest), &obj); err != nil {
+			return manifests, fmt.Errorf("unable to decode the %s %s: %w", o.Kind, o.Metadata.Name, err)
+		}
+		decoded = append(decoded, obj)
+
+		switch {
+		case o.Kind == "CustomResourceDefinition":
+			manifests.crds = append(manifests.crds, obj)
+		case o.Kind == "Deployment" && nestedString(obj, "metadata", "labels", "control-plane") == "controller-manager":
+			manifests.deployment = obj
+		case o.Kind == "ClusterRole" && strings.HasSuffix(o.Metadata.Name, "manager-role"):
+			manifests.managerRole = obj
+		}
+	}
+	if manifests.deployment == nil {
+		return manifests, fmt.Errorf("unable to find the Deployment of the manager in the manifests of %s",
+			render.DefaultKustomization)
+	}
+
+	serviceAccountName := nestedString(manifests.deployment, "spec", "template", "spec", "serviceAccountName")
+	for _, obj := range decoded {
+		if obj["kind"] == "ServiceAccount" && nestedString(obj, "metadata", "name") == serviceAccountName {
+			manifests.serviceAccount = obj
+		}
+	}
+	return manifests, nil
+}
+
+// nestedMap returns the map found at the path of fields of the object, or nil if it does not exist
+func nestedMap(obj object, fields ...string) object {
+	current := obj
+	for _, field := range fields {
+		next, ok := current[field].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = next
+	}
+	return current
+}
+
+// nestedString returns the string found at the path of fields of the object, or an empty string
+func nestedString(obj object, fields ...string) string {
+	parent := nestedMap(obj, fields[:len(fields)-1]...)
+	if parent == nil {
+		return ""
+	}
+	value, _ := parent[fields[len(fields)-1]].(string)
+	return value
+}
+
+// managerContainer returns the manager container of the Deployment of the manager
+func managerContainer(deployment object) (object, error) {
+	containers, _ := nestedMap(deployment, "spec", "template", "spec")["containers"].([]interface{})
+	for _, c := range containers {
+		if container, ok := c.(map[string]interface{}); ok && container["name"] == "manager" {
+			return container, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to find the manager container of the Deployment %s",
+		nestedString(deployment, "metadata", "name"))
+}
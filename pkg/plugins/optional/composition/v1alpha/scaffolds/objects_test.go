@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/render"
+)
+
+// renderedManifests are the manifests of a project rendered from config/default
+const renderedManifests = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: captains.crew.example.com
+spec:
+  group: crew.example.com
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: firstmates.crew.example.com
+spec:
+  group: crew.example.com
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: project-controller-manager
+  namespace: project-system
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: project-other
+  namespace: project-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: project-manager-role
+rules:
+- apiGroups:
+  - crew.example.com
+  resources:
+  - captains
+  verbs:
+  - get
+  - list
+  - watch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: project-metrics-reader
+rules:
+- nonResourceURLs:
+  - /metrics
+  verbs:
+  - get
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: project-webhook-service
+  namespace: project-system
+spec:
+  ports:
+  - port: 443
+---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: project-validating-webhook-configuration
+webhooks:
+- name: vcaptain-v1.kb.io
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: project-unknown
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    control-plane: controller-manager
+  name: project-controller-manager
+  namespace: project-system
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - args:
+        - --leader-elect
+        image: controller:latest
+        name: manager
+        volumeMounts:
+        - mountPath: /tmp/k8s-webhook-server/serving-certs
+          name: cert
+      securityContext:
+        runAsNonRoot: true
+      serviceAccountName: project-controller-manager
+      terminationGracePeriodSeconds: 10
+`
+
+// renderObjects returns the objects of the rendered manifests, excluding the objects of the given kinds
+func renderObjects(excludedKinds ...string) []render.Object {
+	objects, err := render.Objects([]byte(renderedManifests))
+	Expect(err).NotTo(HaveOccurred())
+
+	filtered := make([]render.Object, 0, len(objects))
+	for _, o := range objects {
+		excluded := false
+		for _, kind := range excludedKinds {
+			excluded = excluded || o.Kind == kind
+		}
+		if !excluded {
+			filtered = append(filtered, o)
+		}
+	}
+	return filtered
+}
+
+var _ = Describe("collectManifests", func() {
+	It("should collect the CRDs, the Deployment, the ServiceAccount and the role of the manager", func() {
+		manifests, err := collectManifests(renderObjects())
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(manifests.crds).To(HaveLen(2))
+		Expect(nestedString(manifests.crds[0], "metadata", "name")).To(Equal("captains.crew.example.com"))
+		Expect(nestedString(manifests.crds[1], "metadata", "name")).To(Equal("firstmates.crew.example.com"))
+		Expect(nestedString(manifests.deployment, "metadata", "name")).To(Equal("project-controller-manager"))
+		Expect(nestedString(manifests.serviceAccount, "metadata", "name")).To(Equal("project-controller-manager"))
+		Expect(nestedString(manifests.managerRole, "metadata", "name")).To(Equal("project-manager-role"))
+	})
+
+	It("should not collect the webhooks, the other RBAC objects and the unknown kinds", func() {
+		manifests, err := collectManifests(renderObjects())
+		Expect(err).NotTo(HaveOccurred())
+
+		collected := append([]object{manifests.deployment, manifests.serviceAccount, manifests.managerRole},
+			manifests.crds...)
+		for _, obj := range collected {
+			Expect(obj["kind"]).NotTo(BeElementOf(
+				"ValidatingWebhookConfiguration", "Service", "Unknown"), nestedString(obj, "metadata", "name"))
+			Expect(nestedString(obj, "metadata", "name")).NotTo(BeElementOf(
+				"project-metrics-reader", "project-other"))
+		}
+	})
+
+	It("should not collect a ServiceAccount which is not rendered with the manager", func() {
+		manifests, err := collectManifests(renderObjects("ServiceAccount", "ClusterRole"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(manifests.serviceAccount).To(BeNil())
+		Expect(manifests.managerRole).To(BeNil())
+	})
+
+	It("should fail without the Deployment of the manager", func() {
+		_, err := collectManifests(renderObjects("Deployment"))
+		Expect(err).To(MatchError(ContainSubstring("unable to find the Deployment of the manager")))
+	})
+
+	It("should fail for objects which can not be decoded", func() {
+		objects := renderObjects()
+		objects[0].Manifest = "kind: [CustomResourceDefinition"
+		_, err := collectManifests(objects)
+		Expect(err).To(MatchError(ContainSubstring("unable to decode the CustomResourceDefinition")))
+	})
+})
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/devcontainer/v1alpha/scaffolds"
+)
+
+// bindVersionFlags binds the flags overriding the pinned versions
+func bindVersionFlags(fs *pflag.FlagSet, versions *scaffolds.Versions) {
+	fs.StringVar(&versions.Go, "go-version", scaffolds.GoVersion,
+		"version of the Go image used by the devcontainer")
+	fs.StringVar(&versions.Kind, "kind-version", scaffolds.KindVersion,
+		"version of kind installed in the devcontainer")
+	fs.StringVar(&versions.Kubectl, "kubectl-version", scaffolds.KubectlVersion,
+		"version of kubectl installed in the devcontainer")
+}
+
+// keepNewerTrackedVersions keeps the versions tracked in the PROJECT file which are newer than the ones
+// of this release, unless other versions are requested with the flags
+func keepNewerTrackedVersions(target config.Config, fs *pflag.FlagSet, versions *scaffolds.Versions) error {
+	cfg := pluginConfig{}
+	err := target.DecodePluginConfig(pluginKey, &cfg)
+	if errors.As(err, &config.UnsupportedFieldError{}) || errors.As(err, &config.PluginKeyNotFoundError{}) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, v := range []struct {
+		flag    string
+		tracked string
+		version *string
+	}{
+		{"go-version", cfg.GoVersion, &versions.Go},
+		{"kind-version", cfg.KindVersion, &versions.Kind},
+		{"kubectl-version", cfg.KubectlVersion, &versions.Kubectl},
+	} {
+		if !fs.Changed(v.flag) && isNewerVersion(v.tracked, *v.version) {
+			*v.version = v.tracked
+		}
+	}
+	return nil
+}
+
+// isNewerVersion returns true if the version is newer than the other one. Versions, with or
+// without the "v" prefix, are compared by their numeric components; others are never newer.
+func isNewerVersion(version, other string) bool {
+	parse := func(v string) ([]int, bool) {
+		var numbers []int
+		for _, part := range strings.Split(strings.TrimPrefix(v, "v"), ".") {
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, false
+			}
+			numbers = append(numbers, n)
+		}
+		return numbers, true
+	}
+
+	a, ok := parse(version)
+	if !ok {
+		return false
+	}
+	b, ok := parse(other)
+	if !ok {
+		return false
+	}
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			return x > y
+		}
+	}
+	return false
+}
+
+// insertPluginMetaToConfig will insert the metadata to the plugin configuration
+func insertPluginMetaToConfig(target config.Config, versions scaffolds.Versions) error {
+	err := target.DecodePluginConfig(pluginKey, &pluginConfig{})
+	if !errors.As(err, &config.UnsupportedFieldError{}) {
+		if err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
+			return err
+		}
+		cfg := pluginConfig{
+			GoVersion:      versions.Go,
+			KindVersion:    versions.Kind,
+			KubectlVersion: versions.Kubectl,
+		}
+		if err = target.EncodePluginConfig(pluginKey, cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+//nolint:lll
+const metaDataDescription = `This command will add a devcontainer to the project, which can be used with VS Code or GitHub Codespaces:
+  - The devcontainer configuration, using the Go image and Docker in Docker.
+	('.devcontainer/devcontainer.json')
+  - A script which installs kind, kubectl and kubebuilder when the devcontainer is created.
+	('.devcontainer/post-install.sh')
+
+The versions of Go, kind and kubectl are pinned, and tracked in the PROJECT file (in the 'goVersion',
+'kindVersion' and 'kubectlVersion' fields of this plugin). By default, the versions supported by the
+release of the Kubebuilder binary are used. The edit subcommand updates the pinned versions in place,
+keeping the changes made on top of the scaffold. Pinned versions newer than the ones of the release
+are kept, unless other versions are set with the flags.
+`
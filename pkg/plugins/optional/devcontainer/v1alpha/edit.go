@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/devcontainer/v1alpha/scaffolds"
+)
+
+var _ plugin.EditSubcommand = &editSubcommand{}
+
+type editSubcommand struct {
+	config config.Config
+	force  bool
+
+	// versions are the versions of the tools pinned in the devcontainer
+	versions scaffolds.Versions
+
+	flagSet *pflag.FlagSet
+}
+
+func (p *editSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	subcmdMeta.Description = metaDataDescription
+
+	subcmdMeta.Examples = fmt.Sprintf(`  # Add the devcontainer to a project, or update its pinned versions to the ones of this release.
+  # The pinned versions which are newer than the ones of this release are kept.
+  %[1]s edit --plugins=%[2]s
+
+  # Pin a specific version of kind
+  %[1]s edit --plugins=%[2]s --kind-version=v0.26.0
+
+  # Overwrite the devcontainer with the latest scaffold
+  %[1]s edit --plugins=%[2]s --force-devcontainer
+`, cliMeta.CommandName, pluginKey)
+}
+
+func (p *editSubcommand) BindFlags(fs *pflag.FlagSet) {
+	p.flagSet = fs
+	bindVersionFlags(fs, &p.versions)
+	// The flag is not named force since the plugin is bundled with the plugins which bind it
+	fs.BoolVar(&p.force, "force-devcontainer", false, "if true, overwrites the devcontainer configuration and script")
+}
+
+func (p *editSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+	return keepNewerTrackedVersions(c, p.flagSet, &p.versions)
+}
+
+func (p *editSubcommand) Scaffold(fs machinery.Filesystem) error {
+	if err := insertPluginMetaToConfig(p.config, p.versions); err != nil {
+		return err
+	}
+
+	scaffolder := scaffolds.NewEditScaffolder(p.versions, p.force)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/devcontainer/v1alpha/scaffolds"
+)
+
+var _ = Describe("editSubcommand", func() {
+	var (
+		devContainer      = filepath.Join(".devcontainer", "devcontainer.json")
+		postInstallScript = filepath.Join(".devcontainer", "post-install.sh")
+	)
+
+	var (
+		fs  machinery.Filesystem
+		cfg config.Config
+	)
+
+	edit := func(args ...string) error {
+		subCmd := &editSubcommand{}
+		subCmd.UpdateMetadata(plugin.CLIMetadata{CommandName: "kubebuilder"}, &plugin.SubcommandMetadata{})
+		flags := pflag.NewFlagSet("edit", pflag.ContinueOnError)
+		subCmd.BindFlags(flags)
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if err := subCmd.InjectConfig(cfg); err != nil {
+			return err
+		}
+		return subCmd.Scaffold(fs)
+	}
+
+	trackedVersions := func() pluginConfig {
+		var pluginCfg pluginConfig
+		Expect(cfg.DecodePluginConfig(pluginKey, &pluginCfg)).To(Succeed())
+		return pluginCfg
+	}
+
+	BeforeEach(func() {
+		fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+		cfg = cfgv3.New()
+	})
+
+	It("should scaffold the devcontainer and track the pinned versions in the PROJECT file", func() {
+		Expect(edit()).To(Succeed())
+
+		Expect(afero.ReadFile(fs.FS, devContainer)).To(ContainSubstring("docker.io/golang:" + scaffolds.GoVersion))
+		Expect(afero.ReadFile(fs.FS, postInstallScript)).To(ContainSubstring("KIND_VERSION=" + scaffolds.KindVersion))
+		Expect(trackedVersions()).To(Equal(pluginConfig{
+			GoVersion:      scaffolds.GoVersion,
+			KindVersion:    scaffolds.KindVersion,
+			KubectlVersion: scaffolds.KubectlVersion,
+		}))
+	})
+
+	It("should update the pinned versions and keep the changes made on top of the scaffold", func() {
+		Expect(edit()).To(Succeed())
+		script, err := afero.ReadFile(fs.FS, postInstallScript)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(afero.WriteFile(fs.FS, postInstallScript, append(script, "# custom\n"...), 0o755)).To(Succeed())
+
+		Expect(edit("--kind-version=v0.26.0")).To(Succeed())
+
+		script, err = afero.ReadFile(fs.FS, postInstallScript)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(script)).To(ContainSubstring("KIND_VERSION=v0.26.0"))
+		Expect(string(script)).To(ContainSubstring("# custom"))
+		Expect(trackedVersions().KindVersion).To(Equal("v0.26.0"))
+	})
+
+	It("should keep the tracked versions which are newer than the ones of this release", func() {
+		Expect(insertPluginMetaToConfig(cfg, scaffolds.Versions{
+			Go:      "99.0",
+			Kind:    "v0.1.0",
+			Kubectl: scaffolds.KubectlVersion,
+		})).To(Succeed())
+
+		Expect(edit()).To(Succeed())
+
+		Expect(trackedVersions()).To(Equal(pluginConfig{
+			GoVersion:      "99.0",
+			KindVersion:    scaffolds.KindVersion,
+			KubectlVersion: scaffolds.KubectlVersion,
+		}))
+		Expect(afero.ReadFile(fs.FS, devContainer)).To(ContainSubstring("docker.io/golang:99.0"))
+	})
+
+	It("should overwrite the changes made on top of the scaffold with --force-devcontainer", func() {
+		Expect(afero.WriteFile(fs.FS, postInstallScript, []byte("custom"), 0o755)).To(Succeed())
+
+		Expect(edit("--force-devcontainer")).To(Succeed())
+
+		Expect(afero.ReadFile(fs.FS, postInstallScript)).To(ContainSubstring("KIND_VERSION=" + scaffolds.KindVersion))
+	})
+
+	It("should not bind the force flag of the plugins it is bundled with", func() {
+		Expect(edit("--force")).To(MatchError(ContainSubstring("unknown flag: --force")))
+	})
+
+	DescribeTable("isNewerVersion",
+		func(version, other string, newer bool) {
+			Expect(isNewerVersion(version, other)).To(Equal(newer))
+		},
+		Entry("newer minor version", "v0.28.0", "v0.27.0", true),
+		Entry("older patch version", "v1.32.1", "v1.32.2", false),
+		Entry("same version", "1.23", "1.23", false),
+		Entry("version with more components", "1.23.1", "1.23", true),
+		Entry("invalid version", "latest", "1.23", false),
+	)
+})
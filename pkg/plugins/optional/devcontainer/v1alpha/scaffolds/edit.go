@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/devcontainer/v1alpha/scaffolds/internal/templates"
+)
+
+var _ plugins.Scaffolder = &editScaffolder{}
+
+type editScaffolder struct {
+	// versions are the versions of the tools pinned in the devcontainer
+	versions Versions
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+
+	// force indicates whether to overwrite the scaffolded files
+	force bool
+}
+
+// NewEditScaffolder returns a new Scaffolder which updates the versions pinned in the devcontainer
+func NewEditScaffolder(versions Versions, force bool) plugins.Scaffolder {
+	return &editScaffolder{
+		versions: versions,
+		force:    force,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *editScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// pin is a version pinned in a file of the devcontainer
+type pin struct {
+	path    string
+	regex   *regexp.Regexp
+	replace string
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *editScaffolder) Scaffold() error {
+	// Missing files are scaffolded, and all of them are overwritten when forced
+	initScaffolder := &initScaffolder{versions: s.versions, force: s.force}
+	initScaffolder.InjectFS(s.fs)
+	if err := initScaffolder.Scaffold(); err != nil {
+		return err
+	}
+	if s.force {
+		return nil
+	}
+
+	log.Println("Updating the versions pinned in the devcontainer...")
+	pins := []pin{
+		{
+			path:    templates.DevContainerPath,
+			regex:   regexp.MustCompile(`("image":\s*"docker\.io/golang:)[^"]*(")`),
+			replace: "${1}" + s.versions.Go + "${2}",
+		},
+		{
+			path:    templates.PostInstallScriptPath,
+			regex:   regexp.MustCompile(`(?m)^(KIND_VERSION=).*$`),
+			replace: "${1}" + s.versions.Kind,
+		},
+		{
+			path:    templates.PostInstallScriptPath,
+			regex:   regexp.MustCompile(`(?m)^(KUBECTL_VERSION=).*$`),
+			replace: "${1}" + s.versions.Kubectl,
+		},
+	}
+	for _, p := range pins {
+		if err := s.updatePin(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updatePin replaces the version pinned in the file, keeping the changes made on top of the scaffold
+func (s *editScaffolder) updatePin(p pin) error {
+	info, err := s.fs.FS.Stat(p.path)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return nil
+		}
+		return fmt.Errorf("error reading %s: %w", p.path, err)
+	}
+	content, err := afero.ReadFile(s.fs.FS, p.path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", p.path, err)
+	}
+
+	if !p.regex.Match(content) {
+		log.Warnf("Unable to find the version to update in %s, "+
+			"run the edit subcommand with --force to overwrite it with the latest scaffold", p.path)
+		return nil
+	}
+
+	updated := p.regex.ReplaceAll(content, []byte(p.replace))
+	if err := afero.WriteFile(s.fs.FS, p.path, updated, info.Mode()); err != nil {
+		return fmt.Errorf("error updating %s: %w", p.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/devcontainer/v1alpha/scaffolds/internal/templates"
+)
+
+const (
+	// GoVersion is the version of the Go image used by the devcontainer
+	GoVersion = "1.23"
+	// KindVersion is the version of kind installed in the devcontainer
+	KindVersion = "v0.27.0"
+	// KubectlVersion is the version of kubectl installed in the devcontainer
+	KubectlVersion = "v1.32.2"
+)
+
+// Versions are the versions of the tools pinned in the devcontainer
+type Versions struct {
+	Go      string
+	Kind    string
+	Kubectl string
+}
+
+var _ plugins.Scaffolder = &initScaffolder{}
+
+type initScaffolder struct {
+	// versions are the versions of the tools pinned in the devcontainer
+	versions Versions
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+
+	// force indicates whether to overwrite the scaffolded files
+	force bool
+}
+
+// NewInitScaffolder returns a new Scaffolder for the devcontainer
+func NewInitScaffolder(versions Versions, force bool) plugins.Scaffolder {
+	return &initScaffolder{
+		versions: versions,
+		force:    force,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *initScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *initScaffolder) Scaffold() error {
+	log.Println("Generating the devcontainer...")
+
+	// Initialize the machinery.Scaffold that will write the files to disk
+	scaffold := machinery.NewScaffold(s.fs)
+
+	return scaffold.Execute(
+		&templates.DevContainer{GoVersion: s.versions.Go, Force: s.force},
+		&templates.DevContainerPostInstallScript{
+			KindVersion:    s.versions.Kind,
+			KubectlVersion: s.versions.Kubectl,
+			Force:          s.force,
+		},
+	)
+}
@@ -17,12 +17,21 @@ limitations under the License.
 package templates
 
 import (
+	"path/filepath"
+
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 )
 
+var (
+	// DevContainerPath is the path of the devcontainer configuration
+	DevContainerPath = filepath.Join(".devcontainer", "devcontainer.json")
+	// PostInstallScriptPath is the path of the script run when the devcontainer is created
+	PostInstallScriptPath = filepath.Join(".devcontainer", "post-install.sh")
+)
+
 const devContainerTemplate = `{
   "name": "Kubebuilder DevContainer",
-  "image": "docker.io/golang:1.23",
+  "image": "docker.io/golang:{{ .GoVersion }}",
   "features": {
     "ghcr.io/devcontainers/features/docker-in-docker:2": {},
     "ghcr.io/devcontainers/features/git:1": {}
@@ -50,7 +59,12 @@ const devContainerTemplate = `{
 const postInstallScript = `#!/bin/bash
 set -x
 
-curl -Lo ./kind https://kind.sigs.k8s.io/dl/latest/kind-linux-amd64
+# The versions are pinned by the devcontainer plugin and can be updated with:
+# kubebuilder edit --plugins=devcontainer/v1-alpha
+KIND_VERSION={{ .KindVersion }}
+KUBECTL_VERSION={{ .KubectlVersion }}
+
+curl -Lo ./kind "https://kind.sigs.k8s.io/dl/${KIND_VERSION}/kind-linux-amd64"
 chmod +x ./kind
 mv ./kind /usr/local/bin/kind
 
@@ -58,7 +72,6 @@ curl -L -o kubebuilder https://go.kubebuilder.io/dl/latest/linux/amd64
 chmod +x kubebuilder
 mv kubebuilder /usr/local/bin/
 
-KUBECTL_VERSION=$(curl -L -s https://dl.k8s.io/release/stable.txt)
 curl -LO "https://dl.k8s.io/release/$KUBECTL_VERSION/bin/linux/amd64/kubectl"
 chmod +x kubectl
 mv kubectl /usr/local/bin/kubectl
@@ -78,31 +91,56 @@ var _ machinery.Template = &DevContainerPostInstallScript{}
 // DevContainer scaffoldds a `devcontainer.json` configurations file for creating Kubebuilder & Kind based DevContainer.
 type DevContainer struct {
 	machinery.TemplateMixin
+
+	// GoVersion is the version of the Go image
+	GoVersion string
+
+	Force bool
 }
 
 // DevContainerPostInstallScript defines the scaffold that will be done with the post install script
 type DevContainerPostInstallScript struct {
 	machinery.TemplateMixin
+
+	// KindVersion is the version of kind installed by the script
+	KindVersion string
+	// KubectlVersion is the version of kubectl installed by the script
+	KubectlVersion string
+
+	Force bool
 }
 
 // SetTemplateDefaults set defaults for this template
 func (f *DevContainer) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = ".devcontainer/devcontainer.json"
+		f.Path = DevContainerPath
 	}
 
 	f.TemplateBody = devContainerTemplate
 
+	f.IfExistsAction = ifExistsAction(f.Force)
+
 	return nil
 }
 
 // SetTemplateDefaults set the defaults of this template
 func (f *DevContainerPostInstallScript) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = ".devcontainer/post-install.sh"
+		f.Path = PostInstallScriptPath
 	}
 
 	f.TemplateBody = postInstallScript
 
+	f.IfExistsAction = ifExistsAction(f.Force)
+	f.FilePermissions = machinery.ExecutableFilePermission
+
 	return nil
 }
+
+// ifExistsAction overwrites the existing files only when forced
+func ifExistsAction(force bool) machinery.IfExistsAction {
+	if force {
+		return machinery.OverwriteFile
+	}
+	return machinery.SkipFile
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/devenv/v1alpha/scaffolds"
+)
+
+// validateTool checks that the tool is supported by the plugin
+func validateTool(tool string) error {
+	if !slices.Contains(scaffolds.Tools, tool) {
+		return fmt.Errorf("invalid tool %q, must be one of %v", tool, scaffolds.Tools)
+	}
+	return nil
+}
+
+// loadPluginConfig will load the plugin configuration, which is empty when the plugin was not used yet
+func loadPluginConfig(target config.Config) (pluginConfig, error) {
+	cfg := pluginConfig{}
+	err := target.DecodePluginConfig(pluginKey, &cfg)
+	if err != nil && !errors.As(err, &config.UnsupportedFieldError{}) &&
+		!errors.As(err, &config.PluginKeyNotFoundError{}) {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// insertPluginMetaToConfig will insert the metadata to the plugin configuration
+func insertPluginMetaToConfig(target config.Config, cfg pluginConfig) error {
+	err := target.DecodePluginConfig(pluginKey, &pluginConfig{})
+	if !errors.As(err, &config.UnsupportedFieldError{}) {
+		if err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
+			return err
+		}
+		if err = target.EncodePluginConfig(pluginKey, cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+//nolint:lll
+const metaDataDescription = `This command will add an inner-loop dev environment to the project, which runs the manager
+on a kind cluster and rebuilds it on each change of the code:
+  - A Tiltfile ('Tiltfile'), which syncs the manager binary built on the host into the running container
+    and restarts it, or a Skaffold configuration ('skaffold.yaml') which rebuilds and redeploys the image,
+    selected with the --tool flag.
+  - A 'dev' target in the Makefile, which creates the kind cluster if it is not running and starts the tool.
+
+The tool used is tracked in the PROJECT file (in the 'tool' field of this plugin).
+
+NOTE: This plugin requires kind and the selected tool (tilt or skaffold) to be installed.
+`
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/devenv/v1alpha/scaffolds"
+)
+
+var _ plugin.EditSubcommand = &editSubcommand{}
+
+type editSubcommand struct {
+	config config.Config
+	force  bool
+
+	// tool is the tool used to run the dev environment
+	tool string
+
+	flagSet *pflag.FlagSet
+}
+
+func (p *editSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	subcmdMeta.Description = metaDataDescription
+
+	subcmdMeta.Examples = fmt.Sprintf(`  # Add a Tilt dev environment to a project
+  %[1]s edit --plugins=%[2]s
+
+  # Switch the dev environment of a project to Skaffold
+  %[1]s edit --plugins=%[2]s --tool=skaffold
+
+  # Overwrite the configuration of the tool with the latest scaffold
+  %[1]s edit --plugins=%[2]s --force
+`, cliMeta.CommandName, pluginKey)
+
+	subcmdMeta.Flags = append(subcmdMeta.Flags, plugin.FlagMetadata{Name: "tool", Enum: scaffolds.Tools})
+}
+
+func (p *editSubcommand) BindFlags(fs *pflag.FlagSet) {
+	p.flagSet = fs
+	fs.StringVar(&p.tool, "tool", scaffolds.TiltTool,
+		"tool used to run the dev environment. If not provided, the tool tracked in the PROJECT file is kept")
+	fs.BoolVar(&p.force, "force", false, "if true, overwrites the configuration of the tool")
+}
+
+func (p *editSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+
+	// Keep the tool tracked in the PROJECT file unless another one is requested
+	if !p.flagSet.Changed("tool") {
+		cfg, err := loadPluginConfig(c)
+		if err != nil {
+			return err
+		}
+		if cfg.Tool != "" {
+			p.tool = cfg.Tool
+		}
+	}
+
+	return validateTool(p.tool)
+}
+
+func (p *editSubcommand) Scaffold(fs machinery.Filesystem) error {
+	if err := insertPluginMetaToConfig(p.config, pluginConfig{Tool: p.tool}); err != nil {
+		return err
+	}
+
+	scaffolder := scaffolds.NewInitScaffolder(p.config, p.tool, p.force)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+var _ = Describe("editSubcommand", func() {
+	var (
+		fs  machinery.Filesystem
+		cfg config.Config
+	)
+
+	edit := func(args ...string) error {
+		subCmd := &editSubcommand{}
+		subCmd.UpdateMetadata(plugin.CLIMetadata{CommandName: "kubebuilder"}, &plugin.SubcommandMetadata{})
+		flags := pflag.NewFlagSet("edit", pflag.ContinueOnError)
+		subCmd.BindFlags(flags)
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if err := subCmd.InjectConfig(cfg); err != nil {
+			return err
+		}
+		return subCmd.Scaffold(fs)
+	}
+
+	trackedTool := func() string {
+		pluginCfg, err := loadPluginConfig(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		return pluginCfg.Tool
+	}
+
+	BeforeEach(func() {
+		fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+		Expect(afero.WriteFile(fs.FS, "Makefile", []byte("##@ Dependencies\n"), 0o644)).To(Succeed())
+
+		cfg = cfgv3.New()
+		Expect(cfg.SetProjectName("project")).To(Succeed())
+	})
+
+	It("should scaffold a Tilt dev environment by default and track it in the PROJECT file", func() {
+		Expect(edit()).To(Succeed())
+
+		Expect(afero.Exists(fs.FS, "Tiltfile")).To(BeTrue())
+		Expect(afero.Exists(fs.FS, "skaffold.yaml")).To(BeFalse())
+		Expect(afero.ReadFile(fs.FS, "Makefile")).To(ContainSubstring("$(TILT) up"))
+		Expect(trackedTool()).To(Equal("tilt"))
+	})
+
+	It("should switch the dev environment to the tool set with --tool", func() {
+		Expect(edit()).To(Succeed())
+		Expect(edit("--tool=skaffold")).To(Succeed())
+
+		Expect(afero.Exists(fs.FS, "skaffold.yaml")).To(BeTrue())
+		makefile, err := afero.ReadFile(fs.FS, "Makefile")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(makefile)).To(ContainSubstring("$(SKAFFOLD) dev"))
+		Expect(string(makefile)).NotTo(ContainSubstring("$(TILT) up"))
+		Expect(trackedTool()).To(Equal("skaffold"))
+	})
+
+	It("should keep the tool tracked in the PROJECT file when --tool is not set", func() {
+		Expect(insertPluginMetaToConfig(cfg, pluginConfig{Tool: "skaffold"})).To(Succeed())
+
+		Expect(edit()).To(Succeed())
+
+		Expect(afero.Exists(fs.FS, "skaffold.yaml")).To(BeTrue())
+		Expect(afero.Exists(fs.FS, "Tiltfile")).To(BeFalse())
+		Expect(trackedTool()).To(Equal("skaffold"))
+	})
+
+	It("should reject an unsupported tool", func() {
+		Expect(edit("--tool=garden")).To(MatchError(ContainSubstring(`invalid tool "garden"`)))
+		Expect(afero.Exists(fs.FS, "Tiltfile")).To(BeFalse())
+	})
+})
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/devenv/v1alpha/scaffolds"
+)
+
+var _ plugin.InitSubcommand = &initSubcommand{}
+
+type initSubcommand struct {
+	config config.Config
+
+	// tool is the tool used to run the dev environment
+	tool string
+}
+
+func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	subcmdMeta.Description = metaDataDescription
+
+	subcmdMeta.Examples = fmt.Sprintf(`  # Initialize a common project with a Tilt dev environment
+  %[1]s init --plugins=go/v4,%[2]s
+
+  # Initialize a common project with a Skaffold dev environment
+  %[1]s init --plugins=go/v4,%[2]s --tool=skaffold
+`, cliMeta.CommandName, pluginKey)
+
+	subcmdMeta.Flags = append(subcmdMeta.Flags, plugin.FlagMetadata{Name: "tool", Enum: scaffolds.Tools})
+}
+
+func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&p.tool, "tool", scaffolds.TiltTool,
+		"tool used to run the dev environment")
+}
+
+func (p *initSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+	return validateTool(p.tool)
+}
+
+func (p *initSubcommand) Scaffold(fs machinery.Filesystem) error {
+	if err := insertPluginMetaToConfig(p.config, pluginConfig{Tool: p.tool}); err != nil {
+		return err
+	}
+
+	scaffolder := scaffolds.NewInitScaffolder(p.config, p.tool, false)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
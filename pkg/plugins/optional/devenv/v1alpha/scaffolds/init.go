@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/devenv/v1alpha/scaffolds/internal/templates"
+)
+
+const (
+	// TiltTool runs the dev environment with Tilt
+	TiltTool = "tilt"
+	// SkaffoldTool runs the dev environment with Skaffold
+	SkaffoldTool = "skaffold"
+)
+
+// Tools are the tools which can be used to run the dev environment
+var Tools = []string{TiltTool, SkaffoldTool}
+
+var _ plugins.Scaffolder = &initScaffolder{}
+
+type initScaffolder struct {
+	config config.Config
+
+	// tool is the tool used to run the dev environment
+	tool string
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+
+	// force indicates whether to overwrite the scaffolded files
+	force bool
+}
+
+// NewInitScaffolder returns a new Scaffolder for the dev environment
+func NewInitScaffolder(config config.Config, tool string, force bool) plugins.Scaffolder {
+	return &initScaffolder{
+		config: config,
+		tool:   tool,
+		force:  force,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *initScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *initScaffolder) Scaffold() error {
+	log.Printf("Generating the %s dev environment...", s.tool)
+
+	// Initialize the machinery.Scaffold that will write the files to disk
+	scaffold := machinery.NewScaffold(s.fs,
+		machinery.WithConfig(s.config),
+	)
+
+	var toolFile machinery.Builder = &templates.Tiltfile{Force: s.force}
+	if s.tool == SkaffoldTool {
+		toolFile = &templates.Skaffold{Force: s.force}
+	}
+	if err := scaffold.Execute(toolFile); err != nil {
+		return fmt.Errorf("error scaffolding the %s configuration: %w", s.tool, err)
+	}
+
+	return s.updateMakefile()
+}
+
+// devSectionHeader is the header of the Makefile section with the dev target
+const devSectionHeader = "##@ Dev Environment"
+
+// updateMakefile adds the dev target to the Makefile, before its Dependencies section.
+// When the section already exists, it is replaced so that the target runs the selected tool.
+func (s *initScaffolder) updateMakefile() error {
+	const makefile = "Makefile"
+	content, err := afero.ReadFile(s.fs.FS, makefile)
+	if errors.Is(err, afero.ErrFileNotFound) {
+		log.Warnf("Unable to find the %s to add the dev target", makefile)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error reading the %s: %w", makefile, err)
+	}
+
+	section := fmt.Sprintf(devSectionTemplate, devSectionHeader, strings.ToUpper(s.tool), s.tool, devCommands[s.tool])
+	makefileContent := string(content)
+	if start := strings.Index(makefileContent, devSectionHeader); start >= 0 {
+		end := len(makefileContent)
+		if next := strings.Index(makefileContent[start+len(devSectionHeader):], "##@ "); next >= 0 {
+			end = start + len(devSectionHeader) + next
+		}
+		makefileContent = makefileContent[:start] + section + makefileContent[end:]
+	} else if start := strings.Index(makefileContent, "##@ Dependencies"); start >= 0 {
+		makefileContent = makefileContent[:start] + section + makefileContent[start:]
+	} else {
+		makefileContent += "\n" + section
+	}
+
+	return afero.WriteFile(s.fs.FS, makefile, []byte(makefileContent), 0o644)
+}
+
+// devCommands are the commands running the dev environment with each tool
+var devCommands = map[string]string{
+	TiltTool:     "up",
+	SkaffoldTool: "dev",
+}
+
+const devSectionTemplate = `%[1]s
+
+# The dev target runs the manager on the Kind cluster KIND_CLUSTER, which is created if it is not running,
+# and rebuilds it on each change of the code.
+KIND_CLUSTER ?= kind
+%[2]s ?= %[3]s
+
+.PHONY: dev
+dev: manifests generate kustomize ## Run the manager on a Kind cluster and rebuild it on each change of the code.
+	@command -v kind >/dev/null 2>&1 || { \
+		echo "Kind is not installed. Please install Kind manually."; \
+		exit 1; \
+	}
+	@command -v $(%[2]s) >/dev/null 2>&1 || { \
+		echo "$(%[2]s) is not installed. Please install it manually."; \
+		exit 1; \
+	}
+	@kind get clusters | grep -qx '$(KIND_CLUSTER)' || kind create cluster --name $(KIND_CLUSTER)
+	$(KUBECTL) config use-context kind-$(KIND_CLUSTER)
+	PATH="$(LOCALBIN):$$PATH" $(%[2]s) %[4]s
+
+`
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &Skaffold{}
+
+// Skaffold scaffolds the Skaffold configuration which runs the manager on a Kind cluster
+// and redeploys it on each change of the code
+type Skaffold struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+
+	Force bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *Skaffold) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = "skaffold.yaml"
+	}
+
+	f.TemplateBody = skaffoldTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+
+	return nil
+}
+
+const skaffoldTemplate = `# Runs the manager on a Kind cluster and rebuilds and redeploys it on each change of the code.
+# Start it with 'make dev', which creates the Kind cluster if it is not running.
+# More info: https://skaffold.dev/docs/
+apiVersion: skaffold/v4beta11
+kind: Config
+metadata:
+  name: {{ .ProjectName }}
+build:
+  local:
+    push: false
+  artifacts:
+  # The image name matches the one of config/manager, which is replaced in the deployed manifests
+  - image: controller
+    docker:
+      dockerfile: Dockerfile
+    # Regenerate the manifests and the DeepCopy methods before building the manager
+    hooks:
+      before:
+      - command: ["make", "manifests", "generate"]
+        os: [darwin, linux]
+manifests:
+  kustomize:
+    paths:
+    - config/default
+deploy:
+  kubectl: {}
+`
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &Tiltfile{}
+
+// Tiltfile scaffolds the Tiltfile which runs the manager on a Kind cluster with live-reload
+type Tiltfile struct {
+	machinery.TemplateMixin
+
+	Force bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *Tiltfile) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = "Tiltfile"
+	}
+
+	f.TemplateBody = tiltfileTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+
+	return nil
+}
+
+const tiltfileTemplate = `# -*- mode: Python -*-
+
+# Runs the manager on a Kind cluster with live-reload: the manager binary is built on the host
+# and synced into the running container, which is restarted, instead of rebuilding the image.
+# Start it with 'make dev', which creates the Kind cluster if it is not running.
+# More info: https://docs.tilt.dev/
+
+load('ext://restart_process', 'docker_build_with_restart')
+
+# Regenerate the manifests and the DeepCopy methods when the APIs or the markers change
+local_resource(
+    'generate',
+    'make manifests generate',
+    deps=['api', 'internal'],
+    ignore=['**/zz_generated.deepcopy.go', '**/*_test.go'],
+    labels=['manager'],
+)
+
+# Build the manager binary for the container on the host
+local_resource(
+    'manager-binary',
+    'CGO_ENABLED=0 GOOS=linux go build -o bin/manager-linux cmd/main.go',
+    deps=['api', 'cmd', 'internal', 'go.mod', 'go.sum'],
+    ignore=['**/*_test.go'],
+    resource_deps=['generate'],
+    labels=['manager'],
+)
+
+# The image with a shell allows restarting the manager after syncing the binary.
+# The image name matches the one of config/manager, which is replaced in the deployed manifests.
+docker_build_with_restart(
+    'controller',
+    '.',
+    dockerfile_contents="""
+FROM alpine:3.21
+WORKDIR /
+COPY bin/manager-linux /manager
+USER 65532:65532
+""",
+    entrypoint=['/manager'],
+    only=['bin/manager-linux'],
+    live_update=[sync('bin/manager-linux', '/manager')],
+)
+
+# Deploy the manifests of config/default, which are rendered again when they change
+watch_file('config')
+k8s_yaml(local('bin/kustomize build config/default'))
+`
@@ -18,24 +18,42 @@ package v1alpha
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/grafana/v1alpha/scaffolds"
 )
 
 // InsertPluginMetaToConfig will insert the metadata to the plugin configuration
 func InsertPluginMetaToConfig(target config.Config, cfg pluginConfig) error {
-	err := target.DecodePluginConfig(pluginKey, cfg)
-	if !errors.As(err, &config.UnsupportedFieldError{}) {
-
-		if err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
-			return err
-		}
+	if err := target.EncodePluginConfig(pluginKey, cfg); err != nil &&
+		!errors.As(err, &config.UnsupportedFieldError{}) {
+		return err
+	}
 
-		if err = target.EncodePluginConfig(pluginKey, cfg); err != nil {
-			return err
-		}
+	return nil
+}
 
+// loadPluginConfig returns the plugin configuration stored in the project, if any
+func loadPluginConfig(target config.Config) (pluginConfig, error) {
+	cfg := pluginConfig{}
+	err := target.DecodePluginConfig(pluginKey, &cfg)
+	if err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) &&
+		!errors.As(err, &config.UnsupportedFieldError{}) {
+		return cfg, err
 	}
 
-	return nil
+	return cfg, nil
+}
+
+// validateOutputFormat checks that the output format of the dashboards is supported
+func validateOutputFormat(outputFormat string) error {
+	for _, format := range scaffolds.OutputFormats {
+		if outputFormat == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %q, supported formats are: %s",
+		outputFormat, strings.Join(scaffolds.OutputFormats, ", "))
 }
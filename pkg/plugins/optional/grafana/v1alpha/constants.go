@@ -20,6 +20,11 @@ package v1alpha
 const metaDataDescription = `This command will add Grafana manifests to the project:
   - A JSON file includes dashboard manifest that can be directly copied to Grafana Web UI.
 	('grafana/controller-runtime-metrics.json')
+  - Alerts on the reconcile error rate, the work queue depth and the leader election, as a PrometheusRule
+    and as Grafana-managed alert rules, which are kept updated by the edit command.
+	('grafana/alerts/controller-runtime-alerts.yaml', 'grafana/alerts/controller-runtime-alerts.json')
+  - With '--output-format=grafana-operator', the dashboards wrapped into GrafanaDashboard resources of the Grafana Operator.
+	('config/grafana/controller-runtime-metrics.yaml')
 
 NOTE: This plugin requires:
 - Access to Prometheus
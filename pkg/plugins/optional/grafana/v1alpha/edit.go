@@ -19,6 +19,8 @@ package v1alpha
 import (
 	"fmt"
 
+	"github.com/spf13/pflag"
+
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
@@ -29,6 +31,11 @@ var _ plugin.EditSubcommand = &editSubcommand{}
 
 type editSubcommand struct {
 	config config.Config
+
+	outputFormat string
+
+	// flagSet is used to know if the output format of the dashboards must be changed
+	flagSet *pflag.FlagSet
 }
 
 func (p *editSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
@@ -36,20 +43,49 @@ func (p *editSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *
 
 	subcmdMeta.Examples = fmt.Sprintf(`  # Edit a common project with this plugin
   %[1]s edit --plugins=%[2]s
+
+  # Wrap the dashboards into GrafanaDashboard resources of the Grafana Operator
+  %[1]s edit --plugins=%[2]s --output-format=grafana-operator
 `, cliMeta.CommandName, pluginKey)
 }
 
+func (p *editSubcommand) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&p.outputFormat, "output-format", scaffolds.JSONFormat,
+		"output format of the dashboards: json, or grafana-operator to also scaffold GrafanaDashboard "+
+			"resources of the Grafana Operator under config/grafana. Defaults to the format tracked in the PROJECT file")
+	p.flagSet = fs
+}
+
 func (p *editSubcommand) InjectConfig(c config.Config) error {
 	p.config = c
 	return nil
 }
 
 func (p *editSubcommand) Scaffold(fs machinery.Filesystem) error {
-	if err := InsertPluginMetaToConfig(p.config, pluginConfig{}); err != nil {
+	cfg, err := loadPluginConfig(p.config)
+	if err != nil {
 		return err
 	}
 
-	scaffolder := scaffolds.NewEditScaffolder()
+	// Keep the output format tracked in the PROJECT file unless it is informed
+	if p.flagSet.Changed("output-format") {
+		if err := validateOutputFormat(p.outputFormat); err != nil {
+			return err
+		}
+		cfg.OutputFormat = ""
+		if p.outputFormat != scaffolds.JSONFormat {
+			cfg.OutputFormat = p.outputFormat
+		}
+	}
+	if err := InsertPluginMetaToConfig(p.config, cfg); err != nil {
+		return err
+	}
+
+	outputFormat := cfg.OutputFormat
+	if outputFormat == "" {
+		outputFormat = scaffolds.JSONFormat
+	}
+	scaffolder := scaffolds.NewEditScaffolder(outputFormat)
 	scaffolder.InjectFS(fs)
 	return scaffolder.Scaffold()
 }
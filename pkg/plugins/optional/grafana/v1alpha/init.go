@@ -19,6 +19,8 @@ package v1alpha
 import (
 	"fmt"
 
+	"github.com/spf13/pflag"
+
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
@@ -29,6 +31,8 @@ var _ plugin.InitSubcommand = &initSubcommand{}
 
 type initSubcommand struct {
 	config config.Config
+
+	outputFormat string
 }
 
 func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
@@ -36,20 +40,37 @@ func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *
 
 	subcmdMeta.Examples = fmt.Sprintf(`  # Initialize a common project with this plugin
   %[1]s init --plugins=%[2]s
+
+  # Initialize a project with the dashboards wrapped into GrafanaDashboard resources of the Grafana Operator
+  %[1]s init --plugins=%[2]s --output-format=grafana-operator
 `, cliMeta.CommandName, pluginKey)
 }
 
+func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&p.outputFormat, "output-format", scaffolds.JSONFormat,
+		"output format of the dashboards: json, or grafana-operator to also scaffold GrafanaDashboard "+
+			"resources of the Grafana Operator under config/grafana")
+}
+
 func (p *initSubcommand) InjectConfig(c config.Config) error {
 	p.config = c
 	return nil
 }
 
 func (p *initSubcommand) Scaffold(fs machinery.Filesystem) error {
-	if err := InsertPluginMetaToConfig(p.config, pluginConfig{}); err != nil {
+	if err := validateOutputFormat(p.outputFormat); err != nil {
+		return err
+	}
+
+	cfg := pluginConfig{}
+	if p.outputFormat != scaffolds.JSONFormat {
+		cfg.OutputFormat = p.outputFormat
+	}
+	if err := InsertPluginMetaToConfig(p.config, cfg); err != nil {
 		return err
 	}
 
-	scaffolder := scaffolds.NewInitScaffolder()
+	scaffolder := scaffolds.NewInitScaffolder(p.outputFormat)
 	scaffolder.InjectFS(fs)
 	return scaffolder.Scaffold()
 }
@@ -39,7 +39,9 @@ type Plugin struct {
 }
 
 var (
-	_ plugin.Init = Plugin{}
+	_ plugin.Init           = Plugin{}
+	_ plugin.Deprecated     = Plugin{}
+	_ plugin.HasDeprecation = Plugin{}
 )
 
 // Name returns the name of the plugin
@@ -57,9 +59,18 @@ func (p Plugin) GetInitSubcommand() plugin.InitSubcommand { return &p.initSubcom
 // GetEditSubcommand will return the subcommand which is responsible for adding grafana manifests
 func (p Plugin) GetEditSubcommand() plugin.EditSubcommand { return &p.editSubcommand }
 
-type pluginConfig struct{}
+type pluginConfig struct {
+	// OutputFormat is the output format of the dashboards, omitted for the default JSON format
+	OutputFormat string `json:"outputFormat,omitempty"`
+}
+
+// Deprecated define whether the plugin is deprecated and its deprecation message
+func (p Plugin) Deprecated() (bool, string) {
+	return false, ""
+}
 
 // DeprecationWarning define the deprecation message or return empty when plugin is not deprecated
 func (p Plugin) DeprecationWarning() string {
-	return ""
+	_, warning := p.Deprecated()
+	return warning
 }
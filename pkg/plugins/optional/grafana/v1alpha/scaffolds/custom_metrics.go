@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/grafana/v1alpha/scaffolds/internal/templates"
+)
+
+const (
+	timeSeriesPanel = "timeseries"
+	tablePanel      = "table"
+)
+
+// panelTypes are the types of the panels of the custom metrics supported by the v2 format of the config
+var panelTypes = []string{timeSeriesPanel, "stat", "gauge", "bargauge", tablePanel}
+
+// metricTypes are the types of the custom metrics whose query can be generated
+var metricTypes = []string{"counter", "gauge", "histogram"}
+
+// validateCustomMetricItemsV2 validates the custom metrics of the v2 format of the config and fills their defaults.
+// Unlike the v1 format, whose malformed items are skipped, it fails with the errors of all the malformed items.
+func validateCustomMetricItemsV2(rawItems []templates.CustomMetricItem) ([]templates.CustomMetricItem, error) {
+	items := make([]templates.CustomMetricItem, 0, len(rawItems))
+	var errs []error
+	for i, item := range rawItems {
+		if itemErrs := validateCustomMetricItemV2(item); len(itemErrs) > 0 {
+			for _, err := range itemErrs {
+				errs = append(errs, fmt.Errorf("%s: %w", itemName(i, item), err))
+			}
+			continue
+		}
+		items = append(items, fillCustomMetricItemV2(item))
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid custom metrics:\n%w", errors.Join(errs...))
+	}
+	return items, nil
+}
+
+// itemName identifies an item of the config in the errors
+func itemName(i int, item templates.CustomMetricItem) string {
+	if item.Metric == "" {
+		return fmt.Sprintf("customMetrics[%d]", i)
+	}
+	return fmt.Sprintf("customMetrics[%d] (%s)", i, item.Metric)
+}
+
+func validateCustomMetricItemV2(item templates.CustomMetricItem) []error {
+	var errs []error
+
+	if item.Type != "" && !slices.Contains(metricTypes, strings.ToLower(item.Type)) {
+		errs = append(errs, fmt.Errorf("unsupported type %q, must be one of %s",
+			item.Type, strings.Join(metricTypes, ", ")))
+	}
+
+	switch {
+	case item.Expr != "" && len(item.Queries) > 0:
+		errs = append(errs, errors.New("expr and queries are mutually exclusive"))
+	case item.Expr == "" && len(item.Queries) == 0 && (item.Metric == "" || item.Type == ""):
+		errs = append(errs, errors.New("metric and type are required to generate the query "+
+			"when neither expr nor queries are set"))
+	}
+	for j, query := range item.Queries {
+		if strings.TrimSpace(query.Expr) == "" {
+			errs = append(errs, fmt.Errorf("queries[%d]: expr is required", j))
+		}
+	}
+	if item.Metric == "" && item.Title == "" {
+		errs = append(errs, errors.New("title is required when metric is not set"))
+	}
+
+	if item.PanelType != "" && !slices.Contains(panelTypes, item.PanelType) {
+		errs = append(errs, fmt.Errorf("unsupported panelType %q, must be one of %s",
+			item.PanelType, strings.Join(panelTypes, ", ")))
+	}
+
+	for j, threshold := range item.Thresholds {
+		if threshold.Color == "" {
+			errs = append(errs, fmt.Errorf("thresholds[%d]: color is required", j))
+		}
+		if j > 0 && threshold.Value <= item.Thresholds[j-1].Value {
+			errs = append(errs, fmt.Errorf("thresholds[%d]: value %v must be greater than the value %v "+
+				"of the previous threshold", j, threshold.Value, item.Thresholds[j-1].Value))
+		}
+	}
+
+	return errs
+}
+
+// fillCustomMetricItemV2 fills the defaults of a valid item of the v2 format. The values provided by the user
+// are escaped, since the dashboard is rendered as JSON, while the generated queries are already escaped.
+func fillCustomMetricItemV2(item templates.CustomMetricItem) templates.CustomMetricItem {
+	item.Title = escapeJSON(item.Title)
+	if item.Title == "" && item.Type == "" {
+		item.Title = item.Metric
+	}
+	item.Unit = escapeJSON(item.Unit)
+	if item.Expr != "" {
+		item.Queries = []templates.CustomMetricQuery{{Expr: item.Expr}}
+		item.Expr = ""
+	}
+	for j, query := range item.Queries {
+		item.Queries[j] = templates.CustomMetricQuery{Expr: escapeJSON(query.Expr), Legend: escapeJSON(query.Legend)}
+	}
+	if len(item.Queries) == 0 {
+		item = fillMissingExpr(item)
+	}
+	for j, threshold := range item.Thresholds {
+		item.Thresholds[j].Color = escapeJSON(threshold.Color)
+	}
+	return fillPanel(fillMissingUnit(item))
+}
+
+// escapeJSON escapes a string to be rendered into a JSON string
+func escapeJSON(s string) string {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	// A string can always be encoded
+	_ = encoder.Encode(s)
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(buf.String()), `"`), `"`)
+}
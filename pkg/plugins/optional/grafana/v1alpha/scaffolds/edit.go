@@ -36,13 +36,15 @@ var _ plugins.Scaffolder = &editScaffolder{}
 const configFilePath = "grafana/custom-metrics/config.yaml"
 
 type editScaffolder struct {
+	outputFormat string
+
 	// fs is the filesystem that will be used by the scaffolder
 	fs machinery.Filesystem
 }
 
 // NewEditScaffolder returns a new Scaffolder for project edition operations
-func NewEditScaffolder() plugins.Scaffolder {
-	return &editScaffolder{}
+func NewEditScaffolder(outputFormat string) plugins.Scaffolder {
+	return &editScaffolder{outputFormat: outputFormat}
 }
 
 // InjectFS implements cmdutil.Scaffolder
@@ -90,6 +92,25 @@ func configReader(reader io.Reader) ([]templates.CustomMetricItem, error) {
 		return nil, err
 	}
 
+	switch config.Version {
+	case "", "v1":
+		for i, item := range config.CustomMetrics {
+			if item.Title != "" || len(item.Queries) > 0 || item.PanelType != "" || len(item.Thresholds) > 0 {
+				return nil, fmt.Errorf("%s: title, queries, panelType and thresholds require \"version: %s\"",
+					itemName(i, item), templates.CustomMetricsConfigV2)
+			}
+		}
+	case templates.CustomMetricsConfigV2:
+		// The fields of the v2 format are checked strictly, so that their typos are reported
+		if err := yaml.UnmarshalStrict(yamlFile, &config); err != nil {
+			return nil, fmt.Errorf("invalid custom metrics: %w", err)
+		}
+		return validateCustomMetricItemsV2(config.CustomMetrics)
+	default:
+		return nil, fmt.Errorf("unsupported version %q of the config, must be v1 or %s",
+			config.Version, templates.CustomMetricsConfigV2)
+	}
+
 	validatedMetricItems := validateCustomMetricItems(config.CustomMetrics)
 
 	return validatedMetricItems, nil
@@ -108,7 +129,7 @@ func validateCustomMetricItems(rawItems []templates.CustomMetricItem) []template
 	validatedItems := make([]templates.CustomMetricItem, len(filterResult))
 	for i, item := range filterResult {
 		item = fillMissingExpr(item)
-		validatedItems[i] = fillMissingUnit(item)
+		validatedItems[i] = fillPanel(fillMissingUnit(item))
 	}
 
 	return validatedItems
@@ -160,6 +181,27 @@ func fillMissingUnit(item templates.CustomMetricItem) templates.CustomMetricItem
 	return item
 }
 
+// fillPanel fills the panel of the item as the v1 format defines it: a panel titled after the metric, with its
+// expression as single query, which is a table for the info metrics and a time series otherwise
+func fillPanel(item templates.CustomMetricItem) templates.CustomMetricItem {
+	if item.Title == "" {
+		item.Title = item.Metric + " (" + item.Type + ")"
+	}
+	if len(item.Queries) == 0 {
+		item.Queries = []templates.CustomMetricQuery{{Expr: item.Expr}}
+	}
+	if item.PanelType == "" {
+		item.PanelType = timeSeriesPanel
+		if strings.HasSuffix(item.Metric, "_info") {
+			item.PanelType = tablePanel
+		}
+	}
+	if len(item.Thresholds) == 0 {
+		item.Thresholds = []templates.CustomMetricThreshold{{Value: 80, Color: "red"}}
+	}
+	return item
+}
+
 // Scaffold implements cmdutil.Scaffolder
 func (s *editScaffolder) Scaffold() error {
 	log.Println("Generating Grafana manifests to visualize controller status...")
@@ -172,6 +214,8 @@ func (s *editScaffolder) Scaffold() error {
 	var templatesBuilder = []machinery.Builder{
 		&templates.RuntimeManifest{},
 		&templates.ResourcesManifest{},
+		&templates.AlertsManifest{},
+		&templates.GrafanaAlertsManifest{},
 		&templates.CustomMetricsConfigManifest{ConfigPath: configPath},
 	}
 
@@ -179,8 +223,15 @@ func (s *editScaffolder) Scaffold() error {
 	if err == nil && len(configItems) > 0 {
 		templatesBuilder = append(templatesBuilder, &templates.CustomMetricsDashManifest{Items: configItems})
 	} else if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Error on scaffolding manifest for custom metris:\n%v", err)
+		_, _ = fmt.Fprintf(os.Stderr, "Error on scaffolding manifest for custom metrics:\n%v\n", err)
+	}
+
+	if err := scaffold.Execute(templatesBuilder...); err != nil {
+		return err
 	}
 
-	return scaffold.Execute(templatesBuilder...)
+	if s.outputFormat == GrafanaOperatorFormat {
+		return scaffoldDashboardResources(s.fs, scaffold)
+	}
+	return nil
 }
@@ -27,13 +27,15 @@ import (
 var _ plugins.Scaffolder = &initScaffolder{}
 
 type initScaffolder struct {
+	outputFormat string
+
 	// fs is the filesystem that will be used by the scaffolder
 	fs machinery.Filesystem
 }
 
 // NewInitScaffolder returns a new Scaffolder for project initialization operations
-func NewInitScaffolder() plugins.Scaffolder {
-	return &initScaffolder{}
+func NewInitScaffolder(outputFormat string) plugins.Scaffolder {
+	return &initScaffolder{outputFormat: outputFormat}
 }
 
 // InjectFS implements cmdutil.Scaffolder
@@ -48,10 +50,18 @@ func (s *initScaffolder) Scaffold() error {
 	// Initialize the machinery.Scaffold that will write the files to disk
 	scaffold := machinery.NewScaffold(s.fs)
 
-	return scaffold.Execute(
+	if err := scaffold.Execute(
 		&templates.RuntimeManifest{},
 		&templates.ResourcesManifest{},
+		&templates.AlertsManifest{},
+		&templates.GrafanaAlertsManifest{},
 		&templates.CustomMetricsConfigManifest{ConfigPath: string(configFilePath)},
-	)
+	); err != nil {
+		return err
+	}
 
+	if s.outputFormat == GrafanaOperatorFormat {
+		return scaffoldDashboardResources(s.fs, scaffold)
+	}
+	return nil
 }
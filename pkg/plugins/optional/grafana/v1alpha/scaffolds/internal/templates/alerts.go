@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &AlertsManifest{}
+
+// AlertsManifest scaffolds a PrometheusRule with the alerts on the default controller-runtime metrics
+type AlertsManifest struct {
+	machinery.TemplateMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *AlertsManifest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("grafana", "alerts", "controller-runtime-alerts.yaml")
+	}
+
+	// Prometheus alert templates use {{ }}, which is collided with default delimiter for go template parsing.
+	// Provide an alternative delimiter here to avoid overlaps.
+	f.SetDelim("[[", "]]")
+	f.TemplateBody = alertsTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+//nolint:lll
+const alertsTemplate = `# This PrometheusRule defines the alerts on the default controller-runtime metrics.
+# It requires the Prometheus Operator: apply it in the namespace of the project, and ensure
+# that the ruleSelector of your Prometheus instance selects it, e.g. by adding the expected labels.
+# The same alerts are available as Grafana-managed alert rules in controller-runtime-alerts.json.
+apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  name: controller-runtime-alerts
+spec:
+  groups:
+  - name: controller-runtime
+    rules:
+    - alert: ControllerReconcileErrorRateHigh
+      expr: |
+        sum(rate(controller_runtime_reconcile_errors_total[5m])) by (namespace, controller)
+          / sum(rate(controller_runtime_reconcile_total[5m])) by (namespace, controller) > 0.1
+      for: 10m
+      labels:
+        severity: warning
+      annotations:
+        summary: High reconcile error rate for the controller {{ $labels.controller }}
+        description: More than 10% of the reconciliations of the controller {{ $labels.controller }} in the namespace {{ $labels.namespace }} failed during the last 10 minutes.
+    - alert: ControllerWorkqueueDepthHigh
+      expr: |
+        sum(workqueue_depth) by (namespace, name) > 100
+      for: 15m
+      labels:
+        severity: warning
+      annotations:
+        summary: Work queue {{ $labels.name }} is not drained
+        description: The work queue {{ $labels.name }} in the namespace {{ $labels.namespace }} has more than 100 items waiting for 15 minutes.
+    - alert: ControllerLeaderElectionLost
+      expr: |
+        max(leader_election_master_status) by (namespace, name) < 1
+      for: 5m
+      labels:
+        severity: critical
+      annotations:
+        summary: No leader for the lease {{ $labels.name }}
+        description: None of the replicas in the namespace {{ $labels.namespace }} holds the leader election lease {{ $labels.name }}, so the controllers are not reconciling.
+`
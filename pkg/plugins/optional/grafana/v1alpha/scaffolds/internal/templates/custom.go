@@ -54,4 +54,30 @@ customMetrics:
 #     unit: none
 #     type: histogram
 #   	expr: histogram_quantile(0.90, sum by(instance, le) (rate(foo_bar{job=\"$job\", namespace=\"$namespace\"}[5m])))
+#
+#
+# The v2 format, enabled with "version: v2" at the top of the file, also supports:
+#    title:      # Title of the panel, defaults to the metric and its type (required without metric)
+#    queries:    # PromQL queries of the panel, which replace expr (optional)
+#      - expr:   # PromQL expression of the query (required)
+#        legend: # Legend format of the series of the query, e.g. "{{ "{{pod}}" }}" (optional)
+#    panelType:  # Type of the panel: timeseries/stat/gauge/bargauge/table (optional)
+#    thresholds: # Steps of the thresholds of the panel, above the green base step (optional)
+#      - value:  # Value from which the step applies, greater than the previous one (required)
+#        color:  # Color of the step, examples: red, #E02F44 (required)
+# Its expressions are plain PromQL, without escaped quotes, and its malformed metrics are reported instead of skipped.
+#
+# Example:
+# ---
+# version: v2
+# customMetrics:
+#   - title: Reconcile errors by controller
+#     unit: reqps
+#     queries:
+#       - expr: sum(rate(controller_runtime_reconcile_errors_total{job="$job"}[5m])) by (controller)
+#         legend: "{{ "{{controller}}" }}"
+#     panelType: timeseries
+#     thresholds:
+#       - value: 1
+#         color: red
 `
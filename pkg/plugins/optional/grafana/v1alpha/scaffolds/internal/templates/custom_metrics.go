@@ -20,14 +20,20 @@ import (
 	"bytes"
 	"fmt"
 	"path/filepath"
-	"strings"
+	"strconv"
 	"text/template"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 )
 
+// CustomMetricsConfigV2 is the version of the format of the config of the custom metrics which supports
+// the queries, the panel types and the thresholds of the metrics
+const CustomMetricsConfigV2 = "v2"
+
 // CustomMetricsConfig represents the configuration for custom metrics
 type CustomMetricsConfig struct {
+	// Version is the version of the format of the config. The v1 format is assumed if it is not set
+	Version       string             `json:"version,omitempty"`
 	CustomMetrics []CustomMetricItem `json:"customMetrics"`
 }
 
@@ -37,6 +43,33 @@ type CustomMetricItem struct {
 	Type   string `json:"type"`
 	Expr   string `json:"expr,omitempty"`
 	Unit   string `json:"unit,omitempty"`
+
+	// The fields below are only supported by the v2 format
+
+	// Title is the title of the panel, which defaults to the metric and its type
+	Title string `json:"title,omitempty"`
+	// Queries are the PromQL queries of the panel, which replace expr
+	Queries []CustomMetricQuery `json:"queries,omitempty"`
+	// PanelType is the type of the panel: timeseries, stat, gauge, bargauge or table
+	PanelType string `json:"panelType,omitempty"`
+	// Thresholds are the steps of the thresholds of the panel, above the base step which is green
+	Thresholds []CustomMetricThreshold `json:"thresholds,omitempty"`
+}
+
+// CustomMetricQuery is a PromQL query of the panel of a custom metric
+type CustomMetricQuery struct {
+	// Expr is the PromQL expression of the query
+	Expr string `json:"expr"`
+	// Legend is the legend format of the series of the query, e.g. {{pod}}
+	Legend string `json:"legend,omitempty"`
+}
+
+// CustomMetricThreshold is a step of the thresholds of the panel of a custom metric
+type CustomMetricThreshold struct {
+	// Value is the value from which the step applies
+	Value float64 `json:"value"`
+	// Color is the color of the step, e.g. red or #EAB839
+	Color string `json:"color"`
 }
 
 var _ machinery.Template = &CustomMetricsDashManifest{}
@@ -60,6 +93,8 @@ func (f *CustomMetricsDashManifest) SetTemplateDefaults() error {
 	}
 
 	f.TemplateBody = defaultTemplate
+	// The dashboard is already rendered, so the legends of the queries, e.g. {{pod}}, must not be parsed again
+	f.SetDelim("[[%", "%]]")
 
 	f.IfExistsAction = machinery.OverwriteFile
 
@@ -70,7 +105,12 @@ var fns = template.FuncMap{
 	"plus1": func(x int) int {
 		return x + 1
 	},
-	"hasSuffix": strings.HasSuffix,
+	"refID": func(i int) string {
+		return string(rune('A' + i))
+	},
+	"formatFloat": func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	},
 }
 
 func (f *CustomMetricsDashManifest) createTemplate() (string, error) {
@@ -133,6 +173,7 @@ const customMetricsDashTemplate = `{
       "fieldConfig": {
         "defaults": {
           "color": {
+{{- if or (eq .PanelType "timeseries") (eq .PanelType "table") }}
             "mode": "continuous-GrYlRd"
           },
           "custom": {
@@ -163,6 +204,10 @@ const customMetricsDashTemplate = `{
               "mode": "off"
             }
           },
+{{- else }}
+            "mode": "thresholds"
+          },
+{{- end }}
           "mappings": [],
           "thresholds": {
             "mode": "absolute",
@@ -170,11 +215,11 @@ const customMetricsDashTemplate = `{
               {
                 "color": "green",
                 "value": null
-              },
+              }{{ range .Thresholds }},
               {
-                "color": "red",
-                "value": 80
-              }
+                "color": "{{ .Color }}",
+                "value": {{ formatFloat .Value }}
+              }{{ end }}
             ]
           },
           "unit": "{{ .Unit }}"
@@ -188,6 +233,7 @@ const customMetricsDashTemplate = `{
       "interval": "1m",
       "links": [],
       "options": {
+{{- if or (eq .PanelType "timeseries") (eq .PanelType "table") }}
         "legend": {
           "calcs": [],
           "displayMode": "list",
@@ -197,22 +243,49 @@ const customMetricsDashTemplate = `{
           "mode": "single",
           "sort": "none"
         }
+{{- else }}
+        "reduceOptions": {
+          "calcs": [
+            "lastNotNull"
+          ],
+          "fields": "",
+          "values": false
+        },
+{{- if eq .PanelType "stat" }}
+        "colorMode": "value",
+        "graphMode": "area",
+        "justifyMode": "auto",
+        "orientation": "auto",
+        "textMode": "auto"
+{{- else if eq .PanelType "gauge" }}
+        "orientation": "auto",
+        "showThresholdLabels": false,
+        "showThresholdMarkers": true
+{{- else }}
+        "displayMode": "gradient",
+        "orientation": "horizontal",
+        "showUnfilled": true
+{{- end }}
+{{- end }}
       },
       "pluginVersion": "8.4.3",
-      "targets": [
+      "targets": [{{ $q := len .Queries }}{{ range $j, $query := .Queries }}
         {
           "datasource": "${DS_PROMETHEUS}",
           "exemplar": true,
-          "expr": "{{ .Expr }}",
+          "expr": "{{ $query.Expr }}",
           "format": "time_series",
           "interval": "",
           "intervalFactor": 2,
-          "refId": "A",
+{{- if $query.Legend }}
+          "legendFormat": "{{ $query.Legend }}",
+{{- end }}
+          "refId": "{{ refID $j }}",
           "step": 10
-        }
+        }{{ if ne (plus1 $j) $q }},{{ end }}{{ end }}
       ],
-      "title": "{{ .Metric }} ({{ .Type }})",
-{{- if hasSuffix .Metric "_info" }}
+      "title": "{{ .Title }}",
+{{- if eq .PanelType "table" }}
       "transformations": [
         {
           "id": "labelsToFields",
@@ -223,7 +296,7 @@ const customMetricsDashTemplate = `{
       ],
       "type": "table"
 {{- else }}
-      "type": "timeseries"
+      "type": "{{ .PanelType }}"
 {{- end }}
     }{{ if ne (plus1 $i) $n }},
 		{{end}}{{end}}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &GrafanaDashboardManifest{}
+
+// GrafanaDashboardManifest scaffolds a GrafanaDashboard custom resource of the Grafana Operator
+// which wraps the JSON of a dashboard
+type GrafanaDashboardManifest struct {
+	machinery.TemplateMixin
+
+	// Name is the name of the dashboard, which is also used as the name of the resource and of its file
+	Name string
+	// Dashboard is the JSON of the dashboard
+	Dashboard string
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *GrafanaDashboardManifest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("config", "grafana", f.Name+".yaml")
+	}
+
+	// Indent the dashboard to embed it as a literal block of the spec
+	lines := strings.Split(strings.TrimRight(f.Dashboard, "\n"), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "    " + line
+		}
+	}
+	f.Dashboard = strings.Join(lines, "\n")
+
+	f.TemplateBody = grafanaDashboardTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+const grafanaDashboardTemplate = `apiVersion: grafana.integreatly.org/v1beta1
+kind: GrafanaDashboard
+metadata:
+  name: {{ .Name }}
+  namespace: system
+spec:
+  # Select the Grafana instances managed by the Grafana Operator which should import the dashboard
+  instanceSelector:
+    matchLabels:
+      dashboards: grafana
+  # Map the data source input of the dashboard to the name of your Prometheus data source
+  datasources:
+    - inputName: DS_PROMETHEUS
+      datasourceName: Prometheus
+  json: |
+{{ .Dashboard }}
+`
+
+var _ machinery.Template = &GrafanaKustomization{}
+
+// GrafanaKustomization scaffolds the kustomization of the GrafanaDashboard custom resources
+type GrafanaKustomization struct {
+	machinery.TemplateMixin
+
+	// Dashboards are the names of the dashboards wrapped into custom resources
+	Dashboards []string
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *GrafanaKustomization) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("config", "grafana", "kustomization.yaml")
+	}
+
+	f.TemplateBody = grafanaKustomizationTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+const grafanaKustomizationTemplate = `# The dashboards of the project as custom resources of the Grafana Operator.
+# They are deployed with the project by adding this directory to the resources of config/default.
+resources:
+{{- range .Dashboards }}
+- {{ . }}.yaml
+{{- end }}
+`
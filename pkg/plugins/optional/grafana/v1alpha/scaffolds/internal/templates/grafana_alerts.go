@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &GrafanaAlertsManifest{}
+
+// GrafanaAlertsManifest scaffolds the Grafana-managed alert rules on the default controller-runtime metrics
+type GrafanaAlertsManifest struct {
+	machinery.TemplateMixin
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *GrafanaAlertsManifest) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("grafana", "alerts", "controller-runtime-alerts.json")
+	}
+
+	// Grafana syntax use {{ }} quite often, which is collided with default delimiter for go template parsing.
+	// Provide an alternative delimiter here to avoid overlaps.
+	f.SetDelim("[[", "]]")
+	f.TemplateBody = grafanaAlertsTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+//nolint:lll
+const grafanaAlertsTemplate = `{
+  "apiVersion": 1,
+  "groups": [
+    {
+      "orgId": 1,
+      "name": "controller-runtime",
+      "folder": "controller-runtime",
+      "interval": "1m",
+      "rules": [
+        {
+          "uid": "controller-reconcile-error-rate-high",
+          "title": "ControllerReconcileErrorRateHigh",
+          "condition": "A",
+          "data": [
+            {
+              "refId": "A",
+              "relativeTimeRange": {
+                "from": 600,
+                "to": 0
+              },
+              "datasourceUid": "${DS_PROMETHEUS}",
+              "model": {
+                "expr": "sum(rate(controller_runtime_reconcile_errors_total[5m])) by (namespace, controller) / sum(rate(controller_runtime_reconcile_total[5m])) by (namespace, controller) > 0.1",
+                "instant": true,
+                "refId": "A"
+              }
+            }
+          ],
+          "noDataState": "OK",
+          "execErrState": "Error",
+          "for": "10m",
+          "labels": {
+            "severity": "warning"
+          },
+          "annotations": {
+            "summary": "High reconcile error rate for the controller {{ $labels.controller }}",
+            "description": "More than 10% of the reconciliations of the controller {{ $labels.controller }} in the namespace {{ $labels.namespace }} failed during the last 10 minutes."
+          }
+        },
+        {
+          "uid": "controller-workqueue-depth-high",
+          "title": "ControllerWorkqueueDepthHigh",
+          "condition": "A",
+          "data": [
+            {
+              "refId": "A",
+              "relativeTimeRange": {
+                "from": 600,
+                "to": 0
+              },
+              "datasourceUid": "${DS_PROMETHEUS}",
+              "model": {
+                "expr": "sum(workqueue_depth) by (namespace, name) > 100",
+                "instant": true,
+                "refId": "A"
+              }
+            }
+          ],
+          "noDataState": "OK",
+          "execErrState": "Error",
+          "for": "15m",
+          "labels": {
+            "severity": "warning"
+          },
+          "annotations": {
+            "summary": "Work queue {{ $labels.name }} is not drained",
+            "description": "The work queue {{ $labels.name }} in the namespace {{ $labels.namespace }} has more than 100 items waiting for 15 minutes."
+          }
+        },
+        {
+          "uid": "controller-leader-election-lost",
+          "title": "ControllerLeaderElectionLost",
+          "condition": "A",
+          "data": [
+            {
+              "refId": "A",
+              "relativeTimeRange": {
+                "from": 600,
+                "to": 0
+              },
+              "datasourceUid": "${DS_PROMETHEUS}",
+              "model": {
+                "expr": "max(leader_election_master_status) by (namespace, name) < 1",
+                "instant": true,
+                "refId": "A"
+              }
+            }
+          ],
+          "noDataState": "OK",
+          "execErrState": "Error",
+          "for": "5m",
+          "labels": {
+            "severity": "critical"
+          },
+          "annotations": {
+            "summary": "No leader for the lease {{ $labels.name }}",
+            "description": "None of the replicas in the namespace {{ $labels.namespace }} holds the leader election lease {{ $labels.name }}, so the controllers are not reconciling."
+          }
+        }
+      ]
+    }
+  ]
+}
+`
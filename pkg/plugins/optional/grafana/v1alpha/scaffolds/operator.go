@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/grafana/v1alpha/scaffolds/internal/templates"
+)
+
+const (
+	// JSONFormat scaffolds the dashboards as JSON files to be imported in Grafana
+	JSONFormat = "json"
+	// GrafanaOperatorFormat also wraps the dashboards into GrafanaDashboard custom resources of the Grafana Operator
+	GrafanaOperatorFormat = "grafana-operator"
+)
+
+// OutputFormats are the supported output formats of the dashboards
+var OutputFormats = []string{JSONFormat, GrafanaOperatorFormat}
+
+// dashboardPaths are the JSON files of the dashboards which are wrapped into custom resources
+var dashboardPaths = []string{
+	filepath.Join("grafana", "controller-runtime-metrics.json"),
+	filepath.Join("grafana", "controller-resources-metrics.json"),
+	filepath.Join("grafana", "custom-metrics", "custom-metrics-dashboard.json"),
+}
+
+// scaffoldDashboardResources wraps the scaffolded dashboards into GrafanaDashboard custom resources
+// under config/grafana, so that they can be deployed with the project.
+func scaffoldDashboardResources(fs machinery.Filesystem, scaffold *machinery.Scaffold) error {
+	builders := make([]machinery.Builder, 0, len(dashboardPaths)+1)
+	kustomization := &templates.GrafanaKustomization{}
+	for _, path := range dashboardPaths {
+		content, err := afero.ReadFile(fs.FS, path)
+		if errors.Is(err, afero.ErrFileNotFound) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("unable to read the dashboard %s: %w", path, err)
+		}
+
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		builders = append(builders, &templates.GrafanaDashboardManifest{Name: name, Dashboard: string(content)})
+		kustomization.Dashboards = append(kustomization.Dashboards, name)
+	}
+
+	if err := scaffold.Execute(append(builders, kustomization)...); err != nil {
+		return fmt.Errorf("error scaffolding the GrafanaDashboard resources: %w", err)
+	}
+	return nil
+}
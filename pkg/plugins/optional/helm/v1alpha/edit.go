@@ -19,6 +19,7 @@ package v1alpha
 import (
 	"fmt"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
@@ -29,9 +30,23 @@ import (
 var _ plugin.EditSubcommand = &editSubcommand{}
 
 type editSubcommand struct {
-	config   config.Config
-	force    bool
-	chartDir string
+	config           config.Config
+	force            bool
+	chartDir         string
+	chartReleaser    bool
+	bumpChartVersion string
+	// extraConfigDirs are the directories whose manifests are copied to the chart
+	extraConfigDirs []string
+	// skipCRDs and crdsOnly distribute the CRDs apart from the manager
+	skipCRDs bool
+	crdsOnly bool
+	// diff prints the changes of the chart before writing them, and diffOnly without writing them
+	diff     bool
+	diffOnly bool
+
+	// flagSet is used to know if the chart-releaser workflow must be toggled, the extra config dirs replaced
+	// and the distribution of the CRDs changed
+	flagSet *pflag.FlagSet
 }
 
 //nolint:lll
@@ -47,8 +62,25 @@ the latest Helm chart with your most recent changes.`
 # Update the Helm chart under the dist/ directory and overwrite all files
   %[1]s edit --plugins=%[2]s --force
 
+# Scaffold the GitHub Action which publishes the chart to a Helm repository served from gh-pages
+  %[1]s edit --plugins=%[2]s --chart-releaser
+
+# Bump the version of the chart to release it, with major, minor, patch or a semantic version
+  %[1]s edit --plugins=%[2]s --bump-chart-version=patch
+
+# Copy the manifests of the directory config/extras to the chart, e.g. ConfigMaps or PriorityClasses
+  %[1]s edit --plugins=%[2]s --extra-config-dirs=config/extras
+
+# Update the chart without the CRDs under dist/, and the chart distributing only the CRDs under dist-crds/
+  %[1]s edit --plugins=%[2]s --chart-dir=dist --skip-crds
+  %[1]s edit --plugins=%[2]s --chart-dir=dist-crds --crds-only
+
+# Review the changes of the chart after 'make manifests', without writing them
+  %[1]s edit --plugins=%[2]s --diff-only
+
 **IMPORTANT**: If the "--force" flag is not used, the following files will not be updated to preserve your customizations:
 dist/chart/
+├── README.md (except for its table of values, regenerated from values.yaml)
 ├── values.yaml
 └── templates/
     └── manager/
@@ -62,6 +94,9 @@ The following files are never updated after their initial creation:
 All other files are updated without the usage of the '--force=true' flag
 when the edit option is used to ensure that the
 manifests in the chart align with the latest changes.
+
+The chart files generated from the project manifests (e.g. CRDs, RBAC and webhooks) are tracked
+in the PROJECT file, and removed from the chart when their source no longer exists in the project.
 `, cliMeta.CommandName, plugin.KeyFor(Plugin{}))
 }
 
@@ -69,17 +104,39 @@ manifests in the chart align with the latest changes.
 func (p *editSubcommand) BindFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&p.force, "force", false, "if true, regenerates all the files")
 	fs.StringVar(&p.chartDir, "chart-dir", "dist", "Directory where the Helm chart will be scaffolded")
+	fs.BoolVar(&p.chartReleaser, "chart-releaser", false,
+		"if true, scaffolds a GitHub Action which publishes the chart to a Helm repository served from gh-pages")
+	fs.StringVar(&p.bumpChartVersion, "bump-chart-version", "",
+		"bumps the version and the appVersion of the chart: major, minor, patch or a semantic version")
+	fs.StringSliceVar(&p.extraConfigDirs, "extra-config-dirs", nil,
+		"directories of the project whose manifests are copied to the templates/extras directory of the chart, "+
+			"an empty value removes them")
+	fs.BoolVar(&p.skipCRDs, "skip-crds", false,
+		"if true, scaffolds the chart without the CRDs, which are installed by other means")
+	fs.BoolVar(&p.crdsOnly, "crds-only", false,
+		"if true, scaffolds a chart which only distributes the CRDs")
+	fs.BoolVar(&p.diff, "diff", false,
+		"if true, prints the diff of the chart files which change before writing them")
+	fs.BoolVar(&p.diffOnly, "diff-only", false,
+		"if true, prints the diff of the chart files which would change without writing them or the PROJECT file")
+	p.flagSet = fs
+}
+
+func (p *editSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+	return nil
 }
 
 // Update the Scaffold method to retrieve the stored chart directory
 func (p *editSubcommand) Scaffold(fs machinery.Filesystem) error {
 	// Try to get chartDir from PROJECT file
-	cfg := pluginConfig{}
-	if err := p.config.DecodePluginConfig(pluginKey, &cfg); err == nil {
-		// If a directory was stored and none specified on command line, use the stored one
-		if cfg.ChartDir != "" && p.chartDir == "dist" {
-			p.chartDir = cfg.ChartDir
-		}
+	cfg, err := scaffolds.LoadPluginConfig(p.config)
+	if err != nil {
+		return err
+	}
+	// If a directory was stored and none specified on command line, use the stored one
+	if cfg.ChartDir != "" && p.chartDir == "dist" {
+		p.chartDir = cfg.ChartDir
 	}
 
 	// Use default if still not specified
@@ -87,13 +144,96 @@ func (p *editSubcommand) Scaffold(fs machinery.Filesystem) error {
 		p.chartDir = "dist"
 	}
 
-	scaffolder := scaffolds.NewInitHelmScaffolder(p.config, p.force, p.chartDir)
-	scaffolder.InjectFS(fs)
-	err := scaffolder.Scaffold()
+	// Keep the chart-releaser workflow unless it is toggled with the flag
+	if !p.flagSet.Changed("chart-releaser") {
+		p.chartReleaser = cfg.ChartReleaser
+	}
+
+	// Keep the extra config directories unless they are replaced with the flag
+	extraConfigDirs := cfg.ExtraConfigDirs
+	if p.flagSet.Changed("extra-config-dirs") {
+		if extraConfigDirs, err = cleanExtraConfigDirs(p.extraConfigDirs); err != nil {
+			return err
+		}
+	}
+
+	// Keep the distribution of the CRDs unless it is changed with the flags
+	if !p.flagSet.Changed("skip-crds") && !p.flagSet.Changed("crds-only") {
+		p.skipCRDs, p.crdsOnly = cfg.SkipCRDs, cfg.CRDsOnly
+	}
+	// The tracked chart-releaser workflow and extra config directories are kept for the chart of the manager
+	// when the chart of the CRDs is updated, only the flags are validated
+	var changedExtraConfigDirs []string
+	if p.flagSet.Changed("extra-config-dirs") {
+		changedExtraConfigDirs = extraConfigDirs
+	}
+	err = validateCRDsFlags(p.skipCRDs, p.crdsOnly, p.flagSet.Changed("chart-releaser") && p.chartReleaser,
+		changedExtraConfigDirs)
 	if err != nil {
 		return err
 	}
 
-	// Track or update the chart directory in the PROJECT file
-	return insertPluginMetaToConfig(p.config, pluginConfig{ChartDir: p.chartDir})
+	// The chart is scaffolded in memory to print its changes before writing them, and with a copy of the
+	// configuration when the PROJECT file must not be changed
+	scaffoldFS, scaffoldConfig := fs, p.config
+	var preview *scaffolds.Preview
+	if p.diff || p.diffOnly {
+		preview = scaffolds.NewPreview(fs.FS)
+		scaffoldFS = machinery.Filesystem{FS: preview}
+	}
+	if p.diffOnly {
+		if scaffoldConfig, err = copyConfig(p.config); err != nil {
+			return err
+		}
+	}
+
+	// The scaffolder tracks the chart directory and the generated chart files in the PROJECT file
+	scaffolder := scaffolds.NewInitHelmScaffolder(scaffoldConfig, p.force, p.chartDir, p.chartReleaser,
+		extraConfigDirs, p.skipCRDs, p.crdsOnly)
+	scaffolder.InjectFS(scaffoldFS)
+	if err := scaffolder.Scaffold(); err != nil {
+		return err
+	}
+
+	if p.bumpChartVersion != "" {
+		scaffolder = scaffolds.NewChartVersionScaffolder(scaffoldConfig, p.chartDir, p.bumpChartVersion)
+		scaffolder.InjectFS(scaffoldFS)
+		if err := scaffolder.Scaffold(); err != nil {
+			return fmt.Errorf("error bumping the chart version: %w", err)
+		}
+	}
+
+	if preview == nil {
+		return nil
+	}
+	changes, err := preview.Changes()
+	if err != nil {
+		return fmt.Errorf("error computing the changes of the chart: %w", err)
+	}
+	if len(changes) == 0 {
+		log.Infof("The chart under %s is up to date", p.chartDir)
+	}
+	for _, change := range changes {
+		fmt.Print(change.Diff())
+	}
+	if p.diffOnly {
+		return nil
+	}
+	return scaffolds.ApplyChanges(fs.FS, changes)
+}
+
+// copyConfig returns a copy of the configuration, which is changed without changing the PROJECT file
+func copyConfig(cfg config.Config) (config.Config, error) {
+	content, err := cfg.MarshalYAML()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the PROJECT file: %w", err)
+	}
+	copied, err := config.New(cfg.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	if err := copied.UnmarshalYAML(content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the PROJECT file: %w", err)
+	}
+	return copied, nil
 }
@@ -17,7 +17,10 @@ limitations under the License.
 package v1alpha
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/pflag"
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
@@ -29,8 +32,14 @@ import (
 var _ plugin.InitSubcommand = &initSubcommand{}
 
 type initSubcommand struct {
-	config   config.Config
-	chartDir string
+	config        config.Config
+	chartDir      string
+	chartReleaser bool
+	// extraConfigDirs are the directories whose manifests are copied to the chart
+	extraConfigDirs []string
+	// skipCRDs and crdsOnly distribute the CRDs apart from the manager
+	skipCRDs bool
+	crdsOnly bool
 }
 
 func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
@@ -42,6 +51,16 @@ func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *
 # Initialize a helm chart in a custom location
   %[1]s init --plugins=%[2]s --chart-dir=charts
 
+# Initialize a helm chart and the GitHub Action which publishes it to a Helm repository on gh-pages
+  %[1]s init --plugins=%[2]s --chart-releaser
+
+# Initialize a helm chart with the manifests of the directory config/extras, e.g. ConfigMaps or PriorityClasses
+  %[1]s init --plugins=%[2]s --extra-config-dirs=config/extras
+
+# Initialize a helm chart without the CRDs, and a chart distributing only the CRDs in the directory dist-crds/
+  %[1]s init --plugins=%[2]s --skip-crds
+  %[1]s edit --plugins=%[2]s --chart-dir=dist-crds --crds-only
+
 **IMPORTANT** You must use %[1]s edit --plugins=%[2]s to update the chart when changes are made.
 `, cliMeta.CommandName, plugin.KeyFor(Plugin{}))
 }
@@ -54,6 +73,14 @@ func (p *initSubcommand) InjectConfig(c config.Config) error {
 // Add the BindFlags method to accept the chart-dir flag
 func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&p.chartDir, "chart-dir", "dist", "Directory where the Helm chart will be scaffolded")
+	fs.BoolVar(&p.chartReleaser, "chart-releaser", false,
+		"if true, scaffolds a GitHub Action which publishes the chart to a Helm repository served from gh-pages")
+	fs.StringSliceVar(&p.extraConfigDirs, "extra-config-dirs", nil,
+		"directories of the project whose manifests are copied to the templates/extras directory of the chart")
+	fs.BoolVar(&p.skipCRDs, "skip-crds", false,
+		"if true, scaffolds the chart without the CRDs, which are installed by other means")
+	fs.BoolVar(&p.crdsOnly, "crds-only", false,
+		"if true, scaffolds a chart which only distributes the CRDs")
 }
 
 // Update the Scaffold method to use the chart directory
@@ -63,13 +90,62 @@ func (p *initSubcommand) Scaffold(fs machinery.Filesystem) error {
 		p.chartDir = "dist"
 	}
 
-	scaffolder := scaffolds.NewInitHelmScaffolder(p.config, false, p.chartDir)
-	scaffolder.InjectFS(fs)
-	err := scaffolder.Scaffold()
+	extraConfigDirs, err := cleanExtraConfigDirs(p.extraConfigDirs)
 	if err != nil {
 		return err
 	}
 
-	// Track the chart directory in the PROJECT file
-	return insertPluginMetaToConfig(p.config, pluginConfig{ChartDir: p.chartDir})
+	if err := validateCRDsFlags(p.skipCRDs, p.crdsOnly, p.chartReleaser, extraConfigDirs); err != nil {
+		return err
+	}
+
+	// The scaffolder tracks the chart directory and the generated chart files in the PROJECT file
+	scaffolder := scaffolds.NewInitHelmScaffolder(p.config, false, p.chartDir, p.chartReleaser, extraConfigDirs,
+		p.skipCRDs, p.crdsOnly)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
+
+// cleanExtraConfigDirs validates that the extra config directories are relative to the project and
+// returns them cleaned and without duplicates.
+func cleanExtraConfigDirs(dirs []string) ([]string, error) {
+	cleaned := make([]string, 0, len(dirs))
+	seen := make(map[string]struct{}, len(dirs))
+	for _, dir := range dirs {
+		dir = strings.TrimSpace(dir)
+		if dir == "" {
+			continue
+		}
+		if filepath.IsAbs(dir) || strings.HasPrefix(filepath.Clean(dir), "..") {
+			return nil, fmt.Errorf("extra config directory (%s) must be a path relative to the project", dir)
+		}
+		dir = filepath.ToSlash(filepath.Clean(dir))
+		if _, found := seen[dir]; found {
+			continue
+		}
+		seen[dir] = struct{}{}
+		cleaned = append(cleaned, dir)
+	}
+	return cleaned, nil
+}
+
+// validateCRDsFlags returns an error when the CRDs are both skipped and distributed alone, or when the chart
+// of the CRDs would distribute the chart-releaser workflow or the manifests of the extra config directories,
+// which belong to the chart of the manager.
+func validateCRDsFlags(skipCRDs, crdsOnly, chartReleaser bool, extraConfigDirs []string) error {
+	if !crdsOnly {
+		return nil
+	}
+	if skipCRDs {
+		return errors.New("--skip-crds and --crds-only can not be used together")
+	}
+	if chartReleaser {
+		return errors.New("--chart-releaser can not be used with --crds-only, " +
+			"the workflow publishes the chart of the manager")
+	}
+	if len(extraConfigDirs) > 0 {
+		return errors.New("--extra-config-dirs can not be used with --crds-only, " +
+			"the manifests are distributed with the manager")
+	}
+	return nil
 }
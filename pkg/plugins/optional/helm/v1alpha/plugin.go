@@ -29,7 +29,6 @@ const pluginName = "helm." + plugins.DefaultNameQualifier
 var (
 	pluginVersion            = plugin.Version{Number: 1, Stage: stage.Alpha}
 	supportedProjectVersions = []config.Version{cfgv3.Version}
-	pluginKey                = plugin.KeyFor(Plugin{})
 )
 
 // Plugin implements the plugin.Full interface
@@ -39,14 +38,12 @@ type Plugin struct {
 }
 
 var (
-	_ plugin.Init = Plugin{}
-	_ plugin.Edit = Plugin{}
+	_ plugin.Init           = Plugin{}
+	_ plugin.Edit           = Plugin{}
+	_ plugin.Deprecated     = Plugin{}
+	_ plugin.HasDeprecation = Plugin{}
 )
 
-type pluginConfig struct{
-	ChartDir string `json:"chartDir,omitempty"`
-}
-
 // Name returns the name of the plugin
 func (Plugin) Name() string { return pluginName }
 
@@ -62,7 +59,13 @@ func (p Plugin) GetInitSubcommand() plugin.InitSubcommand { return &p.initSubcom
 // GetEditSubcommand will return the subcommand which is responsible for adding and/or edit a helm chart
 func (p Plugin) GetEditSubcommand() plugin.EditSubcommand { return &p.editSubcommand }
 
+// Deprecated define whether the plugin is deprecated and its deprecation message
+func (p Plugin) Deprecated() (bool, string) {
+	return false, ""
+}
+
 // DeprecationWarning define the deprecation message or return empty when plugin is not deprecated
 func (p Plugin) DeprecationWarning() string {
-	return ""
+	_, warning := p.Deprecated()
+	return warning
 }
@@ -0,0 +1,237 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render renders the Helm chart scaffolded by the helm/v1-alpha plugin as 'helm template' does, so that
+// the tests of Kubebuilder and the plugins which contribute to the chart can verify the objects installed
+// with a given set of values instead of matching the templates of the chart as text.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"sigs.k8s.io/yaml"
+
+	kustomizerender "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/render"
+)
+
+// DefaultChartDir is the directory where the chart is scaffolded by default, see the --chart-dir flag
+const DefaultChartDir = "dist"
+
+// DefaultReleaseName is the name of the release used to render the chart if none is set
+const DefaultReleaseName = "release"
+
+// DefaultNamespace is the namespace of the release used to render the chart if none is set, as with helm
+const DefaultNamespace = "default"
+
+// OptionalAPIVersions are the APIs of the optional dependencies of the chart, cert-manager and the Prometheus
+// operator. The chart only renders their objects when the cluster serves them, or when they are given to
+// the renderer with APIVersions.
+var OptionalAPIVersions = []string{"cert-manager.io/v1/Certificate", "monitoring.coreos.com/v1/ServiceMonitor"}
+
+// Renderer renders the chart of a project in-process with the Helm SDK, so that no helm binary is required
+type Renderer struct {
+	// Fs is the filesystem of the project, e.g. the one scaffolded by the plugins in memory. If it is set,
+	// the chart is read from it, otherwise it is read from Dir
+	Fs afero.Fs
+	// Dir is the root directory of the project, the current directory is used if it is empty
+	Dir string
+	// ChartDir is the directory of the project where the chart is scaffolded, it defaults to DefaultChartDir
+	ChartDir string
+	// ReleaseName is the name of the release, it defaults to DefaultReleaseName
+	ReleaseName string
+	// Namespace is the namespace of the release, it defaults to DefaultNamespace
+	Namespace string
+	// APIVersions are the APIs, formatted as <group>/<version>[/<kind>], considered as served by the cluster
+	// when rendering the chart, e.g. OptionalAPIVersions
+	APIVersions []string
+}
+
+// Object is a Kubernetes object rendered from the chart
+type Object struct {
+	kustomizerender.Object
+
+	// Content is the decoded manifest of the object
+	Content map[string]interface{}
+}
+
+// Field returns the value of the field of the object found at the given path, e.g.
+// Field("spec", "replicas"), and whether it is set
+func (o Object) Field(path ...string) (interface{}, bool) {
+	var value interface{} = o.Content
+	for _, key := range path {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = fields[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// Template returns the manifests rendered from the chart with the given values, which override the
+// values.yaml file of the chart. As with 'helm template', the manifests are sorted in the install order of
+// helm and followed by the hooks of the chart.
+func (r Renderer) Template(values map[string]interface{}) ([]byte, error) {
+	chartPath := filepath.Join(r.chartDir(), "chart")
+	chrt, err := r.load(chartPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	if err := chartutil.ProcessDependenciesWithMerge(chrt, values); err != nil {
+		return nil, fmt.Errorf("unable to process the dependencies of the chart %s: %w", chartPath, err)
+	}
+
+	caps := chartutil.DefaultCapabilities.Copy()
+	caps.APIVersions = append(caps.APIVersions, r.APIVersions...)
+	options := chartutil.ReleaseOptions{
+		Name:      r.releaseName(),
+		Namespace: r.namespace(),
+		Revision:  1,
+		IsInstall: true,
+	}
+	renderValues, err := chartutil.ToRenderValues(chrt, values, options, caps)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute the values of the chart %s: %w", chartPath, err)
+	}
+
+	files, err := engine.Render(chrt, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("unable to render the chart %s: %w", chartPath, err)
+	}
+	for name := range files {
+		if strings.HasSuffix(name, "NOTES.txt") {
+			delete(files, name)
+		}
+	}
+	hooks, manifests, err := releaseutil.SortManifests(files, caps.APIVersions, releaseutil.InstallOrder)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the manifests rendered from the chart %s: %w", chartPath, err)
+	}
+
+	var out bytes.Buffer
+	for _, manifest := range manifests {
+		_, _ = fmt.Fprintf(&out, "---\n# Source: %s\n%s\n", manifest.Name, manifest.Content)
+	}
+	for _, hook := range hooks {
+		_, _ = fmt.Fprintf(&out, "---\n# Source: %s\n%s\n", hook.Path, hook.Manifest)
+	}
+	return out.Bytes(), nil
+}
+
+// TemplateObjects returns the objects rendered from the chart with the given values
+func (r Renderer) TemplateObjects(values map[string]interface{}) ([]Object, error) {
+	manifests, err := r.Template(values)
+	if err != nil {
+		return nil, err
+	}
+	return Objects(manifests)
+}
+
+// Objects splits the manifests rendered by helm into their objects
+func Objects(manifests []byte) ([]Object, error) {
+	rendered, err := kustomizerender.Objects(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]Object, 0, len(rendered))
+	for _, object := range rendered {
+		var content map[string]interface{}
+		if err := yaml.Unmarshal([]byte(object.Manifest), &content); err != nil {
+			return nil, fmt.Errorf("unable to decode the rendered object: %w", err)
+		}
+		objects = append(objects, Object{Object: object, Content: content})
+	}
+	return objects, nil
+}
+
+func (r Renderer) chartDir() string {
+	if r.ChartDir != "" {
+		return r.ChartDir
+	}
+	return DefaultChartDir
+}
+
+func (r Renderer) releaseName() string {
+	if r.ReleaseName != "" {
+		return r.ReleaseName
+	}
+	return DefaultReleaseName
+}
+
+func (r Renderer) namespace() string {
+	if r.Namespace != "" {
+		return r.Namespace
+	}
+	return DefaultNamespace
+}
+
+// load loads the chart from the filesystem of the project if it is set, or from the project directory
+func (r Renderer) load(chartPath string) (*chart.Chart, error) {
+	if r.Fs == nil {
+		chrt, err := loader.Load(filepath.Join(r.Dir, chartPath))
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the chart %s: %w", chartPath, err)
+		}
+		return chrt, nil
+	}
+
+	if _, err := r.Fs.Stat(chartPath); err != nil {
+		return nil, fmt.Errorf("unable to find the chart %s: %w", chartPath, err)
+	}
+	var files []*loader.BufferedFile
+	err := afero.Walk(r.Fs, chartPath, func(file string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(chartPath, file)
+		if err != nil {
+			return err
+		}
+		content, err := afero.ReadFile(r.Fs, file)
+		if err != nil {
+			return err
+		}
+		files = append(files, &loader.BufferedFile{Name: path.Clean(filepath.ToSlash(rel)), Data: content})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the chart %s: %w", chartPath, err)
+	}
+
+	chrt, err := loader.LoadFiles(files)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the chart %s: %w", chartPath, err)
+	}
+	return chrt, nil
+}
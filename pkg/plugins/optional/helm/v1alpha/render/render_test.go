@@ -0,0 +1,330 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	kustomizev2scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
+	helmscaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds"
+)
+
+func TestRender(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Helm Render Suite")
+}
+
+const manifests = `---
+# Source: project/templates/manager/manager.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: project-controller-manager
+  namespace: project-system
+spec:
+  replicas: 2
+---
+# Source: project/templates/metrics/metrics-service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: project-controller-manager-metrics-service
+`
+
+// configMapTemplate renders a ConfigMap named after the release, whose data are the values of the chart and
+// whether cert-manager is served by the cluster
+const configMapTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+  namespace: {{ .Release.Namespace }}
+data:
+  replicas: {{ .Values.replicas | quote }}
+  {{- if .Capabilities.APIVersions.Has "cert-manager.io/v1/Certificate" }}
+  certManager: "true"
+  {{- end }}
+`
+
+// hookTemplate renders a Job run by helm before upgrading the release
+const hookTemplate = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: {{ .Release.Name }}-upgrade
+  annotations:
+    "helm.sh/hook": pre-upgrade
+`
+
+// chartFiles are the files of a minimal chart
+var chartFiles = map[string]string{
+	"Chart.yaml":                "apiVersion: v2\nname: project\nversion: 0.1.0\n",
+	"values.yaml":               "replicas: 1\n",
+	"templates/configmap.yaml":  configMapTemplate,
+	"templates/upgrade.yaml":    hookTemplate,
+	"templates/_helpers.tpl":    "{{- define \"project.name\" -}}project{{- end }}\n",
+	"templates/NOTES.txt":       "The {{ include \"project.name\" . }} chart is installed.\n",
+	"templates/empty.yaml":      "{{- if .Values.missing }}\nkind: Secret\n{{- end }}\n",
+	"templates/service.yaml":    "apiVersion: v1\nkind: Service\nmetadata:\n  name: {{ .Release.Name }}\n",
+	"templates/namespace.yaml":  "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: {{ .Release.Namespace }}\n",
+	"templates/deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: {{ .Release.Name }}\n",
+}
+
+// writeChart writes the files of the minimal chart to the chart dir of the filesystem
+func writeChart(fs afero.Fs, chartDir string) {
+	for name, content := range chartFiles {
+		file := filepath.Join(chartDir, "chart", filepath.FromSlash(name))
+		Expect(fs.MkdirAll(filepath.Dir(file), 0o755)).To(Succeed())
+		Expect(afero.WriteFile(fs, file, []byte(content), 0o644)).To(Succeed())
+	}
+}
+
+var _ = Describe("Objects", func() {
+	It("should split and decode the manifests into their objects", func() {
+		objects, err := Objects([]byte(manifests))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(2))
+
+		Expect(objects[0].Kind).To(Equal("Deployment"))
+		Expect(objects[0].Metadata.Namespace).To(Equal("project-system"))
+		Expect(objects[0].Manifest).To(HavePrefix("# Source: project/templates/manager/manager.yaml\n"))
+		replicas, ok := objects[0].Field("spec", "replicas")
+		Expect(ok).To(BeTrue())
+		Expect(replicas).To(BeEquivalentTo(2))
+
+		Expect(objects[1].Kind).To(Equal("Service"))
+		_, ok = objects[1].Field("spec", "ports")
+		Expect(ok).To(BeFalse())
+		_, ok = objects[1].Field("metadata", "name", "value")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should fail for invalid manifests", func() {
+		_, err := Objects([]byte("kind: [Deployment"))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Renderer", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	kinds := func(objects []Object) []string {
+		kinds := make([]string, 0, len(objects))
+		for _, object := range objects {
+			kinds = append(kinds, object.Kind)
+		}
+		return kinds
+	}
+
+	It("should render the chart of the project directory with its default values", func() {
+		writeChart(afero.NewBasePathFs(afero.NewOsFs(), dir), DefaultChartDir)
+
+		objects, err := Renderer{Dir: dir}.TemplateObjects(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(5))
+
+		configMap := objects[1]
+		Expect(configMap.Kind).To(Equal("ConfigMap"))
+		Expect(configMap.Metadata.Name).To(Equal(DefaultReleaseName))
+		Expect(configMap.Metadata.Namespace).To(Equal(DefaultNamespace))
+		Expect(configMap.Manifest).To(HavePrefix("# Source: project/templates/configmap.yaml\n"))
+		replicas, ok := configMap.Field("data", "replicas")
+		Expect(ok).To(BeTrue())
+		Expect(replicas).To(Equal("1"))
+		_, ok = configMap.Field("data", "certManager")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should sort the objects in the install order of helm and render the hooks last", func() {
+		writeChart(afero.NewBasePathFs(afero.NewOsFs(), dir), DefaultChartDir)
+
+		objects, err := Renderer{Dir: dir}.TemplateObjects(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kinds(objects)).To(Equal([]string{"Namespace", "ConfigMap", "Service", "Deployment", "Job"}))
+	})
+
+	It("should render the chart of the scaffolded filesystem with the given values", func() {
+		fs := afero.NewMemMapFs()
+		writeChart(fs, "charts")
+
+		objects, err := Renderer{
+			Fs:          fs,
+			Dir:         dir,
+			ChartDir:    "charts",
+			ReleaseName: "example",
+			Namespace:   "example-system",
+		}.TemplateObjects(map[string]interface{}{"replicas": 3})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(5))
+
+		configMap := objects[1]
+		Expect(configMap.Metadata.Name).To(Equal("example"))
+		Expect(configMap.Metadata.Namespace).To(Equal("example-system"))
+		replicas, ok := configMap.Field("data", "replicas")
+		Expect(ok).To(BeTrue())
+		Expect(replicas).To(Equal("3"))
+	})
+
+	It("should consider the given APIs as served by the cluster", func() {
+		writeChart(afero.NewBasePathFs(afero.NewOsFs(), dir), DefaultChartDir)
+
+		objects, err := Renderer{Dir: dir, APIVersions: OptionalAPIVersions}.TemplateObjects(nil)
+		Expect(err).NotTo(HaveOccurred())
+		certManager, ok := objects[1].Field("data", "certManager")
+		Expect(ok).To(BeTrue())
+		Expect(certManager).To(Equal("true"))
+	})
+
+	It("should fail when the scaffolded filesystem has no chart", func() {
+		_, err := Renderer{Fs: afero.NewMemMapFs(), Dir: dir}.Template(nil)
+		Expect(err).To(MatchError(ContainSubstring("unable to find the chart")))
+	})
+
+	It("should fail when the project directory has no chart", func() {
+		_, err := Renderer{Dir: dir}.Template(nil)
+		Expect(err).To(MatchError(ContainSubstring("unable to load the chart")))
+	})
+
+	It("should report the errors of the templates", func() {
+		fs := afero.NewMemMapFs()
+		writeChart(fs, DefaultChartDir)
+		Expect(afero.WriteFile(fs, filepath.Join(DefaultChartDir, "chart", "templates", "invalid.yaml"),
+			[]byte("{{ required \"image is required\" .Values.image }}\n"), 0o644)).To(Succeed())
+
+		_, err := Renderer{Fs: fs}.Template(nil)
+		Expect(err).To(MatchError(ContainSubstring("image is required")))
+	})
+})
+
+// crd is the CRD generated by controller-gen for the Captain API
+const crd = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: captains.crew.example.com
+spec:
+  group: crew.example.com
+  names:
+    kind: Captain
+    listKind: CaptainList
+    plural: captains
+    singular: captain
+  scope: Namespaced
+  versions:
+  - name: v1
+    schema:
+      openAPIV3Schema:
+        type: object
+    served: true
+    storage: true
+`
+
+var _ = Describe("Scaffolded chart", func() {
+	var dir string
+
+	BeforeEach(func() {
+		wd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.Chdir, wd)
+
+		// The manifests copied to the chart are read from the working directory
+		dir = GinkgoT().TempDir()
+		Expect(os.Chdir(dir)).To(Succeed())
+		fs := machinery.Filesystem{FS: afero.NewOsFs()}
+
+		cfg := cfgv3.New()
+		Expect(cfg.SetProjectName("project")).To(Succeed())
+		Expect(cfg.SetDomain("example.com")).To(Succeed())
+		Expect(cfg.SetRepository("example.com/project")).To(Succeed())
+		res := resource.Resource{
+			GVK:      resource.GVK{Group: "crew", Domain: "example.com", Version: "v1", Kind: "Captain"},
+			Plural:   "captains",
+			API:      &resource.API{CRDVersion: "v1", Namespaced: true},
+			Webhooks: &resource.Webhooks{},
+		}
+		Expect(cfg.AddResource(res)).To(Succeed())
+
+		for _, scaffolder := range []interface {
+			InjectFS(machinery.Filesystem)
+			Scaffold() error
+		}{
+			kustomizev2scaffolds.NewInitScaffolder(cfg),
+			kustomizev2scaffolds.NewAPIScaffolder(cfg, res, false),
+		} {
+			scaffolder.InjectFS(fs)
+			Expect(scaffolder.Scaffold()).To(Succeed())
+		}
+		Expect(os.MkdirAll(filepath.Join("config", "crd", "bases"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join("config", "crd", "bases", "crew.example.com_captains.yaml"),
+			[]byte(crd), 0o644)).To(Succeed())
+
+		scaffolder := helmscaffolds.NewInitHelmScaffolder(cfg, false, DefaultChartDir, false, nil, false, false)
+		scaffolder.InjectFS(fs)
+		Expect(scaffolder.Scaffold()).To(Succeed())
+	})
+
+	kinds := func(objects []Object) map[string]int {
+		kinds := make(map[string]int)
+		for _, object := range objects {
+			kinds[object.Kind]++
+		}
+		return kinds
+	}
+
+	It("should render the objects of the project with the default values", func() {
+		objects, err := Renderer{Dir: dir}.TemplateObjects(nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(kinds(objects)).To(And(
+			HaveKeyWithValue("Deployment", 1),
+			HaveKeyWithValue("CustomResourceDefinition", 1),
+			HaveKey("ServiceAccount"),
+			HaveKey("ClusterRole"),
+			HaveKey("ClusterRoleBinding"),
+			HaveKey("Role"),
+			HaveKey("RoleBinding"),
+			HaveKey("Service"),
+		))
+		for _, object := range objects {
+			Expect(object.Metadata.Name).NotTo(BeEmpty(), object.Manifest)
+		}
+	})
+
+	It("should render the objects of the optional dependencies served by the cluster", func() {
+		objects, err := Renderer{Dir: dir, APIVersions: OptionalAPIVersions}.TemplateObjects(map[string]interface{}{
+			"prometheus":  map[string]interface{}{"enable": true},
+			"certmanager": map[string]interface{}{"enable": true},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(kinds(objects)).To(And(
+			HaveKey("Deployment"),
+			HaveKey("Certificate"),
+			HaveKey("ServiceMonitor"),
+		))
+	})
+})
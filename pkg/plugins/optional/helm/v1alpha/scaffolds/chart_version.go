@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+)
+
+const (
+	// majorBump bumps the major version of the chart, e.g. 1.2.3 to 2.0.0
+	majorBump = "major"
+	// minorBump bumps the minor version of the chart, e.g. 1.2.3 to 1.3.0
+	minorBump = "minor"
+	// patchBump bumps the patch version of the chart, e.g. 1.2.3 to 1.2.4
+	patchBump = "patch"
+)
+
+var (
+	// semverRegex matches a semantic version, with an optional leading v
+	semverRegex = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?$`)
+	// chartVersionRegex matches the version and the appVersion fields of the Chart.yaml
+	chartVersionRegex = regexp.MustCompile(`(?m)^(version|appVersion):\s*"?([^"\s]*)"?\s*$`)
+)
+
+var _ plugins.Scaffolder = &chartVersionScaffolder{}
+
+type chartVersionScaffolder struct {
+	config   config.Config
+	chartDir string
+	bump     string
+
+	fs machinery.Filesystem
+}
+
+// NewChartVersionScaffolder returns a new Scaffolder which bumps the version and the appVersion of the
+// Chart.yaml. The bump is either major, minor, patch or the new semantic version of the chart.
+func NewChartVersionScaffolder(config config.Config, chartDir, bump string) plugins.Scaffolder {
+	return &chartVersionScaffolder{
+		config:   config,
+		chartDir: chartDir,
+		bump:     bump,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *chartVersionScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *chartVersionScaffolder) Scaffold() error {
+	chartFile := filepath.Join(s.chartDir, "chart", "Chart.yaml")
+	content, err := afero.ReadFile(s.fs.FS, chartFile)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", chartFile, err)
+	}
+
+	matches := chartVersionRegex.FindAllStringSubmatch(string(content), -1)
+	current := ""
+	for _, match := range matches {
+		if match[1] == "version" {
+			current = match[2]
+		}
+	}
+	if current == "" {
+		return fmt.Errorf("unable to find the version of the chart in %s", chartFile)
+	}
+
+	version, err := bumpVersion(current, s.bump)
+	if err != nil {
+		return err
+	}
+
+	updated := chartVersionRegex.ReplaceAllStringFunc(string(content), func(line string) string {
+		if strings.HasPrefix(line, "appVersion") {
+			return fmt.Sprintf("appVersion: %q", version)
+		}
+		return "version: " + version
+	})
+	if err := afero.WriteFile(s.fs.FS, chartFile, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("unable to write %s: %w", chartFile, err)
+	}
+
+	log.Printf("Bumped the version of the chart in %s from %s to %s", chartFile, current, version)
+	return nil
+}
+
+// bumpVersion returns the version following the current semantic version. The bump is either major, minor,
+// patch or the next semantic version itself. The pre-release and build metadata are dropped when bumping.
+func bumpVersion(current, bump string) (string, error) {
+	switch bump {
+	case majorBump, minorBump, patchBump:
+	default:
+		if !semverRegex.MatchString(bump) {
+			return "", fmt.Errorf("invalid chart version %q, it must be %s, %s, %s or a semantic version",
+				bump, majorBump, minorBump, patchBump)
+		}
+		return strings.TrimPrefix(bump, "v"), nil
+	}
+
+	matches := semverRegex.FindStringSubmatch(current)
+	if matches == nil {
+		return "", fmt.Errorf("unable to bump the chart version %q, which is not a semantic version", current)
+	}
+	// The regex ensures the numbers can be parsed
+	major, _ := strconv.Atoi(matches[1])
+	minor, _ := strconv.Atoi(matches[2])
+	patch, _ := strconv.Atoi(matches[3])
+
+	switch bump {
+	case majorBump:
+		major, minor, patch = major+1, 0, 0
+	case minorBump:
+		minor, patch = minor+1, 0
+	case patchBump:
+		patch++
+	}
+	return fmt.Sprintf("%d.%d.%d", major, minor, patch), nil
+}
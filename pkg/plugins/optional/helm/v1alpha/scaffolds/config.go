@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+)
+
+// PluginKey is the key used to track the helm/v1-alpha options in the PROJECT file.
+// It must match plugin.KeyFor(v1alpha.Plugin{}), which cannot be used here to avoid an import cycle.
+const PluginKey = "helm.kubebuilder.io/v1-alpha"
+
+// PluginConfig defines the helm/v1-alpha options which are tracked in the PROJECT file
+type PluginConfig struct {
+	// ChartDir is the directory where the Helm chart is scaffolded
+	ChartDir string `json:"chartDir,omitempty"`
+	// ChartFiles are the chart files generated from the project manifests, such as the CRDs,
+	// the RBAC and the webhooks. They are pruned on edit when their source no longer exists.
+	ChartFiles []string `json:"chartFiles,omitempty"`
+	// ChartReleaser scaffolds the GitHub Action which publishes the chart to a Helm repository
+	// served from the gh-pages branch
+	ChartReleaser bool `json:"chartReleaser,omitempty"`
+	// ExtraConfigDirs are the directories of the project whose manifests are copied to the
+	// templates/extras directory of the chart
+	ExtraConfigDirs []string `json:"extraConfigDirs,omitempty"`
+	// SkipCRDs scaffolds the chart without the CRDs, which are then installed by other means,
+	// e.g. a chart scaffolded with CRDsOnly
+	SkipCRDs bool `json:"skipCRDs,omitempty"`
+	// CRDsOnly scaffolds a chart which only distributes the CRDs of the project
+	CRDsOnly bool `json:"crdsOnly,omitempty"`
+}
+
+// LoadPluginConfig returns the helm/v1-alpha options tracked in the PROJECT file.
+// An empty PluginConfig is returned when nothing was tracked.
+func LoadPluginConfig(cfg config.Config) (PluginConfig, error) {
+	pluginCfg := PluginConfig{}
+	err := cfg.DecodePluginConfig(PluginKey, &pluginCfg)
+	if err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) &&
+		!errors.As(err, &config.UnsupportedFieldError{}) {
+		return pluginCfg, err
+	}
+	return pluginCfg, nil
+}
+
+// SavePluginConfig tracks the helm/v1-alpha options in the PROJECT file
+func SavePluginConfig(cfg config.Config, pluginCfg PluginConfig) error {
+	if err := cfg.EncodePluginConfig(PluginKey, pluginCfg); err != nil &&
+		!errors.As(err, &config.UnsupportedFieldError{}) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	charttemplates "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds/internal/templates/chart-templates"
+)
+
+// helperDefineRegex matches the definition of a named template of the _helpers.tpl file, capturing its name
+var helperDefineRegex = regexp.MustCompile(`{{-?\s*define\s+"([^"]+)"`)
+
+// addMissingHelpers appends to the _helpers.tpl file of the chart the named templates it does not define,
+// e.g. "chart.fullname" for the charts generated by previous versions of the plugin. The file is never
+// overwritten, so that its customizations are kept, but the generated templates rely on its helpers.
+func (s *initScaffolder) addMissingHelpers() error {
+	helpers := &charttemplates.HelmHelpers{ChartDir: s.chartDir}
+	memFS := machinery.Filesystem{FS: afero.NewMemMapFs()}
+	if err := machinery.NewScaffold(memFS, machinery.WithConfig(s.config)).Execute(helpers); err != nil {
+		return fmt.Errorf("error rendering the chart helpers: %w", err)
+	}
+	rendered, err := afero.ReadFile(memFS.FS, helpers.Path)
+	if err != nil {
+		return fmt.Errorf("unable to read the rendered chart helpers: %w", err)
+	}
+
+	content, err := afero.ReadFile(s.fs.FS, helpers.Path)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", helpers.Path, err)
+	}
+	defined := make(map[string]struct{})
+	for _, match := range helperDefineRegex.FindAllStringSubmatch(string(content), -1) {
+		defined[match[1]] = struct{}{}
+	}
+
+	// The named templates are separated by blank lines
+	var missing, names []string
+	for _, block := range strings.Split(string(rendered), "\n\n") {
+		block = strings.TrimSpace(block)
+		match := helperDefineRegex.FindStringSubmatch(block)
+		if match == nil {
+			continue
+		}
+		if _, found := defined[match[1]]; !found {
+			missing = append(missing, block)
+			names = append(names, match[1])
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	log.Infof("Adding the helpers %s to %s", strings.Join(names, ", "), filepath.ToSlash(helpers.Path))
+	updated := strings.TrimRight(string(content), "\n") + "\n\n" + strings.Join(missing, "\n\n") + "\n"
+	if err := afero.WriteFile(s.fs.FS, helpers.Path, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("unable to write %s: %w", helpers.Path, err)
+	}
+	return nil
+}
@@ -17,24 +17,27 @@ limitations under the License.
 package scaffolds
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
-	"sigs.k8s.io/yaml"
-
 	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	kustomizev2scaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2/scaffolds"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/golang/deploy-image/v1alpha1"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds/internal/templates"
 	charttemplates "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds/internal/templates/chart-templates"
 	templatescertmanager "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds/internal/templates/chart-templates/cert-manager"
+	templatescrd "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds/internal/templates/chart-templates/crd"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds/internal/templates/chart-templates/manager"
 	templatesmetrics "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds/internal/templates/chart-templates/metrics"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds/internal/templates/chart-templates/prometheus"
@@ -52,14 +55,32 @@ type initScaffolder struct {
 	force bool
 
 	chartDir string
+
+	// chartReleaser scaffolds the GitHub Action which publishes the chart with chart-releaser
+	chartReleaser bool
+
+	// extraConfigDirs are the directories whose manifests are copied to the templates/extras directory
+	extraConfigDirs []string
+
+	// skipCRDs scaffolds the chart without the CRDs
+	skipCRDs bool
+
+	// crdsOnly scaffolds a chart which only distributes the CRDs
+	crdsOnly bool
 }
 
 // NewInitHelmScaffolder returns a new Scaffolder for HelmPlugin
-func NewInitHelmScaffolder(config config.Config, force bool, chartDir string) plugins.Scaffolder {
+func NewInitHelmScaffolder(config config.Config, force bool, chartDir string, chartReleaser bool,
+	extraConfigDirs []string, skipCRDs, crdsOnly bool,
+) plugins.Scaffolder {
 	return &initScaffolder{
-		config:   config,
-		force:    force,
-		chartDir: chartDir,
+		config:          config,
+		force:           force,
+		chartDir:        chartDir,
+		chartReleaser:   chartReleaser,
+		extraConfigDirs: extraConfigDirs,
+		skipCRDs:        skipCRDs,
+		crdsOnly:        crdsOnly,
 	}
 }
 
@@ -72,68 +93,203 @@ func (s *initScaffolder) InjectFS(fs machinery.Filesystem) {
 func (s *initScaffolder) Scaffold() error {
 	log.Println("Generating Helm Chart to distribute project")
 
-	imagesEnvVars := s.getDeployImagesEnvVars()
+	deployImages := s.getDeployImages()
 
-	mutatingWebhooks, validatingWebhooks, err := s.extractWebhooksFromGeneratedFiles()
+	mutatingWebhooks, validatingWebhooks, err := s.extractWebhooks()
 	if err != nil {
 		return fmt.Errorf("failed to extract webhooks: %w", err)
 	}
 
+	// The manager of the webhook-only projects does not use leader election
+	kustomizeCfg, err := kustomizev2scaffolds.LoadPluginConfig(s.config)
+	if err != nil {
+		return fmt.Errorf("error loading the kustomize plugin configuration: %w", err)
+	}
+
 	scaffold := machinery.NewScaffold(s.fs,
 		machinery.WithConfig(s.config),
 	)
 
 	hasWebhooks := len(mutatingWebhooks) > 0 || len(validatingWebhooks) > 0
 	buildScaffold := []machinery.Builder{
-		&github.HelmChartCI{ChartDir: s.chartDir},
-		&templates.HelmChart{ChartDir: s.chartDir},
+		&templates.HelmChart{CRDsOnly: s.crdsOnly, ChartDir: s.chartDir},
 		&templates.HelmValues{
-			HasWebhooks:  hasWebhooks,
-			DeployImages: imagesEnvVars,
-			Force:        s.force,
-			ChartDir:     s.chartDir,
+			HasWebhooks:     hasWebhooks,
+			DeployImages:    deployImages,
+			LeaderElection:  !kustomizeCfg.WebhookOnly,
+			ComponentConfig: kustomizeCfg.ComponentConfig,
+			HasExtras:       len(s.extraConfigDirs) > 0,
+			Managers:        kustomizeCfg.Managers,
+			SkipCRDs:        s.skipCRDs,
+			CRDsOnly:        s.crdsOnly,
+			Force:           s.force,
+			ChartDir:        s.chartDir,
 		},
+		&templates.HelmReadme{Force: s.force, CRDsOnly: s.crdsOnly, ChartDir: s.chartDir},
 		&templates.HelmIgnore{ChartDir: s.chartDir},
 		&charttemplates.HelmHelpers{ChartDir: s.chartDir},
+	}
+
+	// chartFiles are the files generated from the project manifests, which are tracked to be pruned
+	// once their source is removed from the project
+	var chartFiles []string
+
+	// The chart of the CRDs does not distribute the manager, only the CRDs copied below
+	if s.crdsOnly {
+		if err := scaffold.Execute(buildScaffold...); err != nil {
+			return fmt.Errorf("error scaffolding helm-chart manifests: %v", err)
+		}
+		return s.scaffoldConfigFiles(scaffold, chartFiles)
+	}
+
+	buildScaffold = append(buildScaffold,
+		&github.HelmChartCI{ChartDir: s.chartDir},
 		&manager.Deployment{
 			Force:        s.force,
-			DeployImages: len(imagesEnvVars) > 0,
+			DeployImages: len(deployImages) > 0,
 			HasWebhooks:  hasWebhooks,
 			ChartDir:     s.chartDir,
 		},
 		&templatescertmanager.Certificate{ChartDir: s.chartDir},
+		&manager.Config{ChartDir: s.chartDir},
 		&templatesmetrics.Service{ChartDir: s.chartDir},
 		&prometheus.Monitor{ChartDir: s.chartDir},
+	)
+	if s.chartReleaser {
+		buildScaffold = append(buildScaffold, &github.HelmChartRelease{ChartDir: s.chartDir})
 	}
 
+	for _, name := range kustomizeCfg.Managers {
+		deployment := &manager.AdditionalDeployment{Name: name, ChartDir: s.chartDir}
+		buildScaffold = append(buildScaffold, deployment)
+		if err := deployment.SetTemplateDefaults(); err != nil {
+			return err
+		}
+		chartFiles = append(chartFiles, deployment.Path)
+	}
 	if len(mutatingWebhooks) > 0 || len(validatingWebhooks) > 0 {
-		buildScaffold = append(buildScaffold,
-			&templateswebhooks.Template{
-				MutatingWebhooks:   mutatingWebhooks,
-				ValidatingWebhooks: validatingWebhooks,
-				ChartDir:           s.chartDir,
-			},
-			&templateswebhooks.Service{ChartDir: s.chartDir},
-		)
+		webhookTemplate := &templateswebhooks.Template{
+			MutatingWebhooks:   mutatingWebhooks,
+			ValidatingWebhooks: validatingWebhooks,
+			ChartDir:           s.chartDir,
+		}
+		webhookService := &templateswebhooks.Service{ChartDir: s.chartDir}
+		buildScaffold = append(buildScaffold, webhookTemplate, webhookService)
+		if err := webhookTemplate.SetTemplateDefaults(); err != nil {
+			return err
+		}
+		if err := webhookService.SetTemplateDefaults(); err != nil {
+			return err
+		}
+		chartFiles = append(chartFiles, webhookTemplate.Path, webhookService.Path)
 	}
 
 	if err := scaffold.Execute(buildScaffold...); err != nil {
 		return fmt.Errorf("error scaffolding helm-chart manifests: %v", err)
 	}
 
+	return s.scaffoldConfigFiles(scaffold, chartFiles)
+}
+
+// scaffoldConfigFiles copies the manifests of the project to the chart, scaffolds the Job upgrading
+// the copied CRDs and prunes the stale chart files. The chartFiles are the files already generated
+// from the project manifests.
+func (s *initScaffolder) scaffoldConfigFiles(scaffold *machinery.Scaffold, chartFiles []string) error {
+	if err := s.addMissingHelpers(); err != nil {
+		return fmt.Errorf("error updating the helpers of the chart: %w", err)
+	}
+
+	if err := s.updateReadmeValuesTable(); err != nil {
+		return fmt.Errorf("error updating the values table of the chart README: %w", err)
+	}
+
 	// Copy relevant files from config/ to chartDir/chart/templates/
-	err = s.copyConfigFiles()
+	copiedFiles, err := s.copyConfigFiles()
 	if err != nil {
 		return fmt.Errorf("failed to copy manifests from config to %s/chart/templates/: %v", s.chartDir, err)
 	}
+	chartFiles = append(chartFiles, copiedFiles...)
 
-	return nil
+	// Copy the manifests of the extra config directories to chartDir/chart/templates/extras/,
+	// which are distributed with the manager rather than with the CRDs
+	if !s.crdsOnly {
+		extraFiles, err := s.copyExtraConfigFiles()
+		if err != nil {
+			return fmt.Errorf("failed to copy the manifests of the extra config directories to %s/chart/templates/extras/: %w",
+				s.chartDir, err)
+		}
+		chartFiles = append(chartFiles, extraFiles...)
+	}
+
+	// The Job upgrading the CRDs applies the CRDs copied in the chart
+	var crdFiles []string
+	crdDir := filepath.Join(s.chartDir, "chart", "templates", "crd")
+	for _, file := range copiedFiles {
+		if filepath.Dir(file) == crdDir {
+			crdFiles = append(crdFiles, filepath.Base(file))
+		}
+	}
+	if len(crdFiles) > 0 {
+		upgradeJob := &templatescrd.UpgradeJob{CRDFiles: crdFiles, ChartDir: s.chartDir}
+		if err := scaffold.Execute(upgradeJob); err != nil {
+			return fmt.Errorf("error scaffolding the CRD upgrade job: %v", err)
+		}
+		chartFiles = append(chartFiles, upgradeJob.Path)
+	}
+
+	return s.pruneChartFiles(chartFiles)
 }
 
-// getDeployImagesEnvVars will return the values to append the envvars for projects
-// which has the APIs scaffolded with DeployImage plugin
-func (s *initScaffolder) getDeployImagesEnvVars() map[string]string {
-	deployImages := make(map[string]string)
+// pruneChartFiles removes the chart files which were generated by a previous run but whose source
+// no longer exists in the project, e.g. the CRD of a removed API, and tracks the generated chart
+// files in the PROJECT file. Files which were not generated by the plugin are never removed.
+func (s *initScaffolder) pruneChartFiles(chartFiles []string) error {
+	pluginCfg, err := LoadPluginConfig(s.config)
+	if err != nil {
+		return fmt.Errorf("error loading the plugin configuration: %w", err)
+	}
+
+	generated := make(map[string]struct{}, len(chartFiles))
+	for _, file := range chartFiles {
+		generated[filepath.ToSlash(file)] = struct{}{}
+	}
+
+	chartPrefix := filepath.ToSlash(filepath.Join(s.chartDir, "chart")) + "/"
+	for _, file := range pluginCfg.ChartFiles {
+		if _, ok := generated[file]; ok {
+			continue
+		}
+		// Only the files of the current chart are pruned, the chart directory may have been changed
+		if !strings.HasPrefix(file, chartPrefix) {
+			continue
+		}
+		if err := s.fs.FS.Remove(filepath.FromSlash(file)); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("failed to remove the stale chart file %s: %w", file, err)
+		}
+		log.Printf("Removed %s as its source no longer exists in the project", file)
+	}
+
+	pluginCfg.ChartDir = s.chartDir
+	pluginCfg.ChartReleaser = s.chartReleaser
+	pluginCfg.ExtraConfigDirs = s.extraConfigDirs
+	pluginCfg.SkipCRDs = s.skipCRDs
+	pluginCfg.CRDsOnly = s.crdsOnly
+	pluginCfg.ChartFiles = make([]string, 0, len(generated))
+	for file := range generated {
+		pluginCfg.ChartFiles = append(pluginCfg.ChartFiles, file)
+	}
+	sort.Strings(pluginCfg.ChartFiles)
+
+	return SavePluginConfig(s.config, pluginCfg)
+}
+
+// getDeployImages will return the values of the Operands for projects
+// which has the APIs scaffolded with DeployImage plugin, by lowercase kind
+func (s *initScaffolder) getDeployImages() map[string]templates.DeployImage {
+	deployImages := make(map[string]templates.DeployImage)
 
 	pluginConfig := struct {
 		Resources []struct {
@@ -146,86 +302,45 @@ func (s *initScaffolder) getDeployImagesEnvVars() map[string]string {
 	if err == nil {
 		for _, res := range pluginConfig.Resources {
 			image, ok := res.Options["image"]
-			if ok {
-				deployImages[strings.ToUpper(res.Kind)] = image
+			if !ok {
+				continue
+			}
+			repository, tag := splitImage(image)
+			pullPolicy := res.Options["imagePullPolicy"]
+			if pullPolicy == "" {
+				pullPolicy = "IfNotPresent"
+			}
+			deployImages[strings.ToLower(res.Kind)] = templates.DeployImage{
+				Repository:    repository,
+				Tag:           tag,
+				PullPolicy:    pullPolicy,
+				ContainerPort: res.Options["containerPort"],
+				RunAsUser:     res.Options["runAsUser"],
 			}
 		}
 	}
 	return deployImages
 }
 
-// extractWebhooksFromGeneratedFiles parses the files generated by controller-gen under
-// config/webhooks and created Mutating and Validating helper structures to
-// generate the webhook manifest for the helm-chart
-func (s *initScaffolder) extractWebhooksFromGeneratedFiles() (mutatingWebhooks []templateswebhooks.DataWebhook,
-	validatingWebhooks []templateswebhooks.DataWebhook, err error) {
-	manifestFile := "config/webhook/manifests.yaml"
-
-	if _, err := os.Stat(manifestFile); os.IsNotExist(err) {
-		log.Printf("webhook manifests were not found at %s", manifestFile)
-		return nil, nil, nil
+// splitImage splits an image into its repository and its tag, which is empty for the images
+// without tag or referenced by digest
+func splitImage(image string) (string, string) {
+	if strings.Contains(image, "@") {
+		return image, ""
 	}
-
-	content, err := os.ReadFile(manifestFile)
-	if err != nil {
-		return nil, nil,
-			fmt.Errorf("failed to read %s: %w", manifestFile, err)
-	}
-
-	docs := strings.Split(string(content), "---")
-	for _, doc := range docs {
-		var webhookConfig struct {
-			Kind     string `yaml:"kind"`
-			Webhooks []struct {
-				Name         string `yaml:"name"`
-				ClientConfig struct {
-					Service struct {
-						Name      string `yaml:"name"`
-						Namespace string `yaml:"namespace"`
-						Path      string `yaml:"path"`
-					} `yaml:"service"`
-				} `yaml:"clientConfig"`
-				Rules                   []templateswebhooks.DataWebhookRule `yaml:"rules"`
-				FailurePolicy           string                              `yaml:"failurePolicy"`
-				SideEffects             string                              `yaml:"sideEffects"`
-				AdmissionReviewVersions []string                            `yaml:"admissionReviewVersions"`
-			} `yaml:"webhooks"`
-		}
-
-		if err := yaml.Unmarshal([]byte(doc), &webhookConfig); err != nil {
-			log.Errorf("fail to unmarshalling webhook YAML: %v", err)
-			continue
-		}
-
-		for _, w := range webhookConfig.Webhooks {
-			for i := range w.Rules {
-				if len(w.Rules[i].APIGroups) == 0 {
-					w.Rules[i].APIGroups = []string{""}
-				}
-			}
-			webhook := templateswebhooks.DataWebhook{
-				Name:                    w.Name,
-				ServiceName:             fmt.Sprintf("%s-webhook-service", s.config.GetProjectName()),
-				Path:                    w.ClientConfig.Service.Path,
-				FailurePolicy:           w.FailurePolicy,
-				SideEffects:             w.SideEffects,
-				AdmissionReviewVersions: w.AdmissionReviewVersions,
-				Rules:                   w.Rules,
-			}
-
-			if webhookConfig.Kind == "MutatingWebhookConfiguration" {
-				mutatingWebhooks = append(mutatingWebhooks, webhook)
-			} else if webhookConfig.Kind == "ValidatingWebhookConfiguration" {
-				validatingWebhooks = append(validatingWebhooks, webhook)
-			}
-		}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:]
 	}
-
-	return mutatingWebhooks, validatingWebhooks, nil
+	return image, ""
 }
 
-// Helper function to copy files from config/ to chartDir/chart/templates/
-func (s *initScaffolder) copyConfigFiles() error {
+// Helper function to copy files from config/ to chartDir/chart/templates/.
+// It returns the paths of the files written in the chart.
+func (s *initScaffolder) copyConfigFiles() ([]string, error) {
+	var copiedFiles []string
+
+	aggregatedRoles := s.getAggregatedRoles()
+
 	configDirs := []struct {
 		SrcDir  string
 		DestDir string
@@ -234,9 +349,17 @@ func (s *initScaffolder) copyConfigFiles() error {
 		{"config/rbac", filepath.Join(s.chartDir, "chart/templates/rbac"), "rbac"},
 		{"config/crd/bases", filepath.Join(s.chartDir, "chart/templates/crd"), "crd"},
 		{"config/network-policy", filepath.Join(s.chartDir, "chart/templates/network-policy"), "networkPolicy"},
+		{"config/network-policy/metrics", filepath.Join(s.chartDir, "chart/templates/network-policy"), "networkPolicy"},
+		{"config/network-policy/webhook", filepath.Join(s.chartDir, "chart/templates/network-policy"), "networkPolicy"},
+		{"config/prometheus", filepath.Join(s.chartDir, "chart/templates/prometheus"), "prometheus"},
 	}
 
 	for _, dir := range configDirs {
+		// The CRDs are distributed alone by the chart of the CRDs, and not at all by the chart skipping them
+		if (dir.SubDir == "crd" && s.skipCRDs) || (dir.SubDir != "crd" && s.crdsOnly) {
+			continue
+		}
+
 		// Check if the source directory exists
 		if _, err := os.Stat(dir.SrcDir); os.IsNotExist(err) {
 			// Skip if the source directory does not exist
@@ -245,7 +368,7 @@ func (s *initScaffolder) copyConfigFiles() error {
 
 		files, err := filepath.Glob(filepath.Join(dir.SrcDir, "*.yaml"))
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		// Skip processing if the directory is empty (no matching files)
@@ -254,25 +377,125 @@ func (s *initScaffolder) copyConfigFiles() error {
 		}
 
 		// Ensure destination directory exists
-		if err := os.MkdirAll(dir.DestDir, os.ModePerm); err != nil {
-			return fmt.Errorf("failed to create directory %s: %v", dir.DestDir, err)
+		if err := s.fs.FS.MkdirAll(dir.DestDir, os.ModePerm); err != nil {
+			return nil, fmt.Errorf("failed to create directory %s: %v", dir.DestDir, err)
 		}
 
 		for _, srcFile := range files {
+			// Skip kustomization.yaml or kustomizeconfig.yaml files
+			if strings.HasSuffix(srcFile, "kustomization.yaml") ||
+				strings.HasSuffix(srcFile, "kustomizeconfig.yaml") {
+				continue
+			}
+			// The ServiceMonitor of the chart is scaffolded from its own template, only the
+			// PrometheusRules, e.g. scaffolded by the prometheus-rules plugin, are copied
+			if dir.SubDir == "prometheus" {
+				isRule, err := isPrometheusRuleFile(srcFile)
+				if err != nil {
+					return nil, err
+				}
+				if !isRule {
+					continue
+				}
+			}
+
 			destFile := filepath.Join(dir.DestDir, filepath.Base(srcFile))
-			err := copyFileWithHelmLogic(srcFile, destFile, dir.SubDir, s.config.GetProjectName())
+			var aggregateTo string
+			if dir.SubDir == "rbac" {
+				aggregateTo = aggregatedRoles[filepath.Base(srcFile)]
+			}
+			err := copyFileWithHelmLogic(s.fs.FS, srcFile, destFile, dir.SubDir, s.config.GetProjectName(), aggregateTo)
 			if err != nil {
-				return err
+				return nil, err
 			}
+			copiedFiles = append(copiedFiles, destFile)
 		}
 	}
 
-	return nil
+	return copiedFiles, nil
+}
+
+// copyExtraConfigFiles copies the manifests of the extra config directories to chartDir/chart/templates/extras/.
+// It returns the paths of the files written in the chart.
+func (s *initScaffolder) copyExtraConfigFiles() ([]string, error) {
+	var copiedFiles []string
+
+	destDir := filepath.Join(s.chartDir, "chart", "templates", "extras")
+	// sources are the manifests copied to each file of the chart, to detect the name conflicts
+	sources := make(map[string]string)
+	for _, dir := range s.extraConfigDirs {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			log.Warnf("The extra config directory %s does not exist, its manifests are not added to the chart", dir)
+			continue
+		}
+
+		var files []string
+		for _, pattern := range []string{"*.yaml", "*.yml"} {
+			matches, err := filepath.Glob(filepath.Join(dir, pattern))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, matches...)
+		}
+		sort.Strings(files)
+
+		for _, srcFile := range files {
+			// Skip the files configuring kustomize
+			switch filepath.Base(srcFile) {
+			case "kustomization.yaml", "kustomization.yml", "kustomizeconfig.yaml", "kustomizeconfig.yml":
+				continue
+			}
+
+			destFile := filepath.Join(destDir,
+				strings.TrimSuffix(filepath.Base(srcFile), filepath.Ext(srcFile))+".yaml")
+			if previous, conflicting := sources[destFile]; conflicting {
+				return nil, fmt.Errorf("the manifests %s and %s would both be copied to %s, rename one of them",
+					previous, srcFile, destFile)
+			}
+			sources[destFile] = srcFile
+
+			if err := copyExtraFileWithHelmLogic(s.fs.FS, srcFile, destFile); err != nil {
+				return nil, err
+			}
+			copiedFiles = append(copiedFiles, destFile)
+		}
+	}
+
+	return copiedFiles, nil
+}
+
+// getAggregatedRoles returns the aggregation of the admin, editor and viewer roles of the namespaced
+// APIs into the default ClusterRoles, by file name. The roles of the cluster-scoped APIs are not aggregated
+// since the default ClusterRoles are meant to be granted in a namespace.
+func (s *initScaffolder) getAggregatedRoles() map[string]string {
+	aggregatedRoles := make(map[string]string)
+
+	resources, err := s.config.GetResources()
+	if err != nil {
+		log.Warnf("unable to get the resources of the project: %v", err)
+		return aggregatedRoles
+	}
+
+	for _, res := range resources {
+		if !res.HasAPI() || !res.API.Namespaced {
+			continue
+		}
+		// The file names match the roles scaffolded by the kustomize plugin in config/rbac
+		crdName := strings.ToLower(res.Kind)
+		if s.config.IsMultiGroup() && res.Group != "" {
+			crdName = strings.ToLower(res.Group) + "_" + crdName
+		}
+		aggregatedRoles[crdName+"_admin_role.yaml"] = "admin"
+		aggregatedRoles[crdName+"_editor_role.yaml"] = "edit"
+		aggregatedRoles[crdName+"_viewer_role.yaml"] = "view"
+	}
+	return aggregatedRoles
 }
 
 // copyFileWithHelmLogic reads the source file, modifies the content for Helm, applies patches
-// to spec.conversion if applicable, and writes it to the destination
-func copyFileWithHelmLogic(srcFile, destFile, subDir, projectName string) error {
+// to spec.conversion if applicable, and writes it to the destination in fs.
+// The role is aggregated into the default ClusterRole aggregateTo (admin, edit or view), if any.
+func copyFileWithHelmLogic(fs afero.Fs, srcFile, destFile, subDir, projectName, aggregateTo string) error {
 	if _, err := os.Stat(srcFile); os.IsNotExist(err) {
 		log.Printf("Source file does not exist: %s", srcFile)
 		return err
@@ -286,29 +509,23 @@ func copyFileWithHelmLogic(srcFile, destFile, subDir, projectName string) error
 
 	contentStr := string(content)
 
-	// Skip kustomization.yaml or kustomizeconfig.yaml files
-	if strings.HasSuffix(srcFile, "kustomization.yaml") ||
-		strings.HasSuffix(srcFile, "kustomizeconfig.yaml") {
-		return nil
-	}
-
 	// Apply RBAC-specific replacements
 	if subDir == "rbac" {
 		contentStr = strings.Replace(contentStr,
 			"name: controller-manager",
-			"name: {{ .Values.controllerManager.serviceAccountName }}", -1)
+			`name: {{ include "chart.serviceAccountName" . }}`, -1)
 		contentStr = strings.Replace(contentStr,
 			"name: metrics-reader",
-			fmt.Sprintf("name: %s-metrics-reader", projectName), 1)
+			`name: {{ include "chart.fullname" . }}-metrics-reader`, 1)
 
 		contentStr = strings.Replace(contentStr,
 			"name: metrics-auth-role",
-			fmt.Sprintf("name: %s-metrics-auth-role", projectName), -1)
+			`name: {{ include "chart.fullname" . }}-metrics-auth-role`, -1)
 		contentStr = strings.Replace(contentStr,
 			"name: metrics-auth-rolebinding",
-			fmt.Sprintf("name: %s-metrics-auth-rolebinding", projectName), 1)
+			`name: {{ include "chart.fullname" . }}-metrics-auth-rolebinding`, 1)
 
-		if strings.Contains(contentStr, ".Values.controllerManager.serviceAccountName") &&
+		if strings.Contains(contentStr, `include "chart.serviceAccountName"`) &&
 			strings.Contains(contentStr, "kind: ServiceAccount") &&
 			!strings.Contains(contentStr, "RoleBinding") {
 			// The generated Service Account does not have the annotations field so we must add it.
@@ -323,16 +540,16 @@ func copyFileWithHelmLogic(srcFile, destFile, subDir, projectName string) error
 		}
 		contentStr = strings.Replace(contentStr,
 			"name: leader-election-role",
-			fmt.Sprintf("name: %s-leader-election-role", projectName), -1)
+			`name: {{ include "chart.fullname" . }}-leader-election-role`, -1)
 		contentStr = strings.Replace(contentStr,
 			"name: leader-election-rolebinding",
-			fmt.Sprintf("name: %s-leader-election-rolebinding", projectName), 1)
+			`name: {{ include "chart.fullname" . }}-leader-election-rolebinding`, 1)
 		contentStr = strings.Replace(contentStr,
 			"name: manager-role",
-			fmt.Sprintf("name: %s-manager-role", projectName), -1)
+			`name: {{ include "chart.fullname" . }}-manager-role`, -1)
 		contentStr = strings.Replace(contentStr,
 			"name: manager-rolebinding",
-			fmt.Sprintf("name: %s-manager-rolebinding", projectName), 1)
+			`name: {{ include "chart.fullname" . }}-manager-rolebinding`, 1)
 
 		// The generated files do not include the namespace
 		if strings.Contains(contentStr, "leader-election-rolebinding") ||
@@ -343,13 +560,25 @@ func copyFileWithHelmLogic(srcFile, destFile, subDir, projectName string) error
 		}
 	}
 
+	// The alert templates of Prometheus use the same delimiters as Helm
+	if subDir == "prometheus" {
+		contentStr = strings.ReplaceAll(contentStr, "{{", `{{ "{{" }}`)
+		contentStr = strings.Replace(contentStr,
+			"name: controller-manager-rules",
+			`name: {{ include "chart.fullname" . }}-controller-manager-rules`, 1)
+		// The metrics are scraped from the Service of the release
+		contentStr = strings.ReplaceAll(contentStr,
+			fmt.Sprintf(`job="%s-controller-manager-metrics-service"`, projectName),
+			`job="{{ include "chart.metricsServiceName" . }}"`)
+	}
+
 	// Conditionally handle CRD patches and annotations for CRDs
 	if subDir == "crd" {
-		kind, group := extractKindAndGroupFromFileName(filepath.Base(srcFile))
+		plural, group := extractPluralAndGroupFromFileName(filepath.Base(srcFile))
 		hasWebhookPatch := false
 
 		// Retrieve patch content for the CRD's spec.conversion, if it exists
-		patchContent, patchExists, err := getCRDPatchContent(kind, group)
+		patchContent, patchExists, err := getCRDPatchContent(plural, group)
 		if err != nil {
 			return err
 		}
@@ -375,6 +604,14 @@ func copyFileWithHelmLogic(srcFile, destFile, subDir, projectName string) error
   labels:
     {{- include "chart.labels" . | nindent 4 }}`, 1)
 
+	if aggregateTo != "" {
+		contentStr = strings.Replace(contentStr, `{{- include "chart.labels" . | nindent 4 }}`,
+			fmt.Sprintf(`{{- include "chart.labels" . | nindent 4 }}
+    {{- if .Values.rbac.aggregateToDefaultRoles }}
+    rbac.authorization.k8s.io/aggregate-to-%s: "true"
+    {{- end }}`, aggregateTo), 1)
+	}
+
 	var wrappedContent string
 	if isMetricRBACFile(subDir, srcFile) {
 		wrappedContent = fmt.Sprintf(
@@ -384,11 +621,11 @@ func copyFileWithHelmLogic(srcFile, destFile, subDir, projectName string) error
 			"{{- if .Values.%s.enable }}\n%s{{- end -}}\n", subDir, contentStr)
 	}
 
-	if err := os.MkdirAll(filepath.Dir(destFile), os.ModePerm); err != nil {
+	if err := fs.MkdirAll(filepath.Dir(destFile), os.ModePerm); err != nil {
 		return err
 	}
 
-	err = os.WriteFile(destFile, []byte(wrappedContent), os.ModePerm)
+	err = afero.WriteFile(fs, destFile, []byte(wrappedContent), os.ModePerm)
 	if err != nil {
 		log.Printf("Error writing destination file: %s", destFile)
 		return err
@@ -398,39 +635,85 @@ func copyFileWithHelmLogic(srcFile, destFile, subDir, projectName string) error
 	return nil
 }
 
-// extractKindAndGroupFromFileName extracts the kind and group from a CRD filename
-func extractKindAndGroupFromFileName(fileName string) (kind, group string) {
+// copyExtraFileWithHelmLogic reads a manifest of an extra config directory, which may contain several documents,
+// and writes it to the destination gated by the extras values. The labels of the manifests are kept, and the
+// labels of the chart are added to the manifests without labels.
+func copyExtraFileWithHelmLogic(fs afero.Fs, srcFile, destFile string) error {
+	content, err := os.ReadFile(srcFile)
+	if err != nil {
+		log.Printf("Error reading source file: %s", srcFile)
+		return err
+	}
+
+	// The manifests may contain Go templates, e.g. in the data of a ConfigMap, which use the same delimiters as Helm
+	contentStr := strings.ReplaceAll(string(content), "{{", `{{ "{{" }}`)
+
+	documents := documentSeparatorRegex.Split(contentStr, -1)
+	for i, document := range documents {
+		if topLevelLabelsRegex.MatchString(document) {
+			continue
+		}
+		documents[i] = topLevelMetadataRegex.ReplaceAllLiteralString(document, `metadata:
+  labels:
+    {{- include "chart.labels" . | nindent 4 }}`)
+	}
+	contentStr = strings.Join(documents, "---\n")
+
+	// Replace namespace with Helm template variable
+	contentStr = strings.ReplaceAll(contentStr, "namespace: system", "namespace: {{ .Release.Namespace }}")
+
+	// The values scaffolded before the extras were added to the chart do not have the extras key
+	wrappedContent := fmt.Sprintf("{{- if or (not (hasKey .Values \"extras\")) .Values.extras.enable }}\n%s{{- end -}}\n",
+		strings.TrimLeft(contentStr, "\n"))
+
+	if err := fs.MkdirAll(filepath.Dir(destFile), os.ModePerm); err != nil {
+		return err
+	}
+	if err := afero.WriteFile(fs, destFile, []byte(wrappedContent), os.ModePerm); err != nil {
+		log.Printf("Error writing destination file: %s", destFile)
+		return err
+	}
+
+	log.Printf("Successfully copied %s to %s", srcFile, destFile)
+	return nil
+}
+
+var (
+	// documentSeparatorRegex matches the separators of the documents of a YAML file
+	documentSeparatorRegex = regexp.MustCompile(`(?m)^---[ \t]*\n`)
+	// topLevelMetadataRegex matches the metadata of a manifest
+	topLevelMetadataRegex = regexp.MustCompile(`(?m)^metadata:[ \t]*$`)
+	// topLevelLabelsRegex matches the labels of the metadata of a manifest
+	topLevelLabelsRegex = regexp.MustCompile(`(?m)^  labels:[ \t]*$`)
+)
+
+// extractPluralAndGroupFromFileName extracts the plural and the group from a CRD filename, which controller-gen
+// names <group>.<domain>_<plural>.yaml with the plural of the resource, e.g. its irregular plural set by --plural
+func extractPluralAndGroupFromFileName(fileName string) (plural, group string) {
 	parts := strings.Split(fileName, "_")
 	if len(parts) >= 2 {
 		group = strings.Split(parts[0], ".")[0] // Extract group up to the first dot
-		kind = strings.TrimSuffix(parts[1], ".yaml")
+		plural = strings.TrimSuffix(parts[1], ".yaml")
 	}
-	return kind, group
+	return plural, group
 }
 
-// getCRDPatchContent finds and reads the appropriate patch content for a given kind and group
-func getCRDPatchContent(kind, group string) (string, bool, error) {
-	// First, look for patches that contain both "webhook", the group, and kind in their filename
-	groupKindPattern := fmt.Sprintf("config/crd/patches/webhook_*%s*%s*.yaml", group, kind)
-	patchFiles, err := filepath.Glob(groupKindPattern)
-	if err != nil {
-		return "", false, fmt.Errorf("failed to list patches: %v", err)
-	}
-
-	// If no group-specific patch found, search for patches that contain only "webhook" and the kind
-	if len(patchFiles) == 0 {
-		kindOnlyPattern := fmt.Sprintf("config/crd/patches/webhook_*%s*.yaml", kind)
-		patchFiles, err = filepath.Glob(kindOnlyPattern)
-		if err != nil {
-			return "", false, fmt.Errorf("failed to list patches: %v", err)
-		}
+// getCRDPatchContent finds and reads the webhook patch of the CRD with the given plural and group. The patches are
+// named after the plural, prefixed by the group in multi-group projects, and matched exactly so that the patch of
+// a plural is not taken for the one of another plural containing it, e.g. mice and dormice.
+func getCRDPatchContent(plural, group string) (string, bool, error) {
+	patchFiles := []string{
+		filepath.Join("config", "crd", "patches", fmt.Sprintf("webhook_in_%s_%s.yaml", group, plural)),
+		filepath.Join("config", "crd", "patches", fmt.Sprintf("webhook_in_%s.yaml", plural)),
 	}
 
-	// Read the first matching patch file (if any)
-	if len(patchFiles) > 0 {
-		patchContent, err := os.ReadFile(patchFiles[0])
-		if err != nil {
-			return "", false, fmt.Errorf("failed to read patch file %s: %v", patchFiles[0], err)
+	// Read the first existing patch file (if any)
+	for _, patchFile := range patchFiles {
+		patchContent, err := os.ReadFile(patchFile)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return "", false, fmt.Errorf("failed to read patch file %s: %v", patchFile, err)
 		}
 		return string(patchContent), true, nil
 	}
@@ -447,12 +730,18 @@ func extractConversionSpec(patchContent string) string {
 	return patchContent[specStart:]
 }
 
+// webhookServiceRegex matches the name of the webhook Service of the kustomize manifests, capturing its indentation
+var webhookServiceRegex = regexp.MustCompile(`(?m)^([ \t]*)name: webhook-service$`)
+
 // injectConversionSpecWithCondition inserts the conversion spec under the main spec field with Helm conditional
 func injectConversionSpecWithCondition(contentStr, conversionSpec string) string {
 	specPosition := strings.Index(contentStr, "spec:")
 	if specPosition == -1 {
 		return contentStr // No spec field found; return unchanged
 	}
+	// The conversion webhook is served by the webhook Service of the chart
+	conversionSpec = webhookServiceRegex.ReplaceAllString(conversionSpec,
+		`${1}name: {{ include "chart.webhookServiceName" . }}`+"\n"+`${1}port: {{ include "chart.webhookServicePort" . }}`)
 	conditionalSpec := fmt.Sprintf("\n  {{- if .Values.webhook.enable }}\n  %s\n  {{- end }}",
 		strings.TrimRight(conversionSpec, "\n"))
 	return contentStr[:specPosition+5] + conditionalSpec + contentStr[specPosition+5:]
@@ -462,7 +751,7 @@ func injectConversionSpecWithCondition(contentStr, conversionSpec string) string
 // extra spaces
 func injectAnnotations(contentStr string, hasWebhookPatch bool) string {
 	annotationsBlock := `
-    {{- if .Values.certmanager.enable }}
+    {{- if include "chart.certManagerEnabled" . }}
     cert-manager.io/inject-ca-from: "{{ .Release.Namespace }}/serving-cert"
     {{- end }}
     {{- if .Values.crd.keep }}
@@ -488,6 +777,15 @@ func isMetricRBACFile(subDir, srcFile string) bool {
 		strings.HasSuffix(srcFile, "metrics_reader_role.yaml"))
 }
 
+// isPrometheusRuleFile checks if the manifest of the file is a PrometheusRule
+func isPrometheusRuleFile(srcFile string) (bool, error) {
+	content, err := os.ReadFile(srcFile)
+	if err != nil {
+		return false, err
+	}
+	return regexp.MustCompile(`(?m)^kind: PrometheusRule\s*$`).Match(content), nil
+}
+
 // removeLabels removes any existing labels section from the content
 func removeLabels(content string) string {
 	labelRegex := regexp.MustCompile(`(?m)^  labels:\n(?:    [^\n]+\n)*`)
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ = Describe("Chart files", func() {
+	const (
+		crd      = "dist/chart/templates/crd/crew.example.com_captains.yaml"
+		staleCRD = "dist/chart/templates/crd/crew.example.com_firstmates.yaml"
+		userFile = "dist/chart/templates/crd/custom.yaml"
+	)
+
+	var (
+		fs  machinery.Filesystem
+		cfg config.Config
+	)
+
+	writeFiles := func(files ...string) {
+		for _, file := range files {
+			Expect(afero.WriteFile(fs.FS, file, []byte("kind: ConfigMap\n"), 0o644)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+
+		cfg = cfgv3.New()
+		Expect(cfg.SetProjectName("project")).To(Succeed())
+	})
+
+	Context("pruneChartFiles", func() {
+		var scaffolder *initScaffolder
+
+		BeforeEach(func() {
+			scaffolder = &initScaffolder{config: cfg, fs: fs, chartDir: "dist"}
+		})
+
+		It("should remove the files previously generated which are no longer produced", func() {
+			writeFiles(crd, staleCRD)
+			Expect(SavePluginConfig(cfg, PluginConfig{ChartFiles: []string{crd, staleCRD}})).To(Succeed())
+
+			Expect(scaffolder.pruneChartFiles([]string{crd})).To(Succeed())
+
+			Expect(afero.Exists(fs.FS, crd)).To(BeTrue())
+			Expect(afero.Exists(fs.FS, staleCRD)).To(BeFalse())
+		})
+
+		It("should never remove the files which were not generated by the plugin", func() {
+			writeFiles(crd, staleCRD, userFile)
+			Expect(SavePluginConfig(cfg, PluginConfig{ChartFiles: []string{crd, staleCRD}})).To(Succeed())
+
+			Expect(scaffolder.pruneChartFiles([]string{crd})).To(Succeed())
+
+			Expect(afero.Exists(fs.FS, userFile)).To(BeTrue())
+		})
+
+		It("should not remove anything on the first run", func() {
+			writeFiles(crd, staleCRD, userFile)
+
+			Expect(scaffolder.pruneChartFiles([]string{crd})).To(Succeed())
+
+			for _, file := range []string{crd, staleCRD, userFile} {
+				Expect(afero.Exists(fs.FS, file)).To(BeTrue(), file)
+			}
+		})
+
+		It("should not remove the files of a previous chart directory", func() {
+			previous := "charts/chart/templates/crd/crew.example.com_captains.yaml"
+			writeFiles(previous)
+			Expect(SavePluginConfig(cfg, PluginConfig{ChartDir: "charts", ChartFiles: []string{previous}})).To(Succeed())
+
+			Expect(scaffolder.pruneChartFiles([]string{crd})).To(Succeed())
+
+			Expect(afero.Exists(fs.FS, previous)).To(BeTrue())
+		})
+
+		It("should ignore the stale files which were already removed", func() {
+			Expect(SavePluginConfig(cfg, PluginConfig{ChartFiles: []string{crd, staleCRD}})).To(Succeed())
+
+			Expect(scaffolder.pruneChartFiles([]string{crd})).To(Succeed())
+		})
+
+		It("should track the generated files in the PROJECT file", func() {
+			Expect(SavePluginConfig(cfg, PluginConfig{ChartFiles: []string{staleCRD}})).To(Succeed())
+
+			Expect(scaffolder.pruneChartFiles([]string{crd, "dist/chart/templates/crd/crew.example.com_boats.yaml"})).
+				To(Succeed())
+
+			pluginCfg, err := LoadPluginConfig(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pluginCfg.ChartDir).To(Equal("dist"))
+			Expect(pluginCfg.ChartFiles).To(Equal([]string{
+				"dist/chart/templates/crd/crew.example.com_boats.yaml",
+				crd,
+			}))
+		})
+	})
+
+	Context("Scaffold", func() {
+		const extra = "dist/chart/templates/extras/dashboard.yaml"
+
+		scaffold := func() {
+			scaffolder := NewInitHelmScaffolder(cfg, false, "dist", false, []string{"config/extras"}, false, false)
+			scaffolder.InjectFS(fs)
+			Expect(scaffolder.Scaffold()).To(Succeed())
+		}
+
+		// writeConfig writes the manifests of the project, which are read from the working directory
+		writeConfig := func(file string) {
+			Expect(os.MkdirAll(filepath.Dir(file), 0o755)).To(Succeed())
+			Expect(os.WriteFile(file, []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: "+
+				filepath.Base(file)+"\n"), 0o644)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			wd, err := os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(os.Chdir, wd)
+
+			dir := GinkgoT().TempDir()
+			Expect(os.Chdir(dir)).To(Succeed())
+			fs = machinery.Filesystem{FS: afero.NewOsFs()}
+
+			writeConfig(filepath.Join("config", "crd", "bases", "crew.example.com_captains.yaml"))
+			writeConfig(filepath.Join("config", "crd", "bases", "crew.example.com_firstmates.yaml"))
+			writeConfig(filepath.Join("config", "extras", "dashboard.yaml"))
+		})
+
+		It("should prune the chart files whose source was removed from the project", func() {
+			scaffold()
+			for _, file := range []string{crd, staleCRD, extra} {
+				Expect(file).To(BeAnExistingFile())
+			}
+			writeFiles(userFile)
+
+			Expect(os.Remove(filepath.Join("config", "crd", "bases", "crew.example.com_firstmates.yaml"))).To(Succeed())
+			Expect(os.Remove(filepath.Join("config", "extras", "dashboard.yaml"))).To(Succeed())
+			scaffold()
+
+			Expect(crd).To(BeAnExistingFile())
+			Expect(staleCRD).NotTo(BeAnExistingFile())
+			Expect(extra).NotTo(BeAnExistingFile())
+			Expect(userFile).To(BeAnExistingFile())
+			Expect("dist/chart/Chart.yaml").To(BeAnExistingFile())
+
+			upgradeJob, err := os.ReadFile(filepath.Join("dist", "chart", "templates", "crd-upgrade", "job.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(upgradeJob)).To(ContainSubstring("crew.example.com_captains.yaml"))
+			Expect(string(upgradeJob)).NotTo(ContainSubstring("crew.example.com_firstmates.yaml"))
+		})
+	})
+})
@@ -26,7 +26,6 @@ var _ machinery.Template = &Certificate{}
 // Certificate scaffolds the Certificate for webhooks in the Helm chart
 type Certificate struct {
 	machinery.TemplateMixin
-	machinery.ProjectNameMixin
   ChartDir string
 }
 
@@ -43,7 +42,7 @@ func (f *Certificate) SetTemplateDefaults() error {
 	return nil
 }
 
-const certificateTemplate = `{{` + "`" + `{{- if .Values.certmanager.enable }}` + "`" + `}}
+const certificateTemplate = `{{` + "`" + `{{- if include "chart.certManagerEnabled" . }}` + "`" + `}}
 # Self-signed Issuer
 apiVersion: cert-manager.io/v1
 kind: Issuer
@@ -54,14 +53,14 @@ metadata:
   namespace: {{ "{{ .Release.Namespace }}" }}
 spec:
   selfSigned: {}
-{{ "{{- if .Values.webhook.enable }}" }}
+{{ "{{- if (.Values.webhook | default dict).enable }}" }}
 ---
 # Certificate for the webhook
 apiVersion: cert-manager.io/v1
 kind: Certificate
 metadata:
   annotations:
-    {{ "{{- if .Values.crd.keep }}" }}
+    {{ "{{- if (.Values.crd | default dict).keep }}" }}
     "helm.sh/resource-policy": keep
     {{ "{{- end }}" }}
   name: serving-cert
@@ -70,13 +69,13 @@ metadata:
     {{ "{{- include \"chart.labels\" . | nindent 4 }}" }}
 spec:
   dnsNames:
-    - {{ .ProjectName }}.{{ "{{ .Release.Namespace }}" }}.svc
-    - {{ .ProjectName }}.{{ "{{ .Release.Namespace }}" }}.svc.cluster.local
-    - {{ .ProjectName }}-webhook-service.{{ "{{ .Release.Namespace }}" }}.svc
+    - {{ "{{ include \"chart.fullname\" . }}" }}.{{ "{{ .Release.Namespace }}" }}.svc
+    - {{ "{{ include \"chart.fullname\" . }}" }}.{{ "{{ .Release.Namespace }}" }}.svc.cluster.local
+    - {{ "{{ include \"chart.webhookServiceName\" . }}" }}.{{ "{{ .Release.Namespace }}" }}.svc
   issuerRef:
     kind: Issuer
     name: selfsigned-issuer
-  secretName: webhook-server-cert
+  secretName: {{ "{{ include \"chart.webhookCertSecretName\" . }}" }}
 {{` + "`" + `{{- end }}` + "`" + `}}
 {{ "{{- if .Values.metrics.enable }}" }}
 ---
@@ -85,7 +84,7 @@ apiVersion: cert-manager.io/v1
 kind: Certificate
 metadata:
   annotations:
-    {{ "{{- if .Values.crd.keep }}" }}
+    {{ "{{- if (.Values.crd | default dict).keep }}" }}
     "helm.sh/resource-policy": keep
     {{ "{{- end }}" }}
   labels:
@@ -94,9 +93,9 @@ metadata:
   namespace: {{ "{{ .Release.Namespace }}" }}
 spec:
   dnsNames:
-    - {{ .ProjectName }}.{{ "{{ .Release.Namespace }}" }}.svc
-    - {{ .ProjectName }}.{{ "{{ .Release.Namespace }}" }}.svc.cluster.local
-    - {{ .ProjectName }}-metrics-service.{{ "{{ .Release.Namespace }}" }}.svc
+    - {{ "{{ include \"chart.fullname\" . }}" }}.{{ "{{ .Release.Namespace }}" }}.svc
+    - {{ "{{ include \"chart.fullname\" . }}" }}.{{ "{{ .Release.Namespace }}" }}.svc.cluster.local
+    - {{ "{{ include \"chart.metricsServiceName\" . }}" }}.{{ "{{ .Release.Namespace }}" }}.svc
   issuerRef:
     kind: Issuer
     name: selfsigned-issuer
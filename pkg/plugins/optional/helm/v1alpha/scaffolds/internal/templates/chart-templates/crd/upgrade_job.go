@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &UpgradeJob{}
+
+// UpgradeJob scaffolds the pre-upgrade hook Job which applies the CRDs of the chart with server-side apply
+type UpgradeJob struct {
+	machinery.TemplateMixin
+
+	// CRDFiles are the names of the CRD templates of the chart, under templates/crd
+	CRDFiles []string
+
+	ChartDir string
+}
+
+// SetTemplateDefaults sets the default template configuration
+func (f *UpgradeJob) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "chart", "templates", "crd-upgrade", "job.yaml")
+	}
+
+	f.TemplateBody = upgradeJobTemplate
+
+	// The CRDs applied by the Job change with the APIs of the project
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+const upgradeJobTemplate = `{{ "{{- $job := .Values.crd.upgradeJob }}" }}
+{{ "{{- if and .Values.crd.enable $job $job.enable }}" }}
+# The CRDs of the chart are applied with server-side apply by a pre-upgrade hook, before the
+# manager is upgraded, so that it never runs against CRDs older than its APIs.
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: {{ "{{ include \"chart.fullname\" . }}" }}-crd-upgrade
+  namespace: {{ "{{ .Release.Namespace }}" }}
+  labels:
+    {{ "{{- include \"chart.labels\" . | nindent 4 }}" }}
+  annotations:
+    helm.sh/hook: pre-upgrade
+    helm.sh/hook-weight: "-10"
+    helm.sh/hook-delete-policy: before-hook-creation,hook-succeeded
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: {{ "{{ include \"chart.fullname\" . }}" }}-crd-upgrade
+  labels:
+    {{ "{{- include \"chart.labels\" . | nindent 4 }}" }}
+  annotations:
+    helm.sh/hook: pre-upgrade
+    helm.sh/hook-weight: "-10"
+    helm.sh/hook-delete-policy: before-hook-creation,hook-succeeded
+rules:
+  - apiGroups:
+      - apiextensions.k8s.io
+    resources:
+      - customresourcedefinitions
+    verbs:
+      - create
+      - get
+      - patch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: {{ "{{ include \"chart.fullname\" . }}" }}-crd-upgrade
+  labels:
+    {{ "{{- include \"chart.labels\" . | nindent 4 }}" }}
+  annotations:
+    helm.sh/hook: pre-upgrade
+    helm.sh/hook-weight: "-10"
+    helm.sh/hook-delete-policy: before-hook-creation,hook-succeeded
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: {{ "{{ include \"chart.fullname\" . }}" }}-crd-upgrade
+subjects:
+  - kind: ServiceAccount
+    name: {{ "{{ include \"chart.fullname\" . }}" }}-crd-upgrade
+    namespace: {{ "{{ .Release.Namespace }}" }}
+---
+# The CRDs are rendered from their templates, a ConfigMap can not exceed 1MiB.
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ "{{ include \"chart.fullname\" . }}" }}-crd-upgrade
+  namespace: {{ "{{ .Release.Namespace }}" }}
+  labels:
+    {{ "{{- include \"chart.labels\" . | nindent 4 }}" }}
+  annotations:
+    helm.sh/hook: pre-upgrade
+    helm.sh/hook-weight: "-10"
+    helm.sh/hook-delete-policy: before-hook-creation,hook-succeeded
+data:
+{{- range .CRDFiles }}
+  {{ . }}: |
+    {{ "{{- include (print $.Template.BasePath \"/crd/" }}{{ . }}{{ "\") . | nindent 4 }}" }}
+{{- end }}
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: {{ "{{ include \"chart.fullname\" . }}" }}-crd-upgrade
+  namespace: {{ "{{ .Release.Namespace }}" }}
+  labels:
+    {{ "{{- include \"chart.labels\" . | nindent 4 }}" }}
+  annotations:
+    helm.sh/hook: pre-upgrade
+    helm.sh/hook-weight: "-5"
+    helm.sh/hook-delete-policy: before-hook-creation,hook-succeeded
+spec:
+  backoffLimit: {{ "{{ $job.backoffLimit | default 3 }}" }}
+  template:
+    metadata:
+      labels:
+        {{ "{{- include \"chart.labels\" . | nindent 8 }}" }}
+    spec:
+      serviceAccountName: {{ "{{ include \"chart.fullname\" . }}" }}-crd-upgrade
+      restartPolicy: Never
+      securityContext:
+        runAsNonRoot: true
+        runAsUser: 65532
+        seccompProfile:
+          type: RuntimeDefault
+      containers:
+        - name: kubectl
+          image: {{ "{{ $job.image.repository }}" }}:{{ "{{ $job.image.tag }}" }}
+          # The entrypoint of the image is kubectl
+          args:
+            - apply
+            - --server-side
+            - --force-conflicts
+            - --field-manager={{ "{{ include \"chart.fullname\" . }}" }}-crd-upgrade
+            - --filename=/crds
+          securityContext:
+            allowPrivilegeEscalation: false
+            readOnlyRootFilesystem: true
+            capabilities:
+              drop:
+                - "ALL"
+          {{ "{{- with $job.resources }}" }}
+          resources:
+            {{ "{{- toYaml . | nindent 12 }}" }}
+          {{ "{{- end }}" }}
+          volumeMounts:
+            - name: crds
+              mountPath: /crds
+              readOnly: true
+      volumes:
+        - name: crds
+          configMap:
+            name: {{ "{{ include \"chart.fullname\" . }}" }}-crd-upgrade
+{{ "{{- end }}" }}
+`
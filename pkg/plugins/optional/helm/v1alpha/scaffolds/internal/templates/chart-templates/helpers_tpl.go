@@ -27,7 +27,6 @@ var _ machinery.Template = &HelmHelpers{}
 // HelmHelpers scaffolds the _helpers.tpl file for Helm charts
 type HelmHelpers struct {
 	machinery.TemplateMixin
-	machinery.ProjectNameMixin
   ChartDir string
 }
 
@@ -44,19 +43,73 @@ func (f *HelmHelpers) SetTemplateDefaults() error {
 	return nil
 }
 
-const helmHelpersTemplate = `{{` + "`" + `{{- define "chart.name" -}}` + "`" + `}}
-{{` + "`" + `{{- if .Chart }}` + "`" + `}}
-  {{` + "`" + `{{- if .Chart.Name }}` + "`" + `}}
-    {{` + "`" + `{{- .Chart.Name | trunc 63 | trimSuffix "-" }}` + "`" + `}}
-  {{` + "`" + `{{- else if .Values.nameOverride }}` + "`" + `}}
-    {{` + "`" + `{{ .Values.nameOverride | trunc 63 | trimSuffix "-" }}` + "`" + `}}
-  {{` + "`" + `{{- else }}` + "`" + `}}
-    {{ .ProjectName }}
-  {{` + "`" + `{{- end }}` + "`" + `}}
+//nolint:lll
+const helmHelpersTemplate = `{{/*
+Name of the chart, which can be overridden with nameOverride.
+*/}}
+{{` + "`" + `{{- define "chart.name" -}}` + "`" + `}}
+{{` + "`" + `{{- default .Chart.Name .Values.nameOverride | trunc 63 | trimSuffix "-" }}` + "`" + `}}
+{{` + "`" + `{{- end }}` + "`" + `}}
+
+{{/*
+Fully qualified name of the release, used as the prefix of the names of the resources so that
+several releases of the chart can be installed in the same cluster. It is the release name when
+it contains the chart name and can be overridden with fullnameOverride.
+*/}}
+{{` + "`" + `{{- define "chart.fullname" -}}` + "`" + `}}
+{{` + "`" + `{{- if .Values.fullnameOverride }}` + "`" + `}}
+{{` + "`" + `{{- .Values.fullnameOverride | trunc 63 | trimSuffix "-" }}` + "`" + `}}
+{{` + "`" + `{{- else }}` + "`" + `}}
+{{` + "`" + `{{- $name := default .Chart.Name .Values.nameOverride }}` + "`" + `}}
+{{` + "`" + `{{- if contains $name .Release.Name }}` + "`" + `}}
+{{` + "`" + `{{- .Release.Name | trunc 63 | trimSuffix "-" }}` + "`" + `}}
 {{` + "`" + `{{- else }}` + "`" + `}}
-  {{ .ProjectName }}
+{{` + "`" + `{{- printf "%s-%s" .Release.Name $name | trunc 63 | trimSuffix "-" }}` + "`" + `}}
 {{` + "`" + `{{- end }}` + "`" + `}}
 {{` + "`" + `{{- end }}` + "`" + `}}
+{{` + "`" + `{{- end }}` + "`" + `}}
+
+{{/*
+Name of the ServiceAccount of the manager.
+*/}}
+{{` + "`" + `{{- define "chart.serviceAccountName" -}}` + "`" + `}}
+{{` + "`" + `{{- default (printf "%s-controller-manager" (include "chart.fullname" .)) .Values.controllerManager.serviceAccountName }}` + "`" + `}}
+{{` + "`" + `{{- end }}` + "`" + `}}
+
+{{/*
+Name of the Service of the webhooks, which can be overridden with webhook.service.name.
+*/}}
+{{` + "`" + `{{- define "chart.webhookServiceName" -}}` + "`" + `}}
+{{` + "`" + `{{- dig "service" "name" "" (.Values.webhook | default dict) | default (printf "%s-webhook-service" (include "chart.fullname" .)) | trunc 63 | trimSuffix "-" }}` + "`" + `}}
+{{` + "`" + `{{- end }}` + "`" + `}}
+
+{{/*
+Port of the Service of the webhooks.
+*/}}
+{{` + "`" + `{{- define "chart.webhookServicePort" -}}` + "`" + `}}
+{{` + "`" + `{{- dig "service" "port" "" (.Values.webhook | default dict) | default 443 }}` + "`" + `}}
+{{` + "`" + `{{- end }}` + "`" + `}}
+
+{{/*
+Port on which the manager serves the webhooks, the target port of the Service of the webhooks.
+*/}}
+{{` + "`" + `{{- define "chart.webhookPort" -}}` + "`" + `}}
+{{` + "`" + `{{- (.Values.webhook | default dict).port | default 9443 }}` + "`" + `}}
+{{` + "`" + `{{- end }}` + "`" + `}}
+
+{{/*
+Name of the Secret of the serving certificate of the webhooks, issued by cert-manager and mounted by the manager.
+*/}}
+{{` + "`" + `{{- define "chart.webhookCertSecretName" -}}` + "`" + `}}
+{{` + "`" + `{{- (.Values.webhook | default dict).certSecretName | default "webhook-server-cert" }}` + "`" + `}}
+{{` + "`" + `{{- end }}` + "`" + `}}
+
+{{/*
+Name of the Service of the metrics, which can be overridden with metrics.service.name.
+*/}}
+{{` + "`" + `{{- define "chart.metricsServiceName" -}}` + "`" + `}}
+{{` + "`" + `{{- dig "service" "name" "" (.Values.metrics | default dict) | default (printf "%s-controller-manager-metrics-service" (include "chart.fullname" .)) | trunc 63 | trimSuffix "-" }}` + "`" + `}}
+{{` + "`" + `{{- end }}` + "`" + `}}
 
 {{/*
 Common labels for the chart.
@@ -102,4 +155,26 @@ Helper to check if validating webhooks exist in the services.
     {{` + "`" + `$hasValidating = true }}{{- end }}` + "`" + `}}
 {{` + "`" + `{{- end }}` + "`" + `}}
 {{` + "`" + `{{ $hasValidating }}}}{{- end }}` + "`" + `}}
+
+{{/*
+Whether the cert-manager resources are rendered and used: cert-manager is enabled and the cluster serves its
+Certificate API, so that the installation does not fail on the clusters without cert-manager. The check of the
+API is skipped with certmanager.force, e.g. for helm template, which does not know the APIs of the cluster
+unless they are given with --api-versions.
+*/}}
+{{` + "`" + `{{- define "chart.certManagerEnabled" -}}` + "`" + `}}
+{{` + "`" + `{{- if and .Values.certmanager.enable (or .Values.certmanager.force (.Capabilities.APIVersions.Has "cert-manager.io/v1/Certificate")) -}}` + "`" + `}}
+true
+{{` + "`" + `{{- end }}` + "`" + `}}
+{{` + "`" + `{{- end }}` + "`" + `}}
+
+{{/*
+Whether the ServiceMonitor is rendered: Prometheus is enabled and the cluster serves the ServiceMonitor API of
+the Prometheus operator. The check of the API is skipped with prometheus.force, e.g. for helm template.
+*/}}
+{{` + "`" + `{{- define "chart.prometheusEnabled" -}}` + "`" + `}}
+{{` + "`" + `{{- if and .Values.prometheus.enable (or .Values.prometheus.force (.Capabilities.APIVersions.Has "monitoring.coreos.com/v1/ServiceMonitor")) -}}` + "`" + `}}
+true
+{{` + "`" + `{{- end }}` + "`" + `}}
+{{` + "`" + `{{- end }}` + "`" + `}}
 `
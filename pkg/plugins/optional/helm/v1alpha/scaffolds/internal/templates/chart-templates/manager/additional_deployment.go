@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &AdditionalDeployment{}
+
+// AdditionalDeployment scaffolds the Deployment of an additional manager of the project for the Helm chart
+type AdditionalDeployment struct {
+	machinery.TemplateMixin
+
+	// Name is the name of the additional manager, which is also the name of its binary
+	Name string
+
+	ChartDir string
+}
+
+// SetTemplateDefaults sets the default template configuration
+func (f *AdditionalDeployment) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "chart", "templates", "manager", f.Name+".yaml")
+	}
+
+	f.TemplateBody = additionalDeploymentTemplate
+
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+//nolint:lll
+const additionalDeploymentTemplate = `{{ "{{- $manager := index (.Values.managers | default dict) \"" }}{{ .Name }}{{ "\" }}" }}
+{{ "{{- if $manager }}" }}
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ "{{ include \"chart.fullname\" . }}" }}-{{ .Name }}
+  namespace: {{ "{{ .Release.Namespace }}" }}
+  labels:
+    {{ "{{- include \"chart.labels\" . | nindent 4 }}" }}
+    control-plane: {{ .Name }}
+spec:
+  replicas: {{ "{{ $manager.replicas }}" }}
+  selector:
+    matchLabels:
+      {{ "{{- include \"chart.selectorLabels\" . | nindent 6 }}" }}
+      control-plane: {{ .Name }}
+  template:
+    metadata:
+      annotations:
+        kubectl.kubernetes.io/default-container: manager
+      labels:
+        {{ "{{- include \"chart.labels\" . | nindent 8 }}" }}
+        control-plane: {{ .Name }}
+    spec:
+      containers:
+        - name: manager
+          args:
+            {{ "{{- if .Values.controllerManager.leaderElection.enabled }}" }}
+            - --leader-elect
+            {{ "{{- end }}" }}
+            - --health-probe-bind-address=:{{ "{{ .Values.controllerManager.healthProbe.port }}" }}
+            {{ "{{- range $manager.args }}" }}
+            - {{ "{{ . }}" }}
+            {{ "{{- end }}" }}
+          command:
+            - /{{ .Name }}
+          image: {{ "{{ .Values.controllerManager.container.image.repository }}" }}:{{ "{{ .Values.controllerManager.container.image.tag }}" }}
+          {{ "{{- if .Values.controllerManager.container.env }}" }}
+          env:
+            {{ "{{- range $key, $value := .Values.controllerManager.container.env }}" }}
+            - name: {{ "{{ $key }}" }}
+              value: {{ "{{ $value }}" }}
+            {{ "{{- end }}" }}
+          {{ "{{- end }}" }}
+          livenessProbe:
+            {{ "{{- toYaml .Values.controllerManager.container.livenessProbe | nindent 12 }}" }}
+          readinessProbe:
+            {{ "{{- toYaml .Values.controllerManager.container.readinessProbe | nindent 12 }}" }}
+          ports:
+            - containerPort: {{ "{{ .Values.controllerManager.healthProbe.port }}" }}
+              name: health
+              protocol: TCP
+          resources:
+            {{ "{{- toYaml .Values.controllerManager.container.resources | nindent 12 }}" }}
+          securityContext:
+            {{ "{{- $containerSecurityContext := deepCopy (.Values.controllerManager.container.securityContext | default dict) }}" }}
+            {{ "{{- if .Values.controllerManager.restrictedSecurityContext }}" }}
+            {{ "{{- $_ := set $containerSecurityContext \"allowPrivilegeEscalation\" false }}" }}
+            {{ "{{- $capabilities := deepCopy ($containerSecurityContext.capabilities | default dict) }}" }}
+            {{ "{{- $_ := set $capabilities \"drop\" (list \"ALL\") }}" }}
+            {{ "{{- $_ := set $containerSecurityContext \"capabilities\" $capabilities }}" }}
+            {{ "{{- end }}" }}
+            {{ "{{- toYaml $containerSecurityContext | nindent 12 }}" }}
+      securityContext:
+        {{ "{{- $podSecurityContext := deepCopy (.Values.controllerManager.securityContext | default dict) }}" }}
+        {{ "{{- if .Values.controllerManager.restrictedSecurityContext }}" }}
+        {{ "{{- $_ := set $podSecurityContext \"runAsNonRoot\" true }}" }}
+        {{ "{{- if not $podSecurityContext.seccompProfile }}" }}
+        {{ "{{- $_ := set $podSecurityContext \"seccompProfile\" (dict \"type\" \"RuntimeDefault\") }}" }}
+        {{ "{{- end }}" }}
+        {{ "{{- end }}" }}
+        {{ "{{- toYaml $podSecurityContext | nindent 8 }}" }}
+      serviceAccountName: {{ "{{ include \"chart.serviceAccountName\" . }}" }}
+      terminationGracePeriodSeconds: {{ "{{ .Values.controllerManager.terminationGracePeriodSeconds }}" }}
+{{ "{{- end }}" }}
+`
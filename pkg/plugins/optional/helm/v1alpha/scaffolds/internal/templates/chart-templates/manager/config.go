@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &Config{}
+
+// Config scaffolds the ConfigMap or the Secret holding the configuration file of the manager
+type Config struct {
+	machinery.TemplateMixin
+
+	ChartDir string
+}
+
+// SetTemplateDefaults sets the default template configuration
+func (f *Config) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "chart", "templates", "manager", "config.yaml")
+	}
+
+	f.TemplateBody = managerConfigTemplate
+
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+const managerConfigTemplate = `{{ "{{- $config := .Values.controllerManager.config }}" }}
+{{ "{{- if and $config $config.enabled }}" }}
+apiVersion: v1
+{{ "{{- if $config.secret }}" }}
+kind: Secret
+{{ "{{- else }}" }}
+kind: ConfigMap
+{{ "{{- end }}" }}
+metadata:
+  name: {{ "{{ include \"chart.fullname\" . }}" }}-manager-config
+  namespace: {{ "{{ .Release.Namespace }}" }}
+  labels:
+    {{ "{{- include \"chart.labels\" . | nindent 4 }}" }}
+{{ "{{- if $config.secret }}" }}
+stringData:
+{{ "{{- else }}" }}
+data:
+{{ "{{- end }}" }}
+  {{ "{{ $config.fileName }}" }}: |
+    {{ "{{- if kindIs \"string\" $config.content }}" }}
+    {{ "{{- $config.content | nindent 4 }}" }}
+    {{ "{{- else }}" }}
+    {{ "{{- toYaml $config.content | nindent 4 }}" }}
+    {{ "{{- end }}" }}
+{{ "{{- end }}" }}
+`
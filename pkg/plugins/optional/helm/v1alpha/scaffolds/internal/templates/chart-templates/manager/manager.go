@@ -27,9 +27,8 @@ var _ machinery.Template = &Deployment{}
 // Deployment scaffolds the manager Deployment for the Helm chart
 type Deployment struct {
 	machinery.TemplateMixin
-	machinery.ProjectNameMixin
 
-	// DeployImages if true will scaffold the env with the images
+	// DeployImages if true will scaffold the env with the Operands of the DeployImage plugin
 	DeployImages bool
 	// Force if true allow overwrite the scaffolded file
 	Force bool
@@ -57,10 +56,11 @@ func (f *Deployment) SetTemplateDefaults() error {
 }
 
 //nolint:lll
-const managerDeploymentTemplate = `apiVersion: apps/v1
+const managerDeploymentTemplate = `{{ "{{- $kubeRbacProxy := and .Values.metrics.enable .Values.metrics.secure (eq (dig \"auth\" \"filter\" .Values.metrics) \"kube-rbac-proxy\") }}" }}
+apiVersion: apps/v1
 kind: Deployment
 metadata:
-  name: {{ .ProjectName }}-controller-manager
+  name: {{ "{{ include \"chart.fullname\" . }}" }}-controller-manager
   namespace: {{ "{{ .Release.Namespace }}" }}
   labels:
     {{ "{{- include \"chart.labels\" . | nindent 4 }}" }}
@@ -75,6 +75,9 @@ spec:
     metadata:
       annotations:
         kubectl.kubernetes.io/default-container: manager
+        {{ "{{- if .Values.controllerManager.config.enabled }}" }}
+        checksum/config: {{ "{{ include (print $.Template.BasePath \"/manager/config.yaml\") . | sha256sum }}" }}
+        {{ "{{- end }}" }}
       labels:
         {{ "{{- include \"chart.labels\" . | nindent 8 }}" }}
         control-plane: controller-manager
@@ -87,64 +90,161 @@ spec:
       containers:
         - name: manager
           args:
+            {{ "{{- if .Values.controllerManager.leaderElection.enabled }}" }}
+            - --leader-elect
+            {{ "{{- end }}" }}
+            {{ "{{- if $kubeRbacProxy }}" }}
+            - --metrics-bind-address=127.0.0.1:{{ "{{ dig \"kubeRbacProxy\" \"upstreamPort\" 8080 .Values.metrics }}" }}
+            - --metrics-secure=false
+            {{ "{{- else if .Values.metrics.enable }}" }}
+            - --metrics-bind-address=:{{ "{{ .Values.metrics.port }}" }}
+            - --metrics-secure={{ "{{ .Values.metrics.secure }}" }}
+            {{ "{{- end }}" }}
+            - --health-probe-bind-address=:{{ "{{ .Values.controllerManager.healthProbe.port }}" }}
+{{- if .HasWebhooks }}
+            {{ "{{- if and .Values.webhook.enable (ne (int (include \"chart.webhookPort\" .)) 9443) }}" }}
+            - --webhook-port={{ "{{ include \"chart.webhookPort\" . }}" }}
+            {{ "{{- end }}" }}
+{{- end }}
             {{ "{{- range .Values.controllerManager.container.args }}" }}
             - {{ "{{ . }}" }}
             {{ "{{- end }}" }}
           command:
             - /manager
           image: {{ "{{ .Values.controllerManager.container.image.repository }}" }}:{{ "{{ .Values.controllerManager.container.image.tag }}" }}
+          {{- if .DeployImages }}
+          {{ "{{- if or .Values.controllerManager.container.env .Values.controllerManager.deployImages }}" }}
+          {{- else }}
           {{ "{{- if .Values.controllerManager.container.env }}" }}
+          {{- end }}
           env:
             {{ "{{- range $key, $value := .Values.controllerManager.container.env }}" }}
             - name: {{ "{{ $key }}" }}
               value: {{ "{{ $value }}" }}
             {{ "{{- end }}" }}
+            {{- if .DeployImages }}
+            {{ "{{- range $kind, $operand := .Values.controllerManager.deployImages }}" }}
+            - name: {{ "{{ upper $kind }}" }}_IMAGE
+              value: {{ "{{ $operand.image.repository }}{{ with $operand.image.tag }}:{{ . }}{{ end }}" }}
+            {{ "{{- with $operand.image.pullPolicy }}" }}
+            - name: {{ "{{ upper $kind }}" }}_IMAGE_PULL_POLICY
+              value: {{ "{{ . }}" }}
+            {{ "{{- end }}" }}
+            {{ "{{- with $operand.containerPort }}" }}
+            - name: {{ "{{ upper $kind }}" }}_CONTAINER_PORT
+              value: {{ "{{ . | quote }}" }}
+            {{ "{{- end }}" }}
+            {{ "{{- with $operand.runAsUser }}" }}
+            - name: {{ "{{ upper $kind }}" }}_RUN_AS_USER
+              value: {{ "{{ . | quote }}" }}
+            {{ "{{- end }}" }}
+            {{ "{{- end }}" }}
+            {{- end }}
           {{ "{{- end }}" }}
           livenessProbe:
             {{ "{{- toYaml .Values.controllerManager.container.livenessProbe | nindent 12 }}" }}
           readinessProbe:
             {{ "{{- toYaml .Values.controllerManager.container.readinessProbe | nindent 12 }}" }}
-{{- if .HasWebhooks }}
-          {{ "{{- if .Values.webhook.enable }}" }}
           ports:
-            - containerPort: 9443
+            - containerPort: {{ "{{ .Values.controllerManager.healthProbe.port }}" }}
+              name: health
+              protocol: TCP
+{{- if .HasWebhooks }}
+            {{ "{{- if .Values.webhook.enable }}" }}
+            - containerPort: {{ "{{ include \"chart.webhookPort\" . }}" }}
               name: webhook-server
               protocol: TCP
-          {{ "{{- end }}" }}
+            {{ "{{- end }}" }}
 {{- end }}
           resources:
             {{ "{{- toYaml .Values.controllerManager.container.resources | nindent 12 }}" }}
           securityContext:
-            {{ "{{- toYaml .Values.controllerManager.container.securityContext | nindent 12 }}" }}
-          {{ "{{- if and .Values.certmanager.enable (or .Values.webhook.enable .Values.metrics.enable) }}" }}
+            {{ "{{- $containerSecurityContext := deepCopy (.Values.controllerManager.container.securityContext | default dict) }}" }}
+            {{ "{{- if .Values.controllerManager.restrictedSecurityContext }}" }}
+            {{ "{{- $_ := set $containerSecurityContext \"allowPrivilegeEscalation\" false }}" }}
+            {{ "{{- $capabilities := deepCopy ($containerSecurityContext.capabilities | default dict) }}" }}
+            {{ "{{- $_ := set $capabilities \"drop\" (list \"ALL\") }}" }}
+            {{ "{{- $_ := set $containerSecurityContext \"capabilities\" $capabilities }}" }}
+            {{ "{{- end }}" }}
+            {{ "{{- toYaml $containerSecurityContext | nindent 12 }}" }}
+          {{ "{{- if or .Values.controllerManager.config.enabled (and (include \"chart.certManagerEnabled\" $) (or (.Values.webhook | default dict).enable .Values.metrics.enable)) }}" }}
           volumeMounts:
+            {{ "{{- if .Values.controllerManager.config.enabled }}" }}
+            - name: manager-config
+              mountPath: {{ "{{ .Values.controllerManager.config.mountPath }}" }}
+              readOnly: true
+            {{ "{{- end }}" }}
 {{- if .HasWebhooks }}
-            {{ "{{- if and .Values.webhook.enable .Values.certmanager.enable }}" }}
+            {{ "{{- if and .Values.webhook.enable (include \"chart.certManagerEnabled\" $) }}" }}
             - name: webhook-cert
               mountPath: /tmp/k8s-webhook-server/serving-certs
               readOnly: true
             {{ "{{- end }}" }}
 {{- end }}
-            {{ "{{- if and .Values.metrics.enable .Values.certmanager.enable }}" }}
+            {{ "{{- if and .Values.metrics.enable (include \"chart.certManagerEnabled\" $) }}" }}
             - name: metrics-certs
               mountPath: /tmp/k8s-metrics-server/metrics-certs
               readOnly: true
             {{ "{{- end }}" }}
           {{ "{{- end }}" }}
+        {{ "{{- if $kubeRbacProxy }}" }}
+        - name: kube-rbac-proxy
+          args:
+            - --secure-listen-address=0.0.0.0:{{ "{{ .Values.metrics.port }}" }}
+            - --upstream=http://127.0.0.1:{{ "{{ dig \"kubeRbacProxy\" \"upstreamPort\" 8080 .Values.metrics }}" }}/
+            - --logtostderr=true
+            - --v=0
+            {{ "{{- if include \"chart.certManagerEnabled\" $ }}" }}
+            - --tls-cert-file=/tmp/k8s-metrics-server/metrics-certs/tls.crt
+            - --tls-private-key-file=/tmp/k8s-metrics-server/metrics-certs/tls.key
+            {{ "{{- end }}" }}
+          image: {{ "{{ dig \"kubeRbacProxy\" \"image\" \"repository\" \"quay.io/brancz/kube-rbac-proxy\" .Values.metrics }}" }}:{{ "{{ dig \"kubeRbacProxy\" \"image\" \"tag\" \"v0.18.2\" .Values.metrics }}" }}
+          ports:
+            - containerPort: {{ "{{ .Values.metrics.port }}" }}
+              name: https
+              protocol: TCP
+          resources:
+            {{ "{{- toYaml (dig \"kubeRbacProxy\" \"resources\" (dict) .Values.metrics) | nindent 12 }}" }}
+          securityContext:
+            {{ "{{- toYaml $containerSecurityContext | nindent 12 }}" }}
+          {{ "{{- if include \"chart.certManagerEnabled\" $ }}" }}
+          volumeMounts:
+            - name: metrics-certs
+              mountPath: /tmp/k8s-metrics-server/metrics-certs
+              readOnly: true
+          {{ "{{- end }}" }}
+        {{ "{{- end }}" }}
       securityContext:
-        {{ "{{- toYaml .Values.controllerManager.securityContext | nindent 8 }}" }}
-      serviceAccountName: {{ "{{ .Values.controllerManager.serviceAccountName }}" }}
+        {{ "{{- $podSecurityContext := deepCopy (.Values.controllerManager.securityContext | default dict) }}" }}
+        {{ "{{- if .Values.controllerManager.restrictedSecurityContext }}" }}
+        {{ "{{- $_ := set $podSecurityContext \"runAsNonRoot\" true }}" }}
+        {{ "{{- if not $podSecurityContext.seccompProfile }}" }}
+        {{ "{{- $_ := set $podSecurityContext \"seccompProfile\" (dict \"type\" \"RuntimeDefault\") }}" }}
+        {{ "{{- end }}" }}
+        {{ "{{- end }}" }}
+        {{ "{{- toYaml $podSecurityContext | nindent 8 }}" }}
+      serviceAccountName: {{ "{{ include \"chart.serviceAccountName\" . }}" }}
       terminationGracePeriodSeconds: {{ "{{ .Values.controllerManager.terminationGracePeriodSeconds }}" }}
-      {{ "{{- if and .Values.certmanager.enable (or .Values.webhook.enable .Values.metrics.enable) }}" }}
+      {{ "{{- if or .Values.controllerManager.config.enabled (and (include \"chart.certManagerEnabled\" $) (or (.Values.webhook | default dict).enable .Values.metrics.enable)) }}" }}
       volumes:
+        {{ "{{- if .Values.controllerManager.config.enabled }}" }}
+        - name: manager-config
+          {{ "{{- if .Values.controllerManager.config.secret }}" }}
+          secret:
+            secretName: {{ "{{ include \"chart.fullname\" . }}" }}-manager-config
+          {{ "{{- else }}" }}
+          configMap:
+            name: {{ "{{ include \"chart.fullname\" . }}" }}-manager-config
+          {{ "{{- end }}" }}
+        {{ "{{- end }}" }}
 {{- if .HasWebhooks }}
-        {{ "{{- if and .Values.webhook.enable .Values.certmanager.enable }}" }}
+        {{ "{{- if and .Values.webhook.enable (include \"chart.certManagerEnabled\" $) }}" }}
         - name: webhook-cert
           secret:
-            secretName: webhook-server-cert
+            secretName: {{ "{{ include \"chart.webhookCertSecretName\" . }}" }}
         {{ "{{- end }}" }}
 {{- end }}
-        {{ "{{- if and .Values.metrics.enable .Values.certmanager.enable }}" }}
+        {{ "{{- if and .Values.metrics.enable (include \"chart.certManagerEnabled\" $) }}" }}
         - name: metrics-certs
           secret:
             secretName: metrics-server-cert
@@ -26,7 +26,6 @@ var _ machinery.Template = &Service{}
 // Service scaffolds the Service for metrics in the Helm chart
 type Service struct {
 	machinery.TemplateMixin
-	machinery.ProjectNameMixin
 	ChartDir string
 }
 
@@ -43,20 +42,26 @@ func (f *Service) SetTemplateDefaults() error {
 	return nil
 }
 
+//nolint:lll
 const metricsServiceTemplate = `{{` + "`" + `{{- if .Values.metrics.enable }}` + "`" + `}}
 apiVersion: v1
 kind: Service
 metadata:
-  name: {{ .ProjectName }}-controller-manager-metrics-service
+  name: {{ "{{ include \"chart.metricsServiceName\" . }}" }}
   namespace: {{ "{{ .Release.Namespace }}" }}
   labels:
     {{ "{{- include \"chart.labels\" . | nindent 4 }}" }}
 spec:
+  type: {{ "{{ dig \"service\" \"type\" \"ClusterIP\" .Values.metrics }}" }}
   ports:
-    - port: 8443
-      targetPort: 8443
+    - port: {{ "{{ dig \"service\" \"port\" \"\" .Values.metrics | default .Values.metrics.port | default 8443 }}" }}
+      targetPort: {{ "{{ .Values.metrics.port | default 8443 }}" }}
       protocol: TCP
+      {{ "{{- if or (not (hasKey .Values.metrics \"secure\")) .Values.metrics.secure }}" }}
       name: https
+      {{ "{{- else }}" }}
+      name: http
+      {{ "{{- end }}" }}
   selector:
     control-plane: controller-manager
 {{` + "`" + `{{- end }}` + "`" + `}}
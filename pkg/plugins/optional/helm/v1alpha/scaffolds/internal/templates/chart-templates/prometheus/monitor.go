@@ -26,7 +26,6 @@ var _ machinery.Template = &Monitor{}
 // Monitor scaffolds the ServiceMonitor for Prometheus in the Helm chart
 type Monitor struct {
 	machinery.TemplateMixin
-	machinery.ProjectNameMixin
 	ChartDir string
 }
 
@@ -43,24 +42,26 @@ func (f *Monitor) SetTemplateDefaults() error {
 	return nil
 }
 
+//nolint:lll
 const monitorTemplate = `# To integrate with Prometheus.
-{{ "{{- if .Values.prometheus.enable }}" }}
+{{ "{{- if include \"chart.prometheusEnabled\" . }}" }}
 apiVersion: monitoring.coreos.com/v1
 kind: ServiceMonitor
 metadata:
   labels:
     {{ "{{- include \"chart.labels\" . | nindent 4 }}" }}
-  name: {{ .ProjectName }}-controller-manager-metrics-monitor
+  name: {{ "{{ include \"chart.fullname\" . }}" }}-controller-manager-metrics-monitor
   namespace: {{ "{{ .Release.Namespace }}" }}
 spec:
   endpoints:
     - path: /metrics
+      {{ "{{- if or (not (hasKey .Values.metrics \"secure\")) .Values.metrics.secure }}" }}
       port: https
       scheme: https
       bearerTokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token
       tlsConfig:
-        {{ "{{- if .Values.certmanager.enable }}" }}
-        serverName: {{ .ProjectName }}-controller-manager-metrics-service.{{ "{{ .Release.Namespace }}" }}.svc
+        {{ "{{- if include \"chart.certManagerEnabled\" . }}" }}
+        serverName: {{ "{{ include \"chart.metricsServiceName\" . }}" }}.{{ "{{ .Release.Namespace }}" }}.svc
         # Apply secure TLS configuration with cert-manager
         insecureSkipVerify: false
         ca:
@@ -78,6 +79,10 @@ spec:
         # Development/Test mode (insecure configuration)
         insecureSkipVerify: true
         {{ "{{- end }}" }}
+      {{ "{{- else }}" }}
+      port: http
+      scheme: http
+      {{ "{{- end }}" }}
   selector:
     matchLabels:
       control-plane: controller-manager
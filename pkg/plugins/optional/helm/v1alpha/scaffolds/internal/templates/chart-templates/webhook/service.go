@@ -26,7 +26,6 @@ var _ machinery.Template = &Service{}
 // Service scaffolds the Service for webhooks in the Helm chart
 type Service struct {
 	machinery.TemplateMixin
-	machinery.ProjectNameMixin
 
 	// Force if true allows overwriting the scaffolded file
 	Force bool
@@ -51,15 +50,15 @@ const webhookServiceTemplate = `{{` + "`" + `{{- if .Values.webhook.enable }}` +
 apiVersion: v1
 kind: Service
 metadata:
-  name: {{ .ProjectName }}-webhook-service
+  name: {{ "{{ include \"chart.webhookServiceName\" . }}" }}
   namespace: {{ "{{ .Release.Namespace }}" }}
   labels:
     {{ "{{- include \"chart.labels\" . | nindent 4 }}" }}
 spec:
   ports:
-    - port: 443
+    - port: {{ "{{ include \"chart.webhookServicePort\" . }}" }}
       protocol: TCP
-      targetPort: 9443
+      targetPort: {{ "{{ include \"chart.webhookPort\" . }}" }}
   selector:
     control-plane: controller-manager
 {{` + "`" + `{{- end }}` + "`" + `}}
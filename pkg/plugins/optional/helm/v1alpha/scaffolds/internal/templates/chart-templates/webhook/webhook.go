@@ -26,7 +26,6 @@ var _ machinery.Template = &Template{}
 // Template scaffolds both MutatingWebhookConfiguration and ValidatingWebhookConfiguration for the Helm chart
 type Template struct {
 	machinery.TemplateMixin
-	machinery.ProjectNameMixin
 
 	MutatingWebhooks   []DataWebhook
 	ValidatingWebhooks []DataWebhook
@@ -71,10 +70,10 @@ const webhookTemplate = `{{` + "`" + `{{- if .Values.webhook.enable }}` + "`" +
 apiVersion: admissionregistration.k8s.io/v1
 kind: MutatingWebhookConfiguration
 metadata:
-  name: {{ .ProjectName }}-mutating-webhook-configuration
+  name: {{ "{{ include \"chart.fullname\" . }}" }}-mutating-webhook-configuration
   namespace: {{ "{{ .Release.Namespace }}" }}
   annotations:
-    {{` + "`" + `{{- if .Values.certmanager.enable }}` + "`" + `}}
+    {{` + "`" + `{{- if include "chart.certManagerEnabled" $ }}` + "`" + `}}
     cert-manager.io/inject-ca-from: "{{` + "`" + `{{ $.Release.Namespace }}` + "`" + `}}/serving-cert"
     {{` + "`" + `{{- end }}` + "`" + `}}
   labels:
@@ -87,6 +86,7 @@ webhooks:
         name: {{ .ServiceName }}
         namespace: {{ "{{ .Release.Namespace }}" }}
         path: {{ .Path }}
+        port: {{ "{{ include \"chart.webhookServicePort\" . }}" }}
     failurePolicy: {{ .FailurePolicy }}
     sideEffects: {{ .SideEffects }}
     admissionReviewVersions:
@@ -121,10 +121,10 @@ webhooks:
 apiVersion: admissionregistration.k8s.io/v1
 kind: ValidatingWebhookConfiguration
 metadata:
-  name: {{ .ProjectName }}-validating-webhook-configuration
+  name: {{ "{{ include \"chart.fullname\" . }}" }}-validating-webhook-configuration
   namespace: {{ "{{ .Release.Namespace }}" }}
   annotations:
-    {{` + "`" + `{{- if .Values.certmanager.enable }}` + "`" + `}}
+    {{` + "`" + `{{- if include "chart.certManagerEnabled" $ }}` + "`" + `}}
     cert-manager.io/inject-ca-from: "{{` + "`" + `{{ $.Release.Namespace }}` + "`" + `}}/serving-cert"
     {{` + "`" + `{{- end }}` + "`" + `}}
   labels:
@@ -137,6 +137,7 @@ webhooks:
         name: {{ .ServiceName }}
         namespace: {{ "{{ .Release.Namespace }}" }}
         path: {{ .Path }}
+        port: {{ "{{ include \"chart.webhookServicePort\" . }}" }}
     failurePolicy: {{ .FailurePolicy }}
     sideEffects: {{ .SideEffects }}
     admissionReviewVersions:
@@ -28,6 +28,10 @@ var _ machinery.Template = &HelmChart{}
 type HelmChart struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+
+	// CRDsOnly is true when the chart only distributes the CRDs of the project
+	CRDsOnly bool
+
 	ChartDir string
 }
 
@@ -45,8 +49,13 @@ func (f *HelmChart) SetTemplateDefaults() error {
 }
 
 const helmChartTemplate = `apiVersion: v2
+{{- if .CRDsOnly }}
+name: {{ .ProjectName }}-crds
+description: A Helm chart to distribute the CRDs of the project {{ .ProjectName }}
+{{- else }}
 name: {{ .ProjectName }}
 description: A Helm chart to distribute the project {{ .ProjectName }}
+{{- end }}
 type: application
 version: 0.1.0
 appVersion: "0.1.0"
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &HelmChartRelease{}
+
+// HelmChartRelease scaffolds the GitHub Action which publishes the Helm chart with chart-releaser
+type HelmChartRelease struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+	ChartDir string
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *HelmChartRelease) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(".github", "workflows", "release-chart.yml")
+	}
+
+	// Set the ChartDir to "dist" if it's empty
+	if f.ChartDir == "" {
+		f.ChartDir = "dist"
+	}
+
+	f.TemplateBody = releaseChartTemplate
+	f.IfExistsAction = machinery.SkipFile
+
+	return nil
+}
+
+const releaseChartTemplate = `name: Release Chart
+
+# The chart is released when its version is bumped in {{ .ChartDir }}/chart/Chart.yaml, e.g. with:
+#   kubebuilder edit --plugins=helm/v1-alpha --bump-chart-version=patch
+# The packaged chart is attached to a GitHub release and indexed in the index.yaml
+# of the gh-pages branch, which must exist and be served by GitHub Pages.
+on:
+  push:
+    branches:
+      - main
+    paths:
+      - '{{ .ChartDir }}/chart/**'
+  workflow_dispatch:
+
+jobs:
+  release:
+    name: Release the Helm chart
+    runs-on: ubuntu-latest
+    permissions:
+      contents: write
+    steps:
+      - name: Clone the code
+        uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+
+      - name: Configure Git
+        run: |
+          git config user.name "$GITHUB_ACTOR"
+          git config user.email "$GITHUB_ACTOR@users.noreply.github.com"
+
+      - name: Install Helm
+        uses: azure/setup-helm@v4
+
+      - name: Lint Helm Chart
+        run: |
+          helm lint ./{{ .ChartDir }}/chart
+
+      - name: Release the chart
+        uses: helm/chart-releaser-action@v1.6.0
+        with:
+          charts_dir: {{ .ChartDir }}
+          skip_existing: true
+        env:
+          CR_TOKEN: {{ "${{ secrets.GITHUB_TOKEN }}" }}
+`
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+const (
+	// ValuesTableStartMarker and ValuesTableEndMarker delimit the table of the values in the README of the chart,
+	// which is regenerated from the values.yaml file
+	ValuesTableStartMarker = "<!-- values-table:start -->"
+	ValuesTableEndMarker   = "<!-- values-table:end -->"
+)
+
+var _ machinery.Template = &HelmReadme{}
+
+// HelmReadme scaffolds the README of the Helm chart
+type HelmReadme struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+
+	// Force if true allows overwriting the scaffolded file
+	Force bool
+	// CRDsOnly is true when the chart only distributes the CRDs of the project
+	CRDsOnly bool
+
+	ChartDir string
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *HelmReadme) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "chart", "README.md")
+	}
+	f.TemplateBody = helmReadmeTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+
+	return nil
+}
+
+const helmReadmeTemplate = `{{- if .CRDsOnly }}# {{ .ProjectName }}-crds
+
+A Helm chart to distribute the CRDs of the project {{ .ProjectName }}. Install it before the chart of
+the manager, scaffolded with ` + "`--skip-crds`" + `, and in the same namespace.
+{{- else }}# {{ .ProjectName }}
+
+A Helm chart to distribute the project {{ .ProjectName }}.
+{{- end }}
+
+## Installing the chart
+
+` + "```sh" + `
+helm install {{ .ProjectName }}{{ if .CRDsOnly }}-crds{{ end }} ./{{ .ChartDir }}/chart \
+  --namespace {{ .ProjectName }}-system \
+  --create-namespace
+` + "```" + `
+
+The values can be customized with ` + "`--set`" + ` or with a values file passed with ` + "`--values`" + `.
+
+## Uninstalling the chart
+
+` + "```sh" + `
+helm uninstall {{ .ProjectName }}{{ if .CRDsOnly }}-crds{{ end }} --namespace {{ .ProjectName }}-system
+` + "```" + `
+
+## Values
+
+The table below is generated from the comments of the ` + "`values.yaml`" + ` file, which follow the
+[helm-docs](https://github.com/norwoodj/helm-docs) format, ` + "`# -- <description>`" + ` above each key.
+It is regenerated when the chart is updated with ` + "`kubebuilder edit --plugins=helm/v1-alpha`" + `.
+
+` + ValuesTableStartMarker + `
+` + ValuesTableEndMarker + `
+`
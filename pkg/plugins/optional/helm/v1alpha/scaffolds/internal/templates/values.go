@@ -26,18 +26,43 @@ var _ machinery.Template = &HelmValues{}
 // HelmValues scaffolds a file that defines the values.yaml structure for the Helm chart
 type HelmValues struct {
 	machinery.TemplateMixin
-	machinery.ProjectNameMixin
+	machinery.DomainMixin
 
-	// DeployImages stores the images used for the DeployImage plugin
-	DeployImages map[string]string
+	// DeployImages stores the Operands of the APIs created with the DeployImage plugin, by lowercase kind
+	DeployImages map[string]DeployImage
 	// Force if true allows overwriting the scaffolded file
 	Force bool
 	// HasWebhooks is true when webhooks were found in the config
 	HasWebhooks bool
+	// LeaderElection is true when the manager uses leader election
+	LeaderElection bool
+	// ComponentConfig is true when the manager loads its options from a ControllerManagerConfig file
+	ComponentConfig bool
+	// HasExtras is true when manifests of extra config directories are copied to the chart
+	HasExtras bool
+	// Managers are the names of the additional managers of the project
+	Managers []string
+	// SkipCRDs is true when the chart does not distribute the CRDs
+	SkipCRDs bool
+	// CRDsOnly is true when the chart only distributes the CRDs
+	CRDsOnly bool
 
 	ChartDir string
 }
 
+// DeployImage defines the values of the Operand of an API created with the DeployImage plugin
+type DeployImage struct {
+	// Repository and Tag are the image of the Operand
+	Repository string
+	Tag        string
+	// PullPolicy is the pull policy of the image
+	PullPolicy string
+	// ContainerPort is the default port of the container, if informed
+	ContainerPort string
+	// RunAsUser is the user-id used for running the container, if informed
+	RunAsUser string
+}
+
 // SetTemplateDefaults implements machinery.Template
 func (f *HelmValues) SetTemplateDefaults() error {
 	if f.Path == "" {
@@ -54,17 +79,31 @@ func (f *HelmValues) SetTemplateDefaults() error {
 	return nil
 }
 
-const helmValuesTemplate = `# [MANAGER]: Manager Deployment Configurations
+const helmValuesTemplate = `# -- Overrides the name of the chart, used in the labels of the resources
+nameOverride: ""
+# -- Overrides the prefix of the names of the resources, which is the release name, followed by the
+# name of the chart unless the release name contains it
+fullnameOverride: ""
+{{- if not .CRDsOnly }}
+
+# [MANAGER]: Manager Deployment Configurations
 controllerManager:
+  # -- Number of replicas of the manager
   replicas: 1
   container:
+    # -- Image of the manager
     image:
       repository: controller
       tag: latest
+    # -- Additional arguments of the manager, e.g. "--zap-log-level=debug".
+    # The leader election, metrics and health probe arguments are set from their values.
+    {{- if .ComponentConfig }}
     args:
-      - "--leader-elect"
-      - "--metrics-bind-address=:8443"
-      - "--health-probe-bind-address=:8081"
+      - --config=/etc/manager/controller_manager_config.yaml
+    {{- else }}
+    args: []
+    {{- end }}
+    # -- Resources of the manager container
     resources:
       limits:
         cpu: 500m
@@ -72,75 +111,229 @@ controllerManager:
       requests:
         cpu: 10m
         memory: 64Mi
+    # -- Liveness probe of the manager container
     livenessProbe:
       initialDelaySeconds: 15
       periodSeconds: 20
       httpGet:
         path: /healthz
-        port: 8081
+        port: health
+    # -- Readiness probe of the manager container
     readinessProbe:
       initialDelaySeconds: 5
       periodSeconds: 10
       httpGet:
         path: /readyz
-        port: 8081
-    {{- if .DeployImages }}
-    env:
-    {{- range $kind, $image := .DeployImages }}
-      {{ $kind }}_IMAGE: {{ $image }}
-    {{- end }}
-    {{- end }}
+        port: health
+    # -- Additional environment variables of the manager, by name
+    env: {}
+    # -- The securityContext of the manager container, hardened by default
     securityContext:
       allowPrivilegeEscalation: false
+      readOnlyRootFilesystem: true
       capabilities:
         drop:
           - "ALL"
+  # -- The securityContext of the manager pod, which complies with the "restricted" Pod Security Standard.
+  # More info: https://kubernetes.io/docs/concepts/security/pod-security-standards/#restricted
   securityContext:
     runAsNonRoot: true
     seccompProfile:
       type: RuntimeDefault
+  # -- Enforces the settings required by the "restricted" Pod Security Standard over the securityContext
+  # values above: runAsNonRoot, the RuntimeDefault seccompProfile unless another one is set,
+  # allowPrivilegeEscalation disabled and all the capabilities dropped. Set it to false to relax them,
+  # e.g. on clusters enforcing the "baseline" Pod Security Standard with a different seccompProfile.
+  restrictedSecurityContext: true
+  # -- Seconds given to the manager to stop gracefully
   terminationGracePeriodSeconds: 10
-  serviceAccountName: {{ .ProjectName }}-controller-manager
+  # -- Name of the ServiceAccount of the manager, <fullname>-controller-manager when empty
+  serviceAccountName: ""
+  {{- if .DeployImages }}
+  # -- The Operands deployed by the controllers of the APIs created with the deploy-image plugin, by kind.
+  # They are passed to the manager as the <KIND>_IMAGE, <KIND>_IMAGE_PULL_POLICY, <KIND>_CONTAINER_PORT
+  # and <KIND>_RUN_AS_USER environment variables, which override the defaults of the controllers.
+  deployImages:
+  {{- range $kind, $image := .DeployImages }}
+    {{ $kind }}:
+      image:
+        repository: {{ $image.Repository }}
+        tag: {{ printf "%q" $image.Tag }}
+        pullPolicy: {{ $image.PullPolicy }}
+      {{- if $image.ContainerPort }}
+      containerPort: {{ $image.ContainerPort }}
+      {{- end }}
+      {{- if $image.RunAsUser }}
+      runAsUser: {{ $image.RunAsUser }}
+      {{- end }}
+  {{- end }}
+  {{- end }}
+  leaderElection:
+    # -- Ensures that only one replica of the manager reconciles the resources at a time
+    enabled: {{ .LeaderElection }}
+  healthProbe:
+    # -- Port of the health probe endpoints /healthz and /readyz
+    port: 8081
+  # Configuration file of the manager, rendered from the content below, either YAML or a string,
+  # into a ConfigMap, or into a Secret for sensitive settings, and mounted at mountPath/fileName.
+  # Pass it to the manager with an argument, e.g. "--config=/etc/manager/config.yaml".
+  # The manager is rolled out when the configuration changes.
+  config:
+    # -- Renders the configuration file of the manager
+    enabled: {{ .ComponentConfig }}
+    # -- Renders the configuration file into a Secret instead of a ConfigMap
+    secret: false
+    # -- Directory where the configuration file is mounted
+    mountPath: /etc/manager
+    {{- if .ComponentConfig }}
+    # -- Name of the configuration file
+    fileName: controller_manager_config.yaml
+    # -- The options of the ControllerManagerConfig defined in internal/options/config.go. The arguments
+    # of the manager, such as the leader election and health probe ones, take precedence.
+    content:
+      apiVersion: {{ if .Domain }}config.{{ .Domain }}{{ else }}config{{ end }}/v1alpha1
+      kind: ControllerManagerConfig
+    {{- else }}
+    # -- Name of the configuration file
+    fileName: config.yaml
+    # -- Content of the configuration file, either YAML or a string
+    content: {}
+    {{- end }}
+{{- if .Managers }}
+
+# [MANAGERS]: Deployments of the additional managers, which run the controllers assigned to them.
+# They use the image, the resources, the security context and the ServiceAccount of the manager above.
+managers:
+  {{- range .Managers }}
+  {{ . }}:
+    # -- Number of replicas of the {{ . }} manager
+    replicas: 1
+    # -- Additional arguments of the {{ . }} manager
+    args: []
+  {{- end }}
+{{- end }}
 
 # [RBAC]: To enable RBAC (Permissions) configurations
 rbac:
+  # -- Renders the RBAC manifests
   enable: true
+  # -- Aggregates the admin, editor and viewer roles of the namespaced CRDs into the default admin,
+  # edit and view ClusterRoles, so that the users granted these roles in a namespace can manage
+  # the custom resources of that namespace. The roles of the cluster-scoped CRDs are not aggregated.
+  aggregateToDefaultRoles: false
+{{- end }}
+{{- if not .SkipCRDs }}
 
 # [CRDs]: To enable the CRDs
 crd:
-  # This option determines whether the CRDs are included
+  # -- This option determines whether the CRDs are included
   # in the installation process.
   enable: true
 
-  # Enabling this option adds the "helm.sh/resource-policy": keep
+  # -- Enabling this option adds the "helm.sh/resource-policy": keep
   # annotation to the CRD, ensuring it remains installed even when
   # the Helm release is uninstalled.
   # NOTE: Removing the CRDs will also remove all cert-manager CR(s)
   # (Certificates, Issuers, ...) due to garbage collection.
   keep: true
 
+  upgradeJob:
+    # -- Enabling this option applies the CRDs with server-side apply in a
+    # pre-upgrade hook Job, so that they are upgraded before the manager.
+    enable: false
+    # -- Image of the Job, whose entrypoint must be kubectl
+    image:
+      repository: registry.k8s.io/kubectl
+      tag: v1.32.0
+    # -- Number of retries of the Job
+    backoffLimit: 3
+    # -- Resources of the Job container
+    resources: {}
+{{- end }}
+{{- if not .CRDsOnly }}
+
 # [METRICS]: Set to true to generate manifests for exporting metrics.
-# To disable metrics export set false, and ensure that the
-# ControllerManager argument "--metrics-bind-address=:8443" is removed.
+# To disable metrics export set false.
 metrics:
+  # -- Exposes the metrics of the manager through a Service
   enable: true
+  # -- Serves the metrics over HTTPS, with authentication and authorization
+  secure: true
+  # -- Port of the metrics endpoint
+  port: 8443
+  # -- Authenticates and authorizes the requests to the secure metrics endpoint with the
+  # WithAuthenticationAndAuthorization filter of controller-runtime ("filter"), or with a
+  # kube-rbac-proxy sidecar in front of the manager ("kube-rbac-proxy")
+  auth: filter
+  service:
+    # -- Name of the metrics Service, <fullname>-controller-manager-metrics-service if empty
+    name: ""
+    # -- Type of the metrics Service
+    type: ClusterIP
+    # -- Port of the metrics Service, the port of the metrics endpoint if empty
+    port: ""
+  kubeRbacProxy:
+    # -- Image of the kube-rbac-proxy sidecar
+    image:
+      repository: quay.io/brancz/kube-rbac-proxy
+      tag: v0.18.2
+    # -- Port on which the manager serves the metrics to the kube-rbac-proxy sidecar, on localhost
+    upstreamPort: 8080
+    # -- Resources of the kube-rbac-proxy sidecar
+    resources:
+      limits:
+        cpu: 500m
+        memory: 128Mi
+      requests:
+        cpu: 5m
+        memory: 64Mi
+{{- end }}
 {{ if .HasWebhooks }}
 # [WEBHOOKS]: Webhooks configuration
 # The following configuration is automatically generated from the manifests
 # generated by controller-gen. To update run 'make manifests' and
 # the edit command with the '--force' flag
 webhook:
+  # -- Renders the webhook configurations and their Service
   enable: true
+  # -- Port on which the manager serves the webhooks, passed with --webhook-port when it is not 9443
+  port: 9443
+  # -- Name of the Secret of the serving certificate of the webhooks, issued by cert-manager
+  certSecretName: webhook-server-cert
+  service:
+    # -- Name of the webhook Service, <fullname>-webhook-service if empty
+    name: ""
+    # -- Port of the webhook Service
+    port: 443
 {{ end }}
+{{- if not .CRDsOnly }}
 # [PROMETHEUS]: To enable a ServiceMonitor to export metrics to Prometheus set true
 prometheus:
+  # -- Renders a ServiceMonitor to export the metrics to Prometheus
   enable: false
-
+  # -- Renders the ServiceMonitor even if the cluster does not serve the monitoring.coreos.com/v1 API,
+  # e.g. with helm template, which does not know the APIs of the cluster
+  force: false
+{{ end }}
 # [CERT-MANAGER]: To enable cert-manager injection to webhooks set true
 certmanager:
+  # -- Issues the certificates of the webhooks and metrics with cert-manager
   enable: {{ .HasWebhooks }}
+  # -- Renders the cert-manager resources even if the cluster does not serve the cert-manager.io/v1 API,
+  # e.g. with helm template, which does not know the APIs of the cluster
+  force: false
+{{- if not .CRDsOnly }}
 
 # [NETWORK POLICIES]: To enable NetworkPolicies set true
 networkPolicy:
+  # -- Renders the NetworkPolicies of the project
   enable: false
+{{- if .HasExtras }}
+
+# [EXTRAS]: To enable the manifests copied from the extra config directories of the project
+extras:
+  # -- Renders the manifests copied from the extra config directories
+  enable: true
+{{- end }}
+{{- end }}
 `
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin/util"
+)
+
+// Preview is a filesystem which scaffolds the chart in memory over the files of the project, so that
+// the changes can be reviewed before they are written. The removed files are recorded, not removed.
+type Preview struct {
+	afero.Fs
+
+	base    afero.Fs
+	layer   afero.Fs
+	removed map[string]struct{}
+}
+
+// NewPreview returns a Preview over the files of base
+func NewPreview(base afero.Fs) *Preview {
+	layer := afero.NewMemMapFs()
+	return &Preview{
+		Fs:      afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(base), layer),
+		base:    base,
+		layer:   layer,
+		removed: make(map[string]struct{}),
+	}
+}
+
+// Remove records the removal of the file
+func (p *Preview) Remove(name string) error {
+	if _, err := p.Stat(name); err != nil {
+		return err
+	}
+	if err := p.layer.Remove(name); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	p.removed[filepath.Clean(name)] = struct{}{}
+	return nil
+}
+
+// ChartChange is a change of a file previewed before it is written to the project
+type ChartChange struct {
+	// Path is the path of the file in the project
+	Path string
+	// Before and After are the contents of the file before and after the change
+	Before string
+	After  string
+	// Mode is the permissions of the written file
+	Mode os.FileMode
+	// Created and Removed are set when the file is created or removed by the change
+	Created bool
+	Removed bool
+}
+
+// Diff returns the unified diff of the change
+func (c ChartChange) Diff() string {
+	switch {
+	case c.Created:
+		return fmt.Sprintf("create %s\n%s", c.Path, util.UnifiedDiff(c.Path, "", c.After))
+	case c.Removed:
+		return fmt.Sprintf("remove %s\n%s", c.Path, util.UnifiedDiff(c.Path, c.Before, ""))
+	default:
+		return util.UnifiedDiff(c.Path, c.Before, c.After)
+	}
+}
+
+// Changes returns the changes of the files written or removed in the preview, sorted by path.
+// The files written with their current content are not changed.
+func (p *Preview) Changes() ([]ChartChange, error) {
+	var changes []ChartChange
+	err := afero.Walk(p.layer, "", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		after, err := afero.ReadFile(p.layer, path)
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", path, err)
+		}
+		change := ChartChange{Path: filepath.Clean(path), After: string(after), Mode: info.Mode()}
+		before, err := afero.ReadFile(p.base, path)
+		switch {
+		case os.IsNotExist(err):
+			change.Created = true
+		case err != nil:
+			return fmt.Errorf("unable to read %s: %w", path, err)
+		case string(before) == change.After:
+			return nil
+		default:
+			change.Before = string(before)
+		}
+		changes = append(changes, change)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for path := range p.removed {
+		before, err := afero.ReadFile(p.base, path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w", path, err)
+		}
+		changes = append(changes, ChartChange{Path: path, Before: string(before), Removed: true})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// ApplyChanges writes the previewed changes to fs
+func ApplyChanges(fs afero.Fs, changes []ChartChange) error {
+	for _, change := range changes {
+		if change.Removed {
+			if err := fs.Remove(change.Path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("unable to remove %s: %w", change.Path, err)
+			}
+			continue
+		}
+		if err := fs.MkdirAll(filepath.Dir(change.Path), os.ModePerm); err != nil {
+			return fmt.Errorf("unable to create the directory of %s: %w", change.Path, err)
+		}
+		if err := afero.WriteFile(fs, change.Path, []byte(change.After), change.Mode); err != nil {
+			return fmt.Errorf("unable to write %s: %w", change.Path, err)
+		}
+	}
+	return nil
+}
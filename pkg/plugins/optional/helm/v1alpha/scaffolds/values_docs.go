@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds/internal/templates"
+)
+
+// valueDoc is a row of the table of the values of the chart
+type valueDoc struct {
+	Key         string
+	Type        string
+	Default     string
+	Description string
+}
+
+var (
+	// valuesKeyRegex matches a key of a mapping of the values.yaml file, capturing its indentation and its name
+	valuesKeyRegex = regexp.MustCompile(`^(\s*)("[^"]+"|'[^']+'|[^\s#:'"-][^\s:]*)\s*:(\s|$)`)
+	// valuesDocRegex matches the helm-docs description of a key, i.e. "# -- <description>"
+	valuesDocRegex = regexp.MustCompile(`^\s*#\s*--\s?(.*)$`)
+)
+
+// updateReadmeValuesTable regenerates the table of the values in the README of the chart from the
+// values.yaml file. The README is left unchanged when its table markers were removed.
+func (s *initScaffolder) updateReadmeValuesTable() error {
+	readmePath := filepath.Join(s.chartDir, "chart", "README.md")
+	valuesPath := filepath.Join(s.chartDir, "chart", "values.yaml")
+
+	readme, err := afero.ReadFile(s.fs.FS, readmePath)
+	if errors.Is(err, afero.ErrFileNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to read %s: %w", readmePath, err)
+	}
+	values, err := afero.ReadFile(s.fs.FS, valuesPath)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", valuesPath, err)
+	}
+
+	docs, err := valuesDocs(values)
+	if err != nil {
+		return fmt.Errorf("unable to document the values of %s: %w", valuesPath, err)
+	}
+
+	content := string(readme)
+	start := strings.Index(content, templates.ValuesTableStartMarker)
+	end := strings.Index(content, templates.ValuesTableEndMarker)
+	if start < 0 || end < start {
+		log.Warnf("Unable to find the markers of the values table in %s, the table is not updated", readmePath)
+		return nil
+	}
+
+	updated := content[:start+len(templates.ValuesTableStartMarker)] + "\n" +
+		valuesTable(docs) + content[end:]
+	if updated == content {
+		return nil
+	}
+	if err := afero.WriteFile(s.fs.FS, readmePath, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("unable to write %s: %w", readmePath, err)
+	}
+	return nil
+}
+
+// valuesDocs returns the documentation of the values, in the order of the values.yaml file. The keys
+// with a helm-docs description are documented, as well as the leaves without documented parent.
+// The nested keys of a documented key are covered by its default value.
+func valuesDocs(content []byte) ([]valueDoc, error) {
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, err
+	}
+
+	type parentKey struct {
+		indent     int
+		name       string
+		documented bool
+	}
+	var (
+		docs        []valueDoc
+		parents     []parentKey
+		description []string
+		inDoc       bool
+	)
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if matches := valuesDocRegex.FindStringSubmatch(line); matches != nil {
+			description, inDoc = []string{strings.TrimSpace(matches[1])}, true
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			// The comments following the description continue it
+			if inDoc {
+				description = append(description, strings.TrimSpace(strings.TrimPrefix(trimmed, "#")))
+			}
+			continue
+		}
+
+		matches := valuesKeyRegex.FindStringSubmatch(line)
+		if matches == nil {
+			// Blank lines and list items end the description
+			description, inDoc = nil, false
+			continue
+		}
+
+		indent := len(matches[1])
+		for len(parents) > 0 && parents[len(parents)-1].indent >= indent {
+			parents = parents[:len(parents)-1]
+		}
+		path := make([]string, 0, len(parents)+1)
+		documentedParent := false
+		for _, parent := range parents {
+			path = append(path, parent.name)
+			documentedParent = documentedParent || parent.documented
+		}
+		name := strings.Trim(matches[2], `"'`)
+		path = append(path, name)
+		parents = append(parents, parentKey{indent: indent, name: name, documented: inDoc})
+
+		value, found := lookupValue(values, path)
+		if !found || documentedParent {
+			description, inDoc = nil, false
+			continue
+		}
+		nested, isMap := value.(map[string]interface{})
+		if inDoc || !isMap || len(nested) == 0 {
+			defaultValue, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			docs = append(docs, valueDoc{
+				Key:         strings.Join(path, "."),
+				Type:        valueType(value),
+				Default:     string(defaultValue),
+				Description: strings.TrimSpace(strings.Join(description, " ")),
+			})
+		}
+		description, inDoc = nil, false
+	}
+	return docs, nil
+}
+
+// lookupValue returns the value of the path of keys in the values
+func lookupValue(values map[string]interface{}, path []string) (interface{}, bool) {
+	var value interface{} = values
+	for _, key := range path {
+		mapping, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = mapping[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// valueType returns the type of the value, as named by helm-docs
+func valueType(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return "bool"
+	case float64:
+		if v == float64(int64(v)) {
+			return "int"
+		}
+		return "float"
+	case string:
+		return "string"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return "null"
+	}
+}
+
+// valuesTable returns the markdown table of the values
+func valuesTable(docs []valueDoc) string {
+	escape := strings.NewReplacer("|", `\|`, "\n", " ")
+
+	var table strings.Builder
+	table.WriteString("| Key | Type | Default | Description |\n")
+	table.WriteString("|-----|------|---------|-------------|\n")
+	for _, doc := range docs {
+		fmt.Fprintf(&table, "| %s | %s | `%s` | %s |\n",
+			escape.Replace(doc.Key), doc.Type, escape.Replace(doc.Default), escape.Replace(doc.Description))
+	}
+	return table.String()
+}
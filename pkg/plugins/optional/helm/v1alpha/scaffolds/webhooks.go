@@ -0,0 +1,301 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+	templateswebhooks "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds/internal/templates/chart-templates/webhook"
+)
+
+// webhookMarkerPrefix is the prefix of the controller-gen markers which define the webhook configurations
+const webhookMarkerPrefix = "+kubebuilder:webhook:"
+
+// extractWebhooks returns the mutating and validating webhooks of the project for the helm chart.
+// They are parsed from the webhook markers of the Go source files, so that the chart can be generated
+// before running `make manifests` and keeps the paths customized in the markers. The defaulting and
+// validation webhooks of the resources tracked in the PROJECT file whose marker can not be found are
+// generated from the resource model, as they are scaffolded by `kubebuilder create webhook`.
+func (s *initScaffolder) extractWebhooks() (mutatingWebhooks []templateswebhooks.DataWebhook,
+	validatingWebhooks []templateswebhooks.DataWebhook, err error,
+) {
+	markers, err := findWebhookMarkers(s.fs.FS)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find the webhook markers: %w", err)
+	}
+
+	serviceName := `{{ include "chart.webhookServiceName" . }}`
+	names := make(map[string]struct{}, len(markers))
+	for _, marker := range markers {
+		webhook, mutating, err := parseWebhookMarker(marker)
+		if err != nil {
+			log.Warnf("skipping the webhook marker %q: %v", marker, err)
+			continue
+		}
+		// The same webhook can not be registered twice
+		if _, found := names[webhook.Name]; found {
+			continue
+		}
+		names[webhook.Name] = struct{}{}
+
+		webhook.ServiceName = serviceName
+		if mutating {
+			mutatingWebhooks = append(mutatingWebhooks, webhook)
+		} else {
+			validatingWebhooks = append(validatingWebhooks, webhook)
+		}
+	}
+
+	resources, err := s.config.GetResourcesWithWebhooks()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the resources with webhooks: %w", err)
+	}
+	for _, res := range resources {
+		if res.HasDefaultingWebhook() && !hasWebhookForResource(mutatingWebhooks, res) {
+			log.Warnf("the webhook marker of the defaulting webhook of %s (%s/%s) was not found, "+
+				"its default configuration is used in the helm chart", res.Kind, res.QualifiedGroup(), res.Version)
+			mutatingWebhooks = append(mutatingWebhooks, defaultWebhook(res, true, serviceName))
+		}
+		if res.HasValidationWebhook() && !hasWebhookForResource(validatingWebhooks, res) {
+			log.Warnf("the webhook marker of the validation webhook of %s (%s/%s) was not found, "+
+				"its default configuration is used in the helm chart", res.Kind, res.QualifiedGroup(), res.Version)
+			validatingWebhooks = append(validatingWebhooks, defaultWebhook(res, false, serviceName))
+		}
+	}
+
+	// Sort the webhooks by name, as controller-gen does, so that the chart is stable
+	sort.SliceStable(mutatingWebhooks, func(i, j int) bool {
+		return mutatingWebhooks[i].Name < mutatingWebhooks[j].Name
+	})
+	sort.SliceStable(validatingWebhooks, func(i, j int) bool {
+		return validatingWebhooks[i].Name < validatingWebhooks[j].Name
+	})
+
+	return mutatingWebhooks, validatingWebhooks, nil
+}
+
+// findWebhookMarkers returns the arguments of the webhook markers of the Go source files of the project.
+// The test files, the dependencies and the hidden directories are skipped, as controller-gen does.
+func findWebhookMarkers(fs afero.Fs) ([]string, error) {
+	var markers []string
+	err := afero.Walk(fs, ".", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if path != "." && (strings.HasPrefix(name, ".") || name == "vendor" || name == "bin" ||
+				name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		content, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", path, err)
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			line = strings.TrimSpace(line)
+			if !strings.HasPrefix(line, "//") {
+				continue
+			}
+			line = strings.TrimSpace(strings.TrimPrefix(line, "//"))
+			if strings.HasPrefix(line, webhookMarkerPrefix) {
+				markers = append(markers, strings.TrimPrefix(line, webhookMarkerPrefix))
+			}
+		}
+		return nil
+	})
+	return markers, err
+}
+
+// parseWebhookMarker returns the webhook defined by the arguments of a webhook marker, and whether it
+// is a mutating webhook. The values are converted as controller-gen does to generate the manifests.
+func parseWebhookMarker(marker string) (webhook templateswebhooks.DataWebhook, mutating bool, err error) {
+	rule := templateswebhooks.DataWebhookRule{}
+	for _, arg := range splitMarkerArgs(marker, ',') {
+		key, value, found := strings.Cut(arg, "=")
+		if !found {
+			return webhook, false, fmt.Errorf("argument %q has no value", arg)
+		}
+		switch key {
+		case "name":
+			webhook.Name = unquote(value)
+		case "path":
+			webhook.Path = unquote(value)
+		case "mutating":
+			if mutating, err = strconv.ParseBool(value); err != nil {
+				return webhook, false, fmt.Errorf("invalid value %q of mutating: %w", value, err)
+			}
+		case "failurePolicy":
+			webhook.FailurePolicy = capitalize(unquote(value))
+		case "sideEffects":
+			webhook.SideEffects = capitalize(unquote(value))
+		case "admissionReviewVersions":
+			webhook.AdmissionReviewVersions = markerList(value)
+		case "groups":
+			rule.APIGroups = markerList(value)
+		case "versions":
+			rule.APIVersions = markerList(value)
+		case "resources":
+			rule.Resources = markerList(value)
+		case "verbs":
+			for _, verb := range markerList(value) {
+				rule.Operations = append(rule.Operations, strings.ToUpper(verb))
+			}
+		}
+	}
+
+	if webhook.Name == "" || webhook.Path == "" {
+		return webhook, false, fmt.Errorf("the name and the path of the webhook are required")
+	}
+	if len(rule.APIGroups) == 0 {
+		rule.APIGroups = []string{""}
+	}
+	webhook.Rules = []templateswebhooks.DataWebhookRule{rule}
+	return webhook, mutating, nil
+}
+
+// splitMarkerArgs splits the arguments of a marker by the separator, ignoring the separators
+// within quotes and braces
+func splitMarkerArgs(args string, separator rune) []string {
+	var (
+		parts   []string
+		current strings.Builder
+		depth   int
+		quoted  bool
+	)
+	for _, char := range args {
+		switch {
+		case char == '"':
+			quoted = !quoted
+		case char == '{' && !quoted:
+			depth++
+		case char == '}' && !quoted:
+			depth--
+		case char == separator && !quoted && depth == 0:
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(char)
+	}
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
+
+// markerList returns the values of a list argument of a marker, either in the form `a;b` or `{a,b}`
+func markerList(value string) []string {
+	separator := ';'
+	if strings.HasPrefix(value, "{") && strings.HasSuffix(value, "}") {
+		value = strings.TrimSuffix(strings.TrimPrefix(value, "{"), "}")
+		separator = ','
+	}
+
+	var values []string
+	for _, item := range splitMarkerArgs(value, separator) {
+		values = append(values, unquote(strings.TrimSpace(item)))
+	}
+	return values
+}
+
+// unquote removes the quotes of a string argument of a marker
+func unquote(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return value
+}
+
+// capitalize upper-cases the first letter of the value, e.g. the failure policy `fail` is `Fail`
+func capitalize(value string) string {
+	if value == "" {
+		return value
+	}
+	return strings.ToUpper(value[:1]) + value[1:]
+}
+
+// webhookGroup returns the API group of the resource in the webhook rules, which is empty for the core group
+func webhookGroup(res resource.Resource) string {
+	if res.Core && res.QualifiedGroup() == "core" {
+		return ""
+	}
+	return res.QualifiedGroup()
+}
+
+// hasWebhookForResource checks if any of the webhooks has a rule which matches the resource
+func hasWebhookForResource(webhooks []templateswebhooks.DataWebhook, res resource.Resource) bool {
+	contains := func(values []string, value string) bool {
+		for _, v := range values {
+			if v == value || v == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, webhook := range webhooks {
+		for _, rule := range webhook.Rules {
+			if contains(rule.APIGroups, webhookGroup(res)) && contains(rule.APIVersions, res.Version) &&
+				contains(rule.Resources, res.Plural) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// defaultWebhook returns the defaulting or validation webhook of the resource, as it is defined by the
+// marker scaffolded by `kubebuilder create webhook`
+func defaultWebhook(res resource.Resource, mutating bool, serviceName string) templateswebhooks.DataWebhook {
+	pathGroup := strings.ReplaceAll(webhookGroup(res), ".", "-")
+	pathSuffix := fmt.Sprintf("%s-%s-%s", pathGroup, res.Version, strings.ToLower(res.Kind))
+	path, prefix := "/validate-"+pathSuffix, "v"
+	if mutating {
+		path, prefix = "/mutate-"+pathSuffix, "m"
+	}
+
+	return templateswebhooks.DataWebhook{
+		Name:                    fmt.Sprintf("%s%s-%s.kb.io", prefix, strings.ToLower(res.Kind), res.Version),
+		ServiceName:             serviceName,
+		Path:                    path,
+		FailurePolicy:           "Fail",
+		SideEffects:             "None",
+		AdmissionReviewVersions: []string{"v1"},
+		Rules: []templateswebhooks.DataWebhookRule{{
+			Operations:  []string{"CREATE", "UPDATE"},
+			APIGroups:   []string{webhookGroup(res)},
+			APIVersions: []string{res.Version},
+			Resources:   []string{res.Plural},
+		}},
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+//nolint:lll
+const metaDataDescription = `This command will add the lint of the manifests to the project, so that the quality gates of
+the manifests run with the tests of the project:
+  - The configuration of the linter, tuned to the manifests scaffolded by Kubebuilder
+    ('.kube-linter.yaml' for kube-linter or '.polaris.yaml' for polaris).
+  - The 'lint-manifests' target in the Makefile, which lints the manifests built from 'config/default'
+    and the Helm chart scaffolded by the helm plugin, when the project has one.
+  - A workflow which runs 'make lint-manifests' on each push and pull request ('.github/workflows/lint-manifests.yml').
+
+The linter is tracked in the PROJECT file (in the 'linter' field of this plugin).
+`
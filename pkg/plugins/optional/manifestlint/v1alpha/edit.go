@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/manifestlint/v1alpha/scaffolds"
+)
+
+var _ plugin.EditSubcommand = &editSubcommand{}
+
+type editSubcommand struct {
+	config config.Config
+	force  bool
+
+	// options are the options of the lint of the manifests
+	options pluginConfig
+
+	flagSet *pflag.FlagSet
+}
+
+func (p *editSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	subcmdMeta.Description = metaDataDescription
+
+	subcmdMeta.Examples = fmt.Sprintf(`  # Add the lint of the manifests to a project
+  %[1]s edit --plugins=%[2]s
+
+  # Lint the manifests with polaris instead of kube-linter
+  %[1]s edit --plugins=%[2]s --linter=polaris
+
+  # Overwrite the configuration of the linter and the workflow with the latest scaffold
+  %[1]s edit --plugins=%[2]s --force
+`, cliMeta.CommandName, pluginKey)
+
+	subcmdMeta.Flags = append(subcmdMeta.Flags, optionsFlagsMetadata...)
+}
+
+func (p *editSubcommand) BindFlags(fs *pflag.FlagSet) {
+	p.flagSet = fs
+	bindOptionsFlags(fs, &p.options)
+	fs.BoolVar(&p.force, "force", false,
+		"if true, overwrites the configuration of the linter and the workflow linting the manifests")
+}
+
+func (p *editSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+
+	// Keep the linter tracked in the PROJECT file unless another one is requested
+	cfg, found, err := loadPluginConfig(c)
+	if err != nil {
+		return err
+	}
+	if found && !p.flagSet.Changed("linter") && cfg.Linter != "" {
+		p.options.Linter = cfg.Linter
+	}
+
+	return validateLinter(p.options.Linter)
+}
+
+func (p *editSubcommand) Scaffold(fs machinery.Filesystem) error {
+	if err := insertPluginMetaToConfig(p.config, p.options); err != nil {
+		return err
+	}
+
+	scaffolder := scaffolds.NewInitScaffolder(p.config, p.options.Linter, p.force)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
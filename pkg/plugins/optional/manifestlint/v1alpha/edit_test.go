@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	helmscaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds"
+)
+
+var _ = Describe("editSubcommand", func() {
+	const (
+		kubeLinterConfig = ".kube-linter.yaml"
+		polarisConfig    = ".polaris.yaml"
+	)
+
+	var workflow = filepath.Join(".github", "workflows", "lint-manifests.yml")
+
+	var (
+		fs  machinery.Filesystem
+		cfg config.Config
+	)
+
+	edit := func(args ...string) error {
+		subCmd := &editSubcommand{}
+		subCmd.UpdateMetadata(plugin.CLIMetadata{CommandName: "kubebuilder"}, &plugin.SubcommandMetadata{})
+		flags := pflag.NewFlagSet("edit", pflag.ContinueOnError)
+		subCmd.BindFlags(flags)
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if err := subCmd.InjectConfig(cfg); err != nil {
+			return err
+		}
+		return subCmd.Scaffold(fs)
+	}
+
+	trackedLinter := func() string {
+		pluginCfg, found, err := loadPluginConfig(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return pluginCfg.Linter
+	}
+
+	makefile := func() string {
+		content, err := afero.ReadFile(fs.FS, "Makefile")
+		Expect(err).NotTo(HaveOccurred())
+		return string(content)
+	}
+
+	BeforeEach(func() {
+		fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+		Expect(afero.WriteFile(fs.FS, "Makefile", []byte("##@ Dependencies\n"), 0o644)).To(Succeed())
+
+		cfg = cfgv3.New()
+		Expect(cfg.SetProjectName("project")).To(Succeed())
+	})
+
+	It("should lint the manifests with kube-linter by default and track it in the PROJECT file", func() {
+		Expect(edit()).To(Succeed())
+
+		Expect(afero.Exists(fs.FS, kubeLinterConfig)).To(BeTrue())
+		Expect(afero.Exists(fs.FS, workflow)).To(BeTrue())
+		Expect(makefile()).To(ContainSubstring("lint-manifests: manifests kustomize kube-linter"))
+		Expect(makefile()).To(ContainSubstring("CHART_DIR ?= dist/chart"))
+		Expect(trackedLinter()).To(Equal("kube-linter"))
+	})
+
+	It("should switch the linter with --linter and keep it on the next edits", func() {
+		Expect(edit()).To(Succeed())
+		Expect(edit("--linter=polaris")).To(Succeed())
+		Expect(edit()).To(Succeed())
+
+		Expect(afero.Exists(fs.FS, polarisConfig)).To(BeTrue())
+		Expect(makefile()).To(ContainSubstring("lint-manifests: manifests kustomize polaris"))
+		Expect(makefile()).NotTo(ContainSubstring("KUBE_LINTER"))
+		Expect(trackedLinter()).To(Equal("polaris"))
+	})
+
+	It("should lint the chart of the directory tracked by the helm plugin", func() {
+		Expect(helmscaffolds.SavePluginConfig(cfg, helmscaffolds.PluginConfig{ChartDir: "deploy"})).To(Succeed())
+
+		Expect(edit()).To(Succeed())
+
+		Expect(makefile()).To(ContainSubstring("CHART_DIR ?= deploy/chart"))
+	})
+
+	It("should only overwrite the configuration of the linter with --force", func() {
+		Expect(afero.WriteFile(fs.FS, kubeLinterConfig, []byte("custom"), 0o644)).To(Succeed())
+
+		Expect(edit()).To(Succeed())
+		Expect(afero.ReadFile(fs.FS, kubeLinterConfig)).To(BeEquivalentTo("custom"))
+
+		Expect(edit("--force")).To(Succeed())
+		Expect(afero.ReadFile(fs.FS, kubeLinterConfig)).NotTo(BeEquivalentTo("custom"))
+	})
+
+	It("should reject an unsupported linter", func() {
+		Expect(edit("--linter=kubeval")).To(MatchError(ContainSubstring(`invalid linter "kubeval"`)))
+		Expect(afero.Exists(fs.FS, workflow)).To(BeFalse())
+	})
+})
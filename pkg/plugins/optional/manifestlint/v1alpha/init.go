@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/manifestlint/v1alpha/scaffolds"
+)
+
+var _ plugin.InitSubcommand = &initSubcommand{}
+
+type initSubcommand struct {
+	config config.Config
+
+	// options are the options of the lint of the manifests
+	options pluginConfig
+}
+
+func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	subcmdMeta.Description = metaDataDescription
+
+	subcmdMeta.Examples = fmt.Sprintf(`  # Initialize a common project whose manifests are linted with kube-linter
+  %[1]s init --plugins=go/v4,%[2]s
+
+  # Initialize a project with a Helm chart, whose manifests and chart are linted with polaris
+  %[1]s init --plugins=go/v4,helm/v1-alpha,%[2]s --linter=polaris
+`, cliMeta.CommandName, pluginKey)
+
+	subcmdMeta.Flags = append(subcmdMeta.Flags, optionsFlagsMetadata...)
+}
+
+func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
+	bindOptionsFlags(fs, &p.options)
+}
+
+func (p *initSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+	return validateLinter(p.options.Linter)
+}
+
+func (p *initSubcommand) Scaffold(fs machinery.Filesystem) error {
+	if err := insertPluginMetaToConfig(p.config, p.options); err != nil {
+		return err
+	}
+
+	scaffolder := scaffolds.NewInitScaffolder(p.config, p.options.Linter, false)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
+
+// optionsFlagsMetadata describes the allowed values of the flags of the options
+var optionsFlagsMetadata = []plugin.FlagMetadata{{Name: "linter", Enum: scaffolds.Linters}}
+
+// bindOptionsFlags binds the flags of the options of the lint of the manifests
+func bindOptionsFlags(fs *pflag.FlagSet, options *pluginConfig) {
+	fs.StringVar(&options.Linter, "linter", scaffolds.KubeLinter,
+		"tool which lints the manifests")
+}
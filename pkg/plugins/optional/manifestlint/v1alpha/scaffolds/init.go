@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	helmv1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/manifestlint/v1alpha/scaffolds/internal/templates"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/manifestlint/v1alpha/scaffolds/internal/templates/github"
+)
+
+const (
+	// KubeLinter lints the manifests with kube-linter
+	KubeLinter = "kube-linter"
+	// Polaris lints the manifests with polaris
+	Polaris = "polaris"
+)
+
+// Linters are the tools which can lint the manifests
+var Linters = []string{KubeLinter, Polaris}
+
+// defaultChartDir is the directory of the Helm chart when it is not tracked by the helm plugin
+const defaultChartDir = "dist"
+
+var _ plugins.Scaffolder = &initScaffolder{}
+
+type initScaffolder struct {
+	config config.Config
+
+	// linter is the tool which lints the manifests
+	linter string
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+
+	// force indicates whether to overwrite the scaffolded files
+	force bool
+}
+
+// NewInitScaffolder returns a new Scaffolder for the lint of the manifests
+func NewInitScaffolder(config config.Config, linter string, force bool) plugins.Scaffolder {
+	return &initScaffolder{
+		config: config,
+		linter: linter,
+		force:  force,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *initScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *initScaffolder) Scaffold() error {
+	log.Println("Generating the lint of the manifests...")
+
+	// Initialize the machinery.Scaffold that will write the files to disk
+	scaffold := machinery.NewScaffold(s.fs,
+		machinery.WithConfig(s.config),
+	)
+
+	var linterConfig machinery.Builder
+	switch s.linter {
+	case Polaris:
+		linterConfig = &templates.PolarisConfig{Force: s.force}
+	default:
+		linterConfig = &templates.KubeLinterConfig{Force: s.force}
+	}
+
+	if err := scaffold.Execute(
+		linterConfig,
+		&github.LintManifests{Force: s.force},
+	); err != nil {
+		return fmt.Errorf("error scaffolding the lint of the manifests: %w", err)
+	}
+
+	return s.updateMakefile()
+}
+
+// chartDir returns the directory of the Helm chart scaffolded by the helm plugin. The chart is only
+// linted when it exists, so the project does not need to have one.
+func (s *initScaffolder) chartDir() string {
+	var helmConfig struct {
+		ChartDir string `json:"chartDir,omitempty"`
+	}
+	err := s.config.DecodePluginConfig(plugin.KeyFor(helmv1alpha.Plugin{}), &helmConfig)
+	if err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
+		log.Warnf("Unable to load the configuration of the helm plugin: %v", err)
+	}
+	if helmConfig.ChartDir == "" {
+		return defaultChartDir
+	}
+	return helmConfig.ChartDir
+}
+
+// manifestLintSectionHeader is the header of the Makefile section with the lint of the manifests
+const manifestLintSectionHeader = "##@ Manifest Lint"
+
+// updateMakefile adds the lint-manifests target to the end of the Makefile, after its Dependencies
+// section which defines the directory of the tools. When the section already exists, it is replaced
+// so that it runs the selected linter.
+func (s *initScaffolder) updateMakefile() error {
+	const makefile = "Makefile"
+	content, err := afero.ReadFile(s.fs.FS, makefile)
+	if errors.Is(err, afero.ErrFileNotFound) {
+		log.Warnf("Unable to find the %s to add the lint-manifests target", makefile)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error reading the %s: %w", makefile, err)
+	}
+
+	sectionTemplate := kubeLinterSectionTemplate
+	if s.linter == Polaris {
+		sectionTemplate = polarisSectionTemplate
+	}
+	section := fmt.Sprintf(sectionTemplate, manifestLintSectionHeader, path.Join(s.chartDir(), "chart"))
+
+	makefileContent := string(content)
+	if start := strings.Index(makefileContent, manifestLintSectionHeader); start >= 0 {
+		end := len(makefileContent)
+		if next := strings.Index(makefileContent[start+len(manifestLintSectionHeader):], "##@ "); next >= 0 {
+			end = start + len(manifestLintSectionHeader) + next
+		}
+		makefileContent = makefileContent[:start] + section + makefileContent[end:]
+	} else {
+		makefileContent = strings.TrimRight(makefileContent, "\n") + "\n\n" + section
+	}
+
+	return afero.WriteFile(s.fs.FS, makefile, []byte(makefileContent), 0o644)
+}
+
+const kubeLinterSectionTemplate = `%[1]s
+
+# The manifests built from config/default are linted with the Helm chart of CHART_DIR, when it exists.
+CHART_DIR ?= %[2]s
+LINT_MANIFESTS ?= $(LOCALBIN)/lint-manifests.yaml
+
+.PHONY: lint-manifests
+lint-manifests: manifests kustomize kube-linter ## Lint the manifests and the Helm chart with kube-linter.
+	$(KUSTOMIZE) build config/default > $(LINT_MANIFESTS)
+	$(KUBE_LINTER) lint --config .kube-linter.yaml $(LINT_MANIFESTS)
+	@if [ -d $(CHART_DIR) ]; then \
+		$(KUBE_LINTER) lint --config .kube-linter.yaml $(CHART_DIR); \
+	fi
+
+## Manifest Lint Tool Binaries
+KUBE_LINTER ?= $(LOCALBIN)/kube-linter
+
+## Manifest Lint Tool Versions
+KUBE_LINTER_VERSION ?= v0.7.1
+
+.PHONY: kube-linter
+kube-linter: $(KUBE_LINTER) ## Download kube-linter locally if necessary.
+$(KUBE_LINTER): $(LOCALBIN)
+	$(call go-install-tool,$(KUBE_LINTER),golang.stackrox.io/kube-linter/cmd/kube-linter,$(KUBE_LINTER_VERSION))
+`
+
+const polarisSectionTemplate = `%[1]s
+
+# The manifests built from config/default are linted with the Helm chart of CHART_DIR, when it exists.
+# Only the checks with the danger severity fail the target.
+CHART_DIR ?= %[2]s
+LINT_MANIFESTS ?= $(LOCALBIN)/lint-manifests.yaml
+
+.PHONY: lint-manifests
+lint-manifests: manifests kustomize polaris ## Lint the manifests and the Helm chart with polaris.
+	$(KUSTOMIZE) build config/default > $(LINT_MANIFESTS)
+	$(POLARIS) audit --config .polaris.yaml --audit-path $(LINT_MANIFESTS) \
+		--format pretty --set-exit-code-on-danger
+	@if [ -d $(CHART_DIR) ]; then \
+		$(POLARIS) audit --config .polaris.yaml --helm-chart $(CHART_DIR) --helm-values $(CHART_DIR)/values.yaml \
+			--format pretty --set-exit-code-on-danger; \
+	fi
+
+## Manifest Lint Tool Binaries
+POLARIS ?= $(LOCALBIN)/polaris
+
+## Manifest Lint Tool Versions
+POLARIS_VERSION ?= v9.6.1
+
+.PHONY: polaris
+polaris: $(POLARIS) ## Download polaris locally if necessary.
+$(POLARIS): $(LOCALBIN)
+	$(call go-install-tool,$(POLARIS),github.com/fairwindsops/polaris/v9,$(POLARIS_VERSION))
+`
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &KubeLinterConfig{}
+
+// KubeLinterConfig scaffolds the configuration of kube-linter, tuned to the manifests scaffolded by Kubebuilder
+type KubeLinterConfig struct {
+	machinery.TemplateMixin
+
+	Force bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *KubeLinterConfig) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = ".kube-linter.yaml"
+	}
+
+	f.TemplateBody = kubeLinterConfigTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+
+	return nil
+}
+
+const kubeLinterConfigTemplate = `# Configuration of kube-linter, which lints the manifests built from config/default and the Helm chart
+# with 'make lint-manifests'. See https://docs.kubelinter.io/#/configuring-kubelinter
+checks:
+  # The default checks run with the ones included below.
+  addAllBuiltIn: false
+  include:
+    # The manager is bound to the roles generated from its RBAC markers, which must not use wildcards
+    # nor bind the cluster-admin role.
+    - wildcard-in-rules
+    - cluster-admin-role-binding
+    # The manager must not run as root nor with escalated privileges, as required by the "restricted"
+    # Pod Security Standards which it adheres to.
+    - privilege-escalation-container
+    - run-as-non-root
+    - drop-net-raw-capability
+  exclude:
+    # The image of the manager is set by 'make deploy IMG=<image>' and by the values of the Helm chart.
+    - latest-tag
+    # The manager does not mount a read-only root filesystem by default. Remove this exclusion once
+    # readOnlyRootFilesystem is set in the securityContext of the container of the manager.
+    - no-read-only-root-fs
+`
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &PolarisConfig{}
+
+// PolarisConfig scaffolds the configuration of polaris, tuned to the manifests scaffolded by Kubebuilder
+type PolarisConfig struct {
+	machinery.TemplateMixin
+
+	Force bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *PolarisConfig) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = ".polaris.yaml"
+	}
+
+	f.TemplateBody = polarisConfigTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+
+	return nil
+}
+
+const polarisConfigTemplate = `# Configuration of polaris, which audits the manifests built from config/default and the Helm chart
+# with 'make lint-manifests'. Only the checks with the danger severity fail the target.
+# See https://polaris.docs.fairwinds.com/customization/configuration/
+checks:
+  # The manager must not run as root nor with escalated privileges, as required by the "restricted"
+  # Pod Security Standards which it adheres to.
+  hostIPCSet: danger
+  hostPIDSet: danger
+  hostNetworkSet: danger
+  hostPortSet: warning
+  runAsRootAllowed: danger
+  runAsPrivileged: danger
+  privilegeEscalationAllowed: danger
+  dangerousCapabilities: danger
+  insecureCapabilities: warning
+  # The manager does not mount a read-only root filesystem by default. Raise the severity to danger once
+  # readOnlyRootFilesystem is set in the securityContext of the container of the manager.
+  notReadOnlyRootFilesystem: warning
+
+  # The resources and the probes of the manager are set in config/manager/manager.yaml.
+  cpuRequestsMissing: danger
+  cpuLimitsMissing: warning
+  memoryRequestsMissing: danger
+  memoryLimitsMissing: danger
+  readinessProbeMissing: danger
+  livenessProbeMissing: danger
+
+  # The image of the manager is set by 'make deploy IMG=<image>' and by the values of the Helm chart.
+  tagNotSpecified: ignore
+  pullPolicyNotAlways: ignore
+
+  # The manager is bound to the roles generated from its RBAC markers, which must not bind the
+  # cluster-admin role nor allow to exec into the pods.
+  clusterrolebindingClusterAdmin: danger
+  rolebindingClusterAdminRole: danger
+  clusterrolePodExecAttach: danger
+  rolePodExecAttach: danger
+
+  # A single replica of the manager runs by default, with the leader election enabled.
+  deploymentMissingReplicas: ignore
+  missingPodDisruptionBudget: ignore
+  priorityClassNotSet: ignore
+  topologySpreadConstraint: ignore
+  # The network policies of the manager are optional, see config/network-policy.
+  missingNetworkPolicy: warning
+`
@@ -1,5 +1,5 @@
 /*
-Copyright 2024 The Kubernetes Authors.
+Copyright 2025 The Kubernetes Authors.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -22,8 +22,9 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/config"
 )
 
+// insertPluginMetaToConfig will insert the metadata to the plugin configuration
 func insertPluginMetaToConfig(target config.Config, cfg pluginConfig) error {
-	err := target.DecodePluginConfig(pluginKey, cfg)
+	err := target.DecodePluginConfig(pluginKey, &pluginConfig{})
 	if !errors.As(err, &config.UnsupportedFieldError{}) {
 		if err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
 			return err
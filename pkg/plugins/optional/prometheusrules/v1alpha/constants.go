@@ -0,0 +1,36 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+//nolint:lll
+const metaDataDescription = `This command will add Prometheus alerting rules on the default controller-runtime metrics to the project:
+  - A PrometheusRule with the recording rules and the alerts of the service level objectives of the controllers:
+    the ratio of failed reconciliations, the latency of the work queues and the reconciliations stuck in a worker.
+	('config/prometheus/rules.yaml')
+  - The rules are added to the Prometheus component of the project, which is enabled by uncommenting
+    the [PROMETHEUS] section of config/default/kustomization.yaml.
+	('config/prometheus/kustomization.yaml')
+
+When the project is distributed with the Helm plugin (helm/v1-alpha), the rules are added to the chart
+the next time it is generated, e.g. with 'kubebuilder edit --plugins=helm/v1-alpha'. They are deployed with
+the ServiceMonitor when 'prometheus.enable' is set in the values of the chart.
+
+NOTE: This plugin requires:
+- The Prometheus Operator (https://prometheus-operator.dev), whose Prometheus instance selects the PrometheusRule.
+- The metrics of the project to be scraped by this Prometheus instance.
+Check how to enable the metrics for your project by looking at the doc: https://book.kubebuilder.io/reference/metrics.html
+`
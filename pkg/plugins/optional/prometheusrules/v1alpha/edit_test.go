@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+)
+
+var _ = Describe("editSubcommand", func() {
+	const prometheusKustomization = `apiVersion: kustomize.config.k8s.io/v1alpha1
+kind: Component
+
+resources:
+- monitor.yaml
+
+#patches:
+#  - path: monitor_tls_patch.yaml
+`
+
+	var (
+		rules         = filepath.Join("config", "prometheus", "rules.yaml")
+		kustomization = filepath.Join("config", "prometheus", "kustomization.yaml")
+	)
+
+	var (
+		fs  machinery.Filesystem
+		cfg config.Config
+	)
+
+	edit := func(args ...string) error {
+		subCmd := &editSubcommand{}
+		subCmd.UpdateMetadata(plugin.CLIMetadata{CommandName: "kubebuilder"}, &plugin.SubcommandMetadata{})
+		flags := pflag.NewFlagSet("edit", pflag.ContinueOnError)
+		subCmd.BindFlags(flags)
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if err := subCmd.InjectConfig(cfg); err != nil {
+			return err
+		}
+		return subCmd.Scaffold(fs)
+	}
+
+	BeforeEach(func() {
+		fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+		Expect(afero.WriteFile(fs.FS, kustomization, []byte(prometheusKustomization), 0o644)).To(Succeed())
+
+		cfg = cfgv3.New()
+		Expect(cfg.SetProjectName("project")).To(Succeed())
+	})
+
+	It("should scaffold the rules and track the plugin in the PROJECT file", func() {
+		Expect(edit()).To(Succeed())
+
+		Expect(afero.ReadFile(fs.FS, rules)).To(ContainSubstring("project-controller-manager-metrics-service"))
+		Expect(cfg.DecodePluginConfig(pluginKey, &pluginConfig{})).To(Succeed())
+	})
+
+	It("should add the rules to the resources of the Prometheus component once", func() {
+		Expect(edit()).To(Succeed())
+		Expect(edit()).To(Succeed())
+
+		Expect(afero.ReadFile(fs.FS, kustomization)).To(BeEquivalentTo(`apiVersion: kustomize.config.k8s.io/v1alpha1
+kind: Component
+
+resources:
+- monitor.yaml
+- rules.yaml
+
+#patches:
+#  - path: monitor_tls_patch.yaml
+`))
+	})
+
+	It("should scaffold the rules when the Prometheus component is missing", func() {
+		Expect(fs.FS.Remove(kustomization)).To(Succeed())
+
+		Expect(edit()).To(Succeed())
+
+		Expect(afero.Exists(fs.FS, rules)).To(BeTrue())
+		Expect(afero.Exists(fs.FS, kustomization)).To(BeFalse())
+	})
+
+	It("should only overwrite the rules with --force", func() {
+		Expect(afero.WriteFile(fs.FS, rules, []byte("custom"), 0o644)).To(Succeed())
+
+		Expect(edit()).To(Succeed())
+		Expect(afero.ReadFile(fs.FS, rules)).To(BeEquivalentTo("custom"))
+
+		Expect(edit("--force")).To(Succeed())
+		Expect(afero.ReadFile(fs.FS, rules)).NotTo(BeEquivalentTo("custom"))
+	})
+
+	It("should reject an invalid value of --force", func() {
+		Expect(edit("--force=maybe")).To(MatchError(ContainSubstring("invalid argument")))
+	})
+})
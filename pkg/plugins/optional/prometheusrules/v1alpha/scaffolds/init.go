@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	helmscaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/prometheusrules/v1alpha/scaffolds/internal/templates"
+)
+
+var _ plugins.Scaffolder = &initScaffolder{}
+
+type initScaffolder struct {
+	config config.Config
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+
+	// force indicates whether to overwrite the scaffolded rules
+	force bool
+}
+
+// NewInitScaffolder returns a new Scaffolder for the Prometheus alerting rules
+func NewInitScaffolder(config config.Config, force bool) plugins.Scaffolder {
+	return &initScaffolder{
+		config: config,
+		force:  force,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *initScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *initScaffolder) Scaffold() error {
+	log.Println("Generating the Prometheus alerting rules of the controllers...")
+
+	// Initialize the machinery.Scaffold that will write the files to disk
+	scaffold := machinery.NewScaffold(s.fs,
+		machinery.WithConfig(s.config),
+	)
+
+	if err := scaffold.Execute(&templates.Rules{Force: s.force}); err != nil {
+		return fmt.Errorf("error scaffolding the Prometheus alerting rules: %w", err)
+	}
+
+	if err := s.addRulesToKustomization(); err != nil {
+		return err
+	}
+
+	helmCfg, err := helmscaffolds.LoadPluginConfig(s.config)
+	if err != nil {
+		return fmt.Errorf("error loading the helm plugin configuration: %w", err)
+	}
+	if helmCfg.ChartDir != "" {
+		log.Infof("Run 'kubebuilder edit --plugins=helm/v1-alpha' to add the alerting rules to the Helm chart under %s",
+			helmCfg.ChartDir)
+	}
+
+	return nil
+}
+
+// rulesResource is the entry of the rules in the resources of the Prometheus component
+const rulesResource = "- rules.yaml\n"
+
+// addRulesToKustomization adds the rules to the resources of the Prometheus component, which is
+// enabled by the [PROMETHEUS] section of config/default/kustomization.yaml
+func (s *initScaffolder) addRulesToKustomization() error {
+	kustomization := filepath.Join("config", "prometheus", "kustomization.yaml")
+	content, err := afero.ReadFile(s.fs.FS, kustomization)
+	if errors.Is(err, afero.ErrFileNotFound) {
+		log.Warnf("Unable to find %s, add %s to the manifests applied with the Prometheus ServiceMonitor",
+			kustomization, filepath.Join("config", "prometheus", "rules.yaml"))
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to read %s: %w", kustomization, err)
+	}
+
+	contentStr := string(content)
+	if strings.Contains(contentStr, rulesResource) {
+		return nil
+	}
+
+	const target = "resources:\n"
+	idx := strings.Index(contentStr, target)
+	if idx == -1 {
+		log.Warnf("Unable to find the resources in %s, add rules.yaml to them", kustomization)
+		return nil
+	}
+	// Append the rules after the last resource of the list
+	end := idx + len(target)
+	for end < len(contentStr) && strings.HasPrefix(contentStr[end:], "- ") {
+		next := strings.Index(contentStr[end:], "\n")
+		if next == -1 {
+			contentStr += "\n"
+			next = len(contentStr) - end - 1
+		}
+		end += next + 1
+	}
+	contentStr = contentStr[:end] + rulesResource + contentStr[end:]
+
+	if err := afero.WriteFile(s.fs.FS, kustomization, []byte(contentStr), 0o644); err != nil {
+		return fmt.Errorf("unable to write %s: %w", kustomization, err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &Rules{}
+
+// Rules scaffolds a PrometheusRule with the recording rules and the alerts of the service level
+// objectives of the controllers, on the default controller-runtime metrics
+type Rules struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+
+	Force bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *Rules) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("config", "prometheus", "rules.yaml")
+	}
+
+	// Prometheus alert templates use {{ }}, which is collided with default delimiter for go template parsing.
+	// Provide an alternative delimiter here to avoid overlaps.
+	f.SetDelim("[[", "]]")
+	f.TemplateBody = rulesTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+
+	return nil
+}
+
+//nolint:lll
+const rulesTemplate = `# The recording rules and the alerts of the service level objectives (SLO) of the controllers,
+# computed from the default controller-runtime metrics scraped by the ServiceMonitor in monitor.yaml.
+# TODO(user): The Prometheus instance only loads the PrometheusRules matched by its ruleSelector,
+# add its expected labels, e.g. "release: prometheus", and tune the objectives below for your project.
+apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  labels:
+    control-plane: controller-manager
+    app.kubernetes.io/name: [[ .ProjectName ]]
+    app.kubernetes.io/managed-by: kustomize
+  name: controller-manager-rules
+  namespace: system
+spec:
+  groups:
+  - name: [[ .ProjectName ]]-controller-runtime.rules
+    rules:
+    - record: controller:controller_runtime_reconcile_errors:ratio_rate5m
+      expr: |
+        sum by (namespace, controller) (rate(controller_runtime_reconcile_errors_total{job="[[ .ProjectName ]]-controller-manager-metrics-service"}[5m]))
+          / sum by (namespace, controller) (rate(controller_runtime_reconcile_total{job="[[ .ProjectName ]]-controller-manager-metrics-service"}[5m]))
+    - record: controller:controller_runtime_reconcile_errors:ratio_rate1h
+      expr: |
+        sum by (namespace, controller) (rate(controller_runtime_reconcile_errors_total{job="[[ .ProjectName ]]-controller-manager-metrics-service"}[1h]))
+          / sum by (namespace, controller) (rate(controller_runtime_reconcile_total{job="[[ .ProjectName ]]-controller-manager-metrics-service"}[1h]))
+    - record: name:workqueue_queue_duration_seconds:p99_rate5m
+      expr: |
+        histogram_quantile(0.99, sum by (namespace, name, le) (rate(workqueue_queue_duration_seconds_bucket{job="[[ .ProjectName ]]-controller-manager-metrics-service"}[5m])))
+  - name: [[ .ProjectName ]]-controller-runtime.alerts
+    rules:
+    # The objective is that 99% of the reconciliations succeed. The error budget is burnt 14.4 times
+    # faster than allowed, i.e. 2% of a 30 days budget in one hour, over both the short and the long window.
+    - alert: ControllerReconcileErrorBudgetBurn
+      expr: |
+        controller:controller_runtime_reconcile_errors:ratio_rate5m > (14.4 * 0.01)
+          and controller:controller_runtime_reconcile_errors:ratio_rate1h > (14.4 * 0.01)
+      for: 2m
+      labels:
+        severity: critical
+      annotations:
+        summary: The controller {{ $labels.controller }} is burning its error budget
+        description: '{{ $value | humanizePercentage }} of the reconciliations of the controller {{ $labels.controller }} in the namespace {{ $labels.namespace }} failed during the last 5 minutes and the last hour.'
+    - alert: ControllerReconcileErrorRatioHigh
+      expr: |
+        controller:controller_runtime_reconcile_errors:ratio_rate1h > 0.05
+      for: 1h
+      labels:
+        severity: warning
+      annotations:
+        summary: High ratio of failed reconciliations for the controller {{ $labels.controller }}
+        description: '{{ $value | humanizePercentage }} of the reconciliations of the controller {{ $labels.controller }} in the namespace {{ $labels.namespace }} failed during the last hour.'
+    # The objective is that 99% of the objects wait less than 10 seconds in the work queue before being reconciled.
+    - alert: ControllerWorkqueueLatencyHigh
+      expr: |
+        name:workqueue_queue_duration_seconds:p99_rate5m > 10
+      for: 15m
+      labels:
+        severity: warning
+      annotations:
+        summary: The objects wait too long in the work queue {{ $labels.name }}
+        description: 'The objects of the work queue {{ $labels.name }} in the namespace {{ $labels.namespace }} wait {{ $value | humanizeDuration }} before being reconciled (99th percentile).'
+    - alert: ControllerReconcileStuck
+      expr: |
+        max by (namespace, name) (workqueue_longest_running_processor_seconds{job="[[ .ProjectName ]]-controller-manager-metrics-service"}) > 600
+      for: 5m
+      labels:
+        severity: warning
+      annotations:
+        summary: A reconciliation of the work queue {{ $labels.name }} is stuck
+        description: 'A worker of the work queue {{ $labels.name }} in the namespace {{ $labels.namespace }} has been reconciling the same object for {{ $value | humanizeDuration }}.'
+`
@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+//nolint:lll
+const metaDataDescription = `This command will add a supply chain pipeline to the project, which generates the SBOM of the
+manager image and signs it with cosign keyless signing once the image is published:
+  - A workflow which runs once the 'Publish Image' workflow succeeds, resolves the digest of the pushed image
+    and runs the targets below ('.github/workflows/supply-chain.yml').
+  - The 'sbom', 'sign' and 'attest' targets in the Makefile, which generate the SBOM of the image with syft,
+    sign the image and attest its SBOM with cosign.
+  - The 'sign-chart' target in the Makefile, which packages the Helm chart and signs it with cosign,
+    when the plugin is used with --sign-chart.
+
+The options of the pipeline are tracked in the PROJECT file (in the 'sbomFormat', 'signImage' and 'signChart'
+fields of this plugin).
+
+NOTE: This plugin requires:
+- The project to be hosted on GitHub, with the manager image published to the GitHub Container Registry.
+- The Helm chart to be scaffolded with the helm plugin, when the plugin is used with --sign-chart.
+`
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/supplychain/v1alpha/scaffolds"
+)
+
+var _ plugin.EditSubcommand = &editSubcommand{}
+
+type editSubcommand struct {
+	config config.Config
+	force  bool
+
+	// options are the options of the supply chain pipeline
+	options pluginConfig
+
+	flagSet *pflag.FlagSet
+}
+
+func (p *editSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	subcmdMeta.Description = metaDataDescription
+
+	subcmdMeta.Examples = fmt.Sprintf(`  # Add a supply chain pipeline to a project
+  %[1]s edit --plugins=%[2]s
+
+  # Generate a CycloneDX SBOM instead of an SPDX one
+  %[1]s edit --plugins=%[2]s --sbom-format=cyclonedx-json
+
+  # Sign the package of the Helm chart too
+  %[1]s edit --plugins=%[2]s --sign-chart
+
+  # Overwrite the workflow with the latest scaffold
+  %[1]s edit --plugins=%[2]s --force
+`, cliMeta.CommandName, pluginKey)
+
+	subcmdMeta.Flags = append(subcmdMeta.Flags, optionsFlagsMetadata...)
+}
+
+func (p *editSubcommand) BindFlags(fs *pflag.FlagSet) {
+	p.flagSet = fs
+	bindOptionsFlags(fs, &p.options)
+	fs.BoolVar(&p.force, "force", false, "if true, overwrites the workflow of the supply chain pipeline")
+}
+
+func (p *editSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+
+	// Keep the options tracked in the PROJECT file unless others are requested
+	cfg, found, err := loadPluginConfig(c)
+	if err != nil {
+		return err
+	}
+	if found {
+		if !p.flagSet.Changed("sbom-format") && cfg.SBOMFormat != "" {
+			p.options.SBOMFormat = cfg.SBOMFormat
+		}
+		if !p.flagSet.Changed("sign") {
+			p.options.SignImage = cfg.SignImage
+		}
+		if !p.flagSet.Changed("sign-chart") {
+			p.options.SignChart = cfg.SignChart
+		}
+	}
+
+	return validateSBOMFormat(p.options.SBOMFormat)
+}
+
+func (p *editSubcommand) Scaffold(fs machinery.Filesystem) error {
+	if err := insertPluginMetaToConfig(p.config, p.options); err != nil {
+		return err
+	}
+
+	// The workflow runs the targets of the selected options, so it is overwritten when they are set
+	force := p.force || p.flagSet.Changed("sign") || p.flagSet.Changed("sign-chart")
+
+	scaffolder := scaffolds.NewInitScaffolder(p.config, p.options.SBOMFormat,
+		p.options.SignImage, p.options.SignChart, force)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
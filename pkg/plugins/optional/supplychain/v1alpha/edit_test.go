@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	helmscaffolds "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha/scaffolds"
+)
+
+var _ = Describe("editSubcommand", func() {
+	var workflow = filepath.Join(".github", "workflows", "supply-chain.yml")
+
+	var (
+		fs  machinery.Filesystem
+		cfg config.Config
+	)
+
+	edit := func(args ...string) error {
+		subCmd := &editSubcommand{}
+		subCmd.UpdateMetadata(plugin.CLIMetadata{CommandName: "kubebuilder"}, &plugin.SubcommandMetadata{})
+		flags := pflag.NewFlagSet("edit", pflag.ContinueOnError)
+		subCmd.BindFlags(flags)
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if err := subCmd.InjectConfig(cfg); err != nil {
+			return err
+		}
+		return subCmd.Scaffold(fs)
+	}
+
+	trackedOptions := func() pluginConfig {
+		pluginCfg, found, err := loadPluginConfig(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return pluginCfg
+	}
+
+	makefile := func() string {
+		content, err := afero.ReadFile(fs.FS, "Makefile")
+		Expect(err).NotTo(HaveOccurred())
+		return string(content)
+	}
+
+	BeforeEach(func() {
+		fs = machinery.Filesystem{FS: afero.NewMemMapFs()}
+		Expect(afero.WriteFile(fs.FS, "Makefile", []byte("##@ Dependencies\n"), 0o644)).To(Succeed())
+
+		cfg = cfgv3.New()
+		Expect(cfg.SetProjectName("project")).To(Succeed())
+	})
+
+	It("should scaffold the pipeline with the default options and track them in the PROJECT file", func() {
+		Expect(edit()).To(Succeed())
+
+		Expect(afero.ReadFile(fs.FS, workflow)).To(ContainSubstring("make sign attest"))
+		Expect(makefile()).To(ContainSubstring("SBOM_FORMAT ?= spdx-json"))
+		Expect(makefile()).To(ContainSubstring("sign: cosign"))
+		Expect(makefile()).NotTo(ContainSubstring("sign-chart:"))
+		Expect(trackedOptions()).To(Equal(pluginConfig{SBOMFormat: "spdx-json", SignImage: true}))
+	})
+
+	It("should keep the options tracked in the PROJECT file unless others are requested", func() {
+		Expect(edit("--sbom-format=cyclonedx-json", "--sign=false")).To(Succeed())
+		Expect(edit()).To(Succeed())
+
+		Expect(trackedOptions()).To(Equal(pluginConfig{SBOMFormat: "cyclonedx-json"}))
+		Expect(makefile()).To(ContainSubstring("SBOM ?= dist/sbom.cdx.json"))
+		Expect(makefile()).NotTo(ContainSubstring("sign: cosign"))
+
+		Expect(edit("--sign")).To(Succeed())
+
+		Expect(trackedOptions()).To(Equal(pluginConfig{SBOMFormat: "cyclonedx-json", SignImage: true}))
+		Expect(afero.ReadFile(fs.FS, workflow)).To(ContainSubstring("make sign attest"))
+		Expect(makefile()).To(ContainSubstring("sign: cosign"))
+	})
+
+	It("should sign the chart of the directory tracked by the helm plugin with --sign-chart", func() {
+		Expect(helmscaffolds.SavePluginConfig(cfg, helmscaffolds.PluginConfig{ChartDir: "deploy"})).To(Succeed())
+
+		Expect(edit("--sign-chart")).To(Succeed())
+
+		Expect(makefile()).To(ContainSubstring("CHART_DIR ?= deploy/chart"))
+		Expect(trackedOptions().SignChart).To(BeTrue())
+	})
+
+	It("should reject an unsupported SBOM format", func() {
+		Expect(edit("--sbom-format=xml")).To(MatchError(ContainSubstring(`invalid SBOM format "xml"`)))
+		Expect(afero.Exists(fs.FS, workflow)).To(BeFalse())
+	})
+})
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/supplychain/v1alpha/scaffolds"
+)
+
+var _ plugin.InitSubcommand = &initSubcommand{}
+
+type initSubcommand struct {
+	config config.Config
+
+	// options are the options of the supply chain pipeline
+	options pluginConfig
+}
+
+func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	subcmdMeta.Description = metaDataDescription
+
+	subcmdMeta.Examples = fmt.Sprintf(`  # Initialize a common project with a supply chain pipeline generating an SPDX SBOM
+  %[1]s init --plugins=go/v4,%[2]s
+
+  # Initialize a project with a Helm chart, whose package is also signed
+  %[1]s init --plugins=go/v4,helm/v1-alpha,%[2]s --sign-chart
+`, cliMeta.CommandName, pluginKey)
+
+	subcmdMeta.Flags = append(subcmdMeta.Flags, optionsFlagsMetadata...)
+}
+
+func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
+	bindOptionsFlags(fs, &p.options)
+}
+
+func (p *initSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+	return validateSBOMFormat(p.options.SBOMFormat)
+}
+
+func (p *initSubcommand) Scaffold(fs machinery.Filesystem) error {
+	if err := insertPluginMetaToConfig(p.config, p.options); err != nil {
+		return err
+	}
+
+	scaffolder := scaffolds.NewInitScaffolder(p.config, p.options.SBOMFormat,
+		p.options.SignImage, p.options.SignChart, false)
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
+
+// optionsFlagsMetadata describes the allowed values of the flags of the options
+var optionsFlagsMetadata = []plugin.FlagMetadata{{Name: "sbom-format", Enum: scaffolds.SBOMFormats}}
+
+// bindOptionsFlags binds the flags of the options of the supply chain pipeline
+func bindOptionsFlags(fs *pflag.FlagSet, options *pluginConfig) {
+	fs.StringVar(&options.SBOMFormat, "sbom-format", scaffolds.SPDXFormat,
+		"format of the SBOM generated for the manager image")
+	fs.BoolVar(&options.SignImage, "sign", true,
+		"if true, signs the manager image and attests its SBOM with cosign keyless signing")
+	fs.BoolVar(&options.SignChart, "sign-chart", false,
+		"if true, packages the Helm chart scaffolded by the helm plugin and signs it with cosign keyless signing")
+}
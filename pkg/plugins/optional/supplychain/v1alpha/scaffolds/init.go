@@ -0,0 +1,239 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
+	helmv1alpha "sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/helm/v1alpha"
+	"sigs.k8s.io/kubebuilder/v4/pkg/plugins/optional/supplychain/v1alpha/scaffolds/internal/templates/github"
+)
+
+const (
+	// SPDXFormat generates the SBOM in the SPDX JSON format
+	SPDXFormat = "spdx-json"
+	// CycloneDXFormat generates the SBOM in the CycloneDX JSON format
+	CycloneDXFormat = "cyclonedx-json"
+)
+
+// SBOMFormats are the formats in which the SBOM can be generated
+var SBOMFormats = []string{SPDXFormat, CycloneDXFormat}
+
+// sbomFiles are the files of the SBOM generated in each format
+var sbomFiles = map[string]string{
+	SPDXFormat:      "dist/sbom.spdx.json",
+	CycloneDXFormat: "dist/sbom.cdx.json",
+}
+
+// predicateTypes are the types of the cosign attestations of the SBOM in each format
+var predicateTypes = map[string]string{
+	SPDXFormat:      "spdxjson",
+	CycloneDXFormat: "cyclonedx",
+}
+
+// defaultChartDir is the directory of the Helm chart when it is not tracked by the helm plugin
+const defaultChartDir = "dist"
+
+var _ plugins.Scaffolder = &initScaffolder{}
+
+type initScaffolder struct {
+	config config.Config
+
+	// sbomFormat is the format of the SBOM generated for the manager image
+	sbomFormat string
+	// signImage indicates whether the manager image is signed and its SBOM attested
+	signImage bool
+	// signChart indicates whether the Helm chart is packaged and signed
+	signChart bool
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+
+	// force indicates whether to overwrite the scaffolded files
+	force bool
+}
+
+// NewInitScaffolder returns a new Scaffolder for the supply chain pipeline
+func NewInitScaffolder(config config.Config, sbomFormat string, signImage, signChart, force bool) plugins.Scaffolder {
+	return &initScaffolder{
+		config:     config,
+		sbomFormat: sbomFormat,
+		signImage:  signImage,
+		signChart:  signChart,
+		force:      force,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *initScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *initScaffolder) Scaffold() error {
+	log.Println("Generating the supply chain pipeline of the manager image...")
+
+	// Initialize the machinery.Scaffold that will write the files to disk
+	scaffold := machinery.NewScaffold(s.fs,
+		machinery.WithConfig(s.config),
+	)
+
+	if err := scaffold.Execute(&github.SupplyChain{
+		SignImage: s.signImage,
+		SignChart: s.signChart,
+		Force:     s.force,
+	}); err != nil {
+		return fmt.Errorf("error scaffolding the supply chain workflow: %w", err)
+	}
+
+	return s.updateMakefile()
+}
+
+// chartDir returns the directory of the Helm chart scaffolded by the helm plugin
+func (s *initScaffolder) chartDir() string {
+	var helmConfig struct {
+		ChartDir string `json:"chartDir,omitempty"`
+	}
+	err := s.config.DecodePluginConfig(plugin.KeyFor(helmv1alpha.Plugin{}), &helmConfig)
+	if errors.As(err, &config.PluginKeyNotFoundError{}) {
+		log.Warnf("The Helm chart is not scaffolded in the project, "+
+			"scaffold it with the %s plugin before running 'make sign-chart'", plugin.KeyFor(helmv1alpha.Plugin{}))
+	} else if err != nil {
+		log.Warnf("Unable to load the configuration of the helm plugin: %v", err)
+	}
+	if helmConfig.ChartDir == "" {
+		return defaultChartDir
+	}
+	return helmConfig.ChartDir
+}
+
+// supplyChainSectionHeader is the header of the Makefile section with the supply chain targets
+const supplyChainSectionHeader = "##@ Supply Chain"
+
+// updateMakefile adds the supply chain targets to the end of the Makefile, after its Dependencies
+// section which defines the directory of the tools. When the section already exists, it is replaced
+// so that it has the targets of the selected options.
+func (s *initScaffolder) updateMakefile() error {
+	const makefile = "Makefile"
+	content, err := afero.ReadFile(s.fs.FS, makefile)
+	if errors.Is(err, afero.ErrFileNotFound) {
+		log.Warnf("Unable to find the %s to add the supply chain targets", makefile)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error reading the %s: %w", makefile, err)
+	}
+
+	var section strings.Builder
+	fmt.Fprintf(&section, sbomSectionTemplate, supplyChainSectionHeader, s.sbomFormat, sbomFiles[s.sbomFormat])
+	if s.signImage {
+		fmt.Fprintf(&section, signSectionTemplate, predicateTypes[s.sbomFormat])
+	}
+	if s.signChart {
+		fmt.Fprintf(&section, signChartSectionTemplate, path.Join(s.chartDir(), "chart"))
+	}
+	section.WriteString(supplyChainToolsTemplate)
+
+	makefileContent := string(content)
+	if start := strings.Index(makefileContent, supplyChainSectionHeader); start >= 0 {
+		end := len(makefileContent)
+		if next := strings.Index(makefileContent[start+len(supplyChainSectionHeader):], "##@ "); next >= 0 {
+			end = start + len(supplyChainSectionHeader) + next
+		}
+		makefileContent = makefileContent[:start] + section.String() + makefileContent[end:]
+	} else {
+		makefileContent = strings.TrimRight(makefileContent, "\n") + "\n\n" + section.String()
+	}
+
+	return afero.WriteFile(s.fs.FS, makefile, []byte(makefileContent), 0o644)
+}
+
+const sbomSectionTemplate = `%[1]s
+
+# The SBOM of the manager image is generated with syft. Set IMG_DIGEST to the digest of the pushed image,
+# e.g. example.com/project@sha256:<digest>, so that the SBOM and the signatures cover the exact image
+# which was pushed instead of the image which is tagged with IMG when they run.
+IMG_DIGEST ?= $(IMG)
+SBOM_FORMAT ?= %[2]s
+SBOM ?= %[3]s
+
+.PHONY: sbom
+sbom: syft ## Generate the SBOM of the manager image IMG_DIGEST.
+	mkdir -p $(dir $(SBOM))
+	$(SYFT) scan registry:$(IMG_DIGEST) -o $(SBOM_FORMAT)=$(SBOM)
+`
+
+const signSectionTemplate = `
+# The image is signed and its SBOM attested with cosign keyless signing, which uses the OIDC identity
+# of the CI, or opens a browser to log in when it runs locally.
+SBOM_PREDICATE_TYPE ?= %[1]s
+
+.PHONY: sign
+sign: cosign ## Sign the manager image IMG_DIGEST with cosign keyless signing.
+	$(COSIGN) sign --yes $(IMG_DIGEST)
+
+.PHONY: attest
+attest: cosign sbom ## Attest the SBOM of the manager image IMG_DIGEST with cosign keyless signing.
+	$(COSIGN) attest --yes --type $(SBOM_PREDICATE_TYPE) --predicate $(SBOM) $(IMG_DIGEST)
+`
+
+const signChartSectionTemplate = `
+# The Helm chart is packaged in CHART_PACKAGE_DIR, with the signature bundle of each package.
+CHART_DIR ?= %[1]s
+CHART_PACKAGE_DIR ?= dist/chart-package
+
+.PHONY: sign-chart
+sign-chart: cosign ## Package the Helm chart of CHART_DIR and sign it with cosign keyless signing.
+	@command -v helm >/dev/null 2>&1 || { \
+		echo "Helm is not installed. Please install Helm manually."; \
+		exit 1; \
+	}
+	mkdir -p $(CHART_PACKAGE_DIR)
+	helm package $(CHART_DIR) --destination $(CHART_PACKAGE_DIR)
+	for pkg in $(CHART_PACKAGE_DIR)/*.tgz; do \
+		$(COSIGN) sign-blob --yes --bundle $$pkg.sigstore.json $$pkg || exit 1; \
+	done
+`
+
+const supplyChainToolsTemplate = `
+## Supply Chain Tool Binaries
+SYFT ?= $(LOCALBIN)/syft
+COSIGN ?= $(LOCALBIN)/cosign
+
+## Supply Chain Tool Versions
+SYFT_VERSION ?= v1.19.0
+COSIGN_VERSION ?= v2.4.3
+
+.PHONY: syft
+syft: $(SYFT) ## Download syft locally if necessary.
+$(SYFT): $(LOCALBIN)
+	$(call go-install-tool,$(SYFT),github.com/anchore/syft/cmd/syft,$(SYFT_VERSION))
+
+.PHONY: cosign
+cosign: $(COSIGN) ## Download cosign locally if necessary.
+$(COSIGN): $(LOCALBIN)
+	$(call go-install-tool,$(COSIGN),github.com/sigstore/cosign/v2/cmd/cosign,$(COSIGN_VERSION))
+`
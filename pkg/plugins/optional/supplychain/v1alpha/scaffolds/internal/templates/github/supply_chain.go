@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &SupplyChain{}
+
+// SupplyChain scaffolds the GitHub Action which generates the SBOM of the published manager image
+// and signs it with cosign
+type SupplyChain struct {
+	machinery.TemplateMixin
+
+	// SignImage indicates whether the manager image is signed and its SBOM attested
+	SignImage bool
+	// SignChart indicates whether the Helm chart is packaged and signed
+	SignChart bool
+
+	Force bool
+}
+
+// SetTemplateDefaults implements machinery.Template
+func (f *SupplyChain) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(".github", "workflows", "supply-chain.yml")
+	}
+
+	f.TemplateBody = supplyChainTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+
+	return nil
+}
+
+// The GitHub expressions are written as template strings, since they use the same delimiters
+const supplyChainTemplate = `name: Supply Chain
+
+# Generates the SBOM of the manager image pushed by the Publish Image workflow{{ if .SignImage }},
+# signs the image and attests its SBOM with cosign keyless signing{{ end }}{{ if .SignChart }},
+# packages the Helm chart and signs it with cosign keyless signing{{ end }}.
+# The keyless signatures use the OIDC identity of this workflow, which is recorded
+# in the public transparency log of Sigstore.
+on:
+  workflow_run:
+    workflows: ["Publish Image"]
+    types:
+      - completed
+
+permissions:
+  contents: read
+  packages: write
+  id-token: write
+
+jobs:
+  supply-chain:
+    name: Generate the SBOM{{ if or .SignImage .SignChart }} and sign the artifacts{{ end }}
+    if: {{ "${{ github.event.workflow_run.conclusion == 'success' }}" }}
+    runs-on: ubuntu-latest
+    steps:
+      - name: Clone the code
+        uses: actions/checkout@v4
+        with:
+          ref: {{ "${{ github.event.workflow_run.head_sha }}" }}
+
+      - name: Setup Go
+        uses: actions/setup-go@v5
+        with:
+          go-version-file: go.mod
+
+      - name: Log in to the GitHub Container Registry
+        uses: docker/login-action@v3
+        with:
+          registry: ghcr.io
+          username: {{ "${{ github.actor }}" }}
+          password: {{ "${{ secrets.GITHUB_TOKEN }}" }}
+
+      - name: Resolve the digest of the image
+        # The Publish Image workflow pushes the image tagged with the name of the git tag
+        env:
+          TAG: {{ "${{ github.event.workflow_run.head_branch }}" }}
+        run: |
+          IMG="ghcr.io/${GITHUB_REPOSITORY,,}"
+          DIGEST=$(docker buildx imagetools inspect "${IMG}:${TAG}" | awk '/^Digest:/ {print $2}')
+          echo "IMG_DIGEST=${IMG}@${DIGEST}" >> "$GITHUB_ENV"
+
+      - name: Generate the SBOM
+        run: make sbom IMG_DIGEST="$IMG_DIGEST"
+{{- if .SignImage }}
+
+      - name: Sign the image and attest its SBOM
+        run: make sign attest IMG_DIGEST="$IMG_DIGEST"
+{{- end }}
+{{- if .SignChart }}
+
+      - name: Set up Helm
+        uses: azure/setup-helm@v4
+
+      - name: Package and sign the Helm chart
+        run: make sign-chart
+{{- end }}
+
+      - name: Upload the supply chain artifacts
+        uses: actions/upload-artifact@v4
+        with:
+          name: supply-chain
+          path: |
+            dist/sbom.*
+{{- if .SignChart }}
+            dist/chart-package/
+{{- end }}
+`
@@ -376,10 +376,12 @@ func (t *TestContext) EditHelmPlugin() error {
 	return err
 }
 
-// HelmInstallRelease is for running `helm install`
+// HelmInstallRelease is for running `helm install`. The names of the resources of the release are
+// prefixed with the project name, as they are when the project is deployed with kustomize.
 func (t *TestContext) HelmInstallRelease() error {
 	cmd := exec.Command("helm", "install", fmt.Sprintf("release-%s", t.TestSuffix), "dist/chart",
-		"--namespace", fmt.Sprintf("e2e-%s-system", t.TestSuffix))
+		"--namespace", fmt.Sprintf("e2e-%s-system", t.TestSuffix),
+		"--set", fmt.Sprintf("fullnameOverride=e2e-%s", t.TestSuffix))
 	_, err := t.Run(cmd)
 	return err
 }
@@ -53,16 +53,7 @@ func GenerateV4(kbc *utils.TestContext) {
 
 	scaffoldConversionWebhook(kbc)
 
-	ExpectWithOffset(1, pluginutil.UncommentCode(
-		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		"#- ../certmanager", "#")).To(Succeed())
-	ExpectWithOffset(1, pluginutil.UncommentCode(
-		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		"#- ../prometheus", "#")).To(Succeed())
-	ExpectWithOffset(1, pluginutil.UncommentCode(filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		`#replacements:`, "#")).To(Succeed())
-	ExpectWithOffset(1, pluginutil.UncommentCode(filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		certManagerTarget, "#")).To(Succeed())
+	enableComponents(kbc, "certmanager", "prometheus")
 	ExpectWithOffset(1, pluginutil.UncommentCode(
 		filepath.Join(kbc.Dir, "config", "prometheus", "kustomization.yaml"),
 		monitorTLSPatch, "#")).To(Succeed())
@@ -70,9 +61,8 @@ func GenerateV4(kbc *utils.TestContext) {
 		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
 		metricsCertPatch, "#")).To(Succeed())
 	ExpectWithOffset(1, pluginutil.UncommentCode(
-		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
+		filepath.Join(kbc.Dir, "config", "certmanager", "kustomization.yaml"),
 		metricsCertReplaces, "#")).To(Succeed())
-	uncommentKustomizeCoversion(kbc)
 
 }
 
@@ -101,16 +91,7 @@ func GenerateV4WithoutMetrics(kbc *utils.TestContext) {
 
 	scaffoldConversionWebhook(kbc)
 
-	ExpectWithOffset(1, pluginutil.UncommentCode(
-		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		"#- ../certmanager", "#")).To(Succeed())
-	ExpectWithOffset(1, pluginutil.UncommentCode(
-		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		"#- ../prometheus", "#")).To(Succeed())
-	ExpectWithOffset(1, pluginutil.UncommentCode(filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		`#replacements:`, "#")).To(Succeed())
-	ExpectWithOffset(1, pluginutil.UncommentCode(filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		certManagerTarget, "#")).To(Succeed())
+	enableComponents(kbc, "certmanager", "prometheus")
 	// Disable metrics
 	ExpectWithOffset(1, pluginutil.CommentCode(
 		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
@@ -119,7 +100,6 @@ func GenerateV4WithoutMetrics(kbc *utils.TestContext) {
 		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
 		metricsTarget, "#")).To(Succeed())
 
-	uncommentKustomizeCoversion(kbc)
 }
 
 // GenerateV4WithoutMetrics implements a go/v4 plugin project defined by a TestContext.
@@ -127,16 +107,12 @@ func GenerateV4WithNetworkPoliciesWithoutWebhooks(kbc *utils.TestContext) {
 	initingTheProject(kbc)
 	creatingAPI(kbc)
 
-	ExpectWithOffset(1, pluginutil.UncommentCode(
-		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		"#- ../prometheus", "#")).To(Succeed())
+	enableComponents(kbc, "prometheus")
 	ExpectWithOffset(1, pluginutil.UncommentCode(
 		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
 		metricsTarget, "#")).To(Succeed())
-	By("uncomment kustomization.yaml to enable network policy")
-	ExpectWithOffset(1, pluginutil.UncommentCode(
-		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		"#- ../network-policy", "#")).To(Succeed())
+	By("enabling the network policy component")
+	enableComponents(kbc, "network-policy/metrics")
 }
 
 // GenerateV4WithNetworkPolicies implements a go/v4 plugin project defined by a TestContext.
@@ -164,12 +140,7 @@ func GenerateV4WithNetworkPolicies(kbc *utils.TestContext) {
 
 	scaffoldConversionWebhook(kbc)
 
-	ExpectWithOffset(1, pluginutil.UncommentCode(
-		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		"#- ../certmanager", "#")).To(Succeed())
-	ExpectWithOffset(1, pluginutil.UncommentCode(
-		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		"#- ../prometheus", "#")).To(Succeed())
+	enableComponents(kbc, "certmanager", "prometheus")
 	ExpectWithOffset(1, pluginutil.UncommentCode(
 		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
 		metricsTarget, "#")).To(Succeed())
@@ -177,23 +148,14 @@ func GenerateV4WithNetworkPolicies(kbc *utils.TestContext) {
 		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
 		metricsCertPatch, "#")).To(Succeed())
 	ExpectWithOffset(1, pluginutil.UncommentCode(
-		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
+		filepath.Join(kbc.Dir, "config", "certmanager", "kustomization.yaml"),
 		metricsCertReplaces, "#")).To(Succeed())
 	ExpectWithOffset(1, pluginutil.UncommentCode(
 		filepath.Join(kbc.Dir, "config", "prometheus", "kustomization.yaml"),
 		monitorTLSPatch, "#")).To(Succeed())
 
-	By("uncomment kustomization.yaml to enable network policy")
-	ExpectWithOffset(1, pluginutil.UncommentCode(
-		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		"#- ../network-policy", "#")).To(Succeed())
-
-	ExpectWithOffset(1, pluginutil.UncommentCode(filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		`#replacements:`, "#")).To(Succeed())
-	ExpectWithOffset(1, pluginutil.UncommentCode(filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		certManagerTarget, "#")).To(Succeed())
-
-	uncommentKustomizeCoversion(kbc)
+	By("enabling the network policy components")
+	enableComponents(kbc, "network-policy/metrics", "network-policy/webhook")
 }
 
 // GenerateV4WithoutWebhooks implements a go/v4 plugin with APIs and enable Prometheus and CertManager
@@ -201,9 +163,7 @@ func GenerateV4WithoutWebhooks(kbc *utils.TestContext) {
 	initingTheProject(kbc)
 	creatingAPI(kbc)
 
-	ExpectWithOffset(1, pluginutil.UncommentCode(
-		filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		"#- ../prometheus", "#")).To(Succeed())
+	enableComponents(kbc, "prometheus")
 }
 
 func creatingAPI(kbc *utils.TestContext) {
@@ -243,139 +203,6 @@ const metricsTarget = `- path: manager_metrics_patch.yaml
   target:
     kind: Deployment`
 
-const certManagerTarget = `# - source: # Uncomment the following block if you have any webhook
-#     kind: Service
-#     version: v1
-#     name: webhook-service
-#     fieldPath: .metadata.name # Name of the service
-#   targets:
-#     - select:
-#         kind: Certificate
-#         group: cert-manager.io
-#         version: v1
-#         name: serving-cert
-#       fieldPaths:
-#         - .spec.dnsNames.0
-#         - .spec.dnsNames.1
-#       options:
-#         delimiter: '.'
-#         index: 0
-#         create: true
-# - source:
-#     kind: Service
-#     version: v1
-#     name: webhook-service
-#     fieldPath: .metadata.namespace # Namespace of the service
-#   targets:
-#     - select:
-#         kind: Certificate
-#         group: cert-manager.io
-#         version: v1
-#         name: serving-cert
-#       fieldPaths:
-#         - .spec.dnsNames.0
-#         - .spec.dnsNames.1
-#       options:
-#         delimiter: '.'
-#         index: 1
-#         create: true
-#
-# - source: # Uncomment the following block if you have a ValidatingWebhook (--programmatic-validation)
-#     kind: Certificate
-#     group: cert-manager.io
-#     version: v1
-#     name: serving-cert # This name should match the one in certificate.yaml
-#     fieldPath: .metadata.namespace # Namespace of the certificate CR
-#   targets:
-#     - select:
-#         kind: ValidatingWebhookConfiguration
-#       fieldPaths:
-#         - .metadata.annotations.[cert-manager.io/inject-ca-from]
-#       options:
-#         delimiter: '/'
-#         index: 0
-#         create: true
-# - source:
-#     kind: Certificate
-#     group: cert-manager.io
-#     version: v1
-#     name: serving-cert
-#     fieldPath: .metadata.name
-#   targets:
-#     - select:
-#         kind: ValidatingWebhookConfiguration
-#       fieldPaths:
-#         - .metadata.annotations.[cert-manager.io/inject-ca-from]
-#       options:
-#         delimiter: '/'
-#         index: 1
-#         create: true
-#
-# - source: # Uncomment the following block if you have a DefaultingWebhook (--defaulting )
-#     kind: Certificate
-#     group: cert-manager.io
-#     version: v1
-#     name: serving-cert
-#     fieldPath: .metadata.namespace # Namespace of the certificate CR
-#   targets:
-#     - select:
-#         kind: MutatingWebhookConfiguration
-#       fieldPaths:
-#         - .metadata.annotations.[cert-manager.io/inject-ca-from]
-#       options:
-#         delimiter: '/'
-#         index: 0
-#         create: true
-# - source:
-#     kind: Certificate
-#     group: cert-manager.io
-#     version: v1
-#     name: serving-cert
-#     fieldPath: .metadata.name
-#   targets:
-#     - select:
-#         kind: MutatingWebhookConfiguration
-#       fieldPaths:
-#         - .metadata.annotations.[cert-manager.io/inject-ca-from]
-#       options:
-#         delimiter: '/'
-#         index: 1
-#         create: true`
-
-const certNamespace = `# - source: # Uncomment the following block if you have a ConversionWebhook (--conversion)
-#     kind: Certificate
-#     group: cert-manager.io
-#     version: v1
-#     name: serving-cert
-#     fieldPath: .metadata.namespace # Namespace of the certificate CR
-#   targets: # Do not remove or uncomment the following scaffold marker; required to generate code for target CRD.
-#     - select:
-#         kind: CustomResourceDefinition
-#         name: conversiontests.%s.%s
-#       fieldPaths:
-#         - .metadata.annotations.[cert-manager.io/inject-ca-from]
-#       options:
-#         delimiter: '/'
-#         index: 0
-#         create: true`
-
-const certName = `# - source:
-#     kind: Certificate
-#     group: cert-manager.io
-#     version: v1
-#     name: serving-cert
-#     fieldPath: .metadata.name
-#   targets: # Do not remove or uncomment the following scaffold marker; required to generate code for target CRD.
-#     - select:
-#         kind: CustomResourceDefinition
-#         name: conversiontests.%s.%s
-#       fieldPaths:
-#         - .metadata.annotations.[cert-manager.io/inject-ca-from]
-#       options:
-#         delimiter: '/'
-#         index: 1
-#         create: true`
-
 // scaffoldConversionWebhook sets up conversion webhooks for testing the ConversionTest API
 func scaffoldConversionWebhook(kbc *utils.TestContext) {
 	By("scaffolding conversion webhooks for testing ConversionTest v1 to v2 conversion")
@@ -441,11 +268,14 @@ func scaffoldConversionWebhook(kbc *utils.TestContext) {
 	Expect(err).NotTo(HaveOccurred(), "failed to implement conversion logic from v2 to v1")
 }
 
-func uncommentKustomizeCoversion(kbc *utils.TestContext) {
-	ExpectWithOffset(1, pluginutil.UncommentCode(filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		fmt.Sprintf(certNamespace, kbc.Group, kbc.Domain), "#")).To(Succeed())
-	ExpectWithOffset(1, pluginutil.UncommentCode(filepath.Join(kbc.Dir, "config", "default", "kustomization.yaml"),
-		fmt.Sprintf(certName, kbc.Group, kbc.Domain), "#")).To(Succeed())
+// enableComponents enables the kustomize components in config/default/kustomization.yaml
+func enableComponents(kbc *utils.TestContext, components ...string) {
+	By(fmt.Sprintf("enabling the kustomize components %s", strings.Join(components, ", ")))
+	err := kbc.Edit(
+		"--plugins", "kustomize/v2",
+		"--enable-components", strings.Join(components, ","),
+	)
+	ExpectWithOffset(2, err).NotTo(HaveOccurred(), "Failed to enable the kustomize components")
 }
 
 const monitorTLSPatch = `#patches:
@@ -457,62 +287,61 @@ const metricsCertPatch = `#- path: cert_metrics_manager_patch.yaml
 #  target:
 #    kind: Deployment`
 
-const metricsCertReplaces = `# - source: # Uncomment the following block to enable certificates for metrics
-#     kind: Service
-#     version: v1
-#     name: controller-manager-metrics-service
-#     fieldPath: metadata.name
-#   targets:
-#     - select:
-#         kind: Certificate
-#         group: cert-manager.io
-#         version: v1
-#         name: metrics-certs
-#       fieldPaths:
-#         - spec.dnsNames.0
-#         - spec.dnsNames.1
-#       options:
-#         delimiter: '.'
-#         index: 0
-#         create: true
-#     - select: # Uncomment the following to set the Service name for TLS config in Prometheus ServiceMonitor
-#         kind: ServiceMonitor
-#         group: monitoring.coreos.com
-#         version: v1
-#         name: controller-manager-metrics-monitor
-#       fieldPaths:
-#         - spec.endpoints.0.tlsConfig.serverName
-#       options:
-#         delimiter: '.'
-#         index: 0
-#         create: true
-#
-# - source:
-#     kind: Service
-#     version: v1
-#     name: controller-manager-metrics-service
-#     fieldPath: metadata.namespace
-#   targets:
-#     - select:
-#         kind: Certificate
-#         group: cert-manager.io
-#         version: v1
-#         name: metrics-certs
-#       fieldPaths:
-#         - spec.dnsNames.0
-#         - spec.dnsNames.1
-#       options:
-#         delimiter: '.'
-#         index: 1
-#         create: true
-#     - select: # Uncomment the following to set the Service namespace for TLS in Prometheus ServiceMonitor
-#         kind: ServiceMonitor
-#         group: monitoring.coreos.com
-#         version: v1
-#         name: controller-manager-metrics-monitor
-#       fieldPaths:
-#         - spec.endpoints.0.tlsConfig.serverName
-#       options:
-#         delimiter: '.'
-#         index: 1
-#         create: true`
+const metricsCertReplaces = `#- source:
+#    kind: Service
+#    version: v1
+#    name: controller-manager-metrics-service
+#    fieldPath: metadata.name
+#  targets:
+#    - select:
+#        kind: Certificate
+#        group: cert-manager.io
+#        version: v1
+#        name: metrics-certs
+#      fieldPaths:
+#        - spec.dnsNames.0
+#        - spec.dnsNames.1
+#      options:
+#        delimiter: '.'
+#        index: 0
+#        create: true
+#    - select: # Set the Service name for TLS config in Prometheus ServiceMonitor
+#        kind: ServiceMonitor
+#        group: monitoring.coreos.com
+#        version: v1
+#        name: controller-manager-metrics-monitor
+#      fieldPaths:
+#        - spec.endpoints.0.tlsConfig.serverName
+#      options:
+#        delimiter: '.'
+#        index: 0
+#        create: true
+#- source:
+#    kind: Service
+#    version: v1
+#    name: controller-manager-metrics-service
+#    fieldPath: metadata.namespace
+#  targets:
+#    - select:
+#        kind: Certificate
+#        group: cert-manager.io
+#        version: v1
+#        name: metrics-certs
+#      fieldPaths:
+#        - spec.dnsNames.0
+#        - spec.dnsNames.1
+#      options:
+#        delimiter: '.'
+#        index: 1
+#        create: true
+#    - select: # Set the Service namespace for TLS in Prometheus ServiceMonitor
+#        kind: ServiceMonitor
+#        group: monitoring.coreos.com
+#        version: v1
+#        name: controller-manager-metrics-monitor
+#      fieldPaths:
+#        - spec.endpoints.0.tlsConfig.serverName
+#      options:
+#        delimiter: '.'
+#        index: 1
+#        create: true`
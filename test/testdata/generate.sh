@@ -106,12 +106,32 @@ function scaffold_test_project {
     $kb edit --plugins=grafana.kubebuilder.io/v1-alpha
   fi
 
+  if [[ $project =~ with-plugins ]] ; then
+    header_text 'Editing project with AutoUpdate plugin ...'
+    $kb edit --plugins=autoupdate.kubebuilder.io/v1-alpha
+    header_text 'Editing project with DevEnv plugin ...'
+    $kb edit --plugins=devenv.kubebuilder.io/v1-alpha
+    header_text 'Editing project with DevContainer plugin ...'
+    $kb edit --plugins=devcontainer.kubebuilder.io/v1-alpha
+    header_text 'Editing project with PrometheusRules plugin ...'
+    $kb edit --plugins=prometheus-rules.kubebuilder.io/v1-alpha
+  fi
+
   make all
   make build-installer
 
   if [[ $project =~ with-plugins ]] ; then
     header_text 'Editing project with Helm plugin ...'
     $kb edit --plugins=helm.kubebuilder.io/v1-alpha
+
+    header_text 'Editing project with Composition plugin ...'
+    $kb edit --plugins=composition.kubebuilder.io/v1-alpha
+
+    header_text 'Editing project with SupplyChain plugin ...'
+    $kb edit --plugins=supply-chain.kubebuilder.io/v1-alpha --sign-chart
+
+    header_text 'Editing project with ManifestLint plugin ...'
+    $kb edit --plugins=manifest-lint.kubebuilder.io/v1-alpha
   fi
 
   # To avoid conflicts
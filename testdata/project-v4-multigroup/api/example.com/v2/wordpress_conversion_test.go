@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	examplecomv1 "sigs.k8s.io/kubebuilder/testdata/project-v4-multigroup/api/example.com/v1"
+)
+
+// Ensure that the v2 version can be converted to and from the Hub version (v1).
+var _ conversion.Convertible = &Wordpress{}
+
+// TestWordpressHubRoundTrip verifies that converting a Hub (v1) object to the
+// v2 version and back does not lose any data.
+func TestWordpressHubRoundTrip(t *testing.T) {
+	hub := &examplecomv1.Wordpress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-resource",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "test"},
+		},
+		// TODO(user): Set the spec fields which must be preserved by the conversion.
+	}
+
+	spoke := &Wordpress{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("failed to convert from the Hub version: %v", err)
+	}
+
+	restored := &examplecomv1.Wordpress{}
+	if err := spoke.ConvertTo(restored); err != nil {
+		t.Fatalf("failed to convert to the Hub version: %v", err)
+	}
+
+	if !equality.Semantic.DeepEqual(hub, restored) {
+		t.Errorf("the round trip conversion lost data:\nexpected: %+v\ngot: %+v", hub, restored)
+	}
+}
+
+// TestWordpressSpokeRoundTrip verifies that converting a v2 object to the
+// Hub version (v1) and back does not lose any data.
+func TestWordpressSpokeRoundTrip(t *testing.T) {
+	spoke := &Wordpress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-resource",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "test"},
+		},
+		// TODO(user): Set the spec fields which must be preserved by the conversion.
+	}
+
+	hub := &examplecomv1.Wordpress{}
+	if err := spoke.ConvertTo(hub); err != nil {
+		t.Fatalf("failed to convert to the Hub version: %v", err)
+	}
+
+	restored := &Wordpress{}
+	if err := restored.ConvertFrom(hub); err != nil {
+		t.Fatalf("failed to convert from the Hub version: %v", err)
+	}
+
+	if !equality.Semantic.DeepEqual(spoke, restored) {
+		t.Errorf("the round trip conversion lost data:\nexpected: %+v\ngot: %+v", spoke, restored)
+	}
+}
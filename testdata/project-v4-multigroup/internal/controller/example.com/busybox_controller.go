@@ -19,12 +19,14 @@ package examplecom
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,8 +46,11 @@ const busyboxFinalizer = "example.com.testproject.org/finalizer"
 
 // Definitions to manage status conditions
 const (
-	// typeAvailableBusybox represents the status of the Deployment reconciliation
+	// typeAvailableBusybox represents the status of the Deployment reconciliation,
+	// mirrored from the Available condition of the Deployment once it is reconciled
 	typeAvailableBusybox = "Available"
+	// typeProgressingBusybox is mirrored from the Progressing condition of the Deployment
+	typeProgressingBusybox = "Progressing"
 	// typeDegradedBusybox represents the status used when the custom resource is deleted and the finalizer operations are yet to occur.
 	typeDegradedBusybox = "Degraded"
 )
@@ -233,15 +238,34 @@ func (r *BusyboxReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, err
 	}
 
-	// The CRD API defines that the Busybox type have a BusyboxSpec.Size field
-	// to set the quantity of Deployment instances to the desired state on the cluster.
-	// Therefore, the following code will ensure the Deployment size is the same as defined
-	// via the Size spec of the Custom Resource which we are reconciling.
-	size := busybox.Spec.Size
-	if *found.Spec.Replicas != size {
-		found.Spec.Replicas = &size
-		if err = r.Update(ctx, found); err != nil {
-			log.Error(err, "Failed to update Deployment",
+	// The CRD API defines that the Busybox type have the BusyboxSpec.Replicas,
+	// BusyboxSpec.Env, BusyboxSpec.PodLabels and BusyboxSpec.Strategy fields
+	// to set the desired state of the Deployment on the cluster, which runs the Operand image of the manager.
+	// Therefore, the following code will detect when the Deployment drifted from the spec of the Custom Resource
+	// which we are reconciling, or from the image, and will patch it.
+	desired, err := r.deploymentForBusybox(busybox)
+	if err != nil {
+		log.Error(err, "Failed to define the desired Deployment resource for Busybox")
+		return ctrl.Result{}, err
+	}
+	// The selector of a Deployment is immutable, so its Pods keep the labels of the selector
+	// found on the cluster, e.g. the version of the image the Deployment was created with
+	if found.Spec.Selector != nil {
+		maps.Copy(desired.Spec.Template.Labels, found.Spec.Selector.MatchLabels)
+	}
+	if deploymentDriftedForBusybox(found, desired) {
+		log.Info("Patching the Deployment which drifted from the custom resource",
+			"Deployment.Namespace", found.Namespace, "Deployment.Name", found.Name)
+		patch := client.MergeFrom(found.DeepCopy())
+		found.Spec.Replicas = desired.Spec.Replicas
+		if desired.Spec.Strategy.Type != "" {
+			found.Spec.Strategy = desired.Spec.Strategy
+		}
+		found.Spec.Template.Labels = desired.Spec.Template.Labels
+		found.Spec.Template.Spec.Containers[0].Image = desired.Spec.Template.Spec.Containers[0].Image
+		found.Spec.Template.Spec.Containers[0].Env = desired.Spec.Template.Spec.Containers[0].Env
+		if err = r.Patch(ctx, found, patch); err != nil {
+			log.Error(err, "Failed to patch Deployment",
 				"Deployment.Namespace", found.Namespace, "Deployment.Name", found.Name)
 
 			// Re-fetch the busybox Custom Resource before updating the status
@@ -255,8 +279,8 @@ func (r *BusyboxReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 			// The following implementation will update the status
 			meta.SetStatusCondition(&busybox.Status.Conditions, metav1.Condition{Type: typeAvailableBusybox,
-				Status: metav1.ConditionFalse, Reason: "Resizing",
-				Message: fmt.Sprintf("Failed to update the size for the custom resource (%s): (%s)", busybox.Name, err)})
+				Status: metav1.ConditionFalse, Reason: "Updating",
+				Message: fmt.Sprintf("Failed to update the Deployment for the custom resource (%s): (%s)", busybox.Name, err)})
 
 			if err := r.Status().Update(ctx, busybox); err != nil {
 				log.Error(err, "Failed to update Busybox status")
@@ -266,22 +290,38 @@ func (r *BusyboxReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			return ctrl.Result{}, err
 		}
 
-		// Now, that we update the size we want to requeue the reconciliation
+		// Now, that we update the Deployment we want to requeue the reconciliation
 		// so that we can ensure that we have the latest state of the resource before
 		// update. Also, it will help ensure the desired state on the cluster
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	// The following implementation will update the status
-	meta.SetStatusCondition(&busybox.Status.Conditions, metav1.Condition{Type: typeAvailableBusybox,
-		Status: metav1.ConditionTrue, Reason: "Reconciling",
-		Message: fmt.Sprintf("Deployment for custom resource (%s) with %d replicas created successfully", busybox.Name, size)})
+	// The Available and Progressing conditions of the Deployment are mirrored into the status of the
+	// custom resource. The Deployment is owned by the custom resource, so the changes of its status
+	// trigger a new reconciliation.
+	setDeploymentConditionsForBusybox(busybox, found)
+
+	// The progress of the rollout of the Deployment is also reported in the status of the custom resource
+	busybox.Status.ObservedGeneration = busybox.Generation
+	busybox.Status.Replicas = found.Status.Replicas
+	busybox.Status.UpdatedReplicas = found.Status.UpdatedReplicas
+	busybox.Status.AvailableReplicas = found.Status.AvailableReplicas
 
 	if err := r.Status().Update(ctx, busybox); err != nil {
 		log.Error(err, "Failed to update Busybox status")
 		return ctrl.Result{}, err
 	}
 
+	// The reconciliation is requeued until the rollout of the Deployment is complete, so that its progress
+	// is reported even when the changes of the status of the Deployment are not enough to trigger it,
+	// e.g. while the new Pods are not ready yet
+	if !deploymentRolledOutForBusybox(found) {
+		log.Info("Waiting for the rollout of the Deployment to complete",
+			"Deployment.Namespace", found.Namespace, "Deployment.Name", found.Name,
+			"UpdatedReplicas", found.Status.UpdatedReplicas, "Replicas", ptr.Deref(found.Spec.Replicas, 1))
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -309,7 +349,13 @@ func (r *BusyboxReconciler) doFinalizerOperationsForBusybox(cr *examplecomv1alph
 func (r *BusyboxReconciler) deploymentForBusybox(
 	busybox *examplecomv1alpha1.Busybox) (*appsv1.Deployment, error) {
 	ls := labelsForBusybox()
-	replicas := busybox.Spec.Size
+	replicas := busybox.Spec.Replicas
+
+	// The Pods have the labels defined in the custom resource in addition to the ones used
+	// by the selector of the Deployment, which cannot be overridden
+	podLabels := map[string]string{}
+	maps.Copy(podLabels, busybox.Spec.PodLabels)
+	maps.Copy(podLabels, ls)
 
 	// Get the Operand image
 	image, err := imageForBusybox()
@@ -324,12 +370,13 @@ func (r *BusyboxReconciler) deploymentForBusybox(
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &replicas,
+			Strategy: busybox.Spec.Strategy,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: ls,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: ls,
+					Labels: podLabels,
 				},
 				Spec: corev1.PodSpec{
 					// TODO(user): Uncomment the following code to configure the nodeAffinity expression
@@ -372,7 +419,8 @@ func (r *BusyboxReconciler) deploymentForBusybox(
 					Containers: []corev1.Container{{
 						Image:           image,
 						Name:            "busybox",
-						ImagePullPolicy: corev1.PullIfNotPresent,
+						ImagePullPolicy: imagePullPolicyForBusybox(),
+						Env:             busybox.Spec.Env,
 						// Ensure restrictive context for the container
 						// More info: https://kubernetes.io/docs/concepts/security/pod-security-standards/#restricted
 						SecurityContext: &corev1.SecurityContext{
@@ -398,6 +446,86 @@ func (r *BusyboxReconciler) deploymentForBusybox(
 	return dep, nil
 }
 
+// deploymentDriftedForBusybox returns true when the replicas, the strategy, the Pod labels, the image
+// or the environment variables of the Deployment found on the cluster differ from the desired ones.
+// Note that the environment variables are compared after the defaulting of the Kubernetes API,
+// e.g. the apiVersion of a fieldRef should be set in the custom resource to not be seen as a drift.
+func deploymentDriftedForBusybox(found, desired *appsv1.Deployment) bool {
+	if len(found.Spec.Template.Spec.Containers) == 0 {
+		return true
+	}
+	return ptr.Deref(found.Spec.Replicas, 1) != ptr.Deref(desired.Spec.Replicas, 1) ||
+		strategyDriftedForBusybox(found.Spec.Strategy, desired.Spec.Strategy) ||
+		!equality.Semantic.DeepEqual(found.Spec.Template.Labels, desired.Spec.Template.Labels) ||
+		found.Spec.Template.Spec.Containers[0].Image != desired.Spec.Template.Spec.Containers[0].Image ||
+		!equality.Semantic.DeepEqual(found.Spec.Template.Spec.Containers[0].Env,
+			desired.Spec.Template.Spec.Containers[0].Env)
+}
+
+// strategyDriftedForBusybox returns true when the strategy of the Deployment found on the cluster
+// differs from the desired one. Only the fields set in the custom resource are compared, since the
+// Kubernetes API defaults the others, e.g. the maxSurge and maxUnavailable of a RollingUpdate to 25%.
+func strategyDriftedForBusybox(found, desired appsv1.DeploymentStrategy) bool {
+	if desired.Type == "" {
+		return false
+	}
+	if found.Type != desired.Type {
+		return true
+	}
+	if desired.RollingUpdate == nil {
+		return false
+	}
+	if found.RollingUpdate == nil {
+		return true
+	}
+	return (desired.RollingUpdate.MaxSurge != nil &&
+		!equality.Semantic.DeepEqual(found.RollingUpdate.MaxSurge, desired.RollingUpdate.MaxSurge)) ||
+		(desired.RollingUpdate.MaxUnavailable != nil &&
+			!equality.Semantic.DeepEqual(found.RollingUpdate.MaxUnavailable, desired.RollingUpdate.MaxUnavailable))
+}
+
+// deploymentRolledOutForBusybox returns true when the rollout of the Deployment is complete,
+// i.e. when the Deployment controller observed its latest spec and all its Pods run the desired Pod template
+// and are available, as checked by kubectl rollout status.
+func deploymentRolledOutForBusybox(dep *appsv1.Deployment) bool {
+	replicas := ptr.Deref(dep.Spec.Replicas, 1)
+	return dep.Status.ObservedGeneration >= dep.Generation &&
+		dep.Status.UpdatedReplicas == replicas &&
+		dep.Status.Replicas == dep.Status.UpdatedReplicas &&
+		dep.Status.AvailableReplicas == dep.Status.UpdatedReplicas
+}
+
+// setDeploymentConditionsForBusybox mirrors the Available and Progressing conditions of the
+// Deployment into the status of the custom resource. The conditions are Unknown until the Deployment
+// reports them, e.g. while its controller has not observed it yet.
+func setDeploymentConditionsForBusybox(
+	busybox *examplecomv1alpha1.Busybox, dep *appsv1.Deployment) {
+	mirrored := map[string]appsv1.DeploymentConditionType{
+		typeAvailableBusybox:   appsv1.DeploymentAvailable,
+		typeProgressingBusybox: appsv1.DeploymentProgressing,
+	}
+	for conditionType, deploymentConditionType := range mirrored {
+		condition := metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "DeploymentStatusUnknown",
+			Message:            fmt.Sprintf("The Deployment %s has not reported its %s condition", dep.Name, deploymentConditionType),
+			ObservedGeneration: busybox.Generation,
+		}
+		for _, deploymentCondition := range dep.Status.Conditions {
+			if deploymentCondition.Type != deploymentConditionType {
+				continue
+			}
+			condition.Status = metav1.ConditionStatus(deploymentCondition.Status)
+			if deploymentCondition.Reason != "" {
+				condition.Reason = deploymentCondition.Reason
+			}
+			condition.Message = deploymentCondition.Message
+		}
+		meta.SetStatusCondition(&busybox.Status.Conditions, condition)
+	}
+}
+
 // labelsForBusybox returns the labels for selecting the resources
 // More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/common-labels/
 func labelsForBusybox() map[string]string {
@@ -424,6 +552,15 @@ func imageForBusybox() (string, error) {
 	return image, nil
 }
 
+// imagePullPolicyForBusybox gets the pull policy of the Operand image from the
+// BUSYBOX_IMAGE_PULL_POLICY environment variable, which defaults to IfNotPresent
+func imagePullPolicyForBusybox() corev1.PullPolicy {
+	if policy := os.Getenv("BUSYBOX_IMAGE_PULL_POLICY"); policy != "" {
+		return corev1.PullPolicy(policy)
+	}
+	return corev1.PullIfNotPresent
+}
+
 // SetupWithManager sets up the controller with the Manager.
 // The whole idea is to be watching the resources that matter for the controller.
 // When a resource that the controller is interested in changes, the Watch triggers
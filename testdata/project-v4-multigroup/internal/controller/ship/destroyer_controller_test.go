@@ -37,8 +37,7 @@ var _ = Describe("Destroyer Controller", func() {
 		ctx := context.Background()
 
 		typeNamespacedName := types.NamespacedName{
-			Name:      resourceName,
-			Namespace: "default", // TODO(user):Modify as needed
+			Name: resourceName,
 		}
 		destroyer := &shipv1.Destroyer{}
 
@@ -48,8 +47,7 @@ var _ = Describe("Destroyer Controller", func() {
 			if err != nil && errors.IsNotFound(err) {
 				resource := &shipv1.Destroyer{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:      resourceName,
-						Namespace: "default",
+						Name: resourceName,
 					},
 					// TODO(user): Specify other spec details if needed.
 				}
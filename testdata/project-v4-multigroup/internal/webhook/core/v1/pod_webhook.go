@@ -66,6 +66,8 @@ func (v *PodCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Obj
 	podlog.Info("Validation for Pod upon creation", "name", pod.GetName())
 
 	// TODO(user): fill in your validation logic upon object creation.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.replicas above 10 may exhaust the cluster quota"}, nil
 
 	return nil, nil
 }
@@ -79,6 +81,8 @@ func (v *PodCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj
 	podlog.Info("Validation for Pod upon update", "name", pod.GetName())
 
 	// TODO(user): fill in your validation logic upon object update.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.foo is deprecated and will be removed in a future version"}, nil
 
 	return nil, nil
 }
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	crewv1 "sigs.k8s.io/kubebuilder/testdata/project-v4-multigroup/api/crew/v1"
+)
+
+// These tests send the Captain objects to the API server of the test environment. envtest installs the
+// webhook configurations of config/webhook pointing to the webhook server of the manager started by the suite,
+// which serves them with self-signed certificates, so the admission is verified end-to-end as in a cluster
+// rather than by calling the webhooks directly. Run 'make manifests' after changing the webhook markers.
+var _ = Describe("Captain Webhook Integration", func() {
+	var obj *crewv1.Captain
+
+	BeforeEach(func() {
+		obj = &crewv1.Captain{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-captain-",
+				Namespace:    "default",
+			},
+		}
+		// TODO (user): Set the fields required to create a valid Captain
+	})
+
+	Context("When creating Captain through the API server under Defaulting Webhook", func() {
+		It("Should register the mutating webhook in the API server", func() {
+			path := "/mutate-crew-testproject-org-v1-captain"
+			Expect(testEnv.WebhookInstallOptions.MutatingWebhooks).To(ContainElement(
+				HaveField("Webhooks", ContainElement(HaveField("ClientConfig.URL", HaveValue(HaveSuffix(path)))))))
+		})
+
+		It("Should admit the creation and apply the defaults", func() {
+			By("creating the Captain with a dry run, which calls the webhooks without persisting it")
+			Expect(k8sClient.Create(ctx, obj, client.DryRunAll)).To(Succeed())
+			// TODO (user): Check the defaults set by the webhook in the object returned by the API server, e.g.:
+			// Expect(obj.Spec.SomeFieldWithDefault).To(Equal("default_value"))
+		})
+	})
+
+	Context("When creating or updating Captain through the API server under Validating Webhook", func() {
+		It("Should register the validating webhook in the API server", func() {
+			path := "/validate-crew-testproject-org-v1-captain"
+			Expect(testEnv.WebhookInstallOptions.ValidatingWebhooks).To(ContainElement(
+				HaveField("Webhooks", ContainElement(HaveField("ClientConfig.URL", HaveValue(HaveSuffix(path)))))))
+		})
+
+		It("Should admit the creation", func() {
+			By("creating the Captain with a dry run, which calls the webhooks without persisting it")
+			Expect(k8sClient.Create(ctx, obj, client.DryRunAll)).To(Succeed())
+		})
+
+		It("Should admit the update", func() {
+			By("creating the Captain")
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, obj))).To(Succeed())
+			})
+
+			By("updating the Captain with a dry run")
+			obj.Labels = map[string]string{"updated": "true"}
+			Expect(k8sClient.Update(ctx, obj, client.DryRunAll)).To(Succeed())
+		})
+
+		// TODO (user): Add the cases where the API server must deny the request, e.g.:
+		// It("Should deny creation if a required field is missing", func() {
+		//     obj.Spec.SomeRequiredField = ""
+		//     Expect(k8sClient.Create(ctx, obj, client.DryRunAll)).To(MatchError(ContainSubstring("someRequiredField")))
+		// })
+	})
+})
@@ -67,6 +67,8 @@ func (v *MemcachedCustomValidator) ValidateCreate(ctx context.Context, obj runti
 	memcachedlog.Info("Validation for Memcached upon creation", "name", memcached.GetName())
 
 	// TODO(user): fill in your validation logic upon object creation.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.replicas above 10 may exhaust the cluster quota"}, nil
 
 	return nil, nil
 }
@@ -80,6 +82,8 @@ func (v *MemcachedCustomValidator) ValidateUpdate(ctx context.Context, oldObj, n
 	memcachedlog.Info("Validation for Memcached upon update", "name", memcached.GetName())
 
 	// TODO(user): fill in your validation logic upon object update.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.foo is deprecated and will be removed in a future version"}, nil
 
 	return nil, nil
 }
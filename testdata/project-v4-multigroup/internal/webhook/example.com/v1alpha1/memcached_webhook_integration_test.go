@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	examplecomv1alpha1 "sigs.k8s.io/kubebuilder/testdata/project-v4-multigroup/api/example.com/v1alpha1"
+)
+
+// These tests send the Memcached objects to the API server of the test environment. envtest installs the
+// webhook configurations of config/webhook pointing to the webhook server of the manager started by the suite,
+// which serves them with self-signed certificates, so the admission is verified end-to-end as in a cluster
+// rather than by calling the webhooks directly. Run 'make manifests' after changing the webhook markers.
+var _ = Describe("Memcached Webhook Integration", func() {
+	var obj *examplecomv1alpha1.Memcached
+
+	BeforeEach(func() {
+		obj = &examplecomv1alpha1.Memcached{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-memcached-",
+				Namespace:    "default",
+			},
+		}
+		// TODO (user): Set the fields required to create a valid Memcached
+	})
+
+	Context("When creating or updating Memcached through the API server under Validating Webhook", func() {
+		It("Should register the validating webhook in the API server", func() {
+			path := "/validate-example-com-testproject-org-v1alpha1-memcached"
+			Expect(testEnv.WebhookInstallOptions.ValidatingWebhooks).To(ContainElement(
+				HaveField("Webhooks", ContainElement(HaveField("ClientConfig.URL", HaveValue(HaveSuffix(path)))))))
+		})
+
+		It("Should admit the creation", func() {
+			By("creating the Memcached with a dry run, which calls the webhooks without persisting it")
+			Expect(k8sClient.Create(ctx, obj, client.DryRunAll)).To(Succeed())
+		})
+
+		It("Should admit the update", func() {
+			By("creating the Memcached")
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, obj))).To(Succeed())
+			})
+
+			By("updating the Memcached with a dry run")
+			obj.Labels = map[string]string{"updated": "true"}
+			Expect(k8sClient.Update(ctx, obj, client.DryRunAll)).To(Succeed())
+		})
+
+		// TODO (user): Add the cases where the API server must deny the request, e.g.:
+		// It("Should deny creation if a required field is missing", func() {
+		//     obj.Spec.SomeRequiredField = ""
+		//     Expect(k8sClient.Create(ctx, obj, client.DryRunAll)).To(MatchError(ContainSubstring("someRequiredField")))
+		// })
+	})
+})
@@ -67,6 +67,8 @@ func (v *CruiserCustomValidator) ValidateCreate(ctx context.Context, obj runtime
 	cruiserlog.Info("Validation for Cruiser upon creation", "name", cruiser.GetName())
 
 	// TODO(user): fill in your validation logic upon object creation.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.replicas above 10 may exhaust the cluster quota"}, nil
 
 	return nil, nil
 }
@@ -80,6 +82,8 @@ func (v *CruiserCustomValidator) ValidateUpdate(ctx context.Context, oldObj, new
 	cruiserlog.Info("Validation for Cruiser upon update", "name", cruiser.GetName())
 
 	// TODO(user): fill in your validation logic upon object update.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.foo is deprecated and will be removed in a future version"}, nil
 
 	return nil, nil
 }
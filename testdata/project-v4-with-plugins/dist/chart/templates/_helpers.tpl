@@ -1,15 +1,50 @@
+
 {{- define "chart.name" -}}
-{{- if .Chart }}
-  {{- if .Chart.Name }}
-    {{- .Chart.Name | trunc 63 | trimSuffix "-" }}
-  {{- else if .Values.nameOverride }}
-    {{ .Values.nameOverride | trunc 63 | trimSuffix "-" }}
-  {{- else }}
-    project-v4-with-plugins
-  {{- end }}
+{{- default .Chart.Name .Values.nameOverride | trunc 63 | trimSuffix "-" }}
+{{- end }}
+
+
+{{- define "chart.fullname" -}}
+{{- if .Values.fullnameOverride }}
+{{- .Values.fullnameOverride | trunc 63 | trimSuffix "-" }}
 {{- else }}
-  project-v4-with-plugins
+{{- $name := default .Chart.Name .Values.nameOverride }}
+{{- if contains $name .Release.Name }}
+{{- .Release.Name | trunc 63 | trimSuffix "-" }}
+{{- else }}
+{{- printf "%s-%s" .Release.Name $name | trunc 63 | trimSuffix "-" }}
+{{- end }}
+{{- end }}
+{{- end }}
+
+
+{{- define "chart.serviceAccountName" -}}
+{{- default (printf "%s-controller-manager" (include "chart.fullname" .)) .Values.controllerManager.serviceAccountName }}
+{{- end }}
+
+
+{{- define "chart.webhookServiceName" -}}
+{{- dig "service" "name" "" (.Values.webhook | default dict) | default (printf "%s-webhook-service" (include "chart.fullname" .)) | trunc 63 | trimSuffix "-" }}
+{{- end }}
+
+
+{{- define "chart.webhookServicePort" -}}
+{{- dig "service" "port" "" (.Values.webhook | default dict) | default 443 }}
 {{- end }}
+
+
+{{- define "chart.webhookPort" -}}
+{{- (.Values.webhook | default dict).port | default 9443 }}
+{{- end }}
+
+
+{{- define "chart.webhookCertSecretName" -}}
+{{- (.Values.webhook | default dict).certSecretName | default "webhook-server-cert" }}
+{{- end }}
+
+
+{{- define "chart.metricsServiceName" -}}
+{{- dig "service" "name" "" (.Values.metrics | default dict) | default (printf "%s-controller-manager-metrics-service" (include "chart.fullname" .)) | trunc 63 | trimSuffix "-" }}
 {{- end }}
 
 
@@ -48,3 +83,17 @@ app.kubernetes.io/instance: {{ .Release.Name }}
     $hasValidating = true }}{{- end }}
 {{- end }}
 {{ $hasValidating }}}}{{- end }}
+
+
+{{- define "chart.certManagerEnabled" -}}
+{{- if and .Values.certmanager.enable (or .Values.certmanager.force (.Capabilities.APIVersions.Has "cert-manager.io/v1/Certificate")) -}}
+true
+{{- end }}
+{{- end }}
+
+
+{{- define "chart.prometheusEnabled" -}}
+{{- if and .Values.prometheus.enable (or .Values.prometheus.force (.Capabilities.APIVersions.Has "monitoring.coreos.com/v1/ServiceMonitor")) -}}
+true
+{{- end }}
+{{- end }}
@@ -19,12 +19,14 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,8 +46,11 @@ const memcachedFinalizer = "example.com.testproject.org/finalizer"
 
 // Definitions to manage status conditions
 const (
-	// typeAvailableMemcached represents the status of the Deployment reconciliation
+	// typeAvailableMemcached represents the status of the Deployment reconciliation,
+	// mirrored from the Available condition of the Deployment once it is reconciled
 	typeAvailableMemcached = "Available"
+	// typeProgressingMemcached is mirrored from the Progressing condition of the Deployment
+	typeProgressingMemcached = "Progressing"
 	// typeDegradedMemcached represents the status used when the custom resource is deleted and the finalizer operations are yet to occur.
 	typeDegradedMemcached = "Degraded"
 )
@@ -233,15 +238,34 @@ func (r *MemcachedReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
-	// The CRD API defines that the Memcached type have a MemcachedSpec.Size field
-	// to set the quantity of Deployment instances to the desired state on the cluster.
-	// Therefore, the following code will ensure the Deployment size is the same as defined
-	// via the Size spec of the Custom Resource which we are reconciling.
-	size := memcached.Spec.Size
-	if *found.Spec.Replicas != size {
-		found.Spec.Replicas = &size
-		if err = r.Update(ctx, found); err != nil {
-			log.Error(err, "Failed to update Deployment",
+	// The CRD API defines that the Memcached type have the MemcachedSpec.Replicas,
+	// MemcachedSpec.Env, MemcachedSpec.PodLabels and MemcachedSpec.Strategy fields
+	// to set the desired state of the Deployment on the cluster, which runs the Operand image of the manager.
+	// Therefore, the following code will detect when the Deployment drifted from the spec of the Custom Resource
+	// which we are reconciling, or from the image, and will patch it.
+	desired, err := r.deploymentForMemcached(memcached)
+	if err != nil {
+		log.Error(err, "Failed to define the desired Deployment resource for Memcached")
+		return ctrl.Result{}, err
+	}
+	// The selector of a Deployment is immutable, so its Pods keep the labels of the selector
+	// found on the cluster, e.g. the version of the image the Deployment was created with
+	if found.Spec.Selector != nil {
+		maps.Copy(desired.Spec.Template.Labels, found.Spec.Selector.MatchLabels)
+	}
+	if deploymentDriftedForMemcached(found, desired) {
+		log.Info("Patching the Deployment which drifted from the custom resource",
+			"Deployment.Namespace", found.Namespace, "Deployment.Name", found.Name)
+		patch := client.MergeFrom(found.DeepCopy())
+		found.Spec.Replicas = desired.Spec.Replicas
+		if desired.Spec.Strategy.Type != "" {
+			found.Spec.Strategy = desired.Spec.Strategy
+		}
+		found.Spec.Template.Labels = desired.Spec.Template.Labels
+		found.Spec.Template.Spec.Containers[0].Image = desired.Spec.Template.Spec.Containers[0].Image
+		found.Spec.Template.Spec.Containers[0].Env = desired.Spec.Template.Spec.Containers[0].Env
+		if err = r.Patch(ctx, found, patch); err != nil {
+			log.Error(err, "Failed to patch Deployment",
 				"Deployment.Namespace", found.Namespace, "Deployment.Name", found.Name)
 
 			// Re-fetch the memcached Custom Resource before updating the status
@@ -255,8 +279,8 @@ func (r *MemcachedReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 			// The following implementation will update the status
 			meta.SetStatusCondition(&memcached.Status.Conditions, metav1.Condition{Type: typeAvailableMemcached,
-				Status: metav1.ConditionFalse, Reason: "Resizing",
-				Message: fmt.Sprintf("Failed to update the size for the custom resource (%s): (%s)", memcached.Name, err)})
+				Status: metav1.ConditionFalse, Reason: "Updating",
+				Message: fmt.Sprintf("Failed to update the Deployment for the custom resource (%s): (%s)", memcached.Name, err)})
 
 			if err := r.Status().Update(ctx, memcached); err != nil {
 				log.Error(err, "Failed to update Memcached status")
@@ -266,22 +290,38 @@ func (r *MemcachedReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			return ctrl.Result{}, err
 		}
 
-		// Now, that we update the size we want to requeue the reconciliation
+		// Now, that we update the Deployment we want to requeue the reconciliation
 		// so that we can ensure that we have the latest state of the resource before
 		// update. Also, it will help ensure the desired state on the cluster
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	// The following implementation will update the status
-	meta.SetStatusCondition(&memcached.Status.Conditions, metav1.Condition{Type: typeAvailableMemcached,
-		Status: metav1.ConditionTrue, Reason: "Reconciling",
-		Message: fmt.Sprintf("Deployment for custom resource (%s) with %d replicas created successfully", memcached.Name, size)})
+	// The Available and Progressing conditions of the Deployment are mirrored into the status of the
+	// custom resource. The Deployment is owned by the custom resource, so the changes of its status
+	// trigger a new reconciliation.
+	setDeploymentConditionsForMemcached(memcached, found)
+
+	// The progress of the rollout of the Deployment is also reported in the status of the custom resource
+	memcached.Status.ObservedGeneration = memcached.Generation
+	memcached.Status.Replicas = found.Status.Replicas
+	memcached.Status.UpdatedReplicas = found.Status.UpdatedReplicas
+	memcached.Status.AvailableReplicas = found.Status.AvailableReplicas
 
 	if err := r.Status().Update(ctx, memcached); err != nil {
 		log.Error(err, "Failed to update Memcached status")
 		return ctrl.Result{}, err
 	}
 
+	// The reconciliation is requeued until the rollout of the Deployment is complete, so that its progress
+	// is reported even when the changes of the status of the Deployment are not enough to trigger it,
+	// e.g. while the new Pods are not ready yet
+	if !deploymentRolledOutForMemcached(found) {
+		log.Info("Waiting for the rollout of the Deployment to complete",
+			"Deployment.Namespace", found.Namespace, "Deployment.Name", found.Name,
+			"UpdatedReplicas", found.Status.UpdatedReplicas, "Replicas", ptr.Deref(found.Spec.Replicas, 1))
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -309,7 +349,13 @@ func (r *MemcachedReconciler) doFinalizerOperationsForMemcached(cr *examplecomv1
 func (r *MemcachedReconciler) deploymentForMemcached(
 	memcached *examplecomv1alpha1.Memcached) (*appsv1.Deployment, error) {
 	ls := labelsForMemcached()
-	replicas := memcached.Spec.Size
+	replicas := memcached.Spec.Replicas
+
+	// The Pods have the labels defined in the custom resource in addition to the ones used
+	// by the selector of the Deployment, which cannot be overridden
+	podLabels := map[string]string{}
+	maps.Copy(podLabels, memcached.Spec.PodLabels)
+	maps.Copy(podLabels, ls)
 
 	// Get the Operand image
 	image, err := imageForMemcached()
@@ -324,12 +370,13 @@ func (r *MemcachedReconciler) deploymentForMemcached(
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &replicas,
+			Strategy: memcached.Spec.Strategy,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: ls,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: ls,
+					Labels: podLabels,
 				},
 				Spec: corev1.PodSpec{
 					// TODO(user): Uncomment the following code to configure the nodeAffinity expression
@@ -372,12 +419,13 @@ func (r *MemcachedReconciler) deploymentForMemcached(
 					Containers: []corev1.Container{{
 						Image:           image,
 						Name:            "memcached",
-						ImagePullPolicy: corev1.PullIfNotPresent,
+						ImagePullPolicy: imagePullPolicyForMemcached(),
+						Env:             memcached.Spec.Env,
 						// Ensure restrictive context for the container
 						// More info: https://kubernetes.io/docs/concepts/security/pod-security-standards/#restricted
 						SecurityContext: &corev1.SecurityContext{
 							RunAsNonRoot:             ptr.To(true),
-							RunAsUser:                ptr.To(int64(1001)),
+							RunAsUser:                ptr.To(runAsUserForMemcached()),
 							AllowPrivilegeEscalation: ptr.To(false),
 							Capabilities: &corev1.Capabilities{
 								Drop: []corev1.Capability{
@@ -386,7 +434,7 @@ func (r *MemcachedReconciler) deploymentForMemcached(
 							},
 						},
 						Ports: []corev1.ContainerPort{{
-							ContainerPort: memcached.Spec.ContainerPort,
+							ContainerPort: containerPortForMemcached(memcached),
 							Name:          "memcached",
 						}},
 						Command: []string{"memcached", "--memory-limit=64", "-o", "modern", "-v"},
@@ -404,6 +452,86 @@ func (r *MemcachedReconciler) deploymentForMemcached(
 	return dep, nil
 }
 
+// deploymentDriftedForMemcached returns true when the replicas, the strategy, the Pod labels, the image
+// or the environment variables of the Deployment found on the cluster differ from the desired ones.
+// Note that the environment variables are compared after the defaulting of the Kubernetes API,
+// e.g. the apiVersion of a fieldRef should be set in the custom resource to not be seen as a drift.
+func deploymentDriftedForMemcached(found, desired *appsv1.Deployment) bool {
+	if len(found.Spec.Template.Spec.Containers) == 0 {
+		return true
+	}
+	return ptr.Deref(found.Spec.Replicas, 1) != ptr.Deref(desired.Spec.Replicas, 1) ||
+		strategyDriftedForMemcached(found.Spec.Strategy, desired.Spec.Strategy) ||
+		!equality.Semantic.DeepEqual(found.Spec.Template.Labels, desired.Spec.Template.Labels) ||
+		found.Spec.Template.Spec.Containers[0].Image != desired.Spec.Template.Spec.Containers[0].Image ||
+		!equality.Semantic.DeepEqual(found.Spec.Template.Spec.Containers[0].Env,
+			desired.Spec.Template.Spec.Containers[0].Env)
+}
+
+// strategyDriftedForMemcached returns true when the strategy of the Deployment found on the cluster
+// differs from the desired one. Only the fields set in the custom resource are compared, since the
+// Kubernetes API defaults the others, e.g. the maxSurge and maxUnavailable of a RollingUpdate to 25%.
+func strategyDriftedForMemcached(found, desired appsv1.DeploymentStrategy) bool {
+	if desired.Type == "" {
+		return false
+	}
+	if found.Type != desired.Type {
+		return true
+	}
+	if desired.RollingUpdate == nil {
+		return false
+	}
+	if found.RollingUpdate == nil {
+		return true
+	}
+	return (desired.RollingUpdate.MaxSurge != nil &&
+		!equality.Semantic.DeepEqual(found.RollingUpdate.MaxSurge, desired.RollingUpdate.MaxSurge)) ||
+		(desired.RollingUpdate.MaxUnavailable != nil &&
+			!equality.Semantic.DeepEqual(found.RollingUpdate.MaxUnavailable, desired.RollingUpdate.MaxUnavailable))
+}
+
+// deploymentRolledOutForMemcached returns true when the rollout of the Deployment is complete,
+// i.e. when the Deployment controller observed its latest spec and all its Pods run the desired Pod template
+// and are available, as checked by kubectl rollout status.
+func deploymentRolledOutForMemcached(dep *appsv1.Deployment) bool {
+	replicas := ptr.Deref(dep.Spec.Replicas, 1)
+	return dep.Status.ObservedGeneration >= dep.Generation &&
+		dep.Status.UpdatedReplicas == replicas &&
+		dep.Status.Replicas == dep.Status.UpdatedReplicas &&
+		dep.Status.AvailableReplicas == dep.Status.UpdatedReplicas
+}
+
+// setDeploymentConditionsForMemcached mirrors the Available and Progressing conditions of the
+// Deployment into the status of the custom resource. The conditions are Unknown until the Deployment
+// reports them, e.g. while its controller has not observed it yet.
+func setDeploymentConditionsForMemcached(
+	memcached *examplecomv1alpha1.Memcached, dep *appsv1.Deployment) {
+	mirrored := map[string]appsv1.DeploymentConditionType{
+		typeAvailableMemcached:   appsv1.DeploymentAvailable,
+		typeProgressingMemcached: appsv1.DeploymentProgressing,
+	}
+	for conditionType, deploymentConditionType := range mirrored {
+		condition := metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "DeploymentStatusUnknown",
+			Message:            fmt.Sprintf("The Deployment %s has not reported its %s condition", dep.Name, deploymentConditionType),
+			ObservedGeneration: memcached.Generation,
+		}
+		for _, deploymentCondition := range dep.Status.Conditions {
+			if deploymentCondition.Type != deploymentConditionType {
+				continue
+			}
+			condition.Status = metav1.ConditionStatus(deploymentCondition.Status)
+			if deploymentCondition.Reason != "" {
+				condition.Reason = deploymentCondition.Reason
+			}
+			condition.Message = deploymentCondition.Message
+		}
+		meta.SetStatusCondition(&memcached.Status.Conditions, condition)
+	}
+}
+
 // labelsForMemcached returns the labels for selecting the resources
 // More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/common-labels/
 func labelsForMemcached() map[string]string {
@@ -430,6 +558,34 @@ func imageForMemcached() (string, error) {
 	return image, nil
 }
 
+// imagePullPolicyForMemcached gets the pull policy of the Operand image from the
+// MEMCACHED_IMAGE_PULL_POLICY environment variable, which defaults to IfNotPresent
+func imagePullPolicyForMemcached() corev1.PullPolicy {
+	if policy := os.Getenv("MEMCACHED_IMAGE_PULL_POLICY"); policy != "" {
+		return corev1.PullPolicy(policy)
+	}
+	return corev1.PullIfNotPresent
+}
+
+// containerPortForMemcached gets the port of the Operand from the spec of the custom resource,
+// or from the MEMCACHED_CONTAINER_PORT environment variable when it is not set, which defaults to 11211
+func containerPortForMemcached(memcached *examplecomv1alpha1.Memcached) int32 {
+	if memcached.Spec.ContainerPort != 0 {
+		return memcached.Spec.ContainerPort
+	}
+	port := int32(11211)
+	_, _ = fmt.Sscan(os.Getenv("MEMCACHED_CONTAINER_PORT"), &port)
+	return port
+}
+
+// runAsUserForMemcached gets the user-id running the container of the Operand from the
+// MEMCACHED_RUN_AS_USER environment variable, which defaults to 1001
+func runAsUserForMemcached() int64 {
+	user := int64(1001)
+	_, _ = fmt.Sscan(os.Getenv("MEMCACHED_RUN_AS_USER"), &user)
+	return user
+}
+
 // SetupWithManager sets up the controller with the Manager.
 // The whole idea is to be watching the resources that matter for the controller.
 // When a resource that the controller is interested in changes, the Watch triggers
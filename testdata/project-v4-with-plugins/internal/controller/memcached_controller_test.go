@@ -27,8 +27,11 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	examplecomv1alpha1 "sigs.k8s.io/kubebuilder/testdata/project-v4-with-plugins/api/v1alpha1"
@@ -77,7 +80,14 @@ var _ = Describe("Memcached controller", func() {
 						Namespace: namespace.Name,
 					},
 					Spec: examplecomv1alpha1.MemcachedSpec{
-						Size:          1,
+						Replicas: 1,
+						Strategy: appsv1.DeploymentStrategy{
+							Type: appsv1.RollingUpdateDeploymentStrategyType,
+							RollingUpdate: &appsv1.RollingUpdateDeployment{
+								MaxSurge:       ptr.To(intstr.FromInt32(1)),
+								MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+							},
+						},
 						ContainerPort: 11211,
 					},
 				}
@@ -131,12 +141,73 @@ var _ = Describe("Memcached controller", func() {
 				g.Expect(k8sClient.Get(ctx, typeNamespacedName, found)).To(Succeed())
 			}).Should(Succeed())
 
-			By("Reconciling the custom resource again")
+			By("Changing the replicas, the strategy and the image of the Deployment to drift from the custom resource")
+			found := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, found)).To(Succeed())
+			Expect(found.Spec.Strategy.RollingUpdate).NotTo(BeNil())
+			Expect(found.Spec.Strategy.RollingUpdate.MaxUnavailable).To(Equal(ptr.To(intstr.FromInt32(0))))
+			found.Spec.Replicas = ptr.To(int32(2))
+			found.Spec.Strategy.RollingUpdate.MaxUnavailable = ptr.To(intstr.FromString("25%"))
+			found.Spec.Template.Spec.Containers[0].Image = "example.com/image:drifted"
+			Expect(k8sClient.Update(ctx, found)).To(Succeed())
+
+			By("Reconciling the custom resource to fix the drift")
 			_, err = memcachedReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
 
+			By("Checking if the Deployment has the replicas of the custom resource and the Operand image")
+			Eventually(func(g Gomega) {
+				found := &appsv1.Deployment{}
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, found)).To(Succeed())
+				g.Expect(*found.Spec.Replicas).To(Equal(int32(1)))
+				g.Expect(found.Spec.Strategy.RollingUpdate.MaxUnavailable).To(Equal(ptr.To(intstr.FromInt32(0))))
+				g.Expect(found.Spec.Template.Spec.Containers[0].Image).To(Equal("example.com/image:test"))
+			}).Should(Succeed())
+
+			By("Checking if the reconciliation is requeued until the rollout of the Deployment is complete")
+			result, err := memcachedReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			// The Deployment controller does not run in envtest, so its status is reported here
+			By("Reporting the Deployment as available and rolled out")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, found)).To(Succeed())
+			found.Status.ObservedGeneration = found.Generation
+			found.Status.Replicas = 1
+			found.Status.UpdatedReplicas = 1
+			found.Status.AvailableReplicas = 1
+			found.Status.Conditions = []appsv1.DeploymentCondition{
+				{
+					Type:   appsv1.DeploymentAvailable,
+					Status: corev1.ConditionTrue,
+					Reason: "MinimumReplicasAvailable",
+				},
+				{
+					Type:   appsv1.DeploymentProgressing,
+					Status: corev1.ConditionTrue,
+					Reason: "NewReplicaSetAvailable",
+				},
+			}
+			Expect(k8sClient.Status().Update(ctx, found)).To(Succeed())
+
+			By("Reconciling the custom resource again")
+			result, err = memcachedReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+
+			By("Checking the progress of the rollout reported in the status of the Memcached instance")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, memcached)).To(Succeed())
+			Expect(memcached.Status.ObservedGeneration).To(Equal(memcached.Generation))
+			Expect(memcached.Status.Replicas).To(Equal(int32(1)))
+			Expect(memcached.Status.UpdatedReplicas).To(Equal(int32(1)))
+			Expect(memcached.Status.AvailableReplicas).To(Equal(int32(1)))
+
 			By("Checking the latest Status Condition added to the Memcached instance")
 			Expect(k8sClient.Get(ctx, typeNamespacedName, memcached)).To(Succeed())
 			conditions := []metav1.Condition{}
@@ -144,7 +215,124 @@ var _ = Describe("Memcached controller", func() {
 				HaveField("Type", Equal(typeAvailableMemcached)), &conditions))
 			Expect(conditions).To(HaveLen(1), "Multiple conditions of type %s", typeAvailableMemcached)
 			Expect(conditions[0].Status).To(Equal(metav1.ConditionTrue), "condition %s", typeAvailableMemcached)
-			Expect(conditions[0].Reason).To(Equal("Reconciling"), "condition %s", typeAvailableMemcached)
+			Expect(conditions[0].Reason).To(Equal("MinimumReplicasAvailable"), "condition %s", typeAvailableMemcached)
+			Expect(meta.IsStatusConditionTrue(memcached.Status.Conditions, typeProgressingMemcached)).
+				To(BeTrue(), "condition %s", typeProgressingMemcached)
 		})
 	})
+
+	Context("Memcached Deployment drift", func() {
+		deployment := func(replicas int32, image string, env ...corev1.EnvVar) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To(replicas),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app.kubernetes.io/name": "test"}},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Image: image, Env: env}},
+						},
+					},
+				},
+			}
+		}
+
+		DescribeTable("should detect when the Deployment drifted from the desired one",
+			func(found *appsv1.Deployment, drifted bool) {
+				desired := deployment(1, "example.com/image:test", corev1.EnvVar{Name: "KEY", Value: "value"})
+				Expect(deploymentDriftedForMemcached(found, desired)).To(Equal(drifted))
+			},
+			Entry("when nothing changed",
+				deployment(1, "example.com/image:test", corev1.EnvVar{Name: "KEY", Value: "value"}), false),
+			Entry("when the replicas changed",
+				deployment(2, "example.com/image:test", corev1.EnvVar{Name: "KEY", Value: "value"}), true),
+			Entry("when the image changed",
+				deployment(1, "example.com/image:other", corev1.EnvVar{Name: "KEY", Value: "value"}), true),
+			Entry("when the env changed",
+				deployment(1, "example.com/image:test", corev1.EnvVar{Name: "KEY", Value: "other"}), true),
+			Entry("when the env was removed", deployment(1, "example.com/image:test"), true),
+			Entry("when the Pod labels changed", func() *appsv1.Deployment {
+				found := deployment(1, "example.com/image:test", corev1.EnvVar{Name: "KEY", Value: "value"})
+				found.Spec.Template.Labels["extra"] = "label"
+				return found
+			}(), true),
+		)
+
+		DescribeTable("should detect when the strategy of the Deployment drifted from the desired one",
+			func(found, desired appsv1.DeploymentStrategy, drifted bool) {
+				Expect(strategyDriftedForMemcached(found, desired)).To(Equal(drifted))
+			},
+			Entry("when no strategy is desired", appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+				appsv1.DeploymentStrategy{}, false),
+			Entry("when the type changed", appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+				appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}, true),
+			Entry("when the defaulted parameters are not desired", appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxSurge:       ptr.To(intstr.FromString("25%")),
+					MaxUnavailable: ptr.To(intstr.FromString("25%")),
+				},
+			}, appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}, false),
+			Entry("when the maxUnavailable changed", appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxSurge:       ptr.To(intstr.FromString("25%")),
+					MaxUnavailable: ptr.To(intstr.FromString("25%")),
+				},
+			}, appsv1.DeploymentStrategy{
+				Type:          appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{MaxUnavailable: ptr.To(intstr.FromInt32(0))},
+			}, true),
+		)
+
+		DescribeTable("should detect when the rollout of the Deployment is complete",
+			func(generation int64, status appsv1.DeploymentStatus, rolledOut bool) {
+				dep := &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Generation: generation},
+					Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+					Status:     status,
+				}
+				Expect(deploymentRolledOutForMemcached(dep)).To(Equal(rolledOut))
+			},
+			Entry("when all the Pods are updated and available", int64(2), appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2,
+			}, true),
+			Entry("when the latest spec was not observed", int64(3), appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2,
+			}, false),
+			Entry("when some Pods are not updated", int64(2), appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 1, AvailableReplicas: 2,
+			}, false),
+			Entry("when the old Pods are still terminating", int64(2), appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 2,
+			}, false),
+			Entry("when some updated Pods are not available", int64(2), appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1,
+			}, false),
+		)
+
+		DescribeTable("should mirror the conditions of the Deployment",
+			func(deploymentConditions []appsv1.DeploymentCondition, conditionType string,
+				status metav1.ConditionStatus, reason string) {
+				memcached := &examplecomv1alpha1.Memcached{}
+				dep := &appsv1.Deployment{Status: appsv1.DeploymentStatus{Conditions: deploymentConditions}}
+				setDeploymentConditionsForMemcached(memcached, dep)
+
+				condition := meta.FindStatusCondition(memcached.Status.Conditions, conditionType)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(status))
+				Expect(condition.Reason).To(Equal(reason))
+			},
+			Entry("Available when the Deployment has not reported its status", nil,
+				typeAvailableMemcached, metav1.ConditionUnknown, "DeploymentStatusUnknown"),
+			Entry("Available when the Deployment is available", []appsv1.DeploymentCondition{{
+				Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue, Reason: "MinimumReplicasAvailable",
+			}}, typeAvailableMemcached, metav1.ConditionTrue, "MinimumReplicasAvailable"),
+			Entry("Available when the Deployment is unavailable", []appsv1.DeploymentCondition{{
+				Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse, Reason: "MinimumReplicasUnavailable",
+			}}, typeAvailableMemcached, metav1.ConditionFalse, "MinimumReplicasUnavailable"),
+			Entry("Progressing when the rollout is stuck", []appsv1.DeploymentCondition{{
+				Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded",
+			}}, typeProgressingMemcached, metav1.ConditionFalse, "ProgressDeadlineExceeded"),
+		)
+	})
 })
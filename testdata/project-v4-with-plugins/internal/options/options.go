@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"flag"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// Options are the options of the manager, which are parsed from its command line flags.
+// Add the options of your project here, so that cmd/main.go can be scaffolded again
+// without losing them.
+type Options struct {
+	// MetricsBindAddress is the address the metrics endpoint binds to, "0" disables it.
+	MetricsBindAddress string
+	// SecureMetrics serves the metrics endpoint securely via HTTPS.
+	SecureMetrics bool
+	// MetricsCertPath is the directory that contains the metrics server certificate.
+	MetricsCertPath string
+	// MetricsCertName is the name of the metrics server certificate file.
+	MetricsCertName string
+	// MetricsCertKey is the name of the metrics server key file.
+	MetricsCertKey string
+
+	// WebhookPort is the port the webhook server listens on.
+	WebhookPort int
+	// WebhookCertPath is the directory that contains the webhook certificate.
+	WebhookCertPath string
+	// WebhookCertName is the name of the webhook certificate file.
+	WebhookCertName string
+	// WebhookCertKey is the name of the webhook key file.
+	WebhookCertKey string
+
+	// ProbeBindAddress is the address the health probe endpoint binds to.
+	ProbeBindAddress string
+
+	// LeaderElection ensures there is only one active manager.
+	LeaderElection bool
+	// LeaderElectionID is the name of the resource used as lock for the leader election.
+	LeaderElectionID string
+
+	// EnableHTTP2 enables HTTP/2 for the metrics and webhook servers.
+	EnableHTTP2 bool
+
+	// Zap are the options of the logger.
+	Zap zap.Options
+}
+
+// New returns the Options with their default values.
+func New() *Options {
+	return &Options{
+		MetricsBindAddress: "0",
+		SecureMetrics:      true,
+		MetricsCertName:    "tls.crt",
+		MetricsCertKey:     "tls.key",
+		WebhookPort:        9443,
+		WebhookCertName:    "tls.crt",
+		WebhookCertKey:     "tls.key",
+		ProbeBindAddress:   ":8081",
+		LeaderElectionID:   "a13ae5d8.testproject.org",
+		Zap: zap.Options{
+			Development: true,
+		},
+	}
+}
+
+// BindFlags binds the Options to the flags of the flag set, using their current values as defaults.
+func (o *Options) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.MetricsBindAddress, "metrics-bind-address", o.MetricsBindAddress,
+		"The address the metrics endpoint binds to. "+
+			"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
+	fs.BoolVar(&o.SecureMetrics, "metrics-secure", o.SecureMetrics,
+		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
+	fs.StringVar(&o.MetricsCertPath, "metrics-cert-path", o.MetricsCertPath,
+		"The directory that contains the metrics server certificate.")
+	fs.StringVar(&o.MetricsCertName, "metrics-cert-name", o.MetricsCertName,
+		"The name of the metrics server certificate file.")
+	fs.StringVar(&o.MetricsCertKey, "metrics-cert-key", o.MetricsCertKey, "The name of the metrics server key file.")
+	fs.IntVar(&o.WebhookPort, "webhook-port", o.WebhookPort, "The port the webhook server listens on.")
+	fs.StringVar(&o.WebhookCertPath, "webhook-cert-path", o.WebhookCertPath,
+		"The directory that contains the webhook certificate.")
+	fs.StringVar(&o.WebhookCertName, "webhook-cert-name", o.WebhookCertName, "The name of the webhook certificate file.")
+	fs.StringVar(&o.WebhookCertKey, "webhook-cert-key", o.WebhookCertKey, "The name of the webhook key file.")
+	fs.StringVar(&o.ProbeBindAddress, "health-probe-bind-address", o.ProbeBindAddress,
+		"The address the probe endpoint binds to.")
+	fs.BoolVar(&o.LeaderElection, "leader-elect", o.LeaderElection,
+		"Enable leader election for controller manager. "+
+			"Enabling this will ensure there is only one active controller manager.")
+	fs.StringVar(&o.LeaderElectionID, "leader-election-id", o.LeaderElectionID,
+		"The name of the resource used as lock for the leader election.")
+	fs.BoolVar(&o.EnableHTTP2, "enable-http2", o.EnableHTTP2,
+		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	o.Zap.BindFlags(fs)
+}
+
+// Validate checks the Options parsed from the flags.
+func (o *Options) Validate() error {
+	if o.WebhookPort < 1 || o.WebhookPort > 65535 {
+		return fmt.Errorf("invalid webhook port %d: it must be between 1 and 65535", o.WebhookPort)
+	}
+	if o.LeaderElection && o.LeaderElectionID == "" {
+		return fmt.Errorf("the leader election ID is required when the leader election is enabled")
+	}
+	return nil
+}
@@ -31,6 +31,7 @@ func (src *FirstMate) ConvertTo(dstRaw conversion.Hub) error {
 		"source: %s/%s, target: %s/%s", src.Namespace, src.Name, dst.Namespace, dst.Name)
 
 	// TODO(user): Implement conversion logic from v2 to v1
+	dst.ObjectMeta = src.ObjectMeta
 	return nil
 }
 
@@ -41,5 +42,6 @@ func (dst *FirstMate) ConvertFrom(srcRaw conversion.Hub) error {
 		"source: %s/%s, target: %s/%s", src.Namespace, src.Name, dst.Namespace, dst.Name)
 
 	// TODO(user): Implement conversion logic from v1 to v2
+	dst.ObjectMeta = src.ObjectMeta
 	return nil
 }
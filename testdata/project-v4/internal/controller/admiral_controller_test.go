@@ -37,8 +37,7 @@ var _ = Describe("Admiral Controller", func() {
 		ctx := context.Background()
 
 		typeNamespacedName := types.NamespacedName{
-			Name:      resourceName,
-			Namespace: "default", // TODO(user):Modify as needed
+			Name: resourceName,
 		}
 		admiral := &crewv1.Admiral{}
 
@@ -48,8 +47,7 @@ var _ = Describe("Admiral Controller", func() {
 			if err != nil && errors.IsNotFound(err) {
 				resource := &crewv1.Admiral{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:      resourceName,
-						Namespace: "default",
+						Name: resourceName,
 					},
 					// TODO(user): Specify other spec details if needed.
 				}
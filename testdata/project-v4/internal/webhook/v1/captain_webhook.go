@@ -95,6 +95,8 @@ func (v *CaptainCustomValidator) ValidateCreate(ctx context.Context, obj runtime
 	captainlog.Info("Validation for Captain upon creation", "name", captain.GetName())
 
 	// TODO(user): fill in your validation logic upon object creation.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.replicas above 10 may exhaust the cluster quota"}, nil
 
 	return nil, nil
 }
@@ -108,6 +110,8 @@ func (v *CaptainCustomValidator) ValidateUpdate(ctx context.Context, oldObj, new
 	captainlog.Info("Validation for Captain upon update", "name", captain.GetName())
 
 	// TODO(user): fill in your validation logic upon object update.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.foo is deprecated and will be removed in a future version"}, nil
 
 	return nil, nil
 }
@@ -94,6 +94,8 @@ func (v *DeploymentCustomValidator) ValidateCreate(ctx context.Context, obj runt
 	deploymentlog.Info("Validation for Deployment upon creation", "name", deployment.GetName())
 
 	// TODO(user): fill in your validation logic upon object creation.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.replicas above 10 may exhaust the cluster quota"}, nil
 
 	return nil, nil
 }
@@ -107,6 +109,8 @@ func (v *DeploymentCustomValidator) ValidateUpdate(ctx context.Context, oldObj,
 	deploymentlog.Info("Validation for Deployment upon update", "name", deployment.GetName())
 
 	// TODO(user): fill in your validation logic upon object update.
+	// Warnings are shown to the client without rejecting the request, e.g.:
+	// return admission.Warnings{"spec.foo is deprecated and will be removed in a future version"}, nil
 
 	return nil, nil
 }